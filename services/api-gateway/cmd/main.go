@@ -1,9 +1,18 @@
 package main
 
 import (
+	"context"
+	"crypto/rsa"
+	"strings"
+	"time"
+
 	apigateway "github.com/tamirat-dejene/ha-soranu/services/api-gateway"
 	"github.com/tamirat-dejene/ha-soranu/services/api-gateway/internal/client"
+	"github.com/tamirat-dejene/ha-soranu/services/api-gateway/internal/ratelimit"
 	"github.com/tamirat-dejene/ha-soranu/services/api-gateway/internal/server"
+	jwtvalidator "github.com/tamirat-dejene/ha-soranu/shared/pkg/auth/jwtvalidator"
+	"github.com/tamirat-dejene/ha-soranu/shared/pkg/auth/svcauth"
+	"github.com/tamirat-dejene/ha-soranu/shared/pkg/caching/valkey"
 	"github.com/tamirat-dejene/ha-soranu/shared/pkg/logger"
 	"go.uber.org/zap"
 )
@@ -21,8 +30,14 @@ func main() {
 	defer logger.Log.Sync()
 	logger.Info("Starting API Gateway...", zap.String("env", cfg.SRV_ENV))
 
-	// 3. Initialize Auth Service Client
-	uaServiceClient, err := client.NewUAServiceClient(cfg.AUTH_SRV_NAME + ":" + cfg.AUTH_SRV_PORT)
+	// 3. Initialize the Signer of the calls to the services
+	callSigner, err := svcauth.NewSigner(svcauth.GatewayService, cfg.ServiceAuthPrivateKey)
+	if err != nil {
+		logger.Fatal("Invalid service auth private key", zap.Error(err))
+	}
+
+	// Initialize Auth Service Client
+	uaServiceClient, err := client.NewUAServiceClient(cfg.AUTH_SRV_NAME+":"+cfg.AUTH_SRV_PORT, callSigner)
 	if err != nil {
 		logger.Fatal("Failed to connect to Auth Service", zap.Error(err))
 	}
@@ -30,7 +45,7 @@ func main() {
 	defer uaServiceClient.Close()
 
 	// 4. Initialize Restaurant Service Client
-	restaurantServiceClient, err := client.NewRestaurantServiceClient(cfg.RESTAURANT_SRV_NAME + ":" + cfg.RESTAURANT_SRV_PORT)
+	restaurantServiceClient, err := client.NewRestaurantServiceClient(cfg.RESTAURANT_SRV_NAME+":"+cfg.RESTAURANT_SRV_PORT, callSigner)
 	if err != nil {
 		logger.Fatal("Failed to connect to Restaurant Service", zap.Error(err))
 	}
@@ -38,7 +53,7 @@ func main() {
 	defer restaurantServiceClient.Close()
 
 	// 5. Initialize Notification Service Client
-	notificationServiceClient, err := client.NewNotificationServiceClient(cfg.NOTIFICATION_SRV_NAME + ":" + cfg.NOTIFICATION_SRV_PORT)
+	notificationServiceClient, err := client.NewNotificationServiceClient(cfg.NOTIFICATION_SRV_NAME+":"+cfg.NOTIFICATION_SRV_PORT, callSigner)
 	if err != nil {
 		logger.Fatal("Failed to connect to Notification Service", zap.Error(err))
 	}
@@ -48,8 +63,50 @@ func main() {
 	// 6. Initialize Payment HTTP Client
 	paymentClient := client.NewPaymentClient(cfg.PAYMENT_SRV_NAME, cfg.PAYMENT_HTTP_PORT)
 
-	// 7. Initialize and Run Server
-	srv := server.NewServer(cfg, uaServiceClient, restaurantServiceClient, notificationServiceClient, paymentClient)
+	// 7. Initialize Access Token Verifier
+	var pinnedKeys []*rsa.PublicKey
+	if cfg.ACCESS_TOKEN_PUBLIC_KEY != "" {
+		publicKey, err := jwtvalidator.ParseRSAPublicKeyFromString(cfg.ACCESS_TOKEN_PUBLIC_KEY)
+		if err != nil {
+			logger.Fatal("Invalid access token public key", zap.Error(err))
+		}
+		pinnedKeys = append(pinnedKeys, publicKey)
+	}
+	verifier := jwtvalidator.NewVerifier(cfg.AUTH_JWKS_URL, time.Duration(cfg.JWKS_REFRESH_SECONDS)*time.Second, pinnedKeys...)
+	{
+		// Keys are fetched on demand as well, so the gateway still starts when the auth service is not up yet.
+		ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+		defer cancel()
+		if err := verifier.Refresh(ctx); err != nil {
+			logger.Warn("Failed to fetch JWKS", zap.String("url", cfg.AUTH_JWKS_URL), zap.Error(err))
+		}
+	}
+
+	// 8. Initialize Rate Limiter
+	rateLimits, err := ratelimit.ParseRules(cfg.RATE_LIMITS)
+	if err != nil {
+		logger.Fatal("Invalid rate limits", zap.Error(err))
+	}
+	// Requests are let through while Valkey is unavailable, including at
+	// startup; the client reconnects and limiting resumes once it is back.
+	valkeyClient, err := valkey.NewReconnectingValkeyClient(cfg.VALKEY_HOST, cfg.VALKEY_PORT, cfg.VALKEY_USER, cfg.VALKEY_PASSWORD, cfg.VALKEY_DB)
+	if err != nil {
+		logger.Fatal("Failed to create Valkey Redis client", zap.Error(err))
+	}
+	defer valkeyClient.Close()
+	{
+		ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+		defer cancel()
+		if err := valkeyClient.Ping(ctx); err != nil {
+			logger.Warn("Failed to ping Valkey Redis, requests are let through until it is back", zap.Error(err))
+		} else {
+			logger.Info("Successfully connected to Valkey Redis")
+		}
+	}
+	limiter := ratelimit.NewLimiter(valkeyClient, rateLimits, strings.Split(cfg.RATE_LIMIT_API_KEYS, ","))
+
+	// 9. Initialize and Run Server
+	srv := server.NewServer(cfg, uaServiceClient, restaurantServiceClient, notificationServiceClient, paymentClient, verifier, limiter)
 	srv.SetupRoutes()
 
 	logger.Info("API Gateway listening", zap.String("port", cfg.API_GATEWAY_PORT))
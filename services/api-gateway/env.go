@@ -14,6 +14,10 @@ type Env struct {
 	// Auth Service settings
 	AUTH_SRV_NAME string `mapstructure:"AUTH_SRV_NAME"`
 	AUTH_SRV_PORT string `mapstructure:"AUTH_SRV_PORT"`
+	// AUTH_JWKS_URL is where access token verification keys are fetched from.
+	AUTH_JWKS_URL string `mapstructure:"AUTH_JWKS_URL"`
+	// JWKS_REFRESH_SECONDS is how long fetched keys are used before fetching again.
+	JWKS_REFRESH_SECONDS int `mapstructure:"JWKS_REFRESH_SECONDS"`
 
 	// Restaurant Service settings
 	RESTAURANT_SRV_NAME string `mapstructure:"RESTAURANT_SRV_NAME"`
@@ -27,9 +31,33 @@ type Env struct {
 	PAYMENT_SRV_NAME  string `mapstructure:"PAYMENT_SRV_NAME"`
 	PAYMENT_HTTP_PORT string `mapstructure:"PAYMENT_HTTP_PORT"`
 
-	// JWT Public Keys
+	// JWT Public Keys. ACCESS_TOKEN_PUBLIC_KEY is optional and trusted in
+	// addition to the keys of the JWKS.
 	ACCESS_TOKEN_PUBLIC_KEY  string `mapstructure:"ACCESS_TOKEN_PUBLIC_KEY"`
 	REFRESH_TOKEN_PUBLIC_KEY string `mapstructure:"REFRESH_TOKEN_PUBLIC_KEY"`
+
+	// ServiceAuthPrivateKey is the Ed25519 private key (PEM or base64-encoded
+	// PEM) the service signs its gRPC calls to the other services with.
+	ServiceAuthPrivateKey string `mapstructure:"SERVICE_AUTH_PRIVATE_KEY"`
+
+	// Valkey settings. The rate limiter counts requests in Valkey so that
+	// every gateway instance enforces the same limits.
+	VALKEY_HOST     string `mapstructure:"VALKEY_HOST"`
+	VALKEY_PORT     int    `mapstructure:"VALKEY_PORT"`
+	VALKEY_USER     string `mapstructure:"VALKEY_USER"`
+	VALKEY_PASSWORD string `mapstructure:"VALKEY_PASSWORD"`
+	VALKEY_DB       int    `mapstructure:"VALKEY_DB"`
+
+	// RATE_LIMITS are the request limits per route group, written as
+	// "group=limit/window" separated by commas. Groups without a limit of
+	// their own use the "default" one; "off" turns limiting off.
+	RATE_LIMITS string `mapstructure:"RATE_LIMITS"`
+	// RATE_LIMIT_API_KEYS are the comma separated API keys whose requests are
+	// counted per key rather than per client IP.
+	RATE_LIMIT_API_KEYS string `mapstructure:"RATE_LIMIT_API_KEYS"`
+	// TRUSTED_PROXIES are the comma separated addresses or CIDRs of the
+	// proxies whose X-Forwarded-For header is trusted for the client IP.
+	TRUSTED_PROXIES string `mapstructure:"TRUSTED_PROXIES"`
 }
 
 func getString(key string, defaultValue string) string {
@@ -40,7 +68,7 @@ func getString(key string, defaultValue string) string {
 	return value
 }
 
-func _(key string, defaultValue int) int {
+func getInt(key string, defaultValue int) int {
 	valueStr := os.Getenv(key)
 	if valueStr == "" {
 		return defaultValue
@@ -55,10 +83,13 @@ func _(key string, defaultValue int) int {
 
 func GetEnv() (*Env, error) {
 	env := Env{
-		SRV_ENV:          getString("SRV_ENV", "development"),
-		AUTH_SRV_NAME:    getString("AUTH_SRV_NAME", "auth-service"),
-		AUTH_SRV_PORT:    getString("AUTH_SRV_PORT", "9090"),
-		API_GATEWAY_PORT: getString("API_GATEWAY_PORT", "8080"),
+		SRV_ENV:       getString("SRV_ENV", "development"),
+		AUTH_SRV_NAME: getString("AUTH_SRV_NAME", "auth-service"),
+		AUTH_SRV_PORT: getString("AUTH_SRV_PORT", "9090"),
+		AUTH_JWKS_URL: getString("AUTH_JWKS_URL", "http://auth-service:8090/.well-known/jwks.json"),
+
+		JWKS_REFRESH_SECONDS: getInt("JWKS_REFRESH_SECONDS", 300),
+		API_GATEWAY_PORT:     getString("API_GATEWAY_PORT", "8080"),
 
 		RESTAURANT_SRV_NAME: getString("RESTAURANT_SRV_NAME", "restaurant-service"),
 		RESTAURANT_SRV_PORT: getString("RESTAURANT_SRV_PORT", "9091"),
@@ -66,11 +97,22 @@ func GetEnv() (*Env, error) {
 		NOTIFICATION_SRV_NAME: getString("NOTIFICATION_SRV_NAME", "notification-service"),
 		NOTIFICATION_SRV_PORT: getString("NOTIFICATION_SRV_PORT", "50053"),
 
-		PAYMENT_SRV_NAME:  getString("PAYMENT_SRV_NAME", "payment-service"),
-		PAYMENT_HTTP_PORT: getString("PAYMENT_HTTP_PORT", "8081"),
+		PAYMENT_SRV_NAME:      getString("PAYMENT_SRV_NAME", "payment-service"),
+		ServiceAuthPrivateKey: getString("SERVICE_AUTH_PRIVATE_KEY", ""),
+		PAYMENT_HTTP_PORT:     getString("PAYMENT_HTTP_PORT", "8081"),
 
 		ACCESS_TOKEN_PUBLIC_KEY:  getString("ACCESS_TOKEN_PUBLIC_KEY", ""),
 		REFRESH_TOKEN_PUBLIC_KEY: getString("REFRESH_TOKEN_PUBLIC_KEY", ""),
+
+		VALKEY_HOST:     getString("VALKEY_HOST", "localhost"),
+		VALKEY_PORT:     getInt("VALKEY_PORT", 6379),
+		VALKEY_USER:     getString("VALKEY_USER", "default"),
+		VALKEY_PASSWORD: getString("VALKEY_PASSWORD", "default-password"),
+		VALKEY_DB:       getInt("VALKEY_DB", 0),
+
+		RATE_LIMITS:         getString("RATE_LIMITS", "default=300/1m,auth=30/1m,orders=20/1m,payments=20/1m"),
+		RATE_LIMIT_API_KEYS: getString("RATE_LIMIT_API_KEYS", ""),
+		TRUSTED_PROXIES:     getString("TRUSTED_PROXIES", ""),
 	}
 
 	return &env, nil
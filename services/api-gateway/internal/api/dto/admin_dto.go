@@ -0,0 +1,123 @@
+package dto
+
+import (
+	"time"
+
+	"github.com/tamirat-dejene/ha-soranu/services/api-gateway/internal/domain"
+	"github.com/tamirat-dejene/ha-soranu/shared/protos/adminpb"
+)
+
+// SearchUsersQueryDTO selects the users an admin looks for. Q matches emails
+// and usernames.
+type SearchUsersQueryDTO struct {
+	Q         string `form:"q"`
+	Suspended bool   `form:"suspended"`
+	Limit     int32  `form:"limit" binding:"min=0,max=100"`
+	Offset    int32  `form:"offset" binding:"min=0"`
+}
+
+func (d *SearchUsersQueryDTO) ToProto() *adminpb.SearchUsersRequest {
+	return &adminpb.SearchUsersRequest{
+		Query:         d.Q,
+		SuspendedOnly: d.Suspended,
+		Limit:         d.Limit,
+		Offset:        d.Offset,
+	}
+}
+
+// SearchRestaurantsQueryDTO selects the restaurants an admin looks for. Q
+// matches names and emails.
+type SearchRestaurantsQueryDTO struct {
+	Q      string `form:"q"`
+	Status string `form:"status" binding:"omitempty,oneof=ACTIVE INACTIVE SUSPENDED"`
+	Limit  int32  `form:"limit" binding:"min=0,max=100"`
+	Offset int32  `form:"offset" binding:"min=0"`
+}
+
+func (d *SearchRestaurantsQueryDTO) ToProto() *adminpb.SearchRestaurantsRequest {
+	return &adminpb.SearchRestaurantsRequest{
+		Query:  d.Q,
+		Status: d.Status,
+		Limit:  d.Limit,
+		Offset: d.Offset,
+	}
+}
+
+type SuspendRequestDTO struct {
+	Reason string `json:"reason" binding:"required,max=500"`
+}
+
+type GrantRoleRequestDTO struct {
+	Role string `json:"role" binding:"required"`
+}
+
+type SuspensionDTO struct {
+	Reason      string    `json:"reason"`
+	SuspendedBy string    `json:"suspended_by"`
+	SuspendedAt time.Time `json:"suspended_at"`
+}
+
+type UserAccountDTO struct {
+	User       domain.User    `json:"user"`
+	Roles      []string       `json:"roles"`
+	Suspension *SuspensionDTO `json:"suspension,omitempty"`
+}
+
+type RestaurantAccountDTO struct {
+	Restaurant *domain.Restaurant `json:"restaurant"`
+	OwnerID    string             `json:"owner_id,omitempty"`
+	Suspension *SuspensionDTO     `json:"suspension,omitempty"`
+}
+
+func suspensionFromProto(s *adminpb.Suspension) *SuspensionDTO {
+	if s == nil {
+		return nil
+	}
+	return &SuspensionDTO{
+		Reason:      s.Reason,
+		SuspendedBy: s.SuspendedBy,
+		SuspendedAt: s.SuspendedAt.AsTime(),
+	}
+}
+
+func UserAccountFromProto(account *adminpb.UserAccount) *UserAccountDTO {
+	user := account.GetUser()
+	return &UserAccountDTO{
+		User: domain.User{
+			ID:            user.GetUserId(),
+			Email:         user.GetEmail(),
+			Username:      user.GetUsername(),
+			PhoneNumber:   user.GetPhoneNumber(),
+			Password:      "********",
+			Addresses:     toDomainAddresses(user.GetAddresses()),
+			CreatedAt:     user.GetCreatedAt().AsTime(),
+			EmailVerified: user.GetEmailVerified(),
+		},
+		Roles:      account.Roles,
+		Suspension: suspensionFromProto(account.Suspension),
+	}
+}
+
+func UserAccountsFromProto(resp *adminpb.SearchUsersResponse) []*UserAccountDTO {
+	users := make([]*UserAccountDTO, len(resp.Users))
+	for i, account := range resp.Users {
+		users[i] = UserAccountFromProto(account)
+	}
+	return users
+}
+
+func RestaurantAccountFromProto(account *adminpb.RestaurantAccount) *RestaurantAccountDTO {
+	return &RestaurantAccountDTO{
+		Restaurant: RestaurantResponseFromProto(account.GetRestaurant()),
+		OwnerID:    account.GetRestaurant().GetOwnerId(),
+		Suspension: suspensionFromProto(account.Suspension),
+	}
+}
+
+func RestaurantAccountsFromProto(resp *adminpb.SearchRestaurantsResponse) []*RestaurantAccountDTO {
+	restaurants := make([]*RestaurantAccountDTO, len(resp.Restaurants))
+	for i, account := range resp.Restaurants {
+		restaurants[i] = RestaurantAccountFromProto(account)
+	}
+	return restaurants
+}
@@ -1,6 +1,9 @@
 package dto
 
 import (
+	"encoding/json"
+	"time"
+
 	"github.com/tamirat-dejene/ha-soranu/shared/protos/authpb"
 	"github.com/tamirat-dejene/ha-soranu/shared/protos/userpb"
 )
@@ -10,7 +13,7 @@ type UserRegisterRequestDTO struct {
 	Email       string  `json:"email" binding:"required,email"`
 	Username    string  `json:"username" binding:"required"`
 	PhoneNumber string  `json:"phone_number" binding:"required"`
-	Password    string  `json:"password" binding:"required,min=4"`
+	Password    string  `json:"password" binding:"required"`
 }
 
 // UserRegisterResponseDTO represents the response after registration.
@@ -34,10 +37,24 @@ type GoogleLoginRequestDTO struct {
 	IdToken string `json:"id_token" binding:"required"`
 }
 
-// LoginResponseDTO
+// IdentityProviderLoginRequestDTO for login with an OpenID Connect provider,
+// named in the path.
+type IdentityProviderLoginRequestDTO struct {
+	IdToken string `json:"id_token" binding:"required"`
+}
+
+// IdentityProvidersResponseDTO names the identity providers users can log in with.
+type IdentityProvidersResponseDTO struct {
+	Providers []string `json:"providers"`
+}
+
+// LoginResponseDTO. When MFARequired is set, User and Tokens are empty and
+// the login is completed with MFAToken and a code.
 type LoginResponseDTO struct {
-	User   *userpb.User
-	Tokens *authpb.AuthTokens
+	User        *userpb.User
+	Tokens      *authpb.AuthTokens
+	MFARequired bool   `json:"mfa_required,omitempty"`
+	MFAToken    string `json:"mfa_token,omitempty"`
 }
 
 type RefreshRequestDTO struct {
@@ -112,8 +129,10 @@ func EPLoginRequestFromProto(protoReq *authpb.EPLoginRequest) *EPLoginRequestDTO
 
 func LoginResponseFromProto(protoResp *authpb.LoginResponse) *LoginResponseDTO {
 	return &LoginResponseDTO{
-		User:   protoResp.GetUser(),
-		Tokens: protoResp.GetTokens(),
+		User:        protoResp.GetUser(),
+		Tokens:      protoResp.GetTokens(),
+		MFARequired: protoResp.GetMfaRequired(),
+		MFAToken:    protoResp.GetMfaToken(),
 	}
 }
 
@@ -123,8 +142,267 @@ func (gr *GoogleLoginRequestDTO) ToProto() *authpb.GLoginRequest {
 	}
 }
 
+func (ir *IdentityProviderLoginRequestDTO) ToProto(provider string) *authpb.IdentityProviderLoginRequest {
+	return &authpb.IdentityProviderLoginRequest{
+		Provider: provider,
+		IdToken:  ir.IdToken,
+	}
+}
+
+func IdentityProvidersResponseFromProto(protoResp *authpb.ListIdentityProvidersResponse) *IdentityProvidersResponseDTO {
+	providers := protoResp.GetProviders()
+	if providers == nil {
+		providers = []string{}
+	}
+	return &IdentityProvidersResponseDTO{Providers: providers}
+}
+
 func (lr *LogoutRequestDTO) ToProto() *authpb.LogoutRequest {
 	return &authpb.LogoutRequest{
 		RefreshToken: lr.RefreshToken,
 	}
-}
\ No newline at end of file
+}
+// VerifyEmailRequestDTO carries the token of an email verification link.
+type VerifyEmailRequestDTO struct {
+	Token string `json:"token" binding:"required"`
+}
+
+func (vr *VerifyEmailRequestDTO) ToProto() *authpb.VerifyEmailRequest {
+	return &authpb.VerifyEmailRequest{
+		Token: vr.Token,
+	}
+}
+
+// ForgotPasswordRequestDTO carries the email to send a password reset link to.
+type ForgotPasswordRequestDTO struct {
+	Email string `json:"email" binding:"required,email"`
+}
+
+func (fr *ForgotPasswordRequestDTO) ToProto() *authpb.RequestPasswordResetRequest {
+	return &authpb.RequestPasswordResetRequest{
+		Email: fr.Email,
+	}
+}
+
+// ResetPasswordRequestDTO carries the token of a reset link and the new password.
+type ResetPasswordRequestDTO struct {
+	Token       string `json:"token" binding:"required"`
+	NewPassword string `json:"new_password" binding:"required"`
+}
+
+func (rr *ResetPasswordRequestDTO) ToProto() *authpb.ResetPasswordRequest {
+	return &authpb.ResetPasswordRequest{
+		Token:       rr.Token,
+		NewPassword: rr.NewPassword,
+	}
+}
+
+// ChangePasswordRequestDTO carries the current and the new password of the
+// signed-in user.
+type ChangePasswordRequestDTO struct {
+	CurrentPassword string `json:"current_password" binding:"required"`
+	NewPassword     string `json:"new_password" binding:"required"`
+}
+
+func (cr *ChangePasswordRequestDTO) ToProto(userID, sessionID string) *authpb.ChangePasswordRequest {
+	return &authpb.ChangePasswordRequest{
+		UserId:           userID,
+		CurrentPassword:  cr.CurrentPassword,
+		NewPassword:      cr.NewPassword,
+		CurrentSessionId: sessionID,
+	}
+}
+
+// ReauthenticationDTO is how a signed-in user confirms a sensitive change:
+// their password or, for accounts without one, an ID token the identity
+// provider issued within the last few minutes.
+type ReauthenticationDTO struct {
+	Password string `json:"password" binding:"required_without=IDToken"`
+	Provider string `json:"provider" binding:"required_with=IDToken"`
+	IDToken  string `json:"id_token"`
+}
+
+// ChangeEmailRequestDTO carries the signed-in user's password and the email
+// to change to.
+type ChangeEmailRequestDTO struct {
+	ReauthenticationDTO
+	NewEmail string `json:"new_email" binding:"required,email"`
+}
+
+func (cr *ChangeEmailRequestDTO) ToProto(userID, sessionID string) *authpb.ChangeEmailRequest {
+	return &authpb.ChangeEmailRequest{
+		UserId:           userID,
+		Password:         cr.Password,
+		NewEmail:         cr.NewEmail,
+		CurrentSessionId: sessionID,
+		Provider:         cr.Provider,
+		IdToken:          cr.IDToken,
+	}
+}
+
+// ConfirmEmailChangeRequestDTO carries the token of an email change link.
+type ConfirmEmailChangeRequestDTO struct {
+	Token string `json:"token" binding:"required"`
+}
+
+func (cr *ConfirmEmailChangeRequestDTO) ToProto() *authpb.ConfirmEmailChangeRequest {
+	return &authpb.ConfirmEmailChangeRequest{
+		Token: cr.Token,
+	}
+}
+
+// SessionDTO is a device the user is signed in on.
+type SessionDTO struct {
+	SessionID  string    `json:"session_id"`
+	DeviceID   string    `json:"device_id,omitempty"`
+	UserAgent  string    `json:"user_agent,omitempty"`
+	IPAddress  string    `json:"ip_address,omitempty"`
+	CreatedAt  time.Time `json:"created_at"`
+	LastUsedAt time.Time `json:"last_used_at"`
+	ExpiresAt  time.Time `json:"expires_at"`
+	Current    bool      `json:"current"`
+}
+
+type ListSessionsResponseDTO struct {
+	Sessions []SessionDTO `json:"sessions"`
+}
+
+func ListSessionsResponseFromProto(protoResp *authpb.ListSessionsResponse) *ListSessionsResponseDTO {
+	sessions := make([]SessionDTO, len(protoResp.GetSessions()))
+	for i, s := range protoResp.GetSessions() {
+		sessions[i] = SessionDTO{
+			SessionID:  s.GetSessionId(),
+			DeviceID:   s.GetDeviceId(),
+			UserAgent:  s.GetUserAgent(),
+			IPAddress:  s.GetIpAddress(),
+			CreatedAt:  s.GetCreatedAt().AsTime(),
+			LastUsedAt: s.GetLastUsedAt().AsTime(),
+			ExpiresAt:  s.GetExpiresAt().AsTime(),
+			Current:    s.GetCurrent(),
+		}
+	}
+
+	return &ListSessionsResponseDTO{Sessions: sessions}
+}
+
+// MFALoginRequestDTO carries the challenge token of a login and a TOTP or
+// recovery code.
+type MFALoginRequestDTO struct {
+	MFAToken string `json:"mfa_token" binding:"required"`
+	Code     string `json:"code" binding:"required"`
+}
+
+func (mr *MFALoginRequestDTO) ToProto() *authpb.CompleteMFALoginRequest {
+	return &authpb.CompleteMFALoginRequest{
+		MfaToken: mr.MFAToken,
+		Code:     mr.Code,
+	}
+}
+
+// MFAEnrollmentDTO is the TOTP secret to add to an authenticator app, also as
+// an otpauth:// URI to show as a QR code.
+type MFAEnrollmentDTO struct {
+	Secret          string `json:"secret"`
+	ProvisioningURI string `json:"provisioning_uri"`
+}
+
+func MFAEnrollmentFromProto(protoResp *authpb.EnrollMFAResponse) *MFAEnrollmentDTO {
+	return &MFAEnrollmentDTO{
+		Secret:          protoResp.GetSecret(),
+		ProvisioningURI: protoResp.GetProvisioningUri(),
+	}
+}
+
+// ConfirmMFARequestDTO carries a code generated from the enrolled secret.
+type ConfirmMFARequestDTO struct {
+	Code string `json:"code" binding:"required"`
+}
+
+func (cr *ConfirmMFARequestDTO) ToProto(userID string) *authpb.ConfirmMFARequest {
+	return &authpb.ConfirmMFARequest{
+		UserId: userID,
+		Code:   cr.Code,
+	}
+}
+
+// DisableMFARequestDTO carries the user's password and a TOTP or recovery code.
+type DisableMFARequestDTO struct {
+	ReauthenticationDTO
+	Code string `json:"code" binding:"required"`
+}
+
+func (dr *DisableMFARequestDTO) ToProto(userID string) *authpb.DisableMFARequest {
+	return &authpb.DisableMFARequest{
+		UserId:   userID,
+		Password: dr.Password,
+		Code:     dr.Code,
+		Provider: dr.Provider,
+		IdToken:  dr.IDToken,
+	}
+}
+
+// DeleteAccountRequestDTO carries the password of the user deleting their account.
+type DeleteAccountRequestDTO struct {
+	ReauthenticationDTO
+}
+
+func (dr *DeleteAccountRequestDTO) ToProto(userID string) *authpb.DeleteAccountRequest {
+	return &authpb.DeleteAccountRequest{
+		UserId:   userID,
+		Password: dr.Password,
+		Provider: dr.Provider,
+		IdToken:  dr.IDToken,
+	}
+}
+
+// AccountRequestServiceDTO is the part of a service in an account request.
+type AccountRequestServiceDTO struct {
+	Service     string     `json:"service"`
+	Status      string     `json:"status"`
+	Error       string     `json:"error,omitempty"`
+	CompletedAt *time.Time `json:"completed_at,omitempty"`
+}
+
+// AccountRequestDTO is an account deletion or data export. Data holds the
+// exported data once an export is completed.
+type AccountRequestDTO struct {
+	RequestID   string                     `json:"request_id"`
+	Kind        string                     `json:"kind"`
+	Status      string                     `json:"status"`
+	CreatedAt   time.Time                  `json:"created_at"`
+	CompletedAt *time.Time                 `json:"completed_at,omitempty"`
+	Services    []AccountRequestServiceDTO `json:"services"`
+	Data        json.RawMessage            `json:"data,omitempty"`
+}
+
+func AccountRequestFromProto(protoResp *authpb.AccountRequestResponse) *AccountRequestDTO {
+	request := protoResp.GetRequest()
+	result := &AccountRequestDTO{
+		RequestID: request.GetRequestId(),
+		Kind:      request.GetKind(),
+		Status:    request.GetStatus(),
+		CreatedAt: request.GetCreatedAt().AsTime(),
+		Services:  make([]AccountRequestServiceDTO, len(request.GetServices())),
+	}
+	if request.GetCompletedAt() != nil {
+		completedAt := request.GetCompletedAt().AsTime()
+		result.CompletedAt = &completedAt
+	}
+	if request.GetData() != "" {
+		result.Data = json.RawMessage(request.GetData())
+	}
+
+	for i, s := range request.GetServices() {
+		result.Services[i] = AccountRequestServiceDTO{
+			Service: s.GetService(),
+			Status:  s.GetStatus(),
+			Error:   s.GetError(),
+		}
+		if s.GetCompletedAt() != nil {
+			completedAt := s.GetCompletedAt().AsTime()
+			result.Services[i].CompletedAt = &completedAt
+		}
+	}
+
+	return result
+}
@@ -1,18 +1,160 @@
 package dto
 
-import "google.golang.org/grpc/status"
+import (
+	"math"
+	"net/http"
+	"time"
+
+	"github.com/tamirat-dejene/ha-soranu/services/api-gateway/internal/errs"
+	"google.golang.org/genproto/googleapis/rpc/errdetails"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// StatusClientClosedRequest is the non-standard status of a request the
+// client gave up on before it was answered.
+const StatusClientClosedRequest = 499
 
 type ErrorResponse struct {
 	Error string `json:"error"`
+	// Code is the name of the gRPC code of the error, such as NOT_FOUND.
+	Code string `json:"code,omitempty"`
+	// RetryAfterSeconds is set when the service said when to try again.
+	RetryAfterSeconds int64 `json:"retry_after_seconds,omitempty"`
+	// Violations lists what is wrong with the request fields, such as the
+	// rules a password breaks.
+	Violations []FieldViolation `json:"violations,omitempty"`
+	// Preconditions lists the state of the resources that does not allow
+	// the request, such as an inactive restaurant.
+	Preconditions []PreconditionViolation `json:"preconditions,omitempty"`
+}
+
+type FieldViolation struct {
+	Field       string `json:"field"`
+	Reason      string `json:"reason,omitempty"`
+	Description string `json:"description"`
 }
 
+type PreconditionViolation struct {
+	Type        string `json:"type"`
+	Subject     string `json:"subject,omitempty"`
+	Description string `json:"description"`
+}
+
+// ErrorResponseFromGRPCError builds the body of an error of a service from
+// its status and details. The message of errors the client cannot act on is
+// not passed on.
 func ErrorResponseFromGRPCError(err error) *ErrorResponse {
 	if err == nil {
 		return nil
 	}
 	st, ok := status.FromError(err)
 	if !ok {
-		return &ErrorResponse{Error: "unknown error occurred"}
+		return &ErrorResponse{Error: errs.MsgInternalError, Code: grpcCodeName(codes.Unknown)}
+	}
+
+	resp := &ErrorResponse{Error: st.Message(), Code: grpcCodeName(st.Code())}
+	switch st.Code() {
+	case codes.Unknown, codes.Internal, codes.DataLoss:
+		resp.Error = errs.MsgInternalError
+	}
+	if retryAfter, ok := RetryAfterFromGRPCError(err); ok {
+		resp.RetryAfterSeconds = int64(math.Ceil(retryAfter.Seconds()))
+	}
+	for _, detail := range st.Details() {
+		switch d := detail.(type) {
+		case *errdetails.BadRequest:
+			for _, v := range d.GetFieldViolations() {
+				resp.Violations = append(resp.Violations, FieldViolation{
+					Field:       v.GetField(),
+					Reason:      v.GetReason(),
+					Description: v.GetDescription(),
+				})
+			}
+		case *errdetails.PreconditionFailure:
+			for _, v := range d.GetViolations() {
+				resp.Preconditions = append(resp.Preconditions, PreconditionViolation{
+					Type:        v.GetType(),
+					Subject:     v.GetSubject(),
+					Description: v.GetDescription(),
+				})
+			}
+		}
+	}
+	return resp
+}
+
+// HTTPStatusFromGRPCError returns the HTTP status of an error of a service.
+// Errors that carry no gRPC status are reported as internal.
+func HTTPStatusFromGRPCError(err error) int {
+	switch status.Code(err) {
+	case codes.OK:
+		return http.StatusOK
+	case codes.InvalidArgument, codes.OutOfRange:
+		return http.StatusBadRequest
+	case codes.Unauthenticated:
+		return http.StatusUnauthorized
+	case codes.PermissionDenied:
+		return http.StatusForbidden
+	case codes.NotFound:
+		return http.StatusNotFound
+	case codes.AlreadyExists, codes.Aborted, codes.FailedPrecondition:
+		return http.StatusConflict
+	case codes.ResourceExhausted:
+		return http.StatusTooManyRequests
+	case codes.Canceled:
+		return StatusClientClosedRequest
+	case codes.DeadlineExceeded:
+		return http.StatusGatewayTimeout
+	case codes.Unimplemented:
+		return http.StatusNotImplemented
+	case codes.Unavailable:
+		return http.StatusServiceUnavailable
+	default:
+		return http.StatusInternalServerError
+	}
+}
+
+// grpcCodeName returns the canonical name of code, such as NOT_FOUND.
+func grpcCodeName(code codes.Code) string {
+	name, ok := grpcCodeNames[code]
+	if !ok {
+		return grpcCodeNames[codes.Unknown]
+	}
+	return name
+}
+
+var grpcCodeNames = map[codes.Code]string{
+	codes.OK:                 "OK",
+	codes.Canceled:           "CANCELLED",
+	codes.Unknown:            "UNKNOWN",
+	codes.InvalidArgument:    "INVALID_ARGUMENT",
+	codes.DeadlineExceeded:   "DEADLINE_EXCEEDED",
+	codes.NotFound:           "NOT_FOUND",
+	codes.AlreadyExists:      "ALREADY_EXISTS",
+	codes.PermissionDenied:   "PERMISSION_DENIED",
+	codes.ResourceExhausted:  "RESOURCE_EXHAUSTED",
+	codes.FailedPrecondition: "FAILED_PRECONDITION",
+	codes.Aborted:            "ABORTED",
+	codes.OutOfRange:         "OUT_OF_RANGE",
+	codes.Unimplemented:      "UNIMPLEMENTED",
+	codes.Internal:           "INTERNAL",
+	codes.Unavailable:        "UNAVAILABLE",
+	codes.DataLoss:           "DATA_LOSS",
+	codes.Unauthenticated:    "UNAUTHENTICATED",
+}
+
+// RetryAfterFromGRPCError returns the delay of the RetryInfo detail of err.
+func RetryAfterFromGRPCError(err error) (time.Duration, bool) {
+	st, ok := status.FromError(err)
+	if !ok {
+		return 0, false
+	}
+
+	for _, detail := range st.Details() {
+		if info, ok := detail.(*errdetails.RetryInfo); ok && info.GetRetryDelay() != nil {
+			return info.GetRetryDelay().AsDuration(), true
+		}
 	}
-	return &ErrorResponse{Error: st.Message()}
+	return 0, false
 }
@@ -1,6 +1,8 @@
 package dto
 
 import (
+	"strings"
+
 	"github.com/tamirat-dejene/ha-soranu/services/api-gateway/internal/domain"
 	"github.com/tamirat-dejene/ha-soranu/shared/protos/orderpb"
 	"github.com/tamirat-dejene/ha-soranu/shared/protos/restaurantpb"
@@ -36,6 +38,24 @@ func RestaurantResponseFromProto(restaurant *restaurantpb.Restaurant) *domain.Re
 		Latitude:     restaurant.Latitude,
 		Longitude:    restaurant.Longitude,
 		Menus:        menuItms,
+		Status:       restaurant.Status,
+	}
+}
+
+type UpdateRestaurantDTO struct {
+	Email     string   `json:"email" binding:"required,email"`
+	Name      string   `json:"name" binding:"required"`
+	Latitude  *float32 `json:"latitude" binding:"required,min=-90,max=90"`
+	Longitude *float32 `json:"longitude" binding:"required,min=-180,max=180"`
+}
+
+func (dto *UpdateRestaurantDTO) ToProto(restaurantID string) *restaurantpb.UpdateRestaurantRequest {
+	return &restaurantpb.UpdateRestaurantRequest{
+		RestaurantId: restaurantID,
+		Email:        dto.Email,
+		Name:         dto.Name,
+		Latitude:     *dto.Latitude,
+		Longitude:    *dto.Longitude,
 	}
 }
 
@@ -112,13 +132,63 @@ type UpdateMenuItemDTO struct {
 	Price       float32 `json:"price" binding:"required"`
 }
 
+type MenuImportErrorDTO struct {
+	Line    int32  `json:"line"`
+	Field   string `json:"field,omitempty"`
+	Message string `json:"message"`
+}
+
+type MenuImportResponseDTO struct {
+	Created int32                `json:"created"`
+	Updated int32                `json:"updated"`
+	DryRun  bool                 `json:"dry_run"`
+	Errors  []MenuImportErrorDTO `json:"errors,omitempty"`
+}
+
+func MenuImportResponseFromProto(resp *restaurantpb.ImportMenuResponse) *MenuImportResponseDTO {
+	importErrors := make([]MenuImportErrorDTO, 0, len(resp.Errors))
+	for _, e := range resp.Errors {
+		importErrors = append(importErrors, MenuImportErrorDTO{
+			Line:    e.Line,
+			Field:   e.Field,
+			Message: e.Message,
+		})
+	}
+
+	return &MenuImportResponseDTO{
+		Created: resp.Created,
+		Updated: resp.Updated,
+		DryRun:  resp.DryRun,
+		Errors:  importErrors,
+	}
+}
+
+// MenuFormatFromRequest picks the menu format from the format query value,
+// falling back to the request content type. CSV is the default.
+func MenuFormatFromRequest(format, contentType string) (restaurantpb.MenuFormat, bool) {
+	switch strings.ToLower(format) {
+	case "csv":
+		return restaurantpb.MenuFormat_CSV, true
+	case "json":
+		return restaurantpb.MenuFormat_JSON, true
+	case "":
+		if strings.HasPrefix(contentType, "application/json") {
+			return restaurantpb.MenuFormat_JSON, true
+		}
+		return restaurantpb.MenuFormat_CSV, true
+	default:
+		return restaurantpb.MenuFormat_CSV, false
+	}
+}
+
 type PlaceOrderDTO struct {
-	CustomerID   string             `json:"customer_id" binding:"required"`
 	RestaurantID string             `json:"restaurant_id" binding:"required"`
 	Items        []domain.OrderItem `json:"items" binding:"required,dive,required"`
+	// IdempotencyKey may also be sent in the Idempotency-Key header.
+	IdempotencyKey string `json:"idempotency_key"`
 }
 
-func (dto *PlaceOrderDTO) ToProto() *restaurantpb.PlaceOrderRequest {
+func (dto *PlaceOrderDTO) ToProto(customerID string) *restaurantpb.PlaceOrderRequest {
 	orderItems := make([]*restaurantpb.OrderItem, 0, len(dto.Items))
 	for _, item := range dto.Items {
 		orderItems = append(orderItems, &restaurantpb.OrderItem{
@@ -127,9 +197,10 @@ func (dto *PlaceOrderDTO) ToProto() *restaurantpb.PlaceOrderRequest {
 		})
 	}
 	return &restaurantpb.PlaceOrderRequest{
-		CustomerId:   dto.CustomerID,
-		RestaurantId: dto.RestaurantID,
-		Items:        orderItems,
+		CustomerId:     customerID,
+		RestaurantId:   dto.RestaurantID,
+		Items:          orderItems,
+		IdempotencyKey: dto.IdempotencyKey,
 	}
 }
 
@@ -165,6 +236,22 @@ func OrderResponseFromProto(order *restaurantpb.Order) *domain.Order {
 	}
 }
 
+type OrderFeedEventDTO struct {
+	Type       string        `json:"type"`
+	Cursor     int64         `json:"cursor"`
+	Order      *domain.Order `json:"order"`
+	OccurredAt int64         `json:"occurred_at"`
+}
+
+func OrderFeedEventFromProto(event *restaurantpb.OrderFeedEvent) *OrderFeedEventDTO {
+	return &OrderFeedEventDTO{
+		Type:       event.Type.String(),
+		Cursor:     event.Cursor,
+		Order:      OrderResponseFromProto(event.Order),
+		OccurredAt: event.OccurredAtUnix,
+	}
+}
+
 type UpdateOrderStatusDTO struct {
 	Status string `json:"status" binding:"required"`
 }
@@ -191,11 +278,101 @@ func StringStatusToProto(status string) orderpb.OrderStatus {
 type ShipOrderResponseDTO struct {
 	ConfirmationMessage string `json:"confirmation_message"`
 	DriverID            string `json:"driver_id"`
+	OfferID             string `json:"offer_id,omitempty"`
+	DispatchStatus      string `json:"dispatch_status"`
+	OfferExpiresAt      int64  `json:"offer_expires_at,omitempty"`
 }
 
 func ShipOrderResponseFromProto(resp *restaurantpb.ShipOrderResponse) *ShipOrderResponseDTO {
 	return &ShipOrderResponseDTO{
 		ConfirmationMessage: resp.ConfirmationMessage,
 		DriverID:            resp.DriverId,
+		OfferID:             resp.OfferId,
+		DispatchStatus:      resp.DispatchStatus,
+		OfferExpiresAt:      resp.OfferExpiresAtUnix,
+	}
+}
+
+type AddCartItemDTO struct {
+	RestaurantID string `json:"restaurant_id" binding:"required"`
+	ItemID       string `json:"item_id" binding:"required"`
+	Quantity     int32  `json:"quantity" binding:"required,min=1"`
+}
+
+type UpdateCartItemDTO struct {
+	// Quantity zero removes the item.
+	Quantity *int32 `json:"quantity" binding:"required,min=0"`
+}
+
+type CheckoutCartDTO struct {
+	// IdempotencyKey may also be sent in the Idempotency-Key header.
+	IdempotencyKey string `json:"idempotency_key"`
+}
+
+type CartItemDTO struct {
+	ItemID        string  `json:"item_id"`
+	Name          string  `json:"name"`
+	Quantity      int32   `json:"quantity"`
+	UnitPrice     float32 `json:"unit_price"`
+	Available     bool    `json:"available"`
+	PriceChanged  bool    `json:"price_changed"`
+	PreviousPrice float32 `json:"previous_price,omitempty"`
+}
+
+type CartDTO struct {
+	CustomerID   string        `json:"customer_id"`
+	RestaurantID string        `json:"restaurant_id,omitempty"`
+	Items        []CartItemDTO `json:"items"`
+	TotalAmount  float64       `json:"total_amount"`
+	Valid        bool          `json:"valid"`
+	ExpiresAt    int64         `json:"expires_at,omitempty"`
+}
+
+func CartFromProto(cart *restaurantpb.Cart) *CartDTO {
+	items := make([]CartItemDTO, 0, len(cart.Items))
+	for _, item := range cart.Items {
+		items = append(items, CartItemDTO{
+			ItemID:        item.ItemId,
+			Name:          item.Name,
+			Quantity:      item.Quantity,
+			UnitPrice:     item.UnitPrice,
+			Available:     item.Available,
+			PriceChanged:  item.PriceChanged,
+			PreviousPrice: item.PreviousPrice,
+		})
+	}
+	return &CartDTO{
+		CustomerID:   cart.CustomerId,
+		RestaurantID: cart.RestaurantId,
+		Items:        items,
+		TotalAmount:  cart.TotalAmount,
+		Valid:        cart.Valid,
+		ExpiresAt:    cart.ExpiresAtUnix,
+	}
+}
+
+type RespondToDeliveryOfferDTO struct {
+	// DriverID defaults to the signed-in driver; admins may name another.
+	DriverID string `json:"driver_id"`
+	Accept   *bool  `json:"accept" binding:"required"`
+}
+
+type DeliveryOfferDTO struct {
+	OfferID      string `json:"offer_id"`
+	OrderID      string `json:"order_id"`
+	RestaurantID string `json:"restaurant_id"`
+	DriverID     string `json:"driver_id"`
+	Status       string `json:"status"`
+	ExpiresAt    int64  `json:"expires_at"`
+}
+
+func DeliveryOfferFromProto(offer *restaurantpb.DeliveryOffer) *DeliveryOfferDTO {
+	return &DeliveryOfferDTO{
+		OfferID:      offer.OfferId,
+		OrderID:      offer.OrderId,
+		RestaurantID: offer.RestaurantId,
+		DriverID:     offer.DriverId,
+		Status:       offer.Status,
+		ExpiresAt:    offer.ExpiresAtUnix,
 	}
 }
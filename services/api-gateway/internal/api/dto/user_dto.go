@@ -1,6 +1,8 @@
 package dto
 
 import (
+	"time"
+
 	"github.com/tamirat-dejene/ha-soranu/services/api-gateway/internal/domain"
 	"github.com/tamirat-dejene/ha-soranu/shared/protos/userpb"
 )
@@ -31,12 +33,20 @@ func toDomainAddresses(protoAddresses []*userpb.Address) []domain.Address {
 			Country:    pa.Country,
 			Latitude:   pa.Latitude,
 			Longitude:  pa.Longitude,
+			Label:      pa.Label,
+			IsDefault:  pa.IsDefault,
 		}
 	}
 	return domainAddresses
 }
 
 func GetUserResponseFromProto(protoRes *userpb.GetUserResponse) *GetUserResponseDTO {
+	var defaultAddress *domain.Address
+	if protoRes.User.DefaultAddress != nil {
+		address := toDomainAddress(protoRes.User.DefaultAddress)
+		defaultAddress = &address
+	}
+
 	return &GetUserResponseDTO{
 		User: domain.User{
 			ID:          protoRes.User.UserId,
@@ -46,10 +56,24 @@ func GetUserResponseFromProto(protoRes *userpb.GetUserResponse) *GetUserResponse
 			Password:    "********",
 			Addresses:   toDomainAddresses(protoRes.User.Addresses),
 			CreatedAt:   protoRes.User.CreatedAt.AsTime(),
+			EmailVerified: protoRes.User.EmailVerified,
+			DefaultAddress: defaultAddress,
 		},
 	}
 }
 
+// UpdateProfileRequestDTO carries the signed-in user's new profile details.
+type UpdateProfileRequestDTO struct {
+	Username string `json:"username" binding:"required,max=50"`
+}
+
+func (ur *UpdateProfileRequestDTO) ToProto(userID string) *userpb.UpdateProfileRequest {
+	return &userpb.UpdateProfileRequest{
+		UserId:   userID,
+		Username: ur.Username,
+	}
+}
+
 type GetPhoneNumberRequestDTO struct {
 	UserId string `json:"user_id" binding:"required"`
 }
@@ -71,25 +95,23 @@ func GetPhoneNumberResponseFromProto(protoRes *userpb.GetPhoneNumberResponse) *G
 }
 
 type AddPhoneNumberRequestDTO struct {
-	UserId      string `json:"user_id" binding:"required"`
 	PhoneNumber string `json:"phone_number" binding:"required"`
 }
 
-func (apnr *AddPhoneNumberRequestDTO) ToProto() *userpb.AddPhoneNumberRequest {
+func (apnr *AddPhoneNumberRequestDTO) ToProto(userID string) *userpb.AddPhoneNumberRequest {
 	return &userpb.AddPhoneNumberRequest{
-		UserId:      apnr.UserId,
+		UserId:      userID,
 		PhoneNumber: apnr.PhoneNumber,
 	}
 }
 
 type UpdatePhoneNumberRequestDTO struct {
-	UserId      string `json:"user_id" binding:"required"`
 	PhoneNumber string `json:"phone_number" binding:"required"`
 }
 
-func (upnr *UpdatePhoneNumberRequestDTO) ToProto() *userpb.UpdatePhoneNumberRequest {
+func (upnr *UpdatePhoneNumberRequestDTO) ToProto(userID string) *userpb.UpdatePhoneNumberRequest {
 	return &userpb.UpdatePhoneNumberRequest{
-		UserId:      upnr.UserId,
+		UserId:      userID,
 		PhoneNumber: upnr.PhoneNumber,
 	}
 }
@@ -128,6 +150,8 @@ func toDomainAddress(protoAddress *userpb.Address) domain.Address {
 		Country:    protoAddress.Country,
 		Latitude:   protoAddress.Latitude,
 		Longitude:  protoAddress.Longitude,
+		Label:      protoAddress.Label,
+		IsDefault:  protoAddress.IsDefault,
 		CreatedAt:  protoAddress.CreatedAt.AsTime(),
 	}
 }
@@ -143,19 +167,21 @@ func GetAddressesResponseFromProto(protoRes *userpb.GetAddressesResponse) *GetAd
 }
 
 type AddAddressRequestDTO struct {
-	UserId     string  `json:"user_id" binding:"required"`
 	Street     string  `json:"street" binding:"required"`
 	City       string  `json:"city" binding:"required"`
 	State      string  `json:"state" binding:"required"`
 	PostalCode uint32  `json:"postal_code" binding:"required"`
 	Country    string  `json:"country" binding:"required"`
-	Latitude   float32 `json:"latitude" binding:"required"`
-	Longitude  float32 `json:"longitude" binding:"required"`
+	// 0 is a valid coordinate, so only the range is checked.
+	Latitude   float32 `json:"latitude" binding:"min=-90,max=90"`
+	Longitude  float32 `json:"longitude" binding:"min=-180,max=180"`
+	Label      string  `json:"label" binding:"max=50"`
+	IsDefault  bool    `json:"is_default"`
 }
 
-func (aar *AddAddressRequestDTO) ToProto() *userpb.AddAddressRequest {
+func (aar *AddAddressRequestDTO) ToProto(userID string) *userpb.AddAddressRequest {
 	return &userpb.AddAddressRequest{
-		UserId:     aar.UserId,
+		UserId:     userID,
 		Street:     aar.Street,
 		City:       aar.City,
 		State:      aar.State,
@@ -163,17 +189,57 @@ func (aar *AddAddressRequestDTO) ToProto() *userpb.AddAddressRequest {
 		Country:    aar.Country,
 		Latitude:   aar.Latitude,
 		Longitude:  aar.Longitude,
+		Label:      aar.Label,
+		IsDefault:  aar.IsDefault,
+	}
+}
+
+// UpdateAddressRequestDTO replaces the details of one of the user's addresses.
+type UpdateAddressRequestDTO struct {
+	AddressId  string  `json:"address_id" binding:"required"`
+	Street     string  `json:"street" binding:"required"`
+	City       string  `json:"city" binding:"required"`
+	State      string  `json:"state" binding:"required"`
+	PostalCode uint32  `json:"postal_code" binding:"required"`
+	Country    string  `json:"country" binding:"required"`
+	Latitude   float32 `json:"latitude" binding:"min=-90,max=90"`
+	Longitude  float32 `json:"longitude" binding:"min=-180,max=180"`
+	Label      string  `json:"label" binding:"max=50"`
+}
+
+func (uar *UpdateAddressRequestDTO) ToProto(userID string) *userpb.UpdateAddressRequest {
+	return &userpb.UpdateAddressRequest{
+		UserId:     userID,
+		AddressId:  uar.AddressId,
+		Street:     uar.Street,
+		City:       uar.City,
+		State:      uar.State,
+		PostalCode: uar.PostalCode,
+		Country:    uar.Country,
+		Latitude:   uar.Latitude,
+		Longitude:  uar.Longitude,
+		Label:      uar.Label,
+	}
+}
+
+type SetDefaultAddressRequestDTO struct {
+	AddressId string `json:"address_id" binding:"required"`
+}
+
+func (sar *SetDefaultAddressRequestDTO) ToProto(userID string) *userpb.SetDefaultAddressRequest {
+	return &userpb.SetDefaultAddressRequest{
+		UserId:    userID,
+		AddressId: sar.AddressId,
 	}
 }
 
 type RemoveAddressRequestDTO struct {
-	UserId    string `json:"user_id" binding:"required"`
 	AddressId string `json:"address_id" binding:"required"`
 }
 
-func (rar *RemoveAddressRequestDTO) ToProto() *userpb.RemoveAddressRequest {
+func (rar *RemoveAddressRequestDTO) ToProto(userID string) *userpb.RemoveAddressRequest {
 	return &userpb.RemoveAddressRequest{
-		UserId:    rar.UserId,
+		UserId:    userID,
 		AddressId: rar.AddressId,
 	}
 }
@@ -182,13 +248,89 @@ func AddAddressResponseFromProto(protoRes *userpb.AddAddressResponse) domain.Add
 	return toDomainAddress(protoRes.GetAddress())
 }
 
+// VehicleDTO is the vehicle a driver delivers with. The plate number is
+// required for every type but bicycle.
+type VehicleDTO struct {
+	Type        string `json:"type" binding:"required,oneof=bicycle scooter motorcycle car van"`
+	Make        string `json:"make" binding:"max=50"`
+	Model       string `json:"model" binding:"max=50"`
+	PlateNumber string `json:"plate_number" binding:"max=20"`
+	Color       string `json:"color" binding:"max=30"`
+}
+
+func (v *VehicleDTO) ToProto() *userpb.Vehicle {
+	if v == nil {
+		return nil
+	}
+	return &userpb.Vehicle{
+		Type:        v.Type,
+		Make:        v.Make,
+		Model:       v.Model,
+		PlateNumber: v.PlateNumber,
+		Color:       v.Color,
+	}
+}
+
 type BeDriverRequestDTO struct {
-	UserId string `json:"user_id" binding:"required"`
+	Vehicle *VehicleDTO `json:"vehicle"`
 }
 
-func (bdr *BeDriverRequestDTO) ToProto() *userpb.BeDriverRequest {
+func (bdr *BeDriverRequestDTO) ToProto(userID string) *userpb.BeDriverRequest {
 	return &userpb.BeDriverRequest{
-		UserId: bdr.UserId,
+		UserId:  userID,
+		Vehicle: bdr.Vehicle.ToProto(),
+	}
+}
+
+// DriverProfileDTO is the signed-in driver's profile.
+type DriverProfileDTO struct {
+	DriverId  string      `json:"driver_id"`
+	UserId    string      `json:"user_id"`
+	Vehicle   *VehicleDTO `json:"vehicle,omitempty"`
+	Online    bool        `json:"online"`
+	CreatedAt time.Time   `json:"created_at"`
+}
+
+func DriverProfileFromProto(protoRes *userpb.DriverProfileResponse) *DriverProfileDTO {
+	profile := protoRes.GetProfile()
+	result := &DriverProfileDTO{
+		DriverId:  profile.GetDriverId(),
+		UserId:    profile.GetUserId(),
+		Online:    profile.GetOnline(),
+		CreatedAt: profile.GetCreatedAt().AsTime(),
+	}
+	if v := profile.GetVehicle(); v != nil {
+		result.Vehicle = &VehicleDTO{
+			Type:        v.Type,
+			Make:        v.Make,
+			Model:       v.Model,
+			PlateNumber: v.PlateNumber,
+			Color:       v.Color,
+		}
+	}
+	return result
+}
+
+// DriverLocationRequestDTO carries the signed-in driver's current location.
+type DriverLocationRequestDTO struct {
+	// 0 is a valid coordinate, so only the range is checked.
+	Latitude  float32 `json:"latitude" binding:"min=-90,max=90"`
+	Longitude float32 `json:"longitude" binding:"min=-180,max=180"`
+}
+
+func (dlr *DriverLocationRequestDTO) ToGoOnlineProto(userID string) *userpb.GoOnlineRequest {
+	return &userpb.GoOnlineRequest{
+		UserId:    userID,
+		Latitude:  dlr.Latitude,
+		Longitude: dlr.Longitude,
+	}
+}
+
+func (dlr *DriverLocationRequestDTO) ToProto(userID string) *userpb.UpdateDriverLocationRequest {
+	return &userpb.UpdateDriverLocationRequest{
+		UserId:    userID,
+		Latitude:  dlr.Latitude,
+		Longitude: dlr.Longitude,
 	}
 }
 
@@ -219,9 +361,9 @@ func (rdr *RemoveDriverRequestDTO) ToProto() *userpb.RemoveDriverRequest {
 }
 
 type GetDriversRequestDTO struct {
-	Latitude  float32 `json:"latitude" binding:"required"`
-	Longitude float32 `json:"longitude" binding:"required"`
-	RadiusKm  float32 `json:"radius_km" binding:"required"`
+	Latitude  float32 `json:"latitude" binding:"min=-90,max=90"`
+	Longitude float32 `json:"longitude" binding:"min=-180,max=180"`
+	RadiusKm  float32 `json:"radius_km" binding:"required,gt=0,max=50"`
 }
 
 func (gdr *GetDriversRequestDTO) ToProto() *userpb.GetDriversRequest {
@@ -233,7 +375,16 @@ func (gdr *GetDriversRequestDTO) ToProto() *userpb.GetDriversRequest {
 }
 
 type GetDriversResponseDTO struct {
-	DriverIds []string      `json:"driver_ids"`
+	DriverIds []string          `json:"driver_ids"`
+	Drivers   []NearbyDriverDTO `json:"drivers"`
+}
+
+// NearbyDriverDTO is an online driver near the searched location.
+type NearbyDriverDTO struct {
+	DriverId   string  `json:"driver_id"`
+	Latitude   float32 `json:"latitude"`
+	Longitude  float32 `json:"longitude"`
+	DistanceKm float32 `json:"distance_km"`
 }
 
 func GetDriversResponseFromProto(protoRes *userpb.DriverResponse) *GetDriversResponseDTO {
@@ -242,7 +393,18 @@ func GetDriversResponseFromProto(protoRes *userpb.DriverResponse) *GetDriversRes
 		driverIds = append(driverIds, driver)
 	}
 
+	drivers := make([]NearbyDriverDTO, 0, len(protoRes.GetDrivers()))
+	for _, d := range protoRes.GetDrivers() {
+		drivers = append(drivers, NearbyDriverDTO{
+			DriverId:   d.DriverId,
+			Latitude:   d.Latitude,
+			Longitude:  d.Longitude,
+			DistanceKm: d.DistanceKm,
+		})
+	}
+
 	return &GetDriversResponseDTO{
 		DriverIds: driverIds,
+		Drivers:   drivers,
 	}
 }
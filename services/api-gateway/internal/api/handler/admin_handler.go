@@ -0,0 +1,204 @@
+package handler
+
+import (
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"github.com/tamirat-dejene/ha-soranu/services/api-gateway/internal/api/dto"
+	"github.com/tamirat-dejene/ha-soranu/services/api-gateway/internal/client"
+	"github.com/tamirat-dejene/ha-soranu/services/api-gateway/internal/domain"
+	"github.com/tamirat-dejene/ha-soranu/services/api-gateway/internal/errs"
+	"github.com/tamirat-dejene/ha-soranu/shared/pkg/logger"
+	"github.com/tamirat-dejene/ha-soranu/shared/protos/adminpb"
+	"go.uber.org/zap"
+)
+
+// AdminHandler serves the moderation endpoints. Its routes are for admins
+// only, which the services check again.
+type AdminHandler struct {
+	uaClient         *client.UAServiceClient
+	restaurantClient *client.RestaurantServiceClient
+}
+
+func NewAdminHandler(uaClient *client.UAServiceClient, restaurantClient *client.RestaurantServiceClient) *AdminHandler {
+	return &AdminHandler{uaClient: uaClient, restaurantClient: restaurantClient}
+}
+
+// SearchUsers lists the users matching the q, suspended, limit and offset
+// query parameters.
+func (h *AdminHandler) SearchUsers(c *gin.Context) {
+	var req dto.SearchUsersQueryDTO
+	if err := c.ShouldBindQuery(&req); err != nil {
+		c.JSON(http.StatusBadRequest, errs.NewErrorResponse(errs.MsgInvalidRequest))
+		return
+	}
+
+	resp, err := h.uaClient.AdminClient.SearchUsers(c.Request.Context(), req.ToProto())
+	if err != nil {
+		logger.Error("SearchUsers failed", zap.Error(err))
+		writeGRPCError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"users": dto.UserAccountsFromProto(resp)})
+}
+
+// SuspendUser suspends the user, who is signed out and cannot log in again
+// until unsuspended.
+func (h *AdminHandler) SuspendUser(c *gin.Context) {
+	var req dto.SuspendRequestDTO
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, errs.NewErrorResponse(errs.MsgInvalidRequest))
+		return
+	}
+
+	userID := c.Param("user_id")
+	resp, err := h.uaClient.AdminClient.SuspendUser(c.Request.Context(), &adminpb.SuspendUserRequest{
+		UserId: userID,
+		Reason: req.Reason,
+	})
+	if err != nil {
+		logger.Error("SuspendUser failed", zap.String("user_id", userID), zap.Error(err))
+		writeGRPCError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, dto.UserAccountFromProto(resp))
+}
+
+func (h *AdminHandler) UnsuspendUser(c *gin.Context) {
+	userID := c.Param("user_id")
+	resp, err := h.uaClient.AdminClient.UnsuspendUser(c.Request.Context(), &adminpb.UnsuspendUserRequest{UserId: userID})
+	if err != nil {
+		logger.Error("UnsuspendUser failed", zap.String("user_id", userID), zap.Error(err))
+		writeGRPCError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, dto.UserAccountFromProto(resp))
+}
+
+// RevokeUserSessions signs the user out of every device.
+func (h *AdminHandler) RevokeUserSessions(c *gin.Context) {
+	userID := c.Param("user_id")
+	resp, err := h.uaClient.AdminClient.RevokeUserSessions(c.Request.Context(), &adminpb.RevokeUserSessionsRequest{UserId: userID})
+	if err != nil {
+		logger.Error("RevokeUserSessions failed", zap.String("user_id", userID), zap.Error(err))
+		writeGRPCError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"revoked": resp.GetRevokedSessions()})
+}
+
+// GrantRole gives the user restaurant_owner or admin. Their tokens carry
+// the role once refreshed.
+func (h *AdminHandler) GrantRole(c *gin.Context) {
+	var req dto.GrantRoleRequestDTO
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, errs.NewErrorResponse(errs.MsgInvalidRequest))
+		return
+	}
+
+	userID := c.Param("user_id")
+	resp, err := h.uaClient.AdminClient.GrantRole(c.Request.Context(), &adminpb.GrantRoleRequest{
+		UserId: userID,
+		Role:   req.Role,
+	})
+	if err != nil {
+		logger.Error("GrantRole failed", zap.String("user_id", userID), zap.String("role", req.Role), zap.Error(err))
+		writeGRPCError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, dto.UserAccountFromProto(resp))
+}
+
+// RevokeRole takes restaurant_owner or admin from the user.
+func (h *AdminHandler) RevokeRole(c *gin.Context) {
+	userID, role := c.Param("user_id"), c.Param("role")
+	resp, err := h.uaClient.AdminClient.RevokeRole(c.Request.Context(), &adminpb.RevokeRoleRequest{
+		UserId: userID,
+		Role:   role,
+	})
+	if err != nil {
+		logger.Error("RevokeRole failed", zap.String("user_id", userID), zap.String("role", role), zap.Error(err))
+		writeGRPCError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, dto.UserAccountFromProto(resp))
+}
+
+// GetUserOrders lists the orders the user placed.
+func (h *AdminHandler) GetUserOrders(c *gin.Context) {
+	userID := c.Param("user_id")
+	resp, err := h.restaurantClient.AdminClient.GetUserOrders(c.Request.Context(), &adminpb.GetUserOrdersRequest{UserId: userID})
+	if err != nil {
+		logger.Error("GetUserOrders failed", zap.String("user_id", userID), zap.Error(err))
+		writeGRPCError(c, err)
+		return
+	}
+
+	orders := make([]*domain.Order, len(resp.Orders))
+	for i, order := range resp.Orders {
+		orders[i] = dto.OrderResponseFromProto(order)
+	}
+
+	c.JSON(http.StatusOK, gin.H{"orders": orders})
+}
+
+// SearchRestaurants lists the restaurants matching the q, status, limit and
+// offset query parameters.
+func (h *AdminHandler) SearchRestaurants(c *gin.Context) {
+	var req dto.SearchRestaurantsQueryDTO
+	if err := c.ShouldBindQuery(&req); err != nil {
+		c.JSON(http.StatusBadRequest, errs.NewErrorResponse(errs.MsgInvalidRequest))
+		return
+	}
+
+	resp, err := h.restaurantClient.AdminClient.SearchRestaurants(c.Request.Context(), req.ToProto())
+	if err != nil {
+		logger.Error("SearchRestaurants failed", zap.Error(err))
+		writeGRPCError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"restaurants": dto.RestaurantAccountsFromProto(resp)})
+}
+
+// SuspendRestaurant hides the restaurant from customers and stops it from
+// taking orders until unsuspended.
+func (h *AdminHandler) SuspendRestaurant(c *gin.Context) {
+	var req dto.SuspendRequestDTO
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, errs.NewErrorResponse(errs.MsgInvalidRequest))
+		return
+	}
+
+	restaurantID := c.Param("restaurant_id")
+	resp, err := h.restaurantClient.AdminClient.SuspendRestaurant(c.Request.Context(), &adminpb.SuspendRestaurantRequest{
+		RestaurantId: restaurantID,
+		Reason:       req.Reason,
+	})
+	if err != nil {
+		logger.Error("SuspendRestaurant failed", zap.String("restaurant_id", restaurantID), zap.Error(err))
+		writeGRPCError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, dto.RestaurantAccountFromProto(resp))
+}
+
+// UnsuspendRestaurant lifts the suspension, making the restaurant active.
+func (h *AdminHandler) UnsuspendRestaurant(c *gin.Context) {
+	restaurantID := c.Param("restaurant_id")
+	resp, err := h.restaurantClient.AdminClient.UnsuspendRestaurant(c.Request.Context(), &adminpb.UnsuspendRestaurantRequest{RestaurantId: restaurantID})
+	if err != nil {
+		logger.Error("UnsuspendRestaurant failed", zap.String("restaurant_id", restaurantID), zap.Error(err))
+		writeGRPCError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, dto.RestaurantAccountFromProto(resp))
+}
@@ -2,12 +2,14 @@ package handler
 
 import (
 	"net/http"
+	"strconv"
 
 	"github.com/gin-gonic/gin"
 	"github.com/tamirat-dejene/ha-soranu/services/api-gateway/internal/api/dto"
 	"github.com/tamirat-dejene/ha-soranu/services/api-gateway/internal/client"
 	"github.com/tamirat-dejene/ha-soranu/services/api-gateway/internal/errs"
 	"github.com/tamirat-dejene/ha-soranu/shared/pkg/logger"
+	"github.com/tamirat-dejene/ha-soranu/shared/protos/authpb"
 	"go.uber.org/zap"
 )
 
@@ -37,10 +39,10 @@ func (h *AuthHandler) Register(c *gin.Context) {
 		return
 	}
 
-	resp, err := h.client.AuthClient.Register(c.Request.Context(), req.ToProto())
+	resp, err := h.client.AuthClient.Register(outgoingContext(c), req.ToProto())
 	if err != nil {
 		logger.Error("Failed to register user", zap.Error(err))
-		c.JSON(http.StatusInternalServerError, dto.ErrorResponseFromGRPCError(err))
+		writeGRPCError(c, err)
 		return
 	}
 
@@ -54,10 +56,10 @@ func (h *AuthHandler) LoginWithEmailAndPassword(c *gin.Context) {
 		c.JSON(http.StatusBadRequest, errs.NewErrorResponse(errs.MsgInvalidRequest))
 		return
 	}
-	resp, err := h.client.AuthClient.LoginWithEmailAndPassword(c.Request.Context(), req.ToProto())
+	resp, err := h.client.AuthClient.LoginWithEmailAndPassword(outgoingContext(c), req.ToProto())
 	if err != nil {
 		logger.Error("Failed to login with email and password", zap.String("email", req.Email), zap.Error(err))
-		c.JSON(http.StatusInternalServerError, dto.ErrorResponseFromGRPCError(err))
+		writeGRPCError(c, err)
 		return
 	}
 
@@ -72,16 +74,49 @@ func (h *AuthHandler) LoginWithGoogle(c *gin.Context) {
 		return
 	}
 
-	resp, err := h.client.AuthClient.LoginWithGoogle(c.Request.Context(), req.ToProto())
+	resp, err := h.client.AuthClient.LoginWithGoogle(outgoingContext(c), req.ToProto())
 	if err != nil {
-		logger.Error("Failed to login with Google", zap.String("id_token", req.IdToken), zap.Error(err))
-		c.JSON(http.StatusInternalServerError, dto.ErrorResponseFromGRPCError(err))
+		logger.Error("Failed to login with Google", zap.Error(err))
+		writeGRPCError(c, err)
 		return
 	}
 
 	c.JSON(http.StatusOK, dto.LoginResponseFromProto(resp))
 }
 
+// LoginWithIdentityProvider logs in with an ID token from the provider named
+// in the path.
+func (h *AuthHandler) LoginWithIdentityProvider(c *gin.Context) {
+	provider := c.Param("provider")
+	logger.Info("Identity provider login request received", zap.String("provider", provider))
+	var req *dto.IdentityProviderLoginRequestDTO
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, errs.NewErrorResponse(errs.MsgInvalidRequest))
+		return
+	}
+
+	resp, err := h.client.AuthClient.LoginWithIdentityProvider(outgoingContext(c), req.ToProto(provider))
+	if err != nil {
+		logger.Error("Failed to login with identity provider", zap.String("provider", provider), zap.Error(err))
+		writeGRPCError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, dto.LoginResponseFromProto(resp))
+}
+
+// ListIdentityProviders names the identity providers users can log in with.
+func (h *AuthHandler) ListIdentityProviders(c *gin.Context) {
+	resp, err := h.client.AuthClient.ListIdentityProviders(outgoingContext(c), &authpb.ListIdentityProvidersRequest{})
+	if err != nil {
+		logger.Error("Failed to list identity providers", zap.Error(err))
+		writeGRPCError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, dto.IdentityProvidersResponseFromProto(resp))
+}
+
 func (h *AuthHandler) Logout(c *gin.Context) {
 	logger.Info("Logout request received")
 	var req *dto.LogoutRequestDTO
@@ -93,7 +128,7 @@ func (h *AuthHandler) Logout(c *gin.Context) {
 	_, err := h.client.AuthClient.Logout(c.Request.Context(), req.ToProto())
 	if err != nil {
 		logger.Error("Failed to logout user", zap.String("refresh_token", req.RefreshToken), zap.Error(err))
-		c.JSON(http.StatusInternalServerError, dto.ErrorResponseFromGRPCError(err))
+		writeGRPCError(c, err)
 		return
 	}
 
@@ -108,12 +143,326 @@ func (h *AuthHandler) Refresh(c *gin.Context) {
 		return
 	}
 
-	resp, err := h.client.AuthClient.Refresh(c.Request.Context(), req.ToProto())
+	resp, err := h.client.AuthClient.Refresh(outgoingContext(c), req.ToProto())
 	if err != nil {
 		logger.Error("Failed to refresh tokens", zap.String("refresh_token", req.RefreshToken), zap.Error(err))
-		c.JSON(http.StatusInternalServerError, dto.ErrorResponseFromGRPCError(err))
+		writeGRPCError(c, err)
 		return
 	}
 
 	c.JSON(http.StatusOK, dto.RefreshResponseFromProto(resp))
 }
+
+// SendEmailVerification emails the signed-in user a new verification link.
+func (h *AuthHandler) SendEmailVerification(c *gin.Context) {
+	logger.Info("Email verification request received")
+
+	resp, err := h.client.AuthClient.SendEmailVerification(c.Request.Context(), &authpb.SendEmailVerificationRequest{
+		UserId: c.GetString("user_id"),
+	})
+	if err != nil {
+		logger.Error("Failed to send email verification", zap.Error(err))
+		writeGRPCError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"message": resp.GetMessage()})
+}
+
+func (h *AuthHandler) VerifyEmail(c *gin.Context) {
+	logger.Info("Verify email request received")
+	var req *dto.VerifyEmailRequestDTO
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, errs.NewErrorResponse(errs.MsgInvalidRequest))
+		return
+	}
+
+	resp, err := h.client.AuthClient.VerifyEmail(c.Request.Context(), req.ToProto())
+	if err != nil {
+		logger.Error("Failed to verify email", zap.Error(err))
+		writeGRPCError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"message": resp.GetMessage()})
+}
+
+func (h *AuthHandler) ForgotPassword(c *gin.Context) {
+	logger.Info("Forgot password request received")
+	var req *dto.ForgotPasswordRequestDTO
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, errs.NewErrorResponse(errs.MsgInvalidRequest))
+		return
+	}
+
+	resp, err := h.client.AuthClient.RequestPasswordReset(c.Request.Context(), req.ToProto())
+	if err != nil {
+		logger.Error("Failed to request password reset", zap.Error(err))
+		writeGRPCError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"message": resp.GetMessage()})
+}
+
+func (h *AuthHandler) ResetPassword(c *gin.Context) {
+	logger.Info("Reset password request received")
+	var req *dto.ResetPasswordRequestDTO
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, errs.NewErrorResponse(errs.MsgInvalidRequest))
+		return
+	}
+
+	resp, err := h.client.AuthClient.ResetPassword(c.Request.Context(), req.ToProto())
+	if err != nil {
+		logger.Error("Failed to reset password", zap.Error(err))
+		writeGRPCError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"message": resp.GetMessage()})
+}
+
+// ChangePassword changes the signed-in user's password and signs out their
+// other sessions.
+func (h *AuthHandler) ChangePassword(c *gin.Context) {
+	logger.Info("Change password request received")
+	var req *dto.ChangePasswordRequestDTO
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, errs.NewErrorResponse(errs.MsgInvalidRequest))
+		return
+	}
+
+	resp, err := h.client.AuthClient.ChangePassword(c.Request.Context(), req.ToProto(c.GetString("user_id"), c.GetString("session_id")))
+	if err != nil {
+		logger.Error("Failed to change password", zap.Error(err))
+		writeGRPCError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"message": resp.GetMessage()})
+}
+
+// ChangeEmail emails a confirmation link to the new email of the signed-in
+// user. The email changes once the link is opened.
+func (h *AuthHandler) ChangeEmail(c *gin.Context) {
+	logger.Info("Change email request received")
+	var req *dto.ChangeEmailRequestDTO
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, errs.NewErrorResponse(errs.MsgInvalidRequest))
+		return
+	}
+
+	resp, err := h.client.AuthClient.ChangeEmail(c.Request.Context(), req.ToProto(c.GetString("user_id"), c.GetString("session_id")))
+	if err != nil {
+		logger.Error("Failed to change email", zap.Error(err))
+		writeGRPCError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"message": resp.GetMessage()})
+}
+
+// ConfirmEmailChange changes the email using the token from the link and
+// signs out the user's other sessions.
+func (h *AuthHandler) ConfirmEmailChange(c *gin.Context) {
+	logger.Info("Confirm email change request received")
+	var req *dto.ConfirmEmailChangeRequestDTO
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, errs.NewErrorResponse(errs.MsgInvalidRequest))
+		return
+	}
+
+	resp, err := h.client.AuthClient.ConfirmEmailChange(c.Request.Context(), req.ToProto())
+	if err != nil {
+		logger.Error("Failed to confirm email change", zap.Error(err))
+		writeGRPCError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"message": resp.GetMessage()})
+}
+
+// ListSessions lists the devices the signed-in user is signed in on.
+func (h *AuthHandler) ListSessions(c *gin.Context) {
+	logger.Info("List sessions request received")
+
+	resp, err := h.client.AuthClient.ListSessions(c.Request.Context(), &authpb.ListSessionsRequest{
+		UserId:           c.GetString("user_id"),
+		CurrentSessionId: c.GetString("session_id"),
+	})
+	if err != nil {
+		logger.Error("Failed to list sessions", zap.Error(err))
+		writeGRPCError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, dto.ListSessionsResponseFromProto(resp))
+}
+
+// RevokeSession signs the user out of one of their sessions.
+func (h *AuthHandler) RevokeSession(c *gin.Context) {
+	logger.Info("Revoke session request received")
+
+	resp, err := h.client.AuthClient.RevokeSession(c.Request.Context(), &authpb.RevokeSessionRequest{
+		UserId:    c.GetString("user_id"),
+		SessionId: c.Param("session_id"),
+	})
+	if err != nil {
+		logger.Error("Failed to revoke session", zap.String("session_id", c.Param("session_id")), zap.Error(err))
+		writeGRPCError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"message": resp.GetMessage()})
+}
+
+// RevokeAllSessions signs the user out everywhere. With keep_current=true the
+// session making the request stays signed in.
+func (h *AuthHandler) RevokeAllSessions(c *gin.Context) {
+	logger.Info("Revoke all sessions request received")
+
+	keepCurrent, _ := strconv.ParseBool(c.Query("keep_current"))
+	req := &authpb.RevokeAllSessionsRequest{
+		UserId: c.GetString("user_id"),
+	}
+	if keepCurrent {
+		req.ExceptSessionId = c.GetString("session_id")
+	}
+
+	resp, err := h.client.AuthClient.RevokeAllSessions(c.Request.Context(), req)
+	if err != nil {
+		logger.Error("Failed to revoke sessions", zap.Error(err))
+		writeGRPCError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"revoked": resp.GetRevoked()})
+}
+
+// CompleteMFALogin completes a login challenged for a second factor.
+func (h *AuthHandler) CompleteMFALogin(c *gin.Context) {
+	logger.Info("Two-factor login request received")
+	var req *dto.MFALoginRequestDTO
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, errs.NewErrorResponse(errs.MsgInvalidRequest))
+		return
+	}
+
+	resp, err := h.client.AuthClient.CompleteMFALogin(outgoingContext(c), req.ToProto())
+	if err != nil {
+		logger.Error("Failed to complete two-factor login", zap.Error(err))
+		writeGRPCError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, dto.LoginResponseFromProto(resp))
+}
+
+// EnrollMFA starts setting up two-factor authentication for the signed-in user.
+func (h *AuthHandler) EnrollMFA(c *gin.Context) {
+	logger.Info("Two-factor enrollment request received")
+
+	resp, err := h.client.AuthClient.EnrollMFA(c.Request.Context(), &authpb.EnrollMFARequest{
+		UserId: c.GetString("user_id"),
+	})
+	if err != nil {
+		logger.Error("Failed to enroll two-factor authentication", zap.Error(err))
+		writeGRPCError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, dto.MFAEnrollmentFromProto(resp))
+}
+
+// ConfirmMFA turns two-factor authentication on and returns the recovery codes.
+func (h *AuthHandler) ConfirmMFA(c *gin.Context) {
+	logger.Info("Two-factor confirmation request received")
+	var req *dto.ConfirmMFARequestDTO
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, errs.NewErrorResponse(errs.MsgInvalidRequest))
+		return
+	}
+
+	resp, err := h.client.AuthClient.ConfirmMFA(c.Request.Context(), req.ToProto(c.GetString("user_id")))
+	if err != nil {
+		logger.Error("Failed to confirm two-factor authentication", zap.Error(err))
+		writeGRPCError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"recovery_codes": resp.GetRecoveryCodes()})
+}
+
+// DisableMFA turns two-factor authentication off for the signed-in user.
+func (h *AuthHandler) DisableMFA(c *gin.Context) {
+	logger.Info("Two-factor disable request received")
+	var req *dto.DisableMFARequestDTO
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, errs.NewErrorResponse(errs.MsgInvalidRequest))
+		return
+	}
+
+	resp, err := h.client.AuthClient.DisableMFA(c.Request.Context(), req.ToProto(c.GetString("user_id")))
+	if err != nil {
+		logger.Error("Failed to disable two-factor authentication", zap.Error(err))
+		writeGRPCError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"message": resp.GetMessage()})
+}
+
+// DeleteAccount deletes the signed-in user's account and asks every service to
+// forget them. The returned request tracks the progress.
+func (h *AuthHandler) DeleteAccount(c *gin.Context) {
+	logger.Info("Delete account request received")
+	var req *dto.DeleteAccountRequestDTO
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, errs.NewErrorResponse(errs.MsgInvalidRequest))
+		return
+	}
+
+	resp, err := h.client.AuthClient.DeleteAccount(c.Request.Context(), req.ToProto(c.GetString("user_id")))
+	if err != nil {
+		logger.Error("Failed to delete account", zap.Error(err))
+		writeGRPCError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusAccepted, dto.AccountRequestFromProto(resp))
+}
+
+// ExportMyData starts collecting the signed-in user's data from every service.
+// The data is returned by GetAccountRequest once the export is completed.
+func (h *AuthHandler) ExportMyData(c *gin.Context) {
+	logger.Info("Data export request received")
+
+	resp, err := h.client.AuthClient.ExportMyData(c.Request.Context(), &authpb.ExportMyDataRequest{
+		UserId: c.GetString("user_id"),
+	})
+	if err != nil {
+		logger.Error("Failed to export user data", zap.Error(err))
+		writeGRPCError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusAccepted, dto.AccountRequestFromProto(resp))
+}
+
+// GetAccountRequest returns the progress of one of the user's account requests.
+func (h *AuthHandler) GetAccountRequest(c *gin.Context) {
+	logger.Info("Get account request received")
+
+	resp, err := h.client.AuthClient.GetAccountRequest(c.Request.Context(), &authpb.GetAccountRequestRequest{
+		UserId:    c.GetString("user_id"),
+		RequestId: c.Param("request_id"),
+	})
+	if err != nil {
+		logger.Error("Failed to get account request", zap.String("request_id", c.Param("request_id")), zap.Error(err))
+		writeGRPCError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, dto.AccountRequestFromProto(resp))
+}
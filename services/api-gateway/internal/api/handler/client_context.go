@@ -0,0 +1,24 @@
+package handler
+
+import (
+	"context"
+
+	"github.com/gin-gonic/gin"
+	"github.com/tamirat-dejene/ha-soranu/shared/pkg/clientinfo"
+)
+
+// deviceIDHeader lets client apps name their installation, so that users can
+// tell their sessions apart.
+const deviceIDHeader = "X-Device-ID"
+
+// outgoingContext returns the request context with the details of the
+// client attached, for services that need them. The IP is only read from
+// X-Forwarded-For when the request came through one of TRUSTED_PROXIES, so
+// a client cannot spread its failed logins over made-up addresses.
+func outgoingContext(c *gin.Context) context.Context {
+	return clientinfo.NewOutgoingContext(c.Request.Context(), clientinfo.Info{
+		IP:        c.ClientIP(),
+		UserAgent: c.Request.UserAgent(),
+		DeviceID:  c.GetHeader(deviceIDHeader),
+	})
+}
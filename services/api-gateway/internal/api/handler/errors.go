@@ -0,0 +1,19 @@
+package handler
+
+import (
+	"math"
+	"strconv"
+
+	"github.com/gin-gonic/gin"
+	"github.com/tamirat-dejene/ha-soranu/services/api-gateway/internal/api/dto"
+)
+
+// writeGRPCError answers a request a service failed with the HTTP status of
+// the gRPC code and a body built from the status details. A service asking
+// the client to wait has its delay passed on in Retry-After.
+func writeGRPCError(c *gin.Context, err error) {
+	if retryAfter, ok := dto.RetryAfterFromGRPCError(err); ok {
+		c.Header("Retry-After", strconv.FormatInt(int64(math.Ceil(retryAfter.Seconds())), 10))
+	}
+	c.JSON(dto.HTTPStatusFromGRPCError(err), dto.ErrorResponseFromGRPCError(err))
+}
@@ -0,0 +1,199 @@
+package handler
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/tamirat-dejene/ha-soranu/services/api-gateway/internal/api/dto"
+	"github.com/tamirat-dejene/ha-soranu/services/api-gateway/internal/client"
+	"github.com/tamirat-dejene/ha-soranu/services/api-gateway/internal/errs"
+	"github.com/tamirat-dejene/ha-soranu/shared/protos/restaurantpb"
+	"google.golang.org/genproto/googleapis/rpc/errdetails"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/protoadapt"
+	"google.golang.org/protobuf/types/known/durationpb"
+)
+
+// orderClient is a restaurant service whose GetOrder fails with err.
+type orderClient struct {
+	restaurantpb.RestaurantServiceClient
+	err error
+}
+
+func (c orderClient) GetOrder(context.Context, *restaurantpb.GetOrderRequest, ...grpc.CallOption) (*restaurantpb.Order, error) {
+	return nil, c.err
+}
+
+// withDetails returns the status of code carrying details, as the services
+// build them.
+func withDetails(t *testing.T, code codes.Code, msg string, details ...protoadapt.MessageV1) error {
+	t.Helper()
+
+	st, err := status.New(code, msg).WithDetails(details...)
+	if err != nil {
+		t.Fatalf("WithDetails() error = %v", err)
+	}
+	return st.Err()
+}
+
+func TestServiceErrorsMapToHTTPStatuses(t *testing.T) {
+	tests := []struct {
+		name       string
+		err        func(t *testing.T) error
+		wantStatus int
+		wantBody   dto.ErrorResponse
+		// wantRetryAfter is the Retry-After header expected, if any.
+		wantRetryAfter string
+	}{
+		{
+			name: "invalid argument with field violations",
+			err: func(t *testing.T) error {
+				return withDetails(t, codes.InvalidArgument, "invalid order data", &errdetails.BadRequest{
+					FieldViolations: []*errdetails.BadRequest_FieldViolation{{Field: "items[0].quantity", Description: "must be at least 1"}},
+				})
+			},
+			wantStatus: http.StatusBadRequest,
+			wantBody: dto.ErrorResponse{
+				Error:      "invalid order data",
+				Code:       "INVALID_ARGUMENT",
+				Violations: []dto.FieldViolation{{Field: "items[0].quantity", Description: "must be at least 1"}},
+			},
+		},
+		{
+			name:       "out of range",
+			err:        func(*testing.T) error { return status.Error(codes.OutOfRange, "page out of range") },
+			wantStatus: http.StatusBadRequest,
+			wantBody:   dto.ErrorResponse{Error: "page out of range", Code: "OUT_OF_RANGE"},
+		},
+		{
+			name:       "unauthenticated",
+			err:        func(*testing.T) error { return status.Error(codes.Unauthenticated, "invalid credentials") },
+			wantStatus: http.StatusUnauthorized,
+			wantBody:   dto.ErrorResponse{Error: "invalid credentials", Code: "UNAUTHENTICATED"},
+		},
+		{
+			name:       "permission denied",
+			err:        func(*testing.T) error { return status.Error(codes.PermissionDenied, "permission denied") },
+			wantStatus: http.StatusForbidden,
+			wantBody:   dto.ErrorResponse{Error: "permission denied", Code: "PERMISSION_DENIED"},
+		},
+		{
+			name:       "not found",
+			err:        func(*testing.T) error { return status.Error(codes.NotFound, "order not found") },
+			wantStatus: http.StatusNotFound,
+			wantBody:   dto.ErrorResponse{Error: "order not found", Code: "NOT_FOUND"},
+		},
+		{
+			name:       "already exists",
+			err:        func(*testing.T) error { return status.Error(codes.AlreadyExists, "idempotency key reused") },
+			wantStatus: http.StatusConflict,
+			wantBody:   dto.ErrorResponse{Error: "idempotency key reused", Code: "ALREADY_EXISTS"},
+		},
+		{
+			name:       "aborted",
+			err:        func(*testing.T) error { return status.Error(codes.Aborted, "request in progress") },
+			wantStatus: http.StatusConflict,
+			wantBody:   dto.ErrorResponse{Error: "request in progress", Code: "ABORTED"},
+		},
+		{
+			name: "failed precondition",
+			err: func(t *testing.T) error {
+				return withDetails(t, codes.FailedPrecondition, "restaurant is inactive", &errdetails.PreconditionFailure{
+					Violations: []*errdetails.PreconditionFailure_Violation{{Type: "RESTAURANT_INACTIVE", Subject: "restaurant", Description: "restaurant is inactive"}},
+				})
+			},
+			wantStatus: http.StatusConflict,
+			wantBody: dto.ErrorResponse{
+				Error:         "restaurant is inactive",
+				Code:          "FAILED_PRECONDITION",
+				Preconditions: []dto.PreconditionViolation{{Type: "RESTAURANT_INACTIVE", Subject: "restaurant", Description: "restaurant is inactive"}},
+			},
+		},
+		{
+			name: "resource exhausted with retry info",
+			err: func(t *testing.T) error {
+				return withDetails(t, codes.ResourceExhausted, "too many attempts", &errdetails.RetryInfo{
+					RetryDelay: durationpb.New(1500 * time.Millisecond),
+				})
+			},
+			wantStatus:     http.StatusTooManyRequests,
+			wantBody:       dto.ErrorResponse{Error: "too many attempts", Code: "RESOURCE_EXHAUSTED", RetryAfterSeconds: 2},
+			wantRetryAfter: "2",
+		},
+		{
+			name:       "canceled",
+			err:        func(*testing.T) error { return status.Error(codes.Canceled, "context canceled") },
+			wantStatus: dto.StatusClientClosedRequest,
+			wantBody:   dto.ErrorResponse{Error: "context canceled", Code: "CANCELLED"},
+		},
+		{
+			name:       "deadline exceeded",
+			err:        func(*testing.T) error { return status.Error(codes.DeadlineExceeded, "context deadline exceeded") },
+			wantStatus: http.StatusGatewayTimeout,
+			wantBody:   dto.ErrorResponse{Error: "context deadline exceeded", Code: "DEADLINE_EXCEEDED"},
+		},
+		{
+			name:       "unimplemented",
+			err:        func(*testing.T) error { return status.Error(codes.Unimplemented, "unknown method") },
+			wantStatus: http.StatusNotImplemented,
+			wantBody:   dto.ErrorResponse{Error: "unknown method", Code: "UNIMPLEMENTED"},
+		},
+		{
+			name:       "unavailable",
+			err:        func(*testing.T) error { return status.Error(codes.Unavailable, "connection refused") },
+			wantStatus: http.StatusServiceUnavailable,
+			wantBody:   dto.ErrorResponse{Error: "connection refused", Code: "UNAVAILABLE"},
+		},
+		{
+			name:       "internal hides the message",
+			err:        func(*testing.T) error { return status.Error(codes.Internal, `relation "orders" does not exist`) },
+			wantStatus: http.StatusInternalServerError,
+			wantBody:   dto.ErrorResponse{Error: errs.MsgInternalError, Code: "INTERNAL"},
+		},
+		{
+			name:       "error without a status",
+			err:        func(*testing.T) error { return errors.New("connection reset") },
+			wantStatus: http.StatusInternalServerError,
+			wantBody:   dto.ErrorResponse{Error: errs.MsgInternalError, Code: "UNKNOWN"},
+		},
+	}
+
+	gin.SetMode(gin.TestMode)
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			h := NewRestaurantHandler(&client.RestaurantServiceClient{RestaurantClient: orderClient{err: tt.err(t)}})
+
+			w := httptest.NewRecorder()
+			c, _ := gin.CreateTestContext(w)
+			c.Request = httptest.NewRequest(http.MethodGet, "/api/v1/restaurants/orders/order-1", nil)
+			c.Params = gin.Params{{Key: "order_id", Value: "order-1"}}
+
+			h.GetOrder(c)
+
+			if w.Code != tt.wantStatus {
+				t.Fatalf("status = %d, want %d", w.Code, tt.wantStatus)
+			}
+			if got := w.Header().Get("Retry-After"); got != tt.wantRetryAfter {
+				t.Errorf("Retry-After = %q, want %q", got, tt.wantRetryAfter)
+			}
+
+			var body dto.ErrorResponse
+			if err := json.Unmarshal(w.Body.Bytes(), &body); err != nil {
+				t.Fatalf("decode body %q: %v", w.Body.String(), err)
+			}
+			want, _ := json.Marshal(tt.wantBody)
+			got, _ := json.Marshal(body)
+			if string(got) != string(want) {
+				t.Errorf("body = %s, want %s", got, want)
+			}
+		})
+	}
+}
@@ -4,7 +4,6 @@ import (
 	"net/http"
 
 	"github.com/gin-gonic/gin"
-	"github.com/tamirat-dejene/ha-soranu/services/api-gateway/internal/api/dto"
 	"github.com/tamirat-dejene/ha-soranu/services/api-gateway/internal/client"
 	"github.com/tamirat-dejene/ha-soranu/services/api-gateway/internal/errs"
 	"github.com/tamirat-dejene/ha-soranu/shared/pkg/logger"
@@ -32,7 +31,7 @@ func (h *NotificationHandler) GetUserNotifications(c *gin.Context) {
 	notifications, err := h.client.GetNotifications(c.Request.Context(), userID, "USER")
 	if err != nil {
 		logger.Error("failed to get user notifications", zap.Error(err))
-		c.JSON(http.StatusInternalServerError, dto.ErrorResponseFromGRPCError(err))
+		writeGRPCError(c, err)
 		return
 	}
 
@@ -76,7 +75,7 @@ func (h *NotificationHandler) GetRestaurantNotifications(c *gin.Context) {
 	notifications, err := h.client.GetNotifications(c.Request.Context(), restaurantID, "RESTAURANT")
 	if err != nil {
 		logger.Error("failed to get restaurant notifications", zap.Error(err))
-		c.JSON(http.StatusInternalServerError, dto.ErrorResponseFromGRPCError(err))
+		writeGRPCError(c, err)
 		return
 	}
 
@@ -120,7 +119,7 @@ func (h *NotificationHandler) MarkAsRead(c *gin.Context) {
 	success, err := h.client.MarkAsRead(c.Request.Context(), notificationID)
 	if err != nil {
 		logger.Error("failed to mark notification as read", zap.Error(err))
-		c.JSON(http.StatusInternalServerError, dto.ErrorResponseFromGRPCError(err))
+		writeGRPCError(c, err)
 		return
 	}
 
@@ -2,8 +2,12 @@ package handler
 
 import (
 	"context"
+	"encoding/json"
+	"fmt"
 	"io"
 	"net/http"
+	"strconv"
+	"strings"
 	"time"
 
 	"github.com/gin-gonic/gin"
@@ -38,7 +42,7 @@ func (h *RestaurantHandler) GetOrder(c *gin.Context) {
 
 	resp, err := h.client.RestaurantClient.GetOrder(c.Request.Context(), req)
 	if err != nil {
-		c.JSON(http.StatusInternalServerError, dto.ErrorResponseFromGRPCError(err))
+		writeGRPCError(c, err)
 		return
 	}
 
@@ -50,24 +54,190 @@ func (h *RestaurantHandler) GetOrder(c *gin.Context) {
 }
 
 func (h *RestaurantHandler) ShipOrder(c *gin.Context) {
+	restaurantID := c.Param("restaurant_id")
 	orderID := c.Param("order_id")
 
-	if orderID == "" {
-		c.JSON(http.StatusBadRequest, errs.NewErrorResponse("order_id is required"))
+	if restaurantID == "" || orderID == "" {
+		c.JSON(http.StatusBadRequest, errs.NewErrorResponse("restaurant_id and order_id are required"))
 		return
 	}
 
-	req := &restaurantpb.ShipOrderRequest{OrderId: orderID}
+	req := &restaurantpb.ShipOrderRequest{RestaurantId: restaurantID, OrderId: orderID}
 
 	resp, err := h.client.RestaurantClient.ShipOrder(c.Request.Context(), req)
 	if err != nil {
-		c.JSON(http.StatusInternalServerError, dto.ErrorResponseFromGRPCError(err))
+		writeGRPCError(c, err)
 		return
 	}
 
 	c.JSON(http.StatusOK, dto.ShipOrderResponseFromProto(resp))
 }
 
+// orderFeedHeartbeat keeps idle order feed connections open through proxies.
+const orderFeedHeartbeat = 15 * time.Second
+
+// WatchOrders streams the restaurant's order feed as server-sent events.
+// Clients resume after a reconnect through the Last-Event-ID header or the
+// cursor query parameter.
+func (h *RestaurantHandler) WatchOrders(c *gin.Context) {
+	restaurantID := c.Param("restaurant_id")
+	if restaurantID == "" {
+		c.JSON(http.StatusBadRequest, errs.NewErrorResponse("restaurant_id is required"))
+		return
+	}
+
+	rawCursor := c.GetHeader("Last-Event-ID")
+	if rawCursor == "" {
+		rawCursor = c.Query("cursor")
+	}
+
+	var cursor int64
+	if rawCursor != "" {
+		var err error
+		cursor, err = strconv.ParseInt(rawCursor, 10, 64)
+		if err != nil || cursor < 0 {
+			c.JSON(http.StatusBadRequest, errs.NewErrorResponse("cursor must be a non-negative integer"))
+			return
+		}
+	}
+
+	ctx, cancel := context.WithCancel(c.Request.Context())
+	defer cancel()
+
+	req := &restaurantpb.WatchOrdersRequest{RestaurantId: restaurantID, Cursor: cursor}
+
+	stream, err := h.client.RestaurantClient.WatchOrders(ctx, req)
+	if err != nil {
+		writeGRPCError(c, err)
+		return
+	}
+
+	type received struct {
+		event *restaurantpb.OrderFeedEvent
+		err   error
+	}
+
+	events := make(chan received)
+	go func() {
+		for {
+			event, err := stream.Recv()
+			select {
+			case events <- received{event, err}:
+			case <-ctx.Done():
+				return
+			}
+			if err != nil {
+				return
+			}
+		}
+	}()
+
+	c.Header("Content-Type", "text/event-stream")
+	c.Header("Cache-Control", "no-cache")
+	c.Header("Connection", "keep-alive")
+	c.Header("X-Accel-Buffering", "no")
+	c.Status(http.StatusOK)
+	c.Writer.Flush()
+
+	heartbeat := time.NewTicker(orderFeedHeartbeat)
+	defer heartbeat.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-heartbeat.C:
+			if _, err := io.WriteString(c.Writer, ": keep-alive\n\n"); err != nil {
+				return
+			}
+		case r := <-events:
+			if r.err != nil {
+				if r.err != io.EOF && ctx.Err() == nil {
+					logger.Error("order feed stream receive failed", zap.Error(r.err))
+					writeServerSentEvent(c.Writer, "", "error", dto.ErrorResponseFromGRPCError(r.err))
+				}
+				return
+			}
+
+			// Snapshot events carry no id: a client cut off mid-snapshot
+			// reconnects without a cursor and gets the whole snapshot again.
+			id := ""
+			if r.event.Type != restaurantpb.OrderFeedEvent_SNAPSHOT {
+				id = strconv.FormatInt(r.event.Cursor, 10)
+			}
+
+			eventName := strings.ToLower(r.event.Type.String())
+			if err := writeServerSentEvent(c.Writer, id, eventName, dto.OrderFeedEventFromProto(r.event)); err != nil {
+				return
+			}
+		}
+		c.Writer.Flush()
+	}
+}
+
+func writeServerSentEvent(w io.Writer, id, event string, data any) error {
+	payload, err := json.Marshal(data)
+	if err != nil {
+		return err
+	}
+
+	var buf strings.Builder
+	if id != "" {
+		fmt.Fprintf(&buf, "id: %s\n", id)
+	}
+	fmt.Fprintf(&buf, "event: %s\ndata: %s\n\n", event, payload)
+
+	_, err = io.WriteString(w, buf.String())
+	return err
+}
+
+func (h *RestaurantHandler) GetDeliveryOffers(c *gin.Context) {
+	// The restaurant service answers with the signed-in driver's offers;
+	// admins may name a driver.
+	req := &restaurantpb.GetDeliveryOffersRequest{DriverId: c.Query("driver_id")}
+
+	resp, err := h.client.RestaurantClient.GetDeliveryOffers(c.Request.Context(), req)
+	if err != nil {
+		writeGRPCError(c, err)
+		return
+	}
+
+	offers := make([]*dto.DeliveryOfferDTO, 0, len(resp.Offers))
+	for _, offer := range resp.Offers {
+		offers = append(offers, dto.DeliveryOfferFromProto(offer))
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"offers": offers,
+	})
+}
+
+func (h *RestaurantHandler) RespondToDeliveryOffer(c *gin.Context) {
+	offerID := c.Param("offer_id")
+	if offerID == "" {
+		c.JSON(http.StatusBadRequest, errs.NewErrorResponse("offer_id is required"))
+		return
+	}
+
+	var req dto.RespondToDeliveryOfferDTO
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, errs.NewErrorResponse(errs.MsgInvalidRequest))
+		return
+	}
+
+	resp, err := h.client.RestaurantClient.RespondToDeliveryOffer(c.Request.Context(), &restaurantpb.RespondToDeliveryOfferRequest{
+		OfferId:  offerID,
+		DriverId: req.DriverID,
+		Accept:   *req.Accept,
+	})
+	if err != nil {
+		writeGRPCError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, dto.DeliveryOfferFromProto(resp))
+}
+
 func (h *RestaurantHandler) GetOrders(c *gin.Context) {
 	restaurantID := c.Query("restaurant_id")
 	if restaurantID == "" {
@@ -79,7 +249,7 @@ func (h *RestaurantHandler) GetOrders(c *gin.Context) {
 
 	resp, err := h.client.RestaurantClient.GetOrders(c.Request.Context(), req)
 	if err != nil {
-		c.JSON(http.StatusInternalServerError, dto.ErrorResponseFromGRPCError(err))
+		writeGRPCError(c, err)
 		return
 	}
 
@@ -116,7 +286,7 @@ func (h *RestaurantHandler) UpdateOrderStatus(c *gin.Context) {
 
 	resp, err := h.client.RestaurantClient.UpdateOrderStatus(c.Request.Context(), updateProto)
 	if err != nil {
-		c.JSON(http.StatusInternalServerError, dto.ErrorResponseFromGRPCError(err))
+		writeGRPCError(c, err)
 		return
 	}
 
@@ -130,9 +300,130 @@ func (h *RestaurantHandler) PlaceOrder(c *gin.Context) {
 		return
 	}
 
-	resp, err := h.client.RestaurantClient.PlaceOrder(c.Request.Context(), req.ToProto())
+	if key := c.GetHeader("Idempotency-Key"); key != "" {
+		req.IdempotencyKey = key
+	}
+
+	customerID, ok := subjectID(c)
+	if !ok {
+		return
+	}
+
+	resp, err := h.client.RestaurantClient.PlaceOrder(c.Request.Context(), req.ToProto(customerID))
 	if err != nil {
-		c.JSON(http.StatusInternalServerError, dto.ErrorResponseFromGRPCError(err))
+		writeGRPCError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, dto.PlaceOrderResponseFromProto(resp))
+}
+
+func (h *RestaurantHandler) GetCart(c *gin.Context) {
+	req := &restaurantpb.GetCartRequest{CustomerId: c.Param("customer_id")}
+
+	resp, err := h.client.RestaurantClient.GetCart(c.Request.Context(), req)
+	if err != nil {
+		writeGRPCError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, dto.CartFromProto(resp))
+}
+
+func (h *RestaurantHandler) AddCartItem(c *gin.Context) {
+	var body dto.AddCartItemDTO
+	if err := c.ShouldBindJSON(&body); err != nil {
+		c.JSON(http.StatusBadRequest, errs.NewErrorResponse(errs.MsgInvalidRequest))
+		return
+	}
+
+	req := &restaurantpb.AddCartItemRequest{
+		CustomerId:   c.Param("customer_id"),
+		RestaurantId: body.RestaurantID,
+		ItemId:       body.ItemID,
+		Quantity:     body.Quantity,
+	}
+
+	resp, err := h.client.RestaurantClient.AddCartItem(c.Request.Context(), req)
+	if err != nil {
+		writeGRPCError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, dto.CartFromProto(resp))
+}
+
+func (h *RestaurantHandler) UpdateCartItem(c *gin.Context) {
+	var body dto.UpdateCartItemDTO
+	if err := c.ShouldBindJSON(&body); err != nil {
+		c.JSON(http.StatusBadRequest, errs.NewErrorResponse(errs.MsgInvalidRequest))
+		return
+	}
+
+	req := &restaurantpb.UpdateCartItemRequest{
+		CustomerId: c.Param("customer_id"),
+		ItemId:     c.Param("item_id"),
+		Quantity:   *body.Quantity,
+	}
+
+	resp, err := h.client.RestaurantClient.UpdateCartItem(c.Request.Context(), req)
+	if err != nil {
+		writeGRPCError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, dto.CartFromProto(resp))
+}
+
+func (h *RestaurantHandler) RemoveCartItem(c *gin.Context) {
+	req := &restaurantpb.RemoveCartItemRequest{
+		CustomerId: c.Param("customer_id"),
+		ItemId:     c.Param("item_id"),
+	}
+
+	resp, err := h.client.RestaurantClient.RemoveCartItem(c.Request.Context(), req)
+	if err != nil {
+		writeGRPCError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, dto.CartFromProto(resp))
+}
+
+func (h *RestaurantHandler) ClearCart(c *gin.Context) {
+	req := &restaurantpb.ClearCartRequest{CustomerId: c.Param("customer_id")}
+
+	resp, err := h.client.RestaurantClient.ClearCart(c.Request.Context(), req)
+	if err != nil {
+		writeGRPCError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, dto.CartFromProto(resp))
+}
+
+func (h *RestaurantHandler) CheckoutCart(c *gin.Context) {
+	var body dto.CheckoutCartDTO
+	// The body is optional, the key can come from the header alone.
+	if c.Request.ContentLength > 0 {
+		if err := c.ShouldBindJSON(&body); err != nil {
+			c.JSON(http.StatusBadRequest, errs.NewErrorResponse(errs.MsgInvalidRequest))
+			return
+		}
+	}
+
+	if key := c.GetHeader("Idempotency-Key"); key != "" {
+		body.IdempotencyKey = key
+	}
+
+	req := &restaurantpb.CheckoutCartRequest{
+		CustomerId:     c.Param("customer_id"),
+		IdempotencyKey: body.IdempotencyKey,
+	}
+
+	resp, err := h.client.RestaurantClient.CheckoutCart(c.Request.Context(), req)
+	if err != nil {
+		writeGRPCError(c, err)
 		return
 	}
 
@@ -148,7 +439,7 @@ func (h *RestaurantHandler) Login(c *gin.Context) {
 
 	resp, err := h.client.RestaurantClient.Login(c.Request.Context(), req.ToProto())
 	if err != nil {
-		c.JSON(http.StatusInternalServerError, dto.ErrorResponseFromGRPCError(err))
+		writeGRPCError(c, err)
 		return
 	}
 
@@ -164,7 +455,7 @@ func (h *RestaurantHandler) RegisterRestaurant(c *gin.Context) {
 
 	resp, err := h.client.RestaurantClient.RegisterRestaurant(c.Request.Context(), req.ToProto())
 	if err != nil {
-		c.JSON(http.StatusInternalServerError, dto.ErrorResponseFromGRPCError(err))
+		writeGRPCError(c, err)
 		return
 	}
 
@@ -183,7 +474,65 @@ func (h *RestaurantHandler) GetRestaurant(c *gin.Context) {
 
 	resp, err := h.client.RestaurantClient.GetRestaurant(c.Request.Context(), req)
 	if err != nil {
-		c.JSON(http.StatusInternalServerError, dto.ErrorResponseFromGRPCError(err))
+		writeGRPCError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, dto.RestaurantResponseFromProto(resp))
+}
+
+func (h *RestaurantHandler) UpdateRestaurant(c *gin.Context) {
+	restaurantID := c.Param("restaurant_id")
+	if restaurantID == "" {
+		c.JSON(http.StatusBadRequest, errs.NewErrorResponse("restaurant_id is required"))
+		return
+	}
+
+	var req dto.UpdateRestaurantDTO
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, errs.NewErrorResponse(errs.MsgInvalidRequest))
+		return
+	}
+
+	resp, err := h.client.RestaurantClient.UpdateRestaurant(c.Request.Context(), req.ToProto(restaurantID))
+	if err != nil {
+		writeGRPCError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, dto.RestaurantResponseFromProto(resp))
+}
+
+func (h *RestaurantHandler) DeactivateRestaurant(c *gin.Context) {
+	restaurantID := c.Param("restaurant_id")
+	if restaurantID == "" {
+		c.JSON(http.StatusBadRequest, errs.NewErrorResponse("restaurant_id is required"))
+		return
+	}
+
+	req := &restaurantpb.DeactivateRestaurantRequest{RestaurantId: restaurantID}
+
+	resp, err := h.client.RestaurantClient.DeactivateRestaurant(c.Request.Context(), req)
+	if err != nil {
+		writeGRPCError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, dto.RestaurantResponseFromProto(resp))
+}
+
+func (h *RestaurantHandler) ReactivateRestaurant(c *gin.Context) {
+	restaurantID := c.Param("restaurant_id")
+	if restaurantID == "" {
+		c.JSON(http.StatusBadRequest, errs.NewErrorResponse("restaurant_id is required"))
+		return
+	}
+
+	req := &restaurantpb.ReactivateRestaurantRequest{RestaurantId: restaurantID}
+
+	resp, err := h.client.RestaurantClient.ReactivateRestaurant(c.Request.Context(), req)
+	if err != nil {
+		writeGRPCError(c, err)
 		return
 	}
 
@@ -227,7 +576,7 @@ func (h *RestaurantHandler) ListRestaurants(c *gin.Context) {
 	stream, err := h.client.RestaurantClient.ListRestaurants(ctx, req.ToProto())
 
 	if err != nil {
-		c.JSON(http.StatusInternalServerError, dto.ErrorResponseFromGRPCError(err))
+		writeGRPCError(c, err)
 		return
 	}
 
@@ -244,7 +593,7 @@ func (h *RestaurantHandler) ListRestaurants(c *gin.Context) {
 				zap.Error(err),
 			)
 
-			c.JSON(http.StatusInternalServerError, dto.ErrorResponseFromGRPCError(err))
+			writeGRPCError(c, err)
 			return
 		}
 		logger.Info("Info", zap.Any("res", res))
@@ -273,13 +622,81 @@ func (h *RestaurantHandler) AddMenuItem(c *gin.Context) {
 
 	resp, err := h.client.RestaurantClient.AddMenuItem(c.Request.Context(), menuItem.ToProto())
 	if err != nil {
-		c.JSON(http.StatusInternalServerError, dto.ErrorResponseFromGRPCError(err))
+		writeGRPCError(c, err)
 		return
 	}
 
 	c.JSON(http.StatusOK, dto.MenuItemResponseFromProto(resp))
 }
 
+// maxMenuImportBytes caps the size of an uploaded menu.
+const maxMenuImportBytes = 2 << 20
+
+func (h *RestaurantHandler) ImportMenu(c *gin.Context) {
+	restaurantID := c.Param("restaurant_id")
+	if restaurantID == "" {
+		c.JSON(http.StatusBadRequest, errs.NewErrorResponse("restaurant_id is required"))
+		return
+	}
+
+	format, ok := dto.MenuFormatFromRequest(c.Query("format"), c.ContentType())
+	if !ok {
+		c.JSON(http.StatusBadRequest, errs.NewErrorResponse("format must be csv or json"))
+		return
+	}
+
+	data, err := io.ReadAll(http.MaxBytesReader(c.Writer, c.Request.Body, maxMenuImportBytes))
+	if err != nil {
+		c.JSON(http.StatusRequestEntityTooLarge, errs.NewErrorResponse("menu file is too large"))
+		return
+	}
+
+	resp, err := h.client.RestaurantClient.ImportMenu(c.Request.Context(), &restaurantpb.ImportMenuRequest{
+		RestaurantId: restaurantID,
+		Format:       format,
+		Data:         data,
+		DryRun:       c.Query("dry_run") == "true",
+	})
+	if err != nil {
+		writeGRPCError(c, err)
+		return
+	}
+
+	status := http.StatusOK
+	if len(resp.Errors) > 0 {
+		status = http.StatusUnprocessableEntity
+	}
+
+	c.JSON(status, dto.MenuImportResponseFromProto(resp))
+}
+
+func (h *RestaurantHandler) ExportMenu(c *gin.Context) {
+	restaurantID := c.Param("restaurant_id")
+	if restaurantID == "" {
+		c.JSON(http.StatusBadRequest, errs.NewErrorResponse("restaurant_id is required"))
+		return
+	}
+
+	format, ok := dto.MenuFormatFromRequest(c.Query("format"), "")
+	if !ok {
+		c.JSON(http.StatusBadRequest, errs.NewErrorResponse("format must be csv or json"))
+		return
+	}
+
+	resp, err := h.client.RestaurantClient.ExportMenu(c.Request.Context(), &restaurantpb.ExportMenuRequest{
+		RestaurantId: restaurantID,
+		Format:       format,
+	})
+	if err != nil {
+		writeGRPCError(c, err)
+		return
+	}
+
+	filename := "menu." + strings.ToLower(resp.Format.String())
+	c.Header("Content-Disposition", `attachment; filename="`+filename+`"`)
+	c.Data(http.StatusOK, resp.ContentType, resp.Data)
+}
+
 func (h *RestaurantHandler) RemoveMenuItem(c *gin.Context) {
 	restaurantID := c.Query("restaurant_id")
 	itemID := c.Query("item_id")
@@ -296,7 +713,7 @@ func (h *RestaurantHandler) RemoveMenuItem(c *gin.Context) {
 
 	resp, err := h.client.RestaurantClient.RemoveMenuItem(c.Request.Context(), req)
 	if err != nil {
-		c.JSON(http.StatusInternalServerError, dto.ErrorResponseFromGRPCError(err))
+		writeGRPCError(c, err)
 		return
 	}
 
@@ -328,7 +745,7 @@ func (h *RestaurantHandler) UpdateMenuItem(c *gin.Context) {
 
 	resp, err := h.client.RestaurantClient.UpdateMenuItem(c.Request.Context(), updateProto)
 	if err != nil {
-		c.JSON(http.StatusInternalServerError, dto.ErrorResponseFromGRPCError(err))
+		writeGRPCError(c, err)
 		return
 	}
 
@@ -0,0 +1,38 @@
+package handler
+
+import (
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"github.com/tamirat-dejene/ha-soranu/services/api-gateway/internal/errs"
+	jwtvalidator "github.com/tamirat-dejene/ha-soranu/shared/pkg/auth/jwtvalidator"
+)
+
+// subjectParam names the user an admin acts for, in the query string.
+const subjectParam = "user_id"
+
+// subjectID returns the user a request acts on: the signed-in user, or the
+// user named by the user_id query parameter when an admin asks. Anyone else
+// naming another user gets 403 and false.
+func subjectID(c *gin.Context) (string, bool) {
+	userID := c.GetString("user_id")
+
+	requested := c.Query(subjectParam)
+	if requested == "" || requested == userID {
+		return userID, true
+	}
+
+	if isAdmin(c) {
+		return requested, true
+	}
+
+	c.JSON(http.StatusForbidden, errs.NewErrorResponse(errs.MsgUnauthorized))
+	return "", false
+}
+
+// isAdmin reports whether the signed-in user is an admin.
+func isAdmin(c *gin.Context) bool {
+	value, _ := c.Get("claims")
+	claims, _ := value.(*jwtvalidator.AccessClaims)
+	return claims != nil && claims.HasRole(jwtvalidator.RoleAdmin)
+}
@@ -8,6 +8,7 @@ import (
 	"github.com/tamirat-dejene/ha-soranu/services/api-gateway/internal/client"
 	"github.com/tamirat-dejene/ha-soranu/services/api-gateway/internal/errs"
 	"github.com/tamirat-dejene/ha-soranu/shared/pkg/logger"
+	"github.com/tamirat-dejene/ha-soranu/shared/protos/userpb"
 	"go.uber.org/zap"
 )
 
@@ -30,7 +31,7 @@ func (h *UserHandler) RemoveDriver(c *gin.Context) {
 	resp, err := h.client.UserClient.RemoveDriver(c.Request.Context(), req.ToProto())
 	if err != nil {
 		logger.Error("RemoveDriver failed", zap.String("driver_id", req.DriverId), zap.Error(err))
-		c.JSON(http.StatusInternalServerError, dto.ErrorResponseFromGRPCError(err))
+		writeGRPCError(c, err)
 		return
 	}
 
@@ -48,7 +49,7 @@ func (h *UserHandler) GetDrivers(c *gin.Context) {
 	resp, err := h.client.UserClient.GetDrivers(c.Request.Context(), req.ToProto())
 	if err != nil {
 		logger.Error("GetDrivers failed", zap.Error(err))
-		c.JSON(http.StatusInternalServerError, dto.ErrorResponseFromGRPCError(err))
+		writeGRPCError(c, err)
 		return
 	}
 
@@ -63,20 +64,111 @@ func (h *UserHandler) BeDriver(c *gin.Context) {
 		return
 	}
 
-	resp, err := h.client.UserClient.BeDriver(c.Request.Context(), req.ToProto())
+	userID, ok := subjectID(c)
+	if !ok {
+		return
+	}
+
+	resp, err := h.client.UserClient.BeDriver(c.Request.Context(), req.ToProto(userID))
 	if err != nil {
-		logger.Error("BeDriver failed", zap.String("user_id", req.UserId), zap.Error(err))
-		c.JSON(http.StatusInternalServerError, dto.ErrorResponseFromGRPCError(err))
+		logger.Error("BeDriver failed", zap.String("user_id", userID), zap.Error(err))
+		writeGRPCError(c, err)
 		return
 	}
 
 	c.JSON(200, dto.BeDriverResponseFromProto(resp))
 }
 
+func (h *UserHandler) GetDriverProfile(c *gin.Context) {
+	userID := c.GetString("user_id")
+
+	resp, err := h.client.UserClient.GetDriverProfile(c.Request.Context(), &userpb.GetDriverProfileRequest{UserId: userID})
+	if err != nil {
+		logger.Error("GetDriverProfile failed", zap.String("user_id", userID), zap.Error(err))
+		writeGRPCError(c, err)
+		return
+	}
+
+	c.JSON(200, dto.DriverProfileFromProto(resp))
+}
+
+func (h *UserHandler) UpdateVehicle(c *gin.Context) {
+	var req dto.VehicleDTO
+
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, errs.NewErrorResponse(errs.MsgInvalidRequest))
+		return
+	}
+
+	userID := c.GetString("user_id")
+	resp, err := h.client.UserClient.UpdateVehicle(c.Request.Context(), &userpb.UpdateVehicleRequest{
+		UserId:  userID,
+		Vehicle: req.ToProto(),
+	})
+	if err != nil {
+		logger.Error("UpdateVehicle failed", zap.String("user_id", userID), zap.Error(err))
+		writeGRPCError(c, err)
+		return
+	}
+
+	c.JSON(200, dto.DriverProfileFromProto(resp))
+}
+
+func (h *UserHandler) GoOnline(c *gin.Context) {
+	var req dto.DriverLocationRequestDTO
+
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, errs.NewErrorResponse(errs.MsgInvalidRequest))
+		return
+	}
+
+	userID := c.GetString("user_id")
+	resp, err := h.client.UserClient.GoOnline(c.Request.Context(), req.ToGoOnlineProto(userID))
+	if err != nil {
+		logger.Error("GoOnline failed", zap.String("user_id", userID), zap.Error(err))
+		writeGRPCError(c, err)
+		return
+	}
+
+	c.JSON(200, gin.H{"message": resp.Message})
+}
+
+func (h *UserHandler) GoOffline(c *gin.Context) {
+	userID := c.GetString("user_id")
+
+	resp, err := h.client.UserClient.GoOffline(c.Request.Context(), &userpb.GoOfflineRequest{UserId: userID})
+	if err != nil {
+		logger.Error("GoOffline failed", zap.String("user_id", userID), zap.Error(err))
+		writeGRPCError(c, err)
+		return
+	}
+
+	c.JSON(200, gin.H{"message": resp.Message})
+}
+
+func (h *UserHandler) UpdateDriverLocation(c *gin.Context) {
+	var req dto.DriverLocationRequestDTO
+
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, errs.NewErrorResponse(errs.MsgInvalidRequest))
+		return
+	}
+
+	userID := c.GetString("user_id")
+	resp, err := h.client.UserClient.UpdateDriverLocation(c.Request.Context(), req.ToProto(userID))
+	if err != nil {
+		// Not logged, drivers send their location every few seconds.
+		writeGRPCError(c, err)
+		return
+	}
+
+	c.JSON(200, gin.H{"message": resp.Message})
+}
+
+// GetUser returns the signed-in user, or the user named by an admin.
 func (h *UserHandler) GetUser(c *gin.Context) {
-	userId := c.Query("user_id")
-	if userId == "" {
-		c.JSON(http.StatusBadRequest, errs.NewErrorResponse("user_id is required"))
+	userId, ok := subjectID(c)
+	if !ok {
 		return
 	}
 
@@ -85,7 +177,7 @@ func (h *UserHandler) GetUser(c *gin.Context) {
 	resp, err := h.client.UserClient.GetUser(c.Request.Context(), req.ToProto())
 	if err != nil {
 		logger.Error("Failed to get user", zap.String("user_id", req.UserId), zap.Error(err))
-		c.JSON(http.StatusInternalServerError, dto.ErrorResponseFromGRPCError(err))
+		writeGRPCError(c, err)
 		return
 	}
 
@@ -93,10 +185,33 @@ func (h *UserHandler) GetUser(c *gin.Context) {
 	c.JSON(200, dto.GetUserResponseFromProto(resp))
 }
 
+// UpdateProfile updates the signed-in user's profile.
+func (h *UserHandler) UpdateProfile(c *gin.Context) {
+	var req dto.UpdateProfileRequestDTO
+
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, errs.NewErrorResponse(errs.MsgInvalidRequest))
+		return
+	}
+
+	userID, ok := subjectID(c)
+	if !ok {
+		return
+	}
+
+	resp, err := h.client.UserClient.UpdateProfile(c.Request.Context(), req.ToProto(userID))
+	if err != nil {
+		logger.Error("Failed to update profile", zap.String("user_id", userID), zap.Error(err))
+		writeGRPCError(c, err)
+		return
+	}
+
+	c.JSON(200, dto.GetUserResponseFromProto(resp))
+}
+
 func (h *UserHandler) GetPhoneNumber(c *gin.Context) {
-	userId := c.Query("user_id")
-	if userId == "" {
-		c.JSON(http.StatusBadRequest, errs.NewErrorResponse("user_id is required"))
+	userId, ok := subjectID(c)
+	if !ok {
 		return
 	}
 
@@ -105,7 +220,7 @@ func (h *UserHandler) GetPhoneNumber(c *gin.Context) {
 	resp, err := h.client.UserClient.GetPhoneNumber(c.Request.Context(), req.ToProto())
 	if err != nil {
 		logger.Error("Failed to get phone number", zap.String("user_id", req.UserId), zap.Error(err))
-		c.JSON(http.StatusInternalServerError, dto.ErrorResponseFromGRPCError(err))
+		writeGRPCError(c, err)
 		return
 	}
 
@@ -120,10 +235,15 @@ func (h *UserHandler) AddPhoneNumber(c *gin.Context) {
 		return
 	}
 
-	resp, err := h.client.UserClient.AddPhoneNumber(c.Request.Context(), req.ToProto())
+	userID, ok := subjectID(c)
+	if !ok {
+		return
+	}
+
+	resp, err := h.client.UserClient.AddPhoneNumber(c.Request.Context(), req.ToProto(userID))
 	if err != nil {
-		logger.Error("AddPhoneNumber failed", zap.String("user_id", req.UserId), zap.Error(err))
-		c.JSON(http.StatusInternalServerError, dto.ErrorResponseFromGRPCError(err))
+		logger.Error("AddPhoneNumber failed", zap.String("user_id", userID), zap.Error(err))
+		writeGRPCError(c, err)
 		return
 	}
 
@@ -138,10 +258,15 @@ func (h *UserHandler) UpdatePhoneNumber(c *gin.Context) {
 		return
 	}
 
-	resp, err := h.client.UserClient.UpdatePhoneNumber(c.Request.Context(), req.ToProto())
+	userID, ok := subjectID(c)
+	if !ok {
+		return
+	}
+
+	resp, err := h.client.UserClient.UpdatePhoneNumber(c.Request.Context(), req.ToProto(userID))
 	if err != nil {
-		logger.Error("UpdatePhoneNumber failed", zap.String("user_id", req.UserId), zap.Error(err))
-		c.JSON(http.StatusInternalServerError, dto.ErrorResponseFromGRPCError(err))
+		logger.Error("UpdatePhoneNumber failed", zap.String("user_id", userID), zap.Error(err))
+		writeGRPCError(c, err)
 		return
 	}
 
@@ -149,17 +274,17 @@ func (h *UserHandler) UpdatePhoneNumber(c *gin.Context) {
 }
 
 func (h *UserHandler) RemovePhoneNumber(c *gin.Context) {
-	var req dto.RemovePhoneNumberRequestDTO
-
-	if err := c.ShouldBindJSON(&req); err != nil {
-		c.JSON(http.StatusBadRequest, errs.NewErrorResponse(errs.MsgInvalidRequest))
+	userID, ok := subjectID(c)
+	if !ok {
 		return
 	}
 
+	req := &dto.RemovePhoneNumberRequestDTO{UserId: userID}
+
 	resp, err := h.client.UserClient.RemovePhoneNumber(c.Request.Context(), req.ToProto())
 	if err != nil {
-		logger.Error("RemovePhoneNumber failed", zap.String("user_id", req.UserId), zap.Error(err))
-		c.JSON(http.StatusInternalServerError, dto.ErrorResponseFromGRPCError(err))
+		logger.Error("RemovePhoneNumber failed", zap.String("user_id", userID), zap.Error(err))
+		writeGRPCError(c, err)
 		return
 	}
 
@@ -167,9 +292,8 @@ func (h *UserHandler) RemovePhoneNumber(c *gin.Context) {
 }
 
 func (h *UserHandler) GetAddresses(c *gin.Context) {
-	userId := c.Query("user_id")
-	if userId == "" {
-		c.JSON(http.StatusBadRequest, errs.NewErrorResponse("user_id is required"))
+	userId, ok := subjectID(c)
+	if !ok {
 		return
 	}
 
@@ -178,7 +302,7 @@ func (h *UserHandler) GetAddresses(c *gin.Context) {
 	resp, err := h.client.UserClient.GetAddresses(c.Request.Context(), req.ToProto())
 	if err != nil {
 		logger.Error("GetAddresses failed", zap.String("user_id", userId), zap.Error(err))
-		c.JSON(http.StatusInternalServerError, dto.ErrorResponseFromGRPCError(err))
+		writeGRPCError(c, err)
 		return
 	}
 
@@ -193,16 +317,67 @@ func (h *UserHandler) AddAddress(c *gin.Context) {
 		return
 	}
 
-	resp, err := h.client.UserClient.AddAddress(c.Request.Context(), req.ToProto())
+	userID, ok := subjectID(c)
+	if !ok {
+		return
+	}
+
+	resp, err := h.client.UserClient.AddAddress(c.Request.Context(), req.ToProto(userID))
+	if err != nil {
+		logger.Error("AddAddress failed", zap.String("user_id", userID), zap.Error(err))
+		writeGRPCError(c, err)
+		return
+	}
+
+	c.JSON(200, dto.AddAddressResponseFromProto(resp))
+}
+
+func (h *UserHandler) UpdateAddress(c *gin.Context) {
+	var req dto.UpdateAddressRequestDTO
+
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, errs.NewErrorResponse(errs.MsgInvalidRequest))
+		return
+	}
+
+	userID, ok := subjectID(c)
+	if !ok {
+		return
+	}
+
+	resp, err := h.client.UserClient.UpdateAddress(c.Request.Context(), req.ToProto(userID))
 	if err != nil {
-		logger.Error("AddAddress failed", zap.String("user_id", req.UserId), zap.Error(err))
-		c.JSON(http.StatusInternalServerError, dto.ErrorResponseFromGRPCError(err))
+		logger.Error("UpdateAddress failed", zap.String("address_id", req.AddressId), zap.Error(err))
+		writeGRPCError(c, err)
 		return
 	}
 
 	c.JSON(200, dto.AddAddressResponseFromProto(resp))
 }
 
+func (h *UserHandler) SetDefaultAddress(c *gin.Context) {
+	var req dto.SetDefaultAddressRequestDTO
+
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, errs.NewErrorResponse(errs.MsgInvalidRequest))
+		return
+	}
+
+	userID, ok := subjectID(c)
+	if !ok {
+		return
+	}
+
+	resp, err := h.client.UserClient.SetDefaultAddress(c.Request.Context(), req.ToProto(userID))
+	if err != nil {
+		logger.Error("SetDefaultAddress failed", zap.String("address_id", req.AddressId), zap.Error(err))
+		writeGRPCError(c, err)
+		return
+	}
+
+	c.JSON(200, gin.H{"message": resp.Message})
+}
+
 func (h *UserHandler) RemoveAddress(c *gin.Context) {
 	var req dto.RemoveAddressRequestDTO
 
@@ -211,10 +386,15 @@ func (h *UserHandler) RemoveAddress(c *gin.Context) {
 		return
 	}
 
-	resp, err := h.client.UserClient.RemoveAddress(c.Request.Context(), req.ToProto())
+	userID, ok := subjectID(c)
+	if !ok {
+		return
+	}
+
+	resp, err := h.client.UserClient.RemoveAddress(c.Request.Context(), req.ToProto(userID))
 	if err != nil {
 		logger.Error("RemoveAddress failed", zap.String("address_id", req.AddressId), zap.Error(err))
-		c.JSON(http.StatusInternalServerError, dto.ErrorResponseFromGRPCError(err))
+		writeGRPCError(c, err)
 		return
 	}
 
@@ -3,24 +3,26 @@ package client
 import (
 	"fmt"
 
+	"github.com/tamirat-dejene/ha-soranu/shared/pkg/auth/svcauth"
 	"github.com/tamirat-dejene/ha-soranu/shared/pkg/logger"
+	"github.com/tamirat-dejene/ha-soranu/shared/protos/adminpb"
 	"github.com/tamirat-dejene/ha-soranu/shared/protos/authpb"
 	"github.com/tamirat-dejene/ha-soranu/shared/protos/userpb"
 	"go.uber.org/zap"
 	"google.golang.org/grpc"
-	"google.golang.org/grpc/credentials/insecure"
 )
 
 // User and Auth Service Client
 type UAServiceClient struct {
-	AuthClient authpb.AuthServiceClient
-	UserClient userpb.UserServiceClient
-	conn       *grpc.ClientConn
+	AuthClient  authpb.AuthServiceClient
+	UserClient  userpb.UserServiceClient
+	AdminClient adminpb.UserAdminServiceClient
+	conn        *grpc.ClientConn
 }
 
 // New User and Auth Service Client
-func NewUAServiceClient(addr string) (*UAServiceClient, error) {
-	conn, err := grpc.NewClient(addr, grpc.WithTransportCredentials(insecure.NewCredentials()))
+func NewUAServiceClient(addr string, signer *svcauth.Signer) (*UAServiceClient, error) {
+	conn, err := grpc.NewClient(addr, dialOptions(signer)...)
 	if err != nil {
 		return nil, fmt.Errorf("failed to connect to auth service: %w", err)
 	}
@@ -29,8 +31,9 @@ func NewUAServiceClient(addr string) (*UAServiceClient, error) {
 	user_client := userpb.NewUserServiceClient(conn)
 
 	return &UAServiceClient{
-		AuthClient: auth_client,
-		UserClient: user_client,
+		AuthClient:  auth_client,
+		UserClient:  user_client,
+		AdminClient: adminpb.NewUserAdminServiceClient(conn),
 	}, nil
 }
 
@@ -0,0 +1,16 @@
+package client
+
+import (
+	"github.com/tamirat-dejene/ha-soranu/shared/pkg/auth/svcauth"
+	"github.com/tamirat-dejene/ha-soranu/shared/pkg/correlation"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/credentials/insecure"
+)
+
+// dialOptions returns the options of the connections to the services. Calls
+// are signed by signer, forwarding the user the request is made for and the
+// request's correlation ID.
+func dialOptions(signer *svcauth.Signer) []grpc.DialOption {
+	opts := append([]grpc.DialOption{grpc.WithTransportCredentials(insecure.NewCredentials())}, signer.DialOptions()...)
+	return append(opts, correlation.DialOptions()...)
+}
@@ -3,11 +3,11 @@ package client
 import (
 	"context"
 
+	"github.com/tamirat-dejene/ha-soranu/shared/pkg/auth/svcauth"
 	"github.com/tamirat-dejene/ha-soranu/shared/pkg/logger"
 	notificationpb "github.com/tamirat-dejene/ha-soranu/shared/protos/notificationpb"
 	"go.uber.org/zap"
 	"google.golang.org/grpc"
-	"google.golang.org/grpc/credentials/insecure"
 )
 
 type NotificationServiceClient struct {
@@ -15,8 +15,8 @@ type NotificationServiceClient struct {
 	NotificationClient notificationpb.NotificationServiceClient
 }
 
-func NewNotificationServiceClient(notificationServiceURL string) (*NotificationServiceClient, error) {
-	conn, err := grpc.NewClient(notificationServiceURL, grpc.WithTransportCredentials(insecure.NewCredentials()))
+func NewNotificationServiceClient(notificationServiceURL string, signer *svcauth.Signer) (*NotificationServiceClient, error) {
+	conn, err := grpc.NewClient(notificationServiceURL, dialOptions(signer)...)
 	if err != nil {
 		logger.Error("Failed to connect to Notification Service", zap.Error(err))
 		return nil, err
@@ -1,20 +1,22 @@
 package client
 
 import (
+	"github.com/tamirat-dejene/ha-soranu/shared/pkg/auth/svcauth"
 	"github.com/tamirat-dejene/ha-soranu/shared/pkg/logger"
+	"github.com/tamirat-dejene/ha-soranu/shared/protos/adminpb"
 	"github.com/tamirat-dejene/ha-soranu/shared/protos/restaurantpb"
 	"go.uber.org/zap"
 	"google.golang.org/grpc"
-	"google.golang.org/grpc/credentials/insecure"
 )
 
 type RestaurantServiceClient struct {
 	RestaurantClient restaurantpb.RestaurantServiceClient
+	AdminClient      adminpb.RestaurantAdminServiceClient
 	conn             *grpc.ClientConn
 }
 
-func NewRestaurantServiceClient(addr string) (*RestaurantServiceClient, error) {
-	conn, err := grpc.NewClient(addr, grpc.WithTransportCredentials(insecure.NewCredentials()))
+func NewRestaurantServiceClient(addr string, signer *svcauth.Signer) (*RestaurantServiceClient, error) {
+	conn, err := grpc.NewClient(addr, dialOptions(signer)...)
 	if err != nil {
 		logger.Error("failed to connect to gRPC server", zap.Error(err))
 		return nil, err
@@ -23,6 +25,7 @@ func NewRestaurantServiceClient(addr string) (*RestaurantServiceClient, error) {
 	client := restaurantpb.NewRestaurantServiceClient(conn)
 	return &RestaurantServiceClient{
 		RestaurantClient: client,
+		AdminClient:      adminpb.NewRestaurantAdminServiceClient(conn),
 		conn:             conn,
 	}, nil
 }
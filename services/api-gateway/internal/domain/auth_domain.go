@@ -13,6 +13,8 @@ type User struct {
 	Password    string `json:"password"` 
 	Addresses   []Address `json:"addresses"`
 	CreatedAt   time.Time `json:"created_at"`
+	EmailVerified bool    `json:"email_verified"`
+	DefaultAddress *Address `json:"default_address,omitempty"`
 }
 
 // Address represents a user's address.
@@ -26,5 +28,7 @@ type Address struct {
 	Country    string `json:"country"`
 	Latitude   float32 `json:"latitude"`
 	Longitude  float32 `json:"longitude"`
+	Label      string `json:"label,omitempty"`
+	IsDefault  bool   `json:"is_default"`
 	CreatedAt  time.Time `json:"created_at"`
 }
\ No newline at end of file
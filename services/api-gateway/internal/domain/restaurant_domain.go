@@ -14,6 +14,7 @@ type Restaurant struct {
 	Latitude     float32    `json:"latitude"`
 	Longitude    float32    `json:"longitude"`
 	Menus        []MenuItem `json:"menus"`
+	Status       string     `json:"status"`
 }
 
 type Order struct {
@@ -13,6 +13,7 @@ const (
 	MsgSessionNotFound        = "Session not found. Please login again."
 	MsgAddressNotFound        = "Address not found."
 	MsgRefreshFailed          = "Failed to refresh token. Please login again."
+	MsgTooManyRequests        = "Too many requests. Please try again later."
 )
 
 type ErrorResponse struct {
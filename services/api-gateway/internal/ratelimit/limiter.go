@@ -0,0 +1,188 @@
+package ratelimit
+
+import (
+	"context"
+	"fmt"
+	"math"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/tamirat-dejene/ha-soranu/shared/pkg/caching"
+)
+
+// DefaultGroup names the rule of the route groups that have none of their own.
+const DefaultGroup = "default"
+
+// Rule allows Limit requests per Window.
+type Rule struct {
+	Limit  int64
+	Window time.Duration
+}
+
+// Result is what the limiter decided about a request.
+type Result struct {
+	Allowed   bool
+	Limit     int64
+	Remaining int64
+	// Reset is how long until the current window ends.
+	Reset time.Duration
+	// RetryAfter is how long a rejected client should wait before the
+	// request would be allowed.
+	RetryAfter time.Duration
+}
+
+// ParseRules reads rules written as "group=limit/window" separated by
+// commas, e.g. "default=300/1m,auth=30/1m". A limit of 0 or "off" turns
+// limiting off for the group.
+func ParseRules(spec string) (map[string]Rule, error) {
+	rules := make(map[string]Rule)
+	for _, entry := range strings.Split(spec, ",") {
+		entry = strings.TrimSpace(entry)
+		if entry == "" {
+			continue
+		}
+
+		group, value, ok := strings.Cut(entry, "=")
+		group, value = strings.TrimSpace(group), strings.TrimSpace(value)
+		if !ok || group == "" {
+			return nil, fmt.Errorf("invalid rate limit %q: expected group=limit/window", entry)
+		}
+		if value == "off" || value == "0" {
+			rules[group] = Rule{}
+			continue
+		}
+
+		limitStr, windowStr, ok := strings.Cut(value, "/")
+		if !ok {
+			return nil, fmt.Errorf("invalid rate limit %q: expected group=limit/window", entry)
+		}
+		limit, err := strconv.ParseInt(strings.TrimSpace(limitStr), 10, 64)
+		if err != nil || limit < 0 {
+			return nil, fmt.Errorf("invalid rate limit %q: limit must be a non-negative integer", entry)
+		}
+		window, err := time.ParseDuration(strings.TrimSpace(windowStr))
+		if err != nil || window < time.Second {
+			return nil, fmt.Errorf("invalid rate limit %q: window must be a duration of at least 1s", entry)
+		}
+		rules[group] = Rule{Limit: limit, Window: window}
+	}
+
+	return rules, nil
+}
+
+// Limiter counts requests in a cache shared by every gateway instance, so
+// that a client is limited the same whichever instance it reaches.
+//
+// It uses a sliding window counter: requests are counted per fixed window,
+// and the count of the previous window is weighted by how much of it the
+// sliding window still covers.
+type Limiter struct {
+	client  caching.CacheClient
+	rules   map[string]Rule
+	apiKeys map[string]bool
+}
+
+// NewLimiter creates a Limiter enforcing rules per route group. Requests
+// carrying one of apiKeys are counted per key rather than per client IP.
+func NewLimiter(client caching.CacheClient, rules map[string]Rule, apiKeys []string) *Limiter {
+	keys := make(map[string]bool, len(apiKeys))
+	for _, key := range apiKeys {
+		if key = strings.TrimSpace(key); key != "" {
+			keys[key] = true
+		}
+	}
+
+	return &Limiter{client: client, rules: rules, apiKeys: keys}
+}
+
+// Rule returns the rule of the group, falling back to the default one. The
+// group is not limited when ok is false.
+func (l *Limiter) Rule(group string) (Rule, bool) {
+	rule, found := l.rules[group]
+	if !found {
+		rule, found = l.rules[DefaultGroup]
+	}
+	return rule, found && rule.Limit > 0
+}
+
+// KnownAPIKey reports whether key is one of the configured API keys.
+func (l *Limiter) KnownAPIKey(key string) bool {
+	return l.apiKeys[key]
+}
+
+func getRateLimitKey(group, client string, windowStart time.Time) string {
+	return fmt.Sprintf("rate_limit:%s:%s:%d", group, client, windowStart.Unix())
+}
+
+// Allow counts a request of the client to the group and tells whether it is
+// within the rule.
+func (l *Limiter) Allow(ctx context.Context, group, client string, rule Rule) (Result, error) {
+	now := time.Now()
+	windowStart := now.Truncate(rule.Window)
+	elapsed := now.Sub(windowStart)
+	currentKey := getRateLimitKey(group, client, windowStart)
+
+	current, err := l.client.Increment(ctx, currentKey)
+	if err != nil {
+		return Result{}, err
+	}
+	// The count is still read as the previous window during the next one.
+	if current == 1 {
+		if err := l.client.Expire(ctx, currentKey, 2*rule.Window); err != nil {
+			return Result{}, err
+		}
+	}
+
+	// A missing key fails to read as well, and counts as no requests.
+	var previous int64
+	if data, err := l.client.Get(ctx, getRateLimitKey(group, client, windowStart.Add(-rule.Window))); err == nil {
+		previous, _ = strconv.ParseInt(data, 10, 64)
+	}
+
+	weight := float64(rule.Window-elapsed) / float64(rule.Window)
+	estimate := float64(previous)*weight + float64(current)
+
+	result := Result{
+		Allowed: estimate <= float64(rule.Limit),
+		Limit:   rule.Limit,
+		Reset:   rule.Window - elapsed,
+	}
+	if result.Allowed {
+		result.Remaining = int64(math.Floor(float64(rule.Limit) - estimate))
+		return result, nil
+	}
+
+	// Rejected requests are not counted, so that a client that keeps
+	// retrying is let in once the window has slid far enough.
+	if _, err := l.client.Decrement(ctx, currentKey); err != nil {
+		return Result{}, err
+	}
+	result.RetryAfter = retryAfter(rule, previous, current-1, elapsed)
+
+	return result, nil
+}
+
+// retryAfter returns how long until one more request fits in the sliding
+// window, given the requests allowed in the previous and current windows.
+func retryAfter(rule Rule, previous, current int64, elapsed time.Duration) time.Duration {
+	window := float64(rule.Window)
+	limit := float64(rule.Limit)
+
+	var wait float64
+	if float64(current)+1 <= limit && previous > 0 {
+		// The previous window has to weigh less: wait until
+		// previous*(window-at)/window + current + 1 <= limit.
+		at := window * (1 - (limit-float64(current)-1)/float64(previous))
+		wait = at - float64(elapsed)
+	} else {
+		// The current window is full: wait for it to end and to weigh
+		// little enough as the previous one.
+		wait = window - float64(elapsed)
+		if current > 0 {
+			wait += max(window*(1-(limit-1)/float64(current)), 0)
+		}
+	}
+
+	return max(time.Duration(wait), time.Second)
+}
@@ -0,0 +1,201 @@
+package ratelimit
+
+import (
+	"context"
+	"errors"
+	"reflect"
+	"strconv"
+	"testing"
+	"time"
+
+	"github.com/tamirat-dejene/ha-soranu/shared/pkg/caching"
+)
+
+// memoryCache keeps the counters of the limiter in memory. Only the commands
+// the limiter uses are implemented.
+type memoryCache struct {
+	caching.CacheClient
+	values  map[string]int64
+	expires map[string]time.Duration
+	err     error
+}
+
+func newMemoryCache() *memoryCache {
+	return &memoryCache{values: make(map[string]int64), expires: make(map[string]time.Duration)}
+}
+
+func (m *memoryCache) Increment(_ context.Context, key string) (int64, error) {
+	if m.err != nil {
+		return 0, m.err
+	}
+	m.values[key]++
+	return m.values[key], nil
+}
+
+func (m *memoryCache) Decrement(_ context.Context, key string) (int64, error) {
+	m.values[key]--
+	return m.values[key], nil
+}
+
+func (m *memoryCache) Expire(_ context.Context, key string, expiration time.Duration) error {
+	m.expires[key] = expiration
+	return nil
+}
+
+func (m *memoryCache) Get(_ context.Context, key string) (string, error) {
+	value, ok := m.values[key]
+	if !ok {
+		return "", errors.New("key does not exist")
+	}
+	return strconv.FormatInt(value, 10), nil
+}
+
+func TestParseRules(t *testing.T) {
+	tests := []struct {
+		name    string
+		spec    string
+		want    map[string]Rule
+		wantErr bool
+	}{
+		{name: "empty", spec: "", want: map[string]Rule{}},
+		{
+			name: "several groups",
+			spec: "default=300/1m, auth = 30/1m ,payments=5/10s",
+			want: map[string]Rule{
+				"default":  {Limit: 300, Window: time.Minute},
+				"auth":     {Limit: 30, Window: time.Minute},
+				"payments": {Limit: 5, Window: 10 * time.Second},
+			},
+		},
+		{name: "off", spec: "auth=off,default=0", want: map[string]Rule{"auth": {}, "default": {}}},
+		{name: "trailing comma", spec: "default=10/1h,", want: map[string]Rule{"default": {Limit: 10, Window: time.Hour}}},
+		{name: "missing limit", spec: "default", wantErr: true},
+		{name: "missing group", spec: "=10/1m", wantErr: true},
+		{name: "missing window", spec: "default=10", wantErr: true},
+		{name: "negative limit", spec: "default=-1/1m", wantErr: true},
+		{name: "limit is not a number", spec: "default=ten/1m", wantErr: true},
+		{name: "window is not a duration", spec: "default=10/minute", wantErr: true},
+		{name: "window under a second", spec: "default=10/500ms", wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := ParseRules(tt.spec)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("ParseRules(%q) error = %v, wantErr %v", tt.spec, err, tt.wantErr)
+			}
+			if !tt.wantErr && !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("ParseRules(%q) = %v, want %v", tt.spec, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestLimiterRule(t *testing.T) {
+	limiter := NewLimiter(nil, map[string]Rule{
+		DefaultGroup: {Limit: 100, Window: time.Minute},
+		"auth":       {Limit: 10, Window: time.Minute},
+		"public":     {},
+	}, []string{" partner-key ", ""})
+
+	tests := []struct {
+		group  string
+		want   Rule
+		wantOK bool
+	}{
+		{group: "auth", want: Rule{Limit: 10, Window: time.Minute}, wantOK: true},
+		{group: "orders", want: Rule{Limit: 100, Window: time.Minute}, wantOK: true},
+		{group: "public", wantOK: false},
+	}
+	for _, tt := range tests {
+		got, ok := limiter.Rule(tt.group)
+		if ok != tt.wantOK || (ok && got != tt.want) {
+			t.Errorf("Rule(%q) = (%v, %v), want (%v, %v)", tt.group, got, ok, tt.want, tt.wantOK)
+		}
+	}
+
+	if !limiter.KnownAPIKey("partner-key") || limiter.KnownAPIKey("") || limiter.KnownAPIKey("other") {
+		t.Errorf("KnownAPIKey() does not match exactly the trimmed, non-empty keys")
+	}
+}
+
+func TestLimiterAllow(t *testing.T) {
+	// A day-long window keeps the previous window empty during the test.
+	rule := Rule{Limit: 3, Window: 24 * time.Hour}
+	cache := newMemoryCache()
+	limiter := NewLimiter(cache, map[string]Rule{DefaultGroup: rule}, nil)
+	ctx := context.Background()
+
+	for i := int64(1); i <= rule.Limit; i++ {
+		result, err := limiter.Allow(ctx, "auth", "10.0.0.1", rule)
+		if err != nil {
+			t.Fatalf("Allow() error = %v", err)
+		}
+		if !result.Allowed || result.Remaining != rule.Limit-i || result.Limit != rule.Limit {
+			t.Fatalf("request %d: Allow() = %+v, want allowed with %d remaining", i, result, rule.Limit-i)
+		}
+		if result.Reset <= 0 || result.Reset > rule.Window {
+			t.Errorf("request %d: Reset = %v, want within the window", i, result.Reset)
+		}
+	}
+
+	for i := 0; i < 2; i++ {
+		result, err := limiter.Allow(ctx, "auth", "10.0.0.1", rule)
+		if err != nil {
+			t.Fatalf("Allow() error = %v", err)
+		}
+		if result.Allowed || result.RetryAfter < time.Second {
+			t.Fatalf("Allow() over the limit = %+v, want rejected with a retry delay", result)
+		}
+	}
+
+	key := getRateLimitKey("auth", "10.0.0.1", time.Now().Truncate(rule.Window))
+	if got := cache.values[key]; got != rule.Limit {
+		t.Errorf("counter = %d, want %d: rejected requests must not be counted", got, rule.Limit)
+	}
+	if got := cache.expires[key]; got != 2*rule.Window {
+		t.Errorf("counter expires after %v, want %v", got, 2*rule.Window)
+	}
+
+	// Other clients and groups have counters of their own.
+	for _, call := range []struct{ group, client string }{{"auth", "10.0.0.2"}, {"orders", "10.0.0.1"}} {
+		if result, err := limiter.Allow(ctx, call.group, call.client, rule); err != nil || !result.Allowed {
+			t.Errorf("Allow(%s, %s) = %+v, %v, want allowed", call.group, call.client, result, err)
+		}
+	}
+
+	cache.err = errors.New("connection refused")
+	if _, err := limiter.Allow(ctx, "auth", "10.0.0.3", rule); err == nil {
+		t.Errorf("Allow() with the cache down succeeded, want its error")
+	}
+}
+
+func TestRetryAfter(t *testing.T) {
+	rule := Rule{Limit: 10, Window: time.Minute}
+
+	tests := []struct {
+		name              string
+		previous, current int64
+		elapsed           time.Duration
+		want              time.Duration
+	}{
+		// The window ends in 30s, then the 10 requests weigh 9 once 6s of
+		// the new window have passed.
+		{name: "current window full", previous: 0, current: 10, elapsed: 30 * time.Second, want: 36 * time.Second},
+		{name: "current window full with a busy previous one", previous: 10, current: 10, elapsed: 50 * time.Second, want: 16 * time.Second},
+		// 10 previous requests weigh 9 after 6s.
+		{name: "previous window full", previous: 10, current: 0, elapsed: 0, want: 6 * time.Second},
+		// 10*(24/60) + 5 + 1 = 10 at 36s.
+		{name: "both windows in use", previous: 10, current: 5, elapsed: 30 * time.Second, want: 6 * time.Second},
+		{name: "at least a second", previous: 1, current: 9, elapsed: 59*time.Second + 900*time.Millisecond, want: time.Second},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := retryAfter(rule, tt.previous, tt.current, tt.elapsed)
+			if diff := got - tt.want; diff < -time.Millisecond || diff > time.Millisecond {
+				t.Errorf("retryAfter(%d, %d, %v) = %v, want %v", tt.previous, tt.current, tt.elapsed, got, tt.want)
+			}
+		})
+	}
+}
@@ -1,22 +1,52 @@
 package server
 
 import (
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"math"
 	"net/http"
+	"strconv"
 	"strings"
 	"time"
 
 	"github.com/gin-gonic/gin"
-	apigateway "github.com/tamirat-dejene/ha-soranu/services/api-gateway"
+	"github.com/tamirat-dejene/ha-soranu/services/api-gateway/internal/api/dto"
+	"github.com/tamirat-dejene/ha-soranu/services/api-gateway/internal/client"
+	"github.com/tamirat-dejene/ha-soranu/services/api-gateway/internal/errs"
+	"github.com/tamirat-dejene/ha-soranu/services/api-gateway/internal/ratelimit"
 	jwtvalidator "github.com/tamirat-dejene/ha-soranu/shared/pkg/auth/jwtvalidator"
+	"github.com/tamirat-dejene/ha-soranu/shared/pkg/auth/svcauth"
+	"github.com/tamirat-dejene/ha-soranu/shared/pkg/correlation"
 	"github.com/tamirat-dejene/ha-soranu/shared/pkg/logger"
+	"github.com/tamirat-dejene/ha-soranu/shared/protos/restaurantpb"
 	"go.uber.org/zap"
 )
 
+// RequestID gives every request a correlation ID, taken from the X-Request-ID
+// header when the client sent a valid one. The ID is returned in the same
+// header, logged by GinLogger and forwarded to the services the request
+// calls. It must run before GinLogger.
+func RequestID() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		id := c.GetHeader(correlation.HTTPHeader)
+		if !correlation.Valid(id) {
+			id = correlation.NewID()
+		}
+
+		c.Header(correlation.HTTPHeader, id)
+		c.Request = c.Request.WithContext(correlation.NewContext(c.Request.Context(), id))
+
+		c.Next()
+	}
+}
+
 func GinLogger() gin.HandlerFunc {
 	return func(c *gin.Context) {
 		start := time.Now()
 		path := c.Request.URL.Path
 		query := c.Request.URL.RawQuery
+		log := logger.FromContext(c.Request.Context())
 
 		c.Next()
 
@@ -25,10 +55,10 @@ func GinLogger() gin.HandlerFunc {
 
 		if len(c.Errors) > 0 {
 			for _, e := range c.Errors.Errors() {
-				logger.Error(e)
+				log.Error(e)
 			}
 		} else {
-			logger.Info(path,
+			log.Info(path,
 				zap.Int("status", c.Writer.Status()),
 				zap.String("method", c.Request.Method),
 				zap.String("path", path),
@@ -41,8 +71,8 @@ func GinLogger() gin.HandlerFunc {
 	}
 }
 
-// AuthMiddleware verifies access tokens using the shared RSA validator and injects claims into the Gin context.
-func AuthMiddleware(cfg *apigateway.Env) gin.HandlerFunc {
+// AuthMiddleware verifies access tokens against the auth service's key set and injects claims into the Gin context.
+func AuthMiddleware(verifier *jwtvalidator.Verifier) gin.HandlerFunc {
 	return func(c *gin.Context) {
 		authHeader := c.GetHeader("Authorization")
 		if authHeader == "" {
@@ -56,21 +86,179 @@ func AuthMiddleware(cfg *apigateway.Env) gin.HandlerFunc {
 			return
 		}
 
-		if cfg.ACCESS_TOKEN_PUBLIC_KEY == "" {
-			c.AbortWithStatusJSON(http.StatusUnauthorized, gin.H{"error": "token verifier not configured"})
-			return
-		}
-
-		claims, err := jwtvalidator.ValidateAccessToken(cfg.ACCESS_TOKEN_PUBLIC_KEY, parts[1])
+		claims, err := verifier.ValidateAccessToken(c.Request.Context(), parts[1])
 		if err != nil {
 			c.AbortWithStatusJSON(http.StatusUnauthorized, gin.H{"error": "invalid or expired token"})
 			return
 		}
 
 		// Make claims available to downstream handlers.
+		c.Set("user_id", claims.Subject)
 		c.Set("user_email", claims.UserEmail)
+		c.Set("email_verified", claims.EmailVerified)
+		c.Set("roles", claims.Roles)
+		c.Set("session_id", claims.SessionID)
 		c.Set("claims", claims)
 
+		// Forward the user to the services the request calls.
+		c.Request = c.Request.WithContext(svcauth.WithUser(c.Request.Context(), svcauth.User{
+			ID:        claims.Subject,
+			Roles:     claims.Roles,
+			SessionID: claims.SessionID,
+		}))
+
+		c.Next()
+	}
+}
+
+// RequireRole lets the request through only when the access token grants at
+// least one of the given roles. Admins pass every role check. It must run
+// after AuthMiddleware.
+func RequireRole(roles ...string) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		value, ok := c.Get("claims")
+		claims, _ := value.(*jwtvalidator.AccessClaims)
+		if !ok || claims == nil {
+			c.AbortWithStatusJSON(http.StatusUnauthorized, gin.H{"error": "missing access token"})
+			return
+		}
+
+		if claims.HasRole(jwtvalidator.RoleAdmin) {
+			c.Next()
+			return
+		}
+
+		for _, role := range roles {
+			if claims.HasRole(role) {
+				c.Next()
+				return
+			}
+		}
+
+		c.AbortWithStatusJSON(http.StatusForbidden, gin.H{"error": "insufficient role"})
+	}
+}
+
+// RequireVerifiedEmail rejects requests whose access token was issued before
+// the user verified their email. Users refresh their tokens after verifying.
+// It must run after AuthMiddleware.
+func RequireVerifiedEmail() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		if !c.GetBool("email_verified") {
+			c.AbortWithStatusJSON(http.StatusForbidden, gin.H{"error": "email address is not verified"})
+			return
+		}
+
+		c.Next()
+	}
+}
+
+// RequireSubject lets the request through only when the path parameter param
+// names the signed-in user. Admins may name anyone. It must run after
+// AuthMiddleware.
+func RequireSubject(param string) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		value, _ := c.Get("claims")
+		claims, _ := value.(*jwtvalidator.AccessClaims)
+		if claims == nil {
+			c.AbortWithStatusJSON(http.StatusUnauthorized, gin.H{"error": "missing access token"})
+			return
+		}
+
+		if c.Param(param) != claims.Subject && !claims.HasRole(jwtvalidator.RoleAdmin) {
+			c.AbortWithStatusJSON(http.StatusForbidden, gin.H{"error": "not allowed to act for this user"})
+			return
+		}
+
+		c.Next()
+	}
+}
+
+// RequireRestaurantOwner lets the request through only when the signed-in
+// user owns the restaurant named by the restaurant_id path parameter. Admins
+// own every restaurant. It guards the routes whose service cannot refuse the
+// request itself. It must run after AuthMiddleware.
+func RequireRestaurantOwner(restaurants *client.RestaurantServiceClient) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		value, _ := c.Get("claims")
+		claims, _ := value.(*jwtvalidator.AccessClaims)
+		if claims == nil {
+			c.AbortWithStatusJSON(http.StatusUnauthorized, gin.H{"error": "missing access token"})
+			return
+		}
+
+		if claims.HasRole(jwtvalidator.RoleAdmin) {
+			c.Next()
+			return
+		}
+
+		restaurant, err := restaurants.RestaurantClient.GetRestaurant(c.Request.Context(), &restaurantpb.GetRestaurantRequest{
+			RestaurantId: c.Param("restaurant_id"),
+		})
+		if err != nil {
+			// Unknown restaurants are 404 Not Found, like on the routes the
+			// service guards itself.
+			logger.FromContext(c.Request.Context()).Error("Failed to look up restaurant owner", zap.String("restaurant_id", c.Param("restaurant_id")), zap.Error(err))
+			c.AbortWithStatusJSON(dto.HTTPStatusFromGRPCError(err), dto.ErrorResponseFromGRPCError(err))
+			return
+		}
+
+		if restaurant.OwnerId == "" || restaurant.OwnerId != claims.Subject {
+			c.AbortWithStatusJSON(http.StatusForbidden, gin.H{"error": "not the owner of this restaurant"})
+			return
+		}
+
 		c.Next()
 	}
 }
+
+// RateLimit rejects the requests of a client beyond the rule of the route
+// group with 429 Too Many Requests. Clients are told by the signed-in user,
+// then by a configured API key, then by IP, so it should run after
+// AuthMiddleware on authenticated groups. Requests are let through when the
+// limiter is nil, the group is not limited or the cache is unavailable.
+func RateLimit(limiter *ratelimit.Limiter, group string) gin.HandlerFunc {
+	if limiter == nil {
+		return func(c *gin.Context) { c.Next() }
+	}
+	rule, ok := limiter.Rule(group)
+	if !ok {
+		return func(c *gin.Context) { c.Next() }
+	}
+	policy := fmt.Sprintf("%d;w=%d", rule.Limit, int64(rule.Window.Seconds()))
+
+	return func(c *gin.Context) {
+		result, err := limiter.Allow(c.Request.Context(), group, rateLimitClient(c, limiter), rule)
+		if err != nil {
+			logger.FromContext(c.Request.Context()).Warn("Rate limiter unavailable, letting the request through", zap.String("group", group), zap.Error(err))
+			c.Next()
+			return
+		}
+
+		c.Header("RateLimit-Policy", policy)
+		c.Header("RateLimit-Limit", strconv.FormatInt(result.Limit, 10))
+		c.Header("RateLimit-Remaining", strconv.FormatInt(result.Remaining, 10))
+		c.Header("RateLimit-Reset", strconv.FormatInt(int64(math.Ceil(result.Reset.Seconds())), 10))
+
+		if !result.Allowed {
+			c.Header("Retry-After", strconv.FormatInt(int64(math.Ceil(result.RetryAfter.Seconds())), 10))
+			c.AbortWithStatusJSON(http.StatusTooManyRequests, errs.NewErrorResponse(errs.MsgTooManyRequests))
+			return
+		}
+
+		c.Next()
+	}
+}
+
+// rateLimitClient tells apart the clients counted by RateLimit. API keys are
+// hashed so that they are not stored in the cache.
+func rateLimitClient(c *gin.Context, limiter *ratelimit.Limiter) string {
+	if userID := c.GetString("user_id"); userID != "" {
+		return "user:" + userID
+	}
+	if key := c.GetHeader("X-API-Key"); key != "" && limiter.KnownAPIKey(key) {
+		sum := sha256.Sum256([]byte(key))
+		return "key:" + hex.EncodeToString(sum[:])
+	}
+	return "ip:" + c.ClientIP()
+}
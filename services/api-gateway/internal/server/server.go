@@ -1,11 +1,18 @@
 package server
 
 import (
+	"strings"
+
 	"github.com/gin-contrib/cors"
 	"github.com/gin-gonic/gin"
 	apigateway "github.com/tamirat-dejene/ha-soranu/services/api-gateway"
 	"github.com/tamirat-dejene/ha-soranu/services/api-gateway/internal/api/handler"
 	"github.com/tamirat-dejene/ha-soranu/services/api-gateway/internal/client"
+	"github.com/tamirat-dejene/ha-soranu/services/api-gateway/internal/ratelimit"
+	jwtvalidator "github.com/tamirat-dejene/ha-soranu/shared/pkg/auth/jwtvalidator"
+	"github.com/tamirat-dejene/ha-soranu/shared/pkg/correlation"
+	"github.com/tamirat-dejene/ha-soranu/shared/pkg/logger"
+	"go.uber.org/zap"
 )
 
 type Server struct {
@@ -15,17 +22,43 @@ type Server struct {
 	restaurantHandler   *handler.RestaurantHandler
 	notificationHandler *handler.NotificationHandler
 	paymentHandler      *handler.PaymentHandler
+	adminHandler        *handler.AdminHandler
+	restaurantClient    *client.RestaurantServiceClient
+	verifier            *jwtvalidator.Verifier
+	limiter             *ratelimit.Limiter
 	config              apigateway.Env
 }
 
-func NewServer(cfg *apigateway.Env, uaClient *client.UAServiceClient, restaurantClient *client.RestaurantServiceClient, notificationClient *client.NotificationServiceClient, paymentClient *client.PaymentClient) *Server {
+func NewServer(cfg *apigateway.Env, uaClient *client.UAServiceClient, restaurantClient *client.RestaurantServiceClient, notificationClient *client.NotificationServiceClient, paymentClient *client.PaymentClient, verifier *jwtvalidator.Verifier, limiter *ratelimit.Limiter) *Server {
 	router := gin.New()
+	// The client IP is taken from X-Forwarded-For only when the request came
+	// through a trusted proxy, so that clients cannot pick their rate limit or
+	// the IP the auth service counts their failed logins against. Gin trusts
+	// every proxy unless told otherwise.
+	var trustedProxies []string
+	for _, proxy := range strings.Split(cfg.TRUSTED_PROXIES, ",") {
+		if proxy = strings.TrimSpace(proxy); proxy != "" {
+			trustedProxies = append(trustedProxies, proxy)
+		}
+	}
+	if err := router.SetTrustedProxies(trustedProxies); err != nil {
+		logger.Fatal("Invalid trusted proxies", zap.Error(err))
+	}
 	router.Use(gin.Recovery())
+	router.Use(RequestID())
 	router.Use(GinLogger())
 	router.Use(cors.New(cors.Config{
 		AllowAllOrigins: true,
 		AllowMethods:    []string{"*"},
 		AllowHeaders:    []string{"*"},
+		ExposeHeaders: []string{
+			correlation.HTTPHeader,
+			"Retry-After",
+			"RateLimit-Policy",
+			"RateLimit-Limit",
+			"RateLimit-Remaining",
+			"RateLimit-Reset",
+		},
 	}))
 
 	authHandler := handler.NewAuthHandler(uaClient)
@@ -33,6 +66,7 @@ func NewServer(cfg *apigateway.Env, uaClient *client.UAServiceClient, restaurant
 	restaurantHandler := handler.NewRestaurantHandler(restaurantClient)
 	notificationHandler := handler.NewNotificationHandler(notificationClient)
 	paymentHandler := handler.NewPaymentHandler(paymentClient)
+	adminHandler := handler.NewAdminHandler(uaClient, restaurantClient)
 
 	return &Server{
 		router:              router,
@@ -41,6 +75,10 @@ func NewServer(cfg *apigateway.Env, uaClient *client.UAServiceClient, restaurant
 		restaurantHandler:   restaurantHandler,
 		notificationHandler: notificationHandler,
 		paymentHandler:      paymentHandler,
+		adminHandler:        adminHandler,
+		restaurantClient:    restaurantClient,
+		verifier:            verifier,
+		limiter:             limiter,
 		config:              *cfg,
 	}
 }
@@ -64,21 +102,61 @@ func (s *Server) SetupRoutes() {
 
 	// Auth routes
 	{
-		auth := v1.Group("/auth")
+		auth := v1.Group("/auth", RateLimit(s.limiter, "auth"))
 		{
 			auth.POST("/register", s.authHandler.Register)
 			auth.POST("/login", s.authHandler.LoginWithEmailAndPassword)
+			auth.POST("/login/mfa", s.authHandler.CompleteMFALogin)
 			auth.POST("/google", s.authHandler.LoginWithGoogle)
+			auth.GET("/oidc/providers", s.authHandler.ListIdentityProviders)
+			auth.POST("/oidc/:provider", s.authHandler.LoginWithIdentityProvider)
 			auth.POST("/logout", s.authHandler.Logout)
 			auth.POST("/refresh", s.authHandler.Refresh)
+
+			auth.POST("/verify-email", s.authHandler.VerifyEmail)
+			auth.POST("/verify-email/resend", AuthMiddleware(s.verifier), s.authHandler.SendEmailVerification)
+			auth.POST("/password/forgot", s.authHandler.ForgotPassword)
+			auth.POST("/password/reset", s.authHandler.ResetPassword)
+			auth.POST("/password/change", AuthMiddleware(s.verifier), s.authHandler.ChangePassword)
+			auth.POST("/email/change", AuthMiddleware(s.verifier), s.authHandler.ChangeEmail)
+			auth.POST("/email/confirm", s.authHandler.ConfirmEmailChange)
+
+			mfa := auth.Group("/mfa", AuthMiddleware(s.verifier))
+			{
+				mfa.POST("/enroll", s.authHandler.EnrollMFA)
+				mfa.POST("/confirm", s.authHandler.ConfirmMFA)
+				mfa.POST("/disable", s.authHandler.DisableMFA)
+			}
+		}
+	}
+
+	// Session routes
+	{
+		sessions := v1.Group("/sessions", AuthMiddleware(s.verifier), RateLimit(s.limiter, "user"))
+		{
+			sessions.GET("", s.authHandler.ListSessions)
+			sessions.DELETE("", s.authHandler.RevokeAllSessions)
+			sessions.DELETE("/:session_id", s.authHandler.RevokeSession)
+		}
+	}
+
+	// Account routes
+	{
+		account := v1.Group("/account", AuthMiddleware(s.verifier), RateLimit(s.limiter, "user"))
+		{
+			account.DELETE("", s.authHandler.DeleteAccount)
+			account.POST("/export", s.authHandler.ExportMyData)
+			account.GET("/requests/:request_id", s.authHandler.GetAccountRequest)
 		}
 	}
 
-	// User routes
+	// User routes, acting on the signed-in user. Admins name another user
+	// with the user_id query parameter.
 	{
-		user := v1.Group("/user")
+		user := v1.Group("/user", AuthMiddleware(s.verifier), RateLimit(s.limiter, "user"))
 		{
 			user.GET("/", s.userHandler.GetUser)
+			user.PUT("/profile", s.userHandler.UpdateProfile)
 			user.GET("/phone-number", s.userHandler.GetPhoneNumber)
 			user.POST("/phone-number", s.userHandler.AddPhoneNumber)
 			user.PUT("/phone-number", s.userHandler.UpdatePhoneNumber)
@@ -86,46 +164,89 @@ func (s *Server) SetupRoutes() {
 			user.GET("/addresses", s.userHandler.GetAddresses)
 			user.POST("/addresses", s.userHandler.AddAddress)
 			user.DELETE("/addresses", s.userHandler.RemoveAddress)
+			user.PUT("/addresses", s.userHandler.UpdateAddress)
+			user.PUT("/addresses/default", s.userHandler.SetDefaultAddress)
 
 			user.POST("/be-driver", s.userHandler.BeDriver)
-			user.POST("/drivers", s.userHandler.GetDrivers)
+			// Driver locations are for admins; dispatch searches them internally.
+			user.POST("/drivers", RequireRole(jwtvalidator.RoleAdmin), s.userHandler.GetDrivers)
 			user.DELETE("/drivers", s.userHandler.RemoveDriver)
 
 			// User Notifications
-			user.GET("/:user_id/notifications", s.notificationHandler.GetUserNotifications)
+			user.GET("/:user_id/notifications", RequireSubject("user_id"), s.notificationHandler.GetUserNotifications)
 		}
 	}
 
-	// Restaurant routes
+	// Driver routes, for the signed-in driver
 	{
-		restaurant := v1.Group("/restaurants", AuthMiddleware(&s.config))
+		driver := v1.Group("/drivers/me", AuthMiddleware(s.verifier), RateLimit(s.limiter, "drivers"), RequireRole(jwtvalidator.RoleDriver))
 		{
-			restaurant.POST("/login", s.restaurantHandler.Login)
-			restaurant.POST("/register", s.restaurantHandler.RegisterRestaurant)
+			driver.GET("", s.userHandler.GetDriverProfile)
+			driver.PUT("/vehicle", s.userHandler.UpdateVehicle)
+			driver.POST("/online", s.userHandler.GoOnline)
+			driver.POST("/offline", s.userHandler.GoOffline)
+			driver.PUT("/location", s.userHandler.UpdateDriverLocation)
+		}
+	}
 
+	// Restaurant routes
+	{
+		restaurant := v1.Group("/restaurants", AuthMiddleware(s.verifier), RateLimit(s.limiter, "restaurants"))
+		{
+			// Browsing and ordering, open to every signed-in user
 			restaurant.GET("/", s.restaurantHandler.GetRestaurant)
 			restaurant.POST("/", s.restaurantHandler.ListRestaurants)
+			restaurant.POST("/orders", RateLimit(s.limiter, "orders"), RequireVerifiedEmail(), s.restaurantHandler.PlaceOrder)
+			restaurant.GET("/orders/:order_id", s.restaurantHandler.GetOrder)
+		}
+
+		// Any signed-in user may register a restaurant, which makes them a
+		// restaurant owner once their tokens are refreshed.
+		restaurant.POST("/register", s.restaurantHandler.RegisterRestaurant)
+
+		// The restaurant service checks that the signed-in user owns the
+		// restaurant; streams and notifications are checked here first.
+		owner := restaurant.Group("", RequireRole(jwtvalidator.RoleRestaurantOwner))
+		{
+			owner.POST("/login", s.restaurantHandler.Login)
+			owner.PUT("/:restaurant_id", s.restaurantHandler.UpdateRestaurant)
+			owner.POST("/:restaurant_id/deactivate", s.restaurantHandler.DeactivateRestaurant)
+			owner.POST("/:restaurant_id/reactivate", s.restaurantHandler.ReactivateRestaurant)
 
 			// Menu routes for restaurants
-			restaurant.POST("/menu", s.restaurantHandler.AddMenuItem)
-			restaurant.PUT("/menu", s.restaurantHandler.UpdateMenuItem)
-			restaurant.DELETE("/menu", s.restaurantHandler.RemoveMenuItem)
+			owner.POST("/menu", s.restaurantHandler.AddMenuItem)
+			owner.PUT("/menu", s.restaurantHandler.UpdateMenuItem)
+			owner.DELETE("/menu", s.restaurantHandler.RemoveMenuItem)
+			owner.POST("/:restaurant_id/menu/import", s.restaurantHandler.ImportMenu)
+			owner.GET("/:restaurant_id/menu/export", s.restaurantHandler.ExportMenu)
 
 			// Order routes for restaurants
-			restaurant.GET("/orders", s.restaurantHandler.GetOrders)
-			restaurant.POST("/orders", s.restaurantHandler.PlaceOrder)
-			restaurant.PUT("/:restaurant_id/orders/:order_id/status", s.restaurantHandler.UpdateOrderStatus)
-			restaurant.PUT("orders/:order_id/ship", s.restaurantHandler.ShipOrder)
-			restaurant.GET("/orders/:order_id", s.restaurantHandler.GetOrder)
+			owner.GET("/orders", s.restaurantHandler.GetOrders)
+			owner.PUT("/:restaurant_id/orders/:order_id/status", s.restaurantHandler.UpdateOrderStatus)
+			owner.PUT("/:restaurant_id/orders/:order_id/ship", s.restaurantHandler.ShipOrder)
+			owner.GET("/:restaurant_id/orders/watch", RequireRestaurantOwner(s.restaurantClient), s.restaurantHandler.WatchOrders)
 
 			// Restaurant Notifications
-			restaurant.GET("/:restaurant_id/notifications", s.notificationHandler.GetRestaurantNotifications)
+			owner.GET("/:restaurant_id/notifications", RequireRestaurantOwner(s.restaurantClient), s.notificationHandler.GetRestaurantNotifications)
+		}
+	}
+
+	// Cart routes
+	{
+		cart := v1.Group("/carts", AuthMiddleware(s.verifier), RateLimit(s.limiter, "carts"), RequireRole(jwtvalidator.RoleCustomer), RequireSubject("customer_id"))
+		{
+			cart.GET("/:customer_id", s.restaurantHandler.GetCart)
+			cart.DELETE("/:customer_id", s.restaurantHandler.ClearCart)
+			cart.POST("/:customer_id/items", s.restaurantHandler.AddCartItem)
+			cart.PUT("/:customer_id/items/:item_id", s.restaurantHandler.UpdateCartItem)
+			cart.DELETE("/:customer_id/items/:item_id", s.restaurantHandler.RemoveCartItem)
+			cart.POST("/:customer_id/checkout", RateLimit(s.limiter, "orders"), RequireVerifiedEmail(), s.restaurantHandler.CheckoutCart)
 		}
 	}
 
 	// Notification routes
 	{
-		notification := v1.Group("/notifications", AuthMiddleware(&s.config))
+		notification := v1.Group("/notifications", AuthMiddleware(s.verifier), RateLimit(s.limiter, "notifications"))
 		{
 			notification.PUT("/:notification_id/read", s.notificationHandler.MarkAsRead)
 		}
@@ -133,7 +254,7 @@ func (s *Server) SetupRoutes() {
 
 	// Delivery routes
 	{
-		delivery := v1.Group("/deliveries")
+		delivery := v1.Group("/deliveries", RateLimit(s.limiter, "deliveries"))
 		{
 			delivery.POST("/", func(c *gin.Context) {
 				c.JSON(200, gin.H{
@@ -150,17 +271,42 @@ func (s *Server) SetupRoutes() {
 					"message": "Delivery status updated successfully!",
 				})
 			})
+
+			// Delivery offers for drivers
+			offers := delivery.Group("/offers", AuthMiddleware(s.verifier), RequireRole(jwtvalidator.RoleDriver))
+			{
+				offers.GET("", s.restaurantHandler.GetDeliveryOffers)
+				offers.PUT("/:offer_id", s.restaurantHandler.RespondToDeliveryOffer)
+			}
 		}
 	}
 
 	// Payment routes
 	{
-		payment := v1.Group("/payments", AuthMiddleware(&s.config))
+		payment := v1.Group("/payments", AuthMiddleware(s.verifier), RateLimit(s.limiter, "payments"), RequireRole(jwtvalidator.RoleCustomer), RequireVerifiedEmail())
 		{
 			payment.POST("/intent", s.paymentHandler.CreatePaymentIntent)
 		}
 	}
 
+	// Admin routes for moderating users and restaurants
+	{
+		admin := v1.Group("/admin", AuthMiddleware(s.verifier), RateLimit(s.limiter, "admin"), RequireRole(jwtvalidator.RoleAdmin))
+		{
+			admin.GET("/users", s.adminHandler.SearchUsers)
+			admin.POST("/users/:user_id/suspend", s.adminHandler.SuspendUser)
+			admin.POST("/users/:user_id/unsuspend", s.adminHandler.UnsuspendUser)
+			admin.DELETE("/users/:user_id/sessions", s.adminHandler.RevokeUserSessions)
+			admin.GET("/users/:user_id/orders", s.adminHandler.GetUserOrders)
+			admin.POST("/users/:user_id/roles", s.adminHandler.GrantRole)
+			admin.DELETE("/users/:user_id/roles/:role", s.adminHandler.RevokeRole)
+
+			admin.GET("/restaurants", s.adminHandler.SearchRestaurants)
+			admin.POST("/restaurants/:restaurant_id/suspend", s.adminHandler.SuspendRestaurant)
+			admin.POST("/restaurants/:restaurant_id/unsuspend", s.adminHandler.UnsuspendRestaurant)
+		}
+	}
+
 }
 
 func (s *Server) Run() error {
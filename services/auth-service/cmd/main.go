@@ -4,11 +4,17 @@ import (
 	"context"
 	"fmt"
 	"net"
+	nethttp "net/http"
+	"strings"
 	"time"
 
 	"github.com/tamirat-dejene/ha-soranu/services/auth-service/migrations"
+	"github.com/tamirat-dejene/ha-soranu/shared/pkg/auth/svcauth"
 	"github.com/tamirat-dejene/ha-soranu/shared/pkg/caching/valkey"
+	"github.com/tamirat-dejene/ha-soranu/shared/pkg/correlation"
+	"github.com/tamirat-dejene/ha-soranu/shared/pkg/events"
 	"github.com/tamirat-dejene/ha-soranu/shared/pkg/logger"
+	"github.com/tamirat-dejene/ha-soranu/shared/pkg/messaging/kafka/sarama"
 	"go.uber.org/zap"
 
 	"google.golang.org/grpc"
@@ -16,8 +22,12 @@ import (
 	_ "github.com/jackc/pgx/v5/stdlib"
 	authservice "github.com/tamirat-dejene/ha-soranu/services/auth-service"
 	"github.com/tamirat-dejene/ha-soranu/services/auth-service/internal/api/grpc/handler"
+	apihttp "github.com/tamirat-dejene/ha-soranu/services/auth-service/internal/api/http"
+	"github.com/tamirat-dejene/ha-soranu/services/auth-service/internal/identity"
+	"github.com/tamirat-dejene/ha-soranu/services/auth-service/internal/mailer"
 	"github.com/tamirat-dejene/ha-soranu/services/auth-service/internal/repository"
 	"github.com/tamirat-dejene/ha-soranu/services/auth-service/internal/usecase"
+	internalutil "github.com/tamirat-dejene/ha-soranu/services/auth-service/internal/util"
 	postgres "github.com/tamirat-dejene/ha-soranu/shared/db/pg"
 )
 
@@ -66,30 +76,142 @@ func main() {
 	defer valkeyClient.Close()
 	// 6. Initialize Repositories
 	userRepo := repository.NewUserRepository(pgClient)
-	authRepo := repository.NewAuthRepository(valkeyClient, time.Duration(5)*time.Minute)
+	refreshTTL, err := time.ParseDuration(env.RefreshTokenTTL)
+	if err != nil {
+		logger.Fatal("Invalid refresh token TTL", zap.Error(err))
+	}
+	authRepo := repository.NewAuthRepository(valkeyClient, refreshTTL)
+	loginAttemptRepo := repository.NewLoginAttemptRepository(valkeyClient)
+	sessionRepo := repository.NewSessionRepository(pgClient)
+	mfaRepo := repository.NewMFARepository(pgClient)
+	accountRequestRepo := repository.NewAccountRequestRepository(pgClient)
+	driverLocationRepo := repository.NewDriverLocationRepository(valkeyClient)
+	identityRepo := repository.NewIdentityRepository(pgClient)
+
+	// 7. Load Access Token Signing Keys, the MFA Encryption Key, the Password Policy and the Identity Providers
+	accessKeys, err := internalutil.NewAccessKeySet(env.AccessTokenPrivateKey, env.AccessTokenPublishedKeys)
+	if err != nil {
+		logger.Fatal("Failed to load access token keys", zap.Error(err))
+	}
+	logger.Info("Signing access tokens", zap.String("kid", accessKeys.SigningKey().ID), zap.Int("published_keys", len(accessKeys.JWKS().Keys)))
+
+	// Without the key TOTP secrets cannot be stored, so users cannot enroll.
+	var secrets *internalutil.SecretBox
+	if env.MFAEncryptionKey != "" {
+		secrets, err = internalutil.NewSecretBox(env.MFAEncryptionKey)
+		if err != nil {
+			logger.Fatal("Invalid MFA encryption key", zap.Error(err))
+		}
+	} else {
+		logger.Warn("MFA_ENCRYPTION_KEY is not set, two-factor authentication is unavailable")
+	}
+
+	passwordPolicy, err := internalutil.NewPasswordPolicy(env.PasswordMinLength, env.PasswordMinCharacterClasses, env.PasswordBreachedListFile)
+	if err != nil {
+		logger.Fatal("Failed to load password policy", zap.Error(err))
+	}
+	logger.Info("Password policy loaded", zap.Int("min_length", passwordPolicy.MinLength), zap.Int("breached_passwords", passwordPolicy.BreachedCount()))
+
+	// Identity providers users can log in with besides their password.
+	providerConfigs, err := env.IdentityProviders()
+	if err != nil {
+		logger.Fatal("Invalid identity provider settings", zap.Error(err))
+	}
+	if env.OIDCMockSecret != "" && env.SRV_ENV == "production" {
+		logger.Fatal("OIDC_MOCK_SECRET must not be set in production")
+	}
+	providers, err := identity.New(providerConfigs, env.OIDCMockSecret)
+	if err != nil {
+		logger.Fatal("Failed to initialize identity providers", zap.Error(err))
+	}
+	for name := range providers {
+		logger.Info("Identity provider enabled", zap.String("provider", name))
+	}
+
+	// 8. Initialize Mailer
+	mail, err := mailer.New(env.Mailer, env.MailFrom, env.MailDir)
+	if err != nil {
+		logger.Fatal("Failed to initialize mailer", zap.Error(err))
+	}
+
+	// 9. Initialize Kafka Producer and Consumer
+	producer, err := sarama.NewProducer([]string{env.KafkaBroker})
+	if err != nil {
+		logger.Fatal("Failed to create kafka producer", zap.Error(err))
+	}
+	defer producer.Close()
+
+	consumer, err := sarama.NewConsumer([]string{env.KafkaBroker}, env.AUTH_SRV_CONSUMER_GROUP)
+	if err != nil {
+		logger.Fatal("Failed to create kafka consumer", zap.Error(err))
+	}
+	defer consumer.Close()
 
-	// 7. Initialize Usecases
+	// 10. Initialize Usecases
 	timeout := time.Duration(5) * time.Second // Default timeout
-	userUsecase := usecase.NewUserUsecase(userRepo, timeout)
-	authUsecase := usecase.NewAuthUsecase(timeout, authRepo, userRepo, *env)
+	driverLocationTTL, err := time.ParseDuration(env.DriverLocationTTL)
+	if err != nil {
+		logger.Fatal("Invalid driver location TTL", zap.Error(err))
+	}
+	userUsecase := usecase.NewUserUsecase(userRepo, driverLocationRepo, timeout, env.MaxAddressesPerUser, driverLocationTTL)
+	authUsecase := usecase.NewAuthUsecase(timeout, authRepo, userRepo, sessionRepo, loginAttemptRepo, mfaRepo, identityRepo, providers, accessKeys, secrets, passwordPolicy, mail, *env)
+	adminUsecase := usecase.NewAdminUsecase(timeout, userRepo, authUsecase, env.RESTAURANT_SRV_NAME)
+	if err := adminUsecase.BootstrapAdmins(context.Background(), splitList(env.AdminEmails)); err != nil {
+		logger.Fatal("Failed to grant the admin role to ADMIN_EMAILS", zap.Error(err))
+	}
+	accountUsecase := usecase.NewAccountUsecase(timeout, accountRequestRepo, authRepo, userRepo, sessionRepo, mfaRepo, identityRepo, providers, events.NewEventPublisher(producer), consumer, mail, splitList(env.AccountDataServices))
 
-	// 8. Initialize gRPC Server
+	// 11. Start HTTP server for the JWKS and the account request consumer
+	httpSrv := apihttp.NewServer(accessKeys)
+	go func() {
+		addr := ":" + env.AUTH_HTTP_PORT
+		logger.Info("Auth HTTP server listening", zap.String("port", env.AUTH_HTTP_PORT))
+		if err := nethttp.ListenAndServe(addr, httpSrv.Routes()); err != nil {
+			logger.Fatal("http server error", zap.Error(err))
+		}
+	}()
+	go func() {
+		if err := accountUsecase.StartConsumer(context.Background()); err != nil {
+			logger.Error("account request consumer stopped", zap.Error(err))
+		}
+	}()
+
+	// 12. Initialize gRPC Server
 	lis, err := net.Listen("tcp", fmt.Sprintf(":%s", env.AUTH_SRV_PORT))
 	if err != nil {
 		logger.Fatal("Failed to listen", zap.Error(err))
 	}
 
+	// Only the services listed in SERVICE_AUTH_PUBLIC_KEYS may call.
+	callVerifier, err := svcauth.NewVerifier(env.ServiceAuthPublicKeys)
+	if err != nil {
+		logger.Fatal("Invalid service auth public keys", zap.Error(err))
+	}
+
 	s := grpc.NewServer(
-		grpc.UnaryInterceptor(logger.LoggingInterceptor),
+		grpc.ChainUnaryInterceptor(correlation.UnaryServerInterceptor(), logger.LoggingInterceptor, callVerifier.UnaryServerInterceptor()),
+		grpc.ChainStreamInterceptor(correlation.StreamServerInterceptor(), callVerifier.StreamServerInterceptor()),
 	)
 
-	// 9. Register Handlers
-	handler.NewGrpcAuthHandler(s, authUsecase)
+	// 13. Register Handlers
+	handler.NewGrpcAuthHandler(s, authUsecase, accountUsecase)
 	handler.NewGrpcUserHandler(s, userUsecase)
+	handler.NewGrpcAdminHandler(s, adminUsecase)
 
-	// 10. Start Server
+	// 14. Start Server
 	logger.Info("Auth Service listening", zap.String("port", env.AUTH_SRV_PORT))
 	if err := s.Serve(lis); err != nil {
 		logger.Fatal("Failed to serve", zap.Error(err))
 	}
 }
+
+// splitList splits a comma separated list, dropping empty items.
+func splitList(list string) []string {
+	var items []string
+	for _, item := range strings.Split(list, ",") {
+		if item = strings.TrimSpace(item); item != "" {
+			items = append(items, item)
+		}
+	}
+	return items
+}
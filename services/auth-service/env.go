@@ -1,9 +1,11 @@
 package authservice
 
 import (
+	"fmt"
 	"log"
 	"os"
 	"strconv"
+	"strings"
 )
 
 type Env struct {
@@ -11,17 +13,85 @@ type Env struct {
 	SRV_ENV       string `mapstructure:"SRV_ENV"`
 	AUTH_SRV_NAME string `mapstructure:"AUTH_SRV_NAME"`
 	AUTH_SRV_PORT string `mapstructure:"AUTH_SRV_PORT"`
+	// AUTH_HTTP_PORT serves the JWKS used to verify access tokens.
+	AUTH_HTTP_PORT string `mapstructure:"AUTH_HTTP_PORT"`
 
 	// Google OAuth2 settings
 	GoogleClientID string `mapstructure:"GOOGLE_CLIENT_ID"`
 
+	// OpenID Connect settings. OIDCProviders lists, comma separated, the
+	// providers users can log in with besides Google, each configured with
+	// OIDC_<NAME>_ISSUER and OIDC_<NAME>_CLIENT_ID. OIDCMockSecret enables
+	// the "mock" provider, which accepts ID tokens signed with the secret; it
+	// is meant for tests and refused in production.
+	OIDCProviders  string `mapstructure:"OIDC_PROVIDERS"`
+	OIDCMockSecret string `mapstructure:"OIDC_MOCK_SECRET"`
+
 	// JWT settings
-	AccessTokenPrivateKey  string `mapstructure:"ACCESS_TOKEN_PRIVATE_KEY"`
-	AccessTokenPublicKey   string `mapstructure:"ACCESS_TOKEN_PUBLIC_KEY"`
-	RefreshTokenPrivateKey string `mapstructure:"REFRESH_TOKEN_PRIVATE_KEY"`
-	RefreshTokenPublicKey  string `mapstructure:"REFRESH_TOKEN_PUBLIC_KEY"`
-	AccessTokenTTL         string `mapstructure:"ACCESS_TOKEN_TTL"`
-	RefreshTokenTTL        string `mapstructure:"REFRESH_TOKEN_TTL"`
+	AccessTokenPrivateKey string `mapstructure:"ACCESS_TOKEN_PRIVATE_KEY"`
+	AccessTokenPublicKey  string `mapstructure:"ACCESS_TOKEN_PUBLIC_KEY"`
+	// AccessTokenPublishedKeys are PEM public keys published next to the
+	// signing key: retiring keys and the next key of a rotation.
+	AccessTokenPublishedKeys string `mapstructure:"ACCESS_TOKEN_PUBLISHED_KEYS"`
+	RefreshTokenPrivateKey   string `mapstructure:"REFRESH_TOKEN_PRIVATE_KEY"`
+	RefreshTokenPublicKey    string `mapstructure:"REFRESH_TOKEN_PUBLIC_KEY"`
+	AccessTokenTTL           string `mapstructure:"ACCESS_TOKEN_TTL"`
+	RefreshTokenTTL          string `mapstructure:"REFRESH_TOKEN_TTL"`
+
+	// Login throttling settings. An account is locked for LoginLockout after
+	// LoginMaxFailures failed logins within LoginFailureWindow, and a client IP
+	// after LoginMaxFailuresPerIP.
+	LoginMaxFailures      int    `mapstructure:"LOGIN_MAX_FAILURES"`
+	LoginMaxFailuresPerIP int    `mapstructure:"LOGIN_MAX_FAILURES_PER_IP"`
+	LoginFailureWindow    string `mapstructure:"LOGIN_FAILURE_WINDOW"`
+	LoginLockout          string `mapstructure:"LOGIN_LOCKOUT"`
+
+	// Password policy settings. PasswordBreachedListFile extends the built-in
+	// list of breached passwords, one per line.
+	PasswordMinLength           int    `mapstructure:"PASSWORD_MIN_LENGTH"`
+	PasswordMinCharacterClasses int    `mapstructure:"PASSWORD_MIN_CHARACTER_CLASSES"`
+	PasswordBreachedListFile    string `mapstructure:"PASSWORD_BREACHED_LIST_FILE"`
+
+	// Email verification and password reset settings
+	Mailer     string `mapstructure:"MAILER"`
+	MailFrom   string `mapstructure:"MAIL_FROM"`
+	MailDir    string `mapstructure:"MAIL_DIR"`
+	AppBaseURL string `mapstructure:"APP_BASE_URL"`
+	// EmailVerificationTTL and PasswordResetTTL are how long emailed links stay valid.
+	EmailVerificationTTL string `mapstructure:"EMAIL_VERIFICATION_TTL"`
+	PasswordResetTTL     string `mapstructure:"PASSWORD_RESET_TTL"`
+
+	// Two-factor authentication settings. MFAEncryptionKey is a base64 encoded
+	// 32-byte key TOTP secrets are encrypted with; without it users cannot
+	// enroll. MFAIssuer names the account in authenticator apps.
+	MFAEncryptionKey string `mapstructure:"MFA_ENCRYPTION_KEY"`
+	MFAIssuer        string `mapstructure:"MFA_ISSUER"`
+	// MFAChallengeTTL is how long a login waits for its second factor.
+	MFAChallengeTTL string `mapstructure:"MFA_CHALLENGE_TTL"`
+
+	// Account deletion and data export settings. AccountDataServices lists,
+	// comma separated, the other services holding user data; requests
+	// complete once each of them has reported back.
+	AccountDataServices string `mapstructure:"ACCOUNT_DATA_SERVICES"`
+
+	// MaxAddressesPerUser is how many addresses a user's address book holds.
+	MaxAddressesPerUser int `mapstructure:"MAX_ADDRESSES_PER_USER"`
+
+	// DriverLocationTTL is how long an online driver stays searchable without
+	// sending their location.
+	DriverLocationTTL string `mapstructure:"DRIVER_LOCATION_TTL"`
+
+	// ServiceAuthPublicKeys lists the services allowed to call this one as
+	// service=key pairs, each key the base64-encoded PEM Ed25519 public key
+	// of the service.
+	ServiceAuthPublicKeys string `mapstructure:"SERVICE_AUTH_PUBLIC_KEYS"`
+
+	// Role settings. RESTAURANT_SRV_NAME is the name the restaurant service
+	// signs its calls with; it grants restaurant_owner to the users it
+	// registers restaurants for. AdminEmails lists, comma separated, the
+	// users made admins at startup, provided they verified their email.
+	RESTAURANT_SRV_NAME string `mapstructure:"RESTAURANT_SRV_NAME"`
+	AdminEmails         string `mapstructure:"ADMIN_EMAILS"`
 
 	// Database settings
 	DBHost     string `mapstructure:"POSTGRES_HOST"`
@@ -43,9 +113,53 @@ type Env struct {
 	ValkeyPassword string `mapstructure:"VALKEY_PASSWORD"`
 	ValkeyDB       int    `mapstructure:"VALKEY_DB"`
 
+	// Kafka settings
+	KafkaBroker             string `mapstructure:"KAFKA_BROKER_URL"`
+	AUTH_SRV_CONSUMER_GROUP string `mapstructure:"AUTH_SRV_CONSUMER_GROUP"`
+
 	// Other environment variables can be added here
 }
 
+// googleIssuer is the issuer of Google's ID tokens.
+const googleIssuer = "https://accounts.google.com"
+
+// IdentityProviderConfig configures login with an OpenID Connect provider.
+type IdentityProviderConfig struct {
+	Name     string
+	Issuer   string
+	ClientID string
+}
+
+// IdentityProviders returns the OpenID Connect providers users can log in
+// with: Google when GOOGLE_CLIENT_ID is set, and those listed in
+// OIDC_PROVIDERS.
+func (e *Env) IdentityProviders() ([]IdentityProviderConfig, error) {
+	var providers []IdentityProviderConfig
+	if e.GoogleClientID != "" {
+		providers = append(providers, IdentityProviderConfig{Name: "google", Issuer: googleIssuer, ClientID: e.GoogleClientID})
+	}
+
+	for _, name := range strings.Split(e.OIDCProviders, ",") {
+		name = strings.ToLower(strings.TrimSpace(name))
+		if name == "" {
+			continue
+		}
+
+		prefix := "OIDC_" + strings.ToUpper(strings.ReplaceAll(name, "-", "_"))
+		config := IdentityProviderConfig{
+			Name:     name,
+			Issuer:   getString(prefix+"_ISSUER", ""),
+			ClientID: getString(prefix+"_CLIENT_ID", ""),
+		}
+		if config.Issuer == "" || config.ClientID == "" {
+			return nil, fmt.Errorf("%s_ISSUER and %s_CLIENT_ID must be set", prefix, prefix)
+		}
+		providers = append(providers, config)
+	}
+
+	return providers, nil
+}
+
 func getString(key string, defaultValue string) string {
 	value := os.Getenv(key)
 	if value == "" {
@@ -69,30 +183,58 @@ func getInt(key string, defaultValue int) int {
 
 func GetEnv() (*Env, error) {
 	env := Env{
-		SRV_ENV:                getString("SRV_ENV", "development"),
-		AUTH_SRV_NAME:          getString("AUTH_SRV_NAME", "auth-service"),
-		AUTH_SRV_PORT:          getString("AUTH_SRV_PORT", "9090"),
-		GoogleClientID:         getString("GOOGLE_CLIENT_ID", ""),
-		AccessTokenPrivateKey:  getString("ACCESS_TOKEN_PRIVATE_KEY", ""),
-		AccessTokenPublicKey:   getString("ACCESS_TOKEN_PUBLIC_KEY", ""),
-		RefreshTokenPrivateKey: getString("REFRESH_TOKEN_PRIVATE_KEY", ""),
-		RefreshTokenPublicKey:  getString("REFRESH_TOKEN_PUBLIC_KEY", ""),
-		AccessTokenTTL:         getString("ACCESS_TOKEN_TTL", "15m"),
-		RefreshTokenTTL:        getString("REFRESH_TOKEN_TTL", "7d"),
-		DBHost:                 getString("POSTGRES_HOST", "postgres-db-"),
-		DBPort:                 getString("POSTGRES_PORT", "5432"),
-		DBUser:                 getString("POSTGRES_USER", "postgres"),
-		DBPassword:             getString("POSTGRES_PASSWORD", "password"),
-		DBName:                 getString("POSTGRES_DB", "authdb"),
-		RedisHOST:              getString("REDIS_HOST", "localhost"),
-		RedisPort:              getInt("REDIS_PORT", 6379),
-		RedisPassword:          getString("REDIS_PASSWORD", ""),
-		RedisDB:                getInt("REDIS_DB", 0),
-		ValkeyHOST:             getString("VALKEY_HOST", "localhost"),
-		ValkeyPort:             getInt("VALKEY_PORT", 6379),
-		ValkeyUser:             getString("VALKEY_USER", "default"),
-		ValkeyPassword:         getString("VALKEY_PASSWORD", "default-password"),
-		ValkeyDB:               getInt("VALKEY_DB", 0),
+		SRV_ENV:                     getString("SRV_ENV", "development"),
+		AUTH_SRV_NAME:               getString("AUTH_SRV_NAME", "auth-service"),
+		AUTH_SRV_PORT:               getString("AUTH_SRV_PORT", "9090"),
+		AUTH_HTTP_PORT:              getString("AUTH_HTTP_PORT", "8090"),
+		AUTH_SRV_CONSUMER_GROUP:     getString("AUTH_SRV_CONSUMER_GROUP", "auth-service-group"),
+		GoogleClientID:              getString("GOOGLE_CLIENT_ID", ""),
+		OIDCProviders:               getString("OIDC_PROVIDERS", ""),
+		OIDCMockSecret:              getString("OIDC_MOCK_SECRET", ""),
+		AccessTokenPrivateKey:       getString("ACCESS_TOKEN_PRIVATE_KEY", ""),
+		AccessTokenPublicKey:        getString("ACCESS_TOKEN_PUBLIC_KEY", ""),
+		AccessTokenPublishedKeys:    getString("ACCESS_TOKEN_PUBLISHED_KEYS", ""),
+		RefreshTokenPrivateKey:      getString("REFRESH_TOKEN_PRIVATE_KEY", ""),
+		RefreshTokenPublicKey:       getString("REFRESH_TOKEN_PUBLIC_KEY", ""),
+		AccessTokenTTL:              getString("ACCESS_TOKEN_TTL", "15m"),
+		RefreshTokenTTL:             getString("REFRESH_TOKEN_TTL", "168h"),
+		LoginMaxFailures:            getInt("LOGIN_MAX_FAILURES", 5),
+		LoginMaxFailuresPerIP:       getInt("LOGIN_MAX_FAILURES_PER_IP", 50),
+		LoginFailureWindow:          getString("LOGIN_FAILURE_WINDOW", "15m"),
+		LoginLockout:                getString("LOGIN_LOCKOUT", "15m"),
+		PasswordMinLength:           getInt("PASSWORD_MIN_LENGTH", 8),
+		PasswordMinCharacterClasses: getInt("PASSWORD_MIN_CHARACTER_CLASSES", 3),
+		PasswordBreachedListFile:    getString("PASSWORD_BREACHED_LIST_FILE", ""),
+		Mailer:                      getString("MAILER", "log"),
+		MailFrom:                    getString("MAIL_FROM", "no-reply@ha-soranu.local"),
+		MailDir:                     getString("MAIL_DIR", "/tmp/ha-soranu-mail"),
+		AppBaseURL:                  getString("APP_BASE_URL", "http://localhost:3000"),
+		EmailVerificationTTL:        getString("EMAIL_VERIFICATION_TTL", "24h"),
+		PasswordResetTTL:            getString("PASSWORD_RESET_TTL", "1h"),
+		MFAEncryptionKey:            getString("MFA_ENCRYPTION_KEY", ""),
+		MFAIssuer:                   getString("MFA_ISSUER", "Ha-Soranu"),
+		MFAChallengeTTL:             getString("MFA_CHALLENGE_TTL", "5m"),
+		AccountDataServices:         getString("ACCOUNT_DATA_SERVICES", "restaurant,notification"),
+		MaxAddressesPerUser:         getInt("MAX_ADDRESSES_PER_USER", 10),
+		DriverLocationTTL:           getString("DRIVER_LOCATION_TTL", "2m"),
+		ServiceAuthPublicKeys:       getString("SERVICE_AUTH_PUBLIC_KEYS", ""),
+		RESTAURANT_SRV_NAME:         getString("RESTAURANT_SRV_NAME", "restaurant-service"),
+		AdminEmails:                 getString("ADMIN_EMAILS", ""),
+		DBHost:                      getString("POSTGRES_HOST", "postgres-db-"),
+		DBPort:                      getString("POSTGRES_PORT", "5432"),
+		DBUser:                      getString("POSTGRES_USER", "postgres"),
+		DBPassword:                  getString("POSTGRES_PASSWORD", "password"),
+		DBName:                      getString("POSTGRES_DB", "authdb"),
+		RedisHOST:                   getString("REDIS_HOST", "localhost"),
+		RedisPort:                   getInt("REDIS_PORT", 6379),
+		RedisPassword:               getString("REDIS_PASSWORD", ""),
+		RedisDB:                     getInt("REDIS_DB", 0),
+		ValkeyHOST:                  getString("VALKEY_HOST", "localhost"),
+		ValkeyPort:                  getInt("VALKEY_PORT", 6379),
+		ValkeyUser:                  getString("VALKEY_USER", "default"),
+		ValkeyPassword:              getString("VALKEY_PASSWORD", "default-password"),
+		ValkeyDB:                    getInt("VALKEY_DB", 0),
+		KafkaBroker:                 getString("KAFKA_BROKER_URL", "localhost:9092"),
 	}
 	return &env, nil
 }
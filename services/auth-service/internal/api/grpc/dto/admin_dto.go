@@ -0,0 +1,31 @@
+package dto
+
+import (
+	"github.com/tamirat-dejene/ha-soranu/services/auth-service/internal/domain"
+	"github.com/tamirat-dejene/ha-soranu/shared/protos/adminpb"
+	"google.golang.org/protobuf/types/known/timestamppb"
+)
+
+func ToDomainUserSearch(p *adminpb.SearchUsersRequest) domain.UserSearch {
+	return domain.UserSearch{
+		Query:         p.Query,
+		SuspendedOnly: p.SuspendedOnly,
+		Limit:         int(p.Limit),
+		Offset:        int(p.Offset),
+	}
+}
+
+func ToProtoUserAccount(a *domain.UserAccount) *adminpb.UserAccount {
+	account := &adminpb.UserAccount{
+		User:  ToProtoUser(&a.User),
+		Roles: a.Roles,
+	}
+	if a.Suspension != nil {
+		account.Suspension = &adminpb.Suspension{
+			Reason:      a.Suspension.Reason,
+			SuspendedBy: a.Suspension.SuspendedBy,
+			SuspendedAt: timestamppb.New(a.Suspension.SuspendedAt),
+		}
+	}
+	return account
+}
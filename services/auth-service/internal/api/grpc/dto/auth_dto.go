@@ -1,7 +1,10 @@
 package dto
 
 import (
+    "encoding/json"
+
     "github.com/tamirat-dejene/ha-soranu/services/auth-service/internal/domain"
+    "github.com/tamirat-dejene/ha-soranu/shared/pkg/clientinfo"
     "github.com/tamirat-dejene/ha-soranu/shared/protos/authpb"
     "github.com/tamirat-dejene/ha-soranu/shared/protos/userpb"
     "google.golang.org/protobuf/types/known/timestamppb"
@@ -18,6 +21,7 @@ func ToDomainUserRegister(req *authpb.UserRegisterRequest) domain.UserRegister {
 
 func ToProtoUser(user *domain.User) *userpb.User {
     addresses := make([]*userpb.Address, len(user.Addresses))
+    var defaultAddress *userpb.Address
     for i, a := range user.Addresses {
         addresses[i] = &userpb.Address{
             AddressId:  a.AddressID,
@@ -28,6 +32,11 @@ func ToProtoUser(user *domain.User) *userpb.User {
             Country:    a.Country,
             Latitude:   a.Latitude,
             Longitude:  a.Longitude,
+            Label:      a.Label,
+            IsDefault:  a.IsDefault,
+        }
+        if a.IsDefault {
+            defaultAddress = addresses[i]
         }
     }
 
@@ -38,6 +47,8 @@ func ToProtoUser(user *domain.User) *userpb.User {
         PhoneNumber: user.PhoneNumber,
         Addresses:   addresses,
         CreatedAt:   timestamppb.New(user.CreatedAt),
+        EmailVerified: user.EmailVerified,
+        DefaultAddress: defaultAddress,
     }
 }
 
@@ -54,9 +65,97 @@ func ToDomainLoginWithGoogle(req *authpb.GLoginRequest) domain.LoginWithGoogle {
     }
 }
 
+func ToDomainLoginWithIdentityProvider(req *authpb.IdentityProviderLoginRequest) domain.LoginWithIdentityProvider {
+    return domain.LoginWithIdentityProvider{
+        Provider: req.Provider,
+        IDToken:  req.IdToken,
+    }
+}
+
+// ReauthenticationRequest is a request carrying the proof a signed-in user
+// reauthenticates with.
+type ReauthenticationRequest interface {
+    GetPassword() string
+    GetProvider() string
+    GetIdToken() string
+}
+
+func ToDomainReauthentication(req ReauthenticationRequest) domain.Reauthentication {
+    return domain.Reauthentication{
+        Password: req.GetPassword(),
+        Provider: req.GetProvider(),
+        IDToken:  req.GetIdToken(),
+    }
+}
+
 func ToDomainLoginWithEmail(req *authpb.EPLoginRequest) domain.LoginWithEmail {
     return domain.LoginWithEmail{
         Email:    req.Email,
         Password: req.Password,
     }
-}
\ No newline at end of file
+}
+func ToDomainClientInfo(info clientinfo.Info) domain.ClientInfo {
+    return domain.ClientInfo{
+        IP:        info.IP,
+        UserAgent: info.UserAgent,
+        DeviceID:  info.DeviceID,
+    }
+}
+
+func ToProtoSessions(sessions []domain.Session) []*authpb.Session {
+    result := make([]*authpb.Session, len(sessions))
+    for i, s := range sessions {
+        result[i] = &authpb.Session{
+            SessionId:  s.SessionID,
+            DeviceId:   s.DeviceID,
+            UserAgent:  s.UserAgent,
+            IpAddress:  s.IPAddress,
+            CreatedAt:  timestamppb.New(s.CreatedAt),
+            LastUsedAt: timestamppb.New(s.LastUsedAt),
+            ExpiresAt:  timestamppb.New(s.ExpiresAt),
+            Current:    s.Current,
+        }
+    }
+    return result
+}
+
+// ToProtoAccountRequest converts a request, joining the parts of a completed
+// export into a single JSON object keyed by service.
+func ToProtoAccountRequest(request *domain.AccountRequest) (*authpb.AccountRequest, error) {
+    result := &authpb.AccountRequest{
+        RequestId: request.RequestID,
+        Kind:      string(request.Kind),
+        Status:    string(request.Status),
+        CreatedAt: timestamppb.New(request.CreatedAt),
+    }
+    if !request.CompletedAt.IsZero() {
+        result.CompletedAt = timestamppb.New(request.CompletedAt)
+    }
+
+    data := make(map[string]json.RawMessage)
+    for _, s := range request.Services {
+        service := &authpb.AccountRequestService{
+            Service: s.Service,
+            Status:  string(s.Status),
+            Error:   s.Error,
+        }
+        if !s.CompletedAt.IsZero() {
+            service.CompletedAt = timestamppb.New(s.CompletedAt)
+        }
+        result.Services = append(result.Services, service)
+
+        if len(s.Data) > 0 {
+            data[s.Service] = json.RawMessage(s.Data)
+        }
+    }
+
+    if request.Kind == domain.AccountExport && request.Status == domain.AccountRequestCompleted {
+        export, err := json.Marshal(data)
+        if err != nil {
+            return nil, err
+        }
+        result.Data = string(export)
+    }
+
+    return result, nil
+}
@@ -15,6 +15,22 @@ func ToDomainAddress(p *userpb.AddAddressRequest) domain.Address {
 		Country:    p.Country,
 		Latitude:   p.Latitude,
 		Longitude:  p.Longitude,
+		Label:      p.Label,
+		IsDefault:  p.IsDefault,
+	}
+}
+
+func ToDomainAddressUpdate(p *userpb.UpdateAddressRequest) domain.Address {
+	return domain.Address{
+		AddressID:  p.AddressId,
+		Street:     p.Street,
+		City:       p.City,
+		State:      p.State,
+		PostalCode: p.PostalCode,
+		Country:    p.Country,
+		Latitude:   p.Latitude,
+		Longitude:  p.Longitude,
+		Label:      p.Label,
 	}
 }
 
@@ -29,5 +45,48 @@ func ToProtoAddress(a domain.Address) *userpb.Address {
 		Latitude:   a.Latitude,
 		Longitude:  a.Longitude,
 		CreatedAt:  timestamppb.New(a.CreatedAt),
+		Label:      a.Label,
+		IsDefault:  a.IsDefault,
 	}
-}
\ No newline at end of file
+}
+
+func ToDomainVehicle(p *userpb.Vehicle) domain.Vehicle {
+	if p == nil {
+		return domain.Vehicle{}
+	}
+	return domain.Vehicle{
+		Type:        p.Type,
+		Make:        p.Make,
+		Model:       p.Model,
+		PlateNumber: p.PlateNumber,
+		Color:       p.Color,
+	}
+}
+
+func ToProtoDriverProfile(d *domain.Driver) *userpb.DriverProfile {
+	profile := &userpb.DriverProfile{
+		DriverId:  d.DriverID,
+		UserId:    d.User.UserID,
+		Online:    d.Online,
+		CreatedAt: timestamppb.New(d.CreatedAt),
+	}
+	if d.Vehicle.Type != "" {
+		profile.Vehicle = &userpb.Vehicle{
+			Type:        d.Vehicle.Type,
+			Make:        d.Vehicle.Make,
+			Model:       d.Vehicle.Model,
+			PlateNumber: d.Vehicle.PlateNumber,
+			Color:       d.Vehicle.Color,
+		}
+	}
+	return profile
+}
+
+func ToProtoNearbyDriver(d domain.Driver) *userpb.NearbyDriver {
+	return &userpb.NearbyDriver{
+		DriverId:   d.DriverID,
+		Latitude:   float32(d.Location.Latitude),
+		Longitude:  float32(d.Location.Longitude),
+		DistanceKm: float32(d.Location.DistanceKm),
+	}
+}
@@ -0,0 +1,121 @@
+package handler
+
+import (
+	"context"
+
+	"github.com/tamirat-dejene/ha-soranu/services/auth-service/internal/api/grpc/dto"
+	"github.com/tamirat-dejene/ha-soranu/services/auth-service/internal/domain"
+	errs "github.com/tamirat-dejene/ha-soranu/services/auth-service/internal/domain/err"
+	"github.com/tamirat-dejene/ha-soranu/shared/pkg/logger"
+	"github.com/tamirat-dejene/ha-soranu/shared/protos/adminpb"
+	"go.uber.org/zap"
+	"google.golang.org/grpc"
+)
+
+type adminHandler struct {
+	adminpb.UnimplementedUserAdminServiceServer
+	usecase domain.AdminUseCase
+}
+
+// SearchUsers implements [adminpb.UserAdminServiceServer].
+func (a *adminHandler) SearchUsers(ctx context.Context, req *adminpb.SearchUsersRequest) (*adminpb.SearchUsersResponse, error) {
+	if req == nil {
+		return nil, errs.ToGRPCError(errs.ErrInvalidRequest)
+	}
+
+	accounts, err := a.usecase.SearchUsers(ctx, dto.ToDomainUserSearch(req))
+	if err != nil {
+		return nil, errs.ToGRPCError(err)
+	}
+
+	users := make([]*adminpb.UserAccount, len(accounts))
+	for i := range accounts {
+		users[i] = dto.ToProtoUserAccount(&accounts[i])
+	}
+
+	return &adminpb.SearchUsersResponse{
+		Users: users,
+	}, nil
+}
+
+// SuspendUser implements [adminpb.UserAdminServiceServer].
+func (a *adminHandler) SuspendUser(ctx context.Context, req *adminpb.SuspendUserRequest) (*adminpb.UserAccount, error) {
+	if req == nil || req.UserId == "" {
+		return nil, errs.ToGRPCError(errs.ErrInvalidRequest)
+	}
+
+	account, err := a.usecase.SuspendUser(ctx, req.UserId, req.Reason)
+	if err != nil {
+		logger.Error("Failed to suspend user", zap.String("user_id", req.UserId), zap.Error(err))
+		return nil, errs.ToGRPCError(err)
+	}
+
+	return dto.ToProtoUserAccount(account), nil
+}
+
+// UnsuspendUser implements [adminpb.UserAdminServiceServer].
+func (a *adminHandler) UnsuspendUser(ctx context.Context, req *adminpb.UnsuspendUserRequest) (*adminpb.UserAccount, error) {
+	if req == nil || req.UserId == "" {
+		return nil, errs.ToGRPCError(errs.ErrInvalidRequest)
+	}
+
+	account, err := a.usecase.UnsuspendUser(ctx, req.UserId)
+	if err != nil {
+		logger.Error("Failed to unsuspend user", zap.String("user_id", req.UserId), zap.Error(err))
+		return nil, errs.ToGRPCError(err)
+	}
+
+	return dto.ToProtoUserAccount(account), nil
+}
+
+// RevokeUserSessions implements [adminpb.UserAdminServiceServer].
+func (a *adminHandler) RevokeUserSessions(ctx context.Context, req *adminpb.RevokeUserSessionsRequest) (*adminpb.RevokeUserSessionsResponse, error) {
+	if req == nil || req.UserId == "" {
+		return nil, errs.ToGRPCError(errs.ErrInvalidRequest)
+	}
+
+	revoked, err := a.usecase.RevokeUserSessions(ctx, req.UserId)
+	if err != nil {
+		logger.Error("Failed to revoke user sessions", zap.String("user_id", req.UserId), zap.Error(err))
+		return nil, errs.ToGRPCError(err)
+	}
+
+	return &adminpb.RevokeUserSessionsResponse{
+		RevokedSessions: int32(revoked),
+	}, nil
+}
+
+// GrantRole implements [adminpb.UserAdminServiceServer].
+func (a *adminHandler) GrantRole(ctx context.Context, req *adminpb.GrantRoleRequest) (*adminpb.UserAccount, error) {
+	if req == nil || req.UserId == "" || req.Role == "" {
+		return nil, errs.ToGRPCError(errs.ErrInvalidRequest)
+	}
+
+	account, err := a.usecase.GrantRole(ctx, req.UserId, req.Role)
+	if err != nil {
+		logger.Error("Failed to grant role", zap.String("user_id", req.UserId), zap.String("role", req.Role), zap.Error(err))
+		return nil, errs.ToGRPCError(err)
+	}
+
+	return dto.ToProtoUserAccount(account), nil
+}
+
+// RevokeRole implements [adminpb.UserAdminServiceServer].
+func (a *adminHandler) RevokeRole(ctx context.Context, req *adminpb.RevokeRoleRequest) (*adminpb.UserAccount, error) {
+	if req == nil || req.UserId == "" || req.Role == "" {
+		return nil, errs.ToGRPCError(errs.ErrInvalidRequest)
+	}
+
+	account, err := a.usecase.RevokeRole(ctx, req.UserId, req.Role)
+	if err != nil {
+		logger.Error("Failed to revoke role", zap.String("user_id", req.UserId), zap.String("role", req.Role), zap.Error(err))
+		return nil, errs.ToGRPCError(err)
+	}
+
+	return dto.ToProtoUserAccount(account), nil
+}
+
+func NewGrpcAdminHandler(s *grpc.Server, usecase domain.AdminUseCase) {
+	handler := &adminHandler{usecase: usecase}
+	adminpb.RegisterUserAdminServiceServer(s, handler)
+}
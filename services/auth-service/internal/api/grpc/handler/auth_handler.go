@@ -5,7 +5,9 @@ import (
 
 	"github.com/tamirat-dejene/ha-soranu/services/auth-service/internal/api/grpc/dto"
 	"github.com/tamirat-dejene/ha-soranu/services/auth-service/internal/domain"
+	constants "github.com/tamirat-dejene/ha-soranu/services/auth-service/internal/domain/const"
 	errs "github.com/tamirat-dejene/ha-soranu/services/auth-service/internal/domain/err"
+	"github.com/tamirat-dejene/ha-soranu/shared/pkg/clientinfo"
 	"github.com/tamirat-dejene/ha-soranu/shared/pkg/logger"
 	"github.com/tamirat-dejene/ha-soranu/shared/protos/authpb"
 	"github.com/tamirat-dejene/ha-soranu/shared/protos/userpb"
@@ -15,23 +17,60 @@ import (
 
 type authHandler struct {
 	authpb.UnimplementedAuthServiceServer
-	usecase domain.AuthUseCase
+	usecase  domain.AuthUseCase
+	accounts domain.AccountUseCase
 }
 
 // LoginWithEmailAndPassword implements authpb.AuthServiceServer.
 func (a *authHandler) LoginWithEmailAndPassword(ctx context.Context, req *authpb.EPLoginRequest) (*authpb.LoginResponse, error) {
 	logger.Info("Received email-password login request")
 	if req == nil {
-		return nil, errs.ErrInvalidRequest
+		return nil, errs.ToGRPCError(errs.ErrInvalidRequest)
 	}
 
-	user, tokens, err := a.usecase.LoginWithEmailAndPassword(ctx, dto.ToDomainLoginWithEmail(req))
+	input := dto.ToDomainLoginWithEmail(req)
+	input.Client = dto.ToDomainClientInfo(clientinfo.FromIncomingContext(ctx))
+
+	result, err := a.usecase.LoginWithEmailAndPassword(ctx, input)
 	if err != nil {
 		logger.Error("Failed to login with email and password", zap.String("email", req.Email), zap.Error(err))
-		return nil, err
+		return nil, errs.ToGRPCError(err)
+	}
+
+	if result.MFAToken != "" {
+		logger.Info("Login awaits second factor", zap.String("email", req.Email))
+		return &authpb.LoginResponse{
+			MfaRequired: true,
+			MfaToken:    result.MFAToken,
+		}, nil
+	}
+
+	logger.Info("User logged in successfully", zap.String("user_id", result.User.UserID), zap.String("email", result.User.Email))
+
+	return &authpb.LoginResponse{
+		User:   dto.ToProtoUser(result.User),
+		Tokens: dto.ToProtoTokens(result.Tokens),
+	}, nil
+}
+
+// CompleteMFALogin implements authpb.AuthServiceServer.
+func (a *authHandler) CompleteMFALogin(ctx context.Context, req *authpb.CompleteMFALoginRequest) (*authpb.LoginResponse, error) {
+	logger.Info("Received two-factor login request")
+	if req == nil {
+		return nil, errs.ToGRPCError(errs.ErrInvalidRequest)
 	}
 
-	logger.Info("User logged in successfully", zap.String("user_id", user.UserID), zap.String("email", user.Email))
+	user, tokens, err := a.usecase.CompleteMFALogin(ctx, domain.MFALogin{
+		MFAToken: req.MfaToken,
+		Code:     req.Code,
+		Client:   dto.ToDomainClientInfo(clientinfo.FromIncomingContext(ctx)),
+	})
+	if err != nil {
+		logger.Error("Failed to complete two-factor login", zap.Error(err))
+		return nil, errs.ToGRPCError(err)
+	}
+
+	logger.Info("User logged in with second factor successfully", zap.String("user_id", user.UserID), zap.String("email", user.Email))
 
 	return &authpb.LoginResponse{
 		User:   dto.ToProtoUser(user),
@@ -43,20 +82,70 @@ func (a *authHandler) LoginWithEmailAndPassword(ctx context.Context, req *authpb
 func (a *authHandler) LoginWithGoogle(ctx context.Context, req *authpb.GLoginRequest) (*authpb.LoginResponse, error) {
 	logger.Info("Received Google login request")
 	if req == nil {
-		return nil, errs.ErrInvalidRequest
+		return nil, errs.ToGRPCError(errs.ErrInvalidRequest)
 	}
 
-	user, tokens, err := a.usecase.LoginWithGoogle(ctx, dto.ToDomainLoginWithGoogle(req))
+	input := dto.ToDomainLoginWithGoogle(req)
+	input.Client = dto.ToDomainClientInfo(clientinfo.FromIncomingContext(ctx))
+
+	result, err := a.usecase.LoginWithGoogle(ctx, input)
 	if err != nil {
-		logger.Error("Failed to login with Google", zap.String("id_token", req.IdToken), zap.Error(err))
-		return nil, err
+		logger.Error("Failed to login with Google", zap.Error(err))
+		return nil, errs.ToGRPCError(err)
+	}
+
+	if result.MFAToken != "" {
+		logger.Info("Google login awaits second factor")
+		return &authpb.LoginResponse{
+			MfaRequired: true,
+			MfaToken:    result.MFAToken,
+		}, nil
 	}
 
-	logger.Info("User logged in with Google successfully", zap.String("user_id", user.UserID), zap.String("email", user.Email))
+	logger.Info("User logged in with Google successfully", zap.String("user_id", result.User.UserID), zap.String("email", result.User.Email))
 
 	return &authpb.LoginResponse{
-		User:   dto.ToProtoUser(user),
-		Tokens: dto.ToProtoTokens(tokens),
+		User:   dto.ToProtoUser(result.User),
+		Tokens: dto.ToProtoTokens(result.Tokens),
+	}, nil
+}
+
+// LoginWithIdentityProvider implements authpb.AuthServiceServer.
+func (a *authHandler) LoginWithIdentityProvider(ctx context.Context, req *authpb.IdentityProviderLoginRequest) (*authpb.LoginResponse, error) {
+	logger.Info("Received identity provider login request", zap.String("provider", req.GetProvider()))
+	if req == nil || req.IdToken == "" {
+		return nil, errs.ToGRPCError(errs.ErrInvalidRequest)
+	}
+
+	input := dto.ToDomainLoginWithIdentityProvider(req)
+	input.Client = dto.ToDomainClientInfo(clientinfo.FromIncomingContext(ctx))
+
+	result, err := a.usecase.LoginWithIdentityProvider(ctx, input)
+	if err != nil {
+		logger.Error("Failed to login with identity provider", zap.String("provider", req.Provider), zap.Error(err))
+		return nil, errs.ToGRPCError(err)
+	}
+
+	if result.MFAToken != "" {
+		logger.Info("Identity provider login awaits second factor", zap.String("provider", req.Provider))
+		return &authpb.LoginResponse{
+			MfaRequired: true,
+			MfaToken:    result.MFAToken,
+		}, nil
+	}
+
+	logger.Info("User logged in with identity provider successfully", zap.String("user_id", result.User.UserID), zap.String("provider", req.Provider))
+
+	return &authpb.LoginResponse{
+		User:   dto.ToProtoUser(result.User),
+		Tokens: dto.ToProtoTokens(result.Tokens),
+	}, nil
+}
+
+// ListIdentityProviders implements authpb.AuthServiceServer.
+func (a *authHandler) ListIdentityProviders(ctx context.Context, req *authpb.ListIdentityProvidersRequest) (*authpb.ListIdentityProvidersResponse, error) {
+	return &authpb.ListIdentityProvidersResponse{
+		Providers: a.usecase.IdentityProviders(),
 	}, nil
 }
 
@@ -64,13 +153,13 @@ func (a *authHandler) LoginWithGoogle(ctx context.Context, req *authpb.GLoginReq
 func (a *authHandler) Logout(ctx context.Context, req *authpb.LogoutRequest) (*userpb.MessageResponse, error) {
 	logger.Info("Received logout request")
 	if req == nil {
-		return nil, errs.ErrInvalidRequest
+		return nil, errs.ToGRPCError(errs.ErrInvalidRequest)
 	}
 
 	err := a.usecase.Logout(ctx, req.RefreshToken)
 	if err != nil {
 		logger.Error("Failed to logout user", zap.String("refresh_token", req.RefreshToken), zap.Error(err))
-		return nil, err
+		return nil, errs.ToGRPCError(err)
 	}
 
 	logger.Info("User logged out successfully", zap.String("refresh_token", req.RefreshToken))
@@ -84,13 +173,13 @@ func (a *authHandler) Logout(ctx context.Context, req *authpb.LogoutRequest) (*u
 func (a *authHandler) Refresh(ctx context.Context, req *authpb.RefreshRequest) (*authpb.RefreshResponse, error) {
 	logger.Info("Received token refresh request")
 	if req == nil {
-		return nil, errs.ErrInvalidRequest
+		return nil, errs.ToGRPCError(errs.ErrInvalidRequest)
 	}
 
-	tokens, err := a.usecase.RefreshTokens(ctx, req.RefreshToken)
+	tokens, err := a.usecase.RefreshTokens(ctx, req.RefreshToken, dto.ToDomainClientInfo(clientinfo.FromIncomingContext(ctx)))
 	if  err != nil {
 		logger.Error("Failed to refresh auth tokens", zap.Error(err))
-		return nil, err
+		return nil, errs.ToGRPCError(err)
 	}
 
 	logger.Info("Auth tokens refreshed successfully")
@@ -104,15 +193,16 @@ func (a *authHandler) Refresh(ctx context.Context, req *authpb.RefreshRequest) (
 func (a *authHandler) Register(ctx context.Context, req *authpb.UserRegisterRequest) (*authpb.UserRegisterResponse, error) {
 	logger.Info("Received user registration request")
 	if req == nil {
-		return nil, errs.ErrInvalidRequest
+		return nil, errs.ToGRPCError(errs.ErrInvalidRequest)
 	}
 
 	req_dto := dto.ToDomainUserRegister(req)
+	req_dto.Client = dto.ToDomainClientInfo(clientinfo.FromIncomingContext(ctx))
 	user, authtoken, err :=  a.usecase.Register(ctx, req_dto)
 
 	if err != nil {
 		logger.Error("Failed to register user", zap.String("email", req_dto.Email), zap.Error(err))
-		return nil, err
+		return nil, errs.ToGRPCError(err)
 	}
 
 	logger.Info("User registered successfully", zap.String("user_id", user.UserID), zap.String("email", req_dto.Email))
@@ -123,7 +213,304 @@ func (a *authHandler) Register(ctx context.Context, req *authpb.UserRegisterRequ
 	}, nil
 }
 
-func NewGrpcAuthHandler(s *grpc.Server, usecase domain.AuthUseCase) {
-	handler := &authHandler{usecase: usecase}
+// SendEmailVerification implements authpb.AuthServiceServer.
+func (a *authHandler) SendEmailVerification(ctx context.Context, req *authpb.SendEmailVerificationRequest) (*userpb.MessageResponse, error) {
+	logger.Info("Received email verification request")
+	if req == nil || req.UserId == "" {
+		return nil, errs.ToGRPCError(errs.ErrInvalidRequest)
+	}
+
+	if err := a.usecase.SendEmailVerification(ctx, req.UserId); err != nil {
+		logger.Error("Failed to send email verification", zap.String("user_id", req.UserId), zap.Error(err))
+		return nil, errs.ToGRPCError(err)
+	}
+
+	return &userpb.MessageResponse{
+		Message: constants.EmailVerificationSentMessage,
+	}, nil
+}
+
+// VerifyEmail implements authpb.AuthServiceServer.
+func (a *authHandler) VerifyEmail(ctx context.Context, req *authpb.VerifyEmailRequest) (*userpb.MessageResponse, error) {
+	logger.Info("Received verify email request")
+	if req == nil {
+		return nil, errs.ToGRPCError(errs.ErrInvalidRequest)
+	}
+
+	if err := a.usecase.VerifyEmail(ctx, req.Token); err != nil {
+		logger.Error("Failed to verify email", zap.Error(err))
+		return nil, errs.ToGRPCError(err)
+	}
+
+	logger.Info("Email verified successfully")
+	return &userpb.MessageResponse{
+		Message: constants.EmailVerifiedMessage,
+	}, nil
+}
+
+// RequestPasswordReset implements authpb.AuthServiceServer.
+func (a *authHandler) RequestPasswordReset(ctx context.Context, req *authpb.RequestPasswordResetRequest) (*userpb.MessageResponse, error) {
+	logger.Info("Received password reset request")
+	if req == nil || req.Email == "" {
+		return nil, errs.ToGRPCError(errs.ErrInvalidRequest)
+	}
+
+	if err := a.usecase.RequestPasswordReset(ctx, req.Email); err != nil {
+		logger.Error("Failed to request password reset", zap.Error(err))
+		return nil, errs.ToGRPCError(err)
+	}
+
+	return &userpb.MessageResponse{
+		Message: constants.PasswordResetSentMessage,
+	}, nil
+}
+
+// ResetPassword implements authpb.AuthServiceServer.
+func (a *authHandler) ResetPassword(ctx context.Context, req *authpb.ResetPasswordRequest) (*userpb.MessageResponse, error) {
+	logger.Info("Received reset password request")
+	if req == nil {
+		return nil, errs.ToGRPCError(errs.ErrInvalidRequest)
+	}
+
+	if err := a.usecase.ResetPassword(ctx, req.Token, req.NewPassword); err != nil {
+		logger.Error("Failed to reset password", zap.Error(err))
+		return nil, errs.ToGRPCError(err)
+	}
+
+	logger.Info("Password reset successfully")
+	return &userpb.MessageResponse{
+		Message: constants.PasswordResetMessage,
+	}, nil
+}
+
+// ChangePassword implements authpb.AuthServiceServer.
+func (a *authHandler) ChangePassword(ctx context.Context, req *authpb.ChangePasswordRequest) (*userpb.MessageResponse, error) {
+	logger.Info("Received change password request")
+	if req == nil || req.UserId == "" {
+		return nil, errs.ToGRPCError(errs.ErrInvalidRequest)
+	}
+
+	if err := a.usecase.ChangePassword(ctx, req.UserId, req.CurrentPassword, req.NewPassword, req.CurrentSessionId); err != nil {
+		logger.Error("Failed to change password", zap.String("user_id", req.UserId), zap.Error(err))
+		return nil, errs.ToGRPCError(err)
+	}
+
+	logger.Info("Password changed successfully", zap.String("user_id", req.UserId))
+	return &userpb.MessageResponse{
+		Message: constants.PasswordChangedMessage,
+	}, nil
+}
+
+// ChangeEmail implements authpb.AuthServiceServer.
+func (a *authHandler) ChangeEmail(ctx context.Context, req *authpb.ChangeEmailRequest) (*userpb.MessageResponse, error) {
+	logger.Info("Received change email request")
+	if req == nil || req.UserId == "" || req.NewEmail == "" {
+		return nil, errs.ToGRPCError(errs.ErrInvalidRequest)
+	}
+
+	if err := a.usecase.ChangeEmail(ctx, req.UserId, dto.ToDomainReauthentication(req), req.NewEmail, req.CurrentSessionId); err != nil {
+		logger.Error("Failed to change email", zap.String("user_id", req.UserId), zap.Error(err))
+		return nil, errs.ToGRPCError(err)
+	}
+
+	logger.Info("Email change confirmation sent", zap.String("user_id", req.UserId))
+	return &userpb.MessageResponse{
+		Message: constants.EmailChangeSentMessage,
+	}, nil
+}
+
+// ConfirmEmailChange implements authpb.AuthServiceServer.
+func (a *authHandler) ConfirmEmailChange(ctx context.Context, req *authpb.ConfirmEmailChangeRequest) (*userpb.MessageResponse, error) {
+	logger.Info("Received confirm email change request")
+	if req == nil {
+		return nil, errs.ToGRPCError(errs.ErrInvalidRequest)
+	}
+
+	if err := a.usecase.ConfirmEmailChange(ctx, req.Token); err != nil {
+		logger.Error("Failed to confirm email change", zap.Error(err))
+		return nil, errs.ToGRPCError(err)
+	}
+
+	logger.Info("Email changed successfully")
+	return &userpb.MessageResponse{
+		Message: constants.EmailChangedMessage,
+	}, nil
+}
+
+// ListSessions implements authpb.AuthServiceServer.
+func (a *authHandler) ListSessions(ctx context.Context, req *authpb.ListSessionsRequest) (*authpb.ListSessionsResponse, error) {
+	logger.Info("Received list sessions request")
+	if req == nil || req.UserId == "" {
+		return nil, errs.ToGRPCError(errs.ErrInvalidRequest)
+	}
+
+	sessions, err := a.usecase.ListSessions(ctx, req.UserId, req.CurrentSessionId)
+	if err != nil {
+		logger.Error("Failed to list sessions", zap.String("user_id", req.UserId), zap.Error(err))
+		return nil, errs.ToGRPCError(err)
+	}
+
+	return &authpb.ListSessionsResponse{
+		Sessions: dto.ToProtoSessions(sessions),
+	}, nil
+}
+
+// RevokeSession implements authpb.AuthServiceServer.
+func (a *authHandler) RevokeSession(ctx context.Context, req *authpb.RevokeSessionRequest) (*userpb.MessageResponse, error) {
+	logger.Info("Received revoke session request")
+	if req == nil || req.UserId == "" || req.SessionId == "" {
+		return nil, errs.ToGRPCError(errs.ErrInvalidRequest)
+	}
+
+	if err := a.usecase.RevokeSession(ctx, req.UserId, req.SessionId); err != nil {
+		logger.Error("Failed to revoke session", zap.String("user_id", req.UserId), zap.String("session_id", req.SessionId), zap.Error(err))
+		return nil, errs.ToGRPCError(err)
+	}
+
+	logger.Info("Session revoked successfully", zap.String("user_id", req.UserId), zap.String("session_id", req.SessionId))
+	return &userpb.MessageResponse{
+		Message: constants.SessionRevokedMessage,
+	}, nil
+}
+
+// RevokeAllSessions implements authpb.AuthServiceServer.
+func (a *authHandler) RevokeAllSessions(ctx context.Context, req *authpb.RevokeAllSessionsRequest) (*authpb.RevokeAllSessionsResponse, error) {
+	logger.Info("Received revoke all sessions request")
+	if req == nil || req.UserId == "" {
+		return nil, errs.ToGRPCError(errs.ErrInvalidRequest)
+	}
+
+	revoked, err := a.usecase.RevokeAllSessions(ctx, req.UserId, req.ExceptSessionId)
+	if err != nil {
+		logger.Error("Failed to revoke sessions", zap.String("user_id", req.UserId), zap.Error(err))
+		return nil, errs.ToGRPCError(err)
+	}
+
+	logger.Info("Sessions revoked successfully", zap.String("user_id", req.UserId), zap.Int("revoked", revoked))
+	return &authpb.RevokeAllSessionsResponse{
+		Revoked: int32(revoked),
+	}, nil
+}
+
+// EnrollMFA implements authpb.AuthServiceServer.
+func (a *authHandler) EnrollMFA(ctx context.Context, req *authpb.EnrollMFARequest) (*authpb.EnrollMFAResponse, error) {
+	logger.Info("Received two-factor enrollment request")
+	if req == nil || req.UserId == "" {
+		return nil, errs.ToGRPCError(errs.ErrInvalidRequest)
+	}
+
+	enrollment, err := a.usecase.EnrollMFA(ctx, req.UserId)
+	if err != nil {
+		logger.Error("Failed to enroll two-factor authentication", zap.String("user_id", req.UserId), zap.Error(err))
+		return nil, errs.ToGRPCError(err)
+	}
+
+	return &authpb.EnrollMFAResponse{
+		Secret:          enrollment.Secret,
+		ProvisioningUri: enrollment.ProvisioningURI,
+	}, nil
+}
+
+// ConfirmMFA implements authpb.AuthServiceServer.
+func (a *authHandler) ConfirmMFA(ctx context.Context, req *authpb.ConfirmMFARequest) (*authpb.ConfirmMFAResponse, error) {
+	logger.Info("Received two-factor confirmation request")
+	if req == nil || req.UserId == "" {
+		return nil, errs.ToGRPCError(errs.ErrInvalidRequest)
+	}
+
+	codes, err := a.usecase.ConfirmMFA(ctx, req.UserId, req.Code)
+	if err != nil {
+		logger.Error("Failed to confirm two-factor authentication", zap.String("user_id", req.UserId), zap.Error(err))
+		return nil, errs.ToGRPCError(err)
+	}
+
+	logger.Info("Two-factor authentication enabled", zap.String("user_id", req.UserId))
+	return &authpb.ConfirmMFAResponse{
+		RecoveryCodes: codes,
+	}, nil
+}
+
+// DisableMFA implements authpb.AuthServiceServer.
+func (a *authHandler) DisableMFA(ctx context.Context, req *authpb.DisableMFARequest) (*userpb.MessageResponse, error) {
+	logger.Info("Received two-factor disable request")
+	if req == nil || req.UserId == "" {
+		return nil, errs.ToGRPCError(errs.ErrInvalidRequest)
+	}
+
+	if err := a.usecase.DisableMFA(ctx, req.UserId, dto.ToDomainReauthentication(req), req.Code); err != nil {
+		logger.Error("Failed to disable two-factor authentication", zap.String("user_id", req.UserId), zap.Error(err))
+		return nil, errs.ToGRPCError(err)
+	}
+
+	logger.Info("Two-factor authentication disabled", zap.String("user_id", req.UserId))
+	return &userpb.MessageResponse{
+		Message: constants.MFADisabledMessage,
+	}, nil
+}
+
+// DeleteAccount implements authpb.AuthServiceServer.
+func (a *authHandler) DeleteAccount(ctx context.Context, req *authpb.DeleteAccountRequest) (*authpb.AccountRequestResponse, error) {
+	logger.Info("Received delete account request")
+	if req == nil || req.UserId == "" {
+		return nil, errs.ToGRPCError(errs.ErrInvalidRequest)
+	}
+
+	request, err := a.accounts.DeleteAccount(ctx, req.UserId, dto.ToDomainReauthentication(req))
+	if err != nil {
+		logger.Error("Failed to delete account", zap.String("user_id", req.UserId), zap.Error(err))
+		return nil, errs.ToGRPCError(err)
+	}
+
+	logger.Info("Account deleted", zap.String("user_id", req.UserId), zap.String("request_id", request.RequestID))
+	return a.accountRequestResponse(request)
+}
+
+// ExportMyData implements authpb.AuthServiceServer.
+func (a *authHandler) ExportMyData(ctx context.Context, req *authpb.ExportMyDataRequest) (*authpb.AccountRequestResponse, error) {
+	logger.Info("Received data export request")
+	if req == nil || req.UserId == "" {
+		return nil, errs.ToGRPCError(errs.ErrInvalidRequest)
+	}
+
+	request, err := a.accounts.ExportMyData(ctx, req.UserId)
+	if err != nil {
+		logger.Error("Failed to export data", zap.String("user_id", req.UserId), zap.Error(err))
+		return nil, errs.ToGRPCError(err)
+	}
+
+	logger.Info("Data export started", zap.String("user_id", req.UserId), zap.String("request_id", request.RequestID))
+	return a.accountRequestResponse(request)
+}
+
+// GetAccountRequest implements authpb.AuthServiceServer.
+func (a *authHandler) GetAccountRequest(ctx context.Context, req *authpb.GetAccountRequestRequest) (*authpb.AccountRequestResponse, error) {
+	logger.Info("Received account request status request")
+	if req == nil || req.UserId == "" || req.RequestId == "" {
+		return nil, errs.ToGRPCError(errs.ErrInvalidRequest)
+	}
+
+	request, err := a.accounts.GetAccountRequest(ctx, req.UserId, req.RequestId)
+	if err != nil {
+		logger.Error("Failed to get account request", zap.String("user_id", req.UserId), zap.String("request_id", req.RequestId), zap.Error(err))
+		return nil, errs.ToGRPCError(err)
+	}
+
+	return a.accountRequestResponse(request)
+}
+
+func (a *authHandler) accountRequestResponse(request *domain.AccountRequest) (*authpb.AccountRequestResponse, error) {
+	result, err := dto.ToProtoAccountRequest(request)
+	if err != nil {
+		logger.Error("Failed to convert account request", zap.String("request_id", request.RequestID), zap.Error(err))
+		return nil, errs.ToGRPCError(errs.ErrInternalServer)
+	}
+
+	return &authpb.AccountRequestResponse{
+		Request: result,
+	}, nil
+}
+
+func NewGrpcAuthHandler(s *grpc.Server, usecase domain.AuthUseCase, accounts domain.AccountUseCase) {
+	handler := &authHandler{usecase: usecase, accounts: accounts}
 	authpb.RegisterAuthServiceServer(s, handler)
 }
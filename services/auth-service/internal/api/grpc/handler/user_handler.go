@@ -5,6 +5,7 @@ import (
 
 	"github.com/tamirat-dejene/ha-soranu/services/auth-service/internal/api/grpc/dto"
 	"github.com/tamirat-dejene/ha-soranu/services/auth-service/internal/domain"
+	constants "github.com/tamirat-dejene/ha-soranu/services/auth-service/internal/domain/const"
 	errs "github.com/tamirat-dejene/ha-soranu/services/auth-service/internal/domain/err"
 	"github.com/tamirat-dejene/ha-soranu/shared/pkg/logger"
 	"github.com/tamirat-dejene/ha-soranu/shared/protos/userpb"
@@ -20,38 +21,128 @@ type userHandler struct {
 // GetDrivers implements [userpb.UserServiceServer].
 func (u *userHandler) GetDrivers(ctx context.Context, req *userpb.GetDriversRequest) (*userpb.DriverResponse, error) {
 	if req == nil {
-		return nil, errs.ErrInvalidRequest
+		return nil, errs.ToGRPCError(errs.ErrInvalidRequest)
 	}
 
 	drivers, err := u.userUsecase.GetDrivers(ctx, req.Latitude, req.Longitude, req.RadiusKm)
 	if err != nil {
-		return nil, err
+		return nil, errs.ToGRPCError(err)
 	}
 
 	var protoDrivers []string
+	var nearbyDrivers []*userpb.NearbyDriver
 	for _, driver := range drivers {
 		protoDrivers = append(protoDrivers, driver.DriverID)
+		nearbyDrivers = append(nearbyDrivers, dto.ToProtoNearbyDriver(driver))
 	}
 
 	return &userpb.DriverResponse{
 		DriverIds: protoDrivers,
+		Drivers:   nearbyDrivers,
 	}, nil
 }
 
 // RemoveDriver implements [userpb.UserServiceServer].
-func (u *userHandler) RemoveDriver(context.Context, *userpb.RemoveDriverRequest) (*userpb.MessageResponse, error) {
-	panic("unimplemented")
+func (u *userHandler) RemoveDriver(ctx context.Context, req *userpb.RemoveDriverRequest) (*userpb.MessageResponse, error) {
+	if req == nil || req.DriverId == "" {
+		return nil, errs.ToGRPCError(errs.ErrInvalidRequest)
+	}
+
+	if err := u.userUsecase.RemoveDriver(ctx, req.DriverId); err != nil {
+		return nil, errs.ToGRPCError(err)
+	}
+
+	return &userpb.MessageResponse{
+		Message: constants.DriverRemovedMessage,
+	}, nil
+}
+
+// GetDriverProfile implements [userpb.UserServiceServer].
+func (u *userHandler) GetDriverProfile(ctx context.Context, req *userpb.GetDriverProfileRequest) (*userpb.DriverProfileResponse, error) {
+	if req == nil || req.UserId == "" {
+		return nil, errs.ToGRPCError(errs.ErrInvalidRequest)
+	}
+
+	driver, err := u.userUsecase.GetDriverProfile(ctx, req.UserId)
+	if err != nil {
+		return nil, errs.ToGRPCError(err)
+	}
+
+	return &userpb.DriverProfileResponse{
+		Profile: dto.ToProtoDriverProfile(driver),
+	}, nil
+}
+
+// UpdateVehicle implements [userpb.UserServiceServer].
+func (u *userHandler) UpdateVehicle(ctx context.Context, req *userpb.UpdateVehicleRequest) (*userpb.DriverProfileResponse, error) {
+	if req == nil || req.UserId == "" {
+		return nil, errs.ToGRPCError(errs.ErrInvalidRequest)
+	}
+
+	driver, err := u.userUsecase.UpdateVehicle(ctx, req.UserId, dto.ToDomainVehicle(req.Vehicle))
+	if err != nil {
+		return nil, errs.ToGRPCError(err)
+	}
+
+	return &userpb.DriverProfileResponse{
+		Profile: dto.ToProtoDriverProfile(driver),
+	}, nil
+}
+
+// GoOnline implements [userpb.UserServiceServer].
+func (u *userHandler) GoOnline(ctx context.Context, req *userpb.GoOnlineRequest) (*userpb.MessageResponse, error) {
+	if req == nil || req.UserId == "" {
+		return nil, errs.ToGRPCError(errs.ErrInvalidRequest)
+	}
+
+	if err := u.userUsecase.GoOnline(ctx, req.UserId, req.Latitude, req.Longitude); err != nil {
+		return nil, errs.ToGRPCError(err)
+	}
+
+	return &userpb.MessageResponse{
+		Message: constants.DriverOnlineMessage,
+	}, nil
+}
+
+// GoOffline implements [userpb.UserServiceServer].
+func (u *userHandler) GoOffline(ctx context.Context, req *userpb.GoOfflineRequest) (*userpb.MessageResponse, error) {
+	if req == nil || req.UserId == "" {
+		return nil, errs.ToGRPCError(errs.ErrInvalidRequest)
+	}
+
+	if err := u.userUsecase.GoOffline(ctx, req.UserId); err != nil {
+		return nil, errs.ToGRPCError(err)
+	}
+
+	return &userpb.MessageResponse{
+		Message: constants.DriverOfflineMessage,
+	}, nil
+}
+
+// UpdateDriverLocation implements [userpb.UserServiceServer].
+func (u *userHandler) UpdateDriverLocation(ctx context.Context, req *userpb.UpdateDriverLocationRequest) (*userpb.MessageResponse, error) {
+	if req == nil || req.UserId == "" {
+		return nil, errs.ToGRPCError(errs.ErrInvalidRequest)
+	}
+
+	if err := u.userUsecase.UpdateDriverLocation(ctx, req.UserId, req.Latitude, req.Longitude); err != nil {
+		return nil, errs.ToGRPCError(err)
+	}
+
+	return &userpb.MessageResponse{
+		Message: constants.DriverLocationUpdatedMessage,
+	}, nil
 }
 
 // BeDriver implements [userpb.UserServiceServer].
 func (u *userHandler) BeDriver(ctx context.Context, req *userpb.BeDriverRequest) (*userpb.BeDriverResponse, error) {
 	if req == nil {
-		return nil, errs.ErrInvalidRequest
+		return nil, errs.ToGRPCError(errs.ErrInvalidRequest)
 	}
 
-	driverID, err := u.userUsecase.BeDriver(ctx, req.UserId)
+	driverID, err := u.userUsecase.BeDriver(ctx, req.UserId, dto.ToDomainVehicle(req.Vehicle))
 	if err != nil {
-		return nil, err
+		return nil, errs.ToGRPCError(err)
 	}
 
 	logger.Info("user became a driver", zap.String("user_id", req.UserId), zap.String("driver_id", driverID))
@@ -64,12 +155,12 @@ func (u *userHandler) BeDriver(ctx context.Context, req *userpb.BeDriverRequest)
 // AddAddress implements userpb.UserServiceServer.
 func (u *userHandler) AddAddress(ctx context.Context, req *userpb.AddAddressRequest) (*userpb.AddAddressResponse, error) {
 	if req == nil {
-		return nil, errs.ErrInvalidRequest
+		return nil, errs.ToGRPCError(errs.ErrInvalidRequest)
 	}
 
 	address, err := u.userUsecase.AddAddress(ctx, req.UserId, dto.ToDomainAddress(req))
 	if err != nil {
-		return nil, err
+		return nil, errs.ToGRPCError(err)
 	}
 
 	return &userpb.AddAddressResponse{
@@ -78,15 +169,46 @@ func (u *userHandler) AddAddress(ctx context.Context, req *userpb.AddAddressRequ
 
 }
 
+// UpdateAddress implements userpb.UserServiceServer.
+func (u *userHandler) UpdateAddress(ctx context.Context, req *userpb.UpdateAddressRequest) (*userpb.AddAddressResponse, error) {
+	if req == nil || req.UserId == "" || req.AddressId == "" {
+		return nil, errs.ToGRPCError(errs.ErrInvalidRequest)
+	}
+
+	address, err := u.userUsecase.UpdateAddress(ctx, req.UserId, dto.ToDomainAddressUpdate(req))
+	if err != nil {
+		return nil, errs.ToGRPCError(err)
+	}
+
+	return &userpb.AddAddressResponse{
+		Address: dto.ToProtoAddress(*address),
+	}, nil
+}
+
+// SetDefaultAddress implements userpb.UserServiceServer.
+func (u *userHandler) SetDefaultAddress(ctx context.Context, req *userpb.SetDefaultAddressRequest) (*userpb.MessageResponse, error) {
+	if req == nil || req.UserId == "" || req.AddressId == "" {
+		return nil, errs.ToGRPCError(errs.ErrInvalidRequest)
+	}
+
+	if err := u.userUsecase.SetDefaultAddress(ctx, req.UserId, req.AddressId); err != nil {
+		return nil, errs.ToGRPCError(err)
+	}
+
+	return &userpb.MessageResponse{
+		Message: constants.DefaultAddressSetMessage,
+	}, nil
+}
+
 // AddPhoneNumber implements userpb.UserServiceServer.
 func (u *userHandler) AddPhoneNumber(ctx context.Context, req *userpb.AddPhoneNumberRequest) (*userpb.MessageResponse, error) {
 	if req == nil {
-		return nil, errs.ErrInvalidRequest
+		return nil, errs.ToGRPCError(errs.ErrInvalidRequest)
 	}
 
 	err := u.userUsecase.AddPhoneNumber(ctx, req.UserId, req.PhoneNumber)
 	if err != nil {
-		return nil, err
+		return nil, errs.ToGRPCError(err)
 	}
 
 	return &userpb.MessageResponse{
@@ -97,12 +219,12 @@ func (u *userHandler) AddPhoneNumber(ctx context.Context, req *userpb.AddPhoneNu
 // GetAddresses implements userpb.UserServiceServer.
 func (u *userHandler) GetAddresses(ctx context.Context, req *userpb.GetAddressesRequest) (*userpb.GetAddressesResponse, error) {
 	if req == nil {
-		return nil, errs.ErrInvalidRequest
+		return nil, errs.ToGRPCError(errs.ErrInvalidRequest)
 	}
 
 	addresses, err := u.userUsecase.GetAddresses(ctx, req.UserId)
 	if err != nil {
-		return nil, err
+		return nil, errs.ToGRPCError(err)
 	}
 
 	var protoAddresses []*userpb.Address
@@ -118,12 +240,12 @@ func (u *userHandler) GetAddresses(ctx context.Context, req *userpb.GetAddresses
 // GetPhoneNumber implements userpb.UserServiceServer.
 func (u *userHandler) GetPhoneNumber(ctx context.Context, req *userpb.GetPhoneNumberRequest) (*userpb.GetPhoneNumberResponse, error) {
 	if req == nil {
-		return nil, errs.ErrInvalidRequest
+		return nil, errs.ToGRPCError(errs.ErrInvalidRequest)
 	}
 
 	user, err := u.userUsecase.GetUser(ctx, req.UserId)
 	if err != nil {
-		return nil, err
+		return nil, errs.ToGRPCError(err)
 	}
 
 	return &userpb.GetPhoneNumberResponse{
@@ -134,7 +256,7 @@ func (u *userHandler) GetPhoneNumber(ctx context.Context, req *userpb.GetPhoneNu
 // GetUser implements userpb.UserServiceServer.
 func (u *userHandler) GetUser(ctx context.Context, req *userpb.GetUserRequest) (*userpb.GetUserResponse, error) {
 	if req == nil {
-		return nil, errs.ErrInvalidRequest
+		return nil, errs.ToGRPCError(errs.ErrInvalidRequest)
 	}
 
 	user, err := u.userUsecase.GetUser(ctx, req.UserId)
@@ -142,7 +264,26 @@ func (u *userHandler) GetUser(ctx context.Context, req *userpb.GetUserRequest) (
 		if err == nil {
 			err = errs.ErrUserNotFound
 		}
-		return nil, err
+		return nil, errs.ToGRPCError(err)
+	}
+
+	return &userpb.GetUserResponse{
+		User: dto.ToProtoUser(user),
+	}, nil
+}
+
+// UpdateProfile implements userpb.UserServiceServer.
+func (u *userHandler) UpdateProfile(ctx context.Context, req *userpb.UpdateProfileRequest) (*userpb.GetUserResponse, error) {
+	if req == nil || req.UserId == "" {
+		return nil, errs.ToGRPCError(errs.ErrInvalidRequest)
+	}
+
+	user, err := u.userUsecase.UpdateProfile(ctx, req.UserId, req.Username)
+	if err != nil || user == nil {
+		if err == nil {
+			err = errs.ErrUserNotFound
+		}
+		return nil, errs.ToGRPCError(err)
 	}
 
 	return &userpb.GetUserResponse{
@@ -153,12 +294,12 @@ func (u *userHandler) GetUser(ctx context.Context, req *userpb.GetUserRequest) (
 // RemoveAddress implements userpb.UserServiceServer.
 func (u *userHandler) RemoveAddress(ctx context.Context, req *userpb.RemoveAddressRequest) (*userpb.MessageResponse, error) {
 	if req == nil {
-		return nil, errs.ErrInvalidRequest
+		return nil, errs.ToGRPCError(errs.ErrInvalidRequest)
 	}
 
 	err := u.userUsecase.RemoveAddress(ctx, req.UserId, req.AddressId)
 	if err != nil {
-		return nil, err
+		return nil, errs.ToGRPCError(err)
 	}
 
 	return &userpb.MessageResponse{
@@ -169,12 +310,12 @@ func (u *userHandler) RemoveAddress(ctx context.Context, req *userpb.RemoveAddre
 // RemovePhoneNumber implements userpb.UserServiceServer.
 func (u *userHandler) RemovePhoneNumber(ctx context.Context, req *userpb.RemovePhoneNumberRequest) (*userpb.MessageResponse, error) {
 	if req == nil {
-		return nil, errs.ErrInvalidRequest
+		return nil, errs.ToGRPCError(errs.ErrInvalidRequest)
 	}
 
 	err := u.userUsecase.RemovePhoneNumber(ctx, req.UserId)
 	if err != nil {
-		return nil, err
+		return nil, errs.ToGRPCError(err)
 	}
 
 	return &userpb.MessageResponse{
@@ -185,12 +326,12 @@ func (u *userHandler) RemovePhoneNumber(ctx context.Context, req *userpb.RemoveP
 // UpdatePhoneNumber implements userpb.UserServiceServer.
 func (u *userHandler) UpdatePhoneNumber(ctx context.Context, req *userpb.UpdatePhoneNumberRequest) (*userpb.MessageResponse, error) {
 	if req == nil {
-		return nil, errs.ErrInvalidRequest
+		return nil, errs.ToGRPCError(errs.ErrInvalidRequest)
 	}
 
 	err := u.userUsecase.UpdatePhoneNumber(ctx, req.UserId, req.PhoneNumber)
 	if err != nil {
-		return nil, err
+		return nil, errs.ToGRPCError(err)
 	}
 
 	return &userpb.MessageResponse{
@@ -0,0 +1,41 @@
+package apihttp
+
+import (
+	"encoding/json"
+	"net/http"
+
+	internalutil "github.com/tamirat-dejene/ha-soranu/services/auth-service/internal/util"
+)
+
+// jwksMaxAge is how long clients may cache the key set, in seconds. Keys are
+// published ahead of their use, so this only delays when retired keys stop
+// being accepted.
+const jwksMaxAge = "300"
+
+type Server struct {
+	accessKeys *internalutil.AccessKeySet
+}
+
+func NewServer(accessKeys *internalutil.AccessKeySet) *Server {
+	return &Server{accessKeys: accessKeys}
+}
+
+func (s *Server) Routes() http.Handler {
+	mux := http.NewServeMux()
+	mux.HandleFunc("/healthz", func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusOK)
+		_, _ = w.Write([]byte("ok"))
+	})
+	mux.HandleFunc("/.well-known/jwks.json", s.handleJWKS)
+	return mux
+}
+
+func (s *Server) handleJWKS(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodGet {
+		w.WriteHeader(http.StatusMethodNotAllowed)
+		return
+	}
+	w.Header().Set("Content-Type", "application/json")
+	w.Header().Set("Cache-Control", "public, max-age="+jwksMaxAge)
+	_ = json.NewEncoder(w).Encode(s.accessKeys.JWKS())
+}
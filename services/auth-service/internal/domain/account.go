@@ -0,0 +1,80 @@
+package domain
+
+import (
+	"context"
+	"time"
+)
+
+// AccountRequestKind tells apart account deletions and data exports.
+type AccountRequestKind string
+
+const (
+	AccountDeletion AccountRequestKind = "DELETION"
+	AccountExport   AccountRequestKind = "EXPORT"
+)
+
+type AccountRequestStatus string
+
+const (
+	AccountRequestPending   AccountRequestStatus = "PENDING"
+	AccountRequestCompleted AccountRequestStatus = "COMPLETED"
+	AccountRequestFailed    AccountRequestStatus = "FAILED"
+)
+
+// AccountRequest is a user's request to delete their account or to export
+// their data. Every service holding data of the user carries out its part
+// and reports back; the request completes once all of them have.
+type AccountRequest struct {
+	RequestID string
+	UserID    string
+	Kind      AccountRequestKind
+	Status    AccountRequestStatus
+	// NotifyEmail is where the outcome of the request is reported.
+	NotifyEmail string
+	CreatedAt   time.Time
+	// CompletedAt is the zero time while the request is pending.
+	CompletedAt time.Time
+	Services    []AccountRequestService
+}
+
+// AccountRequestService is the part of a request a service carries out.
+type AccountRequestService struct {
+	Service string
+	Status  AccountRequestStatus
+	// Data is the service's share of an export, as JSON.
+	Data        []byte
+	Error       string
+	CompletedAt time.Time
+}
+
+type AccountUseCase interface {
+	// DeleteAccount reauthenticates the user, deletes the account and asks
+	// every other service to delete the user's data.
+	DeleteAccount(ctx context.Context, userID string, proof Reauthentication) (*AccountRequest, error)
+	// ExportMyData asks every service for the user's data. The export can be
+	// downloaded with GetAccountRequest once it has completed.
+	ExportMyData(ctx context.Context, userID string) (*AccountRequest, error)
+	GetAccountRequest(ctx context.Context, userID, requestID string) (*AccountRequest, error)
+
+	// StartConsumer records what services report about requests until ctx is
+	// cancelled.
+	StartConsumer(ctx context.Context) error
+}
+
+type AccountRequestRepository interface {
+	// CreateRequest stores a pending request awaiting the given services. It
+	// fails with errs.ErrAccountRequestPending when the user already has a
+	// request of the kind in progress.
+	CreateRequest(ctx context.Context, request AccountRequest, services []string) (*AccountRequest, error)
+	// GetRequest returns the user's request with its services, or nil when
+	// the user has no such request.
+	GetRequest(ctx context.Context, userID, requestID string) (*AccountRequest, error)
+	// RecordServiceResult records the outcome of a service's part of a pending
+	// request. When it was the last part awaited the request is completed, or
+	// failed if any part failed, and returned; otherwise it returns nil.
+	RecordServiceResult(ctx context.Context, requestID string, result AccountRequestService) (*AccountRequest, error)
+	// FailRequest fails a pending request that could not be started.
+	FailRequest(ctx context.Context, requestID string) error
+	// ClearNotifyEmail forgets where the outcome of the request was reported.
+	ClearNotifyEmail(ctx context.Context, requestID string) error
+}
@@ -0,0 +1,52 @@
+package domain
+
+import (
+	"context"
+	"time"
+)
+
+// Suspension records why and by whom an account was suspended.
+type Suspension struct {
+	Reason string
+	// SuspendedBy is the admin who suspended the account.
+	SuspendedBy string
+	SuspendedAt time.Time
+}
+
+// UserAccount is a user as admins see them. Suspension is nil unless the
+// user is suspended.
+type UserAccount struct {
+	User       User
+	Roles      []string
+	Suspension *Suspension
+}
+
+// UserSearch selects the users an admin looks for. Query matches emails and
+// usernames case-insensitively; an empty query matches every user.
+type UserSearch struct {
+	Query         string
+	SuspendedOnly bool
+	Limit         int
+	Offset        int
+}
+
+// AdminUseCase lets admins moderate users and manage their roles. Every
+// method fails with errs.ErrUnauthorized unless the call is made for an
+// admin, except that the restaurant service may grant restaurant_owner.
+type AdminUseCase interface {
+	SearchUsers(ctx context.Context, search UserSearch) ([]UserAccount, error)
+	// SuspendUser suspends the user and ends their sessions.
+	SuspendUser(ctx context.Context, userID, reason string) (*UserAccount, error)
+	UnsuspendUser(ctx context.Context, userID string) (*UserAccount, error)
+	// RevokeUserSessions ends every session of the user and returns how many
+	// were ended.
+	RevokeUserSessions(ctx context.Context, userID string) (int, error)
+	// GrantRole gives the user restaurant_owner or admin.
+	GrantRole(ctx context.Context, userID, role string) (*UserAccount, error)
+	// RevokeRole takes restaurant_owner or admin from the user.
+	RevokeRole(ctx context.Context, userID, role string) (*UserAccount, error)
+	// BootstrapAdmins makes the users with the given emails admins, provided
+	// they verified their email. The service runs it at startup, to create
+	// the first admins; it is not served to callers.
+	BootstrapAdmins(ctx context.Context, emails []string) error
+}
@@ -1,6 +1,9 @@
 package domain
 
-import "context"
+import (
+    "context"
+    "time"
+)
 
 type AuthTokens struct {
     AccessToken  string
@@ -12,28 +15,130 @@ type UserRegister struct {
     Password    string
     Username    string
     PhoneNumber string
+    Client      ClientInfo
 }
 
 type LoginWithEmail struct {
     Email    string
     Password string
+    Client   ClientInfo
 }
 
 type LoginWithGoogle struct {
     IDToken string
+    Client  ClientInfo
 }
 
 type AuthUseCase interface {
     Register(ctx context.Context, input UserRegister) (*User, *AuthTokens, error)
-    LoginWithEmailAndPassword(ctx context.Context, input LoginWithEmail) (*User, *AuthTokens, error)
-    LoginWithGoogle(ctx context.Context, input LoginWithGoogle) (*User, *AuthTokens, error)
+    LoginWithEmailAndPassword(ctx context.Context, input LoginWithEmail) (*LoginResult, error)
+    LoginWithGoogle(ctx context.Context, input LoginWithGoogle) (*LoginResult, error)
+    // LoginWithIdentityProvider logs in the user an identity provider vouches
+    // for. On first login the provider account is linked to the user with
+    // the same email, or to a new user. Users with two-factor authentication
+    // get a challenge like after their password.
+    LoginWithIdentityProvider(ctx context.Context, input LoginWithIdentityProvider) (*LoginResult, error)
+    // IdentityProviders names the identity providers users can log in with.
+    IdentityProviders() []string
     Logout(ctx context.Context, refreshToken string) error
-    RefreshTokens(ctx context.Context, refreshToken string) (*AuthTokens, error)
+    RefreshTokens(ctx context.Context, refreshToken string, client ClientInfo) (*AuthTokens, error)
+
+    // ChangePassword replaces the user's password after checking the current
+    // one and ends every other session of the user.
+    ChangePassword(ctx context.Context, userID, currentPassword, newPassword, currentSessionID string) error
+    // ChangeEmail emails a confirmation link to newEmail after
+    // reauthenticating the user. The email changes once the link is opened.
+    ChangeEmail(ctx context.Context, userID string, proof Reauthentication, newEmail, currentSessionID string) error
+    // ConfirmEmailChange changes the user's email to the one the link was sent
+    // to and ends every session of the user but the one that asked for it.
+    ConfirmEmailChange(ctx context.Context, token string) error
+    // ListSessions returns the user's active sessions, marking currentSessionID
+    // as the current one.
+    ListSessions(ctx context.Context, userID, currentSessionID string) ([]Session, error)
+    RevokeSession(ctx context.Context, userID, sessionID string) error
+    // RevokeAllSessions ends every session of the user but exceptSessionID,
+    // which may be empty, and returns how many were ended.
+    RevokeAllSessions(ctx context.Context, userID, exceptSessionID string) (int, error)
+
+    // SendEmailVerification emails the user a new verification link.
+    SendEmailVerification(ctx context.Context, userID string) error
+    VerifyEmail(ctx context.Context, token string) error
+    // RequestPasswordReset emails a reset link when the email belongs to a
+    // user. It succeeds either way so that it does not reveal accounts.
+    RequestPasswordReset(ctx context.Context, email string) error
+    ResetPassword(ctx context.Context, token, newPassword string) error
+
+    // CompleteMFALogin finishes a login challenged for a second factor.
+    CompleteMFALogin(ctx context.Context, input MFALogin) (*User, *AuthTokens, error)
+    // EnrollMFA generates a TOTP secret for the user. It takes effect once
+    // confirmed with ConfirmMFA.
+    EnrollMFA(ctx context.Context, userID string) (*MFAEnrollment, error)
+    // ConfirmMFA enables two-factor authentication when code is valid for the
+    // enrolled secret, and returns the user's recovery codes.
+    ConfirmMFA(ctx context.Context, userID, code string) ([]string, error)
+    // DisableMFA turns two-factor authentication off after reauthenticating
+    // the user and checking a code.
+    DisableMFA(ctx context.Context, userID string, proof Reauthentication, code string) error
+}
+
+// OneTimeTokenPurpose tells apart the single-use tokens sent by email.
+type OneTimeTokenPurpose string
+
+const (
+    TokenPurposeEmailVerification OneTimeTokenPurpose = "email_verification"
+    TokenPurposePasswordReset     OneTimeTokenPurpose = "password_reset"
+    TokenPurposeEmailChange       OneTimeTokenPurpose = "email_change"
+)
+
+// OneTimeToken is what a single-use token was issued for. The email makes a
+// token unusable once the account's email has changed.
+type OneTimeToken struct {
+    UserID string
+    Email  string
+
+    // NewEmail is the email an email change link was sent to, and SessionID
+    // the session that asked for the change.
+    NewEmail  string
+    SessionID string
 }
 
 type AuthRepository interface {
-    SaveRefreshToken(ctx context.Context, userID string, tokenId string) error
-    DeleteRefreshToken(ctx context.Context, tokenId string) error
-    ValidateRefreshToken(ctx context.Context, tokenId string) (string, error)
-    ConsumeRefreshToken(ctx context.Context, tokenId string) (string, error)
-}
\ No newline at end of file
+    // SaveRefreshToken starts a new token family with tokenID as its current token.
+    SaveRefreshToken(ctx context.Context, email, familyID, tokenID string) error
+    // RotateRefreshToken replaces the family's current token with newTokenID and
+    // returns the email it belongs to. Presenting a token that was already
+    // rotated revokes the whole family and fails with errs.ErrTokenReused.
+    RotateRefreshToken(ctx context.Context, familyID, tokenID, newTokenID string) (string, error)
+    RevokeTokenFamily(ctx context.Context, familyID string) error
+
+    // SaveOneTimeToken stores a single-use token, replacing the user's previous
+    // token of the same purpose.
+    SaveOneTimeToken(ctx context.Context, purpose OneTimeTokenPurpose, tokenID string, token OneTimeToken, ttl time.Duration) error
+    // ConsumeOneTimeToken returns what the token was issued for and makes it
+    // unusable. Unknown, expired and used tokens fail with errs.ErrInvalidLink.
+    ConsumeOneTimeToken(ctx context.Context, purpose OneTimeTokenPurpose, tokenID string) (*OneTimeToken, error)
+
+    // SaveMFAChallenge stores the challenge of a login awaiting its second factor.
+    SaveMFAChallenge(ctx context.Context, tokenID string, challenge OneTimeToken, ttl time.Duration) error
+    // UseMFAChallenge counts an attempt at the challenge and returns who it was
+    // issued to with the number of attempts so far. Unknown and expired
+    // challenges fail with errs.ErrMFAChallengeInvalid.
+    UseMFAChallenge(ctx context.Context, tokenID string) (*OneTimeToken, int64, error)
+    DeleteMFAChallenge(ctx context.Context, tokenID string) error
+    // MarkTOTPStepUsed records that the user logged in with the code of a time
+    // step, and reports whether it was the first time.
+    MarkTOTPStepUsed(ctx context.Context, userID string, step int64, ttl time.Duration) (bool, error)
+}
+// LoginAttemptRepository keeps track of failed logins per key, an account or
+// a client IP, and of the keys that may not log in for a while.
+type LoginAttemptRepository interface {
+    // RecordFailure counts a failed login and returns the number of failures
+    // since the first one within window.
+    RecordFailure(ctx context.Context, key string, window time.Duration) (int64, error)
+    ResetFailures(ctx context.Context, key string) error
+    // Block rejects logins for the key until the given time.
+    Block(ctx context.Context, key string, until time.Time) error
+    // BlockedUntil returns when the key's block ends, or the zero time when it
+    // is not blocked.
+    BlockedUntil(ctx context.Context, key string) (time.Time, error)
+}
@@ -6,4 +6,19 @@ const (
 	LogoutSuccessMessage = "Logout successful"
 	AddressAddSuccessMessage = "Address added successfully"
 	AddressRemoveSuccessMessage = "Address removed successfully"
+	DefaultAddressSetMessage = "Default address set successfully"
+	DriverOnlineMessage = "You are online"
+	DriverOfflineMessage = "You are offline"
+	DriverLocationUpdatedMessage = "Location updated"
+	DriverRemovedMessage = "Driver profile removed"
+	EmailVerificationSentMessage = "Verification email sent"
+	EmailVerifiedMessage = "Email verified successfully"
+	// The same message whether or not the email belongs to an account.
+	PasswordResetSentMessage = "If the email is registered, a password reset link has been sent"
+	PasswordResetMessage = "Password reset successfully"
+	PasswordChangedMessage = "Password changed successfully"
+	EmailChangeSentMessage = "A confirmation link has been sent to the new email address"
+	EmailChangedMessage = "Email changed successfully"
+	SessionRevokedMessage = "Session revoked successfully"
+	MFADisabledMessage = "Two-factor authentication disabled"
 )
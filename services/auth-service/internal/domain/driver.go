@@ -0,0 +1,43 @@
+package domain
+
+import (
+	"context"
+	"time"
+)
+
+// Vehicle types a driver can deliver with.
+const (
+	VehicleBicycle    = "bicycle"
+	VehicleScooter    = "scooter"
+	VehicleMotorcycle = "motorcycle"
+	VehicleCar        = "car"
+	VehicleVan        = "van"
+)
+
+// Vehicle is the vehicle a driver delivers with. A driver without one has an
+// empty Type.
+type Vehicle struct {
+	Type        string
+	Make        string
+	Model       string
+	PlateNumber string
+	Color       string
+}
+
+// DriverLocation is the last known location of an online driver.
+type DriverLocation struct {
+	DriverID   string
+	Latitude   float64
+	Longitude  float64
+	DistanceKm float64
+}
+
+// DriverLocationRepository keeps the live locations of online drivers. A
+// location is forgotten when it is not updated within its TTL.
+type DriverLocationRepository interface {
+	SetLocation(ctx context.Context, driverID string, latitude, longitude float64, ttl time.Duration) error
+	RemoveLocation(ctx context.Context, driverID string) error
+	// Nearby returns the drivers with a live location within radiusKm of the
+	// given position, the nearest first.
+	Nearby(ctx context.Context, latitude, longitude, radiusKm float64) ([]DriverLocation, error)
+}
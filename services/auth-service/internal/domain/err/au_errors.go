@@ -1,12 +1,17 @@
 package errs
 
 import (
+	"context"
 	"errors"
+	"fmt"
 	"strings"
+	"time"
 
 	"github.com/jackc/pgx/v5/pgconn"
+	"google.golang.org/genproto/googleapis/rpc/errdetails"
 	"google.golang.org/grpc/codes"
 	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/types/known/durationpb"
 )
 
 var (
@@ -26,6 +31,24 @@ var (
 	ErrUnauthorized       = errors.New("unauthorized access")
 	ErrSessionNotFound    = errors.New("session not found or already invalidated")
 	ErrTokenRevoked       = errors.New("token has been revoked")
+	ErrTokenReused        = errors.New("refresh token was already used")
+	ErrInvalidLink        = errors.New("link is invalid or has expired")
+	ErrEmailNotVerified   = errors.New("email address is not verified")
+	ErrTooManyAttempts    = errors.New("too many failed login attempts")
+	ErrAccountSuspended   = errors.New("account is suspended")
+
+	// Identity provider errors
+	ErrUnknownIdentityProvider = errors.New("unknown identity provider")
+	ErrInvalidIdentityToken    = errors.New("identity provider token is invalid")
+	ErrIdentityEmailUnverified = errors.New("identity provider has not verified the email of an existing account")
+	ErrReauthenticationNeeded  = errors.New("a recent sign-in with a linked identity provider is required")
+
+	// Two-factor authentication errors
+	ErrInvalidMFACode      = errors.New("invalid two-factor authentication code")
+	ErrMFAChallengeInvalid = errors.New("two-factor login is invalid or has expired")
+	ErrMFAAlreadyEnabled   = errors.New("two-factor authentication is already enabled")
+	ErrMFANotEnabled       = errors.New("two-factor authentication is not enabled")
+	ErrMFAUnavailable      = errors.New("two-factor authentication is not configured")
 
 	// User domain errors
 	ErrUserNotFound         = errors.New("user not found")
@@ -36,21 +59,135 @@ var (
 	ErrInvalidPassword      = errors.New("password does not meet requirements")
 	ErrAddressNotFound      = errors.New("address not found")
 	ErrAddressAlreadyExists = errors.New("address already exists")
+	ErrInvalidAddress       = errors.New("address is invalid")
+	ErrAddressLimitReached  = errors.New("address limit reached")
+
+	// Driver errors
+	ErrDriverNotFound  = errors.New("driver not found")
+	ErrDriverOffline   = errors.New("driver is offline")
+	ErrVehicleRequired = errors.New("driver has no vehicle")
+	ErrInvalidVehicle  = errors.New("vehicle is invalid")
+	ErrInvalidLocation = errors.New("location is invalid")
+
+	// Account deletion and data export errors
+	ErrAccountRequestPending  = errors.New("a request of this kind is already in progress")
+	ErrAccountRequestNotFound = errors.New("account request not found")
 )
 
+// ThrottledError rejects a login while its account or client is blocked after
+// failed attempts. It matches ErrTooManyAttempts.
+type ThrottledError struct {
+	RetryAfter time.Duration
+}
+
+func (e *ThrottledError) Error() string {
+	return fmt.Sprintf("%s, retry after %s", ErrTooManyAttempts, e.RetryAfter.Round(time.Second))
+}
+
+func (e *ThrottledError) Unwrap() error {
+	return ErrTooManyAttempts
+}
+
+// Reasons a password breaks the password policy.
+const (
+	ReasonPasswordTooShort        = "PASSWORD_TOO_SHORT"
+	ReasonPasswordTooLong         = "PASSWORD_TOO_LONG"
+	ReasonPasswordTooFewClasses   = "PASSWORD_TOO_FEW_CHARACTER_CLASSES"
+	ReasonPasswordInvalidChars    = "PASSWORD_INVALID_CHARACTERS"
+	ReasonPasswordBreached        = "PASSWORD_BREACHED"
+	ReasonPasswordMatchesUserInfo = "PASSWORD_MATCHES_USER_INFO"
+)
+
+// PasswordViolation is a rule of the password policy a password breaks.
+type PasswordViolation struct {
+	Reason      string
+	Description string
+}
+
+// PasswordPolicyError rejects a password that breaks the password policy. It
+// matches ErrInvalidPassword.
+type PasswordPolicyError struct {
+	// Field is the request field holding the password.
+	Field      string
+	Violations []PasswordViolation
+}
+
+func (e *PasswordPolicyError) Error() string {
+	descriptions := make([]string, len(e.Violations))
+	for i, v := range e.Violations {
+		descriptions[i] = v.Description
+	}
+	return fmt.Sprintf("%s: %s", ErrInvalidPassword, strings.Join(descriptions, "; "))
+}
+
+func (e *PasswordPolicyError) Unwrap() error {
+	return ErrInvalidPassword
+}
+
+// AddressFieldViolation is a field of an address that is missing or invalid.
+type AddressFieldViolation struct {
+	Field       string
+	Description string
+}
+
+// AddressError rejects an address with missing or invalid fields. It matches
+// ErrInvalidAddress.
+type AddressError struct {
+	Violations []AddressFieldViolation
+}
+
+func (e *AddressError) Error() string {
+	descriptions := make([]string, len(e.Violations))
+	for i, v := range e.Violations {
+		descriptions[i] = v.Description
+	}
+	return fmt.Sprintf("%s: %s", ErrInvalidAddress, strings.Join(descriptions, "; "))
+}
+
+func (e *AddressError) Unwrap() error {
+	return ErrInvalidAddress
+}
+
 // User-friendly error messages
 const (
-	MsgInvalidRequest         = "Invalid request. Please check your input."
-	MsgInternalError          = "An unexpected error occurred. Please try again later."
-	MsgInvalidCredentials     = "Invalid email or password."
-	MsgEmailAlreadyRegistered = "This email is already registered."
-	MsgUserNotFound           = "User not found."
-	MsgTokenExpired           = "Your session has expired. Please login again."
-	MsgInvalidToken           = "Invalid authentication token."
-	MsgUnauthorized           = "You are not authorized to perform this action."
-	MsgSessionNotFound        = "Session not found. Please login again."
-	MsgAddressNotFound        = "Address not found."
-	MsgRefreshFailed          = "Failed to refresh token. Please login again."
+	MsgInvalidRequest          = "Invalid request. Please check your input."
+	MsgInternalError           = "An unexpected error occurred. Please try again later."
+	MsgInvalidCredentials      = "Invalid email or password."
+	MsgEmailAlreadyRegistered  = "This email is already registered."
+	MsgUserNotFound            = "User not found."
+	MsgTokenExpired            = "Your session has expired. Please login again."
+	MsgInvalidToken            = "Invalid authentication token."
+	MsgUnauthorized            = "You are not authorized to perform this action."
+	MsgSessionNotFound         = "Session not found. Please login again."
+	MsgAddressNotFound         = "Address not found."
+	MsgInvalidAddress          = "Address is invalid."
+	MsgAddressLimitReached     = "You cannot add more addresses. Please remove one first."
+	MsgRefreshFailed           = "Failed to refresh token. Please login again."
+	MsgInvalidLink             = "This link is invalid or has expired."
+	MsgEmailNotVerified        = "Please verify your email address first."
+	MsgTooManyAttempts         = "Too many failed login attempts. Please try again later."
+	MsgAccountSuspended        = "Your account has been suspended. Please contact support."
+	MsgUnknownIdentityProvider = "This sign-in method is not supported."
+	MsgInvalidIdentityToken    = "Sign-in failed. Please try again."
+	MsgIdentityEmailUnverified = "This email is already registered. Please login with your password."
+	MsgReauthenticationNeeded  = "Please sign in with your identity provider again to confirm it is you."
+	MsgInvalidPassword         = "Password does not meet requirements."
+	MsgInvalidMFACode          = "Invalid two-factor authentication code."
+	MsgMFAChallengeInvalid     = "Your login has expired. Please login again."
+	MsgMFAAlreadyEnabled       = "Two-factor authentication is already enabled."
+	MsgMFANotEnabled           = "Two-factor authentication is not enabled."
+	MsgMFAUnavailable          = "Two-factor authentication is not available."
+	MsgDriverNotFound          = "You are not registered as a driver."
+	MsgDriverOffline           = "You are offline. Please go online first."
+	MsgVehicleRequired         = "Please add your vehicle before going online."
+	MsgInvalidVehicle          = "Vehicle is invalid."
+	MsgInvalidLocation         = "Location is invalid."
+	MsgAccountRequestPending   = "A request of this kind is already in progress."
+	MsgAccountRequestNotFound  = "Request not found."
+	MsgUsernameAlreadyUsed     = "This username is already taken."
+	MsgAddressAlreadyExists    = "This address is already saved."
+	MsgNotFound                = "Not found."
+	MsgConflict                = "The request conflicts with another one. Please try again."
 )
 
 // ToGRPCError converts internal errors to user-friendly gRPC status errors
@@ -59,6 +196,11 @@ func ToGRPCError(err error) error {
 		return nil
 	}
 
+	// Errors that already carry a status are passed on as they are.
+	if _, ok := status.FromError(err); ok {
+		return err
+	}
+
 	errMsg := err.Error()
 
 	// Check for specific error types
@@ -66,6 +208,34 @@ func ToGRPCError(err error) error {
 	// Authentication errors
 	case errors.Is(err, ErrInvalidCredentials):
 		return status.Error(codes.Unauthenticated, MsgInvalidCredentials)
+	case errors.Is(err, ErrTooManyAttempts):
+		return throttledStatus(err)
+	case errors.Is(err, ErrAccountSuspended):
+		return status.Error(codes.PermissionDenied, MsgAccountSuspended)
+	case errors.Is(err, ErrInvalidLink):
+		return status.Error(codes.InvalidArgument, MsgInvalidLink)
+	case errors.Is(err, ErrUnknownIdentityProvider):
+		return status.Error(codes.InvalidArgument, MsgUnknownIdentityProvider)
+	case errors.Is(err, ErrInvalidIdentityToken):
+		return status.Error(codes.Unauthenticated, MsgInvalidIdentityToken)
+	case errors.Is(err, ErrIdentityEmailUnverified):
+		return status.Error(codes.FailedPrecondition, MsgIdentityEmailUnverified)
+	case errors.Is(err, ErrReauthenticationNeeded):
+		return status.Error(codes.Unauthenticated, MsgReauthenticationNeeded)
+	case errors.Is(err, ErrEmailNotVerified):
+		return status.Error(codes.FailedPrecondition, MsgEmailNotVerified)
+	case errors.Is(err, ErrInvalidMFACode):
+		return status.Error(codes.Unauthenticated, MsgInvalidMFACode)
+	case errors.Is(err, ErrMFAChallengeInvalid):
+		return status.Error(codes.Unauthenticated, MsgMFAChallengeInvalid)
+	case errors.Is(err, ErrMFAAlreadyEnabled):
+		return status.Error(codes.FailedPrecondition, MsgMFAAlreadyEnabled)
+	case errors.Is(err, ErrMFANotEnabled):
+		return status.Error(codes.FailedPrecondition, MsgMFANotEnabled)
+	case errors.Is(err, ErrMFAUnavailable):
+		return status.Error(codes.FailedPrecondition, MsgMFAUnavailable)
+	case errors.Is(err, ErrInvalidPassword):
+		return passwordPolicyStatus(err)
 	case strings.Contains(errMsg, "bcrypt"):
 		return status.Error(codes.Unauthenticated, MsgInvalidCredentials)
 	case strings.Contains(errMsg, "password"):
@@ -78,6 +248,8 @@ func ToGRPCError(err error) error {
 		return status.Error(codes.Unauthenticated, MsgSessionNotFound)
 	case errors.Is(err, ErrUnauthorized):
 		return status.Error(codes.PermissionDenied, MsgUnauthorized)
+	case errors.Is(err, ErrTokenRevoked), errors.Is(err, ErrTokenReused):
+		return status.Error(codes.Unauthenticated, MsgRefreshFailed)
 	case errors.Is(err, ErrRefreshFailed):
 		return status.Error(codes.Unauthenticated, MsgRefreshFailed)
 	case strings.Contains(errMsg, "invalid or expired refresh token"):
@@ -92,18 +264,50 @@ func ToGRPCError(err error) error {
 		return status.Error(codes.AlreadyExists, MsgEmailAlreadyRegistered)
 	case errors.Is(err, ErrEmailAlreadyUsed):
 		return status.Error(codes.AlreadyExists, MsgEmailAlreadyRegistered)
+	case errors.Is(err, ErrUsernameAlreadyUsed):
+		return status.Error(codes.AlreadyExists, MsgUsernameAlreadyUsed)
+	case errors.Is(err, ErrAddressAlreadyExists):
+		return status.Error(codes.AlreadyExists, MsgAddressAlreadyExists)
 	case strings.Contains(errMsg, "already exists"):
 		return status.Error(codes.AlreadyExists, MsgEmailAlreadyRegistered)
 	case errors.Is(err, ErrAddressNotFound):
 		return status.Error(codes.NotFound, MsgAddressNotFound)
+	case errors.Is(err, ErrInvalidAddress):
+		return addressStatus(err)
+	case errors.Is(err, ErrAddressLimitReached):
+		return status.Error(codes.FailedPrecondition, MsgAddressLimitReached)
+	case errors.Is(err, ErrDriverNotFound):
+		return status.Error(codes.NotFound, MsgDriverNotFound)
+	case errors.Is(err, ErrDriverOffline):
+		return status.Error(codes.FailedPrecondition, MsgDriverOffline)
+	case errors.Is(err, ErrVehicleRequired):
+		return status.Error(codes.FailedPrecondition, MsgVehicleRequired)
+	case errors.Is(err, ErrInvalidVehicle):
+		return status.Error(codes.InvalidArgument, detailedMessage(err, MsgInvalidVehicle))
+	case errors.Is(err, ErrInvalidLocation):
+		return status.Error(codes.InvalidArgument, detailedMessage(err, MsgInvalidLocation))
+	case errors.Is(err, ErrAccountRequestPending):
+		return status.Error(codes.FailedPrecondition, MsgAccountRequestPending)
+	case errors.Is(err, ErrAccountRequestNotFound):
+		return status.Error(codes.NotFound, MsgAccountRequestNotFound)
 
 	// Validation errors
 	case errors.Is(err, ErrInvalidRequest):
-		return status.Error(codes.InvalidArgument, MsgInvalidRequest)
+		return status.Error(codes.InvalidArgument, detailedMessage(err, MsgInvalidRequest))
 	case errors.Is(err, ErrInvalidEmailFormat):
 		return status.Error(codes.InvalidArgument, "Invalid email format.")
-	case errors.Is(err, ErrInvalidPassword):
-		return status.Error(codes.InvalidArgument, "Password does not meet requirements.")
+
+	// Generic errors
+	case errors.Is(err, ErrNotFound):
+		return status.Error(codes.NotFound, MsgNotFound)
+	case errors.Is(err, ErrConflict):
+		return status.Error(codes.Aborted, MsgConflict)
+	case errors.Is(err, ErrForbidden):
+		return status.Error(codes.PermissionDenied, MsgUnauthorized)
+	case errors.Is(err, context.DeadlineExceeded):
+		return status.Error(codes.DeadlineExceeded, MsgInternalError)
+	case errors.Is(err, context.Canceled):
+		return status.Error(codes.Canceled, MsgInternalError)
 
 	// Database errors
 	case errors.Is(err, ErrDatabase):
@@ -117,6 +321,76 @@ func ToGRPCError(err error) error {
 	}
 }
 
+// throttledStatus reports a throttled login as ResourceExhausted, with the
+// time to wait in a RetryInfo detail when it is known.
+func throttledStatus(err error) error {
+	st := status.New(codes.ResourceExhausted, MsgTooManyAttempts)
+
+	var throttled *ThrottledError
+	if !errors.As(err, &throttled) {
+		return st.Err()
+	}
+
+	detailed, detailErr := st.WithDetails(&errdetails.RetryInfo{
+		RetryDelay: durationpb.New(throttled.RetryAfter),
+	})
+	if detailErr != nil {
+		return st.Err()
+	}
+	return detailed.Err()
+}
+
+// passwordPolicyStatus reports a rejected password as InvalidArgument, with
+// the rules it breaks in a BadRequest detail when they are known.
+func passwordPolicyStatus(err error) error {
+	st := status.New(codes.InvalidArgument, MsgInvalidPassword)
+
+	var policyErr *PasswordPolicyError
+	if !errors.As(err, &policyErr) {
+		return st.Err()
+	}
+
+	badRequest := &errdetails.BadRequest{}
+	for _, v := range policyErr.Violations {
+		badRequest.FieldViolations = append(badRequest.FieldViolations, &errdetails.BadRequest_FieldViolation{
+			Field:       policyErr.Field,
+			Description: v.Description,
+			Reason:      v.Reason,
+		})
+	}
+
+	detailed, detailErr := st.WithDetails(badRequest)
+	if detailErr != nil {
+		return st.Err()
+	}
+	return detailed.Err()
+}
+
+// addressStatus reports a rejected address as InvalidArgument, with the
+// fields at fault in a BadRequest detail when they are known.
+func addressStatus(err error) error {
+	st := status.New(codes.InvalidArgument, MsgInvalidAddress)
+
+	var addressErr *AddressError
+	if !errors.As(err, &addressErr) {
+		return st.Err()
+	}
+
+	badRequest := &errdetails.BadRequest{}
+	for _, v := range addressErr.Violations {
+		badRequest.FieldViolations = append(badRequest.FieldViolations, &errdetails.BadRequest_FieldViolation{
+			Field:       v.Field,
+			Description: v.Description,
+		})
+	}
+
+	detailed, detailErr := st.WithDetails(badRequest)
+	if detailErr != nil {
+		return st.Err()
+	}
+	return detailed.Err()
+}
+
 func OptimizedDbError(err error) error {
 	if err == nil {
 		return nil
@@ -177,4 +451,18 @@ func extractFieldFromConstraint(constraint string) string {
 	}
 
 	return ""
-}
\ No newline at end of file
+}
+
+// detailedMessage is msg followed by what err adds to the error it wraps, as
+// in "Vehicle is invalid: plate_number is required".
+func detailedMessage(err error, msg string) string {
+	wrapped := errors.Unwrap(err)
+	if wrapped == nil {
+		return msg
+	}
+	detail := strings.TrimPrefix(err.Error(), wrapped.Error()+": ")
+	if detail == err.Error() {
+		return msg
+	}
+	return strings.TrimSuffix(msg, ".") + ": " + detail
+}
@@ -0,0 +1,69 @@
+package domain
+
+import (
+	"context"
+	"time"
+)
+
+// GoogleProvider is the name of the Google identity provider.
+const GoogleProvider = "google"
+
+// ExternalIdentity is a user as an identity provider vouches for them in an
+// ID token.
+type ExternalIdentity struct {
+	Provider string
+	// Subject identifies the user at the provider. Unlike the email it never
+	// changes, so accounts are linked by it.
+	Subject       string
+	Email         string
+	EmailVerified bool
+	Name          string
+	// IssuedAt is when the provider issued the ID token, zero when unknown.
+	IssuedAt time.Time
+}
+
+// IdentityProvider verifies the ID tokens users log in with, such as those
+// of an OpenID Connect issuer.
+type IdentityProvider interface {
+	// Name is what clients call the provider by, e.g. "google".
+	Name() string
+	// Verify checks the token's signature, issuer, audience and expiry and
+	// returns the identity it vouches for.
+	Verify(ctx context.Context, idToken string) (*ExternalIdentity, error)
+}
+
+// UserIdentity is a provider account linked to a user.
+type UserIdentity struct {
+	UserID     string
+	Provider   string
+	Subject    string
+	Email      string
+	CreatedAt  time.Time
+	LastUsedAt time.Time
+}
+
+// Reauthentication proves that a signed-in user is still at the keyboard
+// before a sensitive change: their password or, for accounts without one, a
+// freshly issued ID token of a provider linked to the account.
+type Reauthentication struct {
+	Password string
+	Provider string
+	IDToken  string
+}
+
+// LoginWithIdentityProvider is a login with an ID token from the named provider.
+type LoginWithIdentityProvider struct {
+	Provider string
+	IDToken  string
+	Client   ClientInfo
+}
+
+type IdentityRepository interface {
+	// GetUserIDByIdentity returns the user linked to the provider account, or
+	// an empty string when there is none.
+	GetUserIDByIdentity(ctx context.Context, provider, subject string) (string, error)
+	// LinkIdentity links the provider account to the user, or records that
+	// it was used again when it already is.
+	LinkIdentity(ctx context.Context, userID string, identity ExternalIdentity) error
+	GetUserIdentities(ctx context.Context, userID string) ([]UserIdentity, error)
+}
@@ -0,0 +1,54 @@
+package domain
+
+import (
+	"context"
+	"time"
+)
+
+// MFA is a user's TOTP second factor. It is enabled once the user has proven
+// their authenticator app generates valid codes.
+type MFA struct {
+	UserID string
+	// Secret is the TOTP secret, encrypted.
+	Secret    string
+	Enabled   bool
+	CreatedAt time.Time
+}
+
+// MFAEnrollment is what an authenticator app needs to generate codes.
+type MFAEnrollment struct {
+	Secret          string
+	ProvisioningURI string
+}
+
+// LoginResult is the outcome of a password or identity provider login: the
+// user and their tokens, or, when the user has two-factor authentication on,
+// the token of the challenge to complete with a code.
+type LoginResult struct {
+	User     *User
+	Tokens   *AuthTokens
+	MFAToken string
+}
+
+type MFALogin struct {
+	MFAToken string
+	// Code is a TOTP code or a recovery code.
+	Code   string
+	Client ClientInfo
+}
+
+type MFARepository interface {
+	// GetMFA returns the user's second factor, or nil when they have none.
+	GetMFA(ctx context.Context, userID string) (*MFA, error)
+	// SavePendingMFA starts an enrollment with the encrypted secret, replacing
+	// a previous one that was not confirmed.
+	SavePendingMFA(ctx context.Context, userID, secret string) error
+	// EnableMFA enables the user's second factor and replaces their recovery
+	// codes with the given hashes.
+	EnableMFA(ctx context.Context, userID string, recoveryCodeHashes []string) error
+	// DisableMFA removes the user's second factor and recovery codes.
+	DisableMFA(ctx context.Context, userID string) error
+	// UseRecoveryCode marks an unused recovery code of the user as used. It
+	// fails with errs.ErrInvalidMFACode when there is none with the hash.
+	UseRecoveryCode(ctx context.Context, userID, codeHash string) error
+}
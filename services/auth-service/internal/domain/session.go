@@ -0,0 +1,48 @@
+package domain
+
+import (
+	"context"
+	"time"
+)
+
+// ClientInfo describes the client a login or refresh comes from, as far as it
+// is known.
+type ClientInfo struct {
+	IP        string
+	UserAgent string
+	DeviceID  string
+}
+
+// Session is a login of a user on one device. Its ID is the family of the
+// refresh tokens issued for the login.
+type Session struct {
+	SessionID  string
+	UserID     string
+	DeviceID   string
+	UserAgent  string
+	IPAddress  string
+	CreatedAt  time.Time
+	LastUsedAt time.Time
+	ExpiresAt  time.Time
+
+	// Current marks the session the request listing the sessions was made in.
+	Current bool
+}
+
+// SessionRepository keeps the sessions of users for them to review. Ending a
+// session here does not revoke its tokens, see [AuthRepository.RevokeTokenFamily].
+type SessionRepository interface {
+	CreateSession(ctx context.Context, session Session) error
+	// TouchSession records that the session was refreshed from ip and now
+	// lasts until expiresAt.
+	TouchSession(ctx context.Context, sessionID, ip string, expiresAt time.Time) error
+	// GetActiveSessions returns the user's sessions that are neither revoked
+	// nor expired, the most recently used first.
+	GetActiveSessions(ctx context.Context, userID string) ([]Session, error)
+	// RevokeSession ends one session of the user. It fails with
+	// errs.ErrSessionNotFound when the user has no such active session.
+	RevokeSession(ctx context.Context, userID, sessionID string) error
+	// RevokeAllSessions ends every active session of the user but exceptID,
+	// which may be empty, and returns the IDs of the ended sessions.
+	RevokeAllSessions(ctx context.Context, userID, exceptID string) ([]string, error)
+}
@@ -15,6 +15,10 @@ type Address struct {
     Latitude   float32
     Longitude  float32
     CreatedAt  time.Time
+
+    // Label is a name the user gave the address, such as "Home".
+    Label     string
+    IsDefault bool
 }
 
 type User struct {
@@ -24,10 +28,14 @@ type User struct {
     PhoneNumber string
     Addresses   []Address
     CreatedAt   time.Time
+
+    EmailVerified bool
 }
 
 type UserUseCase interface {
     GetUser(ctx context.Context, userID string) (*User, error)
+    // UpdateProfile changes the user's username and returns the updated user.
+    UpdateProfile(ctx context.Context, userID, username string) (*User, error)
 
     AddPhoneNumber(ctx context.Context, userID, phone string) error
     UpdatePhoneNumber(ctx context.Context, userID, phone string) error
@@ -35,34 +43,99 @@ type UserUseCase interface {
 
     GetAddresses(ctx context.Context, userID string) ([]Address, error)
     AddAddress(ctx context.Context, userID string, address Address) (*Address, error)
+    UpdateAddress(ctx context.Context, userID string, address Address) (*Address, error)
+    SetDefaultAddress(ctx context.Context, userID, addressID string) error
     RemoveAddress(ctx context.Context, userID, addressID string) error
 
     // Driver
-    BeDriver(ctx context.Context, userID string) (string, error)
+    BeDriver(ctx context.Context, userID string, vehicle Vehicle) (string, error)
+    // GetDrivers returns the online drivers within radius km, the nearest
+    // first. Only their ID and location are set.
     GetDrivers(ctx context.Context, latitude, longitude, radius float32) ([]Driver, error)
     RemoveDriver(ctx context.Context, driverID string) error
+    GetDriverProfile(ctx context.Context, userID string) (*Driver, error)
+    UpdateVehicle(ctx context.Context, userID string, vehicle Vehicle) (*Driver, error)
+    // GoOnline puts the driver on duty at the given location. The driver
+    // needs a vehicle.
+    GoOnline(ctx context.Context, userID string, latitude, longitude float32) error
+    GoOffline(ctx context.Context, userID string) error
+    // UpdateDriverLocation records the location of a driver on duty.
+    UpdateDriverLocation(ctx context.Context, userID string, latitude, longitude float32) error
 }
 
 type Driver struct {
     DriverID string
     User  User
+
+    Vehicle   Vehicle
+    // Online is whether the driver is on duty.
+    Online    bool
+    CreatedAt time.Time
+
+    // Set by GetDrivers only.
+    Location DriverLocation
 }
 type UserRepository interface {
     CreateUser(ctx context.Context, user *UserRegister) (*User, error)
     GetUserByID(ctx context.Context, userID string) (*User, error)
     GetUserByEmail(ctx context.Context, email string) (*User, error)
     GetUserPasswordHashByEmail(ctx context.Context, email string) (string, error)
+    // GetUserRoles returns every role of the user, customer included.
+    GetUserRoles(ctx context.Context, userID string) ([]string, error)
+    // GrantRole gives the user a role kept in user_roles; granting a role the
+    // user holds does nothing.
+    GrantRole(ctx context.Context, userID, role string) error
+    // RevokeRole takes a role kept in user_roles from the user; revoking a
+    // role the user does not hold does nothing.
+    RevokeRole(ctx context.Context, userID, role string) error
+    // MarkEmailVerified verifies the user's email, provided it is still email.
+    MarkEmailVerified(ctx context.Context, userID, email string) error
+    UpdatePassword(ctx context.Context, userID, passwordHash string) error
+    UpdateUsername(ctx context.Context, userID, username string) error
+    // ChangeEmail replaces the user's email, provided it is still
+    // currentEmail, and marks the new one verified.
+    ChangeEmail(ctx context.Context, userID, currentEmail, newEmail string) error
 
     UpdatePhoneNumber(ctx context.Context, userID string, phone string) error
     AddPhoneNumber(ctx context.Context, userID string, phone string) error
     RemovePhoneNumber(ctx context.Context, userID string) error
 
+    // GetAddresses returns the user's addresses, the default one first.
     GetAddresses(ctx context.Context, userID string) ([]Address, error)
-    AddAddress(ctx context.Context, userID string, address Address) (Address, error)
+    // AddAddress adds an address unless the user already has maxAddresses.
+    // The user's first address becomes the default one.
+    AddAddress(ctx context.Context, userID string, address Address, maxAddresses int) (Address, error)
+    UpdateAddress(ctx context.Context, userID string, address Address) (Address, error)
+    SetDefaultAddress(ctx context.Context, userID, addressID string) error
+    // RemoveAddress removes an address. When it was the default one, the
+    // oldest remaining address becomes the default one.
     RemoveAddress(ctx context.Context, userID, addressID string) error
 
     // Driver
-    BeDriver(ctx context.Context, userID string) (string, error)
-    GetDrivers(ctx context.Context, latitude, longitude, radius float32) ([]Driver, error)
+    BeDriver(ctx context.Context, userID string, vehicle Vehicle) (string, error)
     RemoveDriver(ctx context.Context, driverID string) error
+    // GetDriverByUserID returns the user's driver profile, or nil when they
+    // are not a driver.
+    GetDriverByUserID(ctx context.Context, userID string) (*Driver, error)
+    UpdateVehicle(ctx context.Context, driverID string, vehicle Vehicle) error
+    SetDriverOnline(ctx context.Context, driverID string, online bool) error
+    // GetDriverID returns the user's driver ID, or "" when they are not a driver.
+    GetDriverID(ctx context.Context, userID string) (string, error)
+    // GetDriverUserID returns the user behind a driver profile, failing with
+    // ErrDriverNotFound when there is none.
+    GetDriverUserID(ctx context.Context, driverID string) (string, error)
+
+    // AnonymizeUser deletes the user's personal data, keeping a row that can
+    // no longer log in under their ID. Deleted users are not found anymore.
+    AnonymizeUser(ctx context.Context, userID string) error
+
+    // Moderation
+    // SearchUsers returns the users matching the search, the newest first,
+    // with their suspensions but without their roles and addresses.
+    SearchUsers(ctx context.Context, search UserSearch) ([]UserAccount, error)
+    // GetSuspension returns the user's suspension, or nil when they are not
+    // suspended.
+    GetSuspension(ctx context.Context, userID string) (*Suspension, error)
+    SuspendUser(ctx context.Context, userID string, suspension Suspension) error
+    UnsuspendUser(ctx context.Context, userID string) error
 }
\ No newline at end of file
@@ -0,0 +1,33 @@
+package identity
+
+import (
+	"fmt"
+
+	authservice "github.com/tamirat-dejene/ha-soranu/services/auth-service"
+	"github.com/tamirat-dejene/ha-soranu/services/auth-service/internal/domain"
+)
+
+// New creates the configured identity providers, keyed by name. The mock
+// provider is added when mockSecret is set.
+func New(configs []authservice.IdentityProviderConfig, mockSecret string) (map[string]domain.IdentityProvider, error) {
+	providers := make(map[string]domain.IdentityProvider, len(configs)+1)
+	for _, config := range configs {
+		if _, ok := providers[config.Name]; ok {
+			return nil, fmt.Errorf("identity provider %q is configured twice", config.Name)
+		}
+		providers[config.Name] = NewOIDCProvider(config.Name, config.Issuer, config.ClientID)
+	}
+
+	if mockSecret != "" {
+		if _, ok := providers[MockProviderName]; ok {
+			return nil, fmt.Errorf("identity provider %q is reserved", MockProviderName)
+		}
+		mock, err := NewMockProvider(mockSecret)
+		if err != nil {
+			return nil, err
+		}
+		providers[MockProviderName] = mock
+	}
+
+	return providers, nil
+}
@@ -0,0 +1,90 @@
+package identity
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/golang-jwt/jwt/v5"
+	"github.com/tamirat-dejene/ha-soranu/services/auth-service/internal/domain"
+)
+
+// MockProviderName is what clients call the mock provider by.
+const MockProviderName = "mock"
+
+// mockIssuer and mockAudience are the issuer and audience of mock ID tokens.
+const (
+	mockIssuer   = "ha-soranu-mock-idp"
+	mockAudience = "ha-soranu"
+)
+
+// MockProvider is a local identity provider whose ID tokens are HS256 JWTs
+// signed with a shared secret. Tests and development setups log in with it
+// without an account at a real provider. It must never be enabled in
+// production, where anyone knowing the secret could log in as any user.
+type MockProvider struct {
+	secret []byte
+}
+
+// Name implements [domain.IdentityProvider].
+func (p *MockProvider) Name() string {
+	return MockProviderName
+}
+
+// Verify implements [domain.IdentityProvider].
+func (p *MockProvider) Verify(ctx context.Context, idToken string) (*domain.ExternalIdentity, error) {
+	var claims idTokenClaims
+	_, err := jwt.ParseWithClaims(idToken, &claims, func(t *jwt.Token) (any, error) {
+		return p.secret, nil
+	},
+		jwt.WithValidMethods([]string{jwt.SigningMethodHS256.Alg()}),
+		jwt.WithIssuer(mockIssuer),
+		jwt.WithAudience(mockAudience),
+		jwt.WithExpirationRequired(),
+	)
+	if err != nil {
+		return nil, fmt.Errorf("invalid mock ID token: %w", err)
+	}
+	if claims.Subject == "" {
+		return nil, errors.New("invalid mock ID token: no subject")
+	}
+
+	return &domain.ExternalIdentity{
+		Provider:      MockProviderName,
+		Subject:       claims.Subject,
+		Email:         claims.Email,
+		EmailVerified: claims.emailVerified(),
+		Name:          claims.Name,
+		IssuedAt:      issuedAt(&claims),
+	}, nil
+}
+
+// Issue signs an ID token vouching for the identity, valid for ttl.
+func (p *MockProvider) Issue(identity domain.ExternalIdentity, ttl time.Duration) (string, error) {
+	now := time.Now()
+	claims := idTokenClaims{
+		Email:         identity.Email,
+		EmailVerified: identity.EmailVerified,
+		Name:          identity.Name,
+		RegisteredClaims: jwt.RegisteredClaims{
+			Issuer:    mockIssuer,
+			Subject:   identity.Subject,
+			Audience:  jwt.ClaimStrings{mockAudience},
+			IssuedAt:  jwt.NewNumericDate(now),
+			ExpiresAt: jwt.NewNumericDate(now.Add(ttl)),
+		},
+	}
+
+	return jwt.NewWithClaims(jwt.SigningMethodHS256, claims).SignedString(p.secret)
+}
+
+// NewMockProvider creates a MockProvider signing and accepting tokens with
+// secret, which must be at least 32 bytes.
+func NewMockProvider(secret string) (*MockProvider, error) {
+	if len(secret) < 32 {
+		return nil, errors.New("mock identity provider secret must be at least 32 bytes")
+	}
+
+	return &MockProvider{secret: []byte(secret)}, nil
+}
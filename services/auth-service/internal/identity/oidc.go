@@ -0,0 +1,180 @@
+package identity
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/golang-jwt/jwt/v5"
+	"github.com/tamirat-dejene/ha-soranu/services/auth-service/internal/domain"
+	jwtvalidator "github.com/tamirat-dejene/ha-soranu/shared/pkg/auth/jwtvalidator"
+)
+
+const (
+	// jwksRefreshInterval is how long a provider's signing keys are cached
+	// before they are fetched again.
+	jwksRefreshInterval = time.Hour
+	// discoveryRetryInterval bounds how often a failed discovery is retried.
+	discoveryRetryInterval = 30 * time.Second
+)
+
+// discoveryDocument is the part of an OpenID Provider's configuration
+// (OpenID Connect Discovery 1.0) needed to verify its ID tokens.
+type discoveryDocument struct {
+	Issuer  string `json:"issuer"`
+	JWKSURI string `json:"jwks_uri"`
+}
+
+// idTokenClaims are the claims of an ID token a user is identified by.
+type idTokenClaims struct {
+	Email string `json:"email"`
+	// EmailVerified is a boolean, but some providers, Apple among them, send
+	// it as a string.
+	EmailVerified any    `json:"email_verified"`
+	Name          string `json:"name"`
+	jwt.RegisteredClaims
+}
+
+func (c *idTokenClaims) emailVerified() bool {
+	switch v := c.EmailVerified.(type) {
+	case bool:
+		return v
+	case string:
+		return v == "true"
+	default:
+		return false
+	}
+}
+
+// issuedAt returns when the token was issued, or the zero time when it does
+// not say.
+func issuedAt(claims *idTokenClaims) time.Time {
+	if claims.IssuedAt == nil {
+		return time.Time{}
+	}
+	return claims.IssuedAt.Time
+}
+
+type oidcProvider struct {
+	name     string
+	issuer   string
+	clientID string
+	client   *http.Client
+
+	// The discovery document is fetched on first use, so the service starts
+	// while a provider is unreachable.
+	mu           sync.Mutex
+	verifier     *jwtvalidator.Verifier
+	discoveredAt time.Time
+}
+
+// Name implements [domain.IdentityProvider].
+func (p *oidcProvider) Name() string {
+	return p.name
+}
+
+// Verify implements [domain.IdentityProvider].
+func (p *oidcProvider) Verify(ctx context.Context, idToken string) (*domain.ExternalIdentity, error) {
+	verifier, err := p.keys(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	var claims idTokenClaims
+	_, err = jwt.ParseWithClaims(idToken, &claims, verifier.Keyfunc(ctx),
+		jwt.WithAudience(p.clientID),
+		jwt.WithExpirationRequired(),
+		jwt.WithIssuedAt(),
+	)
+	if err != nil {
+		return nil, fmt.Errorf("invalid %s ID token: %w", p.name, err)
+	}
+
+	// Google also issues tokens naming itself without the scheme.
+	if claims.Issuer != p.issuer && "https://"+claims.Issuer != p.issuer {
+		return nil, fmt.Errorf("invalid %s ID token: unexpected issuer %q", p.name, claims.Issuer)
+	}
+	if claims.Subject == "" {
+		return nil, fmt.Errorf("invalid %s ID token: no subject", p.name)
+	}
+
+	return &domain.ExternalIdentity{
+		Provider:      p.name,
+		Subject:       claims.Subject,
+		Email:         claims.Email,
+		EmailVerified: claims.emailVerified(),
+		Name:          claims.Name,
+		IssuedAt:      issuedAt(&claims),
+	}, nil
+}
+
+// keys returns the verifier of the provider's signing keys, discovering
+// where they are published first if needed.
+func (p *oidcProvider) keys(ctx context.Context) (*jwtvalidator.Verifier, error) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	if p.verifier != nil {
+		return p.verifier, nil
+	}
+	if time.Since(p.discoveredAt) < discoveryRetryInterval {
+		return nil, fmt.Errorf("%s discovery failed recently", p.name)
+	}
+	p.discoveredAt = time.Now()
+
+	doc, err := p.discover(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	p.verifier = jwtvalidator.NewVerifier(doc.JWKSURI, jwksRefreshInterval)
+	return p.verifier, nil
+}
+
+func (p *oidcProvider) discover(ctx context.Context) (*discoveryDocument, error) {
+	url := strings.TrimSuffix(p.issuer, "/") + "/.well-known/openid-configuration"
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, url, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	resp, err := p.client.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("failed to discover %s: %w", p.name, err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("failed to discover %s: status %d", p.name, resp.StatusCode)
+	}
+
+	var doc discoveryDocument
+	if err := json.NewDecoder(resp.Body).Decode(&doc); err != nil {
+		return nil, fmt.Errorf("invalid %s discovery document: %w", p.name, err)
+	}
+	if doc.Issuer != p.issuer {
+		return nil, fmt.Errorf("%s discovery document names issuer %q", p.name, doc.Issuer)
+	}
+	if doc.JWKSURI == "" {
+		return nil, errors.New(p.name + " discovery document has no jwks_uri")
+	}
+
+	return &doc, nil
+}
+
+// NewOIDCProvider creates an IdentityProvider for the OpenID Connect issuer,
+// accepting ID tokens issued to clientID. Where the issuer publishes its
+// signing keys is discovered from its configuration on first use.
+func NewOIDCProvider(name, issuer, clientID string) domain.IdentityProvider {
+	return &oidcProvider{
+		name:     name,
+		issuer:   issuer,
+		clientID: clientID,
+		client:   &http.Client{Timeout: 5 * time.Second},
+	}
+}
@@ -0,0 +1,84 @@
+package mailer
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/tamirat-dejene/ha-soranu/services/auth-service/internal/domain"
+	"github.com/tamirat-dejene/ha-soranu/shared/pkg/logger"
+	"go.uber.org/zap"
+)
+
+type logMailer struct {
+	from string
+}
+
+// Send implements [domain.Mailer].
+func (m *logMailer) Send(ctx context.Context, email domain.Email) error {
+	logger.Info("email",
+		zap.String("from", m.from),
+		zap.String("to", email.To),
+		zap.String("subject", email.Subject),
+		zap.String("body", email.Body),
+	)
+	return nil
+}
+
+// NewLogMailer creates a Mailer that writes emails to the service log instead
+// of delivering them. It is meant for development.
+func NewLogMailer(from string) domain.Mailer {
+	return &logMailer{from: from}
+}
+
+type fileMailer struct {
+	from string
+	dir  string
+}
+
+// Send implements [domain.Mailer].
+func (m *fileMailer) Send(ctx context.Context, email domain.Email) error {
+	now := time.Now().UTC()
+
+	var b strings.Builder
+	fmt.Fprintf(&b, "From: %s\r\n", m.from)
+	fmt.Fprintf(&b, "To: %s\r\n", email.To)
+	fmt.Fprintf(&b, "Subject: %s\r\n", email.Subject)
+	fmt.Fprintf(&b, "Date: %s\r\n", now.Format(time.RFC1123Z))
+	b.WriteString("Content-Type: text/plain; charset=utf-8\r\n\r\n")
+	b.WriteString(email.Body)
+
+	name := fmt.Sprintf("%s-%s.eml", now.Format("20060102T150405.000000000"), uuid.New().String())
+	if err := os.WriteFile(filepath.Join(m.dir, name), []byte(b.String()), 0o600); err != nil {
+		return fmt.Errorf("failed to write email: %w", err)
+	}
+
+	return nil
+}
+
+// NewFileMailer creates a Mailer that writes every email to its own .eml file
+// in dir, creating the directory if needed. It is meant for development and
+// tests that need to read the emails back.
+func NewFileMailer(from, dir string) (domain.Mailer, error) {
+	if err := os.MkdirAll(dir, 0o700); err != nil {
+		return nil, fmt.Errorf("failed to create mail directory: %w", err)
+	}
+
+	return &fileMailer{from: from, dir: dir}, nil
+}
+
+// New creates the Mailer selected by kind, "log" or "file".
+func New(kind, from, dir string) (domain.Mailer, error) {
+	switch kind {
+	case "log":
+		return NewLogMailer(from), nil
+	case "file":
+		return NewFileMailer(from, dir)
+	default:
+		return nil, fmt.Errorf("unknown mailer %q", kind)
+	}
+}
@@ -0,0 +1,302 @@
+package repository
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgconn"
+	"github.com/tamirat-dejene/ha-soranu/services/auth-service/internal/domain"
+	errs "github.com/tamirat-dejene/ha-soranu/services/auth-service/internal/domain/err"
+	postgres "github.com/tamirat-dejene/ha-soranu/shared/db/pg"
+	"github.com/tamirat-dejene/ha-soranu/shared/pkg/logger"
+	"go.uber.org/zap"
+)
+
+type accountRequestRepository struct {
+	db postgres.PostgresClient
+}
+
+// CreateRequest implements [domain.AccountRequestRepository].
+func (a *accountRequestRepository) CreateRequest(ctx context.Context, request domain.AccountRequest, services []string) (*domain.AccountRequest, error) {
+	tx, err := a.db.BeginTx(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to begin transaction: %w", err)
+	}
+
+	// Signal for successful execution
+	success := false
+
+	defer func() {
+		if !success {
+			tx.Rollback(ctx)
+		}
+	}()
+
+	query := `
+		INSERT INTO account_requests (user_id, kind, notify_email)
+		VALUES ($1, $2, $3)
+		RETURNING request_id, status, created_at
+	`
+
+	created := request
+	var status string
+	err = tx.QueryRow(ctx, query, request.UserID, string(request.Kind), request.NotifyEmail).Scan(
+		&created.RequestID,
+		&status,
+		&created.CreatedAt,
+	)
+	if err != nil {
+		var pgErr *pgconn.PgError
+		if errors.As(err, &pgErr) && pgErr.Code == "23505" && pgErr.ConstraintName == "idx_account_requests_user_pending" {
+			return nil, errs.ErrAccountRequestPending
+		}
+		logger.Error("failed to create account request", zap.String("user_id", request.UserID), zap.Error(err))
+		return nil, errs.OptimizedDbError(err)
+	}
+	created.Status = domain.AccountRequestStatus(status)
+
+	created.Services = nil
+	for _, service := range services {
+		_, err := tx.Exec(ctx, `INSERT INTO account_request_services (request_id, service) VALUES ($1, $2)`, created.RequestID, service)
+		if err != nil {
+			return nil, errs.OptimizedDbError(err)
+		}
+		created.Services = append(created.Services, domain.AccountRequestService{
+			Service: service,
+			Status:  domain.AccountRequestPending,
+		})
+	}
+
+	if err := tx.Commit(ctx); err != nil {
+		return nil, errs.OptimizedDbError(err)
+	}
+
+	success = true
+	return &created, nil
+}
+
+// GetRequest implements [domain.AccountRequestRepository].
+func (a *accountRequestRepository) GetRequest(ctx context.Context, userID, requestID string) (*domain.AccountRequest, error) {
+	query := `
+		SELECT request_id, user_id, kind, status, notify_email, created_at, completed_at
+		FROM account_requests
+		WHERE user_id = $1 AND request_id::text = $2
+	`
+
+	request, err := scanAccountRequest(a.db.QueryRow(ctx, query, userID, requestID))
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, nil
+		}
+		logger.Error("failed to get account request", zap.String("request_id", requestID), zap.Error(err))
+		return nil, errs.OptimizedDbError(err)
+	}
+
+	request.Services, err = getAccountRequestServices(ctx, a.db, request.RequestID)
+	if err != nil {
+		return nil, err
+	}
+
+	return request, nil
+}
+
+// RecordServiceResult implements [domain.AccountRequestRepository].
+func (a *accountRequestRepository) RecordServiceResult(ctx context.Context, requestID string, result domain.AccountRequestService) (*domain.AccountRequest, error) {
+	tx, err := a.db.BeginTx(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to begin transaction: %w", err)
+	}
+
+	// Signal for successful execution
+	success := false
+
+	defer func() {
+		if !success {
+			tx.Rollback(ctx)
+		}
+	}()
+
+	// 1. Lock the request, so that of two services reporting at once the
+	// second one sees the part of the first when deciding if all are done.
+	query := `
+		SELECT request_id, user_id, kind, status, notify_email, created_at, completed_at
+		FROM account_requests
+		WHERE request_id::text = $1
+		FOR UPDATE
+	`
+
+	request, err := scanAccountRequest(tx.QueryRow(ctx, query, requestID))
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, errs.ErrAccountRequestNotFound
+		}
+		return nil, errs.OptimizedDbError(err)
+	}
+	if request.Status != domain.AccountRequestPending {
+		success = true
+		return nil, tx.Commit(ctx)
+	}
+
+	// 2. Record the part of the service. Reports repeated by a redelivered
+	// event are ignored.
+	var data any
+	if len(result.Data) > 0 {
+		data = string(result.Data)
+	}
+
+	query = `
+		UPDATE account_request_services
+		SET status = $3, data = $4::jsonb, error = $5, completed_at = CURRENT_TIMESTAMP
+		WHERE request_id = $1 AND service = $2 AND status = 'PENDING'
+	`
+
+	if _, err := tx.Exec(ctx, query, request.RequestID, result.Service, string(result.Status), data, result.Error); err != nil {
+		logger.Error("failed to record account request result", zap.String("request_id", requestID), zap.String("service", result.Service), zap.Error(err))
+		return nil, errs.OptimizedDbError(err)
+	}
+
+	// 3. Complete the request once no part is pending anymore
+	query = `
+		UPDATE account_requests r
+		SET status = CASE
+				WHEN EXISTS (SELECT 1 FROM account_request_services s WHERE s.request_id = r.request_id AND s.status = 'FAILED')
+				THEN 'FAILED' ELSE 'COMPLETED'
+			END,
+			completed_at = CURRENT_TIMESTAMP
+		WHERE r.request_id = $1
+			AND NOT EXISTS (SELECT 1 FROM account_request_services s WHERE s.request_id = r.request_id AND s.status = 'PENDING')
+		RETURNING r.status, r.completed_at
+	`
+
+	var status string
+	var completedAt time.Time
+	err = tx.QueryRow(ctx, query, request.RequestID).Scan(&status, &completedAt)
+	if err != nil && !errors.Is(err, pgx.ErrNoRows) {
+		return nil, errs.OptimizedDbError(err)
+	}
+	finished := err == nil
+
+	if err := tx.Commit(ctx); err != nil {
+		return nil, errs.OptimizedDbError(err)
+	}
+	success = true
+
+	if !finished {
+		return nil, nil
+	}
+
+	request.Status = domain.AccountRequestStatus(status)
+	request.CompletedAt = completedAt
+	request.Services, err = getAccountRequestServices(ctx, a.db, request.RequestID)
+	if err != nil {
+		return nil, err
+	}
+
+	return request, nil
+}
+
+// FailRequest implements [domain.AccountRequestRepository].
+func (a *accountRequestRepository) FailRequest(ctx context.Context, requestID string) error {
+	query := `
+		UPDATE account_requests
+		SET status = 'FAILED', completed_at = CURRENT_TIMESTAMP
+		WHERE request_id = $1 AND status = 'PENDING'
+	`
+
+	if _, err := a.db.Exec(ctx, query, requestID); err != nil {
+		return errs.OptimizedDbError(err)
+	}
+
+	return nil
+}
+
+// ClearNotifyEmail implements [domain.AccountRequestRepository].
+func (a *accountRequestRepository) ClearNotifyEmail(ctx context.Context, requestID string) error {
+	query := `
+		UPDATE account_requests
+		SET notify_email = ''
+		WHERE request_id = $1
+	`
+
+	if _, err := a.db.Exec(ctx, query, requestID); err != nil {
+		return errs.OptimizedDbError(err)
+	}
+
+	return nil
+}
+
+func scanAccountRequest(row postgres.Row) (*domain.AccountRequest, error) {
+	var request domain.AccountRequest
+	var kind, status string
+	var completedAt *time.Time
+
+	err := row.Scan(
+		&request.RequestID,
+		&request.UserID,
+		&kind,
+		&status,
+		&request.NotifyEmail,
+		&request.CreatedAt,
+		&completedAt,
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	request.Kind = domain.AccountRequestKind(kind)
+	request.Status = domain.AccountRequestStatus(status)
+	if completedAt != nil {
+		request.CompletedAt = *completedAt
+	}
+
+	return &request, nil
+}
+
+func getAccountRequestServices(ctx context.Context, db postgres.PostgresClient, requestID string) ([]domain.AccountRequestService, error) {
+	query := `
+		SELECT service, status, COALESCE(data::text, ''), error, completed_at
+		FROM account_request_services
+		WHERE request_id = $1
+		ORDER BY service
+	`
+
+	rows, err := db.Query(ctx, query, requestID)
+	if err != nil {
+		return nil, errs.OptimizedDbError(err)
+	}
+	defer rows.Close()
+
+	var services []domain.AccountRequestService
+	for rows.Next() {
+		var service domain.AccountRequestService
+		var status, data string
+		var completedAt *time.Time
+		if err := rows.Scan(&service.Service, &status, &data, &service.Error, &completedAt); err != nil {
+			return nil, errs.OptimizedDbError(err)
+		}
+
+		service.Status = domain.AccountRequestStatus(status)
+		if data != "" {
+			service.Data = []byte(data)
+		}
+		if completedAt != nil {
+			service.CompletedAt = *completedAt
+		}
+		services = append(services, service)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, errs.OptimizedDbError(err)
+	}
+
+	return services, nil
+}
+
+// NewAccountRequestRepository creates a new instance of AccountRequestRepository
+func NewAccountRequestRepository(db postgres.PostgresClient) domain.AccountRequestRepository {
+	return &accountRequestRepository{
+		db: db,
+	}
+}
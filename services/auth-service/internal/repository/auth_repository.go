@@ -12,96 +12,353 @@ import (
 	"github.com/tamirat-dejene/ha-soranu/shared/pkg/caching"
 )
 
+// Refresh tokens are kept in families: every login starts a family and every
+// refresh replaces its current token. Only the current token is stored as
+// valid; the ones it replaced are remembered as used so that presenting one
+// again can be told apart from an unknown token. The single-use tokens sent by
+// email and the challenges of logins awaiting a second factor are stored
+// hashed as well.
 type authRepository struct {
 	client     caching.CacheClient
 	expiration time.Duration
 }
 
 func getRefreshKey(tokenID string) string {
-	return fmt.Sprintf("refresh:%s", internalutil.HashToken(tokenID))
+	return getHashedRefreshKey(internalutil.HashToken(tokenID))
+}
+
+func getHashedRefreshKey(tokenHash string) string {
+	return fmt.Sprintf("refresh:%s", tokenHash)
+}
+
+func getUsedRefreshKey(tokenID string) string {
+	return fmt.Sprintf("refresh_used:%s", internalutil.HashToken(tokenID))
+}
+
+func getTokenFamilyKey(familyID string) string {
+	return fmt.Sprintf("refresh_family:%s", familyID)
+}
+
+func getOneTimeTokenKey(purpose domain.OneTimeTokenPurpose, tokenID string) string {
+	return getHashedOneTimeTokenKey(purpose, internalutil.HashToken(tokenID))
+}
+
+func getHashedOneTimeTokenKey(purpose domain.OneTimeTokenPurpose, tokenHash string) string {
+	return fmt.Sprintf("%s:%s", purpose, tokenHash)
+}
+
+func getUsedOneTimeTokenKey(purpose domain.OneTimeTokenPurpose, tokenID string) string {
+	return fmt.Sprintf("%s_used:%s", purpose, internalutil.HashToken(tokenID))
+}
+
+// getUserOneTimeTokenKey points at the hash of the user's current token of
+// the purpose, so that issuing a new one voids the previous.
+func getUserOneTimeTokenKey(purpose domain.OneTimeTokenPurpose, userID string) string {
+	return fmt.Sprintf("%s_user:%s", purpose, userID)
+}
+
+func getMFAChallengeKey(tokenID string) string {
+	return fmt.Sprintf("mfa_challenge:%s", internalutil.HashToken(tokenID))
+}
+
+func getMFAChallengeAttemptsKey(tokenID string) string {
+	return fmt.Sprintf("mfa_challenge_attempts:%s", internalutil.HashToken(tokenID))
+}
+
+func getUsedTOTPStepKey(userID string, step int64) string {
+	return fmt.Sprintf("totp_used:%s:%d", userID, step)
 }
 
 type RefreshMeta struct {
+	Email    string `json:"email"`
+	FamilyID string `json:"family_id"`
+}
+
+type OneTimeTokenMeta struct {
+	UserID    string `json:"user_id"`
+	Email     string `json:"email"`
+	NewEmail  string `json:"new_email,omitempty"`
+	SessionID string `json:"session_id,omitempty"`
+}
+
+type TokenFamilyMeta struct {
 	Email string `json:"email"`
-	// CreatedAt int64  `json:"created_at"`
-	// ExpiresAt int64  `json:"expires_at"`
-	// DeviceID  string `json:"device_id,omitempty"`
+	// CurrentToken is the hashed ID of the only token of the family that can
+	// still be used.
+	CurrentToken string `json:"current_token"`
 }
 
-func (a *authRepository) SaveRefreshToken(ctx context.Context, email, tokenID string) error {
+// SaveRefreshToken implements [domain.AuthRepository].
+func (a *authRepository) SaveRefreshToken(ctx context.Context, email, familyID, tokenID string) error {
+	return a.setCurrentToken(ctx, email, familyID, tokenID)
+}
+
+// RotateRefreshToken implements [domain.AuthRepository].
+func (a *authRepository) RotateRefreshToken(ctx context.Context, familyID, tokenID, newTokenID string) (string, error) {
+	// 1. Mark the token as used. The counter makes this atomic, so of two
+	// concurrent refreshes with the same token only the first one wins.
+	usedKey := getUsedRefreshKey(tokenID)
+	uses, err := a.client.Increment(ctx, usedKey)
+	if err != nil {
+		return "", err
+	}
+	if err := a.client.Expire(ctx, usedKey, a.expiration); err != nil {
+		return "", err
+	}
+	if uses > 1 {
+		if err := a.RevokeTokenFamily(ctx, familyID); err != nil {
+			return "", err
+		}
+		return "", errs.ErrTokenReused
+	}
+
+	// 2. Make sure the token is still the current one of its family
 	key := getRefreshKey(tokenID)
-	meta := RefreshMeta{Email: email}
+	meta, err := a.getRefreshMeta(ctx, key)
+	if err != nil {
+		return "", err
+	}
+	if meta == nil || meta.FamilyID != familyID {
+		return "", errs.ErrTokenRevoked
+	}
+
+	// 3. Replace it with the new token
+	if err := a.client.Delete(ctx, key); err != nil {
+		return "", err
+	}
+	if err := a.setCurrentToken(ctx, meta.Email, familyID, newTokenID); err != nil {
+		return "", err
+	}
+
+	return meta.Email, nil
+}
+
+// RevokeTokenFamily implements [domain.AuthRepository].
+func (a *authRepository) RevokeTokenFamily(ctx context.Context, familyID string) error {
+	familyKey := getTokenFamilyKey(familyID)
 
-	data, err := json.Marshal(meta)
+	exists, err := a.client.Exists(ctx, familyKey)
 	if err != nil {
 		return err
 	}
+	if !exists {
+		return nil
+	}
+
+	data, err := a.client.Get(ctx, familyKey)
+	if err != nil {
+		return err
+	}
+
+	var family TokenFamilyMeta
+	if err := json.Unmarshal([]byte(data), &family); err != nil {
+		return err
+	}
+
+	if err := a.client.Delete(ctx, getHashedRefreshKey(family.CurrentToken)); err != nil {
+		return err
+	}
 
-	return a.client.Set(ctx, key, string(data), a.expiration)
+	return a.client.Delete(ctx, familyKey)
 }
 
-func (a *authRepository) DeleteRefreshToken(ctx context.Context, tokenID string) error {
-	key := getRefreshKey(tokenID)
-	return a.client.Delete(ctx, key)
+// SaveOneTimeToken implements [domain.AuthRepository].
+func (a *authRepository) SaveOneTimeToken(ctx context.Context, purpose domain.OneTimeTokenPurpose, tokenID string, token domain.OneTimeToken, ttl time.Duration) error {
+	userKey := getUserOneTimeTokenKey(purpose, token.UserID)
+
+	// 1. Void the previous token of the user
+	exists, err := a.client.Exists(ctx, userKey)
+	if err != nil {
+		return err
+	}
+	if exists {
+		previous, err := a.client.Get(ctx, userKey)
+		if err != nil {
+			return err
+		}
+		if err := a.client.Delete(ctx, getHashedOneTimeTokenKey(purpose, previous)); err != nil {
+			return err
+		}
+	}
+
+	// 2. Store the new one
+	data, err := json.Marshal(OneTimeTokenMeta{
+		UserID:    token.UserID,
+		Email:     token.Email,
+		NewEmail:  token.NewEmail,
+		SessionID: token.SessionID,
+	})
+	if err != nil {
+		return err
+	}
+	if err := a.client.Set(ctx, getOneTimeTokenKey(purpose, tokenID), string(data), ttl); err != nil {
+		return err
+	}
+
+	return a.client.Set(ctx, userKey, internalutil.HashToken(tokenID), ttl)
 }
 
-// ValidateRefreshToken retrieves the user email associated with a token without revoking it
-func (a *authRepository) ValidateRefreshToken(ctx context.Context, tokenID string) (string, error) {
-	key := getRefreshKey(tokenID)
+// ConsumeOneTimeToken implements [domain.AuthRepository].
+func (a *authRepository) ConsumeOneTimeToken(ctx context.Context, purpose domain.OneTimeTokenPurpose, tokenID string) (*domain.OneTimeToken, error) {
+	key := getOneTimeTokenKey(purpose, tokenID)
 
 	exists, err := a.client.Exists(ctx, key)
 	if err != nil {
-		return "", err
+		return nil, err
 	}
 	if !exists {
-		return "", errs.ErrTokenRevoked
+		return nil, errs.ErrInvalidLink
+	}
+
+	// Of two concurrent uses of the same token only the first one wins.
+	usedKey := getUsedOneTimeTokenKey(purpose, tokenID)
+	uses, err := a.client.Increment(ctx, usedKey)
+	if err != nil {
+		return nil, err
+	}
+	if err := a.client.Expire(ctx, usedKey, a.expiration); err != nil {
+		return nil, err
+	}
+	if uses > 1 {
+		return nil, errs.ErrInvalidLink
 	}
 
 	data, err := a.client.Get(ctx, key)
 	if err != nil {
-		return "", err
+		return nil, err
 	}
 
-	var meta RefreshMeta
+	var meta OneTimeTokenMeta
 	if err := json.Unmarshal([]byte(data), &meta); err != nil {
-		return "", err
+		return nil, err
 	}
 
-	return meta.Email, nil
+	if err := a.client.Delete(ctx, key); err != nil {
+		return nil, err
+	}
+	if err := a.client.Delete(ctx, getUserOneTimeTokenKey(purpose, meta.UserID)); err != nil {
+		return nil, err
+	}
+
+	return &domain.OneTimeToken{
+		UserID:    meta.UserID,
+		Email:     meta.Email,
+		NewEmail:  meta.NewEmail,
+		SessionID: meta.SessionID,
+	}, nil
+}
+
+// SaveMFAChallenge implements [domain.AuthRepository].
+func (a *authRepository) SaveMFAChallenge(ctx context.Context, tokenID string, challenge domain.OneTimeToken, ttl time.Duration) error {
+	data, err := json.Marshal(OneTimeTokenMeta{UserID: challenge.UserID, Email: challenge.Email})
+	if err != nil {
+		return err
+	}
+
+	if err := a.client.Set(ctx, getMFAChallengeAttemptsKey(tokenID), "0", ttl); err != nil {
+		return err
+	}
+
+	return a.client.Set(ctx, getMFAChallengeKey(tokenID), string(data), ttl)
 }
 
-// ConsumeRefreshToken retrieves and deletes the refresh token (rotation-safe, one-time-use)
-func (a *authRepository) ConsumeRefreshToken(ctx context.Context, tokenId string) (string, error) {
-	key := getRefreshKey(tokenId)
+// UseMFAChallenge implements [domain.AuthRepository].
+func (a *authRepository) UseMFAChallenge(ctx context.Context, tokenID string) (*domain.OneTimeToken, int64, error) {
+	key := getMFAChallengeKey(tokenID)
 
-	// Check if key exists first
 	exists, err := a.client.Exists(ctx, key)
 	if err != nil {
-		return "", err
+		return nil, 0, err
 	}
 	if !exists {
-		return "", errs.ErrTokenRevoked
+		return nil, 0, errs.ErrMFAChallengeInvalid
+	}
+
+	// The counter was created with the challenge and expires with it.
+	attempts, err := a.client.Increment(ctx, getMFAChallengeAttemptsKey(tokenID))
+	if err != nil {
+		return nil, 0, err
 	}
 
 	data, err := a.client.Get(ctx, key)
 	if err != nil {
-		return "", err
+		return nil, 0, err
 	}
 
-	// Delete the token immediately for one-time use
-	if err := a.client.Delete(ctx, key); err != nil {
-		return "", err
+	var meta OneTimeTokenMeta
+	if err := json.Unmarshal([]byte(data), &meta); err != nil {
+		return nil, 0, err
+	}
+
+	return &domain.OneTimeToken{UserID: meta.UserID, Email: meta.Email}, attempts, nil
+}
+
+// DeleteMFAChallenge implements [domain.AuthRepository].
+func (a *authRepository) DeleteMFAChallenge(ctx context.Context, tokenID string) error {
+	if err := a.client.Delete(ctx, getMFAChallengeKey(tokenID)); err != nil {
+		return err
+	}
+	return a.client.Delete(ctx, getMFAChallengeAttemptsKey(tokenID))
+}
+
+// MarkTOTPStepUsed implements [domain.AuthRepository].
+func (a *authRepository) MarkTOTPStepUsed(ctx context.Context, userID string, step int64, ttl time.Duration) (bool, error) {
+	key := getUsedTOTPStepKey(userID, step)
+
+	uses, err := a.client.Increment(ctx, key)
+	if err != nil {
+		return false, err
+	}
+	if err := a.client.Expire(ctx, key, ttl); err != nil {
+		return false, err
+	}
+
+	return uses == 1, nil
+}
+
+func (a *authRepository) setCurrentToken(ctx context.Context, email, familyID, tokenID string) error {
+	meta, err := json.Marshal(RefreshMeta{Email: email, FamilyID: familyID})
+	if err != nil {
+		return err
+	}
+
+	family, err := json.Marshal(TokenFamilyMeta{Email: email, CurrentToken: internalutil.HashToken(tokenID)})
+	if err != nil {
+		return err
+	}
+
+	if err := a.client.Set(ctx, getTokenFamilyKey(familyID), string(family), a.expiration); err != nil {
+		return err
+	}
+
+	return a.client.Set(ctx, getRefreshKey(tokenID), string(meta), a.expiration)
+}
+
+func (a *authRepository) getRefreshMeta(ctx context.Context, key string) (*RefreshMeta, error) {
+	exists, err := a.client.Exists(ctx, key)
+	if err != nil {
+		return nil, err
+	}
+	if !exists {
+		return nil, nil
+	}
+
+	data, err := a.client.Get(ctx, key)
+	if err != nil {
+		return nil, err
 	}
 
 	var meta RefreshMeta
 	if err := json.Unmarshal([]byte(data), &meta); err != nil {
-		return "", err
+		return nil, err
 	}
 
-	return meta.Email, nil
+	return &meta, nil
 }
 
-// NewAuthRepository creates a Redis-based AuthRepository
+// NewAuthRepository creates a Redis-based AuthRepository. Expiration should
+// match the refresh token lifetime; it also bounds how long used one-time
+// tokens are remembered, so it must not be shorter than their lifetime.
 func NewAuthRepository(client caching.CacheClient, expiration time.Duration) domain.AuthRepository {
 	return &authRepository{
 		client:     client,
@@ -0,0 +1,83 @@
+package repository
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/tamirat-dejene/ha-soranu/services/auth-service/internal/domain"
+	"github.com/tamirat-dejene/ha-soranu/shared/pkg/caching"
+	"github.com/tamirat-dejene/ha-soranu/shared/pkg/logger"
+	"go.uber.org/zap"
+)
+
+// driverLocationsKey is the geo index of the online drivers. Members of a geo
+// index cannot expire, so every driver also has a presence key that lives as
+// long as their location is fresh; drivers whose presence key is gone are
+// dropped from the index when a search finds them.
+const driverLocationsKey = "driver_locations"
+
+type driverLocationRepository struct {
+	client caching.CacheClient
+}
+
+func getDriverPresenceKey(driverID string) string {
+	return fmt.Sprintf("driver_presence:%s", driverID)
+}
+
+// SetLocation implements [domain.DriverLocationRepository].
+func (d *driverLocationRepository) SetLocation(ctx context.Context, driverID string, latitude, longitude float64, ttl time.Duration) error {
+	if err := d.client.Set(ctx, getDriverPresenceKey(driverID), time.Now().Unix(), ttl); err != nil {
+		return err
+	}
+
+	return d.client.GeoAdd(ctx, driverLocationsKey, driverID, longitude, latitude)
+}
+
+// RemoveLocation implements [domain.DriverLocationRepository].
+func (d *driverLocationRepository) RemoveLocation(ctx context.Context, driverID string) error {
+	if err := d.client.GeoRemove(ctx, driverLocationsKey, driverID); err != nil {
+		return err
+	}
+
+	return d.client.Delete(ctx, getDriverPresenceKey(driverID))
+}
+
+// Nearby implements [domain.DriverLocationRepository].
+func (d *driverLocationRepository) Nearby(ctx context.Context, latitude, longitude, radiusKm float64) ([]domain.DriverLocation, error) {
+	found, err := d.client.GeoSearch(ctx, driverLocationsKey, longitude, latitude, radiusKm)
+	if err != nil {
+		return nil, err
+	}
+
+	locations := make([]domain.DriverLocation, 0, len(found))
+	for _, f := range found {
+		present, err := d.client.Exists(ctx, getDriverPresenceKey(f.Member))
+		if err != nil {
+			return nil, err
+		}
+		if !present {
+			// The driver stopped sending their location.
+			if err := d.client.GeoRemove(ctx, driverLocationsKey, f.Member); err != nil {
+				logger.Warn("failed to drop stale driver location", zap.String("driver_id", f.Member), zap.Error(err))
+			}
+			continue
+		}
+
+		locations = append(locations, domain.DriverLocation{
+			DriverID:   f.Member,
+			Latitude:   f.Latitude,
+			Longitude:  f.Longitude,
+			DistanceKm: f.DistanceKm,
+		})
+	}
+
+	return locations, nil
+}
+
+// NewDriverLocationRepository creates a cache-based DriverLocationRepository.
+func NewDriverLocationRepository(client caching.CacheClient) domain.DriverLocationRepository {
+	return &driverLocationRepository{
+		client: client,
+	}
+}
@@ -0,0 +1,107 @@
+package repository
+
+import (
+	"context"
+	"errors"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/tamirat-dejene/ha-soranu/services/auth-service/internal/domain"
+	errs "github.com/tamirat-dejene/ha-soranu/services/auth-service/internal/domain/err"
+	postgres "github.com/tamirat-dejene/ha-soranu/shared/db/pg"
+	"github.com/tamirat-dejene/ha-soranu/shared/pkg/logger"
+	"go.uber.org/zap"
+)
+
+type identityRepository struct {
+	db postgres.PostgresClient
+}
+
+// GetUserIDByIdentity implements [domain.IdentityRepository].
+func (i *identityRepository) GetUserIDByIdentity(ctx context.Context, provider, subject string) (string, error) {
+	query := `
+		SELECT user_id
+		FROM user_identities
+		WHERE provider = $1 AND subject = $2
+	`
+
+	var userID string
+	err := i.db.QueryRow(ctx, query, provider, subject).Scan(&userID)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return "", nil
+		}
+		logger.Error("failed to get identity", zap.String("provider", provider), zap.Error(err))
+		return "", errs.OptimizedDbError(err)
+	}
+
+	return userID, nil
+}
+
+// LinkIdentity implements [domain.IdentityRepository].
+func (i *identityRepository) LinkIdentity(ctx context.Context, userID string, identity domain.ExternalIdentity) error {
+	query := `
+		INSERT INTO user_identities (provider, subject, user_id, email)
+		VALUES ($1, $2, $3, $4)
+		ON CONFLICT (provider, subject) DO UPDATE
+		SET email = EXCLUDED.email, last_used_at = CURRENT_TIMESTAMP
+		WHERE user_identities.user_id = EXCLUDED.user_id
+	`
+
+	rows_affected, err := i.db.Exec(ctx, query, identity.Provider, identity.Subject, userID, identity.Email)
+	if err != nil {
+		logger.Error("failed to link identity", zap.String("user_id", userID), zap.String("provider", identity.Provider), zap.Error(err))
+		return errs.OptimizedDbError(err)
+	}
+
+	// The provider account is linked to another user.
+	if rows_affected == 0 {
+		return errs.ErrConflict
+	}
+
+	return nil
+}
+
+// GetUserIdentities implements [domain.IdentityRepository].
+func (i *identityRepository) GetUserIdentities(ctx context.Context, userID string) ([]domain.UserIdentity, error) {
+	query := `
+		SELECT user_id, provider, subject, email, created_at, last_used_at
+		FROM user_identities
+		WHERE user_id = $1
+		ORDER BY created_at
+	`
+
+	rows, err := i.db.Query(ctx, query, userID)
+	if err != nil {
+		logger.Error("failed to get identities", zap.String("user_id", userID), zap.Error(err))
+		return nil, errs.OptimizedDbError(err)
+	}
+	defer rows.Close()
+
+	var identities []domain.UserIdentity
+	for rows.Next() {
+		var identity domain.UserIdentity
+		err := rows.Scan(
+			&identity.UserID,
+			&identity.Provider,
+			&identity.Subject,
+			&identity.Email,
+			&identity.CreatedAt,
+			&identity.LastUsedAt,
+		)
+		if err != nil {
+			return nil, errs.OptimizedDbError(err)
+		}
+		identities = append(identities, identity)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, errs.OptimizedDbError(err)
+	}
+
+	return identities, nil
+}
+
+// NewIdentityRepository creates the repository of the provider accounts
+// linked to users.
+func NewIdentityRepository(db postgres.PostgresClient) domain.IdentityRepository {
+	return &identityRepository{db: db}
+}
@@ -0,0 +1,91 @@
+package repository
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+	"time"
+
+	"github.com/tamirat-dejene/ha-soranu/services/auth-service/internal/domain"
+	"github.com/tamirat-dejene/ha-soranu/shared/pkg/caching"
+)
+
+type loginAttemptRepository struct {
+	client caching.CacheClient
+}
+
+func getLoginFailuresKey(key string) string {
+	return fmt.Sprintf("login_failures:%s", key)
+}
+
+func getLoginBlockKey(key string) string {
+	return fmt.Sprintf("login_block:%s", key)
+}
+
+// RecordFailure implements [domain.LoginAttemptRepository].
+func (l *loginAttemptRepository) RecordFailure(ctx context.Context, key string, window time.Duration) (int64, error) {
+	failuresKey := getLoginFailuresKey(key)
+
+	failures, err := l.client.Increment(ctx, failuresKey)
+	if err != nil {
+		return 0, err
+	}
+
+	// The window starts with the first failure and is not extended by later ones.
+	if failures == 1 {
+		if err := l.client.Expire(ctx, failuresKey, window); err != nil {
+			return 0, err
+		}
+	}
+
+	return failures, nil
+}
+
+// ResetFailures implements [domain.LoginAttemptRepository].
+func (l *loginAttemptRepository) ResetFailures(ctx context.Context, key string) error {
+	return l.client.Delete(ctx, getLoginFailuresKey(key))
+}
+
+// Block implements [domain.LoginAttemptRepository].
+func (l *loginAttemptRepository) Block(ctx context.Context, key string, until time.Time) error {
+	ttl := time.Until(until)
+	if ttl <= 0 {
+		return nil
+	}
+
+	// The end of the block is stored as well, since the cache cannot tell
+	// how long a key has left to live.
+	return l.client.Set(ctx, getLoginBlockKey(key), strconv.FormatInt(until.UnixMilli(), 10), ttl)
+}
+
+// BlockedUntil implements [domain.LoginAttemptRepository].
+func (l *loginAttemptRepository) BlockedUntil(ctx context.Context, key string) (time.Time, error) {
+	blockKey := getLoginBlockKey(key)
+
+	exists, err := l.client.Exists(ctx, blockKey)
+	if err != nil {
+		return time.Time{}, err
+	}
+	if !exists {
+		return time.Time{}, nil
+	}
+
+	data, err := l.client.Get(ctx, blockKey)
+	if err != nil {
+		return time.Time{}, err
+	}
+
+	until, err := strconv.ParseInt(data, 10, 64)
+	if err != nil {
+		return time.Time{}, err
+	}
+
+	return time.UnixMilli(until), nil
+}
+
+// NewLoginAttemptRepository creates a cache-based LoginAttemptRepository.
+func NewLoginAttemptRepository(client caching.CacheClient) domain.LoginAttemptRepository {
+	return &loginAttemptRepository{
+		client: client,
+	}
+}
@@ -0,0 +1,176 @@
+package repository
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/tamirat-dejene/ha-soranu/services/auth-service/internal/domain"
+	errs "github.com/tamirat-dejene/ha-soranu/services/auth-service/internal/domain/err"
+	postgres "github.com/tamirat-dejene/ha-soranu/shared/db/pg"
+	"github.com/tamirat-dejene/ha-soranu/shared/pkg/logger"
+	"go.uber.org/zap"
+)
+
+type mfaRepository struct {
+	db postgres.PostgresClient
+}
+
+// GetMFA implements [domain.MFARepository].
+func (m *mfaRepository) GetMFA(ctx context.Context, userID string) (*domain.MFA, error) {
+	query := `
+		SELECT user_id, secret, enabled, created_at
+		FROM user_mfa
+		WHERE user_id = $1
+	`
+
+	var mfa domain.MFA
+	err := m.db.QueryRow(ctx, query, userID).Scan(
+		&mfa.UserID,
+		&mfa.Secret,
+		&mfa.Enabled,
+		&mfa.CreatedAt,
+	)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, nil
+		}
+		logger.Error("failed to get mfa", zap.String("user_id", userID), zap.Error(err))
+		return nil, errs.OptimizedDbError(err)
+	}
+
+	return &mfa, nil
+}
+
+// SavePendingMFA implements [domain.MFARepository].
+func (m *mfaRepository) SavePendingMFA(ctx context.Context, userID, secret string) error {
+	query := `
+		INSERT INTO user_mfa (user_id, secret)
+		VALUES ($1, $2)
+		ON CONFLICT (user_id) DO UPDATE
+		SET secret = EXCLUDED.secret, created_at = CURRENT_TIMESTAMP
+		WHERE user_mfa.enabled = FALSE
+	`
+
+	rows_affected, err := m.db.Exec(ctx, query, userID, secret)
+	if err != nil {
+		return errs.OptimizedDbError(err)
+	}
+
+	if rows_affected == 0 {
+		return errs.ErrMFAAlreadyEnabled
+	}
+
+	return nil
+}
+
+// EnableMFA implements [domain.MFARepository].
+func (m *mfaRepository) EnableMFA(ctx context.Context, userID string, recoveryCodeHashes []string) error {
+	tx, err := m.db.BeginTx(ctx)
+	if err != nil {
+		return fmt.Errorf("failed to begin transaction: %w", err)
+	}
+
+	// Signal for successful execution
+	success := false
+
+	defer func() {
+		if !success {
+			tx.Rollback(ctx)
+		}
+	}()
+
+	query := `
+		UPDATE user_mfa
+		SET enabled = TRUE, enabled_at = CURRENT_TIMESTAMP
+		WHERE user_id = $1 AND enabled = FALSE
+	`
+
+	rows_affected, err := tx.Exec(ctx, query, userID)
+	if err != nil {
+		return errs.OptimizedDbError(err)
+	}
+	if rows_affected == 0 {
+		return errs.ErrMFAAlreadyEnabled
+	}
+
+	if _, err := tx.Exec(ctx, `DELETE FROM mfa_recovery_codes WHERE user_id = $1`, userID); err != nil {
+		return errs.OptimizedDbError(err)
+	}
+
+	for _, hash := range recoveryCodeHashes {
+		_, err := tx.Exec(ctx, `INSERT INTO mfa_recovery_codes (user_id, code_hash) VALUES ($1, $2)`, userID, hash)
+		if err != nil {
+			return errs.OptimizedDbError(err)
+		}
+	}
+
+	if err := tx.Commit(ctx); err != nil {
+		return errs.OptimizedDbError(err)
+	}
+
+	success = true
+	return nil
+}
+
+// DisableMFA implements [domain.MFARepository].
+func (m *mfaRepository) DisableMFA(ctx context.Context, userID string) error {
+	tx, err := m.db.BeginTx(ctx)
+	if err != nil {
+		return fmt.Errorf("failed to begin transaction: %w", err)
+	}
+
+	// Signal for successful execution
+	success := false
+
+	defer func() {
+		if !success {
+			tx.Rollback(ctx)
+		}
+	}()
+
+	if _, err := tx.Exec(ctx, `DELETE FROM mfa_recovery_codes WHERE user_id = $1`, userID); err != nil {
+		return errs.OptimizedDbError(err)
+	}
+
+	rows_affected, err := tx.Exec(ctx, `DELETE FROM user_mfa WHERE user_id = $1`, userID)
+	if err != nil {
+		return errs.OptimizedDbError(err)
+	}
+	if rows_affected == 0 {
+		return errs.ErrMFANotEnabled
+	}
+
+	if err := tx.Commit(ctx); err != nil {
+		return errs.OptimizedDbError(err)
+	}
+
+	success = true
+	return nil
+}
+
+// UseRecoveryCode implements [domain.MFARepository].
+func (m *mfaRepository) UseRecoveryCode(ctx context.Context, userID, codeHash string) error {
+	query := `
+		UPDATE mfa_recovery_codes
+		SET used_at = CURRENT_TIMESTAMP
+		WHERE user_id = $1 AND code_hash = $2 AND used_at IS NULL
+	`
+
+	rows_affected, err := m.db.Exec(ctx, query, userID, codeHash)
+	if err != nil {
+		return errs.OptimizedDbError(err)
+	}
+
+	if rows_affected == 0 {
+		return errs.ErrInvalidMFACode
+	}
+
+	return nil
+}
+
+// NewMFARepository creates a Postgres-based MFARepository.
+func NewMFARepository(db postgres.PostgresClient) domain.MFARepository {
+	return &mfaRepository{db: db}
+}
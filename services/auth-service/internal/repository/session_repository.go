@@ -0,0 +1,160 @@
+package repository
+
+import (
+	"context"
+	"time"
+
+	"github.com/tamirat-dejene/ha-soranu/services/auth-service/internal/domain"
+	errs "github.com/tamirat-dejene/ha-soranu/services/auth-service/internal/domain/err"
+	postgres "github.com/tamirat-dejene/ha-soranu/shared/db/pg"
+	"github.com/tamirat-dejene/ha-soranu/shared/pkg/logger"
+	"go.uber.org/zap"
+)
+
+type sessionRepository struct {
+	db postgres.PostgresClient
+}
+
+// CreateSession implements [domain.SessionRepository].
+func (s *sessionRepository) CreateSession(ctx context.Context, session domain.Session) error {
+	query := `
+		INSERT INTO sessions (
+			session_id, user_id, device_id, user_agent, ip_address, expires_at
+		)
+		VALUES ($1, $2, $3, $4, $5, $6)
+	`
+
+	_, err := s.db.Exec(ctx, query,
+		session.SessionID,
+		session.UserID,
+		session.DeviceID,
+		session.UserAgent,
+		session.IPAddress,
+		session.ExpiresAt,
+	)
+	if err != nil {
+		logger.Error("failed to create session", zap.String("user_id", session.UserID), zap.Error(err))
+		return errs.OptimizedDbError(err)
+	}
+
+	return nil
+}
+
+// TouchSession implements [domain.SessionRepository].
+func (s *sessionRepository) TouchSession(ctx context.Context, sessionID, ip string, expiresAt time.Time) error {
+	query := `
+		UPDATE sessions
+		SET last_used_at = CURRENT_TIMESTAMP,
+			ip_address = CASE WHEN $2 = '' THEN ip_address ELSE $2 END,
+			expires_at = $3
+		WHERE session_id = $1 AND revoked_at IS NULL
+	`
+
+	rows_affected, err := s.db.Exec(ctx, query, sessionID, ip, expiresAt)
+	if err != nil {
+		return errs.OptimizedDbError(err)
+	}
+
+	if rows_affected == 0 {
+		return errs.ErrSessionNotFound
+	}
+
+	return nil
+}
+
+// GetActiveSessions implements [domain.SessionRepository].
+func (s *sessionRepository) GetActiveSessions(ctx context.Context, userID string) ([]domain.Session, error) {
+	query := `
+		SELECT session_id, user_id, device_id, user_agent, ip_address, created_at, last_used_at, expires_at
+		FROM sessions
+		WHERE user_id = $1 AND revoked_at IS NULL AND expires_at > CURRENT_TIMESTAMP
+		ORDER BY last_used_at DESC
+	`
+
+	rows, err := s.db.Query(ctx, query, userID)
+	if err != nil {
+		logger.Error("failed to get sessions", zap.String("user_id", userID), zap.Error(err))
+		return nil, errs.OptimizedDbError(err)
+	}
+	defer rows.Close()
+
+	var sessions []domain.Session
+	for rows.Next() {
+		var session domain.Session
+		err := rows.Scan(
+			&session.SessionID,
+			&session.UserID,
+			&session.DeviceID,
+			&session.UserAgent,
+			&session.IPAddress,
+			&session.CreatedAt,
+			&session.LastUsedAt,
+			&session.ExpiresAt,
+		)
+		if err != nil {
+			return nil, errs.OptimizedDbError(err)
+		}
+		sessions = append(sessions, session)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, errs.OptimizedDbError(err)
+	}
+
+	return sessions, nil
+}
+
+// RevokeSession implements [domain.SessionRepository].
+func (s *sessionRepository) RevokeSession(ctx context.Context, userID, sessionID string) error {
+	query := `
+		UPDATE sessions
+		SET revoked_at = CURRENT_TIMESTAMP
+		WHERE user_id = $1 AND session_id::text = $2 AND revoked_at IS NULL
+	`
+
+	rows_affected, err := s.db.Exec(ctx, query, userID, sessionID)
+	if err != nil {
+		return errs.OptimizedDbError(err)
+	}
+
+	if rows_affected == 0 {
+		return errs.ErrSessionNotFound
+	}
+
+	return nil
+}
+
+// RevokeAllSessions implements [domain.SessionRepository].
+func (s *sessionRepository) RevokeAllSessions(ctx context.Context, userID, exceptID string) ([]string, error) {
+	query := `
+		UPDATE sessions
+		SET revoked_at = CURRENT_TIMESTAMP
+		WHERE user_id = $1 AND revoked_at IS NULL AND session_id::text <> $2
+		RETURNING session_id
+	`
+
+	rows, err := s.db.Query(ctx, query, userID, exceptID)
+	if err != nil {
+		logger.Error("failed to revoke sessions", zap.String("user_id", userID), zap.Error(err))
+		return nil, errs.OptimizedDbError(err)
+	}
+	defer rows.Close()
+
+	var revoked []string
+	for rows.Next() {
+		var sessionID string
+		if err := rows.Scan(&sessionID); err != nil {
+			return nil, errs.OptimizedDbError(err)
+		}
+		revoked = append(revoked, sessionID)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, errs.OptimizedDbError(err)
+	}
+
+	return revoked, nil
+}
+
+// NewSessionRepository creates a Postgres-based SessionRepository.
+func NewSessionRepository(db postgres.PostgresClient) domain.SessionRepository {
+	return &sessionRepository{db: db}
+}
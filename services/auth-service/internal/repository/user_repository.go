@@ -4,87 +4,489 @@ import (
 	"context"
 	"errors"
 	"fmt"
+	"strings"
+	"time"
 
 	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgconn"
 	"github.com/tamirat-dejene/ha-soranu/services/auth-service/internal/domain"
 	errs "github.com/tamirat-dejene/ha-soranu/services/auth-service/internal/domain/err"
 	postgres "github.com/tamirat-dejene/ha-soranu/shared/db/pg"
+	jwtvalidator "github.com/tamirat-dejene/ha-soranu/shared/pkg/auth/jwtvalidator"
 	"github.com/tamirat-dejene/ha-soranu/shared/pkg/logger"
 	"go.uber.org/zap"
 )
 
+// likeEscaper escapes the wildcards of a LIKE pattern so that searches match
+// them literally.
+var likeEscaper = strings.NewReplacer(`\`, `\\`, `%`, `\%`, `_`, `\_`)
+
 type userRepository struct {
 	db postgres.PostgresClient
 }
 
-// GetDrivers implements [domain.UserRepository].
-func (u *userRepository) GetDrivers(ctx context.Context, latitude float32, longitude float32, radius float32) ([]domain.Driver, error) {
+// RemoveDriver implements [domain.UserRepository].
+func (u *userRepository) RemoveDriver(ctx context.Context, driverID string) error {
 	query := `
-		SELECT d.driver_id, u.user_id, u.email, u.username, u.phone_number, u.created_at
-		FROM drivers d
-		JOIN users u ON d.user_id = u.user_id
+		DELETE FROM drivers
+		WHERE driver_id = $1
 	`
 
-	rows, err := u.db.Query(ctx, query)
+	rows_affected, err := u.db.Exec(ctx, query, driverID)
 	if err != nil {
-		logger.Error("failed to get drivers", zap.Error(err))
+		return errs.OptimizedDbError(err)
+	}
+
+	if rows_affected == 0 {
+		return errs.ErrDriverNotFound
+	}
+
+	return nil
+}
+
+// GetDriverByUserID implements [domain.UserRepository].
+func (u *userRepository) GetDriverByUserID(ctx context.Context, userID string) (*domain.Driver, error) {
+	query := `
+		SELECT driver_id, vehicle_type, vehicle_make, vehicle_model, vehicle_plate_number, vehicle_color, online, created_at
+		FROM drivers
+		WHERE user_id = $1
+	`
+
+	d := domain.Driver{User: domain.User{UserID: userID}}
+	err := u.db.QueryRow(ctx, query, userID).Scan(
+		&d.DriverID,
+		&d.Vehicle.Type,
+		&d.Vehicle.Make,
+		&d.Vehicle.Model,
+		&d.Vehicle.PlateNumber,
+		&d.Vehicle.Color,
+		&d.Online,
+		&d.CreatedAt,
+	)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, nil
+		}
+		logger.Error("failed to get driver", zap.String("user_id", userID), zap.Error(err))
 		return nil, errs.OptimizedDbError(err)
 	}
-	defer rows.Close()
 
-	var drivers []domain.Driver
+	return &d, nil
+}
+
+// UpdateVehicle implements [domain.UserRepository].
+func (u *userRepository) UpdateVehicle(ctx context.Context, driverID string, vehicle domain.Vehicle) error {
+	query := `
+		UPDATE drivers
+		SET vehicle_type = $2, vehicle_make = $3, vehicle_model = $4, vehicle_plate_number = $5, vehicle_color = $6
+		WHERE driver_id = $1
+	`
+
+	rows_affected, err := u.db.Exec(ctx, query, driverID, vehicle.Type, vehicle.Make, vehicle.Model, vehicle.PlateNumber, vehicle.Color)
+	if err != nil {
+		return errs.OptimizedDbError(err)
+	}
+
+	if rows_affected == 0 {
+		return errs.ErrDriverNotFound
+	}
+
+	return nil
+}
+
+// SetDriverOnline implements [domain.UserRepository].
+func (u *userRepository) SetDriverOnline(ctx context.Context, driverID string, online bool) error {
+	query := `
+		UPDATE drivers
+		SET online = $2
+		WHERE driver_id = $1
+	`
+
+	rows_affected, err := u.db.Exec(ctx, query, driverID, online)
+	if err != nil {
+		return errs.OptimizedDbError(err)
+	}
+
+	if rows_affected == 0 {
+		return errs.ErrDriverNotFound
+	}
+
+	return nil
+}
 
+// GetDriverUserID implements [domain.UserRepository].
+func (u *userRepository) GetDriverUserID(ctx context.Context, driverID string) (string, error) {
+	query := `
+		SELECT user_id
+		FROM drivers
+		WHERE driver_id = $1
+	`
+
+	var userID string
+	err := u.db.QueryRow(ctx, query, driverID).Scan(&userID)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return "", errs.ErrDriverNotFound
+		}
+		return "", errs.OptimizedDbError(err)
+	}
+
+	return userID, nil
+}
+
+// GetDriverID implements [domain.UserRepository].
+func (u *userRepository) GetDriverID(ctx context.Context, userID string) (string, error) {
+	query := `
+		SELECT driver_id
+		FROM drivers
+		WHERE user_id = $1
+	`
+
+	var driverID string
+	err := u.db.QueryRow(ctx, query, userID).Scan(&driverID)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return "", nil
+		}
+		return "", errs.OptimizedDbError(err)
+	}
+
+	return driverID, nil
+}
+
+// AnonymizeUser implements [domain.UserRepository].
+func (u *userRepository) AnonymizeUser(ctx context.Context, userID string) error {
+	tx, err := u.db.BeginTx(ctx)
+	if err != nil {
+		return fmt.Errorf("failed to begin transaction: %w", err)
+	}
+
+	// Signal for successful execution
+	success := false
+
+	defer func() {
+		if !success {
+			tx.Rollback(ctx)
+		}
+	}()
+
+	// The email stays unique, and no password hash matches an empty one.
+	query := `
+		UPDATE users
+		SET email = 'deleted-' || user_id::text || '@deleted.invalid',
+			username = 'Deleted user',
+			phone_number = NULL,
+			password = '',
+			email_verified = FALSE,
+			deleted_at = CURRENT_TIMESTAMP
+		WHERE user_id = $1 AND deleted_at IS NULL
+	`
+
+	rows_affected, err := tx.Exec(ctx, query, userID)
+	if err != nil {
+		return errs.OptimizedDbError(err)
+	}
+	if rows_affected == 0 {
+		return errs.ErrUserNotFound
+	}
+
+	for _, table := range []string{"addresses", "drivers", "user_roles", "mfa_recovery_codes", "user_mfa", "sessions", "user_identities"} {
+		if _, err := tx.Exec(ctx, fmt.Sprintf(`DELETE FROM %s WHERE user_id = $1`, table), userID); err != nil {
+			logger.Error("failed to delete user data", zap.String("table", table), zap.String("user_id", userID), zap.Error(err))
+			return errs.OptimizedDbError(err)
+		}
+	}
+
+	if err := tx.Commit(ctx); err != nil {
+		return errs.OptimizedDbError(err)
+	}
+
+	success = true
+	return nil
+}
+
+// SearchUsers implements [domain.UserRepository].
+func (u *userRepository) SearchUsers(ctx context.Context, search domain.UserSearch) ([]domain.UserAccount, error) {
+	query := `
+		SELECT user_id, email, username, COALESCE(phone_number, ''), created_at, email_verified,
+			suspended_at, suspension_reason, COALESCE(suspended_by::text, '')
+		FROM users
+		WHERE deleted_at IS NULL
+			AND ($1 = '' OR email ILIKE $1 OR username ILIKE $1)
+			AND (NOT $2 OR suspended_at IS NOT NULL)
+		ORDER BY created_at DESC, user_id
+		LIMIT $3 OFFSET $4
+	`
+
+	pattern := ""
+	if search.Query != "" {
+		pattern = "%" + likeEscaper.Replace(search.Query) + "%"
+	}
+
+	rows, err := u.db.Query(ctx, query, pattern, search.SuspendedOnly, search.Limit, search.Offset)
+	if err != nil {
+		logger.Error("failed to search users", zap.Error(err))
+		return nil, errs.OptimizedDbError(err)
+	}
+	defer rows.Close()
+
+	var accounts []domain.UserAccount
 	for rows.Next() {
-		var d domain.Driver
-		var u domain.User
+		var account domain.UserAccount
+		var suspendedAt *time.Time
+		var suspension domain.Suspension
 		err := rows.Scan(
-			&d.DriverID,
-			&u.UserID,
-			&u.Email,
-			&u.Username,
-			&u.PhoneNumber,
-			&u.CreatedAt,
+			&account.User.UserID,
+			&account.User.Email,
+			&account.User.Username,
+			&account.User.PhoneNumber,
+			&account.User.CreatedAt,
+			&account.User.EmailVerified,
+			&suspendedAt,
+			&suspension.Reason,
+			&suspension.SuspendedBy,
 		)
 		if err != nil {
 			return nil, errs.OptimizedDbError(err)
 		}
+		if suspendedAt != nil {
+			suspension.SuspendedAt = *suspendedAt
+			account.Suspension = &suspension
+		}
+		accounts = append(accounts, account)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, errs.OptimizedDbError(err)
+	}
 
-		d.User = u
-		drivers = append(drivers, d)
+	return accounts, nil
+}
+
+// GetSuspension implements [domain.UserRepository].
+func (u *userRepository) GetSuspension(ctx context.Context, userID string) (*domain.Suspension, error) {
+	query := `
+		SELECT suspended_at, suspension_reason, COALESCE(suspended_by::text, '')
+		FROM users
+		WHERE user_id = $1 AND deleted_at IS NULL
+	`
+
+	var suspendedAt *time.Time
+	var suspension domain.Suspension
+	err := u.db.QueryRow(ctx, query, userID).Scan(&suspendedAt, &suspension.Reason, &suspension.SuspendedBy)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, errs.ErrUserNotFound
+		}
+		return nil, errs.OptimizedDbError(err)
+	}
+	if suspendedAt == nil {
+		return nil, nil
 	}
 
-	return drivers, nil
+	suspension.SuspendedAt = *suspendedAt
+	return &suspension, nil
 }
 
-// RemoveDriver implements [domain.UserRepository].
-func (u *userRepository) RemoveDriver(ctx context.Context, driverID string) error {
+// SuspendUser implements [domain.UserRepository].
+func (u *userRepository) SuspendUser(ctx context.Context, userID string, suspension domain.Suspension) error {
 	query := `
-		DELETE FROM drivers
-		WHERE driver_id = $1
+		UPDATE users
+		SET suspended_at = CURRENT_TIMESTAMP, suspension_reason = $2, suspended_by = NULLIF($3, '')::uuid
+		WHERE user_id = $1 AND deleted_at IS NULL
 	`
 
-	rows_affected, err := u.db.Exec(ctx, query, driverID)
+	rows_affected, err := u.db.Exec(ctx, query, userID, suspension.Reason, suspension.SuspendedBy)
+	if err != nil {
+		logger.Error("failed to suspend user", zap.String("user_id", userID), zap.Error(err))
+		return errs.OptimizedDbError(err)
+	}
+	if rows_affected == 0 {
+		return errs.ErrUserNotFound
+	}
+
+	return nil
+}
+
+// UnsuspendUser implements [domain.UserRepository].
+func (u *userRepository) UnsuspendUser(ctx context.Context, userID string) error {
+	query := `
+		UPDATE users
+		SET suspended_at = NULL, suspension_reason = '', suspended_by = NULL
+		WHERE user_id = $1 AND deleted_at IS NULL
+	`
+
+	rows_affected, err := u.db.Exec(ctx, query, userID)
+	if err != nil {
+		logger.Error("failed to unsuspend user", zap.String("user_id", userID), zap.Error(err))
+		return errs.OptimizedDbError(err)
+	}
+	if rows_affected == 0 {
+		return errs.ErrUserNotFound
+	}
+
+	return nil
+}
+
+// GetUserRoles implements [domain.UserRepository].
+func (u *userRepository) GetUserRoles(ctx context.Context, userID string) ([]string, error) {
+	query := `
+		SELECT role FROM user_roles WHERE user_id = $1
+		UNION
+		SELECT 'driver' FROM drivers WHERE user_id = $1
+		ORDER BY 1
+	`
+
+	rows, err := u.db.Query(ctx, query, userID)
+	if err != nil {
+		logger.Error("failed to get user roles", zap.String("user_id", userID), zap.Error(err))
+		return nil, errs.OptimizedDbError(err)
+	}
+	defer rows.Close()
+
+	roles := []string{jwtvalidator.RoleCustomer}
+	for rows.Next() {
+		var role string
+		if err := rows.Scan(&role); err != nil {
+			return nil, errs.OptimizedDbError(err)
+		}
+		roles = append(roles, role)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, errs.OptimizedDbError(err)
+	}
+
+	return roles, nil
+}
+
+// GrantRole implements [domain.UserRepository].
+func (u *userRepository) GrantRole(ctx context.Context, userID, role string) error {
+	query := `
+		INSERT INTO user_roles (user_id, role)
+		VALUES ($1, $2)
+		ON CONFLICT (user_id, role) DO NOTHING
+	`
+
+	if _, err := u.db.Exec(ctx, query, userID, role); err != nil {
+		var pgErr *pgconn.PgError
+		// 23503: the user does not exist; 22P02: the ID cannot name one.
+		if errors.As(err, &pgErr) && (pgErr.Code == "23503" || pgErr.Code == "22P02") {
+			return errs.ErrUserNotFound
+		}
+		logger.Error("failed to grant role", zap.String("user_id", userID), zap.String("role", role), zap.Error(err))
+		return errs.OptimizedDbError(err)
+	}
+
+	return nil
+}
+
+// RevokeRole implements [domain.UserRepository].
+func (u *userRepository) RevokeRole(ctx context.Context, userID, role string) error {
+	query := `DELETE FROM user_roles WHERE user_id = $1 AND role = $2`
+
+	if _, err := u.db.Exec(ctx, query, userID, role); err != nil {
+		logger.Error("failed to revoke role", zap.String("user_id", userID), zap.String("role", role), zap.Error(err))
+		return errs.OptimizedDbError(err)
+	}
+
+	return nil
+}
+
+// MarkEmailVerified implements [domain.UserRepository].
+func (u *userRepository) MarkEmailVerified(ctx context.Context, userID, email string) error {
+	query := `
+		UPDATE users
+		SET email_verified = TRUE
+		WHERE user_id = $1 AND email = $2
+	`
+
+	rows_affected, err := u.db.Exec(ctx, query, userID, email)
+	if err != nil {
+		return errs.OptimizedDbError(err)
+	}
+
+	// The user is gone or the link was sent to an email they no longer use.
+	if rows_affected == 0 {
+		return errs.ErrInvalidLink
+	}
+
+	return nil
+}
+
+// UpdatePassword implements [domain.UserRepository].
+func (u *userRepository) UpdatePassword(ctx context.Context, userID, passwordHash string) error {
+	query := `
+		UPDATE users
+		SET password = $1
+		WHERE user_id = $2
+	`
+
+	rows_affected, err := u.db.Exec(ctx, query, passwordHash, userID)
+	if err != nil {
+		return errs.OptimizedDbError(err)
+	}
+
+	if rows_affected == 0 {
+		return errs.ErrUserNotFound
+	}
+
+	return nil
+}
+
+// UpdateUsername implements [domain.UserRepository].
+func (u *userRepository) UpdateUsername(ctx context.Context, userID, username string) error {
+	query := `
+		UPDATE users
+		SET username = $1
+		WHERE user_id = $2 AND deleted_at IS NULL
+	`
+
+	rows_affected, err := u.db.Exec(ctx, query, username, userID)
 	if err != nil {
 		return errs.OptimizedDbError(err)
 	}
 
 	if rows_affected == 0 {
-		return errors.New("driver not found")
+		return errs.ErrUserNotFound
+	}
+
+	return nil
+}
+
+// ChangeEmail implements [domain.UserRepository].
+func (u *userRepository) ChangeEmail(ctx context.Context, userID, currentEmail, newEmail string) error {
+	query := `
+		UPDATE users
+		SET email = $3, email_verified = TRUE
+		WHERE user_id = $1 AND email = $2 AND deleted_at IS NULL
+	`
+
+	rows_affected, err := u.db.Exec(ctx, query, userID, currentEmail, newEmail)
+	if err != nil {
+		var pgErr *pgconn.PgError
+		if errors.As(err, &pgErr) && pgErr.Code == "23505" {
+			return errs.ErrEmailAlreadyUsed
+		}
+		return errs.OptimizedDbError(err)
+	}
+
+	// The user is gone or changed their email since the link was sent.
+	if rows_affected == 0 {
+		return errs.ErrInvalidLink
 	}
 
 	return nil
 }
 
 // BeDriver implements [domain.UserRepository].
-func (u *userRepository) BeDriver(ctx context.Context, userID string) (string, error) {
+func (u *userRepository) BeDriver(ctx context.Context, userID string, vehicle domain.Vehicle) (string, error) {
 	query := `
-		INSERT INTO drivers (user_id)
-		VALUES ($1)
+		INSERT INTO drivers (user_id, vehicle_type, vehicle_make, vehicle_model, vehicle_plate_number, vehicle_color)
+		VALUES ($1, $2, $3, $4, $5, $6)
 		RETURNING driver_id
 	`
 	var id string
 
-	err := u.db.QueryRow(ctx, query, userID).Scan(&id)
+	err := u.db.QueryRow(ctx, query, userID, vehicle.Type, vehicle.Make, vehicle.Model, vehicle.PlateNumber, vehicle.Color).Scan(&id)
 	if err != nil {
 		logger.Error("failed to make user a driver", zap.String("user_id", userID), zap.Error(err))
 		return "", errs.OptimizedDbError(err)
@@ -98,7 +500,7 @@ func (u *userRepository) GetUserPasswordHashByEmail(ctx context.Context, email s
 	query := `
 		SELECT password
 		FROM users
-		WHERE email = $1
+		WHERE email = $1 AND deleted_at IS NULL
 	`
 
 	var passwordHash string
@@ -114,18 +516,57 @@ func (u *userRepository) GetUserPasswordHashByEmail(ctx context.Context, email s
 }
 
 // AddAddress implements domain.UserRepository.
-func (u *userRepository) AddAddress(ctx context.Context, userID string, address domain.Address) (domain.Address, error) {
+func (u *userRepository) AddAddress(ctx context.Context, userID string, address domain.Address, maxAddresses int) (domain.Address, error) {
+	tx, err := u.db.BeginTx(ctx)
+	if err != nil {
+		return domain.Address{}, fmt.Errorf("failed to begin transaction: %w", err)
+	}
+
+	// Signal for successful execution
+	success := false
+
+	defer func() {
+		if !success {
+			tx.Rollback(ctx)
+		}
+	}()
 
+	// 1. Lock the user, so that of two concurrent additions the second one
+	// counts the address of the first.
+	var lockedID string
+	err = tx.QueryRow(ctx, `SELECT user_id FROM users WHERE user_id = $1 AND deleted_at IS NULL FOR UPDATE`, userID).Scan(&lockedID)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return domain.Address{}, errs.ErrUserNotFound
+		}
+		return domain.Address{}, errs.OptimizedDbError(err)
+	}
+
+	var count int
+	if err := tx.QueryRow(ctx, `SELECT COUNT(*) FROM addresses WHERE user_id = $1`, userID).Scan(&count); err != nil {
+		return domain.Address{}, errs.OptimizedDbError(err)
+	}
+	if count >= maxAddresses {
+		return domain.Address{}, errs.ErrAddressLimitReached
+	}
+
+	// 2. Only one address of a user is the default one
+	isDefault := address.IsDefault || count == 0
+	if isDefault && count > 0 {
+		if _, err := tx.Exec(ctx, `UPDATE addresses SET is_default = FALSE WHERE user_id = $1 AND is_default`, userID); err != nil {
+			return domain.Address{}, errs.OptimizedDbError(err)
+		}
+	}
+
+	// 3. Insert the address
 	query := `
 		INSERT INTO addresses (
-			user_id, street, city, state, postal_code, country, latitude, longitude
+			user_id, street, city, state, postal_code, country, latitude, longitude, label, is_default
 		)
-		VALUES ($1, $2, $3, $4, $5, $6, $7, $8)
-		RETURNING address_id, street, city, state, postal_code, country, latitude, longitude, created_at
-	`
+		VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10)
+		RETURNING ` + addressColumns
 
-	var created domain.Address
-	err := u.db.QueryRow(ctx, query,
+	created, err := scanAddress(tx.QueryRow(ctx, query,
 		userID,
 		address.Street,
 		address.City,
@@ -134,25 +575,100 @@ func (u *userRepository) AddAddress(ctx context.Context, userID string, address
 		address.Country,
 		address.Latitude,
 		address.Longitude,
-	).Scan(
-		&created.AddressID,
-		&created.Street,
-		&created.City,
-		&created.State,
-		&created.PostalCode,
-		&created.Country,
-		&created.Latitude,
-		&created.Longitude,
-		&created.CreatedAt,
-	)
-
+		address.Label,
+		isDefault,
+	))
 	if err != nil {
 		return domain.Address{}, errs.OptimizedDbError(err)
 	}
 
+	if err := tx.Commit(ctx); err != nil {
+		return domain.Address{}, errs.OptimizedDbError(err)
+	}
+
+	success = true
 	return created, nil
 }
 
+// UpdateAddress implements domain.UserRepository.
+func (u *userRepository) UpdateAddress(ctx context.Context, userID string, address domain.Address) (domain.Address, error) {
+	query := `
+		UPDATE addresses
+		SET street = $3, city = $4, state = $5, postal_code = $6, country = $7,
+			latitude = $8, longitude = $9, label = $10
+		WHERE address_id::text = $1 AND user_id = $2
+		RETURNING ` + addressColumns
+
+	updated, err := scanAddress(u.db.QueryRow(ctx, query,
+		address.AddressID,
+		userID,
+		address.Street,
+		address.City,
+		address.State,
+		address.PostalCode,
+		address.Country,
+		address.Latitude,
+		address.Longitude,
+		address.Label,
+	))
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return domain.Address{}, errs.ErrAddressNotFound
+		}
+		return domain.Address{}, errs.OptimizedDbError(err)
+	}
+
+	return updated, nil
+}
+
+// SetDefaultAddress implements domain.UserRepository.
+func (u *userRepository) SetDefaultAddress(ctx context.Context, userID, addressID string) error {
+	tx, err := u.db.BeginTx(ctx)
+	if err != nil {
+		return fmt.Errorf("failed to begin transaction: %w", err)
+	}
+
+	// Signal for successful execution
+	success := false
+
+	defer func() {
+		if !success {
+			tx.Rollback(ctx)
+		}
+	}()
+
+	// The previous default is cleared first, as the unique index is checked
+	// row by row.
+	query := `
+		UPDATE addresses
+		SET is_default = FALSE
+		WHERE user_id = $1 AND is_default AND address_id::text <> $2
+	`
+	if _, err := tx.Exec(ctx, query, userID, addressID); err != nil {
+		return errs.OptimizedDbError(err)
+	}
+
+	query = `
+		UPDATE addresses
+		SET is_default = TRUE
+		WHERE user_id = $1 AND address_id::text = $2
+	`
+	rows_affected, err := tx.Exec(ctx, query, userID, addressID)
+	if err != nil {
+		return errs.OptimizedDbError(err)
+	}
+	if rows_affected == 0 {
+		return errs.ErrAddressNotFound
+	}
+
+	if err := tx.Commit(ctx); err != nil {
+		return errs.OptimizedDbError(err)
+	}
+
+	success = true
+	return nil
+}
+
 // AddPhoneNumber implements domain.UserRepository.
 func (u *userRepository) AddPhoneNumber(ctx context.Context, userID string, phone string) error {
 	query := `
@@ -197,7 +713,7 @@ func (u *userRepository) CreateUser(ctx context.Context, user *domain.UserRegist
 	query := `
 		INSERT INTO users (email, username, phone_number, password)
 		VALUES ($1, $2, $3, $4)
-		RETURNING user_id, email, username, phone_number
+		RETURNING user_id, email, username, phone_number, created_at, email_verified
 	`
 
 	var createdUser domain.User
@@ -211,8 +727,14 @@ func (u *userRepository) CreateUser(ctx context.Context, user *domain.UserRegist
 		&createdUser.Email,
 		&createdUser.Username,
 		&createdUser.PhoneNumber,
+		&createdUser.CreatedAt,
+		&createdUser.EmailVerified,
 	)
 	if err != nil {
+		var pgErr *pgconn.PgError
+		if errors.As(err, &pgErr) && pgErr.Code == "23505" {
+			return nil, errs.ErrEmailAlreadyUsed
+		}
 		return nil, fmt.Errorf("failed to insert user: %w", errs.OptimizedDbError(err))
 	}
 
@@ -222,11 +744,13 @@ func (u *userRepository) CreateUser(ctx context.Context, user *domain.UserRegist
 	return &createdUser, nil
 }
 
+// GetAddresses implements domain.UserRepository.
 func (u *userRepository) GetAddresses(ctx context.Context, userID string) ([]domain.Address, error) {
 	query := `
-		SELECT address_id, street, city, state, postal_code, country, latitude, longitude, created_at
+		SELECT ` + addressColumns + `
 		FROM addresses
 		WHERE user_id = $1
+		ORDER BY is_default DESC, created_at, address_id
 	`
 
 	rows, err := u.db.Query(ctx, query, userID)
@@ -238,18 +762,7 @@ func (u *userRepository) GetAddresses(ctx context.Context, userID string) ([]dom
 	var addresses []domain.Address
 
 	for rows.Next() {
-		var a domain.Address
-		err := rows.Scan(
-			&a.AddressID,
-			&a.Street,
-			&a.City,
-			&a.State,
-			&a.PostalCode,
-			&a.Country,
-			&a.Latitude,
-			&a.Longitude,
-			&a.CreatedAt,
-		)
+		a, err := scanAddress(rows)
 		if err != nil {
 			return nil, errs.OptimizedDbError(err)
 		}
@@ -263,9 +776,9 @@ func (u *userRepository) GetAddresses(ctx context.Context, userID string) ([]dom
 // GetUserByEmail implements domain.UserRepository.
 func (u *userRepository) GetUserByEmail(ctx context.Context, email string) (*domain.User, error) {
 	query := `
-		SELECT user_id, email, username, phone_number, created_at
+		SELECT user_id, email, username, phone_number, created_at, email_verified
 		FROM users
-		WHERE email = $1
+		WHERE email = $1 AND deleted_at IS NULL
 	`
 
 	var user domain.User
@@ -275,6 +788,7 @@ func (u *userRepository) GetUserByEmail(ctx context.Context, email string) (*dom
 		&user.Username,
 		&user.PhoneNumber,
 		&user.CreatedAt,
+		&user.EmailVerified,
 	)
 	if err != nil {
 		if errors.Is(err, pgx.ErrNoRows) {
@@ -295,9 +809,9 @@ func (u *userRepository) GetUserByEmail(ctx context.Context, email string) (*dom
 // GetUserByID implements domain.UserRepository.
 func (u *userRepository) GetUserByID(ctx context.Context, userID string) (*domain.User, error) {
 	query := `
-		SELECT user_id, email, username, phone_number, created_at
+		SELECT user_id, email, username, phone_number, created_at, email_verified
 		FROM users
-		WHERE user_id = $1
+		WHERE user_id = $1 AND deleted_at IS NULL
 	`
 
 	var user domain.User
@@ -307,6 +821,7 @@ func (u *userRepository) GetUserByID(ctx context.Context, userID string) (*domai
 		&user.Username,
 		&user.PhoneNumber,
 		&user.CreatedAt,
+		&user.EmailVerified,
 	)
 	if err != nil {
 		if errors.Is(err, pgx.ErrNoRows) {
@@ -326,20 +841,56 @@ func (u *userRepository) GetUserByID(ctx context.Context, userID string) (*domai
 
 // RemoveAddress implements domain.UserRepository.
 func (u *userRepository) RemoveAddress(ctx context.Context, userID string, addressID string) error {
+	tx, err := u.db.BeginTx(ctx)
+	if err != nil {
+		return fmt.Errorf("failed to begin transaction: %w", err)
+	}
+
+	// Signal for successful execution
+	success := false
+
+	defer func() {
+		if !success {
+			tx.Rollback(ctx)
+		}
+	}()
+
 	query := `
 		DELETE FROM addresses
-		WHERE address_id = $1 AND user_id = $2
+		WHERE address_id::text = $1 AND user_id = $2
+		RETURNING is_default
 	`
 
-	rows_affected, err := u.db.Exec(ctx, query, addressID, userID)
-	if err != nil {
+	var wasDefault bool
+	if err := tx.QueryRow(ctx, query, addressID, userID).Scan(&wasDefault); err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return errs.ErrAddressNotFound
+		}
 		return errs.OptimizedDbError(err)
 	}
 
-	if rows_affected == 0 {
-		return errors.New("address not found")
+	// The oldest remaining address takes over as the default one
+	if wasDefault {
+		query = `
+			UPDATE addresses
+			SET is_default = TRUE
+			WHERE address_id = (
+				SELECT address_id FROM addresses
+				WHERE user_id = $1
+				ORDER BY created_at, address_id
+				LIMIT 1
+			)
+		`
+		if _, err := tx.Exec(ctx, query, userID); err != nil {
+			return errs.OptimizedDbError(err)
+		}
 	}
 
+	if err := tx.Commit(ctx); err != nil {
+		return errs.OptimizedDbError(err)
+	}
+
+	success = true
 	return nil
 }
 
@@ -382,6 +933,27 @@ func (u *userRepository) UpdatePhoneNumber(ctx context.Context, userID string, p
 	return nil
 }
 
+// addressColumns are the columns scanAddress reads.
+const addressColumns = `address_id, street, city, state, postal_code, country, latitude, longitude, created_at, label, is_default`
+
+func scanAddress(row postgres.Row) (domain.Address, error) {
+	var a domain.Address
+	err := row.Scan(
+		&a.AddressID,
+		&a.Street,
+		&a.City,
+		&a.State,
+		&a.PostalCode,
+		&a.Country,
+		&a.Latitude,
+		&a.Longitude,
+		&a.CreatedAt,
+		&a.Label,
+		&a.IsDefault,
+	)
+	return a, err
+}
+
 // NewUserRepository creates a new instance of UserRepository
 func NewUserRepository(db postgres.PostgresClient) domain.UserRepository {
 	return &userRepository{
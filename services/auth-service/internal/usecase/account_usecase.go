@@ -0,0 +1,454 @@
+package usecase
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/tamirat-dejene/ha-soranu/services/auth-service/internal/domain"
+	errs "github.com/tamirat-dejene/ha-soranu/services/auth-service/internal/domain/err"
+	"github.com/tamirat-dejene/ha-soranu/shared/pkg/events"
+	"github.com/tamirat-dejene/ha-soranu/shared/pkg/logger"
+	"github.com/tamirat-dejene/ha-soranu/shared/pkg/messaging/kafka"
+	envent_envelope "github.com/tamirat-dejene/ha-soranu/shared/protos/envent_envelopepb"
+	"github.com/tamirat-dejene/ha-soranu/shared/protos/userdatapb"
+	"go.uber.org/zap"
+	"google.golang.org/protobuf/proto"
+)
+
+type accountUsecase struct {
+	ctxTimeout time.Duration
+	requests   domain.AccountRequestRepository
+	authRepo   domain.AuthRepository
+	userRepo   domain.UserRepository
+	sessions   domain.SessionRepository
+	mfa        domain.MFARepository
+	identities domain.IdentityRepository
+	providers  map[string]domain.IdentityProvider
+	publisher  events.EventPublisher
+	consumer   kafka.Consumer
+	mailer     domain.Mailer
+	// services are the other services holding user data, which every
+	// request waits for.
+	services []string
+}
+
+// userDataExport is the data the auth service holds about a user.
+type userDataExport struct {
+	UserID        string              `json:"user_id"`
+	Email         string              `json:"email"`
+	Username      string              `json:"username"`
+	PhoneNumber   string              `json:"phone_number,omitempty"`
+	EmailVerified bool                `json:"email_verified"`
+	CreatedAt     time.Time           `json:"created_at"`
+	Roles         []string            `json:"roles"`
+	DriverID      string              `json:"driver_id,omitempty"`
+	MFAEnabled    bool                `json:"mfa_enabled"`
+	Addresses     []addressExport     `json:"addresses"`
+	Sessions      []sessionDataExport `json:"sessions"`
+	Identities    []identityExport    `json:"identities"`
+}
+
+type addressExport struct {
+	Street     string    `json:"street"`
+	City       string    `json:"city"`
+	State      string    `json:"state"`
+	PostalCode uint32    `json:"postal_code"`
+	Country    string    `json:"country"`
+	Latitude   float32   `json:"latitude"`
+	Longitude  float32   `json:"longitude"`
+	CreatedAt  time.Time `json:"created_at"`
+}
+
+type identityExport struct {
+	Provider   string    `json:"provider"`
+	Email      string    `json:"email,omitempty"`
+	CreatedAt  time.Time `json:"created_at"`
+	LastUsedAt time.Time `json:"last_used_at"`
+}
+
+type sessionDataExport struct {
+	DeviceID   string    `json:"device_id,omitempty"`
+	UserAgent  string    `json:"user_agent,omitempty"`
+	IPAddress  string    `json:"ip_address,omitempty"`
+	CreatedAt  time.Time `json:"created_at"`
+	LastUsedAt time.Time `json:"last_used_at"`
+}
+
+// DeleteAccount implements domain.AccountUseCase.
+func (a *accountUsecase) DeleteAccount(ctx context.Context, userID string, proof domain.Reauthentication) (*domain.AccountRequest, error) {
+	c, cancel := context.WithTimeout(ctx, a.ctxTimeout)
+	defer cancel()
+
+	// 1. Make sure it is the user asking
+	user, err := a.userRepo.GetUserByID(c, userID)
+	if err != nil {
+		return nil, errs.ErrInternalServer
+	}
+	if user == nil {
+		return nil, errs.ErrUserNotFound
+	}
+
+	if err := reauthenticate(c, a.userRepo, a.identities, a.providers, user, proof); err != nil {
+		return nil, err
+	}
+
+	// 2. Ask the other services to delete their data
+	request, err := a.startRequest(c, user, domain.AccountDeletion)
+	if err != nil {
+		return nil, err
+	}
+
+	// 3. Delete the account. The user is signed out everywhere; access tokens
+	// already issued stay valid until they expire.
+	revoked, err := a.sessions.RevokeAllSessions(c, user.UserID, "")
+	if err != nil {
+		logger.Error("failed to revoke sessions of deleted account", zap.String("user_id", user.UserID), zap.Error(err))
+		return nil, a.recordOwnResult(c, request, nil, err)
+	}
+	for _, sessionID := range revoked {
+		if err := a.authRepo.RevokeTokenFamily(c, sessionID); err != nil {
+			logger.Error("failed to revoke tokens of deleted account", zap.String("user_id", user.UserID), zap.Error(err))
+			return nil, a.recordOwnResult(c, request, nil, err)
+		}
+	}
+
+	if err := a.userRepo.AnonymizeUser(c, user.UserID); err != nil {
+		logger.Error("failed to anonymize user", zap.String("user_id", user.UserID), zap.Error(err))
+		return nil, a.recordOwnResult(c, request, nil, err)
+	}
+
+	if err := a.recordOwnResult(c, request, nil, nil); err != nil {
+		return nil, err
+	}
+
+	return request, nil
+}
+
+// ExportMyData implements domain.AccountUseCase.
+func (a *accountUsecase) ExportMyData(ctx context.Context, userID string) (*domain.AccountRequest, error) {
+	c, cancel := context.WithTimeout(ctx, a.ctxTimeout)
+	defer cancel()
+
+	user, err := a.userRepo.GetUserByID(c, userID)
+	if err != nil {
+		return nil, errs.ErrInternalServer
+	}
+	if user == nil {
+		return nil, errs.ErrUserNotFound
+	}
+
+	request, err := a.startRequest(c, user, domain.AccountExport)
+	if err != nil {
+		return nil, err
+	}
+
+	data, err := a.exportUserData(c, user)
+	if err != nil {
+		logger.Error("failed to export user data", zap.String("user_id", user.UserID), zap.Error(err))
+		return nil, a.recordOwnResult(c, request, nil, err)
+	}
+
+	if err := a.recordOwnResult(c, request, data, nil); err != nil {
+		return nil, err
+	}
+
+	return request, nil
+}
+
+// GetAccountRequest implements domain.AccountUseCase.
+func (a *accountUsecase) GetAccountRequest(ctx context.Context, userID, requestID string) (*domain.AccountRequest, error) {
+	c, cancel := context.WithTimeout(ctx, a.ctxTimeout)
+	defer cancel()
+
+	request, err := a.requests.GetRequest(c, userID, requestID)
+	if err != nil {
+		return nil, errs.ErrInternalServer
+	}
+	if request == nil {
+		return nil, errs.ErrAccountRequestNotFound
+	}
+
+	return request, nil
+}
+
+// StartConsumer implements domain.AccountUseCase.
+func (a *accountUsecase) StartConsumer(ctx context.Context) error {
+	logger.Info("Starting account request consumer")
+
+	return a.consumer.Subscribe(ctx, []string{
+		events.UserDataProcessedEvent,
+	}, func(msgCtx context.Context, msg *kafka.Message) error {
+		var envelope envent_envelope.EventEnvelope
+		if err := proto.Unmarshal(msg.Value, &envelope); err != nil {
+			logger.Error("failed to unmarshal event envelope", zap.Error(err))
+			return err
+		}
+
+		if envelope.EventType != events.UserDataProcessedEvent {
+			logger.Warn("unknown event type", zap.String("event_type", envelope.EventType))
+			return nil
+		}
+
+		var processed userdatapb.UserDataProcessed
+		if err := proto.Unmarshal(envelope.Payload, &processed); err != nil {
+			logger.Error("failed to unmarshal UserDataProcessed event", zap.Error(err))
+			return err
+		}
+
+		return a.handleDataProcessed(msgCtx, &processed)
+	})
+}
+
+func (a *accountUsecase) handleDataProcessed(ctx context.Context, processed *userdatapb.UserDataProcessed) error {
+	c, cancel := context.WithTimeout(ctx, a.ctxTimeout)
+	defer cancel()
+
+	logger.Info("Processing UserDataProcessed event",
+		zap.String("request_id", processed.RequestId),
+		zap.String("service", processed.Service))
+
+	result := domain.AccountRequestService{
+		Service: processed.Service,
+		Status:  domain.AccountRequestCompleted,
+		Data:    processed.Data,
+	}
+	if processed.Error != "" {
+		logger.Error("service failed to process account request",
+			zap.String("request_id", processed.RequestId),
+			zap.String("service", processed.Service),
+			zap.String("error", processed.Error))
+		result.Status = domain.AccountRequestFailed
+		result.Error = processed.Error
+		result.Data = nil
+	}
+
+	finished, err := a.requests.RecordServiceResult(c, processed.RequestId, result)
+	if err != nil {
+		logger.Error("failed to record account request result", zap.String("request_id", processed.RequestId), zap.Error(err))
+		return err
+	}
+	if finished != nil {
+		a.reportFinished(c, finished)
+	}
+
+	return nil
+}
+
+// startRequest records a request of the kind and publishes it to the other
+// services.
+func (a *accountUsecase) startRequest(ctx context.Context, user *domain.User, kind domain.AccountRequestKind) (*domain.AccountRequest, error) {
+	driverID, err := a.userRepo.GetDriverID(ctx, user.UserID)
+	if err != nil {
+		return nil, errs.ErrInternalServer
+	}
+
+	services := append([]string{events.UserDataServiceAuth}, a.services...)
+	request, err := a.requests.CreateRequest(ctx, domain.AccountRequest{
+		UserID:      user.UserID,
+		Kind:        kind,
+		NotifyEmail: user.Email,
+	}, services)
+	if err != nil {
+		if errors.Is(err, errs.ErrAccountRequestPending) {
+			return nil, err
+		}
+		return nil, errs.ErrInternalServer
+	}
+
+	event := &userdatapb.UserDataRequested{
+		RequestId:       request.RequestID,
+		UserId:          user.UserID,
+		DriverId:        driverID,
+		RequestedAtUnix: request.CreatedAt.Unix(),
+	}
+
+	if kind == domain.AccountDeletion {
+		err = a.publisher.PublishUserDeletionRequested(ctx, event)
+	} else {
+		err = a.publisher.PublishUserExportRequested(ctx, event)
+	}
+	if err != nil {
+		// Nothing has been deleted yet, so the user can simply try again.
+		if failErr := a.requests.FailRequest(ctx, request.RequestID); failErr != nil {
+			logger.Error("failed to fail account request", zap.String("request_id", request.RequestID), zap.Error(failErr))
+		}
+		return nil, errs.ErrInternalServer
+	}
+
+	return request, nil
+}
+
+// recordOwnResult records the outcome of the auth service's part of the
+// request. It returns ErrInternalServer when the part failed.
+func (a *accountUsecase) recordOwnResult(ctx context.Context, request *domain.AccountRequest, data []byte, cause error) error {
+	result := domain.AccountRequestService{
+		Service: events.UserDataServiceAuth,
+		Status:  domain.AccountRequestCompleted,
+		Data:    data,
+	}
+	if cause != nil {
+		result.Status = domain.AccountRequestFailed
+		result.Error = cause.Error()
+	}
+
+	finished, err := a.requests.RecordServiceResult(ctx, request.RequestID, result)
+	if err != nil {
+		logger.Error("failed to record account request result", zap.String("request_id", request.RequestID), zap.Error(err))
+		return errs.ErrInternalServer
+	}
+
+	for i := range request.Services {
+		if request.Services[i].Service == result.Service {
+			request.Services[i].Status = result.Status
+		}
+	}
+	if finished != nil {
+		request.Status = finished.Status
+		request.CompletedAt = finished.CompletedAt
+		a.reportFinished(ctx, finished)
+	}
+
+	if cause != nil {
+		return errs.ErrInternalServer
+	}
+	return nil
+}
+
+// reportFinished emails the user the outcome of their request. The address a
+// deleted account was reported to is forgotten afterwards.
+func (a *accountUsecase) reportFinished(ctx context.Context, request *domain.AccountRequest) {
+	if request.NotifyEmail == "" {
+		return
+	}
+
+	var email domain.Email
+	switch {
+	case request.Kind == domain.AccountDeletion && request.Status == domain.AccountRequestCompleted:
+		email = domain.Email{
+			Subject: "Your account has been deleted",
+			Body:    "Your account and the personal data we held about you have been deleted.\n",
+		}
+	case request.Kind == domain.AccountDeletion:
+		email = domain.Email{
+			Subject: "Your account has been deleted",
+			Body: fmt.Sprintf("Your account has been deleted, but some of your data could not be removed yet. "+
+				"Please contact support with the reference %s.\n", request.RequestID),
+		}
+	case request.Status == domain.AccountRequestCompleted:
+		email = domain.Email{
+			Subject: "Your data export is ready",
+			Body:    fmt.Sprintf("Your data export %s is ready. Sign in to download it.\n", request.RequestID),
+		}
+	default:
+		email = domain.Email{
+			Subject: "Your data export failed",
+			Body:    "We could not export your data. Please try again later.\n",
+		}
+	}
+	email.To = request.NotifyEmail
+
+	if err := a.mailer.Send(ctx, email); err != nil {
+		logger.Error("failed to send account request email", zap.String("request_id", request.RequestID), zap.Error(err))
+		return
+	}
+
+	if request.Kind == domain.AccountDeletion {
+		if err := a.requests.ClearNotifyEmail(ctx, request.RequestID); err != nil {
+			logger.Error("failed to clear account request email", zap.String("request_id", request.RequestID), zap.Error(err))
+		}
+	}
+}
+
+// exportUserData collects what the auth service holds about the user as JSON.
+func (a *accountUsecase) exportUserData(ctx context.Context, user *domain.User) ([]byte, error) {
+	roles, err := a.userRepo.GetUserRoles(ctx, user.UserID)
+	if err != nil {
+		return nil, err
+	}
+	driverID, err := a.userRepo.GetDriverID(ctx, user.UserID)
+	if err != nil {
+		return nil, err
+	}
+	mfa, err := a.mfa.GetMFA(ctx, user.UserID)
+	if err != nil {
+		return nil, err
+	}
+	sessions, err := a.sessions.GetActiveSessions(ctx, user.UserID)
+	if err != nil {
+		return nil, err
+	}
+	identities, err := a.identities.GetUserIdentities(ctx, user.UserID)
+	if err != nil {
+		return nil, err
+	}
+
+	export := userDataExport{
+		UserID:        user.UserID,
+		Email:         user.Email,
+		Username:      user.Username,
+		PhoneNumber:   user.PhoneNumber,
+		EmailVerified: user.EmailVerified,
+		CreatedAt:     user.CreatedAt,
+		Roles:         roles,
+		DriverID:      driverID,
+		MFAEnabled:    mfa != nil && mfa.Enabled,
+		Addresses:     []addressExport{},
+		Sessions:      []sessionDataExport{},
+		Identities:    []identityExport{},
+	}
+	for _, address := range user.Addresses {
+		export.Addresses = append(export.Addresses, addressExport{
+			Street:     address.Street,
+			City:       address.City,
+			State:      address.State,
+			PostalCode: address.PostalCode,
+			Country:    address.Country,
+			Latitude:   address.Latitude,
+			Longitude:  address.Longitude,
+			CreatedAt:  address.CreatedAt,
+		})
+	}
+	for _, session := range sessions {
+		export.Sessions = append(export.Sessions, sessionDataExport{
+			DeviceID:   session.DeviceID,
+			UserAgent:  session.UserAgent,
+			IPAddress:  session.IPAddress,
+			CreatedAt:  session.CreatedAt,
+			LastUsedAt: session.LastUsedAt,
+		})
+	}
+	for _, identity := range identities {
+		export.Identities = append(export.Identities, identityExport{
+			Provider:   identity.Provider,
+			Email:      identity.Email,
+			CreatedAt:  identity.CreatedAt,
+			LastUsedAt: identity.LastUsedAt,
+		})
+	}
+
+	return json.Marshal(export)
+}
+
+// NewAccountUsecase creates the usecase of account deletions and data
+// exports. providers are the identity providers users without a password
+// reauthenticate with, and services names the other services holding user
+// data.
+func NewAccountUsecase(ctxTimeout time.Duration, requests domain.AccountRequestRepository, authRepo domain.AuthRepository, userRepo domain.UserRepository, sessions domain.SessionRepository, mfa domain.MFARepository, identities domain.IdentityRepository, providers map[string]domain.IdentityProvider, publisher events.EventPublisher, consumer kafka.Consumer, mailer domain.Mailer, services []string) domain.AccountUseCase {
+	return &accountUsecase{
+		ctxTimeout: ctxTimeout,
+		requests:   requests,
+		authRepo:   authRepo,
+		userRepo:   userRepo,
+		sessions:   sessions,
+		mfa:        mfa,
+		identities: identities,
+		providers:  providers,
+		publisher:  publisher,
+		consumer:   consumer,
+		mailer:     mailer,
+		services:   services,
+	}
+}
@@ -0,0 +1,285 @@
+package usecase
+
+import (
+	"context"
+	"fmt"
+	"slices"
+	"strings"
+	"time"
+	"unicode/utf8"
+
+	"github.com/tamirat-dejene/ha-soranu/services/auth-service/internal/domain"
+	errs "github.com/tamirat-dejene/ha-soranu/services/auth-service/internal/domain/err"
+	jwtvalidator "github.com/tamirat-dejene/ha-soranu/shared/pkg/auth/jwtvalidator"
+	"github.com/tamirat-dejene/ha-soranu/shared/pkg/auth/svcauth"
+	"github.com/tamirat-dejene/ha-soranu/shared/pkg/logger"
+	"go.uber.org/zap"
+)
+
+const (
+	// defaultUserSearchLimit is how many users a search returns when it does
+	// not say, and maxUserSearchLimit how many it may ask for.
+	defaultUserSearchLimit = 20
+	maxUserSearchLimit     = 100
+	// maxSuspensionReasonLength bounds the reason given for a suspension.
+	maxSuspensionReasonLength = 500
+)
+
+// grantedRoles are the roles kept in user_roles; the others are derived.
+var grantedRoles = []string{jwtvalidator.RoleRestaurantOwner, jwtvalidator.RoleAdmin}
+
+type adminUsecase struct {
+	ctxTimeout time.Duration
+	userRepo   domain.UserRepository
+	auth       domain.AuthUseCase
+	// restaurantService is the name the restaurant service signs its calls
+	// with.
+	restaurantService string
+}
+
+// SearchUsers implements [domain.AdminUseCase].
+func (a *adminUsecase) SearchUsers(ctx context.Context, search domain.UserSearch) ([]domain.UserAccount, error) {
+	if !svcauth.IsAdmin(ctx) {
+		return nil, errs.ErrUnauthorized
+	}
+
+	c, cancel := context.WithTimeout(ctx, a.ctxTimeout)
+	defer cancel()
+
+	search.Query = strings.TrimSpace(search.Query)
+	if search.Limit < 0 || search.Offset < 0 {
+		return nil, fmt.Errorf("%w: limit and offset must not be negative", errs.ErrInvalidRequest)
+	}
+	if search.Limit == 0 {
+		search.Limit = defaultUserSearchLimit
+	}
+	search.Limit = min(search.Limit, maxUserSearchLimit)
+
+	accounts, err := a.userRepo.SearchUsers(c, search)
+	if err != nil {
+		return nil, err
+	}
+
+	for i := range accounts {
+		roles, err := a.userRepo.GetUserRoles(c, accounts[i].User.UserID)
+		if err != nil {
+			return nil, err
+		}
+		accounts[i].Roles = roles
+	}
+
+	return accounts, nil
+}
+
+// SuspendUser implements [domain.AdminUseCase].
+func (a *adminUsecase) SuspendUser(ctx context.Context, userID, reason string) (*domain.UserAccount, error) {
+	admin, ok := svcauth.UserFromContext(ctx)
+	if !ok || !svcauth.IsAdmin(ctx) {
+		return nil, errs.ErrUnauthorized
+	}
+
+	c, cancel := context.WithTimeout(ctx, a.ctxTimeout)
+	defer cancel()
+
+	reason = strings.TrimSpace(reason)
+	if reason == "" || utf8.RuneCountInString(reason) > maxSuspensionReasonLength {
+		return nil, fmt.Errorf("%w: reason is required and must be at most %d characters", errs.ErrInvalidRequest, maxSuspensionReasonLength)
+	}
+	if userID == admin.ID {
+		return nil, fmt.Errorf("%w: admins cannot suspend themselves", errs.ErrInvalidRequest)
+	}
+
+	err := a.userRepo.SuspendUser(c, userID, domain.Suspension{
+		Reason:      reason,
+		SuspendedBy: admin.ID,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	// Suspended users cannot refresh their tokens; ending their sessions also
+	// signs them out of the session list.
+	revoked, err := a.auth.RevokeAllSessions(c, userID, "")
+	if err != nil {
+		logger.Error("failed to revoke sessions of suspended user", zap.String("user_id", userID), zap.Error(err))
+	}
+
+	logger.Info("user suspended",
+		zap.String("user_id", userID),
+		zap.String("admin_id", admin.ID),
+		zap.String("reason", reason),
+		zap.Int("revoked_sessions", revoked),
+	)
+
+	return a.account(c, userID)
+}
+
+// UnsuspendUser implements [domain.AdminUseCase].
+func (a *adminUsecase) UnsuspendUser(ctx context.Context, userID string) (*domain.UserAccount, error) {
+	admin, ok := svcauth.UserFromContext(ctx)
+	if !ok || !svcauth.IsAdmin(ctx) {
+		return nil, errs.ErrUnauthorized
+	}
+
+	c, cancel := context.WithTimeout(ctx, a.ctxTimeout)
+	defer cancel()
+
+	if err := a.userRepo.UnsuspendUser(c, userID); err != nil {
+		return nil, err
+	}
+
+	logger.Info("user unsuspended", zap.String("user_id", userID), zap.String("admin_id", admin.ID))
+
+	return a.account(c, userID)
+}
+
+// RevokeUserSessions implements [domain.AdminUseCase].
+func (a *adminUsecase) RevokeUserSessions(ctx context.Context, userID string) (int, error) {
+	admin, ok := svcauth.UserFromContext(ctx)
+	if !ok || !svcauth.IsAdmin(ctx) {
+		return 0, errs.ErrUnauthorized
+	}
+
+	c, cancel := context.WithTimeout(ctx, a.ctxTimeout)
+	defer cancel()
+
+	user, err := a.userRepo.GetUserByID(c, userID)
+	if err != nil {
+		return 0, err
+	}
+	if user == nil {
+		return 0, errs.ErrUserNotFound
+	}
+
+	revoked, err := a.auth.RevokeAllSessions(c, userID, "")
+	if err != nil {
+		return 0, err
+	}
+
+	logger.Info("user sessions revoked by admin", zap.String("user_id", userID), zap.String("admin_id", admin.ID), zap.Int("revoked_sessions", revoked))
+
+	return revoked, nil
+}
+
+// GrantRole implements [domain.AdminUseCase].
+func (a *adminUsecase) GrantRole(ctx context.Context, userID, role string) (*domain.UserAccount, error) {
+	if !svcauth.IsAdmin(ctx) && !a.registersRestaurantOwner(ctx, role) {
+		return nil, errs.ErrUnauthorized
+	}
+	if !slices.Contains(grantedRoles, role) {
+		return nil, fmt.Errorf("%w: role must be one of %s", errs.ErrInvalidRequest, strings.Join(grantedRoles, ", "))
+	}
+
+	c, cancel := context.WithTimeout(ctx, a.ctxTimeout)
+	defer cancel()
+
+	if err := a.userRepo.GrantRole(c, userID, role); err != nil {
+		return nil, err
+	}
+
+	grantedBy, _ := svcauth.UserFromContext(ctx)
+	logger.Info("role granted", zap.String("user_id", userID), zap.String("role", role), zap.String("granted_by", grantedBy.ID))
+
+	return a.account(c, userID)
+}
+
+// RevokeRole implements [domain.AdminUseCase].
+func (a *adminUsecase) RevokeRole(ctx context.Context, userID, role string) (*domain.UserAccount, error) {
+	admin, ok := svcauth.UserFromContext(ctx)
+	if !ok || !svcauth.IsAdmin(ctx) {
+		return nil, errs.ErrUnauthorized
+	}
+	if !slices.Contains(grantedRoles, role) {
+		return nil, fmt.Errorf("%w: role must be one of %s", errs.ErrInvalidRequest, strings.Join(grantedRoles, ", "))
+	}
+	// Keeps at least the admin making the call.
+	if userID == admin.ID && role == jwtvalidator.RoleAdmin {
+		return nil, fmt.Errorf("%w: admins cannot revoke their own admin role", errs.ErrInvalidRequest)
+	}
+
+	c, cancel := context.WithTimeout(ctx, a.ctxTimeout)
+	defer cancel()
+
+	account, err := a.account(c, userID)
+	if err != nil {
+		return nil, err
+	}
+	if err := a.userRepo.RevokeRole(c, userID, role); err != nil {
+		return nil, err
+	}
+	account.Roles = slices.DeleteFunc(account.Roles, func(r string) bool { return r == role })
+
+	logger.Info("role revoked", zap.String("user_id", userID), zap.String("role", role), zap.String("admin_id", admin.ID))
+
+	return account, nil
+}
+
+// registersRestaurantOwner reports whether the call is the restaurant
+// service making the owner of a restaurant it registers a restaurant owner.
+func (a *adminUsecase) registersRestaurantOwner(ctx context.Context, role string) bool {
+	caller, ok := svcauth.CallerFromContext(ctx)
+	return ok && caller.Service == a.restaurantService && role == jwtvalidator.RoleRestaurantOwner
+}
+
+// BootstrapAdmins implements [domain.AdminUseCase].
+func (a *adminUsecase) BootstrapAdmins(ctx context.Context, emails []string) error {
+	c, cancel := context.WithTimeout(ctx, a.ctxTimeout)
+	defer cancel()
+
+	for _, email := range emails {
+		user, err := a.userRepo.GetUserByEmail(c, email)
+		if err != nil {
+			return err
+		}
+		// Whoever registers an unverified address does not become an admin.
+		if user == nil || !user.EmailVerified {
+			logger.Warn("admin email has no verified user yet", zap.String("email", email))
+			continue
+		}
+
+		if err := a.userRepo.GrantRole(c, user.UserID, jwtvalidator.RoleAdmin); err != nil {
+			return err
+		}
+		logger.Info("admin role granted at startup", zap.String("user_id", user.UserID), zap.String("email", email))
+	}
+
+	return nil
+}
+
+// account returns the user as admins see them.
+func (a *adminUsecase) account(ctx context.Context, userID string) (*domain.UserAccount, error) {
+	user, err := a.userRepo.GetUserByID(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+	if user == nil {
+		return nil, errs.ErrUserNotFound
+	}
+
+	roles, err := a.userRepo.GetUserRoles(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	suspension, err := a.userRepo.GetSuspension(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	return &domain.UserAccount{
+		User:       *user,
+		Roles:      roles,
+		Suspension: suspension,
+	}, nil
+}
+
+// NewAdminUsecase creates the admin use case. restaurantService is the name
+// the restaurant service signs its calls with.
+func NewAdminUsecase(timeout time.Duration, userRepo domain.UserRepository, auth domain.AuthUseCase, restaurantService string) domain.AdminUseCase {
+	return &adminUsecase{
+		ctxTimeout:        timeout,
+		userRepo:          userRepo,
+		auth:              auth,
+		restaurantService: restaurantService,
+	}
+}
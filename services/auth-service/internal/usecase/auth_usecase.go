@@ -2,6 +2,12 @@ package usecase
 
 import (
 	"context"
+	"errors"
+	"fmt"
+	"net/mail"
+	"net/url"
+	"sort"
+	"strings"
 	"time"
 
 	authservice "github.com/tamirat-dejene/ha-soranu/services/auth-service"
@@ -9,41 +15,170 @@ import (
 	errs "github.com/tamirat-dejene/ha-soranu/services/auth-service/internal/domain/err"
 	internalutil "github.com/tamirat-dejene/ha-soranu/services/auth-service/internal/util"
 	jwtvalidator "github.com/tamirat-dejene/ha-soranu/shared/pkg/auth/jwtvalidator"
+	"github.com/tamirat-dejene/ha-soranu/shared/pkg/logger"
+	"go.uber.org/zap"
+)
+
+const (
+	// loginFreeFailures is how many failed logins an account can make before
+	// each further one delays its next attempt.
+	loginFreeFailures = 2
+	// loginDelayBase is the delay after the first failure past the free ones.
+	loginDelayBase = time.Second
+
+	// mfaMaxAttempts is how many codes a login challenge accepts before the
+	// user has to enter their password again.
+	mfaMaxAttempts = 5
+	// mfaRecoveryCodes is how many recovery codes a user gets.
+	mfaRecoveryCodes = 10
+	// totpReplayWindow covers the time steps a TOTP code is accepted in, so a
+	// code is remembered as used for as long as it is valid.
+	totpReplayWindow = 2 * time.Minute
+
+	// reauthenticationMaxAge is how recently the ID token a user without a
+	// password reauthenticates with must have been issued.
+	reauthenticationMaxAge = 5 * time.Minute
 )
 
 type authUsecase struct {
 	ctxTimeout time.Duration
 	authRepo   domain.AuthRepository
 	userRepo   domain.UserRepository
+	sessions   domain.SessionRepository
+	attempts   domain.LoginAttemptRepository
+	mfa        domain.MFARepository
+	identities domain.IdentityRepository
+	providers  map[string]domain.IdentityProvider
+	accessKeys *internalutil.AccessKeySet
+	secrets    *internalutil.SecretBox // nil when TOTP secrets cannot be encrypted
+	passwords  *internalutil.PasswordPolicy
+	mailer     domain.Mailer
 	env        authservice.Env
 }
 
 // LoginWithEmailAndPassword implements domain.AuthUseCase.
-func (a *authUsecase) LoginWithEmailAndPassword(ctx context.Context, input domain.LoginWithEmail) (*domain.User, *domain.AuthTokens, error) {
+func (a *authUsecase) LoginWithEmailAndPassword(ctx context.Context, input domain.LoginWithEmail) (*domain.LoginResult, error) {
 	c, cancel := context.WithTimeout(ctx, a.ctxTimeout)
 	defer cancel()
 
+	keys := loginAttemptKeys(input)
+
+	// 1. Refuse the login while the account or the client is blocked
+	if err := a.checkLoginBlocked(c, keys); err != nil {
+		return nil, err
+	}
+
+	// 2. Check the credentials. Unknown emails fail like wrong passwords.
 	passwordHash, err := a.userRepo.GetUserPasswordHashByEmail(c, input.Email)
 	if err != nil {
-		return nil, nil, errs.ErrInternalServer
+		return nil, errs.ErrInternalServer
+	}
+
+	if !internalutil.CheckPassword(passwordHash, input.Password) {
+		a.recordLoginFailure(c, keys)
+		return nil, errs.ErrInvalidCredentials
+	}
+
+	user, err := a.userRepo.GetUserByEmail(c, input.Email)
+	if err != nil || user == nil {
+		return nil, errs.ErrInternalServer
+	}
+
+	// Suspended users are only told so once they have proven who they are.
+	if err := a.checkNotSuspended(c, user.UserID); err != nil {
+		return nil, err
+	}
+
+	// 3. Users with two-factor authentication get a challenge for their code.
+	// Their failures are kept until the code is right too, or whoever knows
+	// the password could guess codes through one challenge after another.
+	mfa, err := a.mfa.GetMFA(c, user.UserID)
+	if err != nil {
+		return nil, errs.ErrInternalServer
+	}
+	if mfa != nil && mfa.Enabled {
+		mfaToken, err := a.saveMFAChallenge(c, user)
+		if err != nil {
+			logger.Error("failed to save mfa challenge", zap.String("user_id", user.UserID), zap.Error(err))
+			return nil, errs.ErrInternalServer
+		}
+		return &domain.LoginResult{MFAToken: mfaToken}, nil
+	}
+
+	// 4. A successful login clears the account's failures
+	a.resetLoginFailures(c, user.UserID, keys)
+
+	authToken, err := a.issueTokens(c, user, input.Client)
+	if err != nil {
+		return nil, errs.ErrInternalServer
+	}
+
+	return &domain.LoginResult{User: user, Tokens: authToken}, nil
+}
+
+// CompleteMFALogin implements domain.AuthUseCase.
+func (a *authUsecase) CompleteMFALogin(ctx context.Context, input domain.MFALogin) (*domain.User, *domain.AuthTokens, error) {
+	c, cancel := context.WithTimeout(ctx, a.ctxTimeout)
+	defer cancel()
+
+	if input.MFAToken == "" {
+		return nil, nil, errs.ErrMFAChallengeInvalid
 	}
 
-	err = internalutil.ComparePassword(passwordHash, input.Password)
+	// 1. Find the challenge, which only takes a few guesses
+	challenge, attempts, err := a.authRepo.UseMFAChallenge(c, input.MFAToken)
 	if err != nil {
-		return nil, nil, errs.ErrInvalidCredentials
+		if errors.Is(err, errs.ErrMFAChallengeInvalid) {
+			return nil, nil, err
+		}
+		return nil, nil, errs.ErrInternalServer
+	}
+	if attempts > mfaMaxAttempts {
+		if err := a.authRepo.DeleteMFAChallenge(c, input.MFAToken); err != nil {
+			logger.Error("failed to delete mfa challenge", zap.String("user_id", challenge.UserID), zap.Error(err))
+		}
+		return nil, nil, errs.ErrMFAChallengeInvalid
 	}
 
-	authToken, refreshTokenID, err := internalutil.SignUser(input.Email, &a.env, nil)
+	user, err := a.userRepo.GetUserByID(c, challenge.UserID)
 	if err != nil {
 		return nil, nil, errs.ErrInternalServer
 	}
+	if user == nil || user.Email != challenge.Email {
+		return nil, nil, errs.ErrMFAChallengeInvalid
+	}
 
-	err = a.authRepo.SaveRefreshToken(c, input.Email, refreshTokenID)
+	mfa, err := a.mfa.GetMFA(c, user.UserID)
 	if err != nil {
 		return nil, nil, errs.ErrInternalServer
 	}
+	if mfa == nil || !mfa.Enabled {
+		return nil, nil, errs.ErrMFAChallengeInvalid
+	}
 
-	user, err := a.userRepo.GetUserByEmail(c, input.Email)
+	// 2. Check the code. Wrong codes count as failed logins of the account,
+	// which locks it like wrong passwords do.
+	keys := loginAttemptKeys(domain.LoginWithEmail{Email: challenge.Email, Client: input.Client})
+	if err := a.checkLoginBlocked(c, keys); err != nil {
+		return nil, nil, err
+	}
+	if err := a.verifyMFACode(c, mfa, input.Code); err != nil {
+		if errors.Is(err, errs.ErrInvalidMFACode) {
+			a.recordLoginFailure(c, keys)
+		}
+		return nil, nil, err
+	}
+
+	if err := a.authRepo.DeleteMFAChallenge(c, input.MFAToken); err != nil {
+		return nil, nil, errs.ErrInternalServer
+	}
+	a.resetLoginFailures(c, user.UserID, keys)
+
+	// 3. Sign the user in, unless they were suspended since the challenge
+	if err := a.checkNotSuspended(c, user.UserID); err != nil {
+		return nil, nil, err
+	}
+	authToken, err := a.issueTokens(c, user, input.Client)
 	if err != nil {
 		return nil, nil, errs.ErrInternalServer
 	}
@@ -51,51 +186,264 @@ func (a *authUsecase) LoginWithEmailAndPassword(ctx context.Context, input domai
 	return user, authToken, nil
 }
 
-func (a *authUsecase) LoginWithGoogle(ctx context.Context, input domain.LoginWithGoogle) (*domain.User, *domain.AuthTokens, error) {
+// EnrollMFA implements domain.AuthUseCase.
+func (a *authUsecase) EnrollMFA(ctx context.Context, userID string) (*domain.MFAEnrollment, error) {
 	c, cancel := context.WithTimeout(ctx, a.ctxTimeout)
 	defer cancel()
 
-	// 1. Validate Google token
-	claims, err := internalutil.ValidateGoogleIDToken(c, a.env.GoogleClientID, input.IDToken)
+	if a.secrets == nil {
+		return nil, errs.ErrMFAUnavailable
+	}
+
+	user, err := a.userRepo.GetUserByID(c, userID)
 	if err != nil {
-		return nil, nil, err
+		return nil, errs.ErrInternalServer
+	}
+	if user == nil {
+		return nil, errs.ErrUserNotFound
 	}
 
-	// 2. Try to find the user
-	user, err := a.userRepo.GetUserByEmail(c, claims.Email)
+	secret, err := internalutil.GenerateTOTPSecret()
+	if err != nil {
+		return nil, errs.ErrInternalServer
+	}
+	sealed, err := a.secrets.Seal(secret)
+	if err != nil {
+		return nil, errs.ErrInternalServer
+	}
+
+	if err := a.mfa.SavePendingMFA(c, user.UserID, sealed); err != nil {
+		if errors.Is(err, errs.ErrMFAAlreadyEnabled) {
+			return nil, err
+		}
+		return nil, errs.ErrInternalServer
+	}
+
+	return &domain.MFAEnrollment{
+		Secret:          secret,
+		ProvisioningURI: internalutil.TOTPProvisioningURI(a.env.MFAIssuer, user.Email, secret),
+	}, nil
+}
+
+// ConfirmMFA implements domain.AuthUseCase.
+func (a *authUsecase) ConfirmMFA(ctx context.Context, userID, code string) ([]string, error) {
+	c, cancel := context.WithTimeout(ctx, a.ctxTimeout)
+	defer cancel()
+
+	mfa, err := a.mfa.GetMFA(c, userID)
+	if err != nil {
+		return nil, errs.ErrInternalServer
+	}
+	if mfa == nil {
+		return nil, errs.ErrMFANotEnabled
+	}
+	if mfa.Enabled {
+		return nil, errs.ErrMFAAlreadyEnabled
+	}
+
+	// Only a TOTP code proves the app was set up; there are no recovery codes yet.
+	if err := a.verifyTOTPCode(c, mfa, code); err != nil {
+		return nil, err
+	}
+
+	codes, err := internalutil.GenerateRecoveryCodes(mfaRecoveryCodes)
+	if err != nil {
+		return nil, errs.ErrInternalServer
+	}
+	hashes := make([]string, len(codes))
+	for i, code := range codes {
+		hashes[i] = internalutil.HashToken(internalutil.NormalizeRecoveryCode(code))
+	}
 
-	// 3. If user does not exist → create a new user
-	if err == nil && user == nil {
-		newUser := &domain.UserRegister{
-			Email:       claims.Email,
-			Username:    claims.Username,
-			PhoneNumber: "",
-			Password:    "",
+	if err := a.mfa.EnableMFA(c, userID, hashes); err != nil {
+		if errors.Is(err, errs.ErrMFAAlreadyEnabled) {
+			return nil, err
 		}
+		return nil, errs.ErrInternalServer
+	}
+
+	return codes, nil
+}
 
-		user, err = a.userRepo.CreateUser(c, newUser)
+// DisableMFA implements domain.AuthUseCase.
+func (a *authUsecase) DisableMFA(ctx context.Context, userID string, proof domain.Reauthentication, code string) error {
+	c, cancel := context.WithTimeout(ctx, a.ctxTimeout)
+	defer cancel()
+
+	mfa, err := a.mfa.GetMFA(c, userID)
+	if err != nil {
+		return errs.ErrInternalServer
+	}
+	if mfa == nil || !mfa.Enabled {
+		return errs.ErrMFANotEnabled
+	}
+
+	user, err := a.userRepo.GetUserByID(c, userID)
+	if err != nil {
+		return errs.ErrInternalServer
+	}
+	if user == nil {
+		return errs.ErrUserNotFound
+	}
+
+	if err := reauthenticate(c, a.userRepo, a.identities, a.providers, user, proof); err != nil {
+		return err
+	}
+
+	if err := a.verifyMFACode(c, mfa, code); err != nil {
+		return err
+	}
+
+	if err := a.mfa.DisableMFA(c, userID); err != nil {
+		if errors.Is(err, errs.ErrMFANotEnabled) {
+			return err
+		}
+		return errs.ErrInternalServer
+	}
+
+	return nil
+}
+
+// LoginWithGoogle implements domain.AuthUseCase.
+func (a *authUsecase) LoginWithGoogle(ctx context.Context, input domain.LoginWithGoogle) (*domain.LoginResult, error) {
+	return a.LoginWithIdentityProvider(ctx, domain.LoginWithIdentityProvider{
+		Provider: domain.GoogleProvider,
+		IDToken:  input.IDToken,
+		Client:   input.Client,
+	})
+}
+
+// LoginWithIdentityProvider implements domain.AuthUseCase.
+func (a *authUsecase) LoginWithIdentityProvider(ctx context.Context, input domain.LoginWithIdentityProvider) (*domain.LoginResult, error) {
+	c, cancel := context.WithTimeout(ctx, a.ctxTimeout)
+	defer cancel()
+
+	provider, ok := a.providers[input.Provider]
+	if !ok {
+		return nil, errs.ErrUnknownIdentityProvider
+	}
+
+	// 1. Validate the ID token
+	identity, err := provider.Verify(c, input.IDToken)
+	if err != nil {
+		logger.Warn("identity provider token rejected", zap.String("provider", input.Provider), zap.Error(err))
+		return nil, errs.ErrInvalidIdentityToken
+	}
+
+	// 2. Find the user the provider account is linked to, linking it on first login
+	user, err := a.identityUser(c, identity)
+	if err != nil {
+		return nil, err
+	}
+
+	// The provider has already confirmed the address.
+	if identity.EmailVerified && !user.EmailVerified && identity.Email == user.Email {
+		if err := a.userRepo.MarkEmailVerified(c, user.UserID, user.Email); err != nil {
+			return nil, errs.ErrInternalServer
+		}
+		user.EmailVerified = true
+	}
+
+	if err := a.checkNotSuspended(c, user.UserID); err != nil {
+		return nil, err
+	}
+
+	// 3. The provider stands in for the password only: users with two-factor
+	// authentication still get a challenge for their code.
+	mfa, err := a.mfa.GetMFA(c, user.UserID)
+	if err != nil {
+		return nil, errs.ErrInternalServer
+	}
+	if mfa != nil && mfa.Enabled {
+		mfaToken, err := a.saveMFAChallenge(c, user)
 		if err != nil {
-			return nil, nil, err
+			logger.Error("failed to save mfa challenge", zap.String("user_id", user.UserID), zap.Error(err))
+			return nil, errs.ErrInternalServer
 		}
-	} else {
-		// other DB errors
-		return nil, nil, err
+		return &domain.LoginResult{MFAToken: mfaToken}, nil
 	}
 
-	// 4. Generate JWT and refresh token
-	authToken, refreshTokenID, err := internalutil.SignUser(user.Email, &a.env, nil)
+	// 4. Generate JWT and refresh token in a new token family
+	authToken, err := a.issueTokens(c, user, input.Client)
 	if err != nil {
-		return nil, nil, err
+		return nil, errs.ErrInternalServer
+	}
+
+	return &domain.LoginResult{User: user, Tokens: authToken}, nil
+}
+
+// IdentityProviders implements domain.AuthUseCase.
+func (a *authUsecase) IdentityProviders() []string {
+	names := make([]string, 0, len(a.providers))
+	for name := range a.providers {
+		names = append(names, name)
 	}
+	sort.Strings(names)
+	return names
+}
 
-	// 5. Store refresh token
-	err = a.authRepo.SaveRefreshToken(c, user.UserID, refreshTokenID)
+// identityUser returns the user the provider account is linked to. On the
+// account's first login it is linked to the user with the same email, or to a
+// new user when there is none.
+func (a *authUsecase) identityUser(ctx context.Context, identity *domain.ExternalIdentity) (*domain.User, error) {
+	userID, err := a.identities.GetUserIDByIdentity(ctx, identity.Provider, identity.Subject)
 	if err != nil {
-		return nil, nil, err
+		return nil, errs.ErrInternalServer
 	}
 
-	// 6. Return user + tokens
-	return user, authToken, nil
+	var user *domain.User
+	if userID != "" {
+		user, err = a.userRepo.GetUserByID(ctx, userID)
+		if err != nil || user == nil {
+			return nil, errs.ErrInternalServer
+		}
+	} else {
+		if identity.Email == "" {
+			return nil, errs.ErrInvalidIdentityToken
+		}
+
+		user, err = a.userRepo.GetUserByEmail(ctx, identity.Email)
+		if err != nil {
+			return nil, errs.ErrInternalServer
+		}
+
+		// Whoever holds an unverified address at the provider need not own
+		// the account registered with it.
+		if user != nil && !identity.EmailVerified {
+			return nil, errs.ErrIdentityEmailUnverified
+		}
+
+		if user == nil {
+			user, err = a.userRepo.CreateUser(ctx, &domain.UserRegister{
+				Email:    identity.Email,
+				Username: identityUsername(identity),
+			})
+			if err != nil {
+				return nil, err
+			}
+		}
+	}
+
+	// Linking again records when the account was last used.
+	if err := a.identities.LinkIdentity(ctx, user.UserID, *identity); err != nil {
+		logger.Error("failed to link identity", zap.String("user_id", user.UserID), zap.String("provider", identity.Provider), zap.Error(err))
+		return nil, errs.ErrInternalServer
+	}
+
+	return user, nil
+}
+
+// identityUsername is the username of a user created on their first login
+// with a provider: their name there, or the local part of their email.
+func identityUsername(identity *domain.ExternalIdentity) string {
+	name := strings.TrimSpace(identity.Name)
+	if name == "" {
+		name, _, _ = strings.Cut(identity.Email, "@")
+	}
+	if runes := []rune(name); len(runes) > maxUsernameLength {
+		name = string(runes[:maxUsernameLength])
+	}
+	return name
 }
 
 // Logout implements domain.AuthUseCase.
@@ -108,28 +456,70 @@ func (a *authUsecase) Logout(ctx context.Context, refreshToken string) error {
 		return errs.ErrTokenRevoked
 	}
 
-	return a.authRepo.DeleteRefreshToken(c, claims.TokenID)
+	// Logging out ends the whole session, whichever of its tokens is presented.
+	if err := a.authRepo.RevokeTokenFamily(c, claims.FamilyID); err != nil {
+		return err
+	}
+
+	a.endSession(c, claims.Subject, claims.FamilyID)
+	return nil
 }
 
 // RefreshTokens implements domain.AuthUseCase.
-func (a *authUsecase) RefreshTokens(ctx context.Context, refreshToken string) (*domain.AuthTokens, error) {
+func (a *authUsecase) RefreshTokens(ctx context.Context, refreshToken string, client domain.ClientInfo) (*domain.AuthTokens, error) {
 	c, cancel := context.WithTimeout(ctx, a.ctxTimeout)
 	defer cancel()
 
 	claims, err := jwtvalidator.ValidateRefreshToken(a.env.RefreshTokenPublicKey, refreshToken)
-	if err != nil {
+	if err != nil || claims.FamilyID == "" {
 		return nil, errs.ErrTokenRevoked
 	}
-	_, err = a.authRepo.ConsumeRefreshToken(c, claims.TokenID)
+
+	// Roles and email are read again so that changes take effect on the next
+	// refresh. Tokens issued before they carried the user ID are looked up by
+	// email.
+	var user *domain.User
+	if claims.Subject != "" {
+		user, err = a.userRepo.GetUserByID(c, claims.Subject)
+	} else {
+		user, err = a.userRepo.GetUserByEmail(c, claims.UserEmail)
+	}
 	if err != nil {
+		return nil, errs.ErrInternalServer
+	}
+	if user == nil {
 		return nil, errs.ErrTokenRevoked
 	}
+	if err := a.checkNotSuspended(c, user.UserID); err != nil {
+		return nil, err
+	}
 
-	authToken, _, err := internalutil.SignUser(claims.UserEmail, &a.env, nil)
+	subject, err := a.tokenSubject(c, user)
 	if err != nil {
 		return nil, errs.ErrInternalServer
 	}
 
+	authToken, refreshTokenID, err := internalutil.SignUser(subject, claims.FamilyID, a.accessKeys, &a.env, nil)
+	if err != nil {
+		return nil, errs.ErrInternalServer
+	}
+
+	// The presented token is only consumed once the new one can be handed out.
+	_, err = a.authRepo.RotateRefreshToken(c, claims.FamilyID, claims.TokenID, refreshTokenID)
+	if err != nil {
+		if errors.Is(err, errs.ErrTokenReused) {
+			logger.Warn("refresh token reuse detected, token family revoked", zap.String("user_email", claims.UserEmail), zap.String("family_id", claims.FamilyID))
+			a.endSession(c, user.UserID, claims.FamilyID)
+			return nil, err
+		}
+		if errors.Is(err, errs.ErrTokenRevoked) {
+			return nil, err
+		}
+		return nil, errs.ErrInternalServer
+	}
+
+	a.touchSession(c, user.UserID, claims.FamilyID, client)
+
 	return authToken, nil
 }
 
@@ -138,6 +528,10 @@ func (a *authUsecase) Register(ctx context.Context, input domain.UserRegister) (
 	c, cancel := context.WithTimeout(ctx, a.ctxTimeout)
 	defer cancel()
 
+	if err := a.passwords.Check("password", input.Password, input.Email, input.Username); err != nil {
+		return nil, nil, err
+	}
+
 	var err error
 	input.Password, err = internalutil.HashPassword(input.Password)
 	if err != nil {
@@ -146,14 +540,18 @@ func (a *authUsecase) Register(ctx context.Context, input domain.UserRegister) (
 
 	user, err := a.userRepo.CreateUser(c, &input)
 	if err != nil {
+		if errors.Is(err, errs.ErrEmailAlreadyUsed) {
+			return nil, nil, err
+		}
 		return nil, nil, errs.ErrInternalServer
 	}
-	authToken, refreshTokenID, err := internalutil.SignUser(user.Email, &a.env, nil)
-	if err != nil {
-		return nil, nil, errs.ErrInternalServer
+
+	// The account works without the email; the user can ask for another one.
+	if err := a.sendEmailVerification(c, user); err != nil {
+		logger.Error("failed to send email verification", zap.String("user_id", user.UserID), zap.Error(err))
 	}
 
-	err = a.authRepo.SaveRefreshToken(c, user.UserID, refreshTokenID)
+	authToken, err := a.issueTokens(c, user, input.Client)
 	if err != nil {
 		return nil, nil, errs.ErrInternalServer
 	}
@@ -161,12 +559,743 @@ func (a *authUsecase) Register(ctx context.Context, input domain.UserRegister) (
 	return user, authToken, nil
 }
 
+// ChangePassword implements domain.AuthUseCase.
+func (a *authUsecase) ChangePassword(ctx context.Context, userID, currentPassword, newPassword, currentSessionID string) error {
+	c, cancel := context.WithTimeout(ctx, a.ctxTimeout)
+	defer cancel()
+
+	user, err := a.userRepo.GetUserByID(c, userID)
+	if err != nil {
+		return errs.ErrInternalServer
+	}
+	if user == nil {
+		return errs.ErrUserNotFound
+	}
+
+	passwordHash, err := a.userRepo.GetUserPasswordHashByEmail(c, user.Email)
+	if err != nil {
+		return errs.ErrInternalServer
+	}
+	if !internalutil.CheckPassword(passwordHash, currentPassword) {
+		return errs.ErrInvalidCredentials
+	}
+
+	if err := a.passwords.Check("new_password", newPassword, user.Email, user.Username); err != nil {
+		return err
+	}
+
+	newPasswordHash, err := internalutil.HashPassword(newPassword)
+	if err != nil {
+		return errs.ErrInternalServer
+	}
+	if err := a.userRepo.UpdatePassword(c, user.UserID, newPasswordHash); err != nil {
+		return errs.ErrInternalServer
+	}
+
+	// Whoever knew the old password is signed out, the user stays signed in.
+	if _, err := a.revokeSessions(c, user.UserID, currentSessionID); err != nil {
+		logger.Error("failed to revoke sessions after password change", zap.String("user_id", user.UserID), zap.Error(err))
+		return errs.ErrInternalServer
+	}
+
+	return nil
+}
+
+// ChangeEmail implements domain.AuthUseCase.
+func (a *authUsecase) ChangeEmail(ctx context.Context, userID string, proof domain.Reauthentication, newEmail, currentSessionID string) error {
+	c, cancel := context.WithTimeout(ctx, a.ctxTimeout)
+	defer cancel()
+
+	newEmail = strings.TrimSpace(newEmail)
+	if address, err := mail.ParseAddress(newEmail); err != nil || address.Address != newEmail {
+		return errs.ErrInvalidEmailFormat
+	}
+
+	user, err := a.userRepo.GetUserByID(c, userID)
+	if err != nil {
+		return errs.ErrInternalServer
+	}
+	if user == nil {
+		return errs.ErrUserNotFound
+	}
+	if strings.EqualFold(user.Email, newEmail) {
+		return errs.ErrInvalidRequest
+	}
+
+	if err := reauthenticate(c, a.userRepo, a.identities, a.providers, user, proof); err != nil {
+		return err
+	}
+
+	existing, err := a.userRepo.GetUserByEmail(c, newEmail)
+	if err != nil {
+		return errs.ErrInternalServer
+	}
+	if existing != nil {
+		return errs.ErrEmailAlreadyUsed
+	}
+
+	ttl, err := time.ParseDuration(a.env.EmailVerificationTTL)
+	if err != nil {
+		return errs.ErrInternalServer
+	}
+
+	token, err := internalutil.NewOneTimeToken()
+	if err != nil {
+		return errs.ErrInternalServer
+	}
+	issued := domain.OneTimeToken{
+		UserID:    user.UserID,
+		Email:     user.Email,
+		NewEmail:  newEmail,
+		SessionID: currentSessionID,
+	}
+	if err := a.authRepo.SaveOneTimeToken(c, domain.TokenPurposeEmailChange, token, issued, ttl); err != nil {
+		return errs.ErrInternalServer
+	}
+
+	// Sent to the new address, which proves the user reads it.
+	link := fmt.Sprintf("%s/confirm-email?token=%s", a.env.AppBaseURL, url.QueryEscape(token))
+	err = a.mailer.Send(c, domain.Email{
+		To:      newEmail,
+		Subject: "Confirm your new email address",
+		Body: fmt.Sprintf("Hi %s,\n\nConfirm %s as the email address of your account by opening the link below within %s:\n\n%s\n\n"+
+			"If you did not ask to change your email, you can ignore this email.\n", user.Username, newEmail, ttl, link),
+	})
+	if err != nil {
+		logger.Error("failed to send email change confirmation", zap.String("user_id", user.UserID), zap.Error(err))
+		return errs.ErrInternalServer
+	}
+
+	return nil
+}
+
+// ConfirmEmailChange implements domain.AuthUseCase.
+func (a *authUsecase) ConfirmEmailChange(ctx context.Context, token string) error {
+	c, cancel := context.WithTimeout(ctx, a.ctxTimeout)
+	defer cancel()
+
+	if token == "" {
+		return errs.ErrInvalidLink
+	}
+
+	issued, err := a.authRepo.ConsumeOneTimeToken(c, domain.TokenPurposeEmailChange, token)
+	if err != nil {
+		if errors.Is(err, errs.ErrInvalidLink) {
+			return err
+		}
+		return errs.ErrInternalServer
+	}
+
+	if err := a.userRepo.ChangeEmail(c, issued.UserID, issued.Email, issued.NewEmail); err != nil {
+		if errors.Is(err, errs.ErrInvalidLink) || errors.Is(err, errs.ErrEmailAlreadyUsed) {
+			return err
+		}
+		return errs.ErrInternalServer
+	}
+
+	// Tokens carry the email, so every other session is signed out. The one
+	// that asked for the change gets the new email on its next refresh.
+	if _, err := a.revokeSessions(c, issued.UserID, issued.SessionID); err != nil {
+		logger.Error("failed to revoke sessions after email change", zap.String("user_id", issued.UserID), zap.Error(err))
+		return errs.ErrInternalServer
+	}
+
+	// Whoever took over the account cannot hide it from the owner.
+	err = a.mailer.Send(c, domain.Email{
+		To:      issued.Email,
+		Subject: "Your email address was changed",
+		Body: fmt.Sprintf("The email address of your account was changed to %s.\n\n"+
+			"If you did not make this change, reset your password and contact support.\n", issued.NewEmail),
+	})
+	if err != nil {
+		logger.Error("failed to send email change notice", zap.String("user_id", issued.UserID), zap.Error(err))
+	}
+
+	return nil
+}
+
+// ListSessions implements domain.AuthUseCase.
+func (a *authUsecase) ListSessions(ctx context.Context, userID, currentSessionID string) ([]domain.Session, error) {
+	c, cancel := context.WithTimeout(ctx, a.ctxTimeout)
+	defer cancel()
+
+	sessions, err := a.sessions.GetActiveSessions(c, userID)
+	if err != nil {
+		return nil, errs.ErrInternalServer
+	}
+
+	for i := range sessions {
+		sessions[i].Current = sessions[i].SessionID == currentSessionID
+	}
+
+	return sessions, nil
+}
+
+// RevokeSession implements domain.AuthUseCase.
+func (a *authUsecase) RevokeSession(ctx context.Context, userID, sessionID string) error {
+	c, cancel := context.WithTimeout(ctx, a.ctxTimeout)
+	defer cancel()
+
+	// The session is ended first, which also checks that it is the user's.
+	if err := a.sessions.RevokeSession(c, userID, sessionID); err != nil {
+		if errors.Is(err, errs.ErrSessionNotFound) {
+			return err
+		}
+		return errs.ErrInternalServer
+	}
+
+	if err := a.authRepo.RevokeTokenFamily(c, sessionID); err != nil {
+		logger.Error("failed to revoke token family", zap.String("user_id", userID), zap.String("session_id", sessionID), zap.Error(err))
+		return errs.ErrInternalServer
+	}
+
+	return nil
+}
+
+// RevokeAllSessions implements domain.AuthUseCase.
+func (a *authUsecase) RevokeAllSessions(ctx context.Context, userID, exceptSessionID string) (int, error) {
+	c, cancel := context.WithTimeout(ctx, a.ctxTimeout)
+	defer cancel()
+
+	revoked, err := a.revokeSessions(c, userID, exceptSessionID)
+	if err != nil {
+		logger.Error("failed to revoke sessions", zap.String("user_id", userID), zap.Error(err))
+		return 0, errs.ErrInternalServer
+	}
+
+	return revoked, nil
+}
+
+// SendEmailVerification implements domain.AuthUseCase.
+func (a *authUsecase) SendEmailVerification(ctx context.Context, userID string) error {
+	c, cancel := context.WithTimeout(ctx, a.ctxTimeout)
+	defer cancel()
+
+	user, err := a.userRepo.GetUserByID(c, userID)
+	if err != nil {
+		return errs.ErrInternalServer
+	}
+	if user == nil {
+		return errs.ErrUserNotFound
+	}
+	if user.EmailVerified {
+		return nil
+	}
+
+	if err := a.sendEmailVerification(c, user); err != nil {
+		logger.Error("failed to send email verification", zap.String("user_id", user.UserID), zap.Error(err))
+		return errs.ErrInternalServer
+	}
+
+	return nil
+}
+
+// VerifyEmail implements domain.AuthUseCase.
+func (a *authUsecase) VerifyEmail(ctx context.Context, token string) error {
+	c, cancel := context.WithTimeout(ctx, a.ctxTimeout)
+	defer cancel()
+
+	if token == "" {
+		return errs.ErrInvalidLink
+	}
+
+	issued, err := a.authRepo.ConsumeOneTimeToken(c, domain.TokenPurposeEmailVerification, token)
+	if err != nil {
+		if errors.Is(err, errs.ErrInvalidLink) {
+			return err
+		}
+		return errs.ErrInternalServer
+	}
+
+	if err := a.userRepo.MarkEmailVerified(c, issued.UserID, issued.Email); err != nil {
+		if errors.Is(err, errs.ErrInvalidLink) {
+			return err
+		}
+		return errs.ErrInternalServer
+	}
+
+	return nil
+}
+
+// RequestPasswordReset implements domain.AuthUseCase.
+func (a *authUsecase) RequestPasswordReset(ctx context.Context, email string) error {
+	c, cancel := context.WithTimeout(ctx, a.ctxTimeout)
+	defer cancel()
+
+	user, err := a.userRepo.GetUserByEmail(c, email)
+	if err != nil {
+		return errs.ErrInternalServer
+	}
+	if user == nil {
+		logger.Info("password reset requested for unknown email")
+		return nil
+	}
+
+	ttl, err := time.ParseDuration(a.env.PasswordResetTTL)
+	if err != nil {
+		return errs.ErrInternalServer
+	}
+
+	token, err := a.saveOneTimeToken(c, domain.TokenPurposePasswordReset, user, ttl)
+	if err != nil {
+		return errs.ErrInternalServer
+	}
+
+	link := fmt.Sprintf("%s/reset-password?token=%s", a.env.AppBaseURL, url.QueryEscape(token))
+	err = a.mailer.Send(c, domain.Email{
+		To:      user.Email,
+		Subject: "Reset your password",
+		Body: fmt.Sprintf("Hi %s,\n\nChoose a new password by opening the link below within %s:\n\n%s\n\n"+
+			"If you did not ask to reset your password, you can ignore this email.\n", user.Username, ttl, link),
+	})
+	if err != nil {
+		logger.Error("failed to send password reset email", zap.String("user_id", user.UserID), zap.Error(err))
+		return errs.ErrInternalServer
+	}
+
+	return nil
+}
+
+// ResetPassword implements domain.AuthUseCase.
+func (a *authUsecase) ResetPassword(ctx context.Context, token, newPassword string) error {
+	c, cancel := context.WithTimeout(ctx, a.ctxTimeout)
+	defer cancel()
+
+	if token == "" {
+		return errs.ErrInvalidLink
+	}
+	// Checked before the link is used up, so that the user can try again.
+	if err := a.passwords.Check("new_password", newPassword); err != nil {
+		return err
+	}
+
+	issued, err := a.authRepo.ConsumeOneTimeToken(c, domain.TokenPurposePasswordReset, token)
+	if err != nil {
+		if errors.Is(err, errs.ErrInvalidLink) {
+			return err
+		}
+		return errs.ErrInternalServer
+	}
+
+	user, err := a.userRepo.GetUserByID(c, issued.UserID)
+	if err != nil {
+		return errs.ErrInternalServer
+	}
+	if user == nil || user.Email != issued.Email {
+		return errs.ErrInvalidLink
+	}
+	if err := a.passwords.Check("new_password", newPassword, user.Email, user.Username); err != nil {
+		return err
+	}
+
+	passwordHash, err := internalutil.HashPassword(newPassword)
+	if err != nil {
+		return errs.ErrInternalServer
+	}
+	if err := a.userRepo.UpdatePassword(c, user.UserID, passwordHash); err != nil {
+		return errs.ErrInternalServer
+	}
+
+	// Anyone who got hold of the account is signed out.
+	if _, err := a.revokeSessions(c, user.UserID, ""); err != nil {
+		logger.Error("failed to revoke sessions after password reset", zap.String("user_id", user.UserID), zap.Error(err))
+	}
+
+	// Following the link proves the user reads the address.
+	if !user.EmailVerified {
+		if err := a.userRepo.MarkEmailVerified(c, user.UserID, user.Email); err != nil {
+			logger.Error("failed to verify email after password reset", zap.String("user_id", user.UserID), zap.Error(err))
+		}
+	}
+
+	return nil
+}
+
+func (a *authUsecase) sendEmailVerification(ctx context.Context, user *domain.User) error {
+	ttl, err := time.ParseDuration(a.env.EmailVerificationTTL)
+	if err != nil {
+		return fmt.Errorf("invalid email verification TTL: %w", err)
+	}
+
+	token, err := a.saveOneTimeToken(ctx, domain.TokenPurposeEmailVerification, user, ttl)
+	if err != nil {
+		return err
+	}
+
+	link := fmt.Sprintf("%s/verify-email?token=%s", a.env.AppBaseURL, url.QueryEscape(token))
+	return a.mailer.Send(ctx, domain.Email{
+		To:      user.Email,
+		Subject: "Verify your email address",
+		Body: fmt.Sprintf("Hi %s,\n\nConfirm your email address by opening the link below within %s:\n\n%s\n\n"+
+			"If you did not create an account, you can ignore this email.\n", user.Username, ttl, link),
+	})
+}
+
+// saveOneTimeToken issues a single-use token for the user's current email.
+func (a *authUsecase) saveOneTimeToken(ctx context.Context, purpose domain.OneTimeTokenPurpose, user *domain.User, ttl time.Duration) (string, error) {
+	token, err := internalutil.NewOneTimeToken()
+	if err != nil {
+		return "", err
+	}
+
+	issued := domain.OneTimeToken{UserID: user.UserID, Email: user.Email}
+	if err := a.authRepo.SaveOneTimeToken(ctx, purpose, token, issued, ttl); err != nil {
+		return "", err
+	}
+
+	return token, nil
+}
+
+// saveMFAChallenge issues the token of a login awaiting the user's code.
+func (a *authUsecase) saveMFAChallenge(ctx context.Context, user *domain.User) (string, error) {
+	ttl, err := time.ParseDuration(a.env.MFAChallengeTTL)
+	if err != nil {
+		return "", fmt.Errorf("invalid mfa challenge TTL: %w", err)
+	}
+
+	token, err := internalutil.NewOneTimeToken()
+	if err != nil {
+		return "", err
+	}
+
+	challenge := domain.OneTimeToken{UserID: user.UserID, Email: user.Email}
+	if err := a.authRepo.SaveMFAChallenge(ctx, token, challenge, ttl); err != nil {
+		return "", err
+	}
+
+	return token, nil
+}
+
+// verifyMFACode accepts a TOTP code or an unused recovery code of the user,
+// and fails with errs.ErrInvalidMFACode otherwise.
+func (a *authUsecase) verifyMFACode(ctx context.Context, mfa *domain.MFA, code string) error {
+	err := a.verifyTOTPCode(ctx, mfa, code)
+	if !errors.Is(err, errs.ErrInvalidMFACode) {
+		return err
+	}
+
+	normalized := internalutil.NormalizeRecoveryCode(code)
+	if normalized == "" {
+		return errs.ErrInvalidMFACode
+	}
+
+	err = a.mfa.UseRecoveryCode(ctx, mfa.UserID, internalutil.HashToken(normalized))
+	if err != nil {
+		if errors.Is(err, errs.ErrInvalidMFACode) {
+			return err
+		}
+		return errs.ErrInternalServer
+	}
+
+	logger.Info("recovery code used", zap.String("user_id", mfa.UserID))
+	return nil
+}
+
+// verifyTOTPCode accepts a TOTP code of the user's secret that was not used
+// before.
+func (a *authUsecase) verifyTOTPCode(ctx context.Context, mfa *domain.MFA, code string) error {
+	if a.secrets == nil {
+		return errs.ErrMFAUnavailable
+	}
+
+	secret, err := a.secrets.Open(mfa.Secret)
+	if err != nil {
+		logger.Error("failed to decrypt totp secret", zap.String("user_id", mfa.UserID), zap.Error(err))
+		return errs.ErrInternalServer
+	}
+
+	step, ok := internalutil.ValidateTOTP(secret, strings.TrimSpace(code), time.Now())
+	if !ok {
+		return errs.ErrInvalidMFACode
+	}
+
+	// A code seen by someone else must not work a second time.
+	first, err := a.authRepo.MarkTOTPStepUsed(ctx, mfa.UserID, step, totpReplayWindow)
+	if err != nil {
+		return errs.ErrInternalServer
+	}
+	if !first {
+		return errs.ErrInvalidMFACode
+	}
+
+	return nil
+}
+
+// loginAttemptKeys returns the keys failed logins are counted under: the
+// account first, then the client IP when it is known.
+// reauthenticate checks that the signed-in user is still at the keyboard. A
+// user with a password enters it again. Accounts made by logging in with an
+// identity provider have none, so their user signs in with a provider linked
+// to the account again and sends the ID token it issued.
+func reauthenticate(ctx context.Context, userRepo domain.UserRepository, identities domain.IdentityRepository, providers map[string]domain.IdentityProvider, user *domain.User, proof domain.Reauthentication) error {
+	passwordHash, err := userRepo.GetUserPasswordHashByEmail(ctx, user.Email)
+	if err != nil {
+		return errs.ErrInternalServer
+	}
+	if passwordHash != "" {
+		if !internalutil.CheckPassword(passwordHash, proof.Password) {
+			return errs.ErrInvalidCredentials
+		}
+		return nil
+	}
+
+	if proof.IDToken == "" {
+		return errs.ErrReauthenticationNeeded
+	}
+	provider, ok := providers[proof.Provider]
+	if !ok {
+		return errs.ErrUnknownIdentityProvider
+	}
+	identity, err := provider.Verify(ctx, proof.IDToken)
+	if err != nil {
+		logger.Warn("identity provider token rejected", zap.String("provider", proof.Provider), zap.Error(err))
+		return errs.ErrInvalidIdentityToken
+	}
+	// A token from an earlier login does not show the user is still there.
+	if time.Since(identity.IssuedAt) > reauthenticationMaxAge {
+		return errs.ErrReauthenticationNeeded
+	}
+
+	linkedUserID, err := identities.GetUserIDByIdentity(ctx, identity.Provider, identity.Subject)
+	if err != nil {
+		return errs.ErrInternalServer
+	}
+	if linkedUserID != user.UserID {
+		return errs.ErrInvalidIdentityToken
+	}
+
+	return nil
+}
+
+func loginAttemptKeys(input domain.LoginWithEmail) []string {
+	keys := []string{"account:" + strings.ToLower(strings.TrimSpace(input.Email))}
+	if input.Client.IP != "" {
+		keys = append(keys, "ip:"+input.Client.IP)
+	}
+	return keys
+}
+
+// checkLoginBlocked fails with an [errs.ThrottledError] while any of the keys
+// is blocked. Logins are let through when the blocks cannot be read, so that
+// an unavailable cache does not lock everybody out.
+func (a *authUsecase) checkLoginBlocked(ctx context.Context, keys []string) error {
+	var until time.Time
+	for _, key := range keys {
+		blockedUntil, err := a.attempts.BlockedUntil(ctx, key)
+		if err != nil {
+			logger.Error("failed to read login block", zap.String("key", key), zap.Error(err))
+			continue
+		}
+		if blockedUntil.After(until) {
+			until = blockedUntil
+		}
+	}
+
+	if retryAfter := time.Until(until); retryAfter > 0 {
+		return &errs.ThrottledError{RetryAfter: retryAfter}
+	}
+	return nil
+}
+
+// checkNotSuspended fails with errs.ErrAccountSuspended while the user is
+// suspended.
+func (a *authUsecase) checkNotSuspended(ctx context.Context, userID string) error {
+	suspension, err := a.userRepo.GetSuspension(ctx, userID)
+	if err != nil {
+		logger.Error("failed to read suspension", zap.String("user_id", userID), zap.Error(err))
+		return errs.ErrInternalServer
+	}
+	if suspension != nil {
+		logger.Warn("suspended user refused", zap.String("user_id", userID))
+		return errs.ErrAccountSuspended
+	}
+	return nil
+}
+
+// recordLoginFailure counts a failed login, with a wrong password or a wrong
+// second factor. Past loginFreeFailures, each failure of the account delays
+// its next attempt twice as long as the one before, and reaching the maximum
+// locks the account or the client.
+func (a *authUsecase) recordLoginFailure(ctx context.Context, keys []string) {
+	window, err := time.ParseDuration(a.env.LoginFailureWindow)
+	if err != nil {
+		logger.Error("invalid login failure window", zap.Error(err))
+		return
+	}
+	lockout, err := time.ParseDuration(a.env.LoginLockout)
+	if err != nil {
+		logger.Error("invalid login lockout", zap.Error(err))
+		return
+	}
+
+	for i, key := range keys {
+		failures, err := a.attempts.RecordFailure(ctx, key, window)
+		if err != nil {
+			logger.Error("failed to record login failure", zap.String("key", key), zap.Error(err))
+			continue
+		}
+
+		maxFailures := int64(a.env.LoginMaxFailures)
+		if i > 0 {
+			maxFailures = int64(a.env.LoginMaxFailuresPerIP)
+		}
+
+		var blockFor time.Duration
+		switch {
+		case failures >= maxFailures:
+			blockFor = lockout
+			logger.Warn("login locked after repeated failures", zap.String("key", key), zap.Int64("failures", failures))
+		case i == 0 && failures > loginFreeFailures:
+			blockFor = lockout
+			if doublings := failures - loginFreeFailures - 1; doublings < 20 {
+				blockFor = min(loginDelayBase<<doublings, lockout)
+			}
+		default:
+			continue
+		}
+
+		if err := a.attempts.Block(ctx, key, time.Now().Add(blockFor)); err != nil {
+			logger.Error("failed to block login", zap.String("key", key), zap.Error(err))
+		}
+	}
+}
+
+// resetLoginFailures clears the failures of the account once the user has
+// logged in. The client's are kept, or logging into one's own account would
+// reset them.
+func (a *authUsecase) resetLoginFailures(ctx context.Context, userID string, keys []string) {
+	if err := a.attempts.ResetFailures(ctx, keys[0]); err != nil {
+		logger.Error("failed to reset login failures", zap.String("user_id", userID), zap.Error(err))
+	}
+}
+
+// issueTokens signs a token pair for the user with their current roles and
+// starts a new session, with its own refresh token family, on the client.
+func (a *authUsecase) issueTokens(ctx context.Context, user *domain.User, client domain.ClientInfo) (*domain.AuthTokens, error) {
+	subject, err := a.tokenSubject(ctx, user)
+	if err != nil {
+		return nil, err
+	}
+
+	familyID := internalutil.NewTokenFamilyID()
+	authToken, refreshTokenID, err := internalutil.SignUser(subject, familyID, a.accessKeys, &a.env, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := a.authRepo.SaveRefreshToken(ctx, user.Email, familyID, refreshTokenID); err != nil {
+		return nil, err
+	}
+
+	expiresAt, err := a.sessionExpiry()
+	if err != nil {
+		return nil, err
+	}
+
+	err = a.sessions.CreateSession(ctx, domain.Session{
+		SessionID: familyID,
+		UserID:    user.UserID,
+		DeviceID:  client.DeviceID,
+		UserAgent: client.UserAgent,
+		IPAddress: client.IP,
+		ExpiresAt: expiresAt,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return authToken, nil
+}
+
+// touchSession extends the session after a refresh. Sessions started before
+// sessions were recorded are recorded now, with the client of the refresh.
+func (a *authUsecase) touchSession(ctx context.Context, userID, sessionID string, client domain.ClientInfo) {
+	expiresAt, err := a.sessionExpiry()
+	if err != nil {
+		logger.Error("failed to extend session", zap.String("session_id", sessionID), zap.Error(err))
+		return
+	}
+
+	err = a.sessions.TouchSession(ctx, sessionID, client.IP, expiresAt)
+	if errors.Is(err, errs.ErrSessionNotFound) {
+		err = a.sessions.CreateSession(ctx, domain.Session{
+			SessionID: sessionID,
+			UserID:    userID,
+			DeviceID:  client.DeviceID,
+			UserAgent: client.UserAgent,
+			IPAddress: client.IP,
+			ExpiresAt: expiresAt,
+		})
+	}
+	if err != nil {
+		logger.Error("failed to extend session", zap.String("session_id", sessionID), zap.Error(err))
+	}
+}
+
+// endSession marks a session whose token family was revoked as ended.
+func (a *authUsecase) endSession(ctx context.Context, userID, sessionID string) {
+	err := a.sessions.RevokeSession(ctx, userID, sessionID)
+	if err != nil && !errors.Is(err, errs.ErrSessionNotFound) {
+		logger.Error("failed to end session", zap.String("session_id", sessionID), zap.Error(err))
+	}
+}
+
+// revokeSessions ends every session of the user but exceptID and revokes
+// their token families. It returns how many sessions were ended.
+func (a *authUsecase) revokeSessions(ctx context.Context, userID, exceptID string) (int, error) {
+	revoked, err := a.sessions.RevokeAllSessions(ctx, userID, exceptID)
+	if err != nil {
+		return 0, err
+	}
+
+	for _, sessionID := range revoked {
+		if err := a.authRepo.RevokeTokenFamily(ctx, sessionID); err != nil {
+			return 0, err
+		}
+	}
+
+	return len(revoked), nil
+}
+
+// sessionExpiry returns when a session refreshed now expires, with its
+// refresh token.
+func (a *authUsecase) sessionExpiry() (time.Time, error) {
+	ttl, err := time.ParseDuration(a.env.RefreshTokenTTL)
+	if err != nil {
+		return time.Time{}, fmt.Errorf("invalid refresh token TTL: %w", err)
+	}
+	return time.Now().Add(ttl), nil
+}
+
+func (a *authUsecase) tokenSubject(ctx context.Context, user *domain.User) (internalutil.TokenSubject, error) {
+	roles, err := a.userRepo.GetUserRoles(ctx, user.UserID)
+	if err != nil {
+		return internalutil.TokenSubject{}, err
+	}
+
+	return internalutil.TokenSubject{
+		UserID:        user.UserID,
+		Email:         user.Email,
+		EmailVerified: user.EmailVerified,
+		Roles:         roles,
+	}, nil
+}
+
 // NewAuthUsecase constructor
-func NewAuthUsecase(ctxTimeout time.Duration, authRepo domain.AuthRepository, userRepo domain.UserRepository, env authservice.Env) domain.AuthUseCase {
+func NewAuthUsecase(ctxTimeout time.Duration, authRepo domain.AuthRepository, userRepo domain.UserRepository, sessions domain.SessionRepository, attempts domain.LoginAttemptRepository, mfa domain.MFARepository, identities domain.IdentityRepository, providers map[string]domain.IdentityProvider, accessKeys *internalutil.AccessKeySet, secrets *internalutil.SecretBox, passwords *internalutil.PasswordPolicy, mailer domain.Mailer, env authservice.Env) domain.AuthUseCase {
 	return &authUsecase{
 		ctxTimeout: ctxTimeout,
 		authRepo:   authRepo,
 		userRepo:   userRepo,
+		sessions:   sessions,
+		attempts:   attempts,
+		mfa:        mfa,
+		identities: identities,
+		providers:  providers,
+		accessKeys: accessKeys,
+		secrets:    secrets,
+		passwords:  passwords,
+		mailer:     mailer,
 		env:        env,
 	}
 }
@@ -0,0 +1,302 @@
+package usecase
+
+import (
+	"context"
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/x509"
+	"encoding/pem"
+	"errors"
+	"os"
+	"testing"
+	"time"
+
+	authservice "github.com/tamirat-dejene/ha-soranu/services/auth-service"
+	"github.com/tamirat-dejene/ha-soranu/services/auth-service/internal/domain"
+	errs "github.com/tamirat-dejene/ha-soranu/services/auth-service/internal/domain/err"
+	"github.com/tamirat-dejene/ha-soranu/services/auth-service/internal/identity"
+	internalutil "github.com/tamirat-dejene/ha-soranu/services/auth-service/internal/util"
+	"github.com/tamirat-dejene/ha-soranu/shared/pkg/logger"
+	"go.uber.org/zap"
+)
+
+func TestMain(m *testing.M) {
+	logger.Log = zap.NewNop()
+	os.Exit(m.Run())
+}
+
+// memoryUsers holds users by email. Only what a provider login needs is
+// implemented.
+type memoryUsers struct {
+	domain.UserRepository
+	users map[string]*domain.User
+}
+
+func (r *memoryUsers) GetUserByEmail(_ context.Context, email string) (*domain.User, error) {
+	return r.users[email], nil
+}
+
+func (r *memoryUsers) GetUserByID(_ context.Context, userID string) (*domain.User, error) {
+	for _, user := range r.users {
+		if user.UserID == userID {
+			return user, nil
+		}
+	}
+	return nil, nil
+}
+
+func (r *memoryUsers) CreateUser(_ context.Context, register *domain.UserRegister) (*domain.User, error) {
+	user := &domain.User{UserID: "new-user", Email: register.Email, Username: register.Username}
+	r.users[user.Email] = user
+	return user, nil
+}
+
+func (r *memoryUsers) MarkEmailVerified(context.Context, string, string) error {
+	return nil
+}
+
+func (r *memoryUsers) GetSuspension(context.Context, string) (*domain.Suspension, error) {
+	return nil, nil
+}
+
+func (r *memoryUsers) GetUserRoles(context.Context, string) ([]string, error) {
+	return []string{"customer"}, nil
+}
+
+// memoryIdentities holds the user ID of each linked provider subject.
+type memoryIdentities struct {
+	domain.IdentityRepository
+	links map[string]string
+}
+
+func (r *memoryIdentities) GetUserIDByIdentity(_ context.Context, _, subject string) (string, error) {
+	return r.links[subject], nil
+}
+
+func (r *memoryIdentities) LinkIdentity(_ context.Context, userID string, identity domain.ExternalIdentity) error {
+	r.links[identity.Subject] = userID
+	return nil
+}
+
+// memoryMFA holds the users with two-factor authentication on.
+type memoryMFA struct {
+	domain.MFARepository
+	enabled map[string]bool
+}
+
+func (r *memoryMFA) GetMFA(_ context.Context, userID string) (*domain.MFA, error) {
+	if !r.enabled[userID] {
+		return nil, nil
+	}
+	return &domain.MFA{UserID: userID, Enabled: true}, nil
+}
+
+// memoryTokens records the challenges and token families saved.
+type memoryTokens struct {
+	domain.AuthRepository
+	challenges map[string]domain.OneTimeToken
+	families   int
+}
+
+func (r *memoryTokens) SaveRefreshToken(context.Context, string, string, string) error {
+	r.families++
+	return nil
+}
+
+func (r *memoryTokens) SaveMFAChallenge(_ context.Context, tokenID string, challenge domain.OneTimeToken, _ time.Duration) error {
+	r.challenges[tokenID] = challenge
+	return nil
+}
+
+type memorySessions struct {
+	domain.SessionRepository
+}
+
+func (memorySessions) CreateSession(context.Context, domain.Session) error {
+	return nil
+}
+
+// testRSAKey returns a new RSA private key in PEM.
+func testRSAKey(t *testing.T) string {
+	t.Helper()
+
+	key, err := rsa.GenerateKey(rand.Reader, 2048)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return string(pem.EncodeToMemory(&pem.Block{Type: "RSA PRIVATE KEY", Bytes: x509.MarshalPKCS1PrivateKey(key)}))
+}
+
+func TestLoginWithIdentityProvider(t *testing.T) {
+	provider, err := identity.NewMockProvider("a mock identity provider secret of 32+ bytes")
+	if err != nil {
+		t.Fatal(err)
+	}
+	accessKeys, err := internalutil.NewAccessKeySet(testRSAKey(t), "")
+	if err != nil {
+		t.Fatal(err)
+	}
+	env := authservice.Env{
+		AUTH_SRV_NAME:          "auth-service",
+		AccessTokenTTL:         "15m",
+		RefreshTokenTTL:        "1h",
+		RefreshTokenPrivateKey: testRSAKey(t),
+		MFAChallengeTTL:        "5m",
+	}
+
+	existing := &domain.User{UserID: "user-1", Email: "abebe@example.com", Username: "abebe"}
+
+	tests := []struct {
+		name string
+		// linked is the user the provider subject is already linked to.
+		linked     string
+		mfaEnabled bool
+		identity   domain.ExternalIdentity
+		wantErr    error
+		wantUser   string
+		wantMFA    bool
+	}{
+		{
+			name:     "first login creates a user",
+			identity: domain.ExternalIdentity{Subject: "sub-new", Email: "kebede@example.com", EmailVerified: true, Name: "Kebede"},
+			wantUser: "new-user",
+		},
+		{
+			name:     "verified email links the existing user",
+			identity: domain.ExternalIdentity{Subject: "sub-1", Email: existing.Email, EmailVerified: true},
+			wantUser: existing.UserID,
+		},
+		{
+			name:     "unverified email of an existing user is refused",
+			identity: domain.ExternalIdentity{Subject: "sub-1", Email: existing.Email},
+			wantErr:  errs.ErrIdentityEmailUnverified,
+		},
+		{
+			name:     "linked account logs in",
+			linked:   existing.UserID,
+			identity: domain.ExternalIdentity{Subject: "sub-1", Email: "other@example.com"},
+			wantUser: existing.UserID,
+		},
+		{
+			name:       "linked account with two-factor authentication gets a challenge",
+			linked:     existing.UserID,
+			mfaEnabled: true,
+			identity:   domain.ExternalIdentity{Subject: "sub-1", Email: existing.Email, EmailVerified: true},
+			wantMFA:    true,
+		},
+		{
+			name:       "linking an account with two-factor authentication gets a challenge",
+			mfaEnabled: true,
+			identity:   domain.ExternalIdentity{Subject: "sub-1", Email: existing.Email, EmailVerified: true},
+			wantMFA:    true,
+		},
+		{
+			name:     "token without an email is refused",
+			identity: domain.ExternalIdentity{Subject: "sub-new"},
+			wantErr:  errs.ErrInvalidIdentityToken,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			user := *existing
+			users := &memoryUsers{users: map[string]*domain.User{user.Email: &user}}
+			identities := &memoryIdentities{links: make(map[string]string)}
+			if tt.linked != "" {
+				identities.links[tt.identity.Subject] = tt.linked
+			}
+			tokens := &memoryTokens{challenges: make(map[string]domain.OneTimeToken)}
+			mfa := &memoryMFA{enabled: map[string]bool{existing.UserID: tt.mfaEnabled}}
+
+			u := NewAuthUsecase(time.Second, tokens, users, memorySessions{}, nil, mfa, identities,
+				map[string]domain.IdentityProvider{provider.Name(): provider}, accessKeys, nil, nil, nil, env)
+
+			idToken, err := provider.Issue(tt.identity, time.Minute)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			result, err := u.LoginWithIdentityProvider(context.Background(), domain.LoginWithIdentityProvider{
+				Provider: identity.MockProviderName,
+				IDToken:  idToken,
+			})
+			if tt.wantErr != nil {
+				if !errors.Is(err, tt.wantErr) {
+					t.Fatalf("LoginWithIdentityProvider() error = %v, want %v", err, tt.wantErr)
+				}
+				if len(identities.links) > 0 && tt.linked == "" {
+					t.Errorf("refused login linked the provider account: %v", identities.links)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("LoginWithIdentityProvider() error = %v", err)
+			}
+
+			if tt.wantMFA {
+				if result.MFAToken == "" || result.Tokens != nil || result.User != nil {
+					t.Fatalf("LoginWithIdentityProvider() = %+v, want only a challenge", result)
+				}
+				if challenge := tokens.challenges[result.MFAToken]; challenge.UserID != existing.UserID {
+					t.Errorf("challenge is for %q, want %q", challenge.UserID, existing.UserID)
+				}
+				if tokens.families != 0 {
+					t.Errorf("%d token families issued before the second factor", tokens.families)
+				}
+				return
+			}
+
+			if result.MFAToken != "" || result.Tokens == nil || result.Tokens.AccessToken == "" {
+				t.Fatalf("LoginWithIdentityProvider() = %+v, want tokens", result)
+			}
+			if result.User.UserID != tt.wantUser {
+				t.Errorf("logged in as %q, want %q", result.User.UserID, tt.wantUser)
+			}
+			if got := identities.links[tt.identity.Subject]; got != tt.wantUser {
+				t.Errorf("provider account linked to %q, want %q", got, tt.wantUser)
+			}
+		})
+	}
+}
+
+func TestLoginWithIdentityProviderRejectsTokens(t *testing.T) {
+	provider, err := identity.NewMockProvider("a mock identity provider secret of 32+ bytes")
+	if err != nil {
+		t.Fatal(err)
+	}
+	other, err := identity.NewMockProvider("another mock identity provider secret")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	u := NewAuthUsecase(time.Second, nil, nil, nil, nil, nil, nil,
+		map[string]domain.IdentityProvider{provider.Name(): provider}, nil, nil, nil, nil, authservice.Env{})
+
+	subject := domain.ExternalIdentity{Subject: "sub-1", Email: "abebe@example.com", EmailVerified: true}
+	expired, _ := provider.Issue(subject, -time.Minute)
+	forged, _ := other.Issue(subject, time.Minute)
+	valid, _ := provider.Issue(subject, time.Minute)
+
+	tests := []struct {
+		name     string
+		provider string
+		idToken  string
+		wantErr  error
+	}{
+		{name: "expired", provider: identity.MockProviderName, idToken: expired, wantErr: errs.ErrInvalidIdentityToken},
+		{name: "signed by someone else", provider: identity.MockProviderName, idToken: forged, wantErr: errs.ErrInvalidIdentityToken},
+		{name: "unknown provider", provider: "facebook", idToken: valid, wantErr: errs.ErrUnknownIdentityProvider},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := u.LoginWithIdentityProvider(context.Background(), domain.LoginWithIdentityProvider{
+				Provider: tt.provider,
+				IDToken:  tt.idToken,
+			})
+			if !errors.Is(err, tt.wantErr) {
+				t.Errorf("LoginWithIdentityProvider() error = %v, want %v", err, tt.wantErr)
+			}
+		})
+	}
+}
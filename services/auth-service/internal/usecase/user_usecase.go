@@ -2,14 +2,32 @@ package usecase
 
 import (
 	"context"
+	"fmt"
+	"math"
+	"strings"
 	"time"
+	"unicode/utf8"
 
 	"github.com/tamirat-dejene/ha-soranu/services/auth-service/internal/domain"
+	errs "github.com/tamirat-dejene/ha-soranu/services/auth-service/internal/domain/err"
+	"github.com/tamirat-dejene/ha-soranu/shared/pkg/auth/svcauth"
+)
+
+const (
+	// maxUsernameLength is the size of the username column.
+	maxUsernameLength = 50
+	// maxAddressLabelLength is the size of the address label column.
+	maxAddressLabelLength = 50
+	// maxDriverSearchRadiusKm bounds the radius drivers are searched within.
+	maxDriverSearchRadiusKm = 50
 )
 
 type userUsecase struct {
-	userRepository domain.UserRepository
-	ctxTimeout     time.Duration
+	userRepository  domain.UserRepository
+	driverLocations domain.DriverLocationRepository
+	ctxTimeout      time.Duration
+	maxAddresses    int
+	locationTTL     time.Duration
 }
 
 // GetDrivers implements [domain.UserUseCase].
@@ -17,7 +35,37 @@ func (u *userUsecase) GetDrivers(ctx context.Context, latitude float32, longitud
 	c, cancel := context.WithTimeout(ctx, u.ctxTimeout)
 	defer cancel()
 
-	return u.userRepository.GetDrivers(c, latitude, longitude, radius)
+	// Where drivers are is only shown to admins and to services on their own
+	// behalf, such as the restaurant service dispatching an order on behalf
+	// of its owner, never to end users through the gateway.
+	if !svcauth.IsAdmin(ctx) {
+		if caller, ok := svcauth.CallerFromContext(ctx); ok && caller.Service == svcauth.GatewayService {
+			return nil, errs.ErrUnauthorized
+		}
+	}
+
+	if err := validateLocation(latitude, longitude); err != nil {
+		return nil, err
+	}
+	if !(radius > 0 && radius <= maxDriverSearchRadiusKm) {
+		return nil, fmt.Errorf("%w: radius_km must be greater than 0 and at most %d", errs.ErrInvalidLocation, maxDriverSearchRadiusKm)
+	}
+
+	locations, err := u.driverLocations.Nearby(c, float64(latitude), float64(longitude), float64(radius))
+	if err != nil {
+		return nil, err
+	}
+
+	drivers := make([]domain.Driver, len(locations))
+	for i, l := range locations {
+		drivers[i] = domain.Driver{
+			DriverID: l.DriverID,
+			Online:   true,
+			Location: l,
+		}
+	}
+
+	return drivers, nil
 }
 
 // RemoveDriver implements [domain.UserUseCase].
@@ -25,15 +73,175 @@ func (u *userUsecase) RemoveDriver(ctx context.Context, driverID string) error {
 	c, cancel := context.WithTimeout(ctx, u.ctxTimeout)
 	defer cancel()
 
-	return u.userRepository.RemoveDriver(c, driverID)
+	userID, err := u.userRepository.GetDriverUserID(c, driverID)
+	if err != nil {
+		return err
+	}
+	if !svcauth.CanActFor(ctx, userID) {
+		return errs.ErrUnauthorized
+	}
+
+	if err := u.userRepository.RemoveDriver(c, driverID); err != nil {
+		return err
+	}
+
+	return u.driverLocations.RemoveLocation(c, driverID)
+}
+
+// GetDriverProfile implements [domain.UserUseCase].
+func (u *userUsecase) GetDriverProfile(ctx context.Context, userID string) (*domain.Driver, error) {
+	c, cancel := context.WithTimeout(ctx, u.ctxTimeout)
+	defer cancel()
+
+	if !svcauth.CanActFor(ctx, userID) {
+		return nil, errs.ErrUnauthorized
+	}
+
+	return u.getDriver(c, userID)
+}
+
+// UpdateVehicle implements [domain.UserUseCase].
+func (u *userUsecase) UpdateVehicle(ctx context.Context, userID string, vehicle domain.Vehicle) (*domain.Driver, error) {
+	c, cancel := context.WithTimeout(ctx, u.ctxTimeout)
+	defer cancel()
+
+	if !svcauth.CanActFor(ctx, userID) {
+		return nil, errs.ErrUnauthorized
+	}
+
+	vehicle = normalizeVehicle(vehicle)
+	if err := validateVehicle(vehicle); err != nil {
+		return nil, err
+	}
+
+	driver, err := u.getDriver(c, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := u.userRepository.UpdateVehicle(c, driver.DriverID, vehicle); err != nil {
+		return nil, err
+	}
+
+	driver.Vehicle = vehicle
+	return driver, nil
+}
+
+// GoOnline implements [domain.UserUseCase].
+func (u *userUsecase) GoOnline(ctx context.Context, userID string, latitude, longitude float32) error {
+	c, cancel := context.WithTimeout(ctx, u.ctxTimeout)
+	defer cancel()
+
+	if !svcauth.CanActFor(ctx, userID) {
+		return errs.ErrUnauthorized
+	}
+
+	if err := validateLocation(latitude, longitude); err != nil {
+		return err
+	}
+
+	driver, err := u.getDriver(c, userID)
+	if err != nil {
+		return err
+	}
+	if driver.Vehicle.Type == "" {
+		return errs.ErrVehicleRequired
+	}
+
+	if err := u.userRepository.SetDriverOnline(c, driver.DriverID, true); err != nil {
+		return err
+	}
+
+	return u.driverLocations.SetLocation(c, driver.DriverID, float64(latitude), float64(longitude), u.locationTTL)
+}
+
+// GoOffline implements [domain.UserUseCase].
+func (u *userUsecase) GoOffline(ctx context.Context, userID string) error {
+	c, cancel := context.WithTimeout(ctx, u.ctxTimeout)
+	defer cancel()
+
+	if !svcauth.CanActFor(ctx, userID) {
+		return errs.ErrUnauthorized
+	}
+
+	driver, err := u.getDriver(c, userID)
+	if err != nil {
+		return err
+	}
+
+	if err := u.userRepository.SetDriverOnline(c, driver.DriverID, false); err != nil {
+		return err
+	}
+
+	return u.driverLocations.RemoveLocation(c, driver.DriverID)
+}
+
+// UpdateDriverLocation implements [domain.UserUseCase].
+func (u *userUsecase) UpdateDriverLocation(ctx context.Context, userID string, latitude, longitude float32) error {
+	c, cancel := context.WithTimeout(ctx, u.ctxTimeout)
+	defer cancel()
+
+	if !svcauth.CanActFor(ctx, userID) {
+		return errs.ErrUnauthorized
+	}
+
+	if err := validateLocation(latitude, longitude); err != nil {
+		return err
+	}
+
+	driver, err := u.getDriver(c, userID)
+	if err != nil {
+		return err
+	}
+	if !driver.Online {
+		return errs.ErrDriverOffline
+	}
+
+	return u.driverLocations.SetLocation(c, driver.DriverID, float64(latitude), float64(longitude), u.locationTTL)
+}
+
+// getDriver returns the driver profile of the user, failing with
+// errs.ErrDriverNotFound when they are not a driver.
+func (u *userUsecase) getDriver(ctx context.Context, userID string) (*domain.Driver, error) {
+	driver, err := u.userRepository.GetDriverByUserID(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+	if driver == nil {
+		return nil, errs.ErrDriverNotFound
+	}
+	return driver, nil
 }
 
 // BeDriver implements [domain.UserUseCase].
-func (u *userUsecase) BeDriver(ctx context.Context, userID string) (string, error) {
+func (u *userUsecase) BeDriver(ctx context.Context, userID string, vehicle domain.Vehicle) (string, error) {
 	c, cancel := context.WithTimeout(ctx, u.ctxTimeout)
 	defer cancel()
 
-	return u.userRepository.BeDriver(c, userID)
+	if !svcauth.CanActFor(ctx, userID) {
+		return "", errs.ErrUnauthorized
+	}
+
+	// The vehicle is optional when becoming a driver.
+	if vehicle != (domain.Vehicle{}) {
+		vehicle = normalizeVehicle(vehicle)
+		if err := validateVehicle(vehicle); err != nil {
+			return "", err
+		}
+	}
+
+	user, err := u.userRepository.GetUserByID(c, userID)
+	if err != nil {
+		return "", err
+	}
+	if user == nil {
+		return "", errs.ErrUserNotFound
+	}
+	if !user.EmailVerified {
+		return "", errs.ErrEmailNotVerified
+	}
+
+	return u.userRepository.BeDriver(c, userID, vehicle)
 }
 
 // AddAddress implements domain.UserUseCase.
@@ -41,18 +249,64 @@ func (u *userUsecase) AddAddress(ctx context.Context, userID string, address dom
 	c, cancel := context.WithTimeout(ctx, u.ctxTimeout)
 	defer cancel()
 
-	addr, err := u.userRepository.AddAddress(c, userID, address)
+	if !svcauth.CanActFor(ctx, userID) {
+		return nil, errs.ErrUnauthorized
+	}
+
+	address = normalizeAddress(address)
+	if err := validateAddress(address); err != nil {
+		return nil, err
+	}
+
+	addr, err := u.userRepository.AddAddress(c, userID, address, u.maxAddresses)
 	if err != nil {
 		return nil, err
 	}
 	return &addr, nil
 }
 
+// UpdateAddress implements domain.UserUseCase.
+func (u *userUsecase) UpdateAddress(ctx context.Context, userID string, address domain.Address) (*domain.Address, error) {
+	c, cancel := context.WithTimeout(ctx, u.ctxTimeout)
+	defer cancel()
+
+	if !svcauth.CanActFor(ctx, userID) {
+		return nil, errs.ErrUnauthorized
+	}
+
+	address = normalizeAddress(address)
+	if err := validateAddress(address); err != nil {
+		return nil, err
+	}
+
+	addr, err := u.userRepository.UpdateAddress(c, userID, address)
+	if err != nil {
+		return nil, err
+	}
+	return &addr, nil
+}
+
+// SetDefaultAddress implements domain.UserUseCase.
+func (u *userUsecase) SetDefaultAddress(ctx context.Context, userID string, addressID string) error {
+	c, cancel := context.WithTimeout(ctx, u.ctxTimeout)
+	defer cancel()
+
+	if !svcauth.CanActFor(ctx, userID) {
+		return errs.ErrUnauthorized
+	}
+
+	return u.userRepository.SetDefaultAddress(c, userID, addressID)
+}
+
 // AddPhoneNumber implements domain.UserUseCase.
 func (u *userUsecase) AddPhoneNumber(ctx context.Context, userID string, phone string) error {
 	c, cancel := context.WithTimeout(ctx, u.ctxTimeout)
 	defer cancel()
 
+	if !svcauth.CanActFor(ctx, userID) {
+		return errs.ErrUnauthorized
+	}
+
 	return u.userRepository.AddPhoneNumber(c, userID, phone)
 }
 
@@ -61,6 +315,10 @@ func (u *userUsecase) GetAddresses(ctx context.Context, userID string) ([]domain
 	c, cancel := context.WithTimeout(ctx, u.ctxTimeout)
 	defer cancel()
 
+	if !svcauth.CanActFor(ctx, userID) {
+		return nil, errs.ErrUnauthorized
+	}
+
 	return u.userRepository.GetAddresses(c, userID)
 }
 
@@ -69,6 +327,31 @@ func (u *userUsecase) GetUser(ctx context.Context, userID string) (*domain.User,
 	c, cancel := context.WithTimeout(ctx, u.ctxTimeout)
 	defer cancel()
 
+	if !svcauth.CanActFor(ctx, userID) {
+		return nil, errs.ErrUnauthorized
+	}
+
+	return u.userRepository.GetUserByID(c, userID)
+}
+
+// UpdateProfile implements domain.UserUseCase.
+func (u *userUsecase) UpdateProfile(ctx context.Context, userID string, username string) (*domain.User, error) {
+	c, cancel := context.WithTimeout(ctx, u.ctxTimeout)
+	defer cancel()
+
+	if !svcauth.CanActFor(ctx, userID) {
+		return nil, errs.ErrUnauthorized
+	}
+
+	username = strings.TrimSpace(username)
+	if username == "" || utf8.RuneCountInString(username) > maxUsernameLength {
+		return nil, errs.ErrInvalidRequest
+	}
+
+	if err := u.userRepository.UpdateUsername(c, userID, username); err != nil {
+		return nil, err
+	}
+
 	return u.userRepository.GetUserByID(c, userID)
 }
 
@@ -77,6 +360,10 @@ func (u *userUsecase) RemoveAddress(ctx context.Context, userID string, addressI
 	c, cancel := context.WithTimeout(ctx, u.ctxTimeout)
 	defer cancel()
 
+	if !svcauth.CanActFor(ctx, userID) {
+		return errs.ErrUnauthorized
+	}
+
 	return u.userRepository.RemoveAddress(c, userID, addressID)
 }
 
@@ -85,6 +372,10 @@ func (u *userUsecase) RemovePhoneNumber(ctx context.Context, userID string) erro
 	c, cancel := context.WithTimeout(ctx, u.ctxTimeout)
 	defer cancel()
 
+	if !svcauth.CanActFor(ctx, userID) {
+		return errs.ErrUnauthorized
+	}
+
 	return u.userRepository.RemovePhoneNumber(c, userID)
 }
 
@@ -93,12 +384,135 @@ func (u *userUsecase) UpdatePhoneNumber(ctx context.Context, userID string, phon
 	c, cancel := context.WithTimeout(ctx, u.ctxTimeout)
 	defer cancel()
 
+	if !svcauth.CanActFor(ctx, userID) {
+		return errs.ErrUnauthorized
+	}
+
 	return u.userRepository.UpdatePhoneNumber(c, userID, phone)
 }
 
-func NewUserUsecase(userRepo domain.UserRepository, timeout time.Duration) domain.UserUseCase {
+// normalizeAddress trims the text fields of an address.
+func normalizeAddress(address domain.Address) domain.Address {
+	address.Street = strings.TrimSpace(address.Street)
+	address.City = strings.TrimSpace(address.City)
+	address.State = strings.TrimSpace(address.State)
+	address.Country = strings.TrimSpace(address.Country)
+	address.Label = strings.TrimSpace(address.Label)
+	return address
+}
+
+// validateAddress reports every missing or invalid field of an address.
+func validateAddress(address domain.Address) error {
+	var violations []errs.AddressFieldViolation
+	add := func(field, description string) {
+		violations = append(violations, errs.AddressFieldViolation{Field: field, Description: description})
+	}
+
+	required := []struct {
+		field, value string
+		maxLength    int
+	}{
+		{"street", address.Street, 255},
+		{"city", address.City, 100},
+		{"country", address.Country, 100},
+	}
+	for _, r := range required {
+		if r.value == "" {
+			add(r.field, r.field+" is required")
+		} else if utf8.RuneCountInString(r.value) > r.maxLength {
+			add(r.field, fmt.Sprintf("%s must be at most %d characters", r.field, r.maxLength))
+		}
+	}
+	if utf8.RuneCountInString(address.State) > 100 {
+		add("state", "state must be at most 100 characters")
+	}
+	if utf8.RuneCountInString(address.Label) > maxAddressLabelLength {
+		add("label", fmt.Sprintf("label must be at most %d characters", maxAddressLabelLength))
+	}
+	if math.IsNaN(float64(address.Latitude)) || address.Latitude < -90 || address.Latitude > 90 {
+		add("latitude", "latitude must be between -90 and 90")
+	}
+	if math.IsNaN(float64(address.Longitude)) || address.Longitude < -180 || address.Longitude > 180 {
+		add("longitude", "longitude must be between -180 and 180")
+	}
+	// The postal code column is a signed integer.
+	if address.PostalCode > math.MaxInt32 {
+		add("postal_code", "postal_code is too large")
+	}
+
+	if len(violations) > 0 {
+		return &errs.AddressError{Violations: violations}
+	}
+	return nil
+}
+
+func normalizeVehicle(vehicle domain.Vehicle) domain.Vehicle {
+	vehicle.Type = strings.ToLower(strings.TrimSpace(vehicle.Type))
+	vehicle.Make = strings.TrimSpace(vehicle.Make)
+	vehicle.Model = strings.TrimSpace(vehicle.Model)
+	vehicle.PlateNumber = strings.ToUpper(strings.TrimSpace(vehicle.PlateNumber))
+	vehicle.Color = strings.TrimSpace(vehicle.Color)
+	return vehicle
+}
+
+// validateVehicle reports the first missing or invalid field of a vehicle.
+func validateVehicle(vehicle domain.Vehicle) error {
+	switch vehicle.Type {
+	case domain.VehicleBicycle, domain.VehicleScooter, domain.VehicleMotorcycle, domain.VehicleCar, domain.VehicleVan:
+	case "":
+		return fmt.Errorf("%w: type is required", errs.ErrInvalidVehicle)
+	default:
+		return fmt.Errorf("%w: type must be one of bicycle, scooter, motorcycle, car or van", errs.ErrInvalidVehicle)
+	}
+
+	if vehicle.PlateNumber == "" && vehicle.Type != domain.VehicleBicycle {
+		return fmt.Errorf("%w: plate_number is required", errs.ErrInvalidVehicle)
+	}
+
+	// The sizes of the vehicle columns.
+	fields := []struct {
+		field, value string
+		maxLength    int
+	}{
+		{"make", vehicle.Make, 50},
+		{"model", vehicle.Model, 50},
+		{"plate_number", vehicle.PlateNumber, 20},
+		{"color", vehicle.Color, 30},
+	}
+	for _, f := range fields {
+		if utf8.RuneCountInString(f.value) > f.maxLength {
+			return fmt.Errorf("%w: %s must be at most %d characters", errs.ErrInvalidVehicle, f.field, f.maxLength)
+		}
+	}
+
+	return nil
+}
+
+func validateLocation(latitude, longitude float32) error {
+	if math.IsNaN(float64(latitude)) || latitude < -90 || latitude > 90 {
+		return fmt.Errorf("%w: latitude must be between -90 and 90", errs.ErrInvalidLocation)
+	}
+	if math.IsNaN(float64(longitude)) || longitude < -180 || longitude > 180 {
+		return fmt.Errorf("%w: longitude must be between -180 and 180", errs.ErrInvalidLocation)
+	}
+	return nil
+}
+
+// NewUserUsecase creates the usecase of user profiles and drivers. A user can
+// have at most maxAddresses addresses, and a driver's location is forgotten
+// when they do not update it within locationTTL.
+func NewUserUsecase(
+	userRepo domain.UserRepository,
+	driverLocations domain.DriverLocationRepository,
+	timeout time.Duration,
+	maxAddresses int,
+	locationTTL time.Duration,
+) domain.UserUseCase {
 	return &userUsecase{
-		userRepository: userRepo,
-		ctxTimeout:     timeout,
+		userRepository:  userRepo,
+		driverLocations: driverLocations,
+		ctxTimeout:      timeout,
+		maxAddresses:    maxAddresses,
+		locationTTL:     locationTTL,
 	}
 }
@@ -1,15 +1,14 @@
 package internalutil
 
 import (
-	"context"
+	"crypto/rand"
 	"crypto/rsa"
 	"crypto/sha256"
+	"encoding/base64"
 	"encoding/hex"
-	"errors"
 	"fmt"
 	"time"
 
-	"cloud.google.com/go/auth/credentials/idtoken"
 	"github.com/golang-jwt/jwt/v5"
 	"github.com/google/uuid"
 	authservice "github.com/tamirat-dejene/ha-soranu/services/auth-service"
@@ -26,30 +25,47 @@ func GetRefreshKey(tokenID string) string {
 	return fmt.Sprintf("refresh:%s", HashToken(tokenID))
 }
 
-func CreateAccessToken(privateKey *rsa.PrivateKey, userEmail string, ttl time.Duration, issuer string, extra map[string]any) (string, error) {
+// TokenSubject is the user a token pair is issued to.
+type TokenSubject struct {
+	UserID        string
+	Email         string
+	EmailVerified bool
+	Roles         []string
+}
+
+// CreateAccessToken signs an access token for the session, naming the signing
+// key in the "kid" header so verifiers can pick it from their key set.
+func CreateAccessToken(signingKey SigningKey, subject TokenSubject, sessionID string, ttl time.Duration, issuer string, extra map[string]any) (string, error) {
 	now := time.Now()
 	claims := jwtvalidator.AccessClaims{
-		UserEmail: userEmail,
-		Extra:     extra,
+		UserEmail:     subject.Email,
+		EmailVerified: subject.EmailVerified,
+		Roles:         subject.Roles,
+		SessionID:     sessionID,
+		Extra:         extra,
 		RegisteredClaims: jwt.RegisteredClaims{
+			Subject:   subject.UserID,
 			IssuedAt:  jwt.NewNumericDate(now),
 			ExpiresAt: jwt.NewNumericDate(now.Add(ttl)),
 			Issuer:    issuer,
 		},
 	}
 	token := jwt.NewWithClaims(jwt.SigningMethodRS256, claims)
-	return token.SignedString(privateKey)
+	token.Header["kid"] = signingKey.ID
+	return token.SignedString(signingKey.Key)
 }
 
-func CreateRefreshToken(privateKey *rsa.PrivateKey, userEmail string, ttl time.Duration, issuer string, extra map[string]any) (*jwtvalidator.RefreshClaims, string, error) {
+func CreateRefreshToken(privateKey *rsa.PrivateKey, subject TokenSubject, familyID string, ttl time.Duration, issuer string, extra map[string]any) (*jwtvalidator.RefreshClaims, string, error) {
 	now := time.Now()
 	tokenID := uuid.New().String()
 
 	claims := jwtvalidator.RefreshClaims{
 		TokenID:   tokenID,
-		UserEmail: userEmail,
+		FamilyID:  familyID,
+		UserEmail: subject.Email,
 		Extra:     extra,
 		RegisteredClaims: jwt.RegisteredClaims{
+			Subject:   subject.UserID,
 			IssuedAt:  jwt.NewNumericDate(now),
 			ExpiresAt: jwt.NewNumericDate(now.Add(ttl)),
 			Issuer:    issuer,
@@ -65,7 +81,24 @@ func CreateRefreshToken(privateKey *rsa.PrivateKey, userEmail string, ttl time.D
 	return &claims, signedToken, nil
 }
 
-func SignUser(userEmail string, env *authservice.Env, extra map[string]any) (*domain.AuthTokens, string, error) {
+// NewOneTimeToken returns a random single-use token to be sent by email.
+func NewOneTimeToken() (string, error) {
+	b := make([]byte, 32)
+	if _, err := rand.Read(b); err != nil {
+		return "", err
+	}
+	return base64.RawURLEncoding.EncodeToString(b), nil
+}
+
+// NewTokenFamilyID returns the ID of a new refresh token family.
+func NewTokenFamilyID() string {
+	return uuid.New().String()
+}
+
+// SignUser issues an access token and a refresh token in the given family,
+// which is also the session of the access token. It returns the tokens and the
+// ID of the refresh token.
+func SignUser(subject TokenSubject, familyID string, accessKeys *AccessKeySet, env *authservice.Env, extra map[string]any) (*domain.AuthTokens, string, error) {
 	attl, err := time.ParseDuration(env.AccessTokenTTL)
 	if err != nil {
 		return nil, "", fmt.Errorf("invalid access token TTL: %w", err)
@@ -76,22 +109,17 @@ func SignUser(userEmail string, env *authservice.Env, extra map[string]any) (*do
 		return nil, "", fmt.Errorf("invalid refresh token TTL: %w", err)
 	}
 
-	accessPrivateKey, err := jwtvalidator.ParseRSAPrivateKeyFromString(env.AccessTokenPrivateKey)
-	if err != nil {
-		return nil, "", fmt.Errorf("invalid access token private key: %w", err)
-	}
-
 	refreshPrivateKey, err := jwtvalidator.ParseRSAPrivateKeyFromString(env.RefreshTokenPrivateKey)
 	if err != nil {
 		return nil, "", fmt.Errorf("invalid refresh token private key: %w", err)
 	}
 
-	accessToken, err := CreateAccessToken(accessPrivateKey, userEmail, attl, env.AUTH_SRV_NAME, extra)
+	accessToken, err := CreateAccessToken(accessKeys.SigningKey(), subject, familyID, attl, env.AUTH_SRV_NAME, extra)
 	if err != nil {
 		return nil, "", fmt.Errorf("failed to create access token: %w", err)
 	}
 
-	refreshClaims, refreshToken, err := CreateRefreshToken(refreshPrivateKey, userEmail, rttl, env.AUTH_SRV_NAME, extra)
+	refreshClaims, refreshToken, err := CreateRefreshToken(refreshPrivateKey, subject, familyID, rttl, env.AUTH_SRV_NAME, extra)
 	if err != nil {
 		return nil, "", fmt.Errorf("failed to create refresh token: %w", err)
 	}
@@ -101,25 +129,3 @@ func SignUser(userEmail string, env *authservice.Env, extra map[string]any) (*do
 		RefreshToken: refreshToken,
 	}, refreshClaims.TokenID, nil
 }
-
-func ValidateGoogleIDToken(ctx context.Context, client_id, id_token string) (domain.User, error) {
-	payload, err := idtoken.Validate(ctx, id_token, client_id)
-	if err != nil {
-		return domain.User{}, errors.New("invalid Google token")
-	}
-
-	// Extract email and name from payload
-	email, ok := payload.Claims["email"].(string)
-	if !ok || email == "" {
-		return domain.User{}, errors.New("email not found in token")
-	}
-
-	name, _ := payload.Claims["name"].(string)
-
-	user := domain.User{
-		Email:    email,
-		Username: name,
-	}
-
-	return user, nil
-}
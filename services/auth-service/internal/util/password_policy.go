@@ -0,0 +1,151 @@
+package internalutil
+
+import (
+	"bufio"
+	_ "embed"
+	"fmt"
+	"os"
+	"strings"
+	"unicode"
+
+	errs "github.com/tamirat-dejene/ha-soranu/services/auth-service/internal/domain/err"
+)
+
+// maxPasswordBytes is the most bcrypt hashes; longer passwords are refused
+// rather than silently cut short.
+const maxPasswordBytes = 72
+
+//go:embed breached_passwords.txt
+var breachedPasswords string
+
+// PasswordPolicy decides which passwords users may choose.
+type PasswordPolicy struct {
+	// MinLength is the minimum number of characters.
+	MinLength int
+	// MinCharacterClasses is how many of lower case letters, upper case
+	// letters, digits and symbols a password must mix.
+	MinCharacterClasses int
+
+	breached map[string]struct{}
+}
+
+// NewPasswordPolicy creates a policy refusing the built-in list of breached
+// passwords and those of breachedListFile, when given: one password per line,
+// lines starting with # are ignored.
+func NewPasswordPolicy(minLength, minCharacterClasses int, breachedListFile string) (*PasswordPolicy, error) {
+	policy := &PasswordPolicy{
+		MinLength:           minLength,
+		MinCharacterClasses: minCharacterClasses,
+		breached:            make(map[string]struct{}),
+	}
+
+	policy.addBreached(breachedPasswords)
+
+	if breachedListFile != "" {
+		data, err := os.ReadFile(breachedListFile)
+		if err != nil {
+			return nil, fmt.Errorf("failed to read breached password list: %w", err)
+		}
+		policy.addBreached(string(data))
+	}
+
+	return policy, nil
+}
+
+// BreachedCount returns how many passwords the policy knows to be breached.
+func (p *PasswordPolicy) BreachedCount() int {
+	return len(p.breached)
+}
+
+// Check returns an [errs.PasswordPolicyError] listing every rule the password
+// breaks, or nil. The password may not be any of userInfo either, such as the
+// user's email or name.
+func (p *PasswordPolicy) Check(field, password string, userInfo ...string) error {
+	var violations []errs.PasswordViolation
+
+	length := len([]rune(password))
+	if length < p.MinLength {
+		violations = append(violations, errs.PasswordViolation{
+			Reason:      errs.ReasonPasswordTooShort,
+			Description: fmt.Sprintf("Password must be at least %d characters long.", p.MinLength),
+		})
+	}
+	if len(password) > maxPasswordBytes {
+		violations = append(violations, errs.PasswordViolation{
+			Reason:      errs.ReasonPasswordTooLong,
+			Description: fmt.Sprintf("Password must be at most %d bytes long.", maxPasswordBytes),
+		})
+	}
+
+	var hasLower, hasUpper, hasDigit, hasSymbol, hasControl bool
+	for _, r := range password {
+		switch {
+		case unicode.IsControl(r):
+			hasControl = true
+		case unicode.IsLower(r):
+			hasLower = true
+		case unicode.IsUpper(r):
+			hasUpper = true
+		case unicode.IsDigit(r):
+			hasDigit = true
+		default:
+			hasSymbol = true
+		}
+	}
+	if hasControl {
+		violations = append(violations, errs.PasswordViolation{
+			Reason:      errs.ReasonPasswordInvalidChars,
+			Description: "Password must not contain control characters.",
+		})
+	}
+
+	classes := 0
+	for _, has := range []bool{hasLower, hasUpper, hasDigit, hasSymbol} {
+		if has {
+			classes++
+		}
+	}
+	if classes < p.MinCharacterClasses {
+		violations = append(violations, errs.PasswordViolation{
+			Reason: errs.ReasonPasswordTooFewClasses,
+			Description: fmt.Sprintf("Password must mix at least %d of lower case letters, upper case letters, digits and symbols.",
+				p.MinCharacterClasses),
+		})
+	}
+
+	normalized := strings.ToLower(password)
+	if _, ok := p.breached[normalized]; ok {
+		violations = append(violations, errs.PasswordViolation{
+			Reason:      errs.ReasonPasswordBreached,
+			Description: "Password is known from data breaches; choose another one.",
+		})
+	}
+
+	for _, info := range userInfo {
+		info = strings.ToLower(strings.TrimSpace(info))
+		local, _, _ := strings.Cut(info, "@")
+		if info != "" && (normalized == info || normalized == local) {
+			violations = append(violations, errs.PasswordViolation{
+				Reason:      errs.ReasonPasswordMatchesUserInfo,
+				Description: "Password must not be your email address or username.",
+			})
+			break
+		}
+	}
+
+	if len(violations) == 0 {
+		return nil
+	}
+	return &errs.PasswordPolicyError{Field: field, Violations: violations}
+}
+
+func (p *PasswordPolicy) addBreached(list string) {
+	scanner := bufio.NewScanner(strings.NewReader(list))
+	for scanner.Scan() {
+		line := strings.TrimSpace(scanner.Text())
+		if line == "" || strings.HasPrefix(line, "#") {
+			continue
+		}
+		p.breached[strings.ToLower(line)] = struct{}{}
+	}
+}
@@ -0,0 +1,118 @@
+package internalutil
+
+import (
+	"errors"
+	"os"
+	"path/filepath"
+	"slices"
+	"strings"
+	"testing"
+
+	errs "github.com/tamirat-dejene/ha-soranu/services/auth-service/internal/domain/err"
+)
+
+func TestPasswordPolicyCheck(t *testing.T) {
+	policy, err := NewPasswordPolicy(8, 3, "")
+	if err != nil {
+		t.Fatalf("NewPasswordPolicy() error = %v", err)
+	}
+
+	tests := []struct {
+		name        string
+		password    string
+		userInfo    []string
+		wantReasons []string
+	}{
+		{name: "strong", password: "Tr0ub4dor&3"},
+		{name: "three classes", password: "correct-Horse-battery"},
+		{name: "multibyte characters count once", password: "Äpfel-und-Öl"},
+		{name: "too short", password: "Ab1!", wantReasons: []string{errs.ReasonPasswordTooShort}},
+		{
+			name:        "too long for bcrypt",
+			password:    "Aa1!" + strings.Repeat("x", maxPasswordBytes),
+			wantReasons: []string{errs.ReasonPasswordTooLong},
+		},
+		{name: "one class", password: "abcdefghijkl", wantReasons: []string{errs.ReasonPasswordTooFewClasses}},
+		{name: "control character", password: "Abcdef1\x00gh", wantReasons: []string{errs.ReasonPasswordInvalidChars}},
+		{name: "breached", password: "P@ssw0rd1", wantReasons: []string{errs.ReasonPasswordBreached}},
+		{
+			name:        "email",
+			password:    "Jane.Doe@Example.com",
+			userInfo:    []string{"jane.doe@example.com", "jane"},
+			wantReasons: []string{errs.ReasonPasswordMatchesUserInfo},
+		},
+		{
+			name:        "local part of the email",
+			password:    "Jane.Doe-1",
+			userInfo:    []string{"jane.doe-1@example.com"},
+			wantReasons: []string{errs.ReasonPasswordMatchesUserInfo},
+		},
+		{name: "empty user info is ignored", password: "Tr0ub4dor&3", userInfo: []string{"", "  "}},
+		{
+			name:        "every broken rule is listed",
+			password:    "abc",
+			wantReasons: []string{errs.ReasonPasswordTooShort, errs.ReasonPasswordTooFewClasses},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := policy.Check("new_password", tt.password, tt.userInfo...)
+			if len(tt.wantReasons) == 0 {
+				if err != nil {
+					t.Fatalf("Check() error = %v, want nil", err)
+				}
+				return
+			}
+
+			var policyErr *errs.PasswordPolicyError
+			if !errors.As(err, &policyErr) {
+				t.Fatalf("Check() error = %v, want a PasswordPolicyError", err)
+			}
+			if !errors.Is(err, errs.ErrInvalidPassword) {
+				t.Errorf("Check() error does not match ErrInvalidPassword")
+			}
+			if policyErr.Field != "new_password" {
+				t.Errorf("Field = %q, want %q", policyErr.Field, "new_password")
+			}
+
+			var reasons []string
+			for _, v := range policyErr.Violations {
+				reasons = append(reasons, v.Reason)
+			}
+			if !slices.Equal(reasons, tt.wantReasons) {
+				t.Errorf("reasons = %v, want %v", reasons, tt.wantReasons)
+			}
+		})
+	}
+}
+
+func TestPasswordPolicyBreachedListFile(t *testing.T) {
+	file := filepath.Join(t.TempDir(), "breached.txt")
+	list := "# company passwords\n\nHaSoranu2026!\n  Spring-Sale-99  \n"
+	if err := os.WriteFile(file, []byte(list), 0o600); err != nil {
+		t.Fatal(err)
+	}
+
+	builtIn, err := NewPasswordPolicy(8, 3, "")
+	if err != nil {
+		t.Fatalf("NewPasswordPolicy() error = %v", err)
+	}
+	policy, err := NewPasswordPolicy(8, 3, file)
+	if err != nil {
+		t.Fatalf("NewPasswordPolicy() error = %v", err)
+	}
+
+	if got, want := policy.BreachedCount(), builtIn.BreachedCount()+2; got != want {
+		t.Errorf("BreachedCount() = %d, want %d", got, want)
+	}
+	for _, password := range []string{"hasoranu2026!", "SPRING-SALE-99"} {
+		if err := policy.Check("password", password); !errors.Is(err, errs.ErrInvalidPassword) {
+			t.Errorf("Check(%q) error = %v, want the password refused as breached", password, err)
+		}
+	}
+
+	if _, err := NewPasswordPolicy(8, 3, filepath.Join(t.TempDir(), "missing.txt")); err == nil {
+		t.Errorf("NewPasswordPolicy() with a missing list file succeeded, want an error")
+	}
+}
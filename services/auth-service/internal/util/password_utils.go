@@ -1,8 +1,6 @@
 package internalutil
 
 import (
-	"unicode"
-
 	"golang.org/x/crypto/bcrypt"
 )
 
@@ -20,56 +18,16 @@ func ComparePassword(hashedPassword, password string) error {
 	return bcrypt.CompareHashAndPassword([]byte(hashedPassword), []byte(password))
 }
 
-// IsPasswordStrong checks if the given password meets strength requirements.
-func IsPasswordStrong(password string) bool {
-	const minPasswordLength = 4
-	runes := []rune(password)
+// dummyPasswordHash stands in for the hash of accounts that do not exist or
+// have no password, so that checking them takes as long as a real check.
+var dummyPasswordHash, _ = HashPassword("not-the-password-of-any-account")
 
-	if len(runes) < minPasswordLength {
+// CheckPassword reports whether password matches hashedPassword. An empty
+// hash never matches but takes the same time to check as one that does not.
+func CheckPassword(hashedPassword, password string) bool {
+	if hashedPassword == "" {
+		_ = ComparePassword(dummyPasswordHash, password)
 		return false
 	}
-
-	var hasUpper, hasLower, hasDigit, hasSpecial bool
-
-	for _, r := range runes {
-		// Reject whitespace or control characters
-		if unicode.IsSpace(r) || r < 33 {
-			return false
-		}
-
-		switch {
-		case unicode.IsUpper(r):
-			hasUpper = true
-		case unicode.IsLower(r):
-			hasLower = true
-		case unicode.IsDigit(r):
-			hasDigit = true
-		default:
-			// Any other printable character counts as special
-			hasSpecial = true
-		}
-	}
-
-	// Count categories
-	categories := 0
-	if hasUpper {
-		categories++
-	}
-	if hasLower {
-		categories++
-	}
-	if hasDigit {
-		categories++
-	}
-	if hasSpecial {
-		categories++
-	}
-
-	// Rules:
-	// - Short passwords (4-7 chars): at least 2 categories
-	// - Long passwords (8+ chars): at least 3 categories
-	if len(runes) < 8 {
-		return categories >= 2
-	}
-	return categories >= 3
+	return ComparePassword(hashedPassword, password) == nil
 }
@@ -0,0 +1,103 @@
+package internalutil
+
+import (
+	"encoding/base64"
+	"strings"
+	"testing"
+)
+
+func testKey(b byte) string {
+	return base64.StdEncoding.EncodeToString([]byte(strings.Repeat(string(b), 32)))
+}
+
+func TestNewSecretBox(t *testing.T) {
+	tests := []struct {
+		name    string
+		key     string
+		wantErr bool
+	}{
+		{"32 bytes", testKey('k'), false},
+		{"16 bytes", base64.StdEncoding.EncodeToString(make([]byte, 16)), true},
+		{"empty", "", true},
+		{"not base64", "not base64!", true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := NewSecretBox(tt.key)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("NewSecretBox() error = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestSecretBoxOpensWhatItSealed(t *testing.T) {
+	box, err := NewSecretBox(testKey('k'))
+	if err != nil {
+		t.Fatalf("NewSecretBox() error = %v", err)
+	}
+
+	sealed, err := box.Seal(rfc6238Secret)
+	if err != nil {
+		t.Fatalf("Seal() error = %v", err)
+	}
+	if strings.Contains(sealed, rfc6238Secret) {
+		t.Fatalf("Seal() = %q, contains the plaintext", sealed)
+	}
+
+	again, err := box.Seal(rfc6238Secret)
+	if err != nil {
+		t.Fatalf("Seal() error = %v", err)
+	}
+	if again == sealed {
+		t.Errorf("Seal() returned the same ciphertext twice; the nonce must be random")
+	}
+
+	for _, s := range []string{sealed, again} {
+		opened, err := box.Open(s)
+		if err != nil {
+			t.Fatalf("Open() error = %v", err)
+		}
+		if opened != rfc6238Secret {
+			t.Errorf("Open() = %q, want %q", opened, rfc6238Secret)
+		}
+	}
+}
+
+func TestSecretBoxRejectsForeignSecrets(t *testing.T) {
+	box, err := NewSecretBox(testKey('k'))
+	if err != nil {
+		t.Fatalf("NewSecretBox() error = %v", err)
+	}
+	other, err := NewSecretBox(testKey('o'))
+	if err != nil {
+		t.Fatalf("NewSecretBox() error = %v", err)
+	}
+
+	sealed, err := box.Seal(rfc6238Secret)
+	if err != nil {
+		t.Fatalf("Seal() error = %v", err)
+	}
+	data, _ := base64.StdEncoding.DecodeString(sealed)
+	data[len(data)-1] ^= 1
+	tampered := base64.StdEncoding.EncodeToString(data)
+
+	tests := []struct {
+		name   string
+		box    *SecretBox
+		sealed string
+	}{
+		{"other key", other, sealed},
+		{"tampered ciphertext", box, tampered},
+		{"shorter than the nonce", box, base64.StdEncoding.EncodeToString([]byte("short"))},
+		{"not base64", box, "not base64!"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if opened, err := tt.box.Open(tt.sealed); err == nil {
+				t.Errorf("Open() = %q, want an error", opened)
+			}
+		})
+	}
+}
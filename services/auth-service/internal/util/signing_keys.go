@@ -0,0 +1,66 @@
+package internalutil
+
+import (
+	"crypto/rsa"
+	"fmt"
+
+	jwtvalidator "github.com/tamirat-dejene/ha-soranu/shared/pkg/auth/jwtvalidator"
+)
+
+// SigningKey is a private key together with the ID put in the "kid" header of
+// the tokens it signs.
+type SigningKey struct {
+	ID  string
+	Key *rsa.PrivateKey
+}
+
+// AccessKeySet holds the key access tokens are signed with and every public
+// key that verifiers should accept. Besides the signing key these are the
+// keys being retired, whose tokens have not expired yet, and the keys about to
+// be used, published ahead so verifiers already know them when signing
+// switches over.
+type AccessKeySet struct {
+	signing SigningKey
+	jwks    jwtvalidator.JWKS
+}
+
+// SigningKey returns the key new access tokens are signed with.
+func (k *AccessKeySet) SigningKey() SigningKey {
+	return k.signing
+}
+
+// JWKS returns the public keys of the set.
+func (k *AccessKeySet) JWKS() jwtvalidator.JWKS {
+	return k.jwks
+}
+
+// NewAccessKeySet parses the PEM signing key and the additional public keys,
+// given as concatenated PEM blocks. Key IDs are derived from the keys
+// themselves, see [jwtvalidator.KeyID].
+func NewAccessKeySet(privateKeyPEM, publishedKeysPEM string) (*AccessKeySet, error) {
+	privateKey, err := jwtvalidator.ParseRSAPrivateKeyFromString(privateKeyPEM)
+	if err != nil {
+		return nil, fmt.Errorf("invalid access token private key: %w", err)
+	}
+
+	published, err := jwtvalidator.ParseRSAPublicKeysFromString(publishedKeysPEM)
+	if err != nil {
+		return nil, fmt.Errorf("invalid access token published keys: %w", err)
+	}
+
+	set := &AccessKeySet{
+		signing: SigningKey{ID: jwtvalidator.KeyID(&privateKey.PublicKey), Key: privateKey},
+	}
+
+	seen := make(map[string]bool)
+	for _, key := range append([]*rsa.PublicKey{&privateKey.PublicKey}, published...) {
+		kid := jwtvalidator.KeyID(key)
+		if seen[kid] {
+			continue
+		}
+		seen[kid] = true
+		set.jwks.Keys = append(set.jwks.Keys, jwtvalidator.NewJWK(kid, key))
+	}
+
+	return set, nil
+}
@@ -0,0 +1,128 @@
+package internalutil
+
+import (
+	"net/url"
+	"regexp"
+	"testing"
+	"time"
+)
+
+// rfc6238Secret is the SHA-1 key of the test vectors of RFC 6238,
+// "12345678901234567890", base32 encoded.
+const rfc6238Secret = "GEZDGNBVGY3TQOJQGEZDGNBVGY3TQOJQ"
+
+func TestValidateTOTP(t *testing.T) {
+	// The RFC gives 8-digit codes; 6-digit codes are their last six digits.
+	tests := []struct {
+		name     string
+		secret   string
+		code     string
+		now      time.Time
+		wantStep int64
+		wantOK   bool
+	}{
+		{"rfc 6238 at 59", rfc6238Secret, "287082", time.Unix(59, 0), 1, true},
+		{"rfc 6238 at 1111111109", rfc6238Secret, "081804", time.Unix(1111111109, 0), 37037036, true},
+		{"rfc 6238 at 1234567890", rfc6238Secret, "005924", time.Unix(1234567890, 0), 41152263, true},
+		{"rfc 6238 at 2000000000", rfc6238Secret, "279037", time.Unix(2000000000, 0), 66666666, true},
+		{"lower case secret", "gezdgnbvgy3tqojqgezdgnbvgy3tqojq", "287082", time.Unix(59, 0), 1, true},
+		{"previous period", rfc6238Secret, "287082", time.Unix(59+30, 0), 1, true},
+		{"next period", rfc6238Secret, "287082", time.Unix(59-30, 0), 1, true},
+		{"two periods late", rfc6238Secret, "287082", time.Unix(59+60, 0), 0, false},
+		{"wrong code", rfc6238Secret, "287083", time.Unix(59, 0), 0, false},
+		{"too short", rfc6238Secret, "28708", time.Unix(59, 0), 0, false},
+		{"eight digits", rfc6238Secret, "94287082", time.Unix(59, 0), 0, false},
+		{"invalid secret", "not base32!", "287082", time.Unix(59, 0), 0, false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			step, ok := ValidateTOTP(tt.secret, tt.code, tt.now)
+			if ok != tt.wantOK || step != tt.wantStep {
+				t.Errorf("ValidateTOTP() = (%d, %v), want (%d, %v)", step, ok, tt.wantStep, tt.wantOK)
+			}
+		})
+	}
+}
+
+func TestGenerateTOTPSecretIsValid(t *testing.T) {
+	secret, err := GenerateTOTPSecret()
+	if err != nil {
+		t.Fatalf("GenerateTOTPSecret() error = %v", err)
+	}
+	key, err := totpEncoding.DecodeString(secret)
+	if err != nil || len(key) != 20 {
+		t.Fatalf("GenerateTOTPSecret() = %q, want 20 base32 encoded bytes", secret)
+	}
+
+	now := time.Now()
+	code := totpCode(key, now.Unix()/int64(totpPeriod.Seconds()))
+	if _, ok := ValidateTOTP(secret, code, now); !ok {
+		t.Errorf("ValidateTOTP() rejected the current code %q of a new secret", code)
+	}
+}
+
+func TestTOTPProvisioningURI(t *testing.T) {
+	uri, err := url.Parse(TOTPProvisioningURI("Ha Soranu", "jane@example.com", rfc6238Secret))
+	if err != nil {
+		t.Fatalf("parse provisioning URI: %v", err)
+	}
+
+	if uri.Scheme != "otpauth" || uri.Host != "totp" {
+		t.Errorf("provisioning URI = %s, want otpauth://totp/...", uri)
+	}
+	if uri.Path != "/Ha Soranu:jane@example.com" {
+		t.Errorf("label = %q, want %q", uri.Path, "/Ha Soranu:jane@example.com")
+	}
+
+	want := map[string]string{
+		"secret":    rfc6238Secret,
+		"issuer":    "Ha Soranu",
+		"algorithm": "SHA1",
+		"digits":    "6",
+		"period":    "30",
+	}
+	for param, value := range want {
+		if got := uri.Query().Get(param); got != value {
+			t.Errorf("%s = %q, want %q", param, got, value)
+		}
+	}
+}
+
+func TestRecoveryCodes(t *testing.T) {
+	codes, err := GenerateRecoveryCodes(10)
+	if err != nil {
+		t.Fatalf("GenerateRecoveryCodes() error = %v", err)
+	}
+	if len(codes) != 10 {
+		t.Fatalf("GenerateRecoveryCodes() returned %d codes, want 10", len(codes))
+	}
+
+	format := regexp.MustCompile(`^[a-z2-7]{5}-[a-z2-7]{5}$`)
+	seen := make(map[string]bool)
+	for _, code := range codes {
+		if !format.MatchString(code) {
+			t.Errorf("recovery code %q does not look like xxxxx-xxxxx", code)
+		}
+		if seen[code] {
+			t.Errorf("recovery code %q generated twice", code)
+		}
+		seen[code] = true
+	}
+
+	tests := []struct {
+		code string
+		want string
+	}{
+		{"abcde-fghij", "abcdefghij"},
+		{"ABCDE-FGHIJ", "abcdefghij"},
+		{"abcdefghij", "abcdefghij"},
+		{" abcde fghij ", "abcdefghij"},
+		{"", ""},
+	}
+	for _, tt := range tests {
+		if got := NormalizeRecoveryCode(tt.code); got != tt.want {
+			t.Errorf("NormalizeRecoveryCode(%q) = %q, want %q", tt.code, got, tt.want)
+		}
+	}
+}
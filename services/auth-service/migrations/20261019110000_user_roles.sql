@@ -0,0 +1,12 @@
+-- +goose Up
+-- Roles granted on top of the ones every user derives: customer for all users
+-- and driver for users with a row in drivers.
+CREATE TABLE IF NOT EXISTS user_roles (
+    user_id UUID NOT NULL REFERENCES users(user_id) ON DELETE CASCADE,
+    role VARCHAR(30) NOT NULL CHECK (role IN ('restaurant_owner', 'admin')),
+    created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+    PRIMARY KEY (user_id, role)
+);
+
+-- +goose Down
+DROP TABLE IF EXISTS user_roles;
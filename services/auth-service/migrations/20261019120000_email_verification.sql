@@ -0,0 +1,8 @@
+-- +goose Up
+ALTER TABLE users ADD COLUMN IF NOT EXISTS email_verified BOOLEAN NOT NULL DEFAULT FALSE;
+
+-- Accounts created before verification existed keep working as they did.
+UPDATE users SET email_verified = TRUE;
+
+-- +goose Down
+ALTER TABLE users DROP COLUMN IF EXISTS email_verified;
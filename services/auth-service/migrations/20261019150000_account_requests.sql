@@ -0,0 +1,39 @@
+-- +goose Up
+-- Deleted accounts keep their row, stripped of personal data, so that what
+-- other services still reference by user ID does not dangle.
+ALTER TABLE users ADD COLUMN IF NOT EXISTS deleted_at TIMESTAMP;
+
+-- An account deletion or data export, carried out by every service holding
+-- user data. notify_email is where the outcome is reported; it is cleared
+-- once a deletion is reported.
+CREATE TABLE IF NOT EXISTS account_requests (
+    request_id UUID PRIMARY KEY DEFAULT uuid_generate_v4(),
+    user_id UUID NOT NULL REFERENCES users(user_id) ON DELETE CASCADE,
+    kind VARCHAR(20) NOT NULL CHECK (kind IN ('DELETION', 'EXPORT')),
+    status VARCHAR(20) NOT NULL DEFAULT 'PENDING' CHECK (status IN ('PENDING', 'COMPLETED', 'FAILED')),
+    notify_email VARCHAR(100) NOT NULL DEFAULT '',
+    created_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    completed_at TIMESTAMP
+);
+
+-- At most one request of each kind in progress per user.
+CREATE UNIQUE INDEX IF NOT EXISTS idx_account_requests_user_pending
+    ON account_requests (user_id, kind)
+    WHERE status = 'PENDING';
+
+-- The part of a request each service carries out. data holds the service's
+-- share of an export.
+CREATE TABLE IF NOT EXISTS account_request_services (
+    request_id UUID NOT NULL REFERENCES account_requests(request_id) ON DELETE CASCADE,
+    service VARCHAR(50) NOT NULL,
+    status VARCHAR(20) NOT NULL DEFAULT 'PENDING' CHECK (status IN ('PENDING', 'COMPLETED', 'FAILED')),
+    data JSONB,
+    error TEXT NOT NULL DEFAULT '',
+    completed_at TIMESTAMP,
+    PRIMARY KEY (request_id, service)
+);
+
+-- +goose Down
+DROP TABLE IF EXISTS account_request_services;
+DROP TABLE IF EXISTS account_requests;
+ALTER TABLE users DROP COLUMN IF EXISTS deleted_at;
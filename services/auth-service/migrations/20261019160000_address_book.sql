@@ -0,0 +1,22 @@
+-- +goose Up
+ALTER TABLE addresses ADD COLUMN IF NOT EXISTS label VARCHAR(50) NOT NULL DEFAULT '';
+ALTER TABLE addresses ADD COLUMN IF NOT EXISTS is_default BOOLEAN NOT NULL DEFAULT FALSE;
+
+-- The oldest address of every user becomes their default one.
+UPDATE addresses a
+SET is_default = TRUE
+WHERE a.address_id = (
+    SELECT b.address_id FROM addresses b
+    WHERE b.user_id = a.user_id
+    ORDER BY b.created_at, b.address_id
+    LIMIT 1
+);
+
+CREATE INDEX IF NOT EXISTS idx_addresses_user ON addresses(user_id);
+CREATE UNIQUE INDEX IF NOT EXISTS idx_addresses_user_default ON addresses(user_id) WHERE is_default;
+
+-- +goose Down
+DROP INDEX IF EXISTS idx_addresses_user_default;
+DROP INDEX IF EXISTS idx_addresses_user;
+ALTER TABLE addresses DROP COLUMN IF EXISTS is_default;
+ALTER TABLE addresses DROP COLUMN IF EXISTS label;
@@ -0,0 +1,16 @@
+-- +goose Up
+ALTER TABLE drivers ADD COLUMN IF NOT EXISTS vehicle_type VARCHAR(20) NOT NULL DEFAULT '';
+ALTER TABLE drivers ADD COLUMN IF NOT EXISTS vehicle_make VARCHAR(50) NOT NULL DEFAULT '';
+ALTER TABLE drivers ADD COLUMN IF NOT EXISTS vehicle_model VARCHAR(50) NOT NULL DEFAULT '';
+ALTER TABLE drivers ADD COLUMN IF NOT EXISTS vehicle_plate_number VARCHAR(20) NOT NULL DEFAULT '';
+ALTER TABLE drivers ADD COLUMN IF NOT EXISTS vehicle_color VARCHAR(30) NOT NULL DEFAULT '';
+-- Whether the driver is on duty. Their live location is kept in Valkey.
+ALTER TABLE drivers ADD COLUMN IF NOT EXISTS online BOOLEAN NOT NULL DEFAULT FALSE;
+
+-- +goose Down
+ALTER TABLE drivers DROP COLUMN IF EXISTS online;
+ALTER TABLE drivers DROP COLUMN IF EXISTS vehicle_color;
+ALTER TABLE drivers DROP COLUMN IF EXISTS vehicle_plate_number;
+ALTER TABLE drivers DROP COLUMN IF EXISTS vehicle_model;
+ALTER TABLE drivers DROP COLUMN IF EXISTS vehicle_make;
+ALTER TABLE drivers DROP COLUMN IF EXISTS vehicle_type;
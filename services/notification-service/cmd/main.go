@@ -7,6 +7,9 @@ import (
 	"time"
 
 	postgres "github.com/tamirat-dejene/ha-soranu/shared/db/pg"
+	"github.com/tamirat-dejene/ha-soranu/shared/pkg/auth/svcauth"
+	"github.com/tamirat-dejene/ha-soranu/shared/pkg/correlation"
+	"github.com/tamirat-dejene/ha-soranu/shared/pkg/events"
 	"github.com/tamirat-dejene/ha-soranu/shared/pkg/logger"
 	"github.com/tamirat-dejene/ha-soranu/shared/pkg/messaging/kafka/sarama"
 	"go.uber.org/zap"
@@ -14,6 +17,7 @@ import (
 
 	svc "github.com/tamirat-dejene/ha-soranu/services/notification-service"
 	"github.com/tamirat-dejene/ha-soranu/services/notification-service/internal/api/grpc/handler"
+	"github.com/tamirat-dejene/ha-soranu/services/notification-service/internal/client"
 	"github.com/tamirat-dejene/ha-soranu/services/notification-service/internal/repository"
 	"github.com/tamirat-dejene/ha-soranu/services/notification-service/internal/usecase"
 	"github.com/tamirat-dejene/ha-soranu/services/notification-service/migrations"
@@ -55,16 +59,43 @@ func main() {
 	}
 	defer consumer.Close()
 
-	// 6. Initialize Repository, Usecase, and register Handler
+	// 6. Initialize sarama producer for reporting on user data requests
+	producer, err := sarama.NewProducer([]string{env.KafkaBroker})
+	if err != nil {
+		logger.Fatal("failed to create kafka producer", zap.Error(err))
+	}
+	defer producer.Close()
+
+	// 7. Initialize the restaurant service client, to check who owns the
+	// restaurants notifications are for
+	callSigner, err := svcauth.NewSigner(env.NOTIFICATION_SRV_NAME, env.ServiceAuthPrivateKey)
+	if err != nil {
+		logger.Fatal("invalid service auth private key", zap.Error(err))
+	}
+	restaurantClient, err := client.NewRestaurantServiceClient(env.RESTAURANT_SRV_NAME+":"+env.RESTAURANT_SRV_PORT, callSigner)
+	if err != nil {
+		logger.Fatal("failed to create restaurant service client", zap.Error(err))
+	}
+	defer restaurantClient.Close()
+
+	// 8. Initialize Repository, Usecase, and register Handler
 	notification_repo := repository.NewNotificationRepository(pgClient)
-	notification_usecase := usecase.NewNotificationUseCase(notification_repo, consumer, 10*time.Second)
+	notification_usecase := usecase.NewNotificationUseCase(notification_repo, events.NewEventPublisher(producer), consumer, restaurantClient, 10*time.Second)
 
-	// 7. Start gRPC Server
+	// 9. Start gRPC Server
 	lis, err := net.Listen("tcp", fmt.Sprintf(":%s", env.NOTIFICATION_SRV_PORT))
 	if err != nil {
 		logger.Fatal("failed to listen", zap.Error(err))
 	}
-	s := grpc.NewServer()
+	// Only the services listed in SERVICE_AUTH_PUBLIC_KEYS may call.
+	callVerifier, err := svcauth.NewVerifier(env.ServiceAuthPublicKeys)
+	if err != nil {
+		logger.Fatal("invalid service auth public keys", zap.Error(err))
+	}
+	s := grpc.NewServer(
+		grpc.ChainUnaryInterceptor(correlation.UnaryServerInterceptor(), callVerifier.UnaryServerInterceptor()),
+		grpc.ChainStreamInterceptor(correlation.StreamServerInterceptor(), callVerifier.StreamServerInterceptor()),
+	)
 
 	handler.NewNotificationHandler(s, notification_usecase)
 
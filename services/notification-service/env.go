@@ -21,6 +21,22 @@ type Env struct {
 
 	// Kafka settings
 	KafkaBroker string `mapstructure:"KAFKA_BROKER_URL"`
+
+	// ServiceAuthPrivateKey is the Ed25519 private key (PEM or base64-encoded
+	// PEM) the service signs its gRPC calls to the other services with.
+	ServiceAuthPrivateKey string `mapstructure:"SERVICE_AUTH_PRIVATE_KEY"`
+
+	// ServiceAuthPublicKeys lists the services allowed to call this one as
+	// service=key pairs, each key the base64-encoded PEM Ed25519 public key
+	// of the service.
+	ServiceAuthPublicKeys string `mapstructure:"SERVICE_AUTH_PUBLIC_KEYS"`
+	// NOTIFICATION_SRV_NAME is the name the service signs its calls with.
+	NOTIFICATION_SRV_NAME string `mapstructure:"NOTIFICATION_SRV_NAME"`
+
+	// Restaurant service settings, to check who owns the restaurants
+	// notifications are for.
+	RESTAURANT_SRV_NAME string `mapstructure:"RESTAURANT_SRV_NAME"`
+	RESTAURANT_SRV_PORT string `mapstructure:"RESTAURANT_SRV_PORT"`
 }
 
 func getString(key string, defaultValue string) string {
@@ -55,6 +71,11 @@ func GetEnv() (*Env, error) {
 		DBPassword:                      getString("POSTGRES_PASSWORD", "password"),
 		DBName:                          getString("POSTGRES_DB", "notification_db"),
 		KafkaBroker:                     getString("KAFKA_BROKER_URL", "localhost:9092"),
+		ServiceAuthPrivateKey:           getString("SERVICE_AUTH_PRIVATE_KEY", ""),
+		ServiceAuthPublicKeys:           getString("SERVICE_AUTH_PUBLIC_KEYS", ""),
+		NOTIFICATION_SRV_NAME:           getString("NOTIFICATION_SRV_NAME", "notification-service"),
+		RESTAURANT_SRV_NAME:             getString("RESTAURANT_SRV_NAME", "restaurant-service"),
+		RESTAURANT_SRV_PORT:             getString("RESTAURANT_SRV_PORT", "7577"),
 	}
 	return &env, nil
 }
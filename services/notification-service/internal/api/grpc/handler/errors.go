@@ -0,0 +1,61 @@
+package handler
+
+import (
+	"context"
+	"errors"
+
+	"github.com/tamirat-dejene/ha-soranu/services/notification-service/internal/domain"
+	"google.golang.org/genproto/googleapis/rpc/errdetails"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// internalErrorMessage is what callers are told of errors they cannot act on.
+const internalErrorMessage = "An unexpected error occurred. Please try again later."
+
+// toGRPCError gives an error of the use cases the status code callers act
+// on. Other errors are reported as Internal, so that they do not leak
+// details of the database.
+func toGRPCError(err error) error {
+	if err == nil {
+		return nil
+	}
+	if _, ok := status.FromError(err); ok {
+		return err
+	}
+
+	switch {
+	case errors.Is(err, domain.ErrInvalidRequest):
+		return status.Error(codes.InvalidArgument, err.Error())
+	case errors.Is(err, domain.ErrNotificationNotFound):
+		return status.Error(codes.NotFound, err.Error())
+	case errors.Is(err, domain.ErrPermissionDenied):
+		return status.Error(codes.PermissionDenied, err.Error())
+	case errors.Is(err, context.DeadlineExceeded):
+		return status.Error(codes.DeadlineExceeded, err.Error())
+	case errors.Is(err, context.Canceled):
+		return status.Error(codes.Canceled, err.Error())
+	}
+
+	return status.Error(codes.Internal, internalErrorMessage)
+}
+
+// requiredFieldsError reports a request missing the given fields as
+// InvalidArgument, with the fields in a BadRequest detail.
+func requiredFieldsError(fields ...string) error {
+	st := status.New(codes.InvalidArgument, domain.ErrInvalidRequest.Error())
+
+	badRequest := &errdetails.BadRequest{}
+	for _, field := range fields {
+		badRequest.FieldViolations = append(badRequest.FieldViolations, &errdetails.BadRequest_FieldViolation{
+			Field:       field,
+			Description: field + " is required",
+		})
+	}
+
+	detailed, err := st.WithDetails(badRequest)
+	if err != nil {
+		return st.Err()
+	}
+	return detailed.Err()
+}
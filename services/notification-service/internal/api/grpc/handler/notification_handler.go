@@ -26,13 +26,20 @@ func NewNotificationHandler(server *grpc.Server, usecase domain.NotificationUseC
 func (h *notificationHandler) GetNotifications(ctx context.Context, req *notificationpb.GetNotificationsRequest) (*notificationpb.GetNotificationsResponse, error) {
 	if req == nil || req.RecipientId == "" || req.RecipientType == "" {
 		logger.Error("invalid request")
-		return nil, domain.ErrInvalidRequest
+		var missing []string
+		if req.GetRecipientId() == "" {
+			missing = append(missing, "recipient_id")
+		}
+		if req.GetRecipientType() == "" {
+			missing = append(missing, "recipient_type")
+		}
+		return nil, requiredFieldsError(missing...)
 	}
 
 	notifications, err := h.usecase.GetNotifications(ctx, req.RecipientId, req.RecipientType)
 	if err != nil {
 		logger.Error("failed to get notifications", zap.Error(err))
-		return nil, err
+		return nil, toGRPCError(err)
 	}
 
 	var notificationProtos []*notificationpb.Notification
@@ -58,13 +65,13 @@ func (h *notificationHandler) GetNotifications(ctx context.Context, req *notific
 func (h *notificationHandler) MarkAsRead(ctx context.Context, req *notificationpb.MarkAsReadRequest) (*notificationpb.MarkAsReadResponse, error) {
 	if req == nil || req.NotificationId == "" {
 		logger.Error("invalid request")
-		return nil, domain.ErrInvalidRequest
+		return nil, requiredFieldsError("notification_id")
 	}
 
 	err := h.usecase.MarkAsRead(ctx, req.NotificationId)
 	if err != nil {
 		logger.Error("failed to mark notification as read", zap.Error(err))
-		return &notificationpb.MarkAsReadResponse{Success: false}, err
+		return &notificationpb.MarkAsReadResponse{Success: false}, toGRPCError(err)
 	}
 
 	return &notificationpb.MarkAsReadResponse{Success: true}, nil
@@ -73,13 +80,13 @@ func (h *notificationHandler) MarkAsRead(ctx context.Context, req *notificationp
 func (h *notificationHandler) DeleteNotification(ctx context.Context, req *notificationpb.DeleteNotificationRequest) (*notificationpb.DeleteNotificationResponse, error) {
 	if req == nil || req.NotificationId == "" {
 		logger.Error("invalid request")
-		return nil, domain.ErrInvalidRequest
+		return nil, requiredFieldsError("notification_id")
 	}
 
 	err := h.usecase.DeleteNotification(ctx, req.NotificationId)
 	if err != nil {
 		logger.Error("failed to delete notification", zap.Error(err))
-		return &notificationpb.DeleteNotificationResponse{Success: false}, err
+		return &notificationpb.DeleteNotificationResponse{Success: false}, toGRPCError(err)
 	}
 
 	return &notificationpb.DeleteNotificationResponse{Success: true}, nil
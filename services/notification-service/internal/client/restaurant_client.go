@@ -0,0 +1,61 @@
+package client
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/tamirat-dejene/ha-soranu/services/notification-service/internal/domain"
+	"github.com/tamirat-dejene/ha-soranu/shared/pkg/auth/svcauth"
+	"github.com/tamirat-dejene/ha-soranu/shared/pkg/correlation"
+	"github.com/tamirat-dejene/ha-soranu/shared/pkg/logger"
+	"github.com/tamirat-dejene/ha-soranu/shared/protos/restaurantpb"
+	"go.uber.org/zap"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/credentials/insecure"
+	"google.golang.org/grpc/status"
+)
+
+// RestaurantServiceClient talks to the restaurant service.
+type RestaurantServiceClient struct {
+	client restaurantpb.RestaurantServiceClient
+	conn   *grpc.ClientConn
+}
+
+var _ domain.RestaurantOwners = (*RestaurantServiceClient)(nil)
+
+func NewRestaurantServiceClient(addr string, signer *svcauth.Signer) (*RestaurantServiceClient, error) {
+	opts := append([]grpc.DialOption{grpc.WithTransportCredentials(insecure.NewCredentials())}, signer.DialOptions()...)
+	opts = append(opts, correlation.DialOptions()...)
+	conn, err := grpc.NewClient(addr, opts...)
+	if err != nil {
+		return nil, fmt.Errorf("failed to connect to restaurant service: %w", err)
+	}
+
+	return &RestaurantServiceClient{
+		client: restaurantpb.NewRestaurantServiceClient(conn),
+		conn:   conn,
+	}, nil
+}
+
+// RestaurantOwner implements [domain.RestaurantOwners].
+func (c *RestaurantServiceClient) RestaurantOwner(ctx context.Context, restaurantID string) (string, error) {
+	resp, err := c.client.GetRestaurant(ctx, &restaurantpb.GetRestaurantRequest{RestaurantId: restaurantID})
+	if status.Code(err) == codes.NotFound {
+		return "", nil
+	}
+	if err != nil {
+		return "", err
+	}
+
+	return resp.GetOwnerId(), nil
+}
+
+// Close the gRPC connection
+func (c *RestaurantServiceClient) Close() {
+	if c.conn != nil {
+		if err := c.conn.Close(); err != nil {
+			logger.Error("failed to close gRPC connection", zap.Error(err))
+		}
+	}
+}
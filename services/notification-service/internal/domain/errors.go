@@ -3,5 +3,7 @@ package domain
 import "errors"
 
 var (
-	ErrInvalidRequest = errors.New("invalid request")
+	ErrInvalidRequest       = errors.New("invalid request")
+	ErrNotificationNotFound = errors.New("notification not found")
+	ErrPermissionDenied     = errors.New("permission denied")
 )
@@ -17,13 +17,34 @@ type Notification struct {
 	CreatedAt     time.Time
 }
 
+// Recipient types of notifications.
+const (
+	RecipientUser       = "USER"
+	RecipientRestaurant = "RESTAURANT"
+)
+
 type NotificationRepository interface {
 	CreateNotification(ctx context.Context, notification *Notification) error
+	// GetNotification returns the notification, or ErrNotificationNotFound.
+	GetNotification(ctx context.Context, notificationID string) (*Notification, error)
 	GetNotifications(ctx context.Context, recipientID string, recipientType string) ([]*Notification, error)
-	MarkAsRead(ctx context.Context, notificationID string) error
-	DeleteNotification(ctx context.Context, notificationID string) error
+	// MarkAsRead and DeleteNotification only act on a notification of the
+	// recipient, and fail with ErrNotificationNotFound otherwise.
+	MarkAsRead(ctx context.Context, notificationID, recipientID, recipientType string) error
+	DeleteNotification(ctx context.Context, notificationID, recipientID, recipientType string) error
+	DeleteRecipientNotifications(ctx context.Context, recipientID string, recipientType string) (int, error)
+}
+
+// RestaurantOwners looks up who owns the restaurants notifications are for.
+type RestaurantOwners interface {
+	// RestaurantOwner returns the ID of the user owning the restaurant, or ""
+	// when it has none or does not exist.
+	RestaurantOwner(ctx context.Context, restaurantID string) (string, error)
 }
 
+// NotificationUseCase serves notifications to their recipients. Calls made
+// for a user fail with ErrPermissionDenied unless the user is the recipient,
+// owns the recipient restaurant or is an admin.
 type NotificationUseCase interface {
 	StartConsumer(ctx context.Context) error
 	GetNotifications(ctx context.Context, recipientID string, recipientType string) ([]*Notification, error)
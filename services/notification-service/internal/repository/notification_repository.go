@@ -2,9 +2,12 @@ package repository
 
 import (
 	"context"
+	"errors"
 	"time"
 
 	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgconn"
 	"github.com/tamirat-dejene/ha-soranu/services/notification-service/internal/domain"
 	postgres "github.com/tamirat-dejene/ha-soranu/shared/db/pg"
 	"github.com/tamirat-dejene/ha-soranu/shared/pkg/logger"
@@ -47,6 +50,39 @@ func (r *notificationRepository) CreateNotification(ctx context.Context, notific
 	return nil
 }
 
+// GetNotification implements [domain.NotificationRepository].
+func (r *notificationRepository) GetNotification(ctx context.Context, notificationID string) (*domain.Notification, error) {
+	query := `
+		SELECT id, recipient_id, recipient_type, order_id, title, message, is_read, type, created_at
+		FROM notifications
+		WHERE id = $1
+	`
+
+	var n domain.Notification
+	err := r.db.QueryRow(ctx, query, notificationID).Scan(
+		&n.ID,
+		&n.RecipientID,
+		&n.RecipientType,
+		&n.OrderID,
+		&n.Title,
+		&n.Message,
+		&n.IsRead,
+		&n.Type,
+		&n.CreatedAt,
+	)
+	if err != nil {
+		var pgErr *pgconn.PgError
+		// 22P02: the ID is not a UUID, so it names no notification.
+		if errors.Is(err, pgx.ErrNoRows) || (errors.As(err, &pgErr) && pgErr.Code == "22P02") {
+			return nil, domain.ErrNotificationNotFound
+		}
+		logger.Error("failed to get notification", zap.Error(err))
+		return nil, err
+	}
+
+	return &n, nil
+}
+
 func (r *notificationRepository) GetNotifications(ctx context.Context, recipientID string, recipientType string) ([]*domain.Notification, error) {
 	logger.Info("Fetching notifications", zap.String("recipient_id", recipientID), zap.String("recipient_type", recipientType))
 	query := `
@@ -87,26 +123,43 @@ func (r *notificationRepository) GetNotifications(ctx context.Context, recipient
 	return notifications, nil
 }
 
-func (r *notificationRepository) MarkAsRead(ctx context.Context, notificationID string) error {
-	query := `UPDATE notifications SET is_read = true WHERE id = $1`
+func (r *notificationRepository) MarkAsRead(ctx context.Context, notificationID, recipientID, recipientType string) error {
+	query := `UPDATE notifications SET is_read = true WHERE id = $1 AND recipient_id = $2 AND recipient_type = $3`
 
-	_, err := r.db.Exec(ctx, query, notificationID)
+	rowsAffected, err := r.db.Exec(ctx, query, notificationID, recipientID, recipientType)
 	if err != nil {
 		logger.Error("failed to mark notification as read", zap.Error(err))
 		return err
 	}
+	if rowsAffected == 0 {
+		return domain.ErrNotificationNotFound
+	}
 
 	return nil
 }
 
-func (r *notificationRepository) DeleteNotification(ctx context.Context, notificationID string) error {
-	query := `DELETE FROM notifications WHERE id = $1`
+func (r *notificationRepository) DeleteNotification(ctx context.Context, notificationID, recipientID, recipientType string) error {
+	query := `DELETE FROM notifications WHERE id = $1 AND recipient_id = $2 AND recipient_type = $3`
 
-	_, err := r.db.Exec(ctx, query, notificationID)
+	rowsAffected, err := r.db.Exec(ctx, query, notificationID, recipientID, recipientType)
 	if err != nil {
 		logger.Error("failed to delete notification", zap.Error(err))
 		return err
 	}
+	if rowsAffected == 0 {
+		return domain.ErrNotificationNotFound
+	}
 
 	return nil
-}
\ No newline at end of file
+}
+func (r *notificationRepository) DeleteRecipientNotifications(ctx context.Context, recipientID string, recipientType string) (int, error) {
+	query := `DELETE FROM notifications WHERE recipient_id = $1 AND recipient_type = $2`
+
+	rows_affected, err := r.db.Exec(ctx, query, recipientID, recipientType)
+	if err != nil {
+		logger.Error("failed to delete recipient notifications", zap.Error(err))
+		return 0, err
+	}
+
+	return rows_affected, nil
+}
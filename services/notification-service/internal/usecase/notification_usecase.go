@@ -2,34 +2,43 @@ package usecase
 
 import (
 	"context"
+	"encoding/json"
 	"fmt"
 	"time"
 
 	"github.com/tamirat-dejene/ha-soranu/services/notification-service/internal/domain"
+	"github.com/tamirat-dejene/ha-soranu/shared/pkg/auth/svcauth"
 	"github.com/tamirat-dejene/ha-soranu/shared/pkg/events"
 	"github.com/tamirat-dejene/ha-soranu/shared/pkg/logger"
 	"github.com/tamirat-dejene/ha-soranu/shared/pkg/messaging/kafka"
 	envent_envelope "github.com/tamirat-dejene/ha-soranu/shared/protos/envent_envelopepb"
 	"github.com/tamirat-dejene/ha-soranu/shared/protos/orderpb"
+	"github.com/tamirat-dejene/ha-soranu/shared/protos/userdatapb"
 	"go.uber.org/zap"
 	"google.golang.org/protobuf/proto"
 )
 
 type notificationUseCase struct {
-	repo     domain.NotificationRepository
-	consumer kafka.Consumer
-	timeout  time.Duration
+	repo        domain.NotificationRepository
+	publisher   events.EventPublisher
+	consumer    kafka.Consumer
+	restaurants domain.RestaurantOwners
+	timeout     time.Duration
 }
 
 func NewNotificationUseCase(
 	repo domain.NotificationRepository,
+	publisher events.EventPublisher,
 	consumer kafka.Consumer,
+	restaurants domain.RestaurantOwners,
 	timeout time.Duration,
 ) domain.NotificationUseCase {
 	return &notificationUseCase{
-		repo:     repo,
-		consumer: consumer,
-		timeout:  timeout,
+		repo:        repo,
+		publisher:   publisher,
+		consumer:    consumer,
+		restaurants: restaurants,
+		timeout:     timeout,
 	}
 }
 
@@ -39,15 +48,19 @@ func (uc *notificationUseCase) StartConsumer(ctx context.Context) error {
 	return uc.consumer.Subscribe(ctx, []string{
 		events.OrderPlacedEvent,
 		events.OrderStatusUpdatedEvent,
+		events.UserDeletionRequestedEvent,
+		events.UserExportRequestedEvent,
 	}, func(msgCtx context.Context, msg *kafka.Message) error {
+		log := logger.FromContext(msgCtx)
+
 		// 1. Unmarshal envelope using binary protobuf
 		var envelope envent_envelope.EventEnvelope
 		if err := proto.Unmarshal(msg.Value, &envelope); err != nil {
-			logger.Error("failed to unmarshal event envelope", zap.Error(err))
+			log.Error("failed to unmarshal event envelope", zap.Error(err))
 			return err
 		}
 
-		logger.Info("Received event",
+		log.Info("Received event",
 			zap.String("event_type", envelope.EventType),
 			zap.String("event_id", envelope.EventId),
 			zap.String("message_key", string(msg.Key)))
@@ -58,22 +71,26 @@ func (uc *notificationUseCase) StartConsumer(ctx context.Context) error {
 			return uc.handleOrderPlaced(msgCtx, &envelope)
 		case events.OrderStatusUpdatedEvent:
 			return uc.handleOrderStatusUpdated(msgCtx, &envelope)
+		case events.UserDeletionRequestedEvent, events.UserExportRequestedEvent:
+			return uc.handleUserDataRequested(msgCtx, &envelope)
 		default:
-			logger.Warn("unknown event type", zap.String("event_type", envelope.EventType))
+			log.Warn("unknown event type", zap.String("event_type", envelope.EventType))
 			return nil
 		}
 	})
 }
 
 func (uc *notificationUseCase) handleOrderPlaced(ctx context.Context, envelope *envent_envelope.EventEnvelope) error {
+	log := logger.FromContext(ctx)
+
 	// Unmarshal payload using binary protobuf
 	var orderCreated orderpb.OrderCreated
 	if err := proto.Unmarshal(envelope.Payload, &orderCreated); err != nil {
-		logger.Error("failed to unmarshal OrderCreated event", zap.Error(err))
+		log.Error("failed to unmarshal OrderCreated event", zap.Error(err))
 		return err
 	}
 
-	logger.Info("Processing OrderPlaced event",
+	log.Info("Processing OrderPlaced event",
 		zap.String("order_id", orderCreated.OrderId),
 		zap.String("customer_id", orderCreated.CustomerId),
 		zap.String("restaurant_id", orderCreated.RestaurantId))
@@ -81,7 +98,7 @@ func (uc *notificationUseCase) handleOrderPlaced(ctx context.Context, envelope *
 	// Create notification for restaurant
 	notification := &domain.Notification{
 		RecipientID:   orderCreated.RestaurantId,
-		RecipientType: "RESTAURANT",
+		RecipientType: domain.RecipientRestaurant,
 		OrderID:       orderCreated.OrderId,
 		Title:         "New Order Received",
 		Message:       fmt.Sprintf("You have a new order #%s for $%.2f", orderCreated.OrderId[:8], orderCreated.TotalAmount),
@@ -90,7 +107,7 @@ func (uc *notificationUseCase) handleOrderPlaced(ctx context.Context, envelope *
 	}
 
 	if err := uc.repo.CreateNotification(ctx, notification); err != nil {
-		logger.Error("failed to create notification for restaurant", zap.Error(err))
+		log.Error("failed to create notification for restaurant", zap.Error(err))
 		return err
 	}
 
@@ -98,14 +115,16 @@ func (uc *notificationUseCase) handleOrderPlaced(ctx context.Context, envelope *
 }
 
 func (uc *notificationUseCase) handleOrderStatusUpdated(ctx context.Context, envelope *envent_envelope.EventEnvelope) error {
+	log := logger.FromContext(ctx)
+
 	// Unmarshal payload using binary protobuf
 	var orderStatusUpdated orderpb.OrderStatusUpdated
 	if err := proto.Unmarshal(envelope.Payload, &orderStatusUpdated); err != nil {
-		logger.Error("failed to unmarshal OrderStatusUpdated event", zap.Error(err))
+		log.Error("failed to unmarshal OrderStatusUpdated event", zap.Error(err))
 		return err
 	}
 
-	logger.Info("Processing OrderStatusUpdated event",
+	log.Info("Processing OrderStatusUpdated event",
 		zap.String("order_id", orderStatusUpdated.OrderId),
 		zap.String("customer_id", orderStatusUpdated.CustomerId),
 		zap.String("new_status", orderStatusUpdated.NewStatus.String()))
@@ -113,7 +132,7 @@ func (uc *notificationUseCase) handleOrderStatusUpdated(ctx context.Context, env
 	// Create notification for customer
 	notification := &domain.Notification{
 		RecipientID:   orderStatusUpdated.CustomerId,
-		RecipientType: "USER",
+		RecipientType: domain.RecipientUser,
 		OrderID:       orderStatusUpdated.OrderId,
 		Title:         "Order Status Updated",
 		Message:       fmt.Sprintf("Your order status has been updated to: %s", orderStatusUpdated.NewStatus.String()),
@@ -122,17 +141,92 @@ func (uc *notificationUseCase) handleOrderStatusUpdated(ctx context.Context, env
 	}
 
 	if err := uc.repo.CreateNotification(ctx, notification); err != nil {
-		logger.Error("failed to create notification for user", zap.Error(err))
+		log.Error("failed to create notification for user", zap.Error(err))
 		return err
 	}
 
 	return nil
 }
 
+func (uc *notificationUseCase) handleUserDataRequested(ctx context.Context, envelope *envent_envelope.EventEnvelope) error {
+	log := logger.FromContext(ctx)
+
+	// Unmarshal payload using binary protobuf
+	var requested userdatapb.UserDataRequested
+	if err := proto.Unmarshal(envelope.Payload, &requested); err != nil {
+		log.Error("failed to unmarshal UserDataRequested event", zap.Error(err))
+		return err
+	}
+
+	log.Info("Processing user data request",
+		zap.String("event_type", envelope.EventType),
+		zap.String("request_id", requested.RequestId),
+		zap.String("user_id", requested.UserId))
+
+	c, cancel := context.WithTimeout(ctx, uc.timeout)
+	defer cancel()
+
+	processed := &userdatapb.UserDataProcessed{
+		RequestId: requested.RequestId,
+		UserId:    requested.UserId,
+		Service:   events.UserDataServiceNotification,
+	}
+
+	var err error
+	if envelope.EventType == events.UserDeletionRequestedEvent {
+		var deleted int
+		deleted, err = uc.repo.DeleteRecipientNotifications(c, requested.UserId, domain.RecipientUser)
+		if err == nil {
+			log.Info("Deleted user notifications", zap.String("user_id", requested.UserId), zap.Int("count", deleted))
+		}
+	} else {
+		var notifications []*domain.Notification
+		notifications, err = uc.repo.GetNotifications(c, requested.UserId, domain.RecipientUser)
+		if err == nil {
+			processed.Data, err = json.Marshal(map[string]any{"notifications": toNotificationExports(notifications)})
+		}
+	}
+	if err != nil {
+		log.Error("failed to process user data request", zap.String("request_id", requested.RequestId), zap.Error(err))
+		processed.Error = err.Error()
+	}
+
+	processed.ProcessedAtUnix = time.Now().Unix()
+	return uc.publisher.PublishUserDataProcessed(ctx, processed)
+}
+
+type notificationExport struct {
+	OrderID   string    `json:"order_id"`
+	Title     string    `json:"title"`
+	Message   string    `json:"message"`
+	IsRead    bool      `json:"is_read"`
+	Type      string    `json:"type"`
+	CreatedAt time.Time `json:"created_at"`
+}
+
+func toNotificationExports(notifications []*domain.Notification) []notificationExport {
+	result := make([]notificationExport, 0, len(notifications))
+	for _, n := range notifications {
+		result = append(result, notificationExport{
+			OrderID:   n.OrderID,
+			Title:     n.Title,
+			Message:   n.Message,
+			IsRead:    n.IsRead,
+			Type:      n.Type,
+			CreatedAt: n.CreatedAt,
+		})
+	}
+	return result
+}
+
 func (uc *notificationUseCase) GetNotifications(ctx context.Context, recipientID string, recipientType string) ([]*domain.Notification, error) {
 	c, cancel := context.WithTimeout(ctx, uc.timeout)
 	defer cancel()
 
+	if err := uc.authorizeRecipient(c, recipientID, recipientType); err != nil {
+		return nil, err
+	}
+
 	return uc.repo.GetNotifications(c, recipientID, recipientType)
 }
 
@@ -140,12 +234,62 @@ func (uc *notificationUseCase) MarkAsRead(ctx context.Context, notificationID st
 	c, cancel := context.WithTimeout(ctx, uc.timeout)
 	defer cancel()
 
-	return uc.repo.MarkAsRead(c, notificationID)
+	n, err := uc.authorizeNotification(c, notificationID)
+	if err != nil {
+		return err
+	}
+
+	return uc.repo.MarkAsRead(c, n.ID, n.RecipientID, n.RecipientType)
 }
 
 func (uc *notificationUseCase) DeleteNotification(ctx context.Context, notificationID string) error {
 	c, cancel := context.WithTimeout(ctx, uc.timeout)
 	defer cancel()
 
-	return uc.repo.DeleteNotification(c, notificationID)
-}
\ No newline at end of file
+	n, err := uc.authorizeNotification(c, notificationID)
+	if err != nil {
+		return err
+	}
+
+	return uc.repo.DeleteNotification(c, n.ID, n.RecipientID, n.RecipientType)
+}
+
+// authorizeNotification returns the notification, provided the call may act
+// for its recipient.
+func (uc *notificationUseCase) authorizeNotification(ctx context.Context, notificationID string) (*domain.Notification, error) {
+	n, err := uc.repo.GetNotification(ctx, notificationID)
+	if err != nil {
+		return nil, err
+	}
+	if err := uc.authorizeRecipient(ctx, n.RecipientID, n.RecipientType); err != nil {
+		return nil, err
+	}
+	return n, nil
+}
+
+// authorizeRecipient fails with domain.ErrPermissionDenied unless the user of
+// the call is the recipient, owns the recipient restaurant or is an admin.
+func (uc *notificationUseCase) authorizeRecipient(ctx context.Context, recipientID, recipientType string) error {
+	switch recipientType {
+	case domain.RecipientUser:
+		if !svcauth.CanActFor(ctx, recipientID) {
+			return domain.ErrPermissionDenied
+		}
+		return nil
+	case domain.RecipientRestaurant:
+		// Admins and services calling on their own behalf need no lookup.
+		if svcauth.CanActFor(ctx, "") {
+			return nil
+		}
+		ownerID, err := uc.restaurants.RestaurantOwner(ctx, recipientID)
+		if err != nil {
+			return err
+		}
+		if !svcauth.CanActFor(ctx, ownerID) {
+			return domain.ErrPermissionDenied
+		}
+		return nil
+	default:
+		return fmt.Errorf("%w: unknown recipient type %q", domain.ErrInvalidRequest, recipientType)
+	}
+}
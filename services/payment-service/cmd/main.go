@@ -10,6 +10,8 @@ import (
 	_ "github.com/jackc/pgx/v5/stdlib"
 	"github.com/stripe/stripe-go/v78"
 	postgres "github.com/tamirat-dejene/ha-soranu/shared/db/pg"
+	"github.com/tamirat-dejene/ha-soranu/shared/pkg/auth/svcauth"
+	"github.com/tamirat-dejene/ha-soranu/shared/pkg/correlation"
 	"github.com/tamirat-dejene/ha-soranu/shared/pkg/logger"
 	"go.uber.org/zap"
 	"google.golang.org/grpc"
@@ -76,7 +78,15 @@ func main() {
 		logger.Fatal("failed to listen", zap.Error(err))
 	}
 
-	s := grpc.NewServer()
+	// Only the services listed in SERVICE_AUTH_PUBLIC_KEYS may call.
+	callVerifier, err := svcauth.NewVerifier(env.ServiceAuthPublicKeys)
+	if err != nil {
+		logger.Fatal("invalid service auth public keys", zap.Error(err))
+	}
+	s := grpc.NewServer(
+		grpc.ChainUnaryInterceptor(correlation.UnaryServerInterceptor(), callVerifier.UnaryServerInterceptor()),
+		grpc.ChainStreamInterceptor(correlation.StreamServerInterceptor(), callVerifier.StreamServerInterceptor()),
+	)
 	logger.Info("Payment gRPC server listening", zap.String("port", env.PAYMENT_SRV_PORT))
 	if err := s.Serve(lis); err != nil {
 		logger.Fatal("failed to serve", zap.Error(err))
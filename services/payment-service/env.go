@@ -32,6 +32,11 @@ type Env struct {
 
 	// Kafka settings (for publishing order status updates)
 	KafkaBroker string `mapstructure:"KAFKA_BROKER_URL"`
+
+	// ServiceAuthPublicKeys lists the services allowed to call this one as
+	// service=key pairs, each key the base64-encoded PEM Ed25519 public key
+	// of the service.
+	ServiceAuthPublicKeys string `mapstructure:"SERVICE_AUTH_PUBLIC_KEYS"`
 }
 
 func getString(key string, defaultValue string) string {
@@ -57,22 +62,23 @@ func getInt(key string, defaultValue int) int {
 
 func GetEnv() (*Env, error) {
 	env := Env{
-		SRV_ENV:             getString("SRV_ENV", "development"),
-		PAYMENT_SRV_NAME:    getString("PAYMENT_SRV_NAME", "payment-service"),
-		PAYMENT_SRV_PORT:    getString("PAYMENT_SRV_PORT", "9090"),
-		PAYMENT_HTTP_PORT:   getString("PAYMENT_HTTP_PORT", "8081"),
-		DBHost:              getString("POSTGRES_HOST", "postgres-db"),
-		DBPort:              getString("POSTGRES_PORT", "5432"),
-		DBUser:              getString("POSTGRES_USER", "postgres"),
-		DBPassword:          getString("POSTGRES_PASSWORD", "password"),
-		DBName:              getString("POSTGRES_DB", "payment-servicedb"),
-		RedisHOST:           getString("REDIS_HOST", "localhost"),
-		RedisPort:           getInt("REDIS_PORT", 6379),
-		RedisPassword:       getString("REDIS_PASSWORD", ""),
-		RedisDB:             getInt("REDIS_DB", 0),
-		StripeSecretKey:     getString("STRIPE_SECRET_KEY", ""),
-		StripeWebhookSecret: getString("STRIPE_WEBHOOK_SECRET", ""),
-		KafkaBroker:         getString("KAFKA_BROKER_URL", "localhost:9092"),
+		SRV_ENV:               getString("SRV_ENV", "development"),
+		PAYMENT_SRV_NAME:      getString("PAYMENT_SRV_NAME", "payment-service"),
+		PAYMENT_SRV_PORT:      getString("PAYMENT_SRV_PORT", "9090"),
+		PAYMENT_HTTP_PORT:     getString("PAYMENT_HTTP_PORT", "8081"),
+		DBHost:                getString("POSTGRES_HOST", "postgres-db"),
+		DBPort:                getString("POSTGRES_PORT", "5432"),
+		DBUser:                getString("POSTGRES_USER", "postgres"),
+		DBPassword:            getString("POSTGRES_PASSWORD", "password"),
+		DBName:                getString("POSTGRES_DB", "payment-servicedb"),
+		RedisHOST:             getString("REDIS_HOST", "localhost"),
+		RedisPort:             getInt("REDIS_PORT", 6379),
+		RedisPassword:         getString("REDIS_PASSWORD", ""),
+		RedisDB:               getInt("REDIS_DB", 0),
+		StripeSecretKey:       getString("STRIPE_SECRET_KEY", ""),
+		StripeWebhookSecret:   getString("STRIPE_WEBHOOK_SECRET", ""),
+		KafkaBroker:           getString("KAFKA_BROKER_URL", "localhost:9092"),
+		ServiceAuthPublicKeys: getString("SERVICE_AUTH_PUBLIC_KEYS", ""),
 	}
 	return &env, nil
 }
@@ -7,6 +7,9 @@ import (
 	"time"
 
 	postgres "github.com/tamirat-dejene/ha-soranu/shared/db/pg"
+	"github.com/tamirat-dejene/ha-soranu/shared/pkg/auth/svcauth"
+	"github.com/tamirat-dejene/ha-soranu/shared/pkg/caching/valkey"
+	"github.com/tamirat-dejene/ha-soranu/shared/pkg/correlation"
 	"github.com/tamirat-dejene/ha-soranu/shared/pkg/events"
 	"github.com/tamirat-dejene/ha-soranu/shared/pkg/logger"
 	"github.com/tamirat-dejene/ha-soranu/shared/pkg/messaging/kafka/sarama"
@@ -15,6 +18,8 @@ import (
 
 	svc "github.com/tamirat-dejene/ha-soranu/services/restaurant-service"
 	"github.com/tamirat-dejene/ha-soranu/services/restaurant-service/internal/api/grpc/handler"
+	"github.com/tamirat-dejene/ha-soranu/services/restaurant-service/internal/client"
+	"github.com/tamirat-dejene/ha-soranu/services/restaurant-service/internal/domain"
 	"github.com/tamirat-dejene/ha-soranu/services/restaurant-service/internal/repository"
 	"github.com/tamirat-dejene/ha-soranu/services/restaurant-service/internal/usecase"
 	"github.com/tamirat-dejene/ha-soranu/services/restaurant-service/migrations"
@@ -54,7 +59,20 @@ func main() {
 	if err != nil {
 		logger.Fatal("failed to listen", zap.Error(err))
 	}
-	s := grpc.NewServer()
+	// Only the services listed in SERVICE_AUTH_PUBLIC_KEYS may call, and
+	// calls to the other services are signed with SERVICE_AUTH_PRIVATE_KEY.
+	callVerifier, err := svcauth.NewVerifier(env.ServiceAuthPublicKeys)
+	if err != nil {
+		logger.Fatal("invalid service auth public keys", zap.Error(err))
+	}
+	callSigner, err := svcauth.NewSigner(env.RESTAURANT_SRV_NAME, env.ServiceAuthPrivateKey)
+	if err != nil {
+		logger.Fatal("invalid service auth private key", zap.Error(err))
+	}
+	s := grpc.NewServer(
+		grpc.ChainUnaryInterceptor(correlation.UnaryServerInterceptor(), callVerifier.UnaryServerInterceptor()),
+		grpc.ChainStreamInterceptor(correlation.StreamServerInterceptor(), callVerifier.StreamServerInterceptor()),
+	)
 
 	// 6. Initialize sarama producer
 	producer, err := sarama.NewProducer([]string{env.KafkaBroker})
@@ -63,12 +81,89 @@ func main() {
 	}
 	defer producer.Close()
 
-	// 7. Initialize Repository, EventPublisher, Usecase, and register Handler
+	// 7. Connect to the user service for driver lookups
+	userClient, err := client.NewUserServiceClient(env.AUTH_SRV_NAME+":"+env.AUTH_SRV_PORT, callSigner)
+	if err != nil {
+		logger.Fatal("failed to connect to user service", zap.Error(err))
+	}
+	defer userClient.Close()
+
+	// 8. Initialize Valkey client for carts
+	valkeyClient, err := valkey.NewValkeyClient(env.ValkeyHOST, env.ValkeyPort, env.ValkeyUser, env.ValkeyPassword, env.ValkeyDB)
+	if err != nil {
+		logger.Fatal("failed to connect to Valkey", zap.Error(err))
+	}
+	{
+		ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+		defer cancel()
+		if err := valkeyClient.Ping(ctx); err != nil {
+			logger.Fatal("failed to ping Valkey", zap.Error(err))
+		}
+	}
+	defer valkeyClient.Close()
+
+	// 9. Initialize sarama consumer for the order feed. Every replica serves
+	// its own watchers, so each one reads all events, outside any group.
+	consumer, err := sarama.NewBroadcastConsumer([]string{env.KafkaBroker})
+	if err != nil {
+		logger.Fatal("failed to create kafka consumer", zap.Error(err))
+	}
+	defer consumer.Close()
+
+	// User data requests are handled once per request, by any replica.
+	userDataConsumer, err := sarama.NewConsumer([]string{env.KafkaBroker}, env.RESTAURANT_SRV_CONSUMER_GROUP)
+	if err != nil {
+		logger.Fatal("failed to create kafka consumer", zap.Error(err))
+	}
+	defer userDataConsumer.Close()
+
+	// 10. Initialize Repository, EventPublisher, Usecase, and register Handler
 	restaurant_repo := repository.NewRestaurantRepository(pgClient)
 	event_publisher := events.NewEventPublisher(producer)
-	restaurant_usecase := usecase.NewRestaurantUseCase(restaurant_repo, event_publisher, 10*time.Second)
+	restaurant_usecase := usecase.NewRestaurantUseCase(restaurant_repo, event_publisher, userClient, 10*time.Second)
+
+	dispatch_repo := repository.NewDispatchRepository(pgClient)
+	dispatcher := usecase.NewDispatchUseCase(
+		dispatch_repo,
+		restaurant_repo,
+		userClient,
+		event_publisher,
+		float32(env.DispatchRadiusKm),
+		domain.OfferPolicy{
+			TTL:          time.Duration(env.DispatchOfferTTLSeconds) * time.Second,
+			ReofferAfter: time.Duration(env.DispatchReofferAfterSeconds) * time.Second,
+			MaxAttempts:  env.DispatchMaxAttempts,
+		},
+		time.Duration(env.DispatchSweepIntervalSeconds)*time.Second,
+		10*time.Second,
+	)
+
+	order_feed_repo := repository.NewOrderFeedRepository(pgClient)
+	order_feed := usecase.NewOrderFeedUseCase(order_feed_repo, restaurant_repo, consumer, 10*time.Second)
+
+	cart_repo := repository.NewCartRepository(valkeyClient, time.Duration(env.CartTTLMinutes)*time.Minute)
+	cart_usecase := usecase.NewCartUseCase(cart_repo, restaurant_repo, restaurant_usecase, 10*time.Second)
+
+	user_data_repo := repository.NewUserDataRepository(pgClient)
+	user_data := usecase.NewUserDataUseCase(user_data_repo, cart_repo, event_publisher, userDataConsumer, 10*time.Second)
+
+	handler.NewRestaurantHandler(s, restaurant_usecase, dispatcher, order_feed, cart_usecase)
+	handler.NewAdminHandler(s, usecase.NewAdminUseCase(restaurant_repo, user_data_repo, 10*time.Second))
 
-	handler.NewRestaurantHandler(s, restaurant_usecase)
+	// 11. Start the dispatch sweeper, the order feed and the user data consumers
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	go dispatcher.Run(ctx)
+	go func() {
+		if err := order_feed.StartConsumer(ctx); err != nil && ctx.Err() == nil {
+			logger.Error("order feed consumer stopped", zap.Error(err))
+		}
+	}()
+	go func() {
+		if err := user_data.StartConsumer(ctx); err != nil && ctx.Err() == nil {
+			logger.Error("user data consumer stopped", zap.Error(err))
+		}
+	}()
 
 	logger.Info("Service listening", zap.String("port", env.RESTAURANT_SRV_PORT))
 	if err := s.Serve(lis); err != nil {
@@ -25,9 +25,39 @@ type Env struct {
 	RedisPassword string `mapstructure:"REDIS_PASSWORD"`
 	RedisDB       int    `mapstructure:"REDIS_DB"`
 
+	// Valkey settings
+	ValkeyHOST     string `mapstructure:"VALKEY_HOST"`
+	ValkeyPort     int    `mapstructure:"VALKEY_PORT"`
+	ValkeyUser     string `mapstructure:"VALKEY_USER"`
+	ValkeyPassword string `mapstructure:"VALKEY_PASSWORD"`
+	ValkeyDB       int    `mapstructure:"VALKEY_DB"`
+
+	// Cart settings
+	CartTTLMinutes int `mapstructure:"CART_TTL_MINUTES"`
+
+	// Auth Service settings
+	AUTH_SRV_NAME string `mapstructure:"AUTH_SRV_NAME"`
+	AUTH_SRV_PORT string `mapstructure:"AUTH_SRV_PORT"`
+
+	// Dispatch settings
+	DispatchRadiusKm             int `mapstructure:"DISPATCH_RADIUS_KM"`
+	DispatchOfferTTLSeconds      int `mapstructure:"DISPATCH_OFFER_TTL_SECONDS"`
+	DispatchSweepIntervalSeconds int `mapstructure:"DISPATCH_SWEEP_INTERVAL_SECONDS"`
+	DispatchReofferAfterSeconds  int `mapstructure:"DISPATCH_REOFFER_AFTER_SECONDS"`
+	DispatchMaxAttempts          int `mapstructure:"DISPATCH_MAX_ATTEMPTS"`
+
 	// Kafka settings
 	KafkaBroker                   string `mapstructure:"KAFKA_BROKER_URL"`
 	RESTAURANT_SRV_CONSUMER_GROUP string `mapstructure:"RESTAURANT_SRV_CONSUMER_GROUP"`
+
+	// ServiceAuthPrivateKey is the Ed25519 private key (PEM or base64-encoded
+	// PEM) the service signs its gRPC calls to the other services with.
+	ServiceAuthPrivateKey string `mapstructure:"SERVICE_AUTH_PRIVATE_KEY"`
+
+	// ServiceAuthPublicKeys lists the services allowed to call this one as
+	// service=key pairs, each key the base64-encoded PEM Ed25519 public key
+	// of the service.
+	ServiceAuthPublicKeys string `mapstructure:"SERVICE_AUTH_PUBLIC_KEYS"`
 }
 
 func getString(key string, defaultValue string) string {
@@ -65,8 +95,23 @@ func GetEnv() (*Env, error) {
 		RedisPort:                     getInt("REDIS_PORT", 6379),
 		RedisPassword:                 getString("REDIS_PASSWORD", ""),
 		RedisDB:                       getInt("REDIS_DB", 0),
+		ValkeyHOST:                    getString("VALKEY_HOST", "localhost"),
+		ValkeyPort:                    getInt("VALKEY_PORT", 6379),
+		ValkeyUser:                    getString("VALKEY_USER", "default"),
+		ValkeyPassword:                getString("VALKEY_PASSWORD", "default-password"),
+		ValkeyDB:                      getInt("VALKEY_DB", 1),
+		CartTTLMinutes:                getInt("CART_TTL_MINUTES", 1440),
+		AUTH_SRV_NAME:                 getString("AUTH_SRV_NAME", "auth-service"),
+		AUTH_SRV_PORT:                 getString("AUTH_SRV_PORT", "9090"),
+		DispatchRadiusKm:              getInt("DISPATCH_RADIUS_KM", 10),
+		DispatchOfferTTLSeconds:       getInt("DISPATCH_OFFER_TTL_SECONDS", 60),
+		DispatchSweepIntervalSeconds:  getInt("DISPATCH_SWEEP_INTERVAL_SECONDS", 15),
+		DispatchReofferAfterSeconds:   getInt("DISPATCH_REOFFER_AFTER_SECONDS", 300),
+		DispatchMaxAttempts:           getInt("DISPATCH_MAX_ATTEMPTS", 40),
 		KafkaBroker:                   getString("KAFKA_BROKER_URL", "localhost:9092"),
 		RESTAURANT_SRV_CONSUMER_GROUP: getString("RESTAURANT_SRV_CONSUMER_GROUP", "restaurant-service-group"),
+		ServiceAuthPrivateKey:         getString("SERVICE_AUTH_PRIVATE_KEY", ""),
+		ServiceAuthPublicKeys:         getString("SERVICE_AUTH_PUBLIC_KEYS", ""),
 	}
 	return &env, nil
 }
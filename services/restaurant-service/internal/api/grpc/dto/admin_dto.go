@@ -0,0 +1,30 @@
+package dto
+
+import (
+	"github.com/tamirat-dejene/ha-soranu/services/restaurant-service/internal/domain"
+	"github.com/tamirat-dejene/ha-soranu/shared/protos/adminpb"
+	"google.golang.org/protobuf/types/known/timestamppb"
+)
+
+func ProtoRestaurantSearchToDomain(req *adminpb.SearchRestaurantsRequest) domain.RestaurantSearch {
+	return domain.RestaurantSearch{
+		Query:  req.Query,
+		Status: req.Status,
+		Limit:  int(req.Limit),
+		Offset: int(req.Offset),
+	}
+}
+
+func DomainRestaurantAccountToProto(r *domain.Restaurant) *adminpb.RestaurantAccount {
+	account := &adminpb.RestaurantAccount{
+		Restaurant: DomainRestaurantToProto(r),
+	}
+	if r.Suspension != nil {
+		account.Suspension = &adminpb.Suspension{
+			Reason:      r.Suspension.Reason,
+			SuspendedBy: r.Suspension.SuspendedBy,
+			SuspendedAt: timestamppb.New(r.Suspension.SuspendedAt),
+		}
+	}
+	return account
+}
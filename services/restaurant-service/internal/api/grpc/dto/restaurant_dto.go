@@ -14,6 +14,8 @@ func DomainRestaurantToProto(r *domain.Restaurant) *restaurantpb.Restaurant {
 		Latitude:     r.Latitude,
 		Longitude:    r.Longitude,
 		Menus:        toProtoMenuItems(r.MenuItems),
+		Status:       r.Status,
+		OwnerId:      r.OwnerID,
 	}
 }
 func toProtoMenuItems(items []domain.MenuItem) []*restaurantpb.MenuItem {
@@ -62,6 +64,114 @@ func DomainOrderToProto(order domain.Order) *restaurantpb.Order {
 	}
 }
 
+func DomainOrderEventToProto(event domain.OrderEvent) *restaurantpb.OrderFeedEvent {
+	var eventType restaurantpb.OrderFeedEvent_Type
+	switch event.Type {
+	case domain.ORDER_EVENT_CREATED:
+		eventType = restaurantpb.OrderFeedEvent_CREATED
+	case domain.ORDER_EVENT_STATUS_CHANGED:
+		eventType = restaurantpb.OrderFeedEvent_STATUS_CHANGED
+	default:
+		eventType = restaurantpb.OrderFeedEvent_SNAPSHOT
+	}
+
+	return &restaurantpb.OrderFeedEvent{
+		Type:           eventType,
+		Cursor:         event.Seq,
+		Order:          DomainOrderToProto(event.Order),
+		OccurredAtUnix: event.OccurredAt.Unix(),
+	}
+}
+
+func DomainCartToProto(cart *domain.Cart) *restaurantpb.Cart {
+	items := make([]*restaurantpb.CartItem, 0, len(cart.Items))
+	for _, item := range cart.Items {
+		items = append(items, &restaurantpb.CartItem{
+			ItemId:        item.ItemID,
+			Name:          item.Name,
+			Quantity:      item.Quantity,
+			UnitPrice:     item.UnitPrice,
+			Available:     item.Available,
+			PriceChanged:  item.PriceChanged,
+			PreviousPrice: item.PreviousPrice,
+		})
+	}
+
+	var expiresAt int64
+	if !cart.ExpiresAt.IsZero() {
+		expiresAt = cart.ExpiresAt.Unix()
+	}
+
+	return &restaurantpb.Cart{
+		CustomerId:    cart.CustomerID,
+		RestaurantId:  cart.RestaurantID,
+		Items:         items,
+		TotalAmount:   cart.TotalAmount,
+		Valid:         cart.Valid,
+		ExpiresAtUnix: expiresAt,
+	}
+}
+
+func DomainDeliveryOfferToProto(offer domain.DeliveryOffer) *restaurantpb.DeliveryOffer {
+	return &restaurantpb.DeliveryOffer{
+		OfferId:       offer.OfferID,
+		OrderId:       offer.OrderID,
+		RestaurantId:  offer.RestaurantID,
+		DriverId:      offer.DriverID,
+		Status:        offer.Status,
+		ExpiresAtUnix: offer.ExpiresAt.Unix(),
+	}
+}
+
+func DomainDispatchToShipOrderResponse(dispatch *domain.Dispatch) *restaurantpb.ShipOrderResponse {
+	resp := &restaurantpb.ShipOrderResponse{
+		ConfirmationMessage: "Order shipped successfully",
+		DriverId:            dispatch.DriverID,
+		DispatchStatus:      dispatch.Status,
+	}
+	if dispatch.Offer != nil && (dispatch.Status == domain.DISPATCH_STATUS_OFFERED || dispatch.Status == domain.DISPATCH_STATUS_ASSIGNED) {
+		resp.OfferId = dispatch.Offer.OfferID
+		resp.OfferExpiresAtUnix = dispatch.Offer.ExpiresAt.Unix()
+	}
+	return resp
+}
+
+func ProtoMenuFormatToDomain(format restaurantpb.MenuFormat) string {
+	switch format {
+	case restaurantpb.MenuFormat_CSV:
+		return domain.MENU_FORMAT_CSV
+	case restaurantpb.MenuFormat_JSON:
+		return domain.MENU_FORMAT_JSON
+	default:
+		return ""
+	}
+}
+
+func MenuFormatContentType(format restaurantpb.MenuFormat) string {
+	if format == restaurantpb.MenuFormat_JSON {
+		return "application/json"
+	}
+	return "text/csv"
+}
+
+func DomainMenuImportToProto(result *domain.MenuImport) *restaurantpb.ImportMenuResponse {
+	var errs []*restaurantpb.MenuImportError
+	for _, e := range result.Errors {
+		errs = append(errs, &restaurantpb.MenuImportError{
+			Line:    int32(e.Line),
+			Field:   e.Field,
+			Message: e.Message,
+		})
+	}
+
+	return &restaurantpb.ImportMenuResponse{
+		Created: int32(result.Created),
+		Updated: int32(result.Updated),
+		DryRun:  result.DryRun,
+		Errors:  errs,
+	}
+}
+
 func ProtoOrderStatusToDomain(status orderpb.OrderStatus) string {
 	switch status {
 	case orderpb.OrderStatus_PENDING:
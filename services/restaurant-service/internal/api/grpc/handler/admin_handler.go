@@ -0,0 +1,94 @@
+package handler
+
+import (
+	"context"
+
+	"github.com/tamirat-dejene/ha-soranu/services/restaurant-service/internal/api/grpc/dto"
+	"github.com/tamirat-dejene/ha-soranu/services/restaurant-service/internal/domain"
+	"github.com/tamirat-dejene/ha-soranu/shared/pkg/logger"
+	"github.com/tamirat-dejene/ha-soranu/shared/protos/adminpb"
+	"github.com/tamirat-dejene/ha-soranu/shared/protos/restaurantpb"
+	"go.uber.org/zap"
+	"google.golang.org/grpc"
+)
+
+type adminHandler struct {
+	adminpb.UnimplementedRestaurantAdminServiceServer
+	adminUsecase domain.AdminUseCase
+}
+
+// SearchRestaurants implements [adminpb.RestaurantAdminServiceServer].
+func (a *adminHandler) SearchRestaurants(ctx context.Context, req *adminpb.SearchRestaurantsRequest) (*adminpb.SearchRestaurantsResponse, error) {
+	if req == nil {
+		return nil, toGRPCError(domain.ErrInvalidSearchData)
+	}
+
+	restaurants, err := a.adminUsecase.SearchRestaurants(ctx, dto.ProtoRestaurantSearchToDomain(req))
+	if err != nil {
+		return nil, toGRPCError(err)
+	}
+
+	accounts := make([]*adminpb.RestaurantAccount, len(restaurants))
+	for i := range restaurants {
+		accounts[i] = dto.DomainRestaurantAccountToProto(&restaurants[i])
+	}
+
+	return &adminpb.SearchRestaurantsResponse{
+		Restaurants: accounts,
+	}, nil
+}
+
+// SuspendRestaurant implements [adminpb.RestaurantAdminServiceServer].
+func (a *adminHandler) SuspendRestaurant(ctx context.Context, req *adminpb.SuspendRestaurantRequest) (*adminpb.RestaurantAccount, error) {
+	if req == nil || req.RestaurantId == "" {
+		return nil, toGRPCError(domain.NewValidationError(domain.ErrInvalidRestaurantData, "restaurant_id", "is required"))
+	}
+
+	restaurant, err := a.adminUsecase.SuspendRestaurant(ctx, req.RestaurantId, req.Reason)
+	if err != nil {
+		logger.Error("failed to suspend restaurant", zap.String("restaurant_id", req.RestaurantId), zap.Error(err))
+		return nil, toGRPCError(err)
+	}
+
+	return dto.DomainRestaurantAccountToProto(restaurant), nil
+}
+
+// UnsuspendRestaurant implements [adminpb.RestaurantAdminServiceServer].
+func (a *adminHandler) UnsuspendRestaurant(ctx context.Context, req *adminpb.UnsuspendRestaurantRequest) (*adminpb.RestaurantAccount, error) {
+	if req == nil || req.RestaurantId == "" {
+		return nil, toGRPCError(domain.NewValidationError(domain.ErrInvalidRestaurantData, "restaurant_id", "is required"))
+	}
+
+	restaurant, err := a.adminUsecase.UnsuspendRestaurant(ctx, req.RestaurantId)
+	if err != nil {
+		logger.Error("failed to unsuspend restaurant", zap.String("restaurant_id", req.RestaurantId), zap.Error(err))
+		return nil, toGRPCError(err)
+	}
+
+	return dto.DomainRestaurantAccountToProto(restaurant), nil
+}
+
+// GetUserOrders implements [adminpb.RestaurantAdminServiceServer].
+func (a *adminHandler) GetUserOrders(ctx context.Context, req *adminpb.GetUserOrdersRequest) (*restaurantpb.GetOrdersResponse, error) {
+	if req == nil || req.UserId == "" {
+		return nil, toGRPCError(domain.NewValidationError(domain.ErrInvalidOrderData, "user_id", "is required"))
+	}
+
+	orders, err := a.adminUsecase.GetUserOrders(ctx, req.UserId)
+	if err != nil {
+		return nil, toGRPCError(err)
+	}
+
+	orderProtos := make([]*restaurantpb.Order, len(orders))
+	for i, order := range orders {
+		orderProtos[i] = dto.DomainOrderToProto(order)
+	}
+
+	return &restaurantpb.GetOrdersResponse{
+		Orders: orderProtos,
+	}, nil
+}
+
+func NewAdminHandler(server *grpc.Server, adminUsecase domain.AdminUseCase) {
+	adminpb.RegisterRestaurantAdminServiceServer(server, &adminHandler{adminUsecase: adminUsecase})
+}
@@ -0,0 +1,131 @@
+package handler
+
+import (
+	"context"
+	"errors"
+
+	"github.com/tamirat-dejene/ha-soranu/services/restaurant-service/internal/domain"
+	"github.com/tamirat-dejene/ha-soranu/shared/pkg/logger"
+	"go.uber.org/zap"
+	"google.golang.org/genproto/googleapis/rpc/errdetails"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// internalErrorMessage is what callers are told of errors they cannot act on.
+const internalErrorMessage = "An unexpected error occurred. Please try again later."
+
+// precondition is an error of a request the current state of a resource
+// does not allow, with how it is reported in a PreconditionFailure detail.
+type precondition struct {
+	err       error
+	violation string
+	subject   string
+}
+
+var preconditions = []precondition{
+	{domain.ErrRestaurantInactive, "RESTAURANT_INACTIVE", "restaurant"},
+	{domain.ErrRestaurantSuspended, "RESTAURANT_SUSPENDED", "restaurant"},
+	{domain.ErrOfferNotPending, "OFFER_NOT_PENDING", "delivery_offer"},
+	{domain.ErrOrderNotAwaitingDriver, "ORDER_NOT_AWAITING_DRIVER", "order"},
+	{domain.ErrCartEmpty, "CART_EMPTY", "cart"},
+	{domain.ErrCartFull, "CART_FULL", "cart"},
+	{domain.ErrCartRestaurantMismatch, "CART_RESTAURANT_MISMATCH", "cart"},
+	{domain.ErrCartInvalid, "CART_INVALID", "cart"},
+	{domain.ErrCartChanged, "CART_CHANGED", "cart"},
+}
+
+// toGRPCError gives an error of the use cases the status code callers act
+// on, with the fields at fault or the failed precondition in the status
+// details. Other errors are logged and reported as Internal, so that they do
+// not leak details of the database.
+func toGRPCError(err error) error {
+	if err == nil {
+		return nil
+	}
+	if _, ok := status.FromError(err); ok {
+		return err
+	}
+
+	var validationErr *domain.ValidationError
+	switch {
+	case errors.As(err, &validationErr):
+		return badRequestStatus(validationErr)
+	case errors.Is(err, domain.ErrInvalidRestaurantData),
+		errors.Is(err, domain.ErrInvalidSearchData),
+		errors.Is(err, domain.ErrInvalidOrderData),
+		errors.Is(err, domain.ErrInvalidCartData),
+		errors.Is(err, domain.ErrInvalidIdempotencyKey),
+		errors.Is(err, domain.ErrInvalidSuspension),
+		errors.Is(err, domain.ErrUnsupportedMenuFormat),
+		errors.Is(err, domain.ErrMenuImportTooLarge):
+		return status.Error(codes.InvalidArgument, err.Error())
+	case errors.Is(err, domain.ErrRestaurantNotFound),
+		errors.Is(err, domain.ErrMenuItemNotFound),
+		errors.Is(err, domain.ErrOrderNotFound),
+		errors.Is(err, domain.ErrOfferNotFound),
+		errors.Is(err, domain.ErrCartItemNotFound):
+		return status.Error(codes.NotFound, err.Error())
+	case errors.Is(err, domain.ErrInvalidCredentials):
+		return status.Error(codes.Unauthenticated, err.Error())
+	case errors.Is(err, domain.ErrPermissionDenied):
+		return status.Error(codes.PermissionDenied, err.Error())
+	case errors.Is(err, domain.ErrRestaurantAlreadyExists),
+		errors.Is(err, domain.ErrIdempotencyKeyReused):
+		return status.Error(codes.AlreadyExists, err.Error())
+	case errors.Is(err, domain.ErrIdempotencyKeyConflict),
+		errors.Is(err, domain.ErrCartConflict):
+		return status.Error(codes.Aborted, err.Error())
+	case errors.Is(err, context.DeadlineExceeded):
+		return status.Error(codes.DeadlineExceeded, err.Error())
+	case errors.Is(err, context.Canceled):
+		return status.Error(codes.Canceled, err.Error())
+	}
+
+	for _, p := range preconditions {
+		if errors.Is(err, p.err) {
+			return preconditionStatus(err, p)
+		}
+	}
+
+	logger.Error("unexpected error", zap.Error(err))
+	return status.Error(codes.Internal, internalErrorMessage)
+}
+
+// badRequestStatus reports an invalid request as InvalidArgument, with the
+// fields at fault in a BadRequest detail.
+func badRequestStatus(err *domain.ValidationError) error {
+	st := status.New(codes.InvalidArgument, err.Err.Error())
+
+	badRequest := &errdetails.BadRequest{}
+	for _, v := range err.Violations {
+		badRequest.FieldViolations = append(badRequest.FieldViolations, &errdetails.BadRequest_FieldViolation{
+			Field:       v.Field,
+			Description: v.Description,
+		})
+	}
+
+	detailed, detailErr := st.WithDetails(badRequest)
+	if detailErr != nil {
+		return st.Err()
+	}
+	return detailed.Err()
+}
+
+// preconditionStatus reports a request the state of a resource does not
+// allow as FailedPrecondition, with a PreconditionFailure detail.
+func preconditionStatus(err error, p precondition) error {
+	st := status.New(codes.FailedPrecondition, err.Error())
+
+	detailed, detailErr := st.WithDetails(&errdetails.PreconditionFailure{
+		Violations: []*errdetails.PreconditionFailure_Violation{{
+			Type:        p.violation,
+			Subject:     p.subject,
+			Description: err.Error(),
+		}},
+	})
+	if detailErr != nil {
+		return st.Err()
+	}
+	return detailed.Err()
+}
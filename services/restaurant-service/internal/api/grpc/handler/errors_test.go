@@ -0,0 +1,134 @@
+package handler
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"os"
+	"testing"
+
+	"github.com/tamirat-dejene/ha-soranu/services/restaurant-service/internal/domain"
+	"github.com/tamirat-dejene/ha-soranu/shared/pkg/logger"
+	"github.com/tamirat-dejene/ha-soranu/shared/protos/restaurantpb"
+	"go.uber.org/zap"
+	"google.golang.org/genproto/googleapis/rpc/errdetails"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+func TestMain(m *testing.M) {
+	logger.Log = zap.NewNop()
+	os.Exit(m.Run())
+}
+
+// orderUsecase is a use case whose GetOrder fails with err.
+type orderUsecase struct {
+	domain.RestaurantUseCase
+	err error
+}
+
+func (u orderUsecase) GetOrder(context.Context, string) (*domain.Order, error) {
+	return nil, u.err
+}
+
+func TestHandlersReportUseCaseErrors(t *testing.T) {
+	tests := []struct {
+		name        string
+		err         error
+		wantCode    codes.Code
+		wantMessage string
+		// wantField and wantPrecondition are the field of the BadRequest
+		// detail and the type of the PreconditionFailure detail expected.
+		wantField        string
+		wantPrecondition string
+	}{
+		{
+			name:      "invalid field",
+			err:       domain.NewValidationError(domain.ErrInvalidOrderData, "items[0].quantity", "must be at least 1"),
+			wantCode:  codes.InvalidArgument,
+			wantField: "items[0].quantity",
+		},
+		{name: "invalid request", err: domain.ErrInvalidCartData, wantCode: codes.InvalidArgument},
+		{name: "not found", err: fmt.Errorf("load order: %w", domain.ErrOrderNotFound), wantCode: codes.NotFound},
+		{name: "invalid credentials", err: domain.ErrInvalidCredentials, wantCode: codes.Unauthenticated},
+		{name: "permission denied", err: domain.ErrPermissionDenied, wantCode: codes.PermissionDenied},
+		{name: "already exists", err: domain.ErrIdempotencyKeyReused, wantCode: codes.AlreadyExists},
+		{name: "conflict", err: domain.ErrIdempotencyKeyConflict, wantCode: codes.Aborted},
+		{
+			name:             "failed precondition",
+			err:              domain.ErrRestaurantInactive,
+			wantCode:         codes.FailedPrecondition,
+			wantPrecondition: "RESTAURANT_INACTIVE",
+		},
+		{
+			name:             "cart changed",
+			err:              domain.ErrCartChanged,
+			wantCode:         codes.FailedPrecondition,
+			wantPrecondition: "CART_CHANGED",
+		},
+		{name: "deadline exceeded", err: context.DeadlineExceeded, wantCode: codes.DeadlineExceeded},
+		{name: "canceled", err: context.Canceled, wantCode: codes.Canceled},
+		{
+			name:        "status of another service",
+			err:         status.Error(codes.Unavailable, "auth service unavailable"),
+			wantCode:    codes.Unavailable,
+			wantMessage: "auth service unavailable",
+		},
+		{
+			name:        "unexpected error",
+			err:         errors.New(`pq: relation "orders" does not exist`),
+			wantCode:    codes.Internal,
+			wantMessage: internalErrorMessage,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			h := &restaurantHandler{restaurantUsecase: orderUsecase{err: tt.err}}
+
+			_, err := h.GetOrder(context.Background(), &restaurantpb.GetOrderRequest{OrderId: "order-1"})
+			st, ok := status.FromError(err)
+			if !ok {
+				t.Fatalf("GetOrder() error = %v, want a gRPC status", err)
+			}
+			if st.Code() != tt.wantCode {
+				t.Fatalf("GetOrder() code = %v, want %v", st.Code(), tt.wantCode)
+			}
+			if tt.wantMessage != "" && st.Message() != tt.wantMessage {
+				t.Errorf("GetOrder() message = %q, want %q", st.Message(), tt.wantMessage)
+			}
+
+			var field, precondition string
+			for _, detail := range st.Details() {
+				switch d := detail.(type) {
+				case *errdetails.BadRequest:
+					field = d.GetFieldViolations()[0].GetField()
+				case *errdetails.PreconditionFailure:
+					precondition = d.GetViolations()[0].GetType()
+				}
+			}
+			if field != tt.wantField {
+				t.Errorf("BadRequest field = %q, want %q", field, tt.wantField)
+			}
+			if precondition != tt.wantPrecondition {
+				t.Errorf("PreconditionFailure type = %q, want %q", precondition, tt.wantPrecondition)
+			}
+		})
+	}
+}
+
+func TestHandlersRejectMissingFields(t *testing.T) {
+	h := &restaurantHandler{}
+
+	_, err := h.GetOrder(context.Background(), &restaurantpb.GetOrderRequest{})
+	st := status.Convert(err)
+	if st.Code() != codes.InvalidArgument {
+		t.Fatalf("GetOrder() code = %v, want %v", st.Code(), codes.InvalidArgument)
+	}
+	for _, detail := range st.Details() {
+		if badRequest, ok := detail.(*errdetails.BadRequest); ok && badRequest.GetFieldViolations()[0].GetField() == "order_id" {
+			return
+		}
+	}
+	t.Errorf("GetOrder() details = %v, want a BadRequest naming order_id", st.Details())
+}
@@ -2,6 +2,7 @@ package handler
 
 import (
 	"context"
+	"errors"
 
 	"github.com/tamirat-dejene/ha-soranu/services/restaurant-service/internal/api/grpc/dto"
 	"github.com/tamirat-dejene/ha-soranu/services/restaurant-service/internal/domain"
@@ -9,22 +10,27 @@ import (
 	"github.com/tamirat-dejene/ha-soranu/shared/protos/restaurantpb"
 	"go.uber.org/zap"
 	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
 )
 
 type restaurantHandler struct {
 	restaurantpb.UnimplementedRestaurantServiceServer
 	restaurantUsecase domain.RestaurantUseCase
+	dispatcher        domain.Dispatcher
+	orderFeed         domain.OrderFeedUseCase
+	cartUsecase       domain.CartUseCase
 }
 
 // GetOrder implements [restaurantpb.RestaurantServiceServer].
 func (r *restaurantHandler) GetOrder(ctx context.Context, req *restaurantpb.GetOrderRequest) (*restaurantpb.Order, error) {
-	if req == nil {
-		return nil, domain.ErrInvalidOrderData
+	if req == nil || req.OrderId == "" {
+		return nil, toGRPCError(domain.NewValidationError(domain.ErrInvalidOrderData, "order_id", "is required"))
 	}
 
 	order, err := r.restaurantUsecase.GetOrder(ctx, req.OrderId)
 	if err != nil {
-		return nil, err
+		return nil, toGRPCError(err)
 	}
 
 	logger.Info("fetched order", zap.String("order_id", order.OrderId))
@@ -36,31 +42,64 @@ func (r *restaurantHandler) GetOrder(ctx context.Context, req *restaurantpb.GetO
 // ShipOrder implements [restaurantpb.RestaurantServiceServer].
 func (r *restaurantHandler) ShipOrder(ctx context.Context, req *restaurantpb.ShipOrderRequest) (*restaurantpb.ShipOrderResponse, error) {
 	if req == nil {
-		return nil, domain.ErrInvalidOrderData
+		return nil, toGRPCError(domain.ErrInvalidOrderData)
+	}
+
+	dispatch, err := r.dispatcher.ShipOrder(ctx, req.RestaurantId, req.OrderId)
+	if err != nil {
+		return nil, toGRPCError(err)
+	}
+
+	logger.Info("shipped order", zap.String("order_id", req.OrderId), zap.String("driver_id", dispatch.DriverID), zap.String("dispatch_status", dispatch.Status))
+
+	return dto.DomainDispatchToShipOrderResponse(dispatch), nil
+}
+
+// GetDeliveryOffers implements [restaurantpb.RestaurantServiceServer].
+func (r *restaurantHandler) GetDeliveryOffers(ctx context.Context, req *restaurantpb.GetDeliveryOffersRequest) (*restaurantpb.GetDeliveryOffersResponse, error) {
+	// Without a driver ID the offers of the caller's own driver profile are returned.
+	if req == nil {
+		return nil, toGRPCError(domain.ErrInvalidOrderData)
 	}
 
-	confirmation, driverID, err := r.restaurantUsecase.ShipOrder(ctx, req.RestaurantId, req.OrderId)
+	offers, err := r.dispatcher.GetDeliveryOffers(ctx, req.DriverId)
 	if err != nil {
-		return nil, err
+		return nil, toGRPCError(err)
 	}
 
-	logger.Info("shipped order", zap.String("order_id", req.OrderId), zap.String("driver_id", driverID))
+	var offerProtos []*restaurantpb.DeliveryOffer
+	for _, offer := range offers {
+		offerProtos = append(offerProtos, dto.DomainDeliveryOfferToProto(offer))
+	}
 
-	return &restaurantpb.ShipOrderResponse{
-		ConfirmationMessage: confirmation,
-		DriverId:            driverID,
+	return &restaurantpb.GetDeliveryOffersResponse{
+		Offers: offerProtos,
 	}, nil
 }
 
+// RespondToDeliveryOffer implements [restaurantpb.RestaurantServiceServer].
+func (r *restaurantHandler) RespondToDeliveryOffer(ctx context.Context, req *restaurantpb.RespondToDeliveryOfferRequest) (*restaurantpb.DeliveryOffer, error) {
+	if req == nil || req.OfferId == "" {
+		return nil, toGRPCError(domain.ErrInvalidOrderData)
+	}
+
+	offer, err := r.dispatcher.RespondToOffer(ctx, req.OfferId, req.DriverId, req.Accept)
+	if err != nil {
+		return nil, toGRPCError(err)
+	}
+
+	return dto.DomainDeliveryOfferToProto(*offer), nil
+}
+
 // GetOrders implements [restaurantpb.RestaurantServiceServer].
 func (r *restaurantHandler) GetOrders(ctx context.Context, req *restaurantpb.GetOrdersRequest) (*restaurantpb.GetOrdersResponse, error) {
 	if req == nil {
-		return nil, domain.ErrInvalidRestaurantData
+		return nil, toGRPCError(domain.ErrInvalidRestaurantData)
 	}
 
 	orders, err := r.restaurantUsecase.GetOrders(ctx, req.RestaurantId)
 	if err != nil {
-		return nil, err
+		return nil, toGRPCError(err)
 	}
 
 	logger.Info("fetched orders", zap.Int("count", len(orders)))
@@ -78,12 +117,12 @@ func (r *restaurantHandler) GetOrders(ctx context.Context, req *restaurantpb.Get
 // UpdateOrderStatus implements [restaurantpb.RestaurantServiceServer].
 func (r *restaurantHandler) UpdateOrderStatus(ctx context.Context, req *restaurantpb.UpdateOrderStatusRequest) (*restaurantpb.Order, error) {
 	if req == nil {
-		return nil, domain.ErrInvalidOrderData
+		return nil, toGRPCError(domain.ErrInvalidOrderData)
 	}
 
 	updatedOrder, err := r.restaurantUsecase.UpdateOrderStatus(ctx, req.RestaurantId, req.OrderId, dto.ProtoOrderStatusToDomain(req.NewStatus))
 	if err != nil {
-		return nil, err
+		return nil, toGRPCError(err)
 	}
 
 	logger.Info("updated order status", zap.String("order_id", updatedOrder.OrderId), zap.String("new_status", string(updatedOrder.Status)))
@@ -95,7 +134,7 @@ func (r *restaurantHandler) UpdateOrderStatus(ctx context.Context, req *restaura
 // PlaceOrder implements restaurantpb.RestaurantServiceServer.
 func (r *restaurantHandler) PlaceOrder(ctx context.Context, req *restaurantpb.PlaceOrderRequest) (*restaurantpb.PlaceOrderResponse, error) {
 	if req == nil {
-		return nil, domain.ErrInvalidOrderData
+		return nil, toGRPCError(domain.ErrInvalidOrderData)
 	}
 
 	var orderItems []domain.OrderItem
@@ -107,12 +146,13 @@ func (r *restaurantHandler) PlaceOrder(ctx context.Context, req *restaurantpb.Pl
 	}
 
 	order, err := r.restaurantUsecase.PlaceOrder(ctx, &domain.PlaceOrder{
-		CustomerID:   req.CustomerId,
-		RestaurantID: req.RestaurantId,
-		Items:        orderItems,
+		CustomerID:     req.CustomerId,
+		RestaurantID:   req.RestaurantId,
+		Items:          orderItems,
+		IdempotencyKey: req.IdempotencyKey,
 	})
 	if err != nil {
-		return nil, err
+		return nil, toGRPCError(err)
 	}
 
 	return &restaurantpb.PlaceOrderResponse{
@@ -128,12 +168,12 @@ func (r *restaurantHandler) ListRestaurants(
 	stream restaurantpb.RestaurantService_ListRestaurantsServer,
 ) error {
 	if req == nil {
-		return domain.ErrInvalidSearchData
+		return toGRPCError(domain.ErrInvalidSearchData)
 	}
 
 	ctx := stream.Context()
 
-	return r.restaurantUsecase.StreamRestaurants(ctx, domain.Area{
+	err := r.restaurantUsecase.StreamRestaurants(ctx, domain.Area{
 		LatitudeMin: req.Latitude,
 		LatitudeMax: req.Longitude,
 		RadiusInKm:  req.RadiusKm,
@@ -144,20 +184,42 @@ func (r *restaurantHandler) ListRestaurants(
 			Name:         res.Name,
 			Latitude:     res.Latitude,
 			Longitude:    res.Longitude,
+			Status:       res.Status,
 		}
 		return stream.Send(protoRes)
 	})
+	return toGRPCError(err)
+}
+
+// WatchOrders implements [restaurantpb.RestaurantServiceServer].
+func (r *restaurantHandler) WatchOrders(
+	req *restaurantpb.WatchOrdersRequest,
+	stream restaurantpb.RestaurantService_WatchOrdersServer,
+) error {
+	if req == nil || req.RestaurantId == "" {
+		return toGRPCError(domain.ErrInvalidRestaurantData)
+	}
+
+	err := r.orderFeed.WatchOrders(stream.Context(), req.RestaurantId, req.Cursor, func(event domain.OrderEvent) error {
+		return stream.Send(dto.DomainOrderEventToProto(event))
+	})
+	return toGRPCError(err)
 }
 
 // Login implements restaurantpb.RestaurantServiceServer.
 func (r *restaurantHandler) Login(ctx context.Context, req *restaurantpb.RestaurantLoginRequest) (*restaurantpb.Restaurant, error) {
 	if req == nil {
-		return nil, domain.ErrInvalidRestaurantData
+		return nil, toGRPCError(domain.ErrInvalidRestaurantData)
 	}
 
 	rest, err := r.restaurantUsecase.LoginRestaurant(ctx, req.Email, req.SecretKey)
+	if errors.Is(err, domain.ErrRestaurantSuspended) {
+		// A suspended restaurant may not sign in at all, rather than being
+		// in a state the caller could change.
+		return nil, status.Error(codes.PermissionDenied, err.Error())
+	}
 	if err != nil {
-		return nil, domain.NewDomainError(domain.InvalidCredentialsMessage)
+		return nil, toGRPCError(err)
 	}
 
 	return dto.DomainRestaurantToProto(rest), nil
@@ -166,7 +228,7 @@ func (r *restaurantHandler) Login(ctx context.Context, req *restaurantpb.Restaur
 // AddMenuItem implements restaurantpb.RestaurantServiceServer.
 func (r *restaurantHandler) AddMenuItem(ctx context.Context, req *restaurantpb.AddMenuItemRequest) (*restaurantpb.MenuItem, error) {
 	if req == nil {
-		return nil, domain.ErrInvalidRestaurantData
+		return nil, toGRPCError(domain.ErrInvalidRestaurantData)
 	}
 
 	item, err := r.restaurantUsecase.AddMenuItem(ctx, req.RestaurantId, domain.MenuItem{
@@ -177,7 +239,7 @@ func (r *restaurantHandler) AddMenuItem(ctx context.Context, req *restaurantpb.A
 	})
 
 	if err != nil {
-		return nil, err
+		return nil, toGRPCError(err)
 	}
 
 	return &restaurantpb.MenuItem{
@@ -191,12 +253,12 @@ func (r *restaurantHandler) AddMenuItem(ctx context.Context, req *restaurantpb.A
 // GetRestaurant implements restaurantpb.RestaurantServiceServer.
 func (r *restaurantHandler) GetRestaurant(ctx context.Context, req *restaurantpb.GetRestaurantRequest) (*restaurantpb.Restaurant, error) {
 	if req == nil {
-		return nil, domain.ErrInvalidRestaurantData
+		return nil, toGRPCError(domain.ErrInvalidRestaurantData)
 	}
 
 	restaurant, err := r.restaurantUsecase.GetRestaurantByID(ctx, req.RestaurantId)
 	if err != nil {
-		return nil, err
+		return nil, toGRPCError(err)
 	}
 
 	var menuItems []*restaurantpb.MenuItem
@@ -216,13 +278,14 @@ func (r *restaurantHandler) GetRestaurant(ctx context.Context, req *restaurantpb
 		Latitude:     restaurant.Latitude,
 		Longitude:    restaurant.Longitude,
 		Menus:        menuItems,
+		Status:       restaurant.Status,
 	}, nil
 }
 
 // RegisterRestaurant implements restaurantpb.RestaurantServiceServer.
 func (r *restaurantHandler) RegisterRestaurant(ctx context.Context, req *restaurantpb.RegisterRestaurantRequest) (*restaurantpb.Restaurant, error) {
 	if req == nil {
-		return nil, domain.ErrInvalidRestaurantData
+		return nil, toGRPCError(domain.ErrInvalidRestaurantData)
 	}
 
 	restaurant, err := r.restaurantUsecase.RegisterRestaurant(ctx, &domain.Restaurant{
@@ -235,7 +298,7 @@ func (r *restaurantHandler) RegisterRestaurant(ctx context.Context, req *restaur
 		MenuItems: dto.ProtoRegisterMenuItemsToDomain(req.Menus),
 	})
 	if err != nil {
-		return nil, err
+		return nil, toGRPCError(err)
 	}
 
 	return &restaurantpb.Restaurant{
@@ -245,18 +308,69 @@ func (r *restaurantHandler) RegisterRestaurant(ctx context.Context, req *restaur
 		Latitude:     restaurant.Latitude,
 		Longitude:    restaurant.Longitude,
 		Menus:        dto.DomainRestaurantToProto(restaurant).Menus,
+		Status:       restaurant.Status,
 	}, nil
 }
 
+// UpdateRestaurant implements [restaurantpb.RestaurantServiceServer].
+func (r *restaurantHandler) UpdateRestaurant(ctx context.Context, req *restaurantpb.UpdateRestaurantRequest) (*restaurantpb.Restaurant, error) {
+	if req == nil {
+		return nil, toGRPCError(domain.ErrInvalidRestaurantData)
+	}
+
+	restaurant, err := r.restaurantUsecase.UpdateRestaurant(ctx, &domain.Restaurant{
+		ID:        req.RestaurantId,
+		Email:     req.Email,
+		Name:      req.Name,
+		Latitude:  req.Latitude,
+		Longitude: req.Longitude,
+	})
+	if err != nil {
+		return nil, toGRPCError(err)
+	}
+
+	logger.Info("updated restaurant", zap.String("restaurant_id", restaurant.ID))
+
+	return dto.DomainRestaurantToProto(restaurant), nil
+}
+
+// DeactivateRestaurant implements [restaurantpb.RestaurantServiceServer].
+func (r *restaurantHandler) DeactivateRestaurant(ctx context.Context, req *restaurantpb.DeactivateRestaurantRequest) (*restaurantpb.Restaurant, error) {
+	if req == nil {
+		return nil, toGRPCError(domain.ErrInvalidRestaurantData)
+	}
+
+	restaurant, err := r.restaurantUsecase.DeactivateRestaurant(ctx, req.RestaurantId)
+	if err != nil {
+		return nil, toGRPCError(err)
+	}
+
+	return dto.DomainRestaurantToProto(restaurant), nil
+}
+
+// ReactivateRestaurant implements [restaurantpb.RestaurantServiceServer].
+func (r *restaurantHandler) ReactivateRestaurant(ctx context.Context, req *restaurantpb.ReactivateRestaurantRequest) (*restaurantpb.Restaurant, error) {
+	if req == nil {
+		return nil, toGRPCError(domain.ErrInvalidRestaurantData)
+	}
+
+	restaurant, err := r.restaurantUsecase.ReactivateRestaurant(ctx, req.RestaurantId)
+	if err != nil {
+		return nil, toGRPCError(err)
+	}
+
+	return dto.DomainRestaurantToProto(restaurant), nil
+}
+
 // RemoveMenuItem implements restaurantpb.RestaurantServiceServer.
 func (r *restaurantHandler) RemoveMenuItem(ctx context.Context, req *restaurantpb.RemoveMenuItemRequest) (*restaurantpb.MenuItem, error) {
 	if req == nil {
-		return nil, domain.ErrInvalidRestaurantData
+		return nil, toGRPCError(domain.ErrInvalidRestaurantData)
 	}
 
 	err := r.restaurantUsecase.RemoveMenuItem(ctx, req.RestaurantId, req.ItemId)
 	if err != nil {
-		return nil, err
+		return nil, toGRPCError(err)
 	}
 
 	return &restaurantpb.MenuItem{
@@ -264,10 +378,50 @@ func (r *restaurantHandler) RemoveMenuItem(ctx context.Context, req *restaurantp
 	}, nil
 }
 
+// ImportMenu implements [restaurantpb.RestaurantServiceServer].
+func (r *restaurantHandler) ImportMenu(ctx context.Context, req *restaurantpb.ImportMenuRequest) (*restaurantpb.ImportMenuResponse, error) {
+	if req == nil {
+		return nil, toGRPCError(domain.ErrInvalidRestaurantData)
+	}
+
+	result, err := r.restaurantUsecase.ImportMenu(ctx, req.RestaurantId, dto.ProtoMenuFormatToDomain(req.Format), req.Data, req.DryRun)
+	if err != nil {
+		return nil, toGRPCError(err)
+	}
+
+	logger.Info("menu import processed",
+		zap.String("restaurant_id", req.RestaurantId),
+		zap.Bool("dry_run", result.DryRun),
+		zap.Int("created", result.Created),
+		zap.Int("updated", result.Updated),
+		zap.Int("errors", len(result.Errors)),
+	)
+
+	return dto.DomainMenuImportToProto(result), nil
+}
+
+// ExportMenu implements [restaurantpb.RestaurantServiceServer].
+func (r *restaurantHandler) ExportMenu(ctx context.Context, req *restaurantpb.ExportMenuRequest) (*restaurantpb.ExportMenuResponse, error) {
+	if req == nil {
+		return nil, toGRPCError(domain.ErrInvalidRestaurantData)
+	}
+
+	data, err := r.restaurantUsecase.ExportMenu(ctx, req.RestaurantId, dto.ProtoMenuFormatToDomain(req.Format))
+	if err != nil {
+		return nil, toGRPCError(err)
+	}
+
+	return &restaurantpb.ExportMenuResponse{
+		Format:      req.Format,
+		ContentType: dto.MenuFormatContentType(req.Format),
+		Data:        data,
+	}, nil
+}
+
 // UpdateMenuItem implements restaurantpb.RestaurantServiceServer.
 func (r *restaurantHandler) UpdateMenuItem(ctx context.Context, req *restaurantpb.UpdateMenuItemRequest) (*restaurantpb.MenuItem, error) {
 	if req == nil {
-		return nil, domain.ErrInvalidRestaurantData
+		return nil, toGRPCError(domain.ErrInvalidRestaurantData)
 	}
 
 	item, err := r.restaurantUsecase.UpdateMenuItem(ctx, req.RestaurantId, domain.MenuItem{
@@ -278,7 +432,7 @@ func (r *restaurantHandler) UpdateMenuItem(ctx context.Context, req *restaurantp
 	})
 
 	if err != nil {
-		return nil, err
+		return nil, toGRPCError(err)
 	}
 
 	return &restaurantpb.MenuItem{
@@ -289,10 +443,102 @@ func (r *restaurantHandler) UpdateMenuItem(ctx context.Context, req *restaurantp
 	}, nil
 }
 
+// GetCart implements [restaurantpb.RestaurantServiceServer].
+func (r *restaurantHandler) GetCart(ctx context.Context, req *restaurantpb.GetCartRequest) (*restaurantpb.Cart, error) {
+	if req == nil {
+		return nil, toGRPCError(domain.ErrInvalidCartData)
+	}
+
+	cart, err := r.cartUsecase.GetCart(ctx, req.CustomerId)
+	if err != nil {
+		return nil, toGRPCError(err)
+	}
+
+	return dto.DomainCartToProto(cart), nil
+}
+
+// AddCartItem implements [restaurantpb.RestaurantServiceServer].
+func (r *restaurantHandler) AddCartItem(ctx context.Context, req *restaurantpb.AddCartItemRequest) (*restaurantpb.Cart, error) {
+	if req == nil {
+		return nil, toGRPCError(domain.ErrInvalidCartData)
+	}
+
+	cart, err := r.cartUsecase.AddCartItem(ctx, req.CustomerId, req.RestaurantId, req.ItemId, req.Quantity)
+	if err != nil {
+		return nil, toGRPCError(err)
+	}
+
+	return dto.DomainCartToProto(cart), nil
+}
+
+// UpdateCartItem implements [restaurantpb.RestaurantServiceServer].
+func (r *restaurantHandler) UpdateCartItem(ctx context.Context, req *restaurantpb.UpdateCartItemRequest) (*restaurantpb.Cart, error) {
+	if req == nil {
+		return nil, toGRPCError(domain.ErrInvalidCartData)
+	}
+
+	cart, err := r.cartUsecase.UpdateCartItem(ctx, req.CustomerId, req.ItemId, req.Quantity)
+	if err != nil {
+		return nil, toGRPCError(err)
+	}
+
+	return dto.DomainCartToProto(cart), nil
+}
+
+// RemoveCartItem implements [restaurantpb.RestaurantServiceServer].
+func (r *restaurantHandler) RemoveCartItem(ctx context.Context, req *restaurantpb.RemoveCartItemRequest) (*restaurantpb.Cart, error) {
+	if req == nil {
+		return nil, toGRPCError(domain.ErrInvalidCartData)
+	}
+
+	cart, err := r.cartUsecase.RemoveCartItem(ctx, req.CustomerId, req.ItemId)
+	if err != nil {
+		return nil, toGRPCError(err)
+	}
+
+	return dto.DomainCartToProto(cart), nil
+}
+
+// ClearCart implements [restaurantpb.RestaurantServiceServer].
+func (r *restaurantHandler) ClearCart(ctx context.Context, req *restaurantpb.ClearCartRequest) (*restaurantpb.Cart, error) {
+	if req == nil {
+		return nil, toGRPCError(domain.ErrInvalidCartData)
+	}
+
+	cart, err := r.cartUsecase.ClearCart(ctx, req.CustomerId)
+	if err != nil {
+		return nil, toGRPCError(err)
+	}
+
+	return dto.DomainCartToProto(cart), nil
+}
+
+// CheckoutCart implements [restaurantpb.RestaurantServiceServer].
+func (r *restaurantHandler) CheckoutCart(ctx context.Context, req *restaurantpb.CheckoutCartRequest) (*restaurantpb.PlaceOrderResponse, error) {
+	if req == nil {
+		return nil, toGRPCError(domain.ErrInvalidCartData)
+	}
+
+	order, err := r.cartUsecase.Checkout(ctx, req.CustomerId, req.IdempotencyKey)
+	if err != nil {
+		return nil, toGRPCError(err)
+	}
+
+	return &restaurantpb.PlaceOrderResponse{
+		OrderId:     order.OrderId,
+		TotalAmount: order.TotalAmount,
+		Status:      order.Status,
+	}, nil
+}
+
 func NewRestaurantHandler(
-	server *grpc.Server, restaurantUsecase domain.RestaurantUseCase) {
+	server *grpc.Server, restaurantUsecase domain.RestaurantUseCase, dispatcher domain.Dispatcher, orderFeed domain.OrderFeedUseCase,
+	cartUsecase domain.CartUseCase) {
 	handler := &restaurantHandler{
 		restaurantUsecase: restaurantUsecase,
+		dispatcher:        dispatcher,
+		orderFeed:         orderFeed,
+		cartUsecase:       cartUsecase,
 	}
 	restaurantpb.RegisterRestaurantServiceServer(server, handler)
 }
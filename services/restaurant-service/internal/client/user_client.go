@@ -0,0 +1,92 @@
+package client
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/tamirat-dejene/ha-soranu/services/restaurant-service/internal/domain"
+	jwtvalidator "github.com/tamirat-dejene/ha-soranu/shared/pkg/auth/jwtvalidator"
+	"github.com/tamirat-dejene/ha-soranu/shared/pkg/auth/svcauth"
+	"github.com/tamirat-dejene/ha-soranu/shared/pkg/correlation"
+	"github.com/tamirat-dejene/ha-soranu/shared/pkg/logger"
+	"github.com/tamirat-dejene/ha-soranu/shared/protos/adminpb"
+	"github.com/tamirat-dejene/ha-soranu/shared/protos/userpb"
+	"go.uber.org/zap"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/credentials/insecure"
+	"google.golang.org/grpc/status"
+)
+
+// UserServiceClient talks to the user and user admin services hosted by
+// auth-service.
+type UserServiceClient struct {
+	client userpb.UserServiceClient
+	admin  adminpb.UserAdminServiceClient
+	conn   *grpc.ClientConn
+}
+
+var (
+	_ domain.DriverLocator    = (*UserServiceClient)(nil)
+	_ domain.OwnerRoleGranter = (*UserServiceClient)(nil)
+)
+
+func NewUserServiceClient(addr string, signer *svcauth.Signer) (*UserServiceClient, error) {
+	opts := append([]grpc.DialOption{grpc.WithTransportCredentials(insecure.NewCredentials())}, signer.DialOptions()...)
+	opts = append(opts, correlation.DialOptions()...)
+	conn, err := grpc.NewClient(addr, opts...)
+	if err != nil {
+		return nil, fmt.Errorf("failed to connect to user service: %w", err)
+	}
+
+	return &UserServiceClient{
+		client: userpb.NewUserServiceClient(conn),
+		admin:  adminpb.NewUserAdminServiceClient(conn),
+		conn:   conn,
+	}, nil
+}
+
+// NearbyDrivers implements [domain.DriverLocator].
+func (c *UserServiceClient) NearbyDrivers(ctx context.Context, latitude float32, longitude float32, radiusKm float32) ([]string, error) {
+	resp, err := c.client.GetDrivers(ctx, &userpb.GetDriversRequest{
+		Latitude:  latitude,
+		Longitude: longitude,
+		RadiusKm:  radiusKm,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return resp.DriverIds, nil
+}
+
+// DriverID implements [domain.DriverLocator].
+func (c *UserServiceClient) DriverID(ctx context.Context, userID string) (string, error) {
+	resp, err := c.client.GetDriverProfile(ctx, &userpb.GetDriverProfileRequest{UserId: userID})
+	if status.Code(err) == codes.NotFound {
+		return "", nil
+	}
+	if err != nil {
+		return "", err
+	}
+
+	return resp.GetProfile().GetDriverId(), nil
+}
+
+// GrantRestaurantOwner implements [domain.OwnerRoleGranter].
+func (c *UserServiceClient) GrantRestaurantOwner(ctx context.Context, userID string) error {
+	_, err := c.admin.GrantRole(ctx, &adminpb.GrantRoleRequest{
+		UserId: userID,
+		Role:   jwtvalidator.RoleRestaurantOwner,
+	})
+	return err
+}
+
+// Close the gRPC connection
+func (c *UserServiceClient) Close() {
+	if c.conn != nil {
+		if err := c.conn.Close(); err != nil {
+			logger.Error("failed to close gRPC connection", zap.Error(err))
+		}
+	}
+}
@@ -0,0 +1,35 @@
+package domain
+
+import (
+	"context"
+	"time"
+)
+
+// Suspension records why and by which admin a restaurant was suspended.
+type Suspension struct {
+	Reason      string
+	SuspendedBy string
+	SuspendedAt time.Time
+}
+
+// RestaurantSearch selects the restaurants an admin looks for. Query matches
+// names and emails case-insensitively and Status, when set, the status.
+type RestaurantSearch struct {
+	Query  string
+	Status string
+	Limit  int
+	Offset int
+}
+
+// AdminUseCase lets admins moderate restaurants and look into users' orders.
+// Every method fails with ErrPermissionDenied unless the call is made for an
+// admin.
+type AdminUseCase interface {
+	SearchRestaurants(ctx context.Context, search RestaurantSearch) ([]Restaurant, error)
+	// SuspendRestaurant hides the restaurant from customers and stops it from
+	// taking orders until an admin lifts the suspension.
+	SuspendRestaurant(ctx context.Context, restaurantID, reason string) (*Restaurant, error)
+	UnsuspendRestaurant(ctx context.Context, restaurantID string) (*Restaurant, error)
+	// GetUserOrders returns the orders the user placed.
+	GetUserOrders(ctx context.Context, userID string) ([]Order, error)
+}
@@ -0,0 +1,54 @@
+package domain
+
+import (
+	"context"
+	"time"
+)
+
+// Cart holds the items a customer is about to order from a single restaurant.
+type Cart struct {
+	CustomerID   string
+	RestaurantID string
+	Items        []CartItem
+	ExpiresAt    time.Time
+	// Revision identifies the stored cart this one was read from, "" when
+	// there was none. SaveCart only replaces that revision.
+	Revision string
+
+	// Filled in when the cart is revalidated against the menu.
+	TotalAmount float64
+	Valid       bool
+}
+
+type CartItem struct {
+	ItemID    string
+	Name      string
+	Quantity  int32
+	UnitPrice float32
+
+	// Filled in when the cart is revalidated against the menu.
+	Available     bool
+	PriceChanged  bool
+	PreviousPrice float32
+}
+
+type CartUseCase interface {
+	GetCart(ctx context.Context, customerID string) (*Cart, error)
+	AddCartItem(ctx context.Context, customerID, restaurantID, itemID string, quantity int32) (*Cart, error)
+	// UpdateCartItem sets the quantity of an item; zero removes it.
+	UpdateCartItem(ctx context.Context, customerID, itemID string, quantity int32) (*Cart, error)
+	RemoveCartItem(ctx context.Context, customerID, itemID string) (*Cart, error)
+	ClearCart(ctx context.Context, customerID string) (*Cart, error)
+	// Checkout places an order from the cart and empties it. It fails with
+	// ErrCartChanged when prices moved since the cart was last read.
+	Checkout(ctx context.Context, customerID, idempotencyKey string) (*Order, error)
+}
+
+type CartRepository interface {
+	// GetCart returns the stored cart, or an empty one when there is none.
+	GetCart(ctx context.Context, customerID string) (*Cart, error)
+	// SaveCart stores the cart and restarts its expiry. It fails with
+	// ErrCartConflict when the stored cart is no longer cart.Revision.
+	SaveCart(ctx context.Context, cart *Cart) error
+	DeleteCart(ctx context.Context, customerID string) error
+}
@@ -0,0 +1,86 @@
+package domain
+
+import (
+	"context"
+	"time"
+)
+
+const (
+	DISPATCH_STATUS_SEARCHING = "SEARCHING"
+	DISPATCH_STATUS_OFFERED   = "OFFERED"
+	DISPATCH_STATUS_ASSIGNED  = "ASSIGNED"
+	// DISPATCH_STATUS_NO_DRIVER ends dispatch after every attempt found no
+	// driver. Shipping the order again starts a new search.
+	DISPATCH_STATUS_NO_DRIVER = "NO_DRIVER"
+)
+
+const (
+	OFFER_STATUS_PENDING  = "PENDING"
+	OFFER_STATUS_ACCEPTED = "ACCEPTED"
+	OFFER_STATUS_DECLINED = "DECLINED"
+	OFFER_STATUS_EXPIRED  = "EXPIRED"
+)
+
+type DeliveryOffer struct {
+	OfferID      string
+	OrderID      string
+	RestaurantID string
+	DriverID     string
+	Status       string
+	ExpiresAt    time.Time
+}
+
+// Dispatch is the delivery state of a shipped order.
+type Dispatch struct {
+	OrderID  string
+	DriverID string
+	Status   string
+	Offer    *DeliveryOffer // Latest offer made for the order, if any
+}
+
+// OfferPolicy bounds how an order is offered to drivers.
+type OfferPolicy struct {
+	TTL          time.Duration // Time a driver has to answer an offer
+	ReofferAfter time.Duration // Time before a driver who let the order go may be asked again
+	MaxAttempts  int           // Offer rounds before the order ends as NO_DRIVER
+}
+
+// DispatchTarget is a shipped order together with its pickup location.
+type DispatchTarget struct {
+	OrderID      string
+	RestaurantID string
+	CustomerID   string
+	Latitude     float32
+	Longitude    float32
+}
+
+// DriverLocator finds drivers that can take a delivery.
+type DriverLocator interface {
+	// NearbyDrivers returns the IDs of drivers around the location, nearest first.
+	NearbyDrivers(ctx context.Context, latitude, longitude, radiusKm float32) ([]string, error)
+	// DriverID returns the ID of the user's driver profile, or "" when they
+	// are not a driver.
+	DriverID(ctx context.Context, userID string) (string, error)
+}
+
+type Dispatcher interface {
+	ShipOrder(ctx context.Context, restaurantID, orderID string) (*Dispatch, error)
+	GetDeliveryOffers(ctx context.Context, driverID string) ([]DeliveryOffer, error)
+	RespondToOffer(ctx context.Context, offerID, driverID string, accept bool) (*DeliveryOffer, error)
+
+	// Run reassigns expired offers and retries orders waiting for a driver
+	// until ctx is cancelled.
+	Run(ctx context.Context)
+}
+
+type DispatchRepository interface {
+	ShipOrder(ctx context.Context, restaurantID, orderID string) (*DispatchTarget, error)
+	GetDispatch(ctx context.Context, orderID string) (*Dispatch, error)
+	GetDispatchTarget(ctx context.Context, orderID string) (*DispatchTarget, error)
+	GetDispatchTargets(ctx context.Context, limit int) ([]DispatchTarget, error)
+
+	CreateOffer(ctx context.Context, orderID string, candidates []string, policy OfferPolicy) (*Dispatch, error)
+	GetPendingOffers(ctx context.Context, driverID string) ([]DeliveryOffer, error)
+	RespondToOffer(ctx context.Context, offerID, driverID string, accept bool) (*DeliveryOffer, error)
+	ExpireOffers(ctx context.Context) (int, error)
+}
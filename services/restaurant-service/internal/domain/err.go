@@ -1,5 +1,7 @@
 package domain
 
+import "strings"
+
 const (
 	InvalidCredentialsMessage     = "Invalid email or secret key"
 	RestaurantNotFoundMessage       = "Restaurant not found"
@@ -9,6 +11,8 @@ const (
 	InvalidSearchDataMessage       = "Invalid search data provided"
 	InvalidOrderDataMessage        = "Invalid order data provided"
 	OrderNotFoundMessage           = "Order not found"
+	InvalidIdempotencyKeyMessage   = "Idempotency key must be at most 255 characters"
+	IdempotencyKeyReusedMessage    = "Idempotency key was already used for a different order"
 )
 
 var (
@@ -21,6 +25,32 @@ var (
 	ErrInvalidSearchData       = NewDomainError(InvalidSearchDataMessage)
 	ErrInvalidOrderData        = NewDomainError(InvalidOrderDataMessage)
 	ErrOrderNotFound           = NewDomainError(OrderNotFoundMessage)
+	ErrInvalidIdempotencyKey   = NewDomainError(InvalidIdempotencyKeyMessage)
+	ErrIdempotencyKeyReused    = NewDomainError(IdempotencyKeyReusedMessage)
+	ErrRestaurantInactive      = NewDomainError("Restaurant is not accepting orders")
+	ErrRestaurantSuspended     = NewDomainError("Restaurant is suspended, please contact support")
+	ErrInvalidSuspension       = NewDomainError("A suspension needs a reason of at most 500 characters")
+	ErrUnsupportedMenuFormat   = NewDomainError("Unsupported menu format")
+	ErrMenuImportTooLarge      = NewDomainError("Menu import has too many items")
+	ErrOfferNotFound           = NewDomainError("Delivery offer not found")
+	ErrOfferNotPending         = NewDomainError("Delivery offer is no longer open")
+	ErrOrderNotAwaitingDriver  = NewDomainError("Order is not waiting for a driver")
+	ErrInvalidCartData         = NewDomainError("Invalid cart data provided")
+	ErrCartEmpty               = NewDomainError("Cart is empty")
+	ErrCartItemNotFound        = NewDomainError("Item is not in the cart")
+	ErrCartFull                = NewDomainError("Cart has too many items")
+	ErrCartRestaurantMismatch  = NewDomainError("Cart already holds items from another restaurant")
+	ErrCartInvalid             = NewDomainError("Cart has items that are no longer available")
+	ErrCartChanged             = NewDomainError("Cart prices changed, review the cart before checking out")
+	// ErrPermissionDenied is returned when the user of a call does not own
+	// the restaurant, order, cart or delivery offer it acts on.
+	ErrPermissionDenied = NewDomainError("You are not allowed to access this resource")
+	// ErrIdempotencyKeyConflict is returned by the repository when a concurrent
+	// request stored an order under the same customer and idempotency key first.
+	ErrIdempotencyKeyConflict = NewDomainError("Idempotency key conflict")
+	// ErrCartConflict is returned by the repository when the cart was changed
+	// by another request since it was read.
+	ErrCartConflict = NewDomainError("Cart was changed by another request, please retry")
 )
 
 type DomainError struct {
@@ -33,4 +63,35 @@ func (e *DomainError) Error() string {
 
 func NewDomainError(message string) error {
 	return &DomainError{Message: message}
-}
\ No newline at end of file
+}
+
+// FieldViolation names a request field at fault and what is wrong with it.
+type FieldViolation struct {
+	Field       string
+	Description string
+}
+
+// ValidationError is an invalid request naming the fields at fault. It
+// unwraps to the error of the data that was invalid, such as
+// ErrInvalidOrderData.
+type ValidationError struct {
+	Err        error
+	Violations []FieldViolation
+}
+
+func (e *ValidationError) Error() string {
+	descriptions := make([]string, len(e.Violations))
+	for i, v := range e.Violations {
+		descriptions[i] = v.Field + " " + v.Description
+	}
+	return e.Err.Error() + ": " + strings.Join(descriptions, "; ")
+}
+
+func (e *ValidationError) Unwrap() error {
+	return e.Err
+}
+
+// NewValidationError returns err naming field as the one at fault.
+func NewValidationError(err error, field, description string) error {
+	return &ValidationError{Err: err, Violations: []FieldViolation{{Field: field, Description: description}}}
+}
@@ -0,0 +1,36 @@
+package domain
+
+import (
+	"context"
+	"time"
+)
+
+const (
+	ORDER_EVENT_SNAPSHOT       = "SNAPSHOT"
+	ORDER_EVENT_CREATED        = "CREATED"
+	ORDER_EVENT_STATUS_CHANGED = "STATUS_CHANGED"
+)
+
+// OrderEvent is an entry of a restaurant's order feed. Seq is the cursor a
+// watcher resumes from.
+type OrderEvent struct {
+	Seq        int64
+	Type       string
+	Order      Order
+	OccurredAt time.Time
+}
+
+type OrderFeedUseCase interface {
+	// WatchOrders sends the restaurant's open orders, or the events after
+	// cursor when resuming, and then follows new events until ctx is done.
+	WatchOrders(ctx context.Context, restaurantID string, cursor int64, onEvent func(OrderEvent) error) error
+	// StartConsumer listens to order events and wakes up the watchers of the
+	// affected restaurant.
+	StartConsumer(ctx context.Context) error
+}
+
+type OrderFeedRepository interface {
+	GetOpenOrders(ctx context.Context, restaurantID string) ([]Order, error)
+	GetLatestOrderEventSeq(ctx context.Context, restaurantID string) (int64, error)
+	GetOrderEvents(ctx context.Context, restaurantID string, afterSeq int64, limit int) ([]OrderEvent, error)
+}
@@ -11,7 +11,13 @@ type Restaurant struct {
 	Name      string
 	Latitude  float32
 	Longitude float32
+	Status    string
+	// OwnerID is the user who registered the restaurant, empty for
+	// restaurants registered before owners were recorded.
+	OwnerID   string
 	MenuItems []MenuItem
+	// Suspension is set while the restaurant is suspended.
+	Suspension *Suspension
 }
 
 type MenuItem struct {
@@ -21,6 +27,26 @@ type MenuItem struct {
 	Price       float32
 }
 
+const (
+	MENU_FORMAT_CSV  = "csv"
+	MENU_FORMAT_JSON = "json"
+)
+
+// MenuImportError describes a rejected line of an imported menu.
+type MenuImportError struct {
+	Line    int
+	Field   string
+	Message string
+}
+
+// MenuImport is the outcome of a menu import. Nothing is applied when Errors is not empty.
+type MenuImport struct {
+	Created int
+	Updated int
+	DryRun  bool
+	Errors  []MenuImportError
+}
+
 type Area struct {
 	LatitudeMin float32
 	LatitudeMax float32
@@ -28,9 +54,11 @@ type Area struct {
 }
 
 type PlaceOrder struct {
-	CustomerID   string
-	RestaurantID string
-	Items        []OrderItem
+	CustomerID     string
+	RestaurantID   string
+	Items          []OrderItem
+	IdempotencyKey string
+	RequestHash    string // Fingerprint of the payload stored alongside IdempotencyKey
 }
 
 type Order struct {
@@ -47,23 +75,32 @@ type OrderItem struct {
 	Quantity int32
 }
 
+// OwnerRoleGranter grants the restaurant_owner role, kept by the auth
+// service, to the users restaurants are registered for.
+type OwnerRoleGranter interface {
+	GrantRestaurantOwner(ctx context.Context, userID string) error
+}
+
 type RestaurantUseCase interface {
 	LoginRestaurant(ctx context.Context, email, secretKey string) (*Restaurant, error)
 
 	RegisterRestaurant(ctx context.Context, restaurant *Restaurant) (*Restaurant, error)
 	StreamRestaurants(ctx context.Context, area Area, onResult func(Restaurant) error) error
 	GetRestaurantByID(ctx context.Context, restaurantID string) (*Restaurant, error)
+	UpdateRestaurant(ctx context.Context, restaurant *Restaurant) (*Restaurant, error)
+	DeactivateRestaurant(ctx context.Context, restaurantID string) (*Restaurant, error)
+	ReactivateRestaurant(ctx context.Context, restaurantID string) (*Restaurant, error)
 
 	AddMenuItem(ctx context.Context, restaurantID string, item MenuItem) (*MenuItem, error)
 	RemoveMenuItem(ctx context.Context, restaurantID, itemID string) error
 	UpdateMenuItem(ctx context.Context, restaurantID string, item MenuItem) (*MenuItem, error)
+	ImportMenu(ctx context.Context, restaurantID, format string, data []byte, dryRun bool) (*MenuImport, error)
+	ExportMenu(ctx context.Context, restaurantID, format string) ([]byte, error)
 
 	PlaceOrder(ctx context.Context, order *PlaceOrder) (*Order, error)
 	GetOrders(ctx context.Context, restaurantID string) ([]Order, error)
 	UpdateOrderStatus(ctx context.Context, restaurantID, orderID, newStatus string) (*Order, error)
 	GetOrder(ctx context.Context, orderID string) (*Order, error)
-
-	ShipOrder(ctx context.Context, restaurantID, orderID string) (string, string, error)
 }
 
 type RestaurantRepository interface {
@@ -72,16 +109,32 @@ type RestaurantRepository interface {
 	CreateRestaurant(ctx context.Context, restaurant *Restaurant) (*Restaurant, error)
 	StreamRestaurants(ctx context.Context, area Area, onRow func(Restaurant) error) error
 	GetRestaurantByID(ctx context.Context, restaurantID string) (*Restaurant, error)
+	// GetRestaurantOwner returns the owner of the restaurant, "" when it has none.
+	GetRestaurantOwner(ctx context.Context, restaurantID string) (string, error)
+	UpdateRestaurant(ctx context.Context, restaurant *Restaurant) error
+	// SetRestaurantStatus changes the status of a restaurant. Suspended
+	// restaurants fail with ErrRestaurantSuspended.
+	SetRestaurantStatus(ctx context.Context, restaurantID, status string) error
+
+	// SearchRestaurants returns the restaurants matching the search, without
+	// their menus.
+	SearchRestaurants(ctx context.Context, search RestaurantSearch) ([]Restaurant, error)
+	SuspendRestaurant(ctx context.Context, restaurantID string, suspension Suspension) error
+	// UnsuspendRestaurant lifts the suspension, making the restaurant active.
+	UnsuspendRestaurant(ctx context.Context, restaurantID string) error
 
 	AddMenuItem(ctx context.Context, restaurantID string, item MenuItem) (*MenuItem, error)
 	RemoveMenuItem(ctx context.Context, restaurantID, itemID string) error
 	UpdateMenuItem(ctx context.Context, restaurantID string, item MenuItem) (*MenuItem, error)
+	// UpsertMenuItems updates items whose name matches an existing item and
+	// inserts the rest in one transaction, rolling back when dryRun is set.
+	UpsertMenuItems(ctx context.Context, restaurantID string, items []MenuItem, dryRun bool) (created, updated int, err error)
 
 	PlaceOrder(ctx context.Context, order *PlaceOrder) (*Order, error)
 	GetOrders(ctx context.Context, restaurantID string) ([]Order, error)
 	UpdateOrderStatus(ctx context.Context, restaurantID, orderID, newStatus string) (*Order, error)
 	GetOrderByID(ctx context.Context, orderID string) (*Order, error)
+	GetOrderByIdempotencyKey(ctx context.Context, customerID, key string) (*Order, string, error)
 
 	GetOrder(ctx context.Context, orderID string) (*Order, error)
-	ShipOrder(ctx context.Context, restaurantID, orderID string) (string, string, error)
 }
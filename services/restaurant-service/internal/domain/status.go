@@ -8,4 +8,11 @@ const (
 	ORDER_STATUS_COMPLETED = "COMPLETED"
 	ORDER_STATUS_SHIPPED   = "SHIPPED"
 	ORDER_STATUS_CANCELLED = "CANCELLED"
-)
\ No newline at end of file
+)
+
+const (
+	RESTAURANT_STATUS_ACTIVE   = "ACTIVE"
+	RESTAURANT_STATUS_INACTIVE = "INACTIVE"
+	// RESTAURANT_STATUS_SUSPENDED is set by admins; owners cannot change it.
+	RESTAURANT_STATUS_SUSPENDED = "SUSPENDED"
+)
@@ -0,0 +1,25 @@
+package domain
+
+import "context"
+
+// AnonymousCustomerID replaces the customer of the orders of a deleted account.
+const AnonymousCustomerID = "00000000-0000-0000-0000-000000000000"
+
+type UserDataUseCase interface {
+	// StartConsumer deletes or exports the data of users who asked for it,
+	// reporting back to the auth service, until ctx is cancelled.
+	StartConsumer(ctx context.Context) error
+}
+
+type UserDataRepository interface {
+	// GetCustomerOrders returns the orders the customer placed, with their items.
+	GetCustomerOrders(ctx context.Context, customerID string) ([]Order, error)
+	// GetDriverDeliveries returns the orders the driver was assigned, with their items.
+	GetDriverDeliveries(ctx context.Context, driverID string) ([]Order, error)
+	// AnonymizeCustomer detaches the customer's orders from them; restaurants
+	// keep the orders themselves. It returns how many orders were detached.
+	AnonymizeCustomer(ctx context.Context, customerID string) (int, error)
+	// ReleaseDriver expires the driver's pending delivery offers, so that the
+	// orders are offered to other drivers. It returns how many were expired.
+	ReleaseDriver(ctx context.Context, driverID string) (int, error)
+}
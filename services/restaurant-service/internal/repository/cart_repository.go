@@ -0,0 +1,125 @@
+package repository
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"time"
+
+	"github.com/tamirat-dejene/ha-soranu/services/restaurant-service/internal/domain"
+	"github.com/tamirat-dejene/ha-soranu/shared/pkg/caching"
+)
+
+type cartRepository struct {
+	client     caching.CacheClient
+	expiration time.Duration
+}
+
+func getCartKey(customerID string) string {
+	return fmt.Sprintf("cart:%s", customerID)
+}
+
+// cartRecord is the stored form of a cart. Only what the customer chose and
+// the prices they last saw are kept; everything else is derived on read.
+type cartRecord struct {
+	RestaurantID string           `json:"restaurant_id"`
+	Items        []cartItemRecord `json:"items"`
+	ExpiresAt    int64            `json:"expires_at"`
+}
+
+type cartItemRecord struct {
+	ItemID    string  `json:"item_id"`
+	Name      string  `json:"name"`
+	Quantity  int32   `json:"quantity"`
+	UnitPrice float32 `json:"unit_price"`
+}
+
+// GetCart implements [domain.CartRepository].
+func (r *cartRepository) GetCart(ctx context.Context, customerID string) (*domain.Cart, error) {
+	key := getCartKey(customerID)
+
+	exists, err := r.client.Exists(ctx, key)
+	if err != nil {
+		return nil, err
+	}
+	if !exists {
+		return &domain.Cart{CustomerID: customerID}, nil
+	}
+
+	data, err := r.client.Get(ctx, key)
+	if err != nil {
+		return nil, err
+	}
+
+	var record cartRecord
+	if err := json.Unmarshal([]byte(data), &record); err != nil {
+		return nil, err
+	}
+
+	cart := &domain.Cart{
+		CustomerID:   customerID,
+		RestaurantID: record.RestaurantID,
+		ExpiresAt:    time.Unix(record.ExpiresAt, 0),
+		Revision:     caching.Digest(data),
+	}
+	for _, item := range record.Items {
+		cart.Items = append(cart.Items, domain.CartItem{
+			ItemID:    item.ItemID,
+			Name:      item.Name,
+			Quantity:  item.Quantity,
+			UnitPrice: item.UnitPrice,
+		})
+	}
+
+	return cart, nil
+}
+
+// SaveCart implements [domain.CartRepository].
+func (r *cartRepository) SaveCart(ctx context.Context, cart *domain.Cart) error {
+	expiresAt := time.Now().Add(r.expiration)
+
+	record := cartRecord{
+		RestaurantID: cart.RestaurantID,
+		Items:        make([]cartItemRecord, 0, len(cart.Items)),
+		ExpiresAt:    expiresAt.Unix(),
+	}
+	for _, item := range cart.Items {
+		record.Items = append(record.Items, cartItemRecord{
+			ItemID:    item.ItemID,
+			Name:      item.Name,
+			Quantity:  item.Quantity,
+			UnitPrice: item.UnitPrice,
+		})
+	}
+
+	data, err := json.Marshal(record)
+	if err != nil {
+		return err
+	}
+
+	set, err := r.client.CompareAndSet(ctx, getCartKey(cart.CustomerID), cart.Revision, string(data), r.expiration)
+	if err != nil {
+		return err
+	}
+	if !set {
+		return domain.ErrCartConflict
+	}
+
+	cart.ExpiresAt = expiresAt
+	cart.Revision = caching.Digest(string(data))
+	return nil
+}
+
+// DeleteCart implements [domain.CartRepository].
+func (r *cartRepository) DeleteCart(ctx context.Context, customerID string) error {
+	return r.client.Delete(ctx, getCartKey(customerID))
+}
+
+// NewCartRepository creates a cache-backed CartRepository whose carts expire
+// after the given time without changes or reads.
+func NewCartRepository(client caching.CacheClient, expiration time.Duration) domain.CartRepository {
+	return &cartRepository{
+		client:     client,
+		expiration: expiration,
+	}
+}
@@ -0,0 +1,499 @@
+package repository
+
+import (
+	"context"
+	"time"
+
+	"github.com/tamirat-dejene/ha-soranu/services/restaurant-service/internal/domain"
+	postgres "github.com/tamirat-dejene/ha-soranu/shared/db/pg"
+	"github.com/tamirat-dejene/ha-soranu/shared/pkg/logger"
+	"go.uber.org/zap"
+)
+
+type dispatchRepository struct {
+	db postgres.PostgresClient
+}
+
+// ShipOrder implements [domain.DispatchRepository].
+// It marks the order as shipped and, unless a driver is already being
+// dispatched for it, queues it for a driver. An order that ended without a
+// driver starts a new search.
+func (d *dispatchRepository) ShipOrder(ctx context.Context, restaurantID string, orderID string) (*domain.DispatchTarget, error) {
+	query := `
+		UPDATE orders o
+		SET status = $1,
+			shipped_at = COALESCE(o.shipped_at, NOW()),
+			dispatch_status = CASE
+				WHEN o.dispatch_status IS NULL OR o.dispatch_status = $3 THEN $2
+				ELSE o.dispatch_status
+			END,
+			dispatch_attempts = CASE WHEN o.dispatch_status = $3 THEN 0 ELSE o.dispatch_attempts END
+		FROM restaurants r
+		WHERE o.order_id = $4 AND o.restaurant_id = $5 AND r.restaurant_id = o.restaurant_id
+		RETURNING o.order_id, o.restaurant_id, o.customer_id, COALESCE(r.latitude, 0), COALESCE(r.longitude, 0)
+	`
+
+	var target domain.DispatchTarget
+	err := d.db.QueryRow(
+		ctx,
+		query,
+		domain.ORDER_STATUS_SHIPPED,
+		domain.DISPATCH_STATUS_SEARCHING,
+		domain.DISPATCH_STATUS_NO_DRIVER,
+		orderID,
+		restaurantID,
+	).Scan(&target.OrderID, &target.RestaurantID, &target.CustomerID, &target.Latitude, &target.Longitude)
+
+	if err != nil {
+		if isNotFound(err) {
+			return nil, domain.ErrOrderNotFound
+		}
+		return nil, err
+	}
+
+	return &target, nil
+}
+
+// GetDispatch implements [domain.DispatchRepository].
+func (d *dispatchRepository) GetDispatch(ctx context.Context, orderID string) (*domain.Dispatch, error) {
+	query := `
+		SELECT o.order_id, o.restaurant_id, COALESCE(o.driver_id::text, ''), COALESCE(o.dispatch_status, ''),
+			lo.offer_id, lo.driver_id, lo.status, lo.expires_at
+		FROM orders o
+		LEFT JOIN LATERAL (
+			SELECT offer_id, driver_id, status, expires_at
+			FROM delivery_offers
+			WHERE order_id = o.order_id
+			ORDER BY created_at DESC
+			LIMIT 1
+		) lo ON TRUE
+		WHERE o.order_id = $1
+	`
+
+	var (
+		dispatch       domain.Dispatch
+		restaurantID   string
+		offerID        *string
+		offerDriverID  *string
+		offerStatus    *string
+		offerExpiresAt *time.Time
+	)
+
+	err := d.db.QueryRow(ctx, query, orderID).Scan(
+		&dispatch.OrderID,
+		&restaurantID,
+		&dispatch.DriverID,
+		&dispatch.Status,
+		&offerID,
+		&offerDriverID,
+		&offerStatus,
+		&offerExpiresAt,
+	)
+	if err != nil {
+		if isNotFound(err) {
+			return nil, domain.ErrOrderNotFound
+		}
+		return nil, err
+	}
+
+	if offerID != nil {
+		dispatch.Offer = &domain.DeliveryOffer{
+			OfferID:      *offerID,
+			OrderID:      dispatch.OrderID,
+			RestaurantID: restaurantID,
+			DriverID:     *offerDriverID,
+			Status:       *offerStatus,
+			ExpiresAt:    *offerExpiresAt,
+		}
+	}
+
+	return &dispatch, nil
+}
+
+// GetDispatchTarget implements [domain.DispatchRepository].
+func (d *dispatchRepository) GetDispatchTarget(ctx context.Context, orderID string) (*domain.DispatchTarget, error) {
+	query := `
+		SELECT o.order_id, o.restaurant_id, o.customer_id, COALESCE(r.latitude, 0), COALESCE(r.longitude, 0)
+		FROM orders o
+		JOIN restaurants r ON r.restaurant_id = o.restaurant_id
+		WHERE o.order_id = $1
+	`
+
+	var target domain.DispatchTarget
+	err := d.db.QueryRow(ctx, query, orderID).Scan(
+		&target.OrderID,
+		&target.RestaurantID,
+		&target.CustomerID,
+		&target.Latitude,
+		&target.Longitude,
+	)
+	if err != nil {
+		if isNotFound(err) {
+			return nil, domain.ErrOrderNotFound
+		}
+		return nil, err
+	}
+
+	return &target, nil
+}
+
+// GetDispatchTargets implements [domain.DispatchRepository].
+// It returns shipped orders that are still waiting for a driver, those
+// waiting longest since their last attempt first, so a full batch cannot
+// starve the rest of the queue.
+func (d *dispatchRepository) GetDispatchTargets(ctx context.Context, limit int) ([]domain.DispatchTarget, error) {
+	query := `
+		SELECT o.order_id, o.restaurant_id, o.customer_id, COALESCE(r.latitude, 0), COALESCE(r.longitude, 0)
+		FROM orders o
+		JOIN restaurants r ON r.restaurant_id = o.restaurant_id
+		WHERE o.dispatch_status = $1 AND o.status = $2
+		ORDER BY o.dispatch_attempted_at NULLS FIRST, o.shipped_at
+		LIMIT $3
+	`
+
+	rows, err := d.db.Query(ctx, query, domain.DISPATCH_STATUS_SEARCHING, domain.ORDER_STATUS_SHIPPED, limit)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var targets []domain.DispatchTarget
+	for rows.Next() {
+		var target domain.DispatchTarget
+		if err := rows.Scan(&target.OrderID, &target.RestaurantID, &target.CustomerID, &target.Latitude, &target.Longitude); err != nil {
+			return nil, err
+		}
+		targets = append(targets, target)
+	}
+
+	return targets, rows.Err()
+}
+
+// CreateOffer implements [domain.DispatchRepository].
+// It offers the order to the first candidate who is not busy with another
+// delivery and was not asked for this order within policy.ReofferAfter.
+// Every call counts as an attempt; when nobody is available the order keeps
+// waiting for a driver until it runs out of attempts and ends as NO_DRIVER.
+func (d *dispatchRepository) CreateOffer(ctx context.Context, orderID string, candidates []string, policy domain.OfferPolicy) (*domain.Dispatch, error) {
+	tx, err := d.db.BeginTx(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	defer func() {
+		if err != nil {
+			_ = tx.Rollback(ctx)
+		}
+	}()
+
+	// 1. Lock the order so concurrent dispatchers cannot offer it twice
+	var restaurantID, dispatchStatus string
+	var attempts int
+	err = tx.QueryRow(ctx, `
+		SELECT restaurant_id, COALESCE(dispatch_status, ''), dispatch_attempts
+		FROM orders
+		WHERE order_id = $1
+		FOR UPDATE
+	`, orderID).Scan(&restaurantID, &dispatchStatus, &attempts)
+
+	if err != nil {
+		if isNotFound(err) {
+			err = domain.ErrOrderNotFound
+		}
+		return nil, err
+	}
+
+	if dispatchStatus != domain.DISPATCH_STATUS_SEARCHING {
+		err = domain.ErrOrderNotAwaitingDriver
+		return nil, err
+	}
+
+	// 2. Pick a driver, unless the order is out of attempts
+	var driverID string
+	outOfAttempts := policy.MaxAttempts > 0 && attempts >= policy.MaxAttempts
+	if !outOfAttempts {
+		driverID, err = pickDriver(ctx, tx, orderID, candidates, policy.ReofferAfter)
+		if err != nil {
+			return nil, err
+		}
+		attempts++
+	}
+
+	if driverID == "" {
+		status := domain.DISPATCH_STATUS_SEARCHING
+		if policy.MaxAttempts > 0 && attempts >= policy.MaxAttempts {
+			status = domain.DISPATCH_STATUS_NO_DRIVER
+		}
+
+		_, err = tx.Exec(ctx, `
+			UPDATE orders
+			SET dispatch_status = $1, dispatch_attempts = $2, dispatch_attempted_at = NOW()
+			WHERE order_id = $3
+		`, status, attempts, orderID)
+
+		if err != nil {
+			return nil, err
+		}
+
+		if err = tx.Commit(ctx); err != nil {
+			return nil, err
+		}
+
+		if status == domain.DISPATCH_STATUS_NO_DRIVER {
+			logger.Warn("no driver found for order", zap.String("order_id", orderID), zap.Int("attempts", attempts))
+		}
+
+		return &domain.Dispatch{OrderID: orderID, Status: status}, nil
+	}
+
+	// 3. Create the offer and hold the driver on the order
+	offer := domain.DeliveryOffer{
+		OrderID:      orderID,
+		RestaurantID: restaurantID,
+		DriverID:     driverID,
+		Status:       domain.OFFER_STATUS_PENDING,
+	}
+
+	err = tx.QueryRow(ctx, `
+		INSERT INTO delivery_offers (order_id, driver_id, status, expires_at)
+		VALUES ($1, $2, $3, NOW() + make_interval(secs => $4))
+		RETURNING offer_id, expires_at
+	`, orderID, driverID, domain.OFFER_STATUS_PENDING, policy.TTL.Seconds()).Scan(&offer.OfferID, &offer.ExpiresAt)
+
+	if err != nil {
+		return nil, err
+	}
+
+	_, err = tx.Exec(ctx, `
+		UPDATE orders
+		SET driver_id = $1, dispatch_status = $2, dispatch_attempts = $3, dispatch_attempted_at = NOW()
+		WHERE order_id = $4
+	`, driverID, domain.DISPATCH_STATUS_OFFERED, attempts, orderID)
+
+	if err != nil {
+		return nil, err
+	}
+
+	// 4. Commit transaction
+	if err = tx.Commit(ctx); err != nil {
+		return nil, err
+	}
+
+	logger.Info("offered delivery", zap.String("order_id", orderID), zap.String("driver_id", driverID), zap.String("offer_id", offer.OfferID))
+
+	return &domain.Dispatch{
+		OrderID:  orderID,
+		DriverID: driverID,
+		Status:   domain.DISPATCH_STATUS_OFFERED,
+		Offer:    &offer,
+	}, nil
+}
+
+// pickDriver returns the first candidate who is not busy with another
+// delivery and was not asked for the order within reofferAfter, or "" when
+// there is none.
+func pickDriver(ctx context.Context, tx postgres.Tx, orderID string, candidates []string, reofferAfter time.Duration) (string, error) {
+	rows, err := tx.Query(ctx, `
+		SELECT driver_id::text FROM delivery_offers
+		WHERE order_id = $1 AND created_at > NOW() - make_interval(secs => $2)
+		UNION
+		SELECT driver_id::text FROM orders
+		WHERE driver_id::text = ANY($3)
+			AND dispatch_status IN ($4, $5)
+			AND status NOT IN ($6, $7)
+	`,
+		orderID,
+		reofferAfter.Seconds(),
+		candidates,
+		domain.DISPATCH_STATUS_OFFERED,
+		domain.DISPATCH_STATUS_ASSIGNED,
+		domain.ORDER_STATUS_COMPLETED,
+		domain.ORDER_STATUS_CANCELLED,
+	)
+	if err != nil {
+		return "", err
+	}
+	defer rows.Close()
+
+	excluded := make(map[string]bool)
+	for rows.Next() {
+		var driverID string
+		if err := rows.Scan(&driverID); err != nil {
+			return "", err
+		}
+		excluded[driverID] = true
+	}
+	if err := rows.Err(); err != nil {
+		return "", err
+	}
+
+	for _, candidate := range candidates {
+		if !excluded[candidate] {
+			return candidate, nil
+		}
+	}
+	return "", nil
+}
+
+// GetPendingOffers implements [domain.DispatchRepository].
+func (d *dispatchRepository) GetPendingOffers(ctx context.Context, driverID string) ([]domain.DeliveryOffer, error) {
+	query := `
+		SELECT d.offer_id, d.order_id, o.restaurant_id, d.driver_id, d.status, d.expires_at
+		FROM delivery_offers d
+		JOIN orders o ON o.order_id = d.order_id
+		WHERE d.driver_id = $1 AND d.status = $2 AND d.expires_at > NOW()
+		ORDER BY d.expires_at
+	`
+
+	rows, err := d.db.Query(ctx, query, driverID, domain.OFFER_STATUS_PENDING)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var offers []domain.DeliveryOffer
+	for rows.Next() {
+		var offer domain.DeliveryOffer
+		if err := rows.Scan(
+			&offer.OfferID,
+			&offer.OrderID,
+			&offer.RestaurantID,
+			&offer.DriverID,
+			&offer.Status,
+			&offer.ExpiresAt,
+		); err != nil {
+			return nil, err
+		}
+		offers = append(offers, offer)
+	}
+
+	return offers, rows.Err()
+}
+
+// RespondToOffer implements [domain.DispatchRepository].
+// Accepting assigns the driver to the order; declining puts the order back
+// in the queue for the next driver.
+func (d *dispatchRepository) RespondToOffer(ctx context.Context, offerID string, driverID string, accept bool) (*domain.DeliveryOffer, error) {
+	tx, err := d.db.BeginTx(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	defer func() {
+		if err != nil {
+			_ = tx.Rollback(ctx)
+		}
+	}()
+
+	// 1. Lock the offer
+	var offer domain.DeliveryOffer
+	var expired bool
+	err = tx.QueryRow(ctx, `
+		SELECT d.offer_id, d.order_id, o.restaurant_id, d.driver_id, d.status, d.expires_at, d.expires_at <= NOW()
+		FROM delivery_offers d
+		JOIN orders o ON o.order_id = d.order_id
+		WHERE d.offer_id = $1
+		FOR UPDATE OF d
+	`, offerID).Scan(
+		&offer.OfferID,
+		&offer.OrderID,
+		&offer.RestaurantID,
+		&offer.DriverID,
+		&offer.Status,
+		&offer.ExpiresAt,
+		&expired,
+	)
+
+	if err != nil {
+		if isNotFound(err) {
+			err = domain.ErrOfferNotFound
+		}
+		return nil, err
+	}
+
+	if offer.DriverID != driverID {
+		err = domain.ErrOfferNotFound
+		return nil, err
+	}
+
+	if offer.Status != domain.OFFER_STATUS_PENDING || expired {
+		err = domain.ErrOfferNotPending
+		return nil, err
+	}
+
+	// 2. Record the response on the offer and the order
+	offer.Status = domain.OFFER_STATUS_DECLINED
+	orderQuery := `
+		UPDATE orders
+		SET driver_id = NULL, dispatch_status = $1
+		WHERE order_id = $2
+	`
+	orderStatus := domain.DISPATCH_STATUS_SEARCHING
+
+	if accept {
+		offer.Status = domain.OFFER_STATUS_ACCEPTED
+		orderQuery = `
+			UPDATE orders
+			SET dispatch_status = $1
+			WHERE order_id = $2
+		`
+		orderStatus = domain.DISPATCH_STATUS_ASSIGNED
+	}
+
+	_, err = tx.Exec(ctx, `
+		UPDATE delivery_offers
+		SET status = $1, responded_at = NOW()
+		WHERE offer_id = $2
+	`, offer.Status, offer.OfferID)
+
+	if err != nil {
+		return nil, err
+	}
+
+	if _, err = tx.Exec(ctx, orderQuery, orderStatus, offer.OrderID); err != nil {
+		return nil, err
+	}
+
+	// 3. Commit transaction
+	if err = tx.Commit(ctx); err != nil {
+		return nil, err
+	}
+
+	return &offer, nil
+}
+
+// ExpireOffers implements [domain.DispatchRepository].
+// It expires lapsed offers and puts their orders back in the queue. Rows
+// locked by another replica are skipped and picked up on a later sweep.
+func (d *dispatchRepository) ExpireOffers(ctx context.Context) (int, error) {
+	query := `
+		WITH lapsed AS (
+			SELECT offer_id
+			FROM delivery_offers
+			WHERE status = $1 AND expires_at <= NOW()
+			FOR UPDATE SKIP LOCKED
+		), expired AS (
+			UPDATE delivery_offers d
+			SET status = $2
+			FROM lapsed l
+			WHERE d.offer_id = l.offer_id
+			RETURNING d.order_id
+		)
+		UPDATE orders
+		SET driver_id = NULL, dispatch_status = $3
+		WHERE order_id IN (SELECT order_id FROM expired)
+	`
+
+	return d.db.Exec(
+		ctx,
+		query,
+		domain.OFFER_STATUS_PENDING,
+		domain.OFFER_STATUS_EXPIRED,
+		domain.DISPATCH_STATUS_SEARCHING,
+	)
+}
+
+// NewDispatchRepository creates a new instance of DispatchRepository.
+func NewDispatchRepository(db postgres.PostgresClient) domain.DispatchRepository {
+	return &dispatchRepository{db: db}
+}
@@ -0,0 +1,172 @@
+package repository
+
+import (
+	"context"
+
+	"github.com/tamirat-dejene/ha-soranu/services/restaurant-service/internal/domain"
+	postgres "github.com/tamirat-dejene/ha-soranu/shared/db/pg"
+)
+
+type orderFeedRepository struct {
+	db postgres.PostgresClient
+}
+
+// GetOpenOrders implements [domain.OrderFeedRepository].
+// Open orders are the ones the kitchen still has to handle.
+func (o *orderFeedRepository) GetOpenOrders(ctx context.Context, restaurantID string) ([]domain.Order, error) {
+	query := `
+		SELECT order_id, customer_id, restaurant_id, total_price, status
+		FROM orders
+		WHERE restaurant_id = $1 AND status NOT IN ($2, $3, $4)
+	`
+
+	rows, err := o.db.Query(
+		ctx,
+		query,
+		restaurantID,
+		domain.ORDER_STATUS_SHIPPED,
+		domain.ORDER_STATUS_COMPLETED,
+		domain.ORDER_STATUS_CANCELLED,
+	)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var orders []domain.Order
+	for rows.Next() {
+		var ord domain.Order
+		if err := rows.Scan(&ord.OrderId, &ord.CustomerID, &ord.RestaurantID, &ord.TotalAmount, &ord.Status); err != nil {
+			return nil, err
+		}
+		orders = append(orders, ord)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+
+	if err := loadOrderItems(ctx, o.db, orders); err != nil {
+		return nil, err
+	}
+
+	return orders, nil
+}
+
+// GetLatestOrderEventSeq implements [domain.OrderFeedRepository].
+func (o *orderFeedRepository) GetLatestOrderEventSeq(ctx context.Context, restaurantID string) (int64, error) {
+	query := `
+		SELECT COALESCE(MAX(seq), 0)
+		FROM order_events
+		WHERE restaurant_id = $1
+	`
+
+	var seq int64
+	if err := o.db.QueryRow(ctx, query, restaurantID).Scan(&seq); err != nil {
+		return 0, err
+	}
+
+	return seq, nil
+}
+
+// GetOrderEvents implements [domain.OrderFeedRepository].
+// Each event carries the order as it is now, with the status it had when
+// the event was recorded.
+func (o *orderFeedRepository) GetOrderEvents(ctx context.Context, restaurantID string, afterSeq int64, limit int) ([]domain.OrderEvent, error) {
+	query := `
+		SELECT e.seq, e.event_type, e.status, e.occurred_at,
+			o.order_id, o.customer_id, o.restaurant_id, o.total_price
+		FROM order_events e
+		JOIN orders o ON o.order_id = e.order_id
+		WHERE e.restaurant_id = $1 AND e.seq > $2
+		ORDER BY e.seq
+		LIMIT $3
+	`
+
+	rows, err := o.db.Query(ctx, query, restaurantID, afterSeq, limit)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var events []domain.OrderEvent
+	for rows.Next() {
+		var ev domain.OrderEvent
+		if err := rows.Scan(
+			&ev.Seq,
+			&ev.Type,
+			&ev.Order.Status,
+			&ev.OccurredAt,
+			&ev.Order.OrderId,
+			&ev.Order.CustomerID,
+			&ev.Order.RestaurantID,
+			&ev.Order.TotalAmount,
+		); err != nil {
+			return nil, err
+		}
+		events = append(events, ev)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+
+	orders := make([]domain.Order, len(events))
+	for i := range events {
+		orders[i] = events[i].Order
+	}
+	if err := loadOrderItems(ctx, o.db, orders); err != nil {
+		return nil, err
+	}
+	for i := range events {
+		events[i].Order.Items = orders[i].Items
+	}
+
+	return events, nil
+}
+
+// loadOrderItems fills in the items of the given orders with a single query.
+func loadOrderItems(ctx context.Context, db postgres.PostgresClient, orders []domain.Order) error {
+	if len(orders) == 0 {
+		return nil
+	}
+
+	ids := make([]string, 0, len(orders))
+	for _, ord := range orders {
+		ids = append(ids, ord.OrderId)
+	}
+
+	query := `
+		SELECT order_id, item_id, quantity
+		FROM order_items
+		WHERE order_id = ANY($1::uuid[])
+	`
+
+	rows, err := db.Query(ctx, query, ids)
+	if err != nil {
+		return err
+	}
+	defer rows.Close()
+
+	items := make(map[string][]domain.OrderItem)
+	for rows.Next() {
+		var orderID string
+		var item domain.OrderItem
+		if err := rows.Scan(&orderID, &item.ItemId, &item.Quantity); err != nil {
+			return err
+		}
+		items[orderID] = append(items[orderID], item)
+	}
+	if err := rows.Err(); err != nil {
+		return err
+	}
+
+	for i := range orders {
+		orders[i].Items = items[orders[i].OrderId]
+	}
+
+	return nil
+}
+
+// NewOrderFeedRepository creates a new instance of OrderFeedRepository.
+func NewOrderFeedRepository(db postgres.PostgresClient) domain.OrderFeedRepository {
+	return &orderFeedRepository{db: db}
+}
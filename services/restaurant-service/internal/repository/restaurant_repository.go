@@ -2,15 +2,23 @@ package repository
 
 import (
 	"context"
-	"database/sql"
 	"errors"
+	"fmt"
+	"strings"
+	"time"
 
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgconn"
 	"github.com/tamirat-dejene/ha-soranu/services/restaurant-service/internal/domain"
 	postgres "github.com/tamirat-dejene/ha-soranu/shared/db/pg"
 	"github.com/tamirat-dejene/ha-soranu/shared/pkg/logger"
 	"go.uber.org/zap"
 )
 
+// likeEscaper escapes the wildcards of a LIKE pattern so that searches match
+// them literally.
+var likeEscaper = strings.NewReplacer(`\`, `\\`, `%`, `\%`, `_`, `\_`)
+
 type restaurantRepository struct {
 	db postgres.PostgresClient
 }
@@ -31,7 +39,7 @@ func (r *restaurantRepository) GetOrderByID(ctx context.Context, orderID string)
 		&ord.Status,
 	)
 	if err != nil {
-		if errors.Is(err, sql.ErrNoRows) {
+		if isNotFound(err) {
 			return nil, domain.ErrOrderNotFound
 		}
 		return nil, err
@@ -65,51 +73,54 @@ func (r *restaurantRepository) GetOrderByID(ctx context.Context, orderID string)
 	return &ord, nil
 }
 
-// ShipOrder implements [domain.RestaurantRepository].
-func (r *restaurantRepository) ShipOrder(ctx context.Context, restaurantID string, orderID string) (string, string, error) {
-	// 1. Update order status to SHIPPED
-	updateQuery := `
-		UPDATE orders
-		SET status = $1
-		WHERE order_id = $2 AND restaurant_id = $3
-		RETURNING order_id
+// GetOrderByIdempotencyKey implements [domain.RestaurantRepository].
+// It returns the order stored under the customer's idempotency key together
+// with the fingerprint of the payload that created it.
+func (r *restaurantRepository) GetOrderByIdempotencyKey(ctx context.Context, customerID string, key string) (*domain.Order, string, error) {
+	query := `
+		SELECT order_id, restaurant_id, customer_id, total_price, status, request_hash
+		FROM orders
+		WHERE customer_id = $1 AND idempotency_key = $2
 	`
 
-	var retOrderID string
-	err := r.db.QueryRow(
-		ctx,
-		updateQuery,
-		domain.ORDER_STATUS_SHIPPED,
-		orderID,
-		restaurantID,
-	).Scan(&retOrderID)
-
+	var ord domain.Order
+	var requestHash string
+	err := r.db.QueryRow(ctx, query, customerID, key).Scan(
+		&ord.OrderId,
+		&ord.RestaurantID,
+		&ord.CustomerID,
+		&ord.TotalAmount,
+		&ord.Status,
+		&requestHash,
+	)
 	if err != nil {
-		if errors.Is(err, sql.ErrNoRows) {
-			return "", "", domain.ErrOrderNotFound
+		if isNotFound(err) {
+			return nil, "", domain.ErrOrderNotFound
 		}
-		return "", "", err
+		return nil, "", err
 	}
 
-	// 2. Select a driver (simple strategy: latest created driver)
-	var driverID string
-	driverQuery := `
-		SELECT driver_id
-		FROM drivers
-		ORDER BY created_at DESC
-		LIMIT 1
+	itemsQuery := `
+		SELECT item_id, quantity
+		FROM order_items
+		WHERE order_id = $1
 	`
 
-	if derr := r.db.QueryRow(ctx, driverQuery).Scan(&driverID); derr != nil {
-		// If no driver found, proceed with empty driver ID
-		if !errors.Is(derr, sql.ErrNoRows) {
-			return "", "", derr
+	rows, err := r.db.Query(ctx, itemsQuery, ord.OrderId)
+	if err != nil {
+		return nil, "", err
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		var item domain.OrderItem
+		if err := rows.Scan(&item.ItemId, &item.Quantity); err != nil {
+			return nil, "", err
 		}
-		driverID = ""
+		ord.Items = append(ord.Items, item)
 	}
 
-	// 3. Return confirmation message and driver ID
-	return "Order shipped successfully", driverID, nil
+	return &ord, requestHash, rows.Err()
 }
 
 // UpdateOrderStatus implements [domain.RestaurantRepository].
@@ -137,7 +148,7 @@ func (r *restaurantRepository) UpdateOrderStatus(ctx context.Context, restaurant
 	)
 
 	if err != nil {
-		if errors.Is(err, sql.ErrNoRows) {
+		if isNotFound(err) {
 			return nil, domain.ErrOrderNotFound
 		}
 		return nil, err
@@ -261,7 +272,7 @@ func (r *restaurantRepository) GetOrder(ctx context.Context, orderID string) (*d
 		&ord.Status,
 	)
 	if err != nil {
-		if errors.Is(err, sql.ErrNoRows) {
+		if isNotFound(err) {
 			return nil, domain.ErrOrderNotFound
 		}
 		return nil, err
@@ -282,8 +293,8 @@ func calculateTotalPrice(ctx context.Context, tx postgres.Tx, restaurantID strin
 
 		err := tx.QueryRow(ctx, query, it.ItemId, restaurantID).Scan(&price)
 		if err != nil {
-			if errors.Is(err, sql.ErrNoRows) {
-				return 0, errors.New("menu item not found or does not belong to restaurant")
+			if isNotFound(err) {
+				return 0, domain.NewValidationError(domain.ErrInvalidOrderData, "items", fmt.Sprintf("item %s is not on the restaurant's menu", it.ItemId))
 			}
 			return 0, err
 		}
@@ -307,7 +318,28 @@ func (r *restaurantRepository) PlaceOrder(ctx context.Context, order *domain.Pla
 		}
 	}()
 
-	// 1. Calculate total price
+	// 1. Make sure the restaurant is taking orders
+	var restaurantStatus string
+	err = tx.QueryRow(ctx, `
+		SELECT status
+		FROM restaurants
+		WHERE restaurant_id = $1
+		FOR SHARE
+	`, order.RestaurantID).Scan(&restaurantStatus)
+
+	if err != nil {
+		if isNotFound(err) {
+			err = domain.ErrRestaurantNotFound
+		}
+		return nil, err
+	}
+
+	if restaurantStatus != domain.RESTAURANT_STATUS_ACTIVE {
+		err = domain.ErrRestaurantInactive
+		return nil, err
+	}
+
+	// 2. Calculate total price
 	totalPrice, err := calculateTotalPrice(
 		ctx,
 		tx,
@@ -318,11 +350,11 @@ func (r *restaurantRepository) PlaceOrder(ctx context.Context, order *domain.Pla
 		return nil, err
 	}
 
-	// 2. Create order
+	// 3. Create order
 	var orderID string
 	createOrderQuery := `
-		INSERT INTO orders (customer_id, restaurant_id, total_price)
-		VALUES ($1, $2, $3)
+		INSERT INTO orders (customer_id, restaurant_id, total_price, idempotency_key, request_hash)
+		VALUES ($1, $2, $3, NULLIF($4, ''), NULLIF($5, ''))
 		RETURNING order_id
 	`
 
@@ -332,13 +364,18 @@ func (r *restaurantRepository) PlaceOrder(ctx context.Context, order *domain.Pla
 		order.CustomerID,
 		order.RestaurantID,
 		totalPrice,
+		order.IdempotencyKey,
+		order.RequestHash,
 	).Scan(&orderID)
 
 	if err != nil {
+		if isUniqueViolation(err, "idx_orders_customer_idempotency_key") {
+			err = domain.ErrIdempotencyKeyConflict
+		}
 		return nil, err
 	}
 
-	// 3. Insert order items
+	// 4. Insert order items
 	insertItemQuery := `
 		INSERT INTO order_items (order_id, item_id, quantity)
 		VALUES ($1, $2, $3)
@@ -357,14 +394,14 @@ func (r *restaurantRepository) PlaceOrder(ctx context.Context, order *domain.Pla
 		}
 	}
 
-	// 4. Commit transaction
+	// 5. Commit transaction
 	if err = tx.Commit(ctx); err != nil {
 		return nil, err
 	}
 
 	logger.Info("placed new order", zap.String("order_id", orderID), zap.String("restaurant_id", order.RestaurantID), zap.Float64("total_price", totalPrice))
 
-	// 5. Return created order
+	// 6. Return created order
 
 	return &domain.Order{
 		OrderId:      orderID,
@@ -384,7 +421,7 @@ func (r *restaurantRepository) LoginRestaurant(
 ) (*domain.Restaurant, error) {
 
 	query := `
-		SELECT restaurant_id, email, name, latitude, longitude
+		SELECT restaurant_id, email, name, latitude, longitude, status, COALESCE(owner_id::text, '')
 		FROM restaurants
 		WHERE email = $1 AND secret_key = $2
 	`
@@ -398,11 +435,13 @@ func (r *restaurantRepository) LoginRestaurant(
 		&res.Name,
 		&res.Latitude,
 		&res.Longitude,
+		&res.Status,
+		&res.OwnerID,
 	)
 
 	if err != nil {
-		if errors.Is(err, sql.ErrNoRows) {
-			return nil, errors.New("invalid credentials")
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, domain.ErrInvalidCredentials
 		}
 		return nil, err
 	}
@@ -428,9 +467,9 @@ func (r *restaurantRepository) CreateRestaurant(
 	}()
 
 	createQuery := `
-		INSERT INTO restaurants (email, secret_key, name, latitude, longitude)
-		VALUES ($1, $2, $3, $4, $5)
-		RETURNING restaurant_id
+		INSERT INTO restaurants (email, secret_key, name, latitude, longitude, owner_id)
+		VALUES ($1, $2, $3, $4, $5, NULLIF($6, '')::uuid)
+		RETURNING restaurant_id, status
 	`
 
 	err = tx.QueryRow(
@@ -441,7 +480,8 @@ func (r *restaurantRepository) CreateRestaurant(
 		restaurant.Name,
 		restaurant.Latitude,
 		restaurant.Longitude,
-	).Scan(&restaurant.ID)
+		restaurant.OwnerID,
+	).Scan(&restaurant.ID, &restaurant.Status)
 	if err != nil {
 		return nil, err
 	}
@@ -474,6 +514,26 @@ func (r *restaurantRepository) CreateRestaurant(
 	return restaurant, nil
 }
 
+// GetRestaurantOwner implements [domain.RestaurantRepository].
+func (r *restaurantRepository) GetRestaurantOwner(ctx context.Context, restaurantID string) (string, error) {
+	query := `
+		SELECT COALESCE(owner_id::text, '')
+		FROM restaurants
+		WHERE restaurant_id = $1
+	`
+
+	var ownerID string
+	err := r.db.QueryRow(ctx, query, restaurantID).Scan(&ownerID)
+	if err != nil {
+		if isNotFound(err) {
+			return "", domain.ErrRestaurantNotFound
+		}
+		return "", err
+	}
+
+	return ownerID, nil
+}
+
 // GetRestaurantByID implements domain.RestaurantRepository.
 func (r *restaurantRepository) GetRestaurantByID(
 	ctx context.Context,
@@ -481,17 +541,17 @@ func (r *restaurantRepository) GetRestaurantByID(
 ) (*domain.Restaurant, error) {
 
 	query := `
-		SELECT restaurant_id, email, name, latitude, longitude
+		SELECT restaurant_id, email, name, latitude, longitude, status, COALESCE(owner_id::text, ''),
+			suspended_at, suspension_reason, COALESCE(suspended_by::text, '')
 		FROM restaurants
 		WHERE restaurant_id = $1
 	`
 
-	var res domain.Restaurant
-
-	err := r.db.QueryRow(ctx, query, restaurantID).
-		Scan(&res.ID, &res.Email, &res.Name, &res.Latitude, &res.Longitude)
-
+	res, err := scanRestaurant(r.db.QueryRow(ctx, query, restaurantID))
 	if err != nil {
+		if isNotFound(err) {
+			return nil, domain.ErrRestaurantNotFound
+		}
 		return nil, err
 	}
 
@@ -521,7 +581,162 @@ func (r *restaurantRepository) GetRestaurantByID(
 		res.MenuItems = append(res.MenuItems, item)
 	}
 
-	return &res, nil
+	return res, nil
+}
+
+// UpdateRestaurant implements [domain.RestaurantRepository].
+func (r *restaurantRepository) UpdateRestaurant(ctx context.Context, restaurant *domain.Restaurant) error {
+	query := `
+		UPDATE restaurants
+		SET email = $1, name = $2, latitude = $3, longitude = $4
+		WHERE restaurant_id = $5
+	`
+
+	affected, err := r.db.Exec(
+		ctx,
+		query,
+		restaurant.Email,
+		restaurant.Name,
+		restaurant.Latitude,
+		restaurant.Longitude,
+		restaurant.ID,
+	)
+
+	if err != nil {
+		if isUniqueViolation(err, "restaurants_email_key") {
+			return domain.ErrRestaurantAlreadyExists
+		}
+		return err
+	}
+
+	if affected == 0 {
+		return domain.ErrRestaurantNotFound
+	}
+
+	return nil
+}
+
+// SetRestaurantStatus implements [domain.RestaurantRepository].
+func (r *restaurantRepository) SetRestaurantStatus(ctx context.Context, restaurantID string, status string) error {
+	query := `
+		UPDATE restaurants
+		SET status = $1, deactivated_at = COALESCE(deactivated_at, NOW())
+		WHERE restaurant_id = $2 AND status <> $3
+	`
+	if status == domain.RESTAURANT_STATUS_ACTIVE {
+		query = `
+			UPDATE restaurants
+			SET status = $1, deactivated_at = NULL
+			WHERE restaurant_id = $2 AND status <> $3
+		`
+	}
+
+	affected, err := r.db.Exec(ctx, query, status, restaurantID, domain.RESTAURANT_STATUS_SUSPENDED)
+	if err != nil {
+		return err
+	}
+
+	if affected == 0 {
+		return r.restaurantUnchanged(ctx, restaurantID)
+	}
+
+	logger.Info("changed restaurant status", zap.String("restaurant_id", restaurantID), zap.String("status", status))
+
+	return nil
+}
+
+// SearchRestaurants implements [domain.RestaurantRepository].
+func (r *restaurantRepository) SearchRestaurants(ctx context.Context, search domain.RestaurantSearch) ([]domain.Restaurant, error) {
+	query := `
+		SELECT restaurant_id, email, name, latitude, longitude, status, COALESCE(owner_id::text, ''),
+			suspended_at, suspension_reason, COALESCE(suspended_by::text, '')
+		FROM restaurants
+		WHERE ($1 = '' OR name ILIKE $1 OR email ILIKE $1)
+			AND ($2 = '' OR status = $2)
+		ORDER BY name, restaurant_id
+		LIMIT $3 OFFSET $4
+	`
+
+	pattern := ""
+	if search.Query != "" {
+		pattern = "%" + likeEscaper.Replace(search.Query) + "%"
+	}
+
+	rows, err := r.db.Query(ctx, query, pattern, search.Status, search.Limit, search.Offset)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var restaurants []domain.Restaurant
+	for rows.Next() {
+		res, err := scanRestaurant(rows)
+		if err != nil {
+			return nil, err
+		}
+		restaurants = append(restaurants, *res)
+	}
+
+	return restaurants, rows.Err()
+}
+
+// SuspendRestaurant implements [domain.RestaurantRepository].
+func (r *restaurantRepository) SuspendRestaurant(ctx context.Context, restaurantID string, suspension domain.Suspension) error {
+	query := `
+		UPDATE restaurants
+		SET status = $2, suspension_reason = $3, suspended_by = NULLIF($4, '')::uuid,
+			suspended_at = NOW(), deactivated_at = COALESCE(deactivated_at, NOW())
+		WHERE restaurant_id = $1
+	`
+
+	affected, err := r.db.Exec(ctx, query, restaurantID, domain.RESTAURANT_STATUS_SUSPENDED, suspension.Reason, suspension.SuspendedBy)
+	if err != nil {
+		return err
+	}
+
+	if affected == 0 {
+		return domain.ErrRestaurantNotFound
+	}
+
+	return nil
+}
+
+// UnsuspendRestaurant implements [domain.RestaurantRepository].
+func (r *restaurantRepository) UnsuspendRestaurant(ctx context.Context, restaurantID string) error {
+	query := `
+		UPDATE restaurants
+		SET status = CASE WHEN status = $2 THEN $3 ELSE status END,
+			deactivated_at = CASE WHEN status = $2 THEN NULL ELSE deactivated_at END,
+			suspension_reason = '', suspended_by = NULL, suspended_at = NULL
+		WHERE restaurant_id = $1
+	`
+
+	affected, err := r.db.Exec(ctx, query, restaurantID, domain.RESTAURANT_STATUS_SUSPENDED, domain.RESTAURANT_STATUS_ACTIVE)
+	if err != nil {
+		return err
+	}
+
+	if affected == 0 {
+		return domain.ErrRestaurantNotFound
+	}
+
+	return nil
+}
+
+// restaurantUnchanged tells why a status change touched no restaurant.
+func (r *restaurantRepository) restaurantUnchanged(ctx context.Context, restaurantID string) error {
+	var status string
+	err := r.db.QueryRow(ctx, `SELECT status FROM restaurants WHERE restaurant_id = $1`, restaurantID).Scan(&status)
+	if err != nil {
+		if isNotFound(err) {
+			return domain.ErrRestaurantNotFound
+		}
+		return err
+	}
+	if status == domain.RESTAURANT_STATUS_SUSPENDED {
+		return domain.ErrRestaurantSuspended
+	}
+	return domain.ErrRestaurantNotFound
 }
 
 // GetRestaurants implements domain.RestaurantRepository.
@@ -531,10 +746,11 @@ func (r *restaurantRepository) StreamRestaurants(
 	onRow func(domain.Restaurant) error,
 ) error {
 	query := `
-		SELECT restaurant_id, email, name, latitude, longitude
+		SELECT restaurant_id, email, name, latitude, longitude, status
 		FROM restaurants
+		WHERE status = $1
 	`
-	rows, err := r.db.Query(ctx, query)
+	rows, err := r.db.Query(ctx, query, domain.RESTAURANT_STATUS_ACTIVE)
 	if err != nil {
 		return err
 	}
@@ -548,7 +764,7 @@ func (r *restaurantRepository) StreamRestaurants(
 		}
 
 		var res domain.Restaurant
-		if err := rows.Scan(&res.ID, &res.Email, &res.Name, &res.Latitude, &res.Longitude); err != nil {
+		if err := rows.Scan(&res.ID, &res.Email, &res.Name, &res.Latitude, &res.Longitude, &res.Status); err != nil {
 			return err
 		}
 
@@ -646,6 +862,145 @@ func (r *restaurantRepository) UpdateMenuItem(
 	return &item, nil
 }
 
+// UpsertMenuItems implements [domain.RestaurantRepository].
+// Names are matched case-insensitively against the restaurant's current menu.
+func (r *restaurantRepository) UpsertMenuItems(
+	ctx context.Context,
+	restaurantID string,
+	items []domain.MenuItem,
+	dryRun bool,
+) (created int, updated int, err error) {
+	tx, err := r.db.BeginTx(ctx)
+	if err != nil {
+		return 0, 0, err
+	}
+
+	defer func() {
+		if err != nil || dryRun {
+			_ = tx.Rollback(ctx)
+		}
+	}()
+
+	// 1. Lock the restaurant so concurrent imports apply one after another
+	var id string
+	err = tx.QueryRow(ctx, `
+		SELECT restaurant_id
+		FROM restaurants
+		WHERE restaurant_id = $1
+		FOR UPDATE
+	`, restaurantID).Scan(&id)
+
+	if err != nil {
+		if isNotFound(err) {
+			err = domain.ErrRestaurantNotFound
+		}
+		return 0, 0, err
+	}
+
+	// 2. Index the current menu by name
+	rows, err := tx.Query(ctx, `
+		SELECT item_id, LOWER(name)
+		FROM menu_items
+		WHERE restaurant_id = $1
+	`, restaurantID)
+	if err != nil {
+		return 0, 0, err
+	}
+
+	existing := make(map[string]string)
+	for rows.Next() {
+		var itemID, name string
+		if err = rows.Scan(&itemID, &name); err != nil {
+			rows.Close()
+			return 0, 0, err
+		}
+		existing[name] = itemID
+	}
+	rows.Close()
+	if err = rows.Err(); err != nil {
+		return 0, 0, err
+	}
+
+	// 3. Update matching items and insert the rest
+	for _, item := range items {
+		if itemID, ok := existing[strings.ToLower(item.Name)]; ok {
+			_, err = tx.Exec(ctx, `
+				UPDATE menu_items
+				SET name = $1, description = $2, price = $3
+				WHERE item_id = $4
+			`, item.Name, item.Description, item.Price, itemID)
+			if err != nil {
+				return 0, 0, err
+			}
+			updated++
+			continue
+		}
+
+		_, err = tx.Exec(ctx, `
+			INSERT INTO menu_items (restaurant_id, name, description, price)
+			VALUES ($1, $2, $3, $4)
+		`, restaurantID, item.Name, item.Description, item.Price)
+		if err != nil {
+			return 0, 0, err
+		}
+		created++
+	}
+
+	if dryRun {
+		return created, updated, nil
+	}
+
+	// 4. Commit transaction
+	if err = tx.Commit(ctx); err != nil {
+		return 0, 0, err
+	}
+
+	logger.Info("imported menu", zap.String("restaurant_id", restaurantID), zap.Int("created", created), zap.Int("updated", updated))
+
+	return created, updated, nil
+}
+
+// scanRestaurant reads a restaurant row selected with its owner and suspension.
+func scanRestaurant(row pgx.Row) (*domain.Restaurant, error) {
+	var res domain.Restaurant
+	var suspendedAt *time.Time
+	var suspension domain.Suspension
+	err := row.Scan(
+		&res.ID,
+		&res.Email,
+		&res.Name,
+		&res.Latitude,
+		&res.Longitude,
+		&res.Status,
+		&res.OwnerID,
+		&suspendedAt,
+		&suspension.Reason,
+		&suspension.SuspendedBy,
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	if suspendedAt != nil {
+		suspension.SuspendedAt = *suspendedAt
+		res.Suspension = &suspension
+	}
+	return &res, nil
+}
+
+// isUniqueViolation reports whether err is a Postgres unique violation on the given constraint.
+func isUniqueViolation(err error, constraint string) bool {
+	var pgErr *pgconn.PgError
+	return errors.As(err, &pgErr) && pgErr.Code == "23505" && pgErr.ConstraintName == constraint
+}
+
+// isNotFound reports whether a lookup found no row, including when it was
+// given an ID that is not a UUID and so cannot name any row.
+func isNotFound(err error) bool {
+	var pgErr *pgconn.PgError
+	return errors.Is(err, pgx.ErrNoRows) || (errors.As(err, &pgErr) && pgErr.Code == "22P02")
+}
+
 // NewRestaurantRepository creates a new instance of RestaurantRepository.
 func NewRestaurantRepository(db postgres.PostgresClient) domain.RestaurantRepository {
 	return &restaurantRepository{db: db}
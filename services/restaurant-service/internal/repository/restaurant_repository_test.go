@@ -0,0 +1,60 @@
+package repository
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgconn"
+	"github.com/tamirat-dejene/ha-soranu/services/restaurant-service/internal/domain"
+	postgres "github.com/tamirat-dejene/ha-soranu/shared/db/pg"
+)
+
+// rowErrClient is a database whose single-row queries all fail with err.
+type rowErrClient struct {
+	postgres.PostgresClient
+	err error
+}
+
+func (c rowErrClient) QueryRow(context.Context, string, ...any) postgres.Row {
+	return errRow{err: c.err}
+}
+
+type errRow struct{ err error }
+
+func (r errRow) Scan(...any) error { return r.err }
+
+func TestLookupsReportMissingRows(t *testing.T) {
+	dbErr := errors.New("connection reset")
+
+	tests := []struct {
+		name    string
+		scanErr error
+		want    error
+	}{
+		{"no row", pgx.ErrNoRows, domain.ErrOrderNotFound},
+		{"id is not a uuid", &pgconn.PgError{Code: "22P02"}, domain.ErrOrderNotFound},
+		{"other error", dbErr, dbErr},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			repo := NewRestaurantRepository(rowErrClient{err: tt.scanErr})
+
+			_, err := repo.GetOrderByID(context.Background(), "order-1")
+			if !errors.Is(err, tt.want) {
+				t.Fatalf("GetOrderByID() error = %v, want %v", err, tt.want)
+			}
+
+			wantRestaurant := tt.want
+			if wantRestaurant == domain.ErrOrderNotFound {
+				wantRestaurant = domain.ErrRestaurantNotFound
+			}
+			_, err = repo.GetRestaurantByID(context.Background(), "restaurant-1")
+			if !errors.Is(err, wantRestaurant) {
+				t.Fatalf("GetRestaurantByID() error = %v, want %v", err, wantRestaurant)
+			}
+		})
+	}
+}
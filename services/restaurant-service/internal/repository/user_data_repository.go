@@ -0,0 +1,92 @@
+package repository
+
+import (
+	"context"
+
+	"github.com/tamirat-dejene/ha-soranu/services/restaurant-service/internal/domain"
+	postgres "github.com/tamirat-dejene/ha-soranu/shared/db/pg"
+)
+
+type userDataRepository struct {
+	db postgres.PostgresClient
+}
+
+// GetCustomerOrders implements [domain.UserDataRepository].
+func (u *userDataRepository) GetCustomerOrders(ctx context.Context, customerID string) ([]domain.Order, error) {
+	query := `
+		SELECT order_id, customer_id, restaurant_id, total_price, status
+		FROM orders
+		WHERE customer_id = $1
+		ORDER BY order_id
+	`
+
+	return u.getOrders(ctx, query, customerID)
+}
+
+// GetDriverDeliveries implements [domain.UserDataRepository].
+func (u *userDataRepository) GetDriverDeliveries(ctx context.Context, driverID string) ([]domain.Order, error) {
+	query := `
+		SELECT order_id, customer_id, restaurant_id, total_price, status
+		FROM orders
+		WHERE driver_id = $1
+		ORDER BY order_id
+	`
+
+	return u.getOrders(ctx, query, driverID)
+}
+
+// AnonymizeCustomer implements [domain.UserDataRepository].
+func (u *userDataRepository) AnonymizeCustomer(ctx context.Context, customerID string) (int, error) {
+	// The idempotency key would tie the orders back to the requests of the
+	// customer's devices.
+	query := `
+		UPDATE orders
+		SET customer_id = $2, idempotency_key = NULL, request_hash = NULL
+		WHERE customer_id = $1
+	`
+
+	return u.db.Exec(ctx, query, customerID, domain.AnonymousCustomerID)
+}
+
+// ReleaseDriver implements [domain.UserDataRepository].
+func (u *userDataRepository) ReleaseDriver(ctx context.Context, driverID string) (int, error) {
+	// The dispatch sweeper reassigns expired offers.
+	query := `
+		UPDATE delivery_offers
+		SET expires_at = NOW()
+		WHERE driver_id = $1 AND status = $2 AND expires_at > NOW()
+	`
+
+	return u.db.Exec(ctx, query, driverID, domain.OFFER_STATUS_PENDING)
+}
+
+func (u *userDataRepository) getOrders(ctx context.Context, query string, args ...any) ([]domain.Order, error) {
+	rows, err := u.db.Query(ctx, query, args...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	orders := make([]domain.Order, 0)
+	for rows.Next() {
+		var ord domain.Order
+		if err := rows.Scan(&ord.OrderId, &ord.CustomerID, &ord.RestaurantID, &ord.TotalAmount, &ord.Status); err != nil {
+			return nil, err
+		}
+		orders = append(orders, ord)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+
+	if err := loadOrderItems(ctx, u.db, orders); err != nil {
+		return nil, err
+	}
+
+	return orders, nil
+}
+
+// NewUserDataRepository creates a new instance of UserDataRepository.
+func NewUserDataRepository(db postgres.PostgresClient) domain.UserDataRepository {
+	return &userDataRepository{db: db}
+}
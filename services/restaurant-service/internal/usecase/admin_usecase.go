@@ -0,0 +1,125 @@
+package usecase
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+	"unicode/utf8"
+
+	"github.com/tamirat-dejene/ha-soranu/services/restaurant-service/internal/domain"
+	"github.com/tamirat-dejene/ha-soranu/shared/pkg/auth/svcauth"
+	"github.com/tamirat-dejene/ha-soranu/shared/pkg/logger"
+	"go.uber.org/zap"
+)
+
+const (
+	// defaultRestaurantSearchLimit is how many restaurants a search returns
+	// when it does not say, and maxRestaurantSearchLimit how many it may ask for.
+	defaultRestaurantSearchLimit = 20
+	maxRestaurantSearchLimit     = 100
+	// maxSuspensionReasonLength bounds the reason given for a suspension.
+	maxSuspensionReasonLength = 500
+)
+
+type adminUseCase struct {
+	restaurants domain.RestaurantRepository
+	userData    domain.UserDataRepository
+	timeout     time.Duration
+}
+
+// SearchRestaurants implements [domain.AdminUseCase].
+func (a *adminUseCase) SearchRestaurants(ctx context.Context, search domain.RestaurantSearch) ([]domain.Restaurant, error) {
+	if !svcauth.IsAdmin(ctx) {
+		return nil, domain.ErrPermissionDenied
+	}
+
+	c, cancel := context.WithTimeout(ctx, a.timeout)
+	defer cancel()
+
+	switch search.Status {
+	case "", domain.RESTAURANT_STATUS_ACTIVE, domain.RESTAURANT_STATUS_INACTIVE, domain.RESTAURANT_STATUS_SUSPENDED:
+	default:
+		return nil, domain.NewValidationError(domain.ErrInvalidSearchData, "status", "is not a restaurant status")
+	}
+	if search.Limit < 0 {
+		return nil, domain.NewValidationError(domain.ErrInvalidSearchData, "limit", "must not be negative")
+	}
+	if search.Offset < 0 {
+		return nil, domain.NewValidationError(domain.ErrInvalidSearchData, "offset", "must not be negative")
+	}
+	if search.Limit == 0 {
+		search.Limit = defaultRestaurantSearchLimit
+	}
+	search.Limit = min(search.Limit, maxRestaurantSearchLimit)
+	search.Query = strings.TrimSpace(search.Query)
+
+	return a.restaurants.SearchRestaurants(c, search)
+}
+
+// SuspendRestaurant implements [domain.AdminUseCase].
+func (a *adminUseCase) SuspendRestaurant(ctx context.Context, restaurantID string, reason string) (*domain.Restaurant, error) {
+	admin, ok := svcauth.UserFromContext(ctx)
+	if !ok || !svcauth.IsAdmin(ctx) {
+		return nil, domain.ErrPermissionDenied
+	}
+
+	c, cancel := context.WithTimeout(ctx, a.timeout)
+	defer cancel()
+
+	reason = strings.TrimSpace(reason)
+	if reason == "" || utf8.RuneCountInString(reason) > maxSuspensionReasonLength {
+		return nil, domain.NewValidationError(domain.ErrInvalidSuspension, "reason", fmt.Sprintf("is required and must be at most %d characters", maxSuspensionReasonLength))
+	}
+
+	err := a.restaurants.SuspendRestaurant(c, restaurantID, domain.Suspension{
+		Reason:      reason,
+		SuspendedBy: admin.ID,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	logger.Info("restaurant suspended",
+		zap.String("restaurant_id", restaurantID),
+		zap.String("admin_id", admin.ID),
+		zap.String("reason", reason),
+	)
+
+	return a.restaurants.GetRestaurantByID(c, restaurantID)
+}
+
+// UnsuspendRestaurant implements [domain.AdminUseCase].
+func (a *adminUseCase) UnsuspendRestaurant(ctx context.Context, restaurantID string) (*domain.Restaurant, error) {
+	admin, ok := svcauth.UserFromContext(ctx)
+	if !ok || !svcauth.IsAdmin(ctx) {
+		return nil, domain.ErrPermissionDenied
+	}
+
+	c, cancel := context.WithTimeout(ctx, a.timeout)
+	defer cancel()
+
+	if err := a.restaurants.UnsuspendRestaurant(c, restaurantID); err != nil {
+		return nil, err
+	}
+
+	logger.Info("restaurant unsuspended", zap.String("restaurant_id", restaurantID), zap.String("admin_id", admin.ID))
+
+	return a.restaurants.GetRestaurantByID(c, restaurantID)
+}
+
+// GetUserOrders implements [domain.AdminUseCase].
+func (a *adminUseCase) GetUserOrders(ctx context.Context, userID string) ([]domain.Order, error) {
+	if !svcauth.IsAdmin(ctx) {
+		return nil, domain.ErrPermissionDenied
+	}
+
+	c, cancel := context.WithTimeout(ctx, a.timeout)
+	defer cancel()
+
+	return a.userData.GetCustomerOrders(c, userID)
+}
+
+func NewAdminUseCase(restaurants domain.RestaurantRepository, userData domain.UserDataRepository, timeout time.Duration) domain.AdminUseCase {
+	return &adminUseCase{restaurants: restaurants, userData: userData, timeout: timeout}
+}
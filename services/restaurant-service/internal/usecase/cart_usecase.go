@@ -0,0 +1,374 @@
+package usecase
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/tamirat-dejene/ha-soranu/services/restaurant-service/internal/domain"
+	"github.com/tamirat-dejene/ha-soranu/shared/pkg/auth/svcauth"
+	"github.com/tamirat-dejene/ha-soranu/shared/pkg/logger"
+	"go.uber.org/zap"
+)
+
+const (
+	// maxCartItems bounds the number of distinct items in a cart.
+	maxCartItems = 50
+	// maxCartItemQuantity bounds the quantity of a single cart item.
+	maxCartItemQuantity = 99
+	// maxCartAttempts bounds how often a cart change is tried while other
+	// requests keep changing the same cart.
+	maxCartAttempts = 3
+)
+
+type cartUseCase struct {
+	repo        domain.CartRepository
+	restaurants domain.RestaurantRepository
+	orders      domain.RestaurantUseCase
+	timeout     time.Duration
+}
+
+// GetCart implements [domain.CartUseCase].
+func (u *cartUseCase) GetCart(ctx context.Context, customerID string) (*domain.Cart, error) {
+	if customerID == "" {
+		return nil, domain.NewValidationError(domain.ErrInvalidCartData, "customer_id", "is required")
+	}
+	if !svcauth.CanActFor(ctx, customerID) {
+		return nil, domain.ErrPermissionDenied
+	}
+
+	c, cancel := context.WithTimeout(ctx, u.timeout)
+	defer cancel()
+
+	return u.updateCart(c, customerID, func(cart *domain.Cart) (*domain.Cart, error) {
+		if len(cart.Items) == 0 {
+			return cart, nil
+		}
+		return cart, u.refresh(c, cart)
+	})
+}
+
+// AddCartItem implements [domain.CartUseCase].
+func (u *cartUseCase) AddCartItem(ctx context.Context, customerID, restaurantID, itemID string, quantity int32) (*domain.Cart, error) {
+	if err := validateCartItem(customerID, itemID, quantity, 1); err != nil {
+		return nil, err
+	}
+	if restaurantID == "" {
+		return nil, domain.NewValidationError(domain.ErrInvalidCartData, "restaurant_id", "is required")
+	}
+	if !svcauth.CanActFor(ctx, customerID) {
+		return nil, domain.ErrPermissionDenied
+	}
+
+	c, cancel := context.WithTimeout(ctx, u.timeout)
+	defer cancel()
+
+	restaurant, err := u.restaurants.GetRestaurantByID(c, restaurantID)
+	if err != nil {
+		return nil, err
+	}
+	if restaurant.Status != domain.RESTAURANT_STATUS_ACTIVE {
+		return nil, domain.ErrRestaurantInactive
+	}
+
+	menuItem, ok := findMenuItem(restaurant.MenuItems, itemID)
+	if !ok {
+		return nil, domain.ErrMenuItemNotFound
+	}
+
+	return u.updateCart(c, customerID, func(cart *domain.Cart) (*domain.Cart, error) {
+		if len(cart.Items) > 0 && cart.RestaurantID != restaurantID {
+			return nil, domain.ErrCartRestaurantMismatch
+		}
+
+		cart.RestaurantID = restaurant.ID
+		if i := findCartItem(cart.Items, menuItem.ItemID); i >= 0 {
+			if cart.Items[i].Quantity+quantity > maxCartItemQuantity {
+				return nil, domain.NewValidationError(domain.ErrInvalidCartData, "quantity", fmt.Sprintf("would bring the item to more than %d", maxCartItemQuantity))
+			}
+			cart.Items[i].Quantity += quantity
+		} else {
+			if len(cart.Items) >= maxCartItems {
+				return nil, domain.ErrCartFull
+			}
+			cart.Items = append(cart.Items, domain.CartItem{
+				ItemID:    menuItem.ItemID,
+				Name:      menuItem.Name,
+				Quantity:  quantity,
+				UnitPrice: menuItem.Price,
+			})
+		}
+
+		applyMenu(cart, restaurant)
+
+		if err := u.repo.SaveCart(c, cart); err != nil {
+			return nil, err
+		}
+
+		return cart, nil
+	})
+}
+
+// UpdateCartItem implements [domain.CartUseCase].
+func (u *cartUseCase) UpdateCartItem(ctx context.Context, customerID, itemID string, quantity int32) (*domain.Cart, error) {
+	if err := validateCartItem(customerID, itemID, quantity, 0); err != nil {
+		return nil, err
+	}
+	if !svcauth.CanActFor(ctx, customerID) {
+		return nil, domain.ErrPermissionDenied
+	}
+	if quantity == 0 {
+		return u.RemoveCartItem(ctx, customerID, itemID)
+	}
+
+	c, cancel := context.WithTimeout(ctx, u.timeout)
+	defer cancel()
+
+	return u.updateCart(c, customerID, func(cart *domain.Cart) (*domain.Cart, error) {
+		i := findCartItem(cart.Items, itemID)
+		if i < 0 {
+			return nil, domain.ErrCartItemNotFound
+		}
+		cart.Items[i].Quantity = quantity
+
+		return cart, u.refresh(c, cart)
+	})
+}
+
+// RemoveCartItem implements [domain.CartUseCase].
+func (u *cartUseCase) RemoveCartItem(ctx context.Context, customerID, itemID string) (*domain.Cart, error) {
+	if err := validateCartItem(customerID, itemID, 0, 0); err != nil {
+		return nil, err
+	}
+	if !svcauth.CanActFor(ctx, customerID) {
+		return nil, domain.ErrPermissionDenied
+	}
+
+	c, cancel := context.WithTimeout(ctx, u.timeout)
+	defer cancel()
+
+	return u.updateCart(c, customerID, func(cart *domain.Cart) (*domain.Cart, error) {
+		i := findCartItem(cart.Items, itemID)
+		if i < 0 {
+			return nil, domain.ErrCartItemNotFound
+		}
+		cart.Items = append(cart.Items[:i], cart.Items[i+1:]...)
+
+		// An empty cart is no longer tied to a restaurant. It is saved rather
+		// than deleted so an item added meanwhile is not lost.
+		if len(cart.Items) == 0 {
+			cart.RestaurantID = ""
+			if err := u.repo.SaveCart(c, cart); err != nil {
+				return nil, err
+			}
+			return &domain.Cart{CustomerID: customerID}, nil
+		}
+
+		return cart, u.refresh(c, cart)
+	})
+}
+
+// ClearCart implements [domain.CartUseCase].
+func (u *cartUseCase) ClearCart(ctx context.Context, customerID string) (*domain.Cart, error) {
+	if customerID == "" {
+		return nil, domain.NewValidationError(domain.ErrInvalidCartData, "customer_id", "is required")
+	}
+	if !svcauth.CanActFor(ctx, customerID) {
+		return nil, domain.ErrPermissionDenied
+	}
+
+	c, cancel := context.WithTimeout(ctx, u.timeout)
+	defer cancel()
+
+	if err := u.repo.DeleteCart(c, customerID); err != nil {
+		return nil, err
+	}
+
+	return &domain.Cart{CustomerID: customerID}, nil
+}
+
+// Checkout implements [domain.CartUseCase].
+func (u *cartUseCase) Checkout(ctx context.Context, customerID, idempotencyKey string) (*domain.Order, error) {
+	if customerID == "" {
+		return nil, domain.NewValidationError(domain.ErrInvalidCartData, "customer_id", "is required")
+	}
+	if !svcauth.CanActFor(ctx, customerID) {
+		return nil, domain.ErrPermissionDenied
+	}
+
+	c, cancel := context.WithTimeout(ctx, u.timeout)
+	defer cancel()
+
+	cart, err := u.updateCart(c, customerID, func(cart *domain.Cart) (*domain.Cart, error) {
+		if len(cart.Items) == 0 {
+			return cart, nil
+		}
+		return cart, u.refresh(c, cart)
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	if len(cart.Items) == 0 {
+		// The cart is gone after a successful checkout, so a retry of it is
+		// answered from the order placed under the same key.
+		if idempotencyKey != "" {
+			ord, _, err := u.restaurants.GetOrderByIdempotencyKey(c, customerID, idempotencyKey)
+			if !errors.Is(err, domain.ErrOrderNotFound) {
+				return ord, err
+			}
+		}
+		return nil, domain.ErrCartEmpty
+	}
+
+	if !cart.Valid {
+		return nil, domain.ErrCartInvalid
+	}
+	for _, item := range cart.Items {
+		if item.PriceChanged {
+			// The new prices are saved, so checking out again accepts them.
+			return nil, domain.ErrCartChanged
+		}
+	}
+
+	items := make([]domain.OrderItem, 0, len(cart.Items))
+	for _, item := range cart.Items {
+		items = append(items, domain.OrderItem{ItemId: item.ItemID, Quantity: item.Quantity})
+	}
+
+	ord, err := u.orders.PlaceOrder(c, &domain.PlaceOrder{
+		CustomerID:     customerID,
+		RestaurantID:   cart.RestaurantID,
+		Items:          items,
+		IdempotencyKey: idempotencyKey,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	// The order stands even if the cart cannot be removed; it expires on its own.
+	if err := u.repo.DeleteCart(c, customerID); err != nil {
+		logger.Error("failed to delete cart after checkout", zap.String("customer_id", customerID), zap.Error(err))
+	}
+
+	return ord, nil
+}
+
+// updateCart reads the customer's cart and applies change to it, which saves
+// it. When another request changed the cart before change saved it, the cart
+// is read and changed again.
+func (u *cartUseCase) updateCart(ctx context.Context, customerID string, change func(*domain.Cart) (*domain.Cart, error)) (*domain.Cart, error) {
+	for attempt := 1; ; attempt++ {
+		cart, err := u.repo.GetCart(ctx, customerID)
+		if err != nil {
+			return nil, err
+		}
+
+		cart, err = change(cart)
+		if errors.Is(err, domain.ErrCartConflict) && attempt < maxCartAttempts {
+			continue
+		}
+		return cart, err
+	}
+}
+
+// refresh revalidates the cart against the restaurant's current menu and
+// saves it, which also restarts its expiry.
+func (u *cartUseCase) refresh(ctx context.Context, cart *domain.Cart) error {
+	restaurant, err := u.restaurants.GetRestaurantByID(ctx, cart.RestaurantID)
+	if err != nil && !errors.Is(err, domain.ErrRestaurantNotFound) {
+		return err
+	}
+
+	applyMenu(cart, restaurant)
+
+	return u.repo.SaveCart(ctx, cart)
+}
+
+// applyMenu updates names and prices from the menu, flags items whose price
+// changed or that can no longer be ordered, and recomputes the total. A nil
+// restaurant makes every item unavailable.
+func applyMenu(cart *domain.Cart, restaurant *domain.Restaurant) {
+	open := restaurant != nil && restaurant.Status == domain.RESTAURANT_STATUS_ACTIVE
+
+	var menu []domain.MenuItem
+	if restaurant != nil {
+		menu = restaurant.MenuItems
+	}
+
+	cart.Valid = open
+	cart.TotalAmount = 0
+
+	for i := range cart.Items {
+		item := &cart.Items[i]
+		item.PriceChanged = false
+		item.PreviousPrice = 0
+
+		menuItem, ok := findMenuItem(menu, item.ItemID)
+		item.Available = open && ok
+		if !ok {
+			cart.Valid = false
+			continue
+		}
+
+		item.Name = menuItem.Name
+		if menuItem.Price != item.UnitPrice {
+			item.PriceChanged = true
+			item.PreviousPrice = item.UnitPrice
+			item.UnitPrice = menuItem.Price
+		}
+
+		if item.Available {
+			cart.TotalAmount += float64(item.UnitPrice) * float64(item.Quantity)
+		}
+	}
+}
+
+func findMenuItem(items []domain.MenuItem, itemID string) (domain.MenuItem, bool) {
+	for _, item := range items {
+		if item.ItemID == itemID {
+			return item, true
+		}
+	}
+	return domain.MenuItem{}, false
+}
+
+func findCartItem(items []domain.CartItem, itemID string) int {
+	for i, item := range items {
+		if item.ItemID == itemID {
+			return i
+		}
+	}
+	return -1
+}
+
+// validateCartItem checks the IDs of a request on a cart item, and that
+// quantity is between minQuantity and maxCartItemQuantity.
+func validateCartItem(customerID, itemID string, quantity, minQuantity int32) error {
+	switch {
+	case customerID == "":
+		return domain.NewValidationError(domain.ErrInvalidCartData, "customer_id", "is required")
+	case itemID == "":
+		return domain.NewValidationError(domain.ErrInvalidCartData, "item_id", "is required")
+	case quantity < minQuantity || quantity > maxCartItemQuantity:
+		return domain.NewValidationError(domain.ErrInvalidCartData, "quantity", fmt.Sprintf("must be between %d and %d", minQuantity, maxCartItemQuantity))
+	}
+	return nil
+}
+
+// NewCartUseCase creates a new instance of CartUseCase. Orders are placed
+// through the restaurant use case so checkout behaves like PlaceOrder.
+func NewCartUseCase(
+	repo domain.CartRepository,
+	restaurants domain.RestaurantRepository,
+	orders domain.RestaurantUseCase,
+	timeout time.Duration,
+) domain.CartUseCase {
+	return &cartUseCase{
+		repo:        repo,
+		restaurants: restaurants,
+		orders:      orders,
+		timeout:     timeout,
+	}
+}
@@ -0,0 +1,147 @@
+package usecase
+
+import (
+	"context"
+	"errors"
+	"strconv"
+	"testing"
+	"time"
+
+	"github.com/tamirat-dejene/ha-soranu/services/restaurant-service/internal/domain"
+)
+
+// memoryCarts stores carts the way the cache does: a save only replaces the
+// revision it was read at. interfere, when set, runs before a save as a
+// concurrent request changing the stored cart.
+type memoryCarts struct {
+	cart      *domain.Cart
+	revision  int
+	interfere func(m *memoryCarts)
+}
+
+func (m *memoryCarts) GetCart(_ context.Context, customerID string) (*domain.Cart, error) {
+	if m.cart == nil {
+		return &domain.Cart{CustomerID: customerID}, nil
+	}
+	cart := *m.cart
+	cart.Items = append([]domain.CartItem(nil), m.cart.Items...)
+	cart.Revision = strconv.Itoa(m.revision)
+	return &cart, nil
+}
+
+func (m *memoryCarts) SaveCart(_ context.Context, cart *domain.Cart) error {
+	if m.interfere != nil {
+		m.interfere(m)
+	}
+
+	current := ""
+	if m.cart != nil {
+		current = strconv.Itoa(m.revision)
+	}
+	if cart.Revision != current {
+		return domain.ErrCartConflict
+	}
+
+	stored := *cart
+	stored.Items = append([]domain.CartItem(nil), cart.Items...)
+	m.cart = &stored
+	m.revision++
+	cart.Revision = strconv.Itoa(m.revision)
+	return nil
+}
+
+func (m *memoryCarts) DeleteCart(context.Context, string) error {
+	m.cart = nil
+	return nil
+}
+
+// menuRestaurants is a restaurant repository serving one active restaurant.
+type menuRestaurants struct {
+	domain.RestaurantRepository
+	restaurant *domain.Restaurant
+}
+
+func (r menuRestaurants) GetRestaurantByID(context.Context, string) (*domain.Restaurant, error) {
+	return r.restaurant, nil
+}
+
+func TestCartChangesSurviveConcurrentUpdates(t *testing.T) {
+	restaurant := &domain.Restaurant{
+		ID:     "restaurant-1",
+		Status: domain.RESTAURANT_STATUS_ACTIVE,
+		MenuItems: []domain.MenuItem{
+			{ItemID: "pizza", Name: "Pizza", Price: 10},
+			{ItemID: "salad", Name: "Salad", Price: 6},
+		},
+	}
+
+	tests := []struct {
+		name string
+		// conflicts is how many times a concurrent request adds a pizza
+		// before our save.
+		conflicts  int
+		wantErr    error
+		wantPizzas int32
+	}{
+		{name: "no other request", conflicts: 0, wantPizzas: 0},
+		{name: "other request saves first", conflicts: 1, wantPizzas: 1},
+		{name: "retried until the last attempt", conflicts: maxCartAttempts - 1, wantPizzas: maxCartAttempts - 1},
+		{name: "cart keeps changing", conflicts: maxCartAttempts, wantErr: domain.ErrCartConflict},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			carts := &memoryCarts{}
+			conflicts := tt.conflicts
+			carts.interfere = func(m *memoryCarts) {
+				if conflicts == 0 {
+					return
+				}
+				conflicts--
+				// Another request adds a pizza behind our back.
+				other := *m
+				other.interfere = nil
+				current, _ := other.GetCart(context.Background(), "customer-1")
+				if i := findCartItem(current.Items, "pizza"); i >= 0 {
+					current.Items[i].Quantity++
+				} else {
+					current.RestaurantID = restaurant.ID
+					current.Items = append(current.Items, domain.CartItem{ItemID: "pizza", Name: "Pizza", Quantity: 1, UnitPrice: 10})
+				}
+				if err := other.SaveCart(context.Background(), current); err != nil {
+					t.Fatalf("concurrent SaveCart() error = %v", err)
+				}
+				m.cart, m.revision = other.cart, other.revision
+			}
+
+			u := NewCartUseCase(carts, menuRestaurants{restaurant: restaurant}, nil, time.Second)
+
+			cart, err := u.AddCartItem(context.Background(), "customer-1", restaurant.ID, "salad", 1)
+			if tt.wantErr != nil {
+				if !errors.Is(err, tt.wantErr) {
+					t.Fatalf("AddCartItem() error = %v, want %v", err, tt.wantErr)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("AddCartItem() error = %v", err)
+			}
+
+			stored, _ := carts.GetCart(context.Background(), "customer-1")
+			for _, got := range []*domain.Cart{cart, stored} {
+				var pizzas, salads int32
+				for _, item := range got.Items {
+					switch item.ItemID {
+					case "pizza":
+						pizzas = item.Quantity
+					case "salad":
+						salads = item.Quantity
+					}
+				}
+				if pizzas != tt.wantPizzas || salads != 1 {
+					t.Errorf("cart holds %d pizzas and %d salads, want %d and 1", pizzas, salads, tt.wantPizzas)
+				}
+			}
+		})
+	}
+}
@@ -0,0 +1,223 @@
+package usecase
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/tamirat-dejene/ha-soranu/services/restaurant-service/internal/domain"
+	"github.com/tamirat-dejene/ha-soranu/shared/pkg/auth/svcauth"
+	"github.com/tamirat-dejene/ha-soranu/shared/pkg/events"
+	"github.com/tamirat-dejene/ha-soranu/shared/pkg/logger"
+	"github.com/tamirat-dejene/ha-soranu/shared/protos/orderpb"
+	"go.uber.org/zap"
+)
+
+// dispatchSweepBatchSize caps how many waiting orders one sweep retries.
+const dispatchSweepBatchSize = 50
+
+type dispatchUseCase struct {
+	repo          domain.DispatchRepository
+	restaurants   domain.RestaurantRepository
+	locator       domain.DriverLocator
+	publisher     events.EventPublisher
+	radiusKm      float32
+	policy        domain.OfferPolicy
+	sweepInterval time.Duration
+	timeout       time.Duration
+}
+
+// ShipOrder implements [domain.Dispatcher].
+func (d *dispatchUseCase) ShipOrder(ctx context.Context, restaurantID string, orderID string) (*domain.Dispatch, error) {
+	c, cancel := context.WithTimeout(ctx, d.timeout)
+	defer cancel()
+
+	if err := authorizeRestaurant(c, d.restaurants, restaurantID); err != nil {
+		return nil, err
+	}
+
+	target, err := d.repo.ShipOrder(c, restaurantID, orderID)
+	if err != nil {
+		return nil, err
+	}
+
+	update_event := orderpb.OrderStatusUpdated{
+		OrderId:       target.OrderID,
+		CustomerId:    target.CustomerID,
+		RestaurantId:  target.RestaurantID,
+		NewStatus:     orderpb.OrderStatus_SHIPPED,
+		UpdatedAtUnix: time.Now().Unix(),
+	}
+
+	if err := d.publisher.PublishOrderStatusUpdated(c, &update_event); err != nil {
+		logger.Error("failed to publish order status updated event", zap.Error(err))
+		return nil, err
+	}
+
+	dispatch, err := d.offer(c, *target)
+	if err != nil {
+		// The order is shipped and queued either way; an offer already in
+		// flight or a failed driver lookup is left to the sweeper.
+		if !errors.Is(err, domain.ErrOrderNotAwaitingDriver) {
+			logger.Error("failed to offer delivery", zap.String("order_id", orderID), zap.Error(err))
+		}
+		return d.repo.GetDispatch(c, orderID)
+	}
+
+	return dispatch, nil
+}
+
+// GetDeliveryOffers implements [domain.Dispatcher].
+func (d *dispatchUseCase) GetDeliveryOffers(ctx context.Context, driverID string) ([]domain.DeliveryOffer, error) {
+	c, cancel := context.WithTimeout(ctx, d.timeout)
+	defer cancel()
+
+	driverID, err := d.resolveDriver(c, driverID)
+	if err != nil {
+		return nil, err
+	}
+
+	return d.repo.GetPendingOffers(c, driverID)
+}
+
+// RespondToOffer implements [domain.Dispatcher].
+func (d *dispatchUseCase) RespondToOffer(ctx context.Context, offerID string, driverID string, accept bool) (*domain.DeliveryOffer, error) {
+	c, cancel := context.WithTimeout(ctx, d.timeout)
+	defer cancel()
+
+	driverID, err := d.resolveDriver(c, driverID)
+	if err != nil {
+		return nil, err
+	}
+
+	offer, err := d.repo.RespondToOffer(c, offerID, driverID, accept)
+	if err != nil {
+		return nil, err
+	}
+
+	logger.Info("driver responded to delivery offer", zap.String("offer_id", offer.OfferID), zap.String("driver_id", driverID), zap.String("status", offer.Status))
+
+	if !accept {
+		// Move on to the next driver right away instead of waiting for the sweeper.
+		if err := d.redispatch(c, offer.OrderID); err != nil {
+			logger.Error("failed to redispatch order", zap.String("order_id", offer.OrderID), zap.Error(err))
+		}
+	}
+
+	return offer, nil
+}
+
+// Run implements [domain.Dispatcher].
+func (d *dispatchUseCase) Run(ctx context.Context) {
+	ticker := time.NewTicker(d.sweepInterval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			d.sweep(ctx)
+		}
+	}
+}
+
+// sweep expires lapsed offers and offers the orders waiting longest to the
+// next available driver.
+func (d *dispatchUseCase) sweep(ctx context.Context) {
+	c, cancel := context.WithTimeout(ctx, d.timeout)
+	defer cancel()
+
+	expired, err := d.repo.ExpireOffers(c)
+	if err != nil {
+		logger.Error("failed to expire delivery offers", zap.Error(err))
+		return
+	}
+	if expired > 0 {
+		logger.Info("expired delivery offers", zap.Int("count", expired))
+	}
+
+	targets, err := d.repo.GetDispatchTargets(c, dispatchSweepBatchSize)
+	if err != nil {
+		logger.Error("failed to get orders waiting for a driver", zap.Error(err))
+		return
+	}
+
+	for _, target := range targets {
+		if _, err := d.offer(c, target); err != nil && !errors.Is(err, domain.ErrOrderNotAwaitingDriver) {
+			logger.Error("failed to offer delivery", zap.String("order_id", target.OrderID), zap.Error(err))
+		}
+	}
+}
+
+func (d *dispatchUseCase) redispatch(ctx context.Context, orderID string) error {
+	target, err := d.repo.GetDispatchTarget(ctx, orderID)
+	if err != nil {
+		return err
+	}
+
+	_, err = d.offer(ctx, *target)
+	if errors.Is(err, domain.ErrOrderNotAwaitingDriver) {
+		return nil
+	}
+	return err
+}
+
+func (d *dispatchUseCase) offer(ctx context.Context, target domain.DispatchTarget) (*domain.Dispatch, error) {
+	drivers, err := d.locator.NearbyDrivers(ctx, target.Latitude, target.Longitude, d.radiusKm)
+	if err != nil {
+		return nil, err
+	}
+
+	return d.repo.CreateOffer(ctx, target.OrderID, drivers, d.policy)
+}
+
+// resolveDriver returns the driver a call acts for: the driver profile of its
+// user when driverID is empty, or driverID when the user is that driver or an
+// admin. Calls without a user must name the driver.
+func (d *dispatchUseCase) resolveDriver(ctx context.Context, driverID string) (string, error) {
+	if driverID != "" && svcauth.IsAdmin(ctx) {
+		return driverID, nil
+	}
+
+	user, ok := svcauth.UserFromContext(ctx)
+	if !ok {
+		if driverID == "" || !svcauth.CanActFor(ctx, driverID) {
+			return "", domain.ErrPermissionDenied
+		}
+		return driverID, nil
+	}
+
+	ownID, err := d.locator.DriverID(ctx, user.ID)
+	if err != nil {
+		return "", err
+	}
+	if ownID == "" || (driverID != "" && driverID != ownID) {
+		return "", domain.ErrPermissionDenied
+	}
+
+	return ownID, nil
+}
+
+// NewDispatchUseCase creates a new instance of Dispatcher.
+func NewDispatchUseCase(
+	repo domain.DispatchRepository,
+	restaurants domain.RestaurantRepository,
+	locator domain.DriverLocator,
+	publisher events.EventPublisher,
+	radiusKm float32,
+	policy domain.OfferPolicy,
+	sweepInterval time.Duration,
+	timeout time.Duration,
+) domain.Dispatcher {
+	return &dispatchUseCase{
+		repo:          repo,
+		restaurants:   restaurants,
+		locator:       locator,
+		publisher:     publisher,
+		radiusKm:      radiusKm,
+		policy:        policy,
+		sweepInterval: sweepInterval,
+		timeout:       timeout,
+	}
+}
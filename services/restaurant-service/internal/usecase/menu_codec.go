@@ -0,0 +1,275 @@
+package usecase
+
+import (
+	"bytes"
+	"encoding/csv"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"math"
+	"sort"
+	"strconv"
+	"strings"
+	"unicode/utf8"
+
+	"github.com/tamirat-dejene/ha-soranu/services/restaurant-service/internal/domain"
+)
+
+const (
+	// maxMenuImportItems bounds a single import so it fits in one transaction.
+	maxMenuImportItems = 1000
+	// maxMenuItemNameLength matches the width of menu_items.name.
+	maxMenuItemNameLength = 100
+)
+
+var menuCSVHeader = []string{"name", "description", "price"}
+
+// menuRow is a menu item as it appears in an import, before validation.
+type menuRow struct {
+	Line        int
+	Name        string
+	Description string
+	Price       *float32
+}
+
+// menuJSONItem is the JSON representation of a menu item in imports and exports.
+type menuJSONItem struct {
+	Name        string   `json:"name"`
+	Description string   `json:"description"`
+	Price       *float32 `json:"price"`
+}
+
+// parseMenu decodes an imported menu. Rows that cannot be decoded are
+// reported as errors alongside the rows that could.
+func parseMenu(format string, data []byte) ([]menuRow, []domain.MenuImportError, error) {
+	switch format {
+	case domain.MENU_FORMAT_CSV:
+		rows, errs := parseMenuCSV(data)
+		return rows, errs, nil
+	case domain.MENU_FORMAT_JSON:
+		rows, errs := parseMenuJSON(data)
+		return rows, errs, nil
+	default:
+		return nil, nil, domain.ErrUnsupportedMenuFormat
+	}
+}
+
+func parseMenuCSV(data []byte) ([]menuRow, []domain.MenuImportError) {
+	r := csv.NewReader(bytes.NewReader(data))
+	r.FieldsPerRecord = -1
+	r.TrimLeadingSpace = true
+
+	header, err := r.Read()
+	if err != nil {
+		if errors.Is(err, io.EOF) {
+			return nil, []domain.MenuImportError{{Line: 1, Message: "menu is empty"}}
+		}
+		return nil, []domain.MenuImportError{csvError(err, 1)}
+	}
+
+	columns := make(map[string]int)
+	for i, name := range header {
+		columns[strings.ToLower(strings.TrimSpace(name))] = i
+	}
+
+	var errs []domain.MenuImportError
+	for _, name := range []string{"name", "price"} {
+		if _, ok := columns[name]; !ok {
+			errs = append(errs, domain.MenuImportError{Line: 1, Field: name, Message: "missing column"})
+		}
+	}
+	if len(errs) > 0 {
+		return nil, errs
+	}
+
+	field := func(record []string, name string) string {
+		i, ok := columns[name]
+		if !ok || i >= len(record) {
+			return ""
+		}
+		return strings.TrimSpace(record[i])
+	}
+
+	var rows []menuRow
+	for {
+		record, err := r.Read()
+		if errors.Is(err, io.EOF) {
+			break
+		}
+		if err != nil {
+			// The reader cannot recover from malformed quoting.
+			errs = append(errs, csvError(err, 0))
+			break
+		}
+		line, _ := r.FieldPos(0)
+
+		row := menuRow{
+			Line:        line,
+			Name:        field(record, "name"),
+			Description: field(record, "description"),
+		}
+
+		if raw := field(record, "price"); raw != "" {
+			price, err := strconv.ParseFloat(raw, 32)
+			if err != nil {
+				errs = append(errs, domain.MenuImportError{Line: line, Field: "price", Message: "price must be a number"})
+				continue
+			}
+			p := float32(price)
+			row.Price = &p
+		}
+
+		rows = append(rows, row)
+	}
+
+	return rows, errs
+}
+
+func csvError(err error, line int) domain.MenuImportError {
+	var parseErr *csv.ParseError
+	if errors.As(err, &parseErr) {
+		return domain.MenuImportError{Line: parseErr.Line, Message: parseErr.Err.Error()}
+	}
+	return domain.MenuImportError{Line: line, Message: err.Error()}
+}
+
+func parseMenuJSON(data []byte) ([]menuRow, []domain.MenuImportError) {
+	dec := json.NewDecoder(bytes.NewReader(data))
+
+	tok, err := dec.Token()
+	if err != nil || tok != json.Delim('[') {
+		return nil, []domain.MenuImportError{{Line: 1, Message: "menu must be a JSON array of items"}}
+	}
+
+	var rows []menuRow
+	var errs []domain.MenuImportError
+	for dec.More() {
+		line := lineAt(data, int(dec.InputOffset()))
+
+		var item menuJSONItem
+		if err := dec.Decode(&item); err != nil {
+			var typeErr *json.UnmarshalTypeError
+			if errors.As(err, &typeErr) {
+				// The decoder has consumed the whole item, so carry on.
+				errs = append(errs, jsonTypeError(typeErr, line))
+				continue
+			}
+			errs = append(errs, domain.MenuImportError{Line: line, Message: err.Error()})
+			return rows, errs
+		}
+
+		rows = append(rows, menuRow{
+			Line:        line,
+			Name:        strings.TrimSpace(item.Name),
+			Description: strings.TrimSpace(item.Description),
+			Price:       item.Price,
+		})
+	}
+
+	return rows, errs
+}
+
+func jsonTypeError(err *json.UnmarshalTypeError, line int) domain.MenuImportError {
+	switch err.Field {
+	case "":
+		return domain.MenuImportError{Line: line, Message: "item must be a JSON object"}
+	case "price":
+		return domain.MenuImportError{Line: line, Field: err.Field, Message: "price must be a number"}
+	default:
+		return domain.MenuImportError{Line: line, Field: err.Field, Message: err.Field + " must be a string"}
+	}
+}
+
+// lineAt returns the line of the first non-space byte at or after offset.
+func lineAt(data []byte, offset int) int {
+	for offset < len(data) && strings.ContainsRune(" \t\r\n,", rune(data[offset])) {
+		offset++
+	}
+	return bytes.Count(data[:offset], []byte("\n")) + 1
+}
+
+// validateMenuRows checks every row and returns the items to import along
+// with one error per problem found.
+func validateMenuRows(rows []menuRow) ([]domain.MenuItem, []domain.MenuImportError) {
+	var items []domain.MenuItem
+	var errs []domain.MenuImportError
+	seen := make(map[string]int)
+
+	for _, row := range rows {
+		valid := true
+
+		switch {
+		case row.Name == "":
+			errs = append(errs, domain.MenuImportError{Line: row.Line, Field: "name", Message: "name is required"})
+			valid = false
+		case utf8.RuneCountInString(row.Name) > maxMenuItemNameLength:
+			errs = append(errs, domain.MenuImportError{Line: row.Line, Field: "name", Message: fmt.Sprintf("name must be at most %d characters", maxMenuItemNameLength)})
+			valid = false
+		default:
+			key := strings.ToLower(row.Name)
+			if first, ok := seen[key]; ok {
+				errs = append(errs, domain.MenuImportError{Line: row.Line, Field: "name", Message: fmt.Sprintf("duplicate of the item on line %d", first)})
+				valid = false
+			} else {
+				seen[key] = row.Line
+			}
+		}
+
+		switch {
+		case row.Price == nil:
+			errs = append(errs, domain.MenuImportError{Line: row.Line, Field: "price", Message: "price is required"})
+			valid = false
+		case math.IsNaN(float64(*row.Price)) || math.IsInf(float64(*row.Price), 0):
+			errs = append(errs, domain.MenuImportError{Line: row.Line, Field: "price", Message: "price must be a number"})
+			valid = false
+		case *row.Price <= 0:
+			errs = append(errs, domain.MenuImportError{Line: row.Line, Field: "price", Message: "price must be greater than zero"})
+			valid = false
+		}
+
+		if valid {
+			items = append(items, domain.MenuItem{
+				Name:        row.Name,
+				Description: row.Description,
+				Price:       *row.Price,
+			})
+		}
+	}
+
+	return items, errs
+}
+
+// encodeMenu renders menu items sorted by name in the requested format.
+func encodeMenu(format string, items []domain.MenuItem) ([]byte, error) {
+	sorted := append([]domain.MenuItem(nil), items...)
+	sort.Slice(sorted, func(i, j int) bool {
+		return strings.ToLower(sorted[i].Name) < strings.ToLower(sorted[j].Name)
+	})
+
+	switch format {
+	case domain.MENU_FORMAT_CSV:
+		var buf bytes.Buffer
+		w := csv.NewWriter(&buf)
+		if err := w.Write(menuCSVHeader); err != nil {
+			return nil, err
+		}
+		for _, item := range sorted {
+			price := strconv.FormatFloat(float64(item.Price), 'f', -1, 32)
+			if err := w.Write([]string{item.Name, item.Description, price}); err != nil {
+				return nil, err
+			}
+		}
+		w.Flush()
+		return buf.Bytes(), w.Error()
+	case domain.MENU_FORMAT_JSON:
+		out := make([]menuJSONItem, 0, len(sorted))
+		for _, item := range sorted {
+			price := item.Price
+			out = append(out, menuJSONItem{Name: item.Name, Description: item.Description, Price: &price})
+		}
+		return json.MarshalIndent(out, "", "  ")
+	default:
+		return nil, domain.ErrUnsupportedMenuFormat
+	}
+}
@@ -0,0 +1,229 @@
+package usecase
+
+import (
+	"errors"
+	"math"
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/tamirat-dejene/ha-soranu/services/restaurant-service/internal/domain"
+)
+
+func price(p float32) *float32 {
+	return &p
+}
+
+func TestParseMenu(t *testing.T) {
+	tests := []struct {
+		name     string
+		format   string
+		data     string
+		wantRows []menuRow
+		wantErrs []domain.MenuImportError
+		wantErr  error
+	}{
+		{
+			name:   "csv",
+			format: domain.MENU_FORMAT_CSV,
+			data:   "name,description,price\nPizza, Cheese ,10.5\nSalad,,6\n",
+			wantRows: []menuRow{
+				{Line: 2, Name: "Pizza", Description: "Cheese", Price: price(10.5)},
+				{Line: 3, Name: "Salad", Price: price(6)},
+			},
+		},
+		{
+			name:     "csv columns in any order and case",
+			format:   domain.MENU_FORMAT_CSV,
+			data:     "Price, NAME\n4,Tea\n",
+			wantRows: []menuRow{{Line: 2, Name: "Tea", Price: price(4)}},
+		},
+		{
+			name:     "csv without a price",
+			format:   domain.MENU_FORMAT_CSV,
+			data:     "name,price\nTea,\nCoffee\n",
+			wantRows: []menuRow{{Line: 2, Name: "Tea"}, {Line: 3, Name: "Coffee"}},
+		},
+		{
+			name:     "csv price that is not a number",
+			format:   domain.MENU_FORMAT_CSV,
+			data:     "name,price\nTea,cheap\nCoffee,3\n",
+			wantRows: []menuRow{{Line: 3, Name: "Coffee", Price: price(3)}},
+			wantErrs: []domain.MenuImportError{{Line: 2, Field: "price", Message: "price must be a number"}},
+		},
+		{
+			name:     "csv missing columns",
+			format:   domain.MENU_FORMAT_CSV,
+			data:     "description\nHot\n",
+			wantErrs: []domain.MenuImportError{{Line: 1, Field: "name", Message: "missing column"}, {Line: 1, Field: "price", Message: "missing column"}},
+		},
+		{
+			name:     "csv empty",
+			format:   domain.MENU_FORMAT_CSV,
+			data:     "",
+			wantErrs: []domain.MenuImportError{{Line: 1, Message: "menu is empty"}},
+		},
+		{
+			name:     "csv malformed quoting",
+			format:   domain.MENU_FORMAT_CSV,
+			data:     "name,price\nTea,3\n\"Coffee,4\n",
+			wantRows: []menuRow{{Line: 2, Name: "Tea", Price: price(3)}},
+			wantErrs: []domain.MenuImportError{{Line: 3, Message: `extraneous or missing " in quoted-field`}},
+		},
+		{
+			name:   "json",
+			format: domain.MENU_FORMAT_JSON,
+			data:   "[\n  {\"name\": \" Pizza \", \"description\": \"Cheese\", \"price\": 10.5},\n  {\"name\": \"Salad\"}\n]",
+			wantRows: []menuRow{
+				{Line: 2, Name: "Pizza", Description: "Cheese", Price: price(10.5)},
+				{Line: 3, Name: "Salad"},
+			},
+		},
+		{
+			name:     "json wrong types",
+			format:   domain.MENU_FORMAT_JSON,
+			data:     "[\n{\"name\": 1, \"price\": 2},\n{\"name\": \"Tea\", \"price\": \"3\"},\n\"Coffee\",\n{\"name\": \"Cake\", \"price\": 4}\n]",
+			wantRows: []menuRow{{Line: 5, Name: "Cake", Price: price(4)}},
+			wantErrs: []domain.MenuImportError{
+				{Line: 2, Field: "name", Message: "name must be a string"},
+				{Line: 3, Field: "price", Message: "price must be a number"},
+				{Line: 4, Message: "item must be a JSON object"},
+			},
+		},
+		{
+			name:     "json not an array",
+			format:   domain.MENU_FORMAT_JSON,
+			data:     `{"name": "Tea", "price": 3}`,
+			wantErrs: []domain.MenuImportError{{Line: 1, Message: "menu must be a JSON array of items"}},
+		},
+		{
+			name:     "json cut short",
+			format:   domain.MENU_FORMAT_JSON,
+			data:     "[\n{\"name\": \"Tea\", \"price\": 3},\n{\"name\": \"Coffee\"",
+			wantRows: []menuRow{{Line: 2, Name: "Tea", Price: price(3)}},
+			wantErrs: []domain.MenuImportError{{Line: 3, Message: "unexpected EOF"}},
+		},
+		{
+			name:    "unsupported format",
+			format:  "xml",
+			data:    "<menu/>",
+			wantErr: domain.ErrUnsupportedMenuFormat,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			rows, errs, err := parseMenu(tt.format, []byte(tt.data))
+			if !errors.Is(err, tt.wantErr) {
+				t.Fatalf("parseMenu() error = %v, want %v", err, tt.wantErr)
+			}
+			if !reflect.DeepEqual(rows, tt.wantRows) {
+				t.Errorf("parseMenu() rows = %+v, want %+v", rows, tt.wantRows)
+			}
+			if !reflect.DeepEqual(errs, tt.wantErrs) {
+				t.Errorf("parseMenu() errors = %+v, want %+v", errs, tt.wantErrs)
+			}
+		})
+	}
+}
+
+func TestValidateMenuRows(t *testing.T) {
+	tests := []struct {
+		name      string
+		rows      []menuRow
+		wantItems []domain.MenuItem
+		wantErrs  []domain.MenuImportError
+	}{
+		{
+			name:      "valid",
+			rows:      []menuRow{{Line: 2, Name: "Pizza", Description: "Cheese", Price: price(10)}},
+			wantItems: []domain.MenuItem{{Name: "Pizza", Description: "Cheese", Price: 10}},
+		},
+		{
+			name:     "name missing",
+			rows:     []menuRow{{Line: 2, Price: price(10)}},
+			wantErrs: []domain.MenuImportError{{Line: 2, Field: "name", Message: "name is required"}},
+		},
+		{
+			name:     "name too long",
+			rows:     []menuRow{{Line: 2, Name: strings.Repeat("é", maxMenuItemNameLength+1), Price: price(10)}},
+			wantErrs: []domain.MenuImportError{{Line: 2, Field: "name", Message: "name must be at most 100 characters"}},
+		},
+		{
+			name:      "longest name",
+			rows:      []menuRow{{Line: 2, Name: strings.Repeat("é", maxMenuItemNameLength), Price: price(10)}},
+			wantItems: []domain.MenuItem{{Name: strings.Repeat("é", maxMenuItemNameLength), Price: 10}},
+		},
+		{
+			name:      "duplicate names in any case",
+			rows:      []menuRow{{Line: 2, Name: "Pizza", Price: price(10)}, {Line: 5, Name: "PIZZA", Price: price(12)}},
+			wantItems: []domain.MenuItem{{Name: "Pizza", Price: 10}},
+			wantErrs:  []domain.MenuImportError{{Line: 5, Field: "name", Message: "duplicate of the item on line 2"}},
+		},
+		{
+			name: "bad prices",
+			rows: []menuRow{
+				{Line: 2, Name: "A"},
+				{Line: 3, Name: "B", Price: price(0)},
+				{Line: 4, Name: "C", Price: price(-1)},
+				{Line: 5, Name: "D", Price: price(float32(math.Inf(1)))},
+			},
+			wantErrs: []domain.MenuImportError{
+				{Line: 2, Field: "price", Message: "price is required"},
+				{Line: 3, Field: "price", Message: "price must be greater than zero"},
+				{Line: 4, Field: "price", Message: "price must be greater than zero"},
+				{Line: 5, Field: "price", Message: "price must be a number"},
+			},
+		},
+		{
+			name:     "every problem of a row",
+			rows:     []menuRow{{Line: 2}},
+			wantErrs: []domain.MenuImportError{{Line: 2, Field: "name", Message: "name is required"}, {Line: 2, Field: "price", Message: "price is required"}},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			items, errs := validateMenuRows(tt.rows)
+			if !reflect.DeepEqual(items, tt.wantItems) {
+				t.Errorf("validateMenuRows() items = %+v, want %+v", items, tt.wantItems)
+			}
+			if !reflect.DeepEqual(errs, tt.wantErrs) {
+				t.Errorf("validateMenuRows() errors = %+v, want %+v", errs, tt.wantErrs)
+			}
+		})
+	}
+}
+
+func TestEncodedMenuImportsBack(t *testing.T) {
+	items := []domain.MenuItem{
+		{Name: "salad", Description: "Green, fresh", Price: 6.25},
+		{Name: "Pizza", Description: `"Cheese"`, Price: 10},
+	}
+	want := []domain.MenuItem{items[1], items[0]}
+
+	for _, format := range []string{domain.MENU_FORMAT_CSV, domain.MENU_FORMAT_JSON} {
+		t.Run(format, func(t *testing.T) {
+			data, err := encodeMenu(format, items)
+			if err != nil {
+				t.Fatalf("encodeMenu() error = %v", err)
+			}
+
+			rows, errs, err := parseMenu(format, data)
+			if err != nil || errs != nil {
+				t.Fatalf("parseMenu() errors = %v, %v", errs, err)
+			}
+			got, errs := validateMenuRows(rows)
+			if errs != nil {
+				t.Fatalf("validateMenuRows() errors = %v", errs)
+			}
+			if !reflect.DeepEqual(got, want) {
+				t.Errorf("imported %+v, want %+v sorted by name", got, want)
+			}
+		})
+	}
+
+	if _, err := encodeMenu("xml", items); !errors.Is(err, domain.ErrUnsupportedMenuFormat) {
+		t.Errorf("encodeMenu(xml) error = %v, want %v", err, domain.ErrUnsupportedMenuFormat)
+	}
+}
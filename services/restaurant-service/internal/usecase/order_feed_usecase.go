@@ -0,0 +1,214 @@
+package usecase
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	"github.com/tamirat-dejene/ha-soranu/services/restaurant-service/internal/domain"
+	"github.com/tamirat-dejene/ha-soranu/shared/pkg/events"
+	"github.com/tamirat-dejene/ha-soranu/shared/pkg/logger"
+	"github.com/tamirat-dejene/ha-soranu/shared/pkg/messaging/kafka"
+	envent_envelope "github.com/tamirat-dejene/ha-soranu/shared/protos/envent_envelopepb"
+	"github.com/tamirat-dejene/ha-soranu/shared/protos/orderpb"
+	"go.uber.org/zap"
+	"google.golang.org/protobuf/proto"
+)
+
+const (
+	// orderFeedBatchSize is how many journal entries are read per query.
+	orderFeedBatchSize = 100
+	// orderFeedPollInterval re-reads the journal even without a wake-up, in
+	// case an event was missed by the consumer.
+	orderFeedPollInterval = 15 * time.Second
+)
+
+type orderFeedUseCase struct {
+	repo        domain.OrderFeedRepository
+	restaurants domain.RestaurantRepository
+	consumer    kafka.Consumer
+	timeout     time.Duration
+
+	mu       sync.Mutex
+	watchers map[string]map[chan struct{}]struct{}
+}
+
+// WatchOrders implements [domain.OrderFeedUseCase].
+func (o *orderFeedUseCase) WatchOrders(ctx context.Context, restaurantID string, cursor int64, onEvent func(domain.OrderEvent) error) error {
+	c, cancel := context.WithTimeout(ctx, o.timeout)
+	err := authorizeRestaurant(c, o.restaurants, restaurantID)
+	cancel()
+	if err != nil {
+		return err
+	}
+
+	// Subscribe before reading so no wake-up between the read and the wait is lost.
+	wake, unsubscribe := o.subscribe(restaurantID)
+	defer unsubscribe()
+
+	if cursor <= 0 {
+		var err error
+		if cursor, err = o.sendSnapshot(ctx, restaurantID, onEvent); err != nil {
+			return err
+		}
+	}
+
+	ticker := time.NewTicker(orderFeedPollInterval)
+	defer ticker.Stop()
+
+	for {
+		next, err := o.sendEvents(ctx, restaurantID, cursor, onEvent)
+		if err != nil {
+			return err
+		}
+		cursor = next
+
+		select {
+		case <-ctx.Done():
+			return nil
+		case <-wake:
+		case <-ticker.C:
+		}
+	}
+}
+
+// sendSnapshot sends the open orders and returns the cursor they reflect.
+func (o *orderFeedUseCase) sendSnapshot(ctx context.Context, restaurantID string, onEvent func(domain.OrderEvent) error) (int64, error) {
+	c, cancel := context.WithTimeout(ctx, o.timeout)
+	defer cancel()
+
+	// Read the cursor first: events recorded while the orders are loaded are
+	// sent again afterwards rather than lost.
+	seq, err := o.repo.GetLatestOrderEventSeq(c, restaurantID)
+	if err != nil {
+		return 0, err
+	}
+
+	orders, err := o.repo.GetOpenOrders(c, restaurantID)
+	if err != nil {
+		return 0, err
+	}
+
+	now := time.Now()
+	for _, ord := range orders {
+		if err := onEvent(domain.OrderEvent{
+			Seq:        seq,
+			Type:       domain.ORDER_EVENT_SNAPSHOT,
+			Order:      ord,
+			OccurredAt: now,
+		}); err != nil {
+			return 0, err
+		}
+	}
+
+	return seq, nil
+}
+
+// sendEvents sends every journaled event after cursor and returns the new cursor.
+func (o *orderFeedUseCase) sendEvents(ctx context.Context, restaurantID string, cursor int64, onEvent func(domain.OrderEvent) error) (int64, error) {
+	for {
+		c, cancel := context.WithTimeout(ctx, o.timeout)
+		evs, err := o.repo.GetOrderEvents(c, restaurantID, cursor, orderFeedBatchSize)
+		cancel()
+		if err != nil {
+			return cursor, err
+		}
+
+		for _, ev := range evs {
+			if err := onEvent(ev); err != nil {
+				return cursor, err
+			}
+			cursor = ev.Seq
+		}
+
+		if len(evs) < orderFeedBatchSize {
+			return cursor, nil
+		}
+	}
+}
+
+func (o *orderFeedUseCase) subscribe(restaurantID string) (<-chan struct{}, func()) {
+	ch := make(chan struct{}, 1)
+
+	o.mu.Lock()
+	if o.watchers[restaurantID] == nil {
+		o.watchers[restaurantID] = make(map[chan struct{}]struct{})
+	}
+	o.watchers[restaurantID][ch] = struct{}{}
+	o.mu.Unlock()
+
+	return ch, func() {
+		o.mu.Lock()
+		defer o.mu.Unlock()
+		delete(o.watchers[restaurantID], ch)
+		if len(o.watchers[restaurantID]) == 0 {
+			delete(o.watchers, restaurantID)
+		}
+	}
+}
+
+// notify wakes up every watcher of the restaurant without blocking; a watcher
+// that already has a pending wake-up reads all new events anyway.
+func (o *orderFeedUseCase) notify(restaurantID string) {
+	o.mu.Lock()
+	defer o.mu.Unlock()
+
+	for ch := range o.watchers[restaurantID] {
+		select {
+		case ch <- struct{}{}:
+		default:
+		}
+	}
+}
+
+// StartConsumer implements [domain.OrderFeedUseCase].
+func (o *orderFeedUseCase) StartConsumer(ctx context.Context) error {
+	logger.Info("Starting order feed Kafka consumer")
+
+	return o.consumer.Subscribe(ctx, []string{
+		events.OrderPlacedEvent,
+		events.OrderStatusUpdatedEvent,
+	}, func(msgCtx context.Context, msg *kafka.Message) error {
+		var envelope envent_envelope.EventEnvelope
+		if err := proto.Unmarshal(msg.Value, &envelope); err != nil {
+			logger.Error("failed to unmarshal event envelope", zap.Error(err))
+			return err
+		}
+
+		var restaurantID string
+		switch envelope.EventType {
+		case events.OrderPlacedEvent:
+			var created orderpb.OrderCreated
+			if err := proto.Unmarshal(envelope.Payload, &created); err != nil {
+				logger.Error("failed to unmarshal OrderCreated event", zap.Error(err))
+				return err
+			}
+			restaurantID = created.RestaurantId
+		case events.OrderStatusUpdatedEvent:
+			var updated orderpb.OrderStatusUpdated
+			if err := proto.Unmarshal(envelope.Payload, &updated); err != nil {
+				logger.Error("failed to unmarshal OrderStatusUpdated event", zap.Error(err))
+				return err
+			}
+			restaurantID = updated.RestaurantId
+		default:
+			return nil
+		}
+
+		if restaurantID != "" {
+			o.notify(restaurantID)
+		}
+		return nil
+	})
+}
+
+// NewOrderFeedUseCase creates a new instance of OrderFeedUseCase.
+func NewOrderFeedUseCase(repo domain.OrderFeedRepository, restaurants domain.RestaurantRepository, consumer kafka.Consumer, timeout time.Duration) domain.OrderFeedUseCase {
+	return &orderFeedUseCase{
+		repo:        repo,
+		restaurants: restaurants,
+		consumer:    consumer,
+		timeout:     timeout,
+		watchers:    make(map[string]map[chan struct{}]struct{}),
+	}
+}
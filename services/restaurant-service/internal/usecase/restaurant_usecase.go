@@ -2,19 +2,30 @@ package usecase
 
 import (
 	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"sort"
+	"strings"
 	"time"
 
 	"github.com/tamirat-dejene/ha-soranu/services/restaurant-service/internal/api/grpc/dto"
 	"github.com/tamirat-dejene/ha-soranu/services/restaurant-service/internal/domain"
+	"github.com/tamirat-dejene/ha-soranu/shared/pkg/auth/svcauth"
 	"github.com/tamirat-dejene/ha-soranu/shared/pkg/events"
 	"github.com/tamirat-dejene/ha-soranu/shared/pkg/logger"
 	"github.com/tamirat-dejene/ha-soranu/shared/protos/orderpb"
 	"go.uber.org/zap"
 )
 
+// maxIdempotencyKeyLength matches the width of orders.idempotency_key.
+const maxIdempotencyKeyLength = 255
+
 type restaurantUseCase struct {
 	repo      domain.RestaurantRepository
 	publisher events.EventPublisher
+	owners    domain.OwnerRoleGranter
 	timeout   time.Duration
 }
 
@@ -23,15 +34,20 @@ func (r *restaurantUseCase) GetOrder(ctx context.Context, orderID string) (*doma
 	c, cancel := context.WithTimeout(ctx, r.timeout)
 	defer cancel()
 
-	return r.repo.GetOrderByID(c, orderID)
-}
+	ord, err := r.repo.GetOrderByID(c, orderID)
+	if err != nil {
+		return nil, err
+	}
 
-// ShipOrder implements [domain.RestaurantUseCase].
-func (r *restaurantUseCase) ShipOrder(ctx context.Context, restaurantID string, orderID string) (string, string, error) {
-	c, cancel := context.WithTimeout(ctx, r.timeout)
-	defer cancel()
+	// The customer who placed the order and the restaurant it was placed
+	// with may read it.
+	if !svcauth.CanActFor(c, ord.CustomerID) {
+		if err := r.authorizeRestaurant(c, ord.RestaurantID); err != nil {
+			return nil, err
+		}
+	}
 
-	return r.repo.ShipOrder(c, restaurantID, orderID)
+	return ord, nil
 }
 
 // UpdateOrderStatus implements [domain.RestaurantUseCase].
@@ -39,6 +55,10 @@ func (r *restaurantUseCase) UpdateOrderStatus(ctx context.Context, restaurantID
 	c, cancel := context.WithTimeout(ctx, r.timeout)
 	defer cancel()
 
+	if err := r.authorizeRestaurant(c, restaurantID); err != nil {
+		return nil, err
+	}
+
 	ord, err := r.repo.UpdateOrderStatus(c, restaurantID, orderID, newStatus)
 	if err != nil {
 		return nil, err
@@ -50,6 +70,7 @@ func (r *restaurantUseCase) UpdateOrderStatus(ctx context.Context, restaurantID
 		CustomerId:    ord.CustomerID,
 		NewStatus:     dto.DomainOrderStatusToProto(ord.Status),
 		UpdatedAtUnix: time.Now().Unix(),
+		RestaurantId:  ord.RestaurantID,
 	}
 
 	if err := r.publisher.PublishOrderStatusUpdated(c, &update_event); err != nil {
@@ -65,6 +86,10 @@ func (r *restaurantUseCase) GetOrders(ctx context.Context, restaurantID string)
 	c, cancel := context.WithTimeout(ctx, r.timeout)
 	defer cancel()
 
+	if err := r.authorizeRestaurant(c, restaurantID); err != nil {
+		return nil, err
+	}
+
 	return r.repo.GetOrders(c, restaurantID)
 }
 
@@ -73,7 +98,29 @@ func (r *restaurantUseCase) PlaceOrder(ctx context.Context, order *domain.PlaceO
 	c, cancel := context.WithTimeout(ctx, r.timeout)
 	defer cancel()
 
+	if !svcauth.CanActFor(c, order.CustomerID) {
+		return nil, domain.ErrPermissionDenied
+	}
+	if err := validatePlaceOrder(order); err != nil {
+		return nil, err
+	}
+
+	if order.IdempotencyKey != "" {
+
+		order.RequestHash = orderFingerprint(order)
+
+		// A retry of an order we already placed returns the original one
+		// without publishing the created event again.
+		if ord, err := r.replayOrder(c, order); !errors.Is(err, domain.ErrOrderNotFound) {
+			return ord, err
+		}
+	}
+
 	ord, err := r.repo.PlaceOrder(c, order)
+	if errors.Is(err, domain.ErrIdempotencyKeyConflict) {
+		// A concurrent retry won the insert, hand back its order instead.
+		return r.replayOrder(c, order)
+	}
 	if err != nil {
 		return nil, err
 	}
@@ -97,12 +144,126 @@ func (r *restaurantUseCase) PlaceOrder(ctx context.Context, order *domain.PlaceO
 	return ord, nil
 }
 
+// validatePlaceOrder checks the fields of an order before it is placed.
+func validatePlaceOrder(order *domain.PlaceOrder) error {
+	if order.RestaurantID == "" {
+		return domain.NewValidationError(domain.ErrInvalidOrderData, "restaurant_id", "is required")
+	}
+	if len(order.Items) == 0 {
+		return domain.NewValidationError(domain.ErrInvalidOrderData, "items", "must not be empty")
+	}
+	for i, item := range order.Items {
+		if item.ItemId == "" {
+			return domain.NewValidationError(domain.ErrInvalidOrderData, fmt.Sprintf("items[%d].item_id", i), "is required")
+		}
+		if item.Quantity <= 0 {
+			return domain.NewValidationError(domain.ErrInvalidOrderData, fmt.Sprintf("items[%d].quantity", i), "must be positive")
+		}
+	}
+	if len(order.IdempotencyKey) > maxIdempotencyKeyLength {
+		return domain.NewValidationError(domain.ErrInvalidIdempotencyKey, "idempotency_key", fmt.Sprintf("must be at most %d characters", maxIdempotencyKeyLength))
+	}
+	return nil
+}
+
+// replayOrder looks up the order previously placed under the request's
+// idempotency key and checks that it was created from the same payload.
+func (r *restaurantUseCase) replayOrder(ctx context.Context, order *domain.PlaceOrder) (*domain.Order, error) {
+	ord, requestHash, err := r.repo.GetOrderByIdempotencyKey(ctx, order.CustomerID, order.IdempotencyKey)
+	if err != nil {
+		return nil, err
+	}
+
+	if requestHash != order.RequestHash {
+		return nil, domain.ErrIdempotencyKeyReused
+	}
+
+	logger.Info("replayed idempotent order", zap.String("order_id", ord.OrderId), zap.String("customer_id", ord.CustomerID))
+
+	return ord, nil
+}
+
+// orderFingerprint hashes the parts of an order request that must match for
+// a retry to be treated as the same order. Items are sorted so that their
+// order in the request does not matter.
+func orderFingerprint(order *domain.PlaceOrder) string {
+	items := make([]string, 0, len(order.Items))
+	for _, it := range order.Items {
+		items = append(items, fmt.Sprintf("%s:%d", it.ItemId, it.Quantity))
+	}
+	sort.Strings(items)
+
+	sum := sha256.Sum256([]byte(order.RestaurantID + "|" + strings.Join(items, ",")))
+	return hex.EncodeToString(sum[:])
+}
+
+// ImportMenu implements [domain.RestaurantUseCase].
+func (r *restaurantUseCase) ImportMenu(ctx context.Context, restaurantID string, format string, data []byte, dryRun bool) (*domain.MenuImport, error) {
+	c, cancel := context.WithTimeout(ctx, r.timeout)
+	defer cancel()
+
+	if err := r.authorizeRestaurant(c, restaurantID); err != nil {
+		return nil, err
+	}
+
+	rows, errs, err := parseMenu(format, data)
+	if err != nil {
+		return nil, err
+	}
+
+	if len(rows) > maxMenuImportItems {
+		return nil, domain.ErrMenuImportTooLarge
+	}
+
+	items, invalid := validateMenuRows(rows)
+	errs = append(errs, invalid...)
+
+	result := &domain.MenuImport{DryRun: dryRun}
+	if len(errs) > 0 {
+		sort.SliceStable(errs, func(i, j int) bool { return errs[i].Line < errs[j].Line })
+		result.Errors = errs
+		return result, nil
+	}
+
+	result.Created, result.Updated, err = r.repo.UpsertMenuItems(c, restaurantID, items, dryRun)
+	if err != nil {
+		return nil, err
+	}
+
+	return result, nil
+}
+
+// ExportMenu implements [domain.RestaurantUseCase].
+func (r *restaurantUseCase) ExportMenu(ctx context.Context, restaurantID string, format string) ([]byte, error) {
+	c, cancel := context.WithTimeout(ctx, r.timeout)
+	defer cancel()
+
+	if err := r.authorizeRestaurant(c, restaurantID); err != nil {
+		return nil, err
+	}
+
+	restaurant, err := r.repo.GetRestaurantByID(c, restaurantID)
+	if err != nil {
+		return nil, err
+	}
+
+	return encodeMenu(format, restaurant.MenuItems)
+}
+
 // LoginRestaurant implements domain.RestaurantUseCase.
 func (r *restaurantUseCase) LoginRestaurant(ctx context.Context, email string, secretKey string) (*domain.Restaurant, error) {
 	c, cancel := context.WithTimeout(ctx, r.timeout)
 	defer cancel()
 
-	return r.repo.LoginRestaurant(c, email, secretKey)
+	restaurant, err := r.repo.LoginRestaurant(c, email, secretKey)
+	if err != nil {
+		return nil, err
+	}
+	if restaurant.Status == domain.RESTAURANT_STATUS_SUSPENDED {
+		return nil, domain.ErrRestaurantSuspended
+	}
+
+	return restaurant, nil
 }
 
 // AddMenuItem implements domain.RestaurantUseCase.
@@ -110,6 +271,10 @@ func (r *restaurantUseCase) AddMenuItem(ctx context.Context, restaurantID string
 	c, cancel := context.WithTimeout(ctx, r.timeout)
 	defer cancel()
 
+	if err := r.authorizeRestaurant(c, restaurantID); err != nil {
+		return nil, err
+	}
+
 	return r.repo.AddMenuItem(c, restaurantID, item)
 }
 
@@ -121,6 +286,61 @@ func (r *restaurantUseCase) GetRestaurantByID(ctx context.Context, restaurantID
 	return r.repo.GetRestaurantByID(c, restaurantID)
 }
 
+// UpdateRestaurant implements [domain.RestaurantUseCase].
+func (r *restaurantUseCase) UpdateRestaurant(ctx context.Context, restaurant *domain.Restaurant) (*domain.Restaurant, error) {
+	c, cancel := context.WithTimeout(ctx, r.timeout)
+	defer cancel()
+
+	var violations []domain.FieldViolation
+	for _, field := range []struct{ name, value string }{
+		{"restaurant_id", restaurant.ID},
+		{"name", restaurant.Name},
+		{"email", restaurant.Email},
+	} {
+		if field.value == "" {
+			violations = append(violations, domain.FieldViolation{Field: field.name, Description: "is required"})
+		}
+	}
+	if len(violations) > 0 {
+		return nil, &domain.ValidationError{Err: domain.ErrInvalidRestaurantData, Violations: violations}
+	}
+
+	if err := r.authorizeRestaurant(c, restaurant.ID); err != nil {
+		return nil, err
+	}
+
+	if err := r.repo.UpdateRestaurant(c, restaurant); err != nil {
+		return nil, err
+	}
+
+	return r.repo.GetRestaurantByID(c, restaurant.ID)
+}
+
+// DeactivateRestaurant implements [domain.RestaurantUseCase].
+func (r *restaurantUseCase) DeactivateRestaurant(ctx context.Context, restaurantID string) (*domain.Restaurant, error) {
+	return r.setRestaurantStatus(ctx, restaurantID, domain.RESTAURANT_STATUS_INACTIVE)
+}
+
+// ReactivateRestaurant implements [domain.RestaurantUseCase].
+func (r *restaurantUseCase) ReactivateRestaurant(ctx context.Context, restaurantID string) (*domain.Restaurant, error) {
+	return r.setRestaurantStatus(ctx, restaurantID, domain.RESTAURANT_STATUS_ACTIVE)
+}
+
+func (r *restaurantUseCase) setRestaurantStatus(ctx context.Context, restaurantID string, status string) (*domain.Restaurant, error) {
+	c, cancel := context.WithTimeout(ctx, r.timeout)
+	defer cancel()
+
+	if err := r.authorizeRestaurant(c, restaurantID); err != nil {
+		return nil, err
+	}
+
+	if err := r.repo.SetRestaurantStatus(c, restaurantID, status); err != nil {
+		return nil, err
+	}
+
+	return r.repo.GetRestaurantByID(c, restaurantID)
+}
+
 // GetRestaurants implements domain.RestaurantUseCase.
 func (r *restaurantUseCase) StreamRestaurants(
 	ctx context.Context,
@@ -137,6 +357,20 @@ func (r *restaurantUseCase) RegisterRestaurant(ctx context.Context, restaurant *
 	c, cancel := context.WithTimeout(ctx, r.timeout)
 	defer cancel()
 
+	// The user registering the restaurant owns it.
+	if user, ok := svcauth.UserFromContext(c); ok {
+		restaurant.OwnerID = user.ID
+	}
+
+	// The role is granted first: granting it again does nothing, so a
+	// registration that fails afterwards can be retried.
+	if restaurant.OwnerID != "" {
+		if err := r.owners.GrantRestaurantOwner(c, restaurant.OwnerID); err != nil {
+			logger.Error("failed to grant the restaurant owner role", zap.String("user_id", restaurant.OwnerID), zap.Error(err))
+			return nil, err
+		}
+	}
+
 	return r.repo.CreateRestaurant(c, restaurant)
 }
 
@@ -145,6 +379,10 @@ func (r *restaurantUseCase) RemoveMenuItem(ctx context.Context, restaurantID str
 	c, cancel := context.WithTimeout(ctx, r.timeout)
 	defer cancel()
 
+	if err := r.authorizeRestaurant(c, restaurantID); err != nil {
+		return err
+	}
+
 	return r.repo.RemoveMenuItem(c, restaurantID, itemID)
 }
 
@@ -153,11 +391,33 @@ func (r *restaurantUseCase) UpdateMenuItem(ctx context.Context, restaurantID str
 	c, cancel := context.WithTimeout(ctx, r.timeout)
 	defer cancel()
 
+	if err := r.authorizeRestaurant(c, restaurantID); err != nil {
+		return nil, err
+	}
+
 	return r.repo.UpdateMenuItem(c, restaurantID, item)
 }
 
+func (r *restaurantUseCase) authorizeRestaurant(ctx context.Context, restaurantID string) error {
+	return authorizeRestaurant(ctx, r.repo, restaurantID)
+}
+
+// authorizeRestaurant fails with domain.ErrPermissionDenied unless the user of
+// the call owns the restaurant or is an admin.
+func authorizeRestaurant(ctx context.Context, restaurants domain.RestaurantRepository, restaurantID string) error {
+	ownerID, err := restaurants.GetRestaurantOwner(ctx, restaurantID)
+	if err != nil {
+		return err
+	}
+	if !svcauth.CanActFor(ctx, ownerID) {
+		return domain.ErrPermissionDenied
+	}
+	return nil
+}
+
 func NewRestaurantUseCase(repo domain.RestaurantRepository,
 	publisher events.EventPublisher,
+	owners domain.OwnerRoleGranter,
 	timeout time.Duration) domain.RestaurantUseCase {
-	return &restaurantUseCase{repo: repo, publisher: publisher, timeout: timeout}
+	return &restaurantUseCase{repo: repo, publisher: publisher, owners: owners, timeout: timeout}
 }
@@ -0,0 +1,205 @@
+package usecase
+
+import (
+	"context"
+	"encoding/json"
+	"time"
+
+	"github.com/tamirat-dejene/ha-soranu/services/restaurant-service/internal/domain"
+	"github.com/tamirat-dejene/ha-soranu/shared/pkg/events"
+	"github.com/tamirat-dejene/ha-soranu/shared/pkg/logger"
+	"github.com/tamirat-dejene/ha-soranu/shared/pkg/messaging/kafka"
+	envent_envelope "github.com/tamirat-dejene/ha-soranu/shared/protos/envent_envelopepb"
+	"github.com/tamirat-dejene/ha-soranu/shared/protos/userdatapb"
+	"go.uber.org/zap"
+	"google.golang.org/protobuf/proto"
+)
+
+type userDataUseCase struct {
+	repo      domain.UserDataRepository
+	carts     domain.CartRepository
+	publisher events.EventPublisher
+	consumer  kafka.Consumer
+	timeout   time.Duration
+}
+
+// userDataExport is the data the restaurant service holds about a user.
+type userDataExport struct {
+	Orders     []orderExport `json:"orders"`
+	Deliveries []orderExport `json:"deliveries,omitempty"`
+	Cart       *cartExport   `json:"cart,omitempty"`
+}
+
+type orderExport struct {
+	OrderID      string            `json:"order_id"`
+	RestaurantID string            `json:"restaurant_id"`
+	TotalAmount  float64           `json:"total_amount"`
+	Status       string            `json:"status"`
+	Items        []orderItemExport `json:"items"`
+}
+
+type orderItemExport struct {
+	ItemID   string `json:"item_id"`
+	Quantity int32  `json:"quantity"`
+}
+
+type cartExport struct {
+	RestaurantID string            `json:"restaurant_id"`
+	Items        []orderItemExport `json:"items"`
+	ExpiresAt    time.Time         `json:"expires_at"`
+}
+
+// StartConsumer implements [domain.UserDataUseCase].
+func (u *userDataUseCase) StartConsumer(ctx context.Context) error {
+	logger.Info("Starting user data consumer")
+
+	return u.consumer.Subscribe(ctx, []string{
+		events.UserDeletionRequestedEvent,
+		events.UserExportRequestedEvent,
+	}, func(msgCtx context.Context, msg *kafka.Message) error {
+		var envelope envent_envelope.EventEnvelope
+		if err := proto.Unmarshal(msg.Value, &envelope); err != nil {
+			logger.Error("failed to unmarshal event envelope", zap.Error(err))
+			return err
+		}
+
+		var requested userdatapb.UserDataRequested
+		if err := proto.Unmarshal(envelope.Payload, &requested); err != nil {
+			logger.Error("failed to unmarshal UserDataRequested event", zap.Error(err))
+			return err
+		}
+
+		logger.Info("Processing user data request",
+			zap.String("event_type", envelope.EventType),
+			zap.String("request_id", requested.RequestId),
+			zap.String("user_id", requested.UserId))
+
+		var data []byte
+		var err error
+		switch envelope.EventType {
+		case events.UserDeletionRequestedEvent:
+			err = u.deleteUserData(msgCtx, &requested)
+		case events.UserExportRequestedEvent:
+			data, err = u.exportUserData(msgCtx, &requested)
+		default:
+			logger.Warn("unknown event type", zap.String("event_type", envelope.EventType))
+			return nil
+		}
+
+		processed := &userdatapb.UserDataProcessed{
+			RequestId:       requested.RequestId,
+			UserId:          requested.UserId,
+			Service:         events.UserDataServiceRestaurant,
+			Data:            data,
+			ProcessedAtUnix: time.Now().Unix(),
+		}
+		if err != nil {
+			logger.Error("failed to process user data request", zap.String("request_id", requested.RequestId), zap.Error(err))
+			processed.Error = err.Error()
+		}
+
+		return u.publisher.PublishUserDataProcessed(msgCtx, processed)
+	})
+}
+
+// deleteUserData forgets the user's cart, detaches their orders and, for a
+// driver, hands their pending deliveries to other drivers.
+func (u *userDataUseCase) deleteUserData(ctx context.Context, requested *userdatapb.UserDataRequested) error {
+	c, cancel := context.WithTimeout(ctx, u.timeout)
+	defer cancel()
+
+	if err := u.carts.DeleteCart(c, requested.UserId); err != nil {
+		return err
+	}
+
+	orders, err := u.repo.AnonymizeCustomer(c, requested.UserId)
+	if err != nil {
+		return err
+	}
+
+	offers := 0
+	if requested.DriverId != "" {
+		if offers, err = u.repo.ReleaseDriver(c, requested.DriverId); err != nil {
+			return err
+		}
+	}
+
+	logger.Info("Deleted user data",
+		zap.String("user_id", requested.UserId),
+		zap.Int("orders", orders),
+		zap.Int("delivery_offers", offers))
+
+	return nil
+}
+
+func (u *userDataUseCase) exportUserData(ctx context.Context, requested *userdatapb.UserDataRequested) ([]byte, error) {
+	c, cancel := context.WithTimeout(ctx, u.timeout)
+	defer cancel()
+
+	orders, err := u.repo.GetCustomerOrders(c, requested.UserId)
+	if err != nil {
+		return nil, err
+	}
+
+	export := userDataExport{Orders: toOrderExports(orders)}
+
+	if requested.DriverId != "" {
+		deliveries, err := u.repo.GetDriverDeliveries(c, requested.DriverId)
+		if err != nil {
+			return nil, err
+		}
+		export.Deliveries = toOrderExports(deliveries)
+	}
+
+	cart, err := u.carts.GetCart(c, requested.UserId)
+	if err != nil {
+		return nil, err
+	}
+	if len(cart.Items) > 0 {
+		export.Cart = &cartExport{
+			RestaurantID: cart.RestaurantID,
+			ExpiresAt:    cart.ExpiresAt,
+		}
+		for _, item := range cart.Items {
+			export.Cart.Items = append(export.Cart.Items, orderItemExport{ItemID: item.ItemID, Quantity: item.Quantity})
+		}
+	}
+
+	return json.Marshal(export)
+}
+
+func toOrderExports(orders []domain.Order) []orderExport {
+	result := make([]orderExport, 0, len(orders))
+	for _, ord := range orders {
+		export := orderExport{
+			OrderID:      ord.OrderId,
+			RestaurantID: ord.RestaurantID,
+			TotalAmount:  ord.TotalAmount,
+			Status:       ord.Status,
+			Items:        make([]orderItemExport, 0, len(ord.Items)),
+		}
+		for _, item := range ord.Items {
+			export.Items = append(export.Items, orderItemExport{ItemID: item.ItemId, Quantity: item.Quantity})
+		}
+		result = append(result, export)
+	}
+	return result
+}
+
+// NewUserDataUseCase creates the usecase answering account deletions and
+// data exports.
+func NewUserDataUseCase(
+	repo domain.UserDataRepository,
+	carts domain.CartRepository,
+	publisher events.EventPublisher,
+	consumer kafka.Consumer,
+	timeout time.Duration,
+) domain.UserDataUseCase {
+	return &userDataUseCase{
+		repo:      repo,
+		carts:     carts,
+		publisher: publisher,
+		consumer:  consumer,
+		timeout:   timeout,
+	}
+}
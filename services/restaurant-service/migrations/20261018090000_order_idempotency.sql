@@ -0,0 +1,14 @@
+-- +goose Up
+ALTER TABLE orders
+    ADD COLUMN IF NOT EXISTS idempotency_key VARCHAR(255),
+    ADD COLUMN IF NOT EXISTS request_hash VARCHAR(64);
+
+CREATE UNIQUE INDEX IF NOT EXISTS idx_orders_customer_idempotency_key
+    ON orders (customer_id, idempotency_key)
+    WHERE idempotency_key IS NOT NULL;
+
+-- +goose Down
+DROP INDEX IF EXISTS idx_orders_customer_idempotency_key;
+ALTER TABLE orders
+    DROP COLUMN IF EXISTS request_hash,
+    DROP COLUMN IF EXISTS idempotency_key;
@@ -0,0 +1,33 @@
+-- +goose Up
+ALTER TABLE orders
+    ADD COLUMN IF NOT EXISTS driver_id UUID,
+    ADD COLUMN IF NOT EXISTS dispatch_status VARCHAR(20);
+
+CREATE INDEX IF NOT EXISTS idx_orders_dispatch_status
+    ON orders (dispatch_status)
+    WHERE dispatch_status IS NOT NULL;
+
+CREATE TABLE IF NOT EXISTS delivery_offers (
+    offer_id UUID PRIMARY KEY DEFAULT uuid_generate_v4(),
+    order_id UUID NOT NULL REFERENCES orders(order_id) ON DELETE CASCADE,
+    driver_id UUID NOT NULL,
+    status VARCHAR(20) NOT NULL DEFAULT 'PENDING',
+    expires_at TIMESTAMP NOT NULL,
+    responded_at TIMESTAMP,
+    created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP
+);
+
+-- At most one open offer per order.
+CREATE UNIQUE INDEX IF NOT EXISTS idx_delivery_offers_order_pending
+    ON delivery_offers (order_id)
+    WHERE status = 'PENDING';
+
+CREATE INDEX IF NOT EXISTS idx_delivery_offers_driver_status
+    ON delivery_offers (driver_id, status);
+
+-- +goose Down
+DROP TABLE IF EXISTS delivery_offers;
+DROP INDEX IF EXISTS idx_orders_dispatch_status;
+ALTER TABLE orders
+    DROP COLUMN IF EXISTS dispatch_status,
+    DROP COLUMN IF EXISTS driver_id;
@@ -0,0 +1,9 @@
+-- +goose Up
+ALTER TABLE restaurants
+    ADD COLUMN IF NOT EXISTS status VARCHAR(20) NOT NULL DEFAULT 'ACTIVE',
+    ADD COLUMN IF NOT EXISTS deactivated_at TIMESTAMP;
+
+-- +goose Down
+ALTER TABLE restaurants
+    DROP COLUMN IF EXISTS deactivated_at,
+    DROP COLUMN IF EXISTS status;
@@ -0,0 +1,47 @@
+-- +goose Up
+CREATE TABLE IF NOT EXISTS order_events (
+    seq BIGSERIAL PRIMARY KEY,
+    order_id UUID NOT NULL REFERENCES orders(order_id) ON DELETE CASCADE,
+    restaurant_id UUID NOT NULL,
+    event_type VARCHAR(20) NOT NULL,
+    status VARCHAR(50) NOT NULL,
+    occurred_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP
+);
+
+CREATE INDEX IF NOT EXISTS idx_order_events_restaurant_seq
+    ON order_events (restaurant_id, seq);
+
+-- Every new order and status change is journaled so watchers can resume from
+-- a cursor. The advisory lock keeps a restaurant's events committing in seq
+-- order, so a reader never skips past an event that is not yet visible.
+-- +goose StatementBegin
+CREATE OR REPLACE FUNCTION record_order_event() RETURNS TRIGGER AS $$
+BEGIN
+    IF TG_OP = 'UPDATE' AND NEW.status IS NOT DISTINCT FROM OLD.status THEN
+        RETURN NEW;
+    END IF;
+
+    PERFORM pg_advisory_xact_lock(hashtext('order_events:' || NEW.restaurant_id::text));
+
+    INSERT INTO order_events (order_id, restaurant_id, event_type, status)
+    VALUES (
+        NEW.order_id,
+        NEW.restaurant_id,
+        CASE WHEN TG_OP = 'INSERT' THEN 'CREATED' ELSE 'STATUS_CHANGED' END,
+        NEW.status
+    );
+
+    RETURN NEW;
+END;
+$$ LANGUAGE plpgsql;
+-- +goose StatementEnd
+
+DROP TRIGGER IF EXISTS orders_record_event ON orders;
+CREATE TRIGGER orders_record_event
+    AFTER INSERT OR UPDATE OF status ON orders
+    FOR EACH ROW EXECUTE FUNCTION record_order_event();
+
+-- +goose Down
+DROP TRIGGER IF EXISTS orders_record_event ON orders;
+DROP FUNCTION IF EXISTS record_order_event();
+DROP TABLE IF EXISTS order_events;
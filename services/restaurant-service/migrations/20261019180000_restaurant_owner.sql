@@ -0,0 +1,12 @@
+-- +goose Up
+-- Restaurants registered before owners were recorded have none and can only be managed by admins.
+ALTER TABLE restaurants
+    ADD COLUMN IF NOT EXISTS owner_id UUID;
+
+CREATE INDEX IF NOT EXISTS idx_restaurants_owner_id ON restaurants (owner_id);
+
+-- +goose Down
+DROP INDEX IF EXISTS idx_restaurants_owner_id;
+
+ALTER TABLE restaurants
+    DROP COLUMN IF EXISTS owner_id;
@@ -0,0 +1,16 @@
+-- +goose Up
+-- Suspended restaurants have status SUSPENDED; the columns record why and by which admin.
+ALTER TABLE restaurants
+    ADD COLUMN IF NOT EXISTS suspension_reason TEXT NOT NULL DEFAULT '',
+    ADD COLUMN IF NOT EXISTS suspended_at TIMESTAMP,
+    ADD COLUMN IF NOT EXISTS suspended_by UUID;
+
+CREATE INDEX IF NOT EXISTS idx_restaurants_status ON restaurants (status);
+
+-- +goose Down
+DROP INDEX IF EXISTS idx_restaurants_status;
+
+ALTER TABLE restaurants
+    DROP COLUMN IF EXISTS suspended_by,
+    DROP COLUMN IF EXISTS suspended_at,
+    DROP COLUMN IF EXISTS suspension_reason;
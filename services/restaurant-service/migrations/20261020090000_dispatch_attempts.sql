@@ -0,0 +1,21 @@
+-- +goose Up
+ALTER TABLE orders
+    ADD COLUMN IF NOT EXISTS shipped_at TIMESTAMP,
+    ADD COLUMN IF NOT EXISTS dispatch_attempts INT NOT NULL DEFAULT 0,
+    ADD COLUMN IF NOT EXISTS dispatch_attempted_at TIMESTAMP;
+
+-- The sweeper retries the orders that waited longest since their last attempt first.
+CREATE INDEX IF NOT EXISTS idx_orders_dispatch_queue
+    ON orders (dispatch_attempted_at NULLS FIRST, shipped_at)
+    WHERE dispatch_status = 'SEARCHING';
+
+CREATE INDEX IF NOT EXISTS idx_delivery_offers_order_driver
+    ON delivery_offers (order_id, driver_id, created_at);
+
+-- +goose Down
+DROP INDEX IF EXISTS idx_delivery_offers_order_driver;
+DROP INDEX IF EXISTS idx_orders_dispatch_queue;
+ALTER TABLE orders
+    DROP COLUMN IF EXISTS dispatch_attempted_at,
+    DROP COLUMN IF EXISTS dispatch_attempts,
+    DROP COLUMN IF EXISTS shipped_at;
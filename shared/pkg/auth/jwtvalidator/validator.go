@@ -9,14 +9,41 @@ import (
 	"github.com/golang-jwt/jwt/v5"
 )
 
+// Roles a user can hold. Every user is a customer; the subject ID of the
+// token is carried in the registered "sub" claim.
+const (
+	RoleCustomer        = "customer"
+	RoleDriver          = "driver"
+	RoleRestaurantOwner = "restaurant_owner"
+	RoleAdmin           = "admin"
+)
+
 type AccessClaims struct {
 	UserEmail string
+	// EmailVerified is false until the user confirms their email address.
+	EmailVerified bool
+	Roles         []string
+	// SessionID names the login session the token was issued in, the family
+	// of the refresh token it came with.
+	SessionID string
 	Extra     map[string]any
 	jwt.RegisteredClaims
 }
 
+// HasRole reports whether the token grants role.
+func (c *AccessClaims) HasRole(role string) bool {
+	for _, r := range c.Roles {
+		if r == role {
+			return true
+		}
+	}
+	return false
+}
+
 type RefreshClaims struct {
-	TokenID   string
+	TokenID string
+	// FamilyID links the refresh tokens issued from a single login.
+	FamilyID  string
 	UserEmail string
 	Extra     map[string]any
 	jwt.RegisteredClaims
@@ -87,6 +114,7 @@ func validateAccessTokenWithKey(publicKey *rsa.PublicKey, tokenStr string) (*Acc
 }
 
 // ValidateAccessToken validates an access token using the provided PEM formatted RSA public key string.
+// The key is parsed on every call; a [Verifier] caches its keys and follows key rotation.
 func ValidateAccessToken(publicKeyPEM string, tokenStr string) (*AccessClaims, error) {
 	publicKey, err := ParseRSAPublicKeyFromString(publicKeyPEM)
 	if err != nil {
@@ -0,0 +1,231 @@
+package jwtvalidator
+
+import (
+	"context"
+	"crypto/rsa"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"sync"
+	"time"
+
+	"github.com/golang-jwt/jwt/v5"
+)
+
+// minJWKSRefreshInterval bounds how often tokens naming an unknown key can
+// make the verifier fetch the key set again.
+const minJWKSRefreshInterval = 30 * time.Second
+
+var ErrUnknownSigningKey = errors.New("unknown signing key")
+
+// Verifier validates access tokens against a cached set of public keys,
+// picking the key named by the token's "kid" header. Keys are fetched from a
+// JWKS endpoint and fetched again once they are older than the refresh
+// interval or when a token names a key that is not known yet, so signing keys
+// can be rotated without restarting the services that verify tokens.
+type Verifier struct {
+	jwksURL         string
+	refreshInterval time.Duration
+	client          *http.Client
+
+	// pinned keys are always trusted, whatever the endpoint returns.
+	pinned map[string]*rsa.PublicKey
+
+	mu          sync.RWMutex
+	keys        map[string]*rsa.PublicKey
+	fetchedAt   time.Time
+	attemptedAt time.Time
+
+	// fetchMu lets a single request fetch the key set at a time.
+	fetchMu sync.Mutex
+}
+
+// ValidateAccessToken validates an access token signed with one of the keys
+// of the set. Tokens without a "kid" header are checked against every key.
+func (v *Verifier) ValidateAccessToken(ctx context.Context, tokenStr string) (*AccessClaims, error) {
+	token, err := jwt.ParseWithClaims(tokenStr, &AccessClaims{}, v.Keyfunc(ctx))
+	if err != nil {
+		return nil, err
+	}
+
+	claims, ok := token.Claims.(*AccessClaims)
+	if !ok || !token.Valid {
+		return nil, jwt.ErrTokenInvalidClaims
+	}
+
+	return claims, nil
+}
+
+// Keyfunc returns a [jwt.Keyfunc] that picks the key of the set named by a
+// token's "kid" header, for validating tokens other than access tokens.
+// Tokens without a "kid" header are checked against every key.
+func (v *Verifier) Keyfunc(ctx context.Context) jwt.Keyfunc {
+	return func(t *jwt.Token) (any, error) {
+		if _, ok := t.Method.(*jwt.SigningMethodRSA); !ok {
+			return nil, jwt.ErrTokenSignatureInvalid
+		}
+
+		kid, _ := t.Header["kid"].(string)
+		if kid == "" {
+			return v.allKeys(ctx)
+		}
+		return v.key(ctx, kid)
+	}
+}
+
+// Refresh fetches the key set now.
+func (v *Verifier) Refresh(ctx context.Context) error {
+	if v.jwksURL == "" {
+		return nil
+	}
+	return v.refresh(ctx, true)
+}
+
+func (v *Verifier) key(ctx context.Context, kid string) (*rsa.PublicKey, error) {
+	key, found := v.lookup(kid)
+	if found && !v.stale() {
+		return key, nil
+	}
+
+	if v.jwksURL != "" {
+		// A key that is already known stays usable if the endpoint is down.
+		if err := v.refresh(ctx, false); err != nil && !found {
+			return nil, err
+		}
+		key, found = v.lookup(kid)
+	}
+
+	if !found {
+		return nil, ErrUnknownSigningKey
+	}
+	return key, nil
+}
+
+func (v *Verifier) allKeys(ctx context.Context) (jwt.VerificationKeySet, error) {
+	if v.stale() {
+		_ = v.refresh(ctx, false)
+	}
+
+	v.mu.RLock()
+	defer v.mu.RUnlock()
+
+	var set jwt.VerificationKeySet
+	for _, key := range v.pinned {
+		set.Keys = append(set.Keys, key)
+	}
+	for _, key := range v.keys {
+		set.Keys = append(set.Keys, key)
+	}
+	if len(set.Keys) == 0 {
+		return set, ErrUnknownSigningKey
+	}
+
+	return set, nil
+}
+
+func (v *Verifier) lookup(kid string) (*rsa.PublicKey, bool) {
+	if key, ok := v.pinned[kid]; ok {
+		return key, true
+	}
+
+	v.mu.RLock()
+	defer v.mu.RUnlock()
+
+	key, ok := v.keys[kid]
+	return key, ok
+}
+
+func (v *Verifier) stale() bool {
+	if v.jwksURL == "" {
+		return false
+	}
+
+	v.mu.RLock()
+	defer v.mu.RUnlock()
+
+	return time.Since(v.fetchedAt) > v.refreshInterval
+}
+
+// refresh fetches the key set unless another request just did, or force is set.
+func (v *Verifier) refresh(ctx context.Context, force bool) error {
+	v.fetchMu.Lock()
+	defer v.fetchMu.Unlock()
+
+	v.mu.Lock()
+	if !force && time.Since(v.attemptedAt) < minJWKSRefreshInterval {
+		v.mu.Unlock()
+		return nil
+	}
+	v.attemptedAt = time.Now()
+	v.mu.Unlock()
+
+	keys, err := v.fetch(ctx)
+	if err != nil {
+		return err
+	}
+
+	v.mu.Lock()
+	v.keys = keys
+	v.fetchedAt = time.Now()
+	v.mu.Unlock()
+
+	return nil
+}
+
+func (v *Verifier) fetch(ctx context.Context) (map[string]*rsa.PublicKey, error) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, v.jwksURL, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	resp, err := v.client.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("failed to fetch JWKS: %w", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("failed to fetch JWKS: status %d", resp.StatusCode)
+	}
+
+	var set JWKS
+	if err := json.NewDecoder(resp.Body).Decode(&set); err != nil {
+		return nil, fmt.Errorf("invalid JWKS: %w", err)
+	}
+
+	keys := make(map[string]*rsa.PublicKey, len(set.Keys))
+	for _, jwk := range set.Keys {
+		if jwk.Kid == "" || (jwk.Use != "" && jwk.Use != "sig") {
+			continue
+		}
+
+		key, err := jwk.PublicKey()
+		if err != nil {
+			// One malformed key must not take the others down with it.
+			continue
+		}
+		keys[jwk.Kid] = key
+	}
+
+	return keys, nil
+}
+
+// NewVerifier creates a Verifier that fetches its keys from jwksURL and keeps
+// them for refreshInterval. The given keys are trusted in addition to the
+// fetched ones under their [KeyID]; with an empty jwksURL they are the only
+// keys.
+func NewVerifier(jwksURL string, refreshInterval time.Duration, keys ...*rsa.PublicKey) *Verifier {
+	pinned := make(map[string]*rsa.PublicKey, len(keys))
+	for _, key := range keys {
+		pinned[KeyID(key)] = key
+	}
+
+	return &Verifier{
+		jwksURL:         jwksURL,
+		refreshInterval: refreshInterval,
+		client:          &http.Client{Timeout: 5 * time.Second},
+		pinned:          pinned,
+		keys:            make(map[string]*rsa.PublicKey),
+	}
+}
@@ -0,0 +1,255 @@
+package jwtvalidator
+
+import (
+	"context"
+	"crypto/rand"
+	"crypto/rsa"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/golang-jwt/jwt/v5"
+)
+
+func testRSAKey(t *testing.T) *rsa.PrivateKey {
+	t.Helper()
+
+	key, err := rsa.GenerateKey(rand.Reader, 2048)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return key
+}
+
+// signToken signs an access token for subject with key, naming kid in its
+// header unless kid is empty.
+func signToken(t *testing.T, key *rsa.PrivateKey, kid, subject string) string {
+	t.Helper()
+
+	token := jwt.NewWithClaims(jwt.SigningMethodRS256, AccessClaims{
+		RegisteredClaims: jwt.RegisteredClaims{
+			Subject:   subject,
+			ExpiresAt: jwt.NewNumericDate(time.Now().Add(time.Minute)),
+		},
+	})
+	if kid != "" {
+		token.Header["kid"] = kid
+	}
+
+	signed, err := token.SignedString(key)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return signed
+}
+
+// jwksServer serves a key set that tests can replace, counting the fetches.
+type jwksServer struct {
+	*httptest.Server
+
+	mu      sync.Mutex
+	set     JWKS
+	fetches int
+}
+
+func newJWKSServer(t *testing.T, set JWKS) *jwksServer {
+	s := &jwksServer{set: set}
+	s.Server = httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		s.mu.Lock()
+		defer s.mu.Unlock()
+		s.fetches++
+		_ = json.NewEncoder(w).Encode(s.set)
+	}))
+	t.Cleanup(s.Close)
+	return s
+}
+
+func (s *jwksServer) serve(set JWKS) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.set = set
+}
+
+func (s *jwksServer) fetchCount() int {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.fetches
+}
+
+func TestVerifierPicksKeyByKid(t *testing.T) {
+	current := testRSAKey(t)
+	retiring := testRSAKey(t)
+	encryption := testRSAKey(t)
+	unknown := testRSAKey(t)
+
+	currentKid := KeyID(&current.PublicKey)
+	retiringKid := KeyID(&retiring.PublicKey)
+	encryptionJWK := NewJWK("enc-key", &encryption.PublicKey)
+	encryptionJWK.Use = "enc"
+
+	server := newJWKSServer(t, JWKS{Keys: []JWK{
+		NewJWK(currentKid, &current.PublicKey),
+		NewJWK(retiringKid, &retiring.PublicKey),
+		encryptionJWK,
+		{Kty: "RSA", Kid: "broken", N: "!", E: "AQAB"},
+	}})
+	verifier := NewVerifier(server.URL, time.Hour)
+
+	hmacToken, err := jwt.NewWithClaims(jwt.SigningMethodHS256, AccessClaims{}).SignedString([]byte("secret"))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	tests := []struct {
+		name    string
+		token   string
+		wantErr error
+	}{
+		{name: "current key", token: signToken(t, current, currentKid, "user-1")},
+		{name: "retiring key", token: signToken(t, retiring, retiringKid, "user-1")},
+		{name: "no kid checked against every key", token: signToken(t, retiring, "", "user-1")},
+		{name: "kid of another key", token: signToken(t, unknown, currentKid, "user-1"), wantErr: jwt.ErrTokenSignatureInvalid},
+		{name: "unknown kid", token: signToken(t, unknown, KeyID(&unknown.PublicKey), "user-1"), wantErr: ErrUnknownSigningKey},
+		{name: "encryption key", token: signToken(t, encryption, "enc-key", "user-1"), wantErr: ErrUnknownSigningKey},
+		{name: "malformed key skipped", token: signToken(t, unknown, "broken", "user-1"), wantErr: ErrUnknownSigningKey},
+		{name: "not RSA", token: hmacToken, wantErr: jwt.ErrTokenSignatureInvalid},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			claims, err := verifier.ValidateAccessToken(context.Background(), tt.token)
+			if tt.wantErr != nil {
+				if !errors.Is(err, tt.wantErr) {
+					t.Fatalf("ValidateAccessToken() error = %v, want %v", err, tt.wantErr)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("ValidateAccessToken() error = %v", err)
+			}
+			if claims.Subject != "user-1" {
+				t.Errorf("ValidateAccessToken() subject = %q, want user-1", claims.Subject)
+			}
+		})
+	}
+
+	// Unknown kids fetch the set at most once per minJWKSRefreshInterval.
+	if got := server.fetchCount(); got != 1 {
+		t.Errorf("key set fetched %d times, want 1", got)
+	}
+}
+
+func TestVerifierFetchesRotatedKeys(t *testing.T) {
+	old := testRSAKey(t)
+	rotated := testRSAKey(t)
+	oldKid := KeyID(&old.PublicKey)
+	rotatedKid := KeyID(&rotated.PublicKey)
+
+	server := newJWKSServer(t, JWKS{Keys: []JWK{NewJWK(oldKid, &old.PublicKey)}})
+	verifier := NewVerifier(server.URL, time.Hour)
+	ctx := context.Background()
+
+	if _, err := verifier.ValidateAccessToken(ctx, signToken(t, old, oldKid, "user-1")); err != nil {
+		t.Fatalf("ValidateAccessToken() with the old key error = %v", err)
+	}
+
+	// The signer switches keys; the set was fetched too recently to be
+	// fetched again for the unknown kid.
+	server.serve(JWKS{Keys: []JWK{NewJWK(oldKid, &old.PublicKey), NewJWK(rotatedKid, &rotated.PublicKey)}})
+	rotatedToken := signToken(t, rotated, rotatedKid, "user-1")
+	if _, err := verifier.ValidateAccessToken(ctx, rotatedToken); !errors.Is(err, ErrUnknownSigningKey) {
+		t.Fatalf("ValidateAccessToken() right after a fetch error = %v, want %v", err, ErrUnknownSigningKey)
+	}
+
+	if err := verifier.Refresh(ctx); err != nil {
+		t.Fatalf("Refresh() error = %v", err)
+	}
+	if _, err := verifier.ValidateAccessToken(ctx, rotatedToken); err != nil {
+		t.Fatalf("ValidateAccessToken() with the rotated key error = %v", err)
+	}
+
+	// A key the endpoint drops is no longer trusted once the set is fetched again.
+	server.serve(JWKS{Keys: []JWK{NewJWK(rotatedKid, &rotated.PublicKey)}})
+	if err := verifier.Refresh(ctx); err != nil {
+		t.Fatalf("Refresh() error = %v", err)
+	}
+	if _, err := verifier.ValidateAccessToken(ctx, signToken(t, old, oldKid, "user-1")); !errors.Is(err, ErrUnknownSigningKey) {
+		t.Errorf("ValidateAccessToken() with a dropped key error = %v, want %v", err, ErrUnknownSigningKey)
+	}
+
+	if got := server.fetchCount(); got != 3 {
+		t.Errorf("key set fetched %d times, want 3", got)
+	}
+}
+
+func TestVerifierPinnedKeys(t *testing.T) {
+	pinned := testRSAKey(t)
+	fetched := testRSAKey(t)
+	pinnedKid := KeyID(&pinned.PublicKey)
+	fetchedKid := KeyID(&fetched.PublicKey)
+
+	down := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusServiceUnavailable)
+	}))
+	t.Cleanup(down.Close)
+
+	tests := []struct {
+		name     string
+		verifier *Verifier
+		token    string
+		wantErr  error
+	}{
+		{
+			name:     "pinned key without endpoint",
+			verifier: NewVerifier("", 0, &pinned.PublicKey),
+			token:    signToken(t, pinned, pinnedKid, "user-1"),
+		},
+		{
+			name:     "pinned key without kid",
+			verifier: NewVerifier("", 0, &pinned.PublicKey),
+			token:    signToken(t, pinned, "", "user-1"),
+		},
+		{
+			name:     "other key without endpoint",
+			verifier: NewVerifier("", 0, &pinned.PublicKey),
+			token:    signToken(t, fetched, fetchedKid, "user-1"),
+			wantErr:  ErrUnknownSigningKey,
+		},
+		{
+			name:     "no keys at all",
+			verifier: NewVerifier("", 0),
+			token:    signToken(t, pinned, "", "user-1"),
+			wantErr:  ErrUnknownSigningKey,
+		},
+		{
+			name:     "pinned key while the endpoint is down",
+			verifier: NewVerifier(down.URL, time.Hour, &pinned.PublicKey),
+			token:    signToken(t, pinned, pinnedKid, "user-1"),
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := tt.verifier.ValidateAccessToken(context.Background(), tt.token)
+			if tt.wantErr == nil && err != nil {
+				t.Fatalf("ValidateAccessToken() error = %v", err)
+			}
+			if tt.wantErr != nil && !errors.Is(err, tt.wantErr) {
+				t.Fatalf("ValidateAccessToken() error = %v, want %v", err, tt.wantErr)
+			}
+		})
+	}
+
+	// Pinned keys stay trusted whatever the endpoint serves.
+	server := newJWKSServer(t, JWKS{Keys: []JWK{NewJWK(fetchedKid, &fetched.PublicKey)}})
+	verifier := NewVerifier(server.URL, time.Hour, &pinned.PublicKey)
+	for _, token := range []string{signToken(t, pinned, pinnedKid, "user-1"), signToken(t, fetched, fetchedKid, "user-1")} {
+		if _, err := verifier.ValidateAccessToken(context.Background(), token); err != nil {
+			t.Errorf("ValidateAccessToken() error = %v", err)
+		}
+	}
+}
@@ -0,0 +1,105 @@
+package svcauth
+
+import (
+	"crypto/ed25519"
+	"crypto/x509"
+	"encoding/base64"
+	"encoding/pem"
+	"errors"
+	"fmt"
+	"strings"
+)
+
+// decodePEM returns the PEM block of key, given as PEM or as base64-encoded
+// PEM.
+func decodePEM(key string) (*pem.Block, error) {
+	key = strings.TrimSpace(key)
+	if key == "" {
+		return nil, errors.New("key is empty")
+	}
+
+	data := []byte(key)
+	if !strings.Contains(key, "-----BEGIN") {
+		decoded, err := base64.StdEncoding.DecodeString(key)
+		if err != nil {
+			return nil, errors.New("key is neither PEM nor base64-encoded PEM")
+		}
+		data = decoded
+	}
+
+	block, _ := pem.Decode(data)
+	if block == nil {
+		return nil, errors.New("key is not a valid PEM block")
+	}
+	return block, nil
+}
+
+// ParsePrivateKey parses the Ed25519 private key a service signs its calls
+// with, a PKCS #8 PEM block as written by `openssl genpkey -algorithm ed25519`.
+func ParsePrivateKey(key string) (ed25519.PrivateKey, error) {
+	block, err := decodePEM(key)
+	if err != nil {
+		return nil, err
+	}
+
+	parsed, err := x509.ParsePKCS8PrivateKey(block.Bytes)
+	if err != nil {
+		return nil, fmt.Errorf("invalid service private key: %w", err)
+	}
+	privateKey, ok := parsed.(ed25519.PrivateKey)
+	if !ok {
+		return nil, errors.New("service private key is not an Ed25519 key")
+	}
+	return privateKey, nil
+}
+
+// ParsePublicKey parses the Ed25519 public key of a service, a PKIX PEM block
+// as written by `openssl pkey -pubout`.
+func ParsePublicKey(key string) (ed25519.PublicKey, error) {
+	block, err := decodePEM(key)
+	if err != nil {
+		return nil, err
+	}
+
+	parsed, err := x509.ParsePKIXPublicKey(block.Bytes)
+	if err != nil {
+		return nil, fmt.Errorf("invalid service public key: %w", err)
+	}
+	publicKey, ok := parsed.(ed25519.PublicKey)
+	if !ok {
+		return nil, errors.New("service public key is not an Ed25519 key")
+	}
+	return publicKey, nil
+}
+
+// ParsePublicKeys parses a comma-separated list of service=key pairs, each key
+// a base64-encoded PEM public key, into the public keys of the services.
+func ParsePublicKeys(list string) (map[string]ed25519.PublicKey, error) {
+	keys := make(map[string]ed25519.PublicKey)
+	for _, entry := range strings.Split(list, ",") {
+		entry = strings.TrimSpace(entry)
+		if entry == "" {
+			continue
+		}
+
+		service, key, ok := strings.Cut(entry, "=")
+		service = strings.TrimSpace(service)
+		if !ok || service == "" {
+			return nil, fmt.Errorf("service public key %q is not service=key", entry)
+		}
+		if _, dup := keys[service]; dup {
+			return nil, fmt.Errorf("service %s has more than one public key", service)
+		}
+
+		publicKey, err := ParsePublicKey(key)
+		if err != nil {
+			return nil, fmt.Errorf("public key of %s: %w", service, err)
+		}
+		keys[service] = publicKey
+	}
+
+	if len(keys) == 0 {
+		return nil, errors.New("no service public keys given")
+	}
+	return keys, nil
+}
@@ -0,0 +1,30 @@
+package svcauth
+
+import (
+	"context"
+
+	jwtvalidator "github.com/tamirat-dejene/ha-soranu/shared/pkg/auth/jwtvalidator"
+)
+
+// GatewayService is the name the API gateway signs its calls with. The
+// gateway only calls on behalf of end users, so its calls never act on
+// resources without one.
+const GatewayService = "api-gateway"
+
+// IsAdmin reports whether the call ctx belongs to is made for an admin.
+func IsAdmin(ctx context.Context) bool {
+	user, ok := UserFromContext(ctx)
+	return ok && user.HasRole(jwtvalidator.RoleAdmin)
+}
+
+// CanActFor reports whether the call ctx belongs to may act on what ownerID
+// owns: its user is the owner or an admin. Calls without a user, made by a
+// service on its own behalf or by a background job, may act for anyone,
+// unless they come from the API gateway.
+func CanActFor(ctx context.Context, ownerID string) bool {
+	if user, ok := UserFromContext(ctx); ok {
+		return (ownerID != "" && user.ID == ownerID) || user.HasRole(jwtvalidator.RoleAdmin)
+	}
+	caller, ok := CallerFromContext(ctx)
+	return !ok || caller.Service != GatewayService
+}
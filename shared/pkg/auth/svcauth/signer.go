@@ -0,0 +1,72 @@
+package svcauth
+
+import (
+	"context"
+	"crypto/ed25519"
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/metadata"
+)
+
+// Signer signs the outgoing calls of a service.
+type Signer struct {
+	service string
+	key     ed25519.PrivateKey
+}
+
+// NewSigner creates a Signer for the calls of service, signing them with the
+// service's private key (see [ParsePrivateKey]).
+func NewSigner(service, privateKey string) (*Signer, error) {
+	key, err := ParsePrivateKey(privateKey)
+	if err != nil {
+		return nil, fmt.Errorf("signer of %s: %w", service, err)
+	}
+	return &Signer{service: service, key: key}, nil
+}
+
+// DialOptions returns the options that make a client connection sign its
+// calls.
+func (s *Signer) DialOptions() []grpc.DialOption {
+	return []grpc.DialOption{
+		grpc.WithChainUnaryInterceptor(s.UnaryClientInterceptor()),
+		grpc.WithChainStreamInterceptor(s.StreamClientInterceptor()),
+	}
+}
+
+// UnaryClientInterceptor signs unary calls.
+func (s *Signer) UnaryClientInterceptor() grpc.UnaryClientInterceptor {
+	return func(ctx context.Context, method string, req, reply any, cc *grpc.ClientConn, invoker grpc.UnaryInvoker, opts ...grpc.CallOption) error {
+		return invoker(s.signedContext(ctx, method), method, req, reply, cc, opts...)
+	}
+}
+
+// StreamClientInterceptor signs streaming calls.
+func (s *Signer) StreamClientInterceptor() grpc.StreamClientInterceptor {
+	return func(ctx context.Context, desc *grpc.StreamDesc, cc *grpc.ClientConn, method string, streamer grpc.Streamer, opts ...grpc.CallOption) (grpc.ClientStream, error) {
+		return streamer(s.signedContext(ctx, method), desc, cc, method, opts...)
+	}
+}
+
+func (s *Signer) signedContext(ctx context.Context, method string) context.Context {
+	user, _ := UserFromContext(ctx)
+	timestamp := strconv.FormatInt(time.Now().Unix(), 10)
+
+	pairs := []string{
+		serviceKey, s.service,
+		timestampKey, timestamp,
+		signatureKey, sign(s.key, s.service, timestamp, method, user),
+	}
+	if user.ID != "" {
+		pairs = append(pairs,
+			userIDKey, user.ID,
+			userRolesKey, strings.Join(user.Roles, ","),
+			sessionIDKey, user.SessionID,
+		)
+	}
+
+	return metadata.AppendToOutgoingContext(ctx, pairs...)
+}
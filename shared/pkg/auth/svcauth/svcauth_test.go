@@ -0,0 +1,263 @@
+package svcauth
+
+import (
+	"context"
+	"crypto/ed25519"
+	"crypto/rand"
+	"crypto/x509"
+	"encoding/base64"
+	"encoding/pem"
+	"strconv"
+	"strings"
+	"testing"
+	"time"
+
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+)
+
+const testMethod = "/restaurant.RestaurantService/GetOrder"
+
+// testKeys returns a new key pair as the services are configured with it:
+// the PEM private key and the base64-encoded PEM public key.
+func testKeys(t *testing.T) (privateKey, publicKey string) {
+	t.Helper()
+
+	public, private, err := ed25519.GenerateKey(rand.Reader)
+	if err != nil {
+		t.Fatal(err)
+	}
+	privateDER, err := x509.MarshalPKCS8PrivateKey(private)
+	if err != nil {
+		t.Fatal(err)
+	}
+	publicDER, err := x509.MarshalPKIXPublicKey(public)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	privateKey = string(pem.EncodeToMemory(&pem.Block{Type: "PRIVATE KEY", Bytes: privateDER}))
+	publicKey = base64.StdEncoding.EncodeToString(pem.EncodeToMemory(&pem.Block{Type: "PUBLIC KEY", Bytes: publicDER}))
+	return privateKey, publicKey
+}
+
+// signedMetadata returns the metadata of a call to method signed by service
+// with privateKey at signedAt.
+func signedMetadata(t *testing.T, privateKey, service string, signedAt time.Time, method string, user User) metadata.MD {
+	t.Helper()
+
+	key, err := ParsePrivateKey(privateKey)
+	if err != nil {
+		t.Fatal(err)
+	}
+	timestamp := strconv.FormatInt(signedAt.Unix(), 10)
+
+	md := metadata.Pairs(
+		serviceKey, service,
+		timestampKey, timestamp,
+		signatureKey, sign(key, service, timestamp, method, user),
+	)
+	if user.ID != "" {
+		md.Set(userIDKey, user.ID)
+		md.Set(userRolesKey, strings.Join(user.Roles, ","))
+		md.Set(sessionIDKey, user.SessionID)
+	}
+	return md
+}
+
+func TestVerifierAuthenticatesCalls(t *testing.T) {
+	gatewayKey, gatewayPublic := testKeys(t)
+	restaurantKey, restaurantPublic := testKeys(t)
+	strangerKey, _ := testKeys(t)
+
+	verifier, err := NewVerifier(GatewayService + "=" + gatewayPublic + ", restaurant-service=" + restaurantPublic)
+	if err != nil {
+		t.Fatalf("NewVerifier() error = %v", err)
+	}
+
+	customer := User{ID: "user-1", Roles: []string{"customer"}, SessionID: "session-1"}
+	now := time.Now()
+
+	tests := []struct {
+		name     string
+		md       func(t *testing.T) metadata.MD
+		wantCode codes.Code
+		wantUser User
+	}{
+		{
+			name: "gateway call for a user",
+			md: func(t *testing.T) metadata.MD {
+				return signedMetadata(t, gatewayKey, GatewayService, now, testMethod, customer)
+			},
+			wantCode: codes.OK,
+			wantUser: customer,
+		},
+		{
+			name: "service call without a user",
+			md: func(t *testing.T) metadata.MD {
+				return signedMetadata(t, restaurantKey, "restaurant-service", now, testMethod, User{})
+			},
+			wantCode: codes.OK,
+		},
+		{
+			name: "signed within the clock skew",
+			md: func(t *testing.T) metadata.MD {
+				return signedMetadata(t, gatewayKey, GatewayService, now.Add(-MaxClockSkew+5*time.Second), testMethod, customer)
+			},
+			wantCode: codes.OK,
+			wantUser: customer,
+		},
+		{
+			name: "signed too long ago",
+			md: func(t *testing.T) metadata.MD {
+				return signedMetadata(t, gatewayKey, GatewayService, now.Add(-MaxClockSkew-5*time.Second), testMethod, customer)
+			},
+			wantCode: codes.Unauthenticated,
+		},
+		{
+			name: "signed too far in the future",
+			md: func(t *testing.T) metadata.MD {
+				return signedMetadata(t, gatewayKey, GatewayService, now.Add(MaxClockSkew+5*time.Second), testMethod, customer)
+			},
+			wantCode: codes.Unauthenticated,
+		},
+		{
+			name: "role added after signing",
+			md: func(t *testing.T) metadata.MD {
+				md := signedMetadata(t, gatewayKey, GatewayService, now, testMethod, customer)
+				md.Set(userRolesKey, "customer,admin")
+				return md
+			},
+			wantCode: codes.Unauthenticated,
+		},
+		{
+			name: "user swapped after signing",
+			md: func(t *testing.T) metadata.MD {
+				md := signedMetadata(t, gatewayKey, GatewayService, now, testMethod, customer)
+				md.Set(userIDKey, "user-2")
+				return md
+			},
+			wantCode: codes.Unauthenticated,
+		},
+		{
+			name: "signed for another method",
+			md: func(t *testing.T) metadata.MD {
+				return signedMetadata(t, gatewayKey, GatewayService, now, "/restaurant.RestaurantService/ListOrders", customer)
+			},
+			wantCode: codes.Unauthenticated,
+		},
+		{
+			name: "service calling in the name of another",
+			md: func(t *testing.T) metadata.MD {
+				return signedMetadata(t, restaurantKey, GatewayService, now, testMethod, customer)
+			},
+			wantCode: codes.Unauthenticated,
+		},
+		{
+			name: "unknown service",
+			md: func(t *testing.T) metadata.MD {
+				return signedMetadata(t, strangerKey, "payment-service", now, testMethod, User{})
+			},
+			wantCode: codes.Unauthenticated,
+		},
+		{
+			name: "signature is not base64",
+			md: func(t *testing.T) metadata.MD {
+				md := signedMetadata(t, gatewayKey, GatewayService, now, testMethod, customer)
+				md.Set(signatureKey, "not base64!")
+				return md
+			},
+			wantCode: codes.Unauthenticated,
+		},
+		{
+			name: "timestamp is not a number",
+			md: func(t *testing.T) metadata.MD {
+				md := signedMetadata(t, gatewayKey, GatewayService, now, testMethod, customer)
+				md.Set(timestampKey, "yesterday")
+				return md
+			},
+			wantCode: codes.Unauthenticated,
+		},
+		{
+			name:     "unsigned",
+			md:       func(*testing.T) metadata.MD { return metadata.Pairs(serviceKey, GatewayService) },
+			wantCode: codes.Unauthenticated,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctx := metadata.NewIncomingContext(context.Background(), tt.md(t))
+
+			caller, err := verifier.verify(ctx, testMethod)
+			if code := status.Code(err); code != tt.wantCode {
+				t.Fatalf("verify() code = %v, want %v (error %v)", code, tt.wantCode, err)
+			}
+			if err != nil {
+				return
+			}
+			if caller.User.ID != tt.wantUser.ID || caller.User.SessionID != tt.wantUser.SessionID ||
+				strings.Join(caller.User.Roles, ",") != strings.Join(tt.wantUser.Roles, ",") {
+				t.Errorf("verify() user = %+v, want %+v", caller.User, tt.wantUser)
+			}
+		})
+	}
+}
+
+func TestSignerCallsPassTheVerifier(t *testing.T) {
+	privateKey, publicKey := testKeys(t)
+
+	signer, err := NewSigner("notification-service", privateKey)
+	if err != nil {
+		t.Fatalf("NewSigner() error = %v", err)
+	}
+	verifier, err := NewVerifier("notification-service=" + publicKey)
+	if err != nil {
+		t.Fatalf("NewVerifier() error = %v", err)
+	}
+
+	admin := User{ID: "admin-1", Roles: []string{"customer", "admin"}, SessionID: "session-1"}
+	outgoing := signer.signedContext(WithUser(context.Background(), admin), testMethod)
+	md, _ := metadata.FromOutgoingContext(outgoing)
+
+	caller, err := verifier.verify(metadata.NewIncomingContext(context.Background(), md), testMethod)
+	if err != nil {
+		t.Fatalf("verify() error = %v", err)
+	}
+	if caller.Service != "notification-service" || caller.User.ID != admin.ID || !caller.User.HasRole("admin") {
+		t.Errorf("verify() caller = %+v, want notification-service calling for %+v", caller, admin)
+	}
+}
+
+func TestKeyConfiguration(t *testing.T) {
+	privateKey, publicKey := testKeys(t)
+	publicPEM, _ := base64.StdEncoding.DecodeString(publicKey)
+
+	tests := []struct {
+		name       string
+		privateKey string
+		publicKeys string
+		wantErr    bool
+	}{
+		{name: "pem private key", privateKey: privateKey, publicKeys: "a=" + publicKey},
+		{name: "base64 private key", privateKey: base64.StdEncoding.EncodeToString([]byte(privateKey)), publicKeys: "a=" + publicKey},
+		{name: "several public keys", privateKey: privateKey, publicKeys: "a=" + publicKey + ",b=" + publicKey + ","},
+		{name: "empty private key", privateKey: "", publicKeys: "a=" + publicKey, wantErr: true},
+		{name: "public key as private key", privateKey: string(publicPEM), publicKeys: "a=" + publicKey, wantErr: true},
+		{name: "no public keys", privateKey: privateKey, publicKeys: " ", wantErr: true},
+		{name: "public key without a service", privateKey: privateKey, publicKeys: publicKey, wantErr: true},
+		{name: "service listed twice", privateKey: privateKey, publicKeys: "a=" + publicKey + ",a=" + publicKey, wantErr: true},
+		{name: "invalid public key", privateKey: privateKey, publicKeys: "a=bm90IGEga2V5", wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, signErr := NewSigner("a", tt.privateKey)
+			_, verifyErr := NewVerifier(tt.publicKeys)
+			if gotErr := signErr != nil || verifyErr != nil; gotErr != tt.wantErr {
+				t.Errorf("NewSigner() error = %v, NewVerifier() error = %v, wantErr %v", signErr, verifyErr, tt.wantErr)
+			}
+		})
+	}
+}
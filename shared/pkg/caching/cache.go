@@ -2,6 +2,8 @@ package caching
 
 import (
 	"context"
+	"crypto/sha1"
+	"encoding/hex"
 	"time"
 )
 
@@ -17,4 +19,55 @@ type CacheClient interface {
 	Decrement(ctx context.Context, key string) (int64, error)
 	Expire(ctx context.Context, key string, expiration time.Duration) error
 	Ping(ctx context.Context) error
+
+	// CompareAndSet sets key to value only while its current value has the
+	// given digest (see [Digest]), or while it does not exist when digest is
+	// "". It reports whether the value was set.
+	CompareAndSet(ctx context.Context, key, digest string, value any, expiration time.Duration) (bool, error)
+
+	// GeoAdd adds member at the given position to the geo index at key, or
+	// moves it there.
+	GeoAdd(ctx context.Context, key, member string, longitude, latitude float64) error
+	// GeoRemove removes member from the geo index at key.
+	GeoRemove(ctx context.Context, key, member string) error
+	// GeoSearch returns the members of the geo index at key within radiusKm
+	// of the given position, the nearest first.
+	GeoSearch(ctx context.Context, key string, longitude, latitude, radiusKm float64) ([]GeoLocation, error)
+}
+
+// GeoLocation is a member of a geo index found by a search.
+type GeoLocation struct {
+	Member     string
+	Longitude  float64
+	Latitude   float64
+	DistanceKm float64
+}
+
+// CompareAndSetScript is the script behind CompareAndSet: it sets KEYS[1] to
+// ARGV[2], expiring after ARGV[3] milliseconds unless that is 0, only while
+// the SHA-1 of its value is ARGV[1], or while it does not exist when ARGV[1]
+// is empty. It returns 1 when the value was set.
+const CompareAndSetScript = `
+local current = redis.call('GET', KEYS[1])
+local matches
+if current then
+	matches = redis.sha1hex(current) == ARGV[1]
+else
+	matches = ARGV[1] == ''
+end
+if not matches then
+	return 0
+end
+if tonumber(ARGV[3]) > 0 then
+	redis.call('SET', KEYS[1], ARGV[2], 'PX', ARGV[3])
+else
+	redis.call('SET', KEYS[1], ARGV[2])
+end
+return 1
+`
+
+// Digest returns the digest of a stored value that CompareAndSet compares.
+func Digest(value string) string {
+	sum := sha1.Sum([]byte(value))
+	return hex.EncodeToString(sum[:])
 }
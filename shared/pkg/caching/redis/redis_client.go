@@ -13,6 +13,8 @@ type redisClient struct {
 	client *redis.Client
 }
 
+var compareAndSet = redis.NewScript(caching.CompareAndSetScript)
+
 // Ping implements [caching.CacheClient].
 func (r *redisClient) Ping(ctx context.Context) error {
 	if err := r.client.Ping().Err(); err != nil {
@@ -94,6 +96,58 @@ func (r *redisClient) Set(ctx context.Context, key string, value any, expiration
 	return nil
 }
 
+// CompareAndSet implements [caching.CacheClient].
+func (r *redisClient) CompareAndSet(ctx context.Context, key, digest string, value any, expiration time.Duration) (bool, error) {
+	set, err := compareAndSet.Run(r.client, []string{key}, digest, value, expiration.Milliseconds()).Int64()
+	if err != nil {
+		return false, fmt.Errorf("failed to compare and set key %s: %w", key, err)
+	}
+	return set == 1, nil
+}
+
+// GeoAdd implements [caching.CacheClient].
+func (r *redisClient) GeoAdd(ctx context.Context, key, member string, longitude, latitude float64) error {
+	location := &redis.GeoLocation{Name: member, Longitude: longitude, Latitude: latitude}
+	if err := r.client.GeoAdd(key, location).Err(); err != nil {
+		return fmt.Errorf("failed to add %s to geo index %s: %w", member, key, err)
+	}
+	return nil
+}
+
+// GeoRemove implements [caching.CacheClient].
+func (r *redisClient) GeoRemove(ctx context.Context, key, member string) error {
+	// A geo index is a sorted set.
+	if err := r.client.ZRem(key, member).Err(); err != nil {
+		return fmt.Errorf("failed to remove %s from geo index %s: %w", member, key, err)
+	}
+	return nil
+}
+
+// GeoSearch implements [caching.CacheClient].
+func (r *redisClient) GeoSearch(ctx context.Context, key string, longitude, latitude, radiusKm float64) ([]caching.GeoLocation, error) {
+	found, err := r.client.GeoRadius(key, longitude, latitude, &redis.GeoRadiusQuery{
+		Radius:    radiusKm,
+		Unit:      "km",
+		WithCoord: true,
+		WithDist:  true,
+		Sort:      "ASC",
+	}).Result()
+	if err != nil {
+		return nil, fmt.Errorf("failed to search geo index %s: %w", key, err)
+	}
+
+	locations := make([]caching.GeoLocation, len(found))
+	for i, l := range found {
+		locations[i] = caching.GeoLocation{
+			Member:     l.Name,
+			Longitude:  l.Longitude,
+			Latitude:   l.Latitude,
+			DistanceKm: l.Dist,
+		}
+	}
+	return locations, nil
+}
+
 // NewRedisClient initializes the Redis client
 func NewRedisClient(host string, port int, password string, db int) (caching.CacheClient, error) {
 	client := redis.NewClient(&redis.Options{
@@ -3,6 +3,7 @@ package valkey
 import (
 	"context"
 	"fmt"
+	"strconv"
 	"time"
 
 	"github.com/tamirat-dejene/ha-soranu/shared/pkg/caching"
@@ -13,6 +14,8 @@ type valkeyClient struct {
 	client valkey.Client
 }
 
+var compareAndSet = valkey.NewLuaScript(caching.CompareAndSetScript)
+
 // Ping implements [caching.CacheClient].
 func (v *valkeyClient) Ping(ctx context.Context) error {
 	cmd := v.client.B().Ping().Build()
@@ -114,17 +117,76 @@ func (v *valkeyClient) Set(ctx context.Context, key string, value any, expiratio
 	return nil
 }
 
-// NewValkeyClient initializes the Valkey client
-func NewValkeyClient(host string, port int, user string, password string, db int) (caching.CacheClient, error) {
-	opts := valkey.ClientOption{
+// CompareAndSet implements [caching.CacheClient].
+func (v *valkeyClient) CompareAndSet(ctx context.Context, key, digest string, value any, expiration time.Duration) (bool, error) {
+	strVal := fmt.Sprintf("%v", value)
+	args := []string{digest, strVal, strconv.FormatInt(expiration.Milliseconds(), 10)}
+
+	set, err := compareAndSet.Exec(ctx, v.client, []string{key}, args).AsInt64()
+	if err != nil {
+		return false, fmt.Errorf("failed to compare and set key %s: %w", key, err)
+	}
+	return set == 1, nil
+}
+
+// GeoAdd implements [caching.CacheClient].
+func (v *valkeyClient) GeoAdd(ctx context.Context, key, member string, longitude, latitude float64) error {
+	cmd := v.client.B().Geoadd().Key(key).LongitudeLatitudeMember().LongitudeLatitudeMember(longitude, latitude, member).Build()
+
+	if err := v.client.Do(ctx, cmd).Error(); err != nil {
+		return fmt.Errorf("failed to add %s to geo index %s: %w", member, key, err)
+	}
+	return nil
+}
+
+// GeoRemove implements [caching.CacheClient].
+func (v *valkeyClient) GeoRemove(ctx context.Context, key, member string) error {
+	// A geo index is a sorted set.
+	cmd := v.client.B().Zrem().Key(key).Member(member).Build()
+
+	if err := v.client.Do(ctx, cmd).Error(); err != nil {
+		return fmt.Errorf("failed to remove %s from geo index %s: %w", member, key, err)
+	}
+	return nil
+}
+
+// GeoSearch implements [caching.CacheClient].
+func (v *valkeyClient) GeoSearch(ctx context.Context, key string, longitude, latitude, radiusKm float64) ([]caching.GeoLocation, error) {
+	cmd := v.client.B().Geosearch().Key(key).Fromlonlat(longitude, latitude).Byradius(radiusKm).Km().Asc().Withcoord().Withdist().Build()
+
+	found, err := v.client.Do(ctx, cmd).AsGeosearch()
+	if err != nil {
+		if valkey.IsValkeyNil(err) {
+			return nil, nil
+		}
+		return nil, fmt.Errorf("failed to search geo index %s: %w", key, err)
+	}
+
+	locations := make([]caching.GeoLocation, len(found))
+	for i, l := range found {
+		locations[i] = caching.GeoLocation{
+			Member:     l.Name,
+			Longitude:  l.Longitude,
+			Latitude:   l.Latitude,
+			DistanceKm: l.Dist,
+		}
+	}
+	return locations, nil
+}
+
+func clientOptions(host string, port int, user string, password string, db int) valkey.ClientOption {
+	return valkey.ClientOption{
 		InitAddress:  []string{fmt.Sprintf("%s:%d", host, port)},
 		Username:     user,
 		Password:     password,
 		SelectDB:     db,
 		DisableCache: true,
 	}
+}
 
-	client, err := valkey.NewClient(opts)
+// NewValkeyClient initializes the Valkey client
+func NewValkeyClient(host string, port int, user string, password string, db int) (caching.CacheClient, error) {
+	client, err := valkey.NewClient(clientOptions(host, port, user, password, db))
 	if err != nil {
 		return nil, fmt.Errorf("failed to connect to valkey: %w", err)
 	}
@@ -133,3 +195,21 @@ func NewValkeyClient(host string, port int, user string, password string, db int
 		client: client,
 	}, nil
 }
+
+// NewReconnectingValkeyClient initializes a client of a single Valkey server
+// that is created even while the server is unreachable. Commands fail until
+// it is back; the client connects again on the next command.
+func NewReconnectingValkeyClient(host string, port int, user string, password string, db int) (caching.CacheClient, error) {
+	opts := clientOptions(host, port, user, password, db)
+	opts.ForceSingleClient = true
+
+	// The client is returned along with the error of its first connection.
+	client, err := valkey.NewClient(opts)
+	if client == nil {
+		return nil, fmt.Errorf("failed to create valkey client: %w", err)
+	}
+
+	return &valkeyClient{
+		client: client,
+	}, nil
+}
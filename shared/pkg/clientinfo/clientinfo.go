@@ -0,0 +1,64 @@
+// Package clientinfo passes what the API gateway knows about the end user's
+// client to the services behind it, in gRPC metadata.
+package clientinfo
+
+import (
+	"context"
+
+	"google.golang.org/grpc/metadata"
+)
+
+const (
+	ipKey        = "x-client-ip"
+	userAgentKey = "x-client-user-agent"
+	deviceIDKey  = "x-device-id"
+)
+
+// Info describes the client a request originates from.
+type Info struct {
+	IP        string
+	UserAgent string
+	// DeviceID is an identifier the client app chose for its installation.
+	DeviceID string
+}
+
+// NewOutgoingContext attaches info to the metadata of outgoing gRPC calls.
+func NewOutgoingContext(ctx context.Context, info Info) context.Context {
+	var pairs []string
+	for key, value := range map[string]string{
+		ipKey:        info.IP,
+		userAgentKey: info.UserAgent,
+		deviceIDKey:  info.DeviceID,
+	} {
+		if value != "" {
+			pairs = append(pairs, key, value)
+		}
+	}
+
+	if len(pairs) == 0 {
+		return ctx
+	}
+	return metadata.AppendToOutgoingContext(ctx, pairs...)
+}
+
+// FromIncomingContext reads the info attached by the caller. Fields the caller
+// did not set are empty.
+func FromIncomingContext(ctx context.Context) Info {
+	md, ok := metadata.FromIncomingContext(ctx)
+	if !ok {
+		return Info{}
+	}
+
+	return Info{
+		IP:        first(md, ipKey),
+		UserAgent: first(md, userAgentKey),
+		DeviceID:  first(md, deviceIDKey),
+	}
+}
+
+func first(md metadata.MD, key string) string {
+	if values := md.Get(key); len(values) > 0 {
+		return values[0]
+	}
+	return ""
+}
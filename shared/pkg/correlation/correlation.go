@@ -0,0 +1,124 @@
+// Package correlation follows a user request through the services it
+// reaches, by an ID the API gateway assigns to it. The ID travels in the
+// X-Request-ID HTTP header, in gRPC metadata and with published events, and
+// is logged by the logger the context carries.
+package correlation
+
+import (
+	"context"
+	"regexp"
+
+	"github.com/google/uuid"
+	"github.com/tamirat-dejene/ha-soranu/shared/pkg/logger"
+	"go.uber.org/zap"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/metadata"
+)
+
+const (
+	// HTTPHeader carries the ID of HTTP requests and responses.
+	HTTPHeader = "X-Request-ID"
+	// KafkaHeader carries the ID of published events.
+	KafkaHeader = "correlation_id"
+
+	metadataKey = "x-request-id"
+	logField    = "correlation_id"
+)
+
+// validID bounds the IDs accepted from clients, so that they cannot fill the
+// logs with arbitrary text.
+var validID = regexp.MustCompile(`^[A-Za-z0-9._:-]{1,128}$`)
+
+type idKey struct{}
+
+// NewID generates an ID for a request that came without one.
+func NewID() string {
+	return uuid.NewString()
+}
+
+// Valid reports whether id may be used as given by a client.
+func Valid(id string) bool {
+	return validID.MatchString(id)
+}
+
+// NewContext returns a copy of ctx carrying id, with a logger that logs it.
+func NewContext(ctx context.Context, id string) context.Context {
+	ctx = context.WithValue(ctx, idKey{}, id)
+	return logger.NewContext(ctx, logger.FromContext(ctx).With(zap.String(logField, id)))
+}
+
+// FromContext returns the ID carried by ctx, or an empty string.
+func FromContext(ctx context.Context) string {
+	id, _ := ctx.Value(idKey{}).(string)
+	return id
+}
+
+// DialOptions returns the options that make a client connection forward
+// the ID of the calling context.
+func DialOptions() []grpc.DialOption {
+	return []grpc.DialOption{
+		grpc.WithChainUnaryInterceptor(UnaryClientInterceptor()),
+		grpc.WithChainStreamInterceptor(StreamClientInterceptor()),
+	}
+}
+
+// UnaryClientInterceptor forwards the ID with unary calls.
+func UnaryClientInterceptor() grpc.UnaryClientInterceptor {
+	return func(ctx context.Context, method string, req, reply any, cc *grpc.ClientConn, invoker grpc.UnaryInvoker, opts ...grpc.CallOption) error {
+		return invoker(outgoingContext(ctx), method, req, reply, cc, opts...)
+	}
+}
+
+// StreamClientInterceptor forwards the ID with streaming calls.
+func StreamClientInterceptor() grpc.StreamClientInterceptor {
+	return func(ctx context.Context, desc *grpc.StreamDesc, cc *grpc.ClientConn, method string, streamer grpc.Streamer, opts ...grpc.CallOption) (grpc.ClientStream, error) {
+		return streamer(outgoingContext(ctx), desc, cc, method, opts...)
+	}
+}
+
+// UnaryServerInterceptor puts the ID of unary calls in their context. Calls
+// without one get a new ID. It should run first, so that the interceptors
+// after it log the ID.
+func UnaryServerInterceptor() grpc.UnaryServerInterceptor {
+	return func(ctx context.Context, req any, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (any, error) {
+		return handler(incomingContext(ctx), req)
+	}
+}
+
+// StreamServerInterceptor puts the ID of streaming calls in their context.
+func StreamServerInterceptor() grpc.StreamServerInterceptor {
+	return func(srv any, ss grpc.ServerStream, info *grpc.StreamServerInfo, handler grpc.StreamHandler) error {
+		return handler(srv, &serverStream{ServerStream: ss, ctx: incomingContext(ss.Context())})
+	}
+}
+
+func outgoingContext(ctx context.Context) context.Context {
+	id := FromContext(ctx)
+	if id == "" {
+		return ctx
+	}
+	return metadata.AppendToOutgoingContext(ctx, metadataKey, id)
+}
+
+func incomingContext(ctx context.Context) context.Context {
+	var id string
+	if md, ok := metadata.FromIncomingContext(ctx); ok {
+		if values := md.Get(metadataKey); len(values) > 0 && Valid(values[0]) {
+			id = values[0]
+		}
+	}
+	if id == "" {
+		id = NewID()
+	}
+	return NewContext(ctx, id)
+}
+
+// serverStream carries the context with the ID to stream handlers.
+type serverStream struct {
+	grpc.ServerStream
+	ctx context.Context
+}
+
+func (s *serverStream) Context() context.Context {
+	return s.ctx
+}
@@ -0,0 +1,87 @@
+package correlation
+
+import (
+	"context"
+	"os"
+	"strings"
+	"testing"
+
+	"github.com/tamirat-dejene/ha-soranu/shared/pkg/logger"
+	"go.uber.org/zap"
+	"google.golang.org/grpc/metadata"
+)
+
+func TestMain(m *testing.M) {
+	logger.Log = zap.NewNop()
+	os.Exit(m.Run())
+}
+
+func TestValid(t *testing.T) {
+	tests := []struct {
+		name string
+		id   string
+		want bool
+	}{
+		{name: "uuid", id: "0b6f3a52-8d7e-4c1a-9f2b-5e4d3c2b1a09", want: true},
+		{name: "other tracing ids", id: "req_01.HX:4", want: true},
+		{name: "longest", id: strings.Repeat("a", 128), want: true},
+		{name: "empty", id: "", want: false},
+		{name: "too long", id: strings.Repeat("a", 129), want: false},
+		{name: "space", id: "request 1", want: false},
+		{name: "newline", id: "request-1\nfake log line", want: false},
+		{name: "log markup", id: `"}{"level":"error`, want: false},
+		{name: "not ascii", id: "requête", want: false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := Valid(tt.id); got != tt.want {
+				t.Errorf("Valid(%q) = %v, want %v", tt.id, got, tt.want)
+			}
+		})
+	}
+
+	if id := NewID(); !Valid(id) {
+		t.Errorf("NewID() = %q is not valid", id)
+	}
+}
+
+func TestIDCrossesCalls(t *testing.T) {
+	tests := []struct {
+		name     string
+		incoming []string
+		wantKept bool
+	}{
+		{name: "valid id kept", incoming: []string{"request-1"}, wantKept: true},
+		{name: "invalid id replaced", incoming: []string{"request 1"}},
+		{name: "no id", incoming: nil},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			md := metadata.MD{}
+			if tt.incoming != nil {
+				md.Set(metadataKey, tt.incoming...)
+			}
+
+			ctx := incomingContext(metadata.NewIncomingContext(context.Background(), md))
+			id := FromContext(ctx)
+			if !Valid(id) {
+				t.Fatalf("FromContext() = %q, want a valid id", id)
+			}
+			if kept := id == strings.Join(tt.incoming, ""); kept != tt.wantKept {
+				t.Errorf("FromContext() = %q, incoming %q kept = %v, want %v", id, tt.incoming, kept, tt.wantKept)
+			}
+
+			// Calls made while handling the request forward the same id.
+			out, _ := metadata.FromOutgoingContext(outgoingContext(ctx))
+			if got := out.Get(metadataKey); len(got) != 1 || got[0] != id {
+				t.Errorf("outgoing %s = %q, want %q", metadataKey, got, id)
+			}
+		})
+	}
+
+	if _, ok := metadata.FromOutgoingContext(outgoingContext(context.Background())); ok {
+		t.Errorf("outgoingContext() without an id added metadata")
+	}
+}
@@ -5,4 +5,17 @@ const (
 	OrderShippedEvent   = "order.shipped"
 	OrderCancelledEvent = "order.cancelled"
 	OrderStatusUpdatedEvent  = "order.status_updated"
+
+	// A user asked to delete their account or to export their data. Every
+	// service holding user data answers with a UserDataProcessedEvent.
+	UserDeletionRequestedEvent = "user.deletion_requested"
+	UserExportRequestedEvent   = "user.export_requested"
+	UserDataProcessedEvent     = "user.data_processed"
+)
+
+// Services that hold user data, as named in UserDataProcessedEvent.
+const (
+	UserDataServiceAuth         = "auth"
+	UserDataServiceRestaurant   = "restaurant"
+	UserDataServiceNotification = "notification"
 )
@@ -6,10 +6,12 @@ import (
 	"time"
 
 	"github.com/google/uuid"
+	"github.com/tamirat-dejene/ha-soranu/shared/pkg/correlation"
 	"github.com/tamirat-dejene/ha-soranu/shared/pkg/logger"
 	"github.com/tamirat-dejene/ha-soranu/shared/pkg/messaging/kafka"
 	envent_envelope "github.com/tamirat-dejene/ha-soranu/shared/protos/envent_envelopepb"
 	"github.com/tamirat-dejene/ha-soranu/shared/protos/orderpb"
+	"github.com/tamirat-dejene/ha-soranu/shared/protos/userdatapb"
 	"go.uber.org/zap"
 	"google.golang.org/protobuf/proto"
 )
@@ -18,6 +20,9 @@ import (
 type EventPublisher interface {
 	PublishOrderCreated(ctx context.Context, event *orderpb.OrderCreated) error
 	PublishOrderStatusUpdated(ctx context.Context, event *orderpb.OrderStatusUpdated) error
+	PublishUserDeletionRequested(ctx context.Context, event *userdatapb.UserDataRequested) error
+	PublishUserExportRequested(ctx context.Context, event *userdatapb.UserDataRequested) error
+	PublishUserDataProcessed(ctx context.Context, event *userdatapb.UserDataProcessed) error
 }
 
 type kafkaEventPublisher struct {
@@ -41,6 +46,23 @@ func (p *kafkaEventPublisher) PublishOrderStatusUpdated(ctx context.Context, eve
 	return p.publishEvent(ctx, OrderStatusUpdatedEvent, event.OrderId, event)
 }
 
+// PublishUserDeletionRequested publishes a UserDataRequested event asking
+// services to delete the user's data
+func (p *kafkaEventPublisher) PublishUserDeletionRequested(ctx context.Context, event *userdatapb.UserDataRequested) error {
+	return p.publishEvent(ctx, UserDeletionRequestedEvent, event.UserId, event)
+}
+
+// PublishUserExportRequested publishes a UserDataRequested event asking
+// services to export the user's data
+func (p *kafkaEventPublisher) PublishUserExportRequested(ctx context.Context, event *userdatapb.UserDataRequested) error {
+	return p.publishEvent(ctx, UserExportRequestedEvent, event.UserId, event)
+}
+
+// PublishUserDataProcessed publishes a UserDataProcessed event to Kafka
+func (p *kafkaEventPublisher) PublishUserDataProcessed(ctx context.Context, event *userdatapb.UserDataProcessed) error {
+	return p.publishEvent(ctx, UserDataProcessedEvent, event.UserId, event)
+}
+
 // publishEvent is the generic event publishing logic
 func (p *kafkaEventPublisher) publishEvent(ctx context.Context, eventType string, key string, event proto.Message) error {
 	// 1. Marshal the domain event to binary protobuf
@@ -50,12 +72,14 @@ func (p *kafkaEventPublisher) publishEvent(ctx context.Context, eventType string
 		return fmt.Errorf("failed to marshal event: %w", err)
 	}
 
-	// 2. Create event envelope with event_type
+	// 2. Create event envelope with event_type and the ID of the request
+	// that caused the event
 	envelope := &envent_envelope.EventEnvelope{
 		EventId:        uuid.NewString(),
 		EventType:      eventType,
 		OccurredAtUnix: time.Now().Unix(),
 		Payload:        eventBytes,
+		CorrelationId:  correlation.FromContext(ctx),
 	}
 
 	// 3. Marshal the envelope to binary protobuf
@@ -75,16 +99,19 @@ func (p *kafkaEventPublisher) publishEvent(ctx context.Context, eventType string
 			"content_type": []byte("application/x-protobuf"),
 		},
 	}
+	if envelope.CorrelationId != "" {
+		msg.Headers[correlation.KafkaHeader] = []byte(envelope.CorrelationId)
+	}
 
 	if err := p.producer.Publish(ctx, msg); err != nil {
-		logger.Error("failed to publish event to kafka",
+		logger.FromContext(ctx).Error("failed to publish event to kafka",
 			zap.String("event_type", eventType),
 			zap.String("key", key),
 			zap.Error(err))
 		return fmt.Errorf("failed to publish event: %w", err)
 	}
 
-	logger.Info("published event to kafka",
+	logger.FromContext(ctx).Info("published event to kafka",
 		zap.String("event_type", eventType),
 		zap.String("event_id", envelope.EventId),
 		zap.String("key", key))
@@ -26,9 +26,9 @@ func LoggingInterceptor(ctx context.Context, req any, info *grpc.UnaryServerInfo
 
 	if err != nil {
 		fields = append(fields, zap.Error(err))
-		Error("gRPC Request Failed", fields...)
+		FromContext(ctx).Error("gRPC Request Failed", fields...)
 	} else {
-		Info("gRPC Request Success", fields...)
+		FromContext(ctx).Info("gRPC Request Success", fields...)
 	}
 
 	return resp, err
@@ -0,0 +1,76 @@
+package sarama
+
+import (
+	"context"
+	"sync"
+
+	"github.com/IBM/sarama"
+	"github.com/tamirat-dejene/ha-soranu/shared/pkg/messaging/kafka"
+)
+
+// BroadcastConsumer reads every partition of its topics from the newest
+// offset without joining a consumer group, so each process using one sees
+// every event published while it runs. It commits no offsets and leaves
+// nothing behind on the brokers. Partitions added while it runs are only read
+// after a restart.
+type BroadcastConsumer struct {
+	consumer sarama.Consumer
+}
+
+func NewBroadcastConsumer(brokers []string) (*BroadcastConsumer, error) {
+	c, err := sarama.NewConsumer(brokers, NewConfig())
+	if err != nil {
+		return nil, err
+	}
+
+	return &BroadcastConsumer{consumer: c}, nil
+}
+
+// Subscribe implements [kafka.Consumer]. Partitions are handled concurrently,
+// as in a consumer group.
+func (c *BroadcastConsumer) Subscribe(ctx context.Context, topics []string, handler kafka.Handler) error {
+	var partitions []sarama.PartitionConsumer
+	closeAll := func() {
+		for _, pc := range partitions {
+			pc.AsyncClose()
+		}
+	}
+
+	for _, topic := range topics {
+		ids, err := c.consumer.Partitions(topic)
+		if err != nil {
+			closeAll()
+			return err
+		}
+
+		for _, id := range ids {
+			pc, err := c.consumer.ConsumePartition(topic, id, sarama.OffsetNewest)
+			if err != nil {
+				closeAll()
+				return err
+			}
+			partitions = append(partitions, pc)
+		}
+	}
+
+	var wg sync.WaitGroup
+	for _, pc := range partitions {
+		wg.Add(1)
+		go func(pc sarama.PartitionConsumer) {
+			defer wg.Done()
+			for message := range pc.Messages() {
+				_ = handler(fromSarama(ctx, message))
+			}
+		}(pc)
+	}
+
+	<-ctx.Done()
+	closeAll()
+	wg.Wait()
+
+	return ctx.Err()
+}
+
+func (c *BroadcastConsumer) Close() error {
+	return c.consumer.Close()
+}
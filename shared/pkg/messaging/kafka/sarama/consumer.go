@@ -4,6 +4,7 @@ import (
 	"context"
 
 	"github.com/IBM/sarama"
+	"github.com/tamirat-dejene/ha-soranu/shared/pkg/correlation"
 	"github.com/tamirat-dejene/ha-soranu/shared/pkg/messaging/kafka"
 )
 
@@ -28,17 +29,34 @@ func (c *consumerHandler) Cleanup(sarama.ConsumerGroupSession) error {
 // ConsumeClaim implements [sarama.ConsumerGroupHandler].
 func (c *consumerHandler) ConsumeClaim(session sarama.ConsumerGroupSession, claim sarama.ConsumerGroupClaim) error {
 	for message := range claim.Messages() {
-		_ = c.handler(session.Context(), &kafka.Message{
-			Topic: message.Topic,
-			Key:   message.Key,
-			Value: message.Value,
-		})
+		_ = c.handler(fromSarama(session.Context(), message))
 
 		session.MarkMessage(message, "")
 	}
 
 	return nil
 }
+
+// fromSarama converts a consumed message, returning it with the context its
+// handler runs in.
+func fromSarama(ctx context.Context, message *sarama.ConsumerMessage) (context.Context, *kafka.Message) {
+	headers := make(map[string][]byte, len(message.Headers))
+	for _, header := range message.Headers {
+		headers[string(header.Key)] = header.Value
+	}
+
+	// Handlers log with the ID of the request that caused the event.
+	if id := string(headers[correlation.KafkaHeader]); correlation.Valid(id) {
+		ctx = correlation.NewContext(ctx, id)
+	}
+
+	return ctx, &kafka.Message{
+		Topic:   message.Topic,
+		Key:     message.Key,
+		Value:   message.Value,
+		Headers: headers,
+	}
+}
 func NewConsumer(brokers []string, groupID string) (*Consumer, error) {
 	g, err := sarama.NewConsumerGroup(brokers, groupID, NewConfig())
 	if err != nil {
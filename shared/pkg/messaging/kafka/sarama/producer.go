@@ -21,10 +21,16 @@ func NewProducer(brokers []string) (*Producer, error) {
 }
 
 func (p *Producer) Publish(ctx context.Context, msg *kafka.Message) error {
+	headers := make([]sarama.RecordHeader, 0, len(msg.Headers))
+	for key, value := range msg.Headers {
+		headers = append(headers, sarama.RecordHeader{Key: []byte(key), Value: value})
+	}
+
 	_, _, err := p.producer.SendMessage(&sarama.ProducerMessage{
-		Topic: msg.Topic,
-		Key:   sarama.ByteEncoder(msg.Key),
-		Value: sarama.ByteEncoder(msg.Value),
+		Topic:   msg.Topic,
+		Key:     sarama.ByteEncoder(msg.Key),
+		Value:   sarama.ByteEncoder(msg.Value),
+		Headers: headers,
 	})
 
 	return err
@@ -0,0 +1,557 @@
+// Code generated by protoc-gen-go-grpc. DO NOT EDIT.
+// versions:
+// - protoc-gen-go-grpc v1.6.0
+// - protoc             v3.21.12
+// source: admin.proto
+
+package adminpb
+
+import (
+	context "context"
+	restaurantpb "github.com/tamirat-dejene/ha-soranu/shared/protos/restaurantpb"
+	grpc "google.golang.org/grpc"
+	codes "google.golang.org/grpc/codes"
+	status "google.golang.org/grpc/status"
+)
+
+// This is a compile-time assertion to ensure that this generated file
+// is compatible with the grpc package it is being compiled against.
+// Requires gRPC-Go v1.64.0 or later.
+const _ = grpc.SupportPackageIsVersion9
+
+const (
+	UserAdminService_SearchUsers_FullMethodName        = "/admin.UserAdminService/SearchUsers"
+	UserAdminService_SuspendUser_FullMethodName        = "/admin.UserAdminService/SuspendUser"
+	UserAdminService_UnsuspendUser_FullMethodName      = "/admin.UserAdminService/UnsuspendUser"
+	UserAdminService_RevokeUserSessions_FullMethodName = "/admin.UserAdminService/RevokeUserSessions"
+	UserAdminService_GrantRole_FullMethodName          = "/admin.UserAdminService/GrantRole"
+	UserAdminService_RevokeRole_FullMethodName         = "/admin.UserAdminService/RevokeRole"
+)
+
+// UserAdminServiceClient is the client API for UserAdminService service.
+//
+// For semantics around ctx use and closing/ending streaming RPCs, please refer to https://pkg.go.dev/google.golang.org/grpc/?tab=doc#ClientConn.NewStream.
+//
+// UserAdminService lets operations staff moderate users. It is served by the auth service and only answers calls made for admins.
+type UserAdminServiceClient interface {
+	// Searches users by email or username.
+	SearchUsers(ctx context.Context, in *SearchUsersRequest, opts ...grpc.CallOption) (*SearchUsersResponse, error)
+	// Suspends a user and ends their sessions. Suspended users cannot log in or refresh their tokens.
+	SuspendUser(ctx context.Context, in *SuspendUserRequest, opts ...grpc.CallOption) (*UserAccount, error)
+	// Lifts the suspension of a user.
+	UnsuspendUser(ctx context.Context, in *UnsuspendUserRequest, opts ...grpc.CallOption) (*UserAccount, error)
+	// Ends every session of a user, logging them out everywhere.
+	RevokeUserSessions(ctx context.Context, in *RevokeUserSessionsRequest, opts ...grpc.CallOption) (*RevokeUserSessionsResponse, error)
+	// Grants restaurant_owner or admin to a user. Besides admins, the restaurant service grants restaurant_owner to the users it registers restaurants for. The user's tokens carry the role once refreshed.
+	GrantRole(ctx context.Context, in *GrantRoleRequest, opts ...grpc.CallOption) (*UserAccount, error)
+	// Revokes restaurant_owner or admin from a user. Admins cannot revoke their own admin role.
+	RevokeRole(ctx context.Context, in *RevokeRoleRequest, opts ...grpc.CallOption) (*UserAccount, error)
+}
+
+type userAdminServiceClient struct {
+	cc grpc.ClientConnInterface
+}
+
+func NewUserAdminServiceClient(cc grpc.ClientConnInterface) UserAdminServiceClient {
+	return &userAdminServiceClient{cc}
+}
+
+func (c *userAdminServiceClient) SearchUsers(ctx context.Context, in *SearchUsersRequest, opts ...grpc.CallOption) (*SearchUsersResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(SearchUsersResponse)
+	err := c.cc.Invoke(ctx, UserAdminService_SearchUsers_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *userAdminServiceClient) SuspendUser(ctx context.Context, in *SuspendUserRequest, opts ...grpc.CallOption) (*UserAccount, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(UserAccount)
+	err := c.cc.Invoke(ctx, UserAdminService_SuspendUser_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *userAdminServiceClient) UnsuspendUser(ctx context.Context, in *UnsuspendUserRequest, opts ...grpc.CallOption) (*UserAccount, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(UserAccount)
+	err := c.cc.Invoke(ctx, UserAdminService_UnsuspendUser_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *userAdminServiceClient) RevokeUserSessions(ctx context.Context, in *RevokeUserSessionsRequest, opts ...grpc.CallOption) (*RevokeUserSessionsResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(RevokeUserSessionsResponse)
+	err := c.cc.Invoke(ctx, UserAdminService_RevokeUserSessions_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *userAdminServiceClient) GrantRole(ctx context.Context, in *GrantRoleRequest, opts ...grpc.CallOption) (*UserAccount, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(UserAccount)
+	err := c.cc.Invoke(ctx, UserAdminService_GrantRole_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *userAdminServiceClient) RevokeRole(ctx context.Context, in *RevokeRoleRequest, opts ...grpc.CallOption) (*UserAccount, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(UserAccount)
+	err := c.cc.Invoke(ctx, UserAdminService_RevokeRole_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+// UserAdminServiceServer is the server API for UserAdminService service.
+// All implementations must embed UnimplementedUserAdminServiceServer
+// for forward compatibility.
+//
+// UserAdminService lets operations staff moderate users. It is served by the auth service and only answers calls made for admins.
+type UserAdminServiceServer interface {
+	// Searches users by email or username.
+	SearchUsers(context.Context, *SearchUsersRequest) (*SearchUsersResponse, error)
+	// Suspends a user and ends their sessions. Suspended users cannot log in or refresh their tokens.
+	SuspendUser(context.Context, *SuspendUserRequest) (*UserAccount, error)
+	// Lifts the suspension of a user.
+	UnsuspendUser(context.Context, *UnsuspendUserRequest) (*UserAccount, error)
+	// Ends every session of a user, logging them out everywhere.
+	RevokeUserSessions(context.Context, *RevokeUserSessionsRequest) (*RevokeUserSessionsResponse, error)
+	// Grants restaurant_owner or admin to a user. Besides admins, the restaurant service grants restaurant_owner to the users it registers restaurants for. The user's tokens carry the role once refreshed.
+	GrantRole(context.Context, *GrantRoleRequest) (*UserAccount, error)
+	// Revokes restaurant_owner or admin from a user. Admins cannot revoke their own admin role.
+	RevokeRole(context.Context, *RevokeRoleRequest) (*UserAccount, error)
+	mustEmbedUnimplementedUserAdminServiceServer()
+}
+
+// UnimplementedUserAdminServiceServer must be embedded to have
+// forward compatible implementations.
+//
+// NOTE: this should be embedded by value instead of pointer to avoid a nil
+// pointer dereference when methods are called.
+type UnimplementedUserAdminServiceServer struct{}
+
+func (UnimplementedUserAdminServiceServer) SearchUsers(context.Context, *SearchUsersRequest) (*SearchUsersResponse, error) {
+	return nil, status.Error(codes.Unimplemented, "method SearchUsers not implemented")
+}
+func (UnimplementedUserAdminServiceServer) SuspendUser(context.Context, *SuspendUserRequest) (*UserAccount, error) {
+	return nil, status.Error(codes.Unimplemented, "method SuspendUser not implemented")
+}
+func (UnimplementedUserAdminServiceServer) UnsuspendUser(context.Context, *UnsuspendUserRequest) (*UserAccount, error) {
+	return nil, status.Error(codes.Unimplemented, "method UnsuspendUser not implemented")
+}
+func (UnimplementedUserAdminServiceServer) RevokeUserSessions(context.Context, *RevokeUserSessionsRequest) (*RevokeUserSessionsResponse, error) {
+	return nil, status.Error(codes.Unimplemented, "method RevokeUserSessions not implemented")
+}
+func (UnimplementedUserAdminServiceServer) GrantRole(context.Context, *GrantRoleRequest) (*UserAccount, error) {
+	return nil, status.Error(codes.Unimplemented, "method GrantRole not implemented")
+}
+func (UnimplementedUserAdminServiceServer) RevokeRole(context.Context, *RevokeRoleRequest) (*UserAccount, error) {
+	return nil, status.Error(codes.Unimplemented, "method RevokeRole not implemented")
+}
+func (UnimplementedUserAdminServiceServer) mustEmbedUnimplementedUserAdminServiceServer() {}
+func (UnimplementedUserAdminServiceServer) testEmbeddedByValue()                          {}
+
+// UnsafeUserAdminServiceServer may be embedded to opt out of forward compatibility for this service.
+// Use of this interface is not recommended, as added methods to UserAdminServiceServer will
+// result in compilation errors.
+type UnsafeUserAdminServiceServer interface {
+	mustEmbedUnimplementedUserAdminServiceServer()
+}
+
+func RegisterUserAdminServiceServer(s grpc.ServiceRegistrar, srv UserAdminServiceServer) {
+	// If the following call panics, it indicates UnimplementedUserAdminServiceServer was
+	// embedded by pointer and is nil.  This will cause panics if an
+	// unimplemented method is ever invoked, so we test this at initialization
+	// time to prevent it from happening at runtime later due to I/O.
+	if t, ok := srv.(interface{ testEmbeddedByValue() }); ok {
+		t.testEmbeddedByValue()
+	}
+	s.RegisterService(&UserAdminService_ServiceDesc, srv)
+}
+
+func _UserAdminService_SearchUsers_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(SearchUsersRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(UserAdminServiceServer).SearchUsers(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: UserAdminService_SearchUsers_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(UserAdminServiceServer).SearchUsers(ctx, req.(*SearchUsersRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _UserAdminService_SuspendUser_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(SuspendUserRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(UserAdminServiceServer).SuspendUser(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: UserAdminService_SuspendUser_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(UserAdminServiceServer).SuspendUser(ctx, req.(*SuspendUserRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _UserAdminService_UnsuspendUser_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(UnsuspendUserRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(UserAdminServiceServer).UnsuspendUser(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: UserAdminService_UnsuspendUser_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(UserAdminServiceServer).UnsuspendUser(ctx, req.(*UnsuspendUserRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _UserAdminService_RevokeUserSessions_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(RevokeUserSessionsRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(UserAdminServiceServer).RevokeUserSessions(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: UserAdminService_RevokeUserSessions_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(UserAdminServiceServer).RevokeUserSessions(ctx, req.(*RevokeUserSessionsRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _UserAdminService_GrantRole_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GrantRoleRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(UserAdminServiceServer).GrantRole(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: UserAdminService_GrantRole_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(UserAdminServiceServer).GrantRole(ctx, req.(*GrantRoleRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _UserAdminService_RevokeRole_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(RevokeRoleRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(UserAdminServiceServer).RevokeRole(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: UserAdminService_RevokeRole_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(UserAdminServiceServer).RevokeRole(ctx, req.(*RevokeRoleRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+// UserAdminService_ServiceDesc is the grpc.ServiceDesc for UserAdminService service.
+// It's only intended for direct use with grpc.RegisterService,
+// and not to be introspected or modified (even as a copy)
+var UserAdminService_ServiceDesc = grpc.ServiceDesc{
+	ServiceName: "admin.UserAdminService",
+	HandlerType: (*UserAdminServiceServer)(nil),
+	Methods: []grpc.MethodDesc{
+		{
+			MethodName: "SearchUsers",
+			Handler:    _UserAdminService_SearchUsers_Handler,
+		},
+		{
+			MethodName: "SuspendUser",
+			Handler:    _UserAdminService_SuspendUser_Handler,
+		},
+		{
+			MethodName: "UnsuspendUser",
+			Handler:    _UserAdminService_UnsuspendUser_Handler,
+		},
+		{
+			MethodName: "RevokeUserSessions",
+			Handler:    _UserAdminService_RevokeUserSessions_Handler,
+		},
+		{
+			MethodName: "GrantRole",
+			Handler:    _UserAdminService_GrantRole_Handler,
+		},
+		{
+			MethodName: "RevokeRole",
+			Handler:    _UserAdminService_RevokeRole_Handler,
+		},
+	},
+	Streams:  []grpc.StreamDesc{},
+	Metadata: "admin.proto",
+}
+
+const (
+	RestaurantAdminService_SearchRestaurants_FullMethodName   = "/admin.RestaurantAdminService/SearchRestaurants"
+	RestaurantAdminService_SuspendRestaurant_FullMethodName   = "/admin.RestaurantAdminService/SuspendRestaurant"
+	RestaurantAdminService_UnsuspendRestaurant_FullMethodName = "/admin.RestaurantAdminService/UnsuspendRestaurant"
+	RestaurantAdminService_GetUserOrders_FullMethodName       = "/admin.RestaurantAdminService/GetUserOrders"
+)
+
+// RestaurantAdminServiceClient is the client API for RestaurantAdminService service.
+//
+// For semantics around ctx use and closing/ending streaming RPCs, please refer to https://pkg.go.dev/google.golang.org/grpc/?tab=doc#ClientConn.NewStream.
+//
+// RestaurantAdminService lets operations staff moderate restaurants and look into orders. It is served by the restaurant service and only answers calls made for admins.
+type RestaurantAdminServiceClient interface {
+	// Searches restaurants by name or email.
+	SearchRestaurants(ctx context.Context, in *SearchRestaurantsRequest, opts ...grpc.CallOption) (*SearchRestaurantsResponse, error)
+	// Suspends a restaurant: it is hidden from listings, takes no orders and cannot log in.
+	SuspendRestaurant(ctx context.Context, in *SuspendRestaurantRequest, opts ...grpc.CallOption) (*RestaurantAccount, error)
+	// Lifts the suspension of a restaurant, making it active again.
+	UnsuspendRestaurant(ctx context.Context, in *UnsuspendRestaurantRequest, opts ...grpc.CallOption) (*RestaurantAccount, error)
+	// Returns the orders a user placed.
+	GetUserOrders(ctx context.Context, in *GetUserOrdersRequest, opts ...grpc.CallOption) (*restaurantpb.GetOrdersResponse, error)
+}
+
+type restaurantAdminServiceClient struct {
+	cc grpc.ClientConnInterface
+}
+
+func NewRestaurantAdminServiceClient(cc grpc.ClientConnInterface) RestaurantAdminServiceClient {
+	return &restaurantAdminServiceClient{cc}
+}
+
+func (c *restaurantAdminServiceClient) SearchRestaurants(ctx context.Context, in *SearchRestaurantsRequest, opts ...grpc.CallOption) (*SearchRestaurantsResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(SearchRestaurantsResponse)
+	err := c.cc.Invoke(ctx, RestaurantAdminService_SearchRestaurants_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *restaurantAdminServiceClient) SuspendRestaurant(ctx context.Context, in *SuspendRestaurantRequest, opts ...grpc.CallOption) (*RestaurantAccount, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(RestaurantAccount)
+	err := c.cc.Invoke(ctx, RestaurantAdminService_SuspendRestaurant_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *restaurantAdminServiceClient) UnsuspendRestaurant(ctx context.Context, in *UnsuspendRestaurantRequest, opts ...grpc.CallOption) (*RestaurantAccount, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(RestaurantAccount)
+	err := c.cc.Invoke(ctx, RestaurantAdminService_UnsuspendRestaurant_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *restaurantAdminServiceClient) GetUserOrders(ctx context.Context, in *GetUserOrdersRequest, opts ...grpc.CallOption) (*restaurantpb.GetOrdersResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(restaurantpb.GetOrdersResponse)
+	err := c.cc.Invoke(ctx, RestaurantAdminService_GetUserOrders_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+// RestaurantAdminServiceServer is the server API for RestaurantAdminService service.
+// All implementations must embed UnimplementedRestaurantAdminServiceServer
+// for forward compatibility.
+//
+// RestaurantAdminService lets operations staff moderate restaurants and look into orders. It is served by the restaurant service and only answers calls made for admins.
+type RestaurantAdminServiceServer interface {
+	// Searches restaurants by name or email.
+	SearchRestaurants(context.Context, *SearchRestaurantsRequest) (*SearchRestaurantsResponse, error)
+	// Suspends a restaurant: it is hidden from listings, takes no orders and cannot log in.
+	SuspendRestaurant(context.Context, *SuspendRestaurantRequest) (*RestaurantAccount, error)
+	// Lifts the suspension of a restaurant, making it active again.
+	UnsuspendRestaurant(context.Context, *UnsuspendRestaurantRequest) (*RestaurantAccount, error)
+	// Returns the orders a user placed.
+	GetUserOrders(context.Context, *GetUserOrdersRequest) (*restaurantpb.GetOrdersResponse, error)
+	mustEmbedUnimplementedRestaurantAdminServiceServer()
+}
+
+// UnimplementedRestaurantAdminServiceServer must be embedded to have
+// forward compatible implementations.
+//
+// NOTE: this should be embedded by value instead of pointer to avoid a nil
+// pointer dereference when methods are called.
+type UnimplementedRestaurantAdminServiceServer struct{}
+
+func (UnimplementedRestaurantAdminServiceServer) SearchRestaurants(context.Context, *SearchRestaurantsRequest) (*SearchRestaurantsResponse, error) {
+	return nil, status.Error(codes.Unimplemented, "method SearchRestaurants not implemented")
+}
+func (UnimplementedRestaurantAdminServiceServer) SuspendRestaurant(context.Context, *SuspendRestaurantRequest) (*RestaurantAccount, error) {
+	return nil, status.Error(codes.Unimplemented, "method SuspendRestaurant not implemented")
+}
+func (UnimplementedRestaurantAdminServiceServer) UnsuspendRestaurant(context.Context, *UnsuspendRestaurantRequest) (*RestaurantAccount, error) {
+	return nil, status.Error(codes.Unimplemented, "method UnsuspendRestaurant not implemented")
+}
+func (UnimplementedRestaurantAdminServiceServer) GetUserOrders(context.Context, *GetUserOrdersRequest) (*restaurantpb.GetOrdersResponse, error) {
+	return nil, status.Error(codes.Unimplemented, "method GetUserOrders not implemented")
+}
+func (UnimplementedRestaurantAdminServiceServer) mustEmbedUnimplementedRestaurantAdminServiceServer() {
+}
+func (UnimplementedRestaurantAdminServiceServer) testEmbeddedByValue() {}
+
+// UnsafeRestaurantAdminServiceServer may be embedded to opt out of forward compatibility for this service.
+// Use of this interface is not recommended, as added methods to RestaurantAdminServiceServer will
+// result in compilation errors.
+type UnsafeRestaurantAdminServiceServer interface {
+	mustEmbedUnimplementedRestaurantAdminServiceServer()
+}
+
+func RegisterRestaurantAdminServiceServer(s grpc.ServiceRegistrar, srv RestaurantAdminServiceServer) {
+	// If the following call panics, it indicates UnimplementedRestaurantAdminServiceServer was
+	// embedded by pointer and is nil.  This will cause panics if an
+	// unimplemented method is ever invoked, so we test this at initialization
+	// time to prevent it from happening at runtime later due to I/O.
+	if t, ok := srv.(interface{ testEmbeddedByValue() }); ok {
+		t.testEmbeddedByValue()
+	}
+	s.RegisterService(&RestaurantAdminService_ServiceDesc, srv)
+}
+
+func _RestaurantAdminService_SearchRestaurants_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(SearchRestaurantsRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(RestaurantAdminServiceServer).SearchRestaurants(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: RestaurantAdminService_SearchRestaurants_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(RestaurantAdminServiceServer).SearchRestaurants(ctx, req.(*SearchRestaurantsRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _RestaurantAdminService_SuspendRestaurant_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(SuspendRestaurantRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(RestaurantAdminServiceServer).SuspendRestaurant(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: RestaurantAdminService_SuspendRestaurant_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(RestaurantAdminServiceServer).SuspendRestaurant(ctx, req.(*SuspendRestaurantRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _RestaurantAdminService_UnsuspendRestaurant_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(UnsuspendRestaurantRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(RestaurantAdminServiceServer).UnsuspendRestaurant(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: RestaurantAdminService_UnsuspendRestaurant_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(RestaurantAdminServiceServer).UnsuspendRestaurant(ctx, req.(*UnsuspendRestaurantRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _RestaurantAdminService_GetUserOrders_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetUserOrdersRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(RestaurantAdminServiceServer).GetUserOrders(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: RestaurantAdminService_GetUserOrders_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(RestaurantAdminServiceServer).GetUserOrders(ctx, req.(*GetUserOrdersRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+// RestaurantAdminService_ServiceDesc is the grpc.ServiceDesc for RestaurantAdminService service.
+// It's only intended for direct use with grpc.RegisterService,
+// and not to be introspected or modified (even as a copy)
+var RestaurantAdminService_ServiceDesc = grpc.ServiceDesc{
+	ServiceName: "admin.RestaurantAdminService",
+	HandlerType: (*RestaurantAdminServiceServer)(nil),
+	Methods: []grpc.MethodDesc{
+		{
+			MethodName: "SearchRestaurants",
+			Handler:    _RestaurantAdminService_SearchRestaurants_Handler,
+		},
+		{
+			MethodName: "SuspendRestaurant",
+			Handler:    _RestaurantAdminService_SuspendRestaurant_Handler,
+		},
+		{
+			MethodName: "UnsuspendRestaurant",
+			Handler:    _RestaurantAdminService_UnsuspendRestaurant_Handler,
+		},
+		{
+			MethodName: "GetUserOrders",
+			Handler:    _RestaurantAdminService_GetUserOrders_Handler,
+		},
+	},
+	Streams:  []grpc.StreamDesc{},
+	Metadata: "admin.proto",
+}
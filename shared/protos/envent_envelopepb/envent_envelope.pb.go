@@ -27,8 +27,10 @@ type EventEnvelope struct {
 	EventType      string                 `protobuf:"bytes,2,opt,name=event_type,json=eventType,proto3" json:"event_type,omitempty"`
 	OccurredAtUnix int64                  `protobuf:"varint,3,opt,name=occurred_at_unix,json=occurredAtUnix,proto3" json:"occurred_at_unix,omitempty"`
 	Payload        []byte                 `protobuf:"bytes,4,opt,name=payload,proto3" json:"payload,omitempty"`
-	unknownFields  protoimpl.UnknownFields
-	sizeCache      protoimpl.SizeCache
+	// correlation_id is the ID of the user request that caused the event.
+	CorrelationId string `protobuf:"bytes,5,opt,name=correlation_id,json=correlationId,proto3" json:"correlation_id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
 }
 
 func (x *EventEnvelope) Reset() {
@@ -89,17 +91,25 @@ func (x *EventEnvelope) GetPayload() []byte {
 	return nil
 }
 
+func (x *EventEnvelope) GetCorrelationId() string {
+	if x != nil {
+		return x.CorrelationId
+	}
+	return ""
+}
+
 var File_envent_envelope_proto protoreflect.FileDescriptor
 
 const file_envent_envelope_proto_rawDesc = "" +
 	"\n" +
-	"\x15envent_envelope.proto\x12\x06common\"\x8d\x01\n" +
+	"\x15envent_envelope.proto\x12\x06common\"\xb4\x01\n" +
 	"\rEventEnvelope\x12\x19\n" +
 	"\bevent_id\x18\x01 \x01(\tR\aeventId\x12\x1d\n" +
 	"\n" +
 	"event_type\x18\x02 \x01(\tR\teventType\x12(\n" +
 	"\x10occurred_at_unix\x18\x03 \x01(\x03R\x0eoccurredAtUnix\x12\x18\n" +
-	"\apayload\x18\x04 \x01(\fR\apayloadBWZUgithub.com/tamirat-dejene/ha-soranu/shared/protos/envent_envelopepb;envent_envelopepbb\x06proto3"
+	"\apayload\x18\x04 \x01(\fR\apayload\x12%\n" +
+	"\x0ecorrelation_id\x18\x05 \x01(\tR\rcorrelationIdBWZUgithub.com/tamirat-dejene/ha-soranu/shared/protos/envent_envelopepb;envent_envelopepbb\x06proto3"
 
 var (
 	file_envent_envelope_proto_rawDescOnce sync.Once
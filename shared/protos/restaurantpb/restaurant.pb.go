@@ -22,14 +22,113 @@ const (
 	_ = protoimpl.EnforceVersion(protoimpl.MaxVersion - 20)
 )
 
+type MenuFormat int32
+
+const (
+	MenuFormat_CSV  MenuFormat = 0
+	MenuFormat_JSON MenuFormat = 1
+)
+
+// Enum value maps for MenuFormat.
+var (
+	MenuFormat_name = map[int32]string{
+		0: "CSV",
+		1: "JSON",
+	}
+	MenuFormat_value = map[string]int32{
+		"CSV":  0,
+		"JSON": 1,
+	}
+)
+
+func (x MenuFormat) Enum() *MenuFormat {
+	p := new(MenuFormat)
+	*p = x
+	return p
+}
+
+func (x MenuFormat) String() string {
+	return protoimpl.X.EnumStringOf(x.Descriptor(), protoreflect.EnumNumber(x))
+}
+
+func (MenuFormat) Descriptor() protoreflect.EnumDescriptor {
+	return file_restaurant_proto_enumTypes[0].Descriptor()
+}
+
+func (MenuFormat) Type() protoreflect.EnumType {
+	return &file_restaurant_proto_enumTypes[0]
+}
+
+func (x MenuFormat) Number() protoreflect.EnumNumber {
+	return protoreflect.EnumNumber(x)
+}
+
+// Deprecated: Use MenuFormat.Descriptor instead.
+func (MenuFormat) EnumDescriptor() ([]byte, []int) {
+	return file_restaurant_proto_rawDescGZIP(), []int{0}
+}
+
+type OrderFeedEvent_Type int32
+
+const (
+	OrderFeedEvent_SNAPSHOT       OrderFeedEvent_Type = 0
+	OrderFeedEvent_CREATED        OrderFeedEvent_Type = 1
+	OrderFeedEvent_STATUS_CHANGED OrderFeedEvent_Type = 2
+)
+
+// Enum value maps for OrderFeedEvent_Type.
+var (
+	OrderFeedEvent_Type_name = map[int32]string{
+		0: "SNAPSHOT",
+		1: "CREATED",
+		2: "STATUS_CHANGED",
+	}
+	OrderFeedEvent_Type_value = map[string]int32{
+		"SNAPSHOT":       0,
+		"CREATED":        1,
+		"STATUS_CHANGED": 2,
+	}
+)
+
+func (x OrderFeedEvent_Type) Enum() *OrderFeedEvent_Type {
+	p := new(OrderFeedEvent_Type)
+	*p = x
+	return p
+}
+
+func (x OrderFeedEvent_Type) String() string {
+	return protoimpl.X.EnumStringOf(x.Descriptor(), protoreflect.EnumNumber(x))
+}
+
+func (OrderFeedEvent_Type) Descriptor() protoreflect.EnumDescriptor {
+	return file_restaurant_proto_enumTypes[1].Descriptor()
+}
+
+func (OrderFeedEvent_Type) Type() protoreflect.EnumType {
+	return &file_restaurant_proto_enumTypes[1]
+}
+
+func (x OrderFeedEvent_Type) Number() protoreflect.EnumNumber {
+	return protoreflect.EnumNumber(x)
+}
+
+// Deprecated: Use OrderFeedEvent_Type.Descriptor instead.
+func (OrderFeedEvent_Type) EnumDescriptor() ([]byte, []int) {
+	return file_restaurant_proto_rawDescGZIP(), []int{15, 0}
+}
+
 type Restaurant struct {
-	state         protoimpl.MessageState `protogen:"open.v1"`
-	RestaurantId  string                 `protobuf:"bytes,1,opt,name=restaurant_id,json=restaurantId,proto3" json:"restaurant_id,omitempty"`
-	Email         string                 `protobuf:"bytes,2,opt,name=email,proto3" json:"email,omitempty"`
-	Name          string                 `protobuf:"bytes,3,opt,name=name,proto3" json:"name,omitempty"`
-	Latitude      float32                `protobuf:"fixed32,4,opt,name=latitude,proto3" json:"latitude,omitempty"`
-	Longitude     float32                `protobuf:"fixed32,5,opt,name=longitude,proto3" json:"longitude,omitempty"`
-	Menus         []*MenuItem            `protobuf:"bytes,6,rep,name=menus,proto3" json:"menus,omitempty"`
+	state        protoimpl.MessageState `protogen:"open.v1"`
+	RestaurantId string                 `protobuf:"bytes,1,opt,name=restaurant_id,json=restaurantId,proto3" json:"restaurant_id,omitempty"`
+	Email        string                 `protobuf:"bytes,2,opt,name=email,proto3" json:"email,omitempty"`
+	Name         string                 `protobuf:"bytes,3,opt,name=name,proto3" json:"name,omitempty"`
+	Latitude     float32                `protobuf:"fixed32,4,opt,name=latitude,proto3" json:"latitude,omitempty"`
+	Longitude    float32                `protobuf:"fixed32,5,opt,name=longitude,proto3" json:"longitude,omitempty"`
+	Menus        []*MenuItem            `protobuf:"bytes,6,rep,name=menus,proto3" json:"menus,omitempty"`
+	// ACTIVE, INACTIVE or SUSPENDED. Inactive and suspended restaurants are hidden from listings and do not take orders; only admins lift a suspension.
+	Status string `protobuf:"bytes,7,opt,name=status,proto3" json:"status,omitempty"`
+	// User who registered the restaurant and manages it. Empty for restaurants registered before owners were recorded.
+	OwnerId       string `protobuf:"bytes,8,opt,name=owner_id,json=ownerId,proto3" json:"owner_id,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
@@ -106,6 +205,20 @@ func (x *Restaurant) GetMenus() []*MenuItem {
 	return nil
 }
 
+func (x *Restaurant) GetStatus() string {
+	if x != nil {
+		return x.Status
+	}
+	return ""
+}
+
+func (x *Restaurant) GetOwnerId() string {
+	if x != nil {
+		return x.OwnerId
+	}
+	return ""
+}
+
 type MenuItem struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
 	ItemId        string                 `protobuf:"bytes,1,opt,name=item_id,json=itemId,proto3" json:"item_id,omitempty"`
@@ -414,6 +527,170 @@ func (x *GetRestaurantRequest) GetRestaurantId() string {
 	return ""
 }
 
+type UpdateRestaurantRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	RestaurantId  string                 `protobuf:"bytes,1,opt,name=restaurant_id,json=restaurantId,proto3" json:"restaurant_id,omitempty"`
+	Email         string                 `protobuf:"bytes,2,opt,name=email,proto3" json:"email,omitempty"`
+	Name          string                 `protobuf:"bytes,3,opt,name=name,proto3" json:"name,omitempty"`
+	Latitude      float32                `protobuf:"fixed32,4,opt,name=latitude,proto3" json:"latitude,omitempty"`
+	Longitude     float32                `protobuf:"fixed32,5,opt,name=longitude,proto3" json:"longitude,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *UpdateRestaurantRequest) Reset() {
+	*x = UpdateRestaurantRequest{}
+	mi := &file_restaurant_proto_msgTypes[6]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *UpdateRestaurantRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*UpdateRestaurantRequest) ProtoMessage() {}
+
+func (x *UpdateRestaurantRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_restaurant_proto_msgTypes[6]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use UpdateRestaurantRequest.ProtoReflect.Descriptor instead.
+func (*UpdateRestaurantRequest) Descriptor() ([]byte, []int) {
+	return file_restaurant_proto_rawDescGZIP(), []int{6}
+}
+
+func (x *UpdateRestaurantRequest) GetRestaurantId() string {
+	if x != nil {
+		return x.RestaurantId
+	}
+	return ""
+}
+
+func (x *UpdateRestaurantRequest) GetEmail() string {
+	if x != nil {
+		return x.Email
+	}
+	return ""
+}
+
+func (x *UpdateRestaurantRequest) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
+}
+
+func (x *UpdateRestaurantRequest) GetLatitude() float32 {
+	if x != nil {
+		return x.Latitude
+	}
+	return 0
+}
+
+func (x *UpdateRestaurantRequest) GetLongitude() float32 {
+	if x != nil {
+		return x.Longitude
+	}
+	return 0
+}
+
+type DeactivateRestaurantRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	RestaurantId  string                 `protobuf:"bytes,1,opt,name=restaurant_id,json=restaurantId,proto3" json:"restaurant_id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *DeactivateRestaurantRequest) Reset() {
+	*x = DeactivateRestaurantRequest{}
+	mi := &file_restaurant_proto_msgTypes[7]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *DeactivateRestaurantRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*DeactivateRestaurantRequest) ProtoMessage() {}
+
+func (x *DeactivateRestaurantRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_restaurant_proto_msgTypes[7]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use DeactivateRestaurantRequest.ProtoReflect.Descriptor instead.
+func (*DeactivateRestaurantRequest) Descriptor() ([]byte, []int) {
+	return file_restaurant_proto_rawDescGZIP(), []int{7}
+}
+
+func (x *DeactivateRestaurantRequest) GetRestaurantId() string {
+	if x != nil {
+		return x.RestaurantId
+	}
+	return ""
+}
+
+type ReactivateRestaurantRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	RestaurantId  string                 `protobuf:"bytes,1,opt,name=restaurant_id,json=restaurantId,proto3" json:"restaurant_id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ReactivateRestaurantRequest) Reset() {
+	*x = ReactivateRestaurantRequest{}
+	mi := &file_restaurant_proto_msgTypes[8]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ReactivateRestaurantRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ReactivateRestaurantRequest) ProtoMessage() {}
+
+func (x *ReactivateRestaurantRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_restaurant_proto_msgTypes[8]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ReactivateRestaurantRequest.ProtoReflect.Descriptor instead.
+func (*ReactivateRestaurantRequest) Descriptor() ([]byte, []int) {
+	return file_restaurant_proto_rawDescGZIP(), []int{8}
+}
+
+func (x *ReactivateRestaurantRequest) GetRestaurantId() string {
+	if x != nil {
+		return x.RestaurantId
+	}
+	return ""
+}
+
 type ListRestaurantsRequest struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
 	Latitude      float32                `protobuf:"fixed32,1,opt,name=latitude,proto3" json:"latitude,omitempty"`
@@ -425,7 +702,7 @@ type ListRestaurantsRequest struct {
 
 func (x *ListRestaurantsRequest) Reset() {
 	*x = ListRestaurantsRequest{}
-	mi := &file_restaurant_proto_msgTypes[6]
+	mi := &file_restaurant_proto_msgTypes[9]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -437,7 +714,7 @@ func (x *ListRestaurantsRequest) String() string {
 func (*ListRestaurantsRequest) ProtoMessage() {}
 
 func (x *ListRestaurantsRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_restaurant_proto_msgTypes[6]
+	mi := &file_restaurant_proto_msgTypes[9]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -450,7 +727,7 @@ func (x *ListRestaurantsRequest) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use ListRestaurantsRequest.ProtoReflect.Descriptor instead.
 func (*ListRestaurantsRequest) Descriptor() ([]byte, []int) {
-	return file_restaurant_proto_rawDescGZIP(), []int{6}
+	return file_restaurant_proto_rawDescGZIP(), []int{9}
 }
 
 func (x *ListRestaurantsRequest) GetLatitude() float32 {
@@ -486,7 +763,7 @@ type AddMenuItemRequest struct {
 
 func (x *AddMenuItemRequest) Reset() {
 	*x = AddMenuItemRequest{}
-	mi := &file_restaurant_proto_msgTypes[7]
+	mi := &file_restaurant_proto_msgTypes[10]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -498,7 +775,7 @@ func (x *AddMenuItemRequest) String() string {
 func (*AddMenuItemRequest) ProtoMessage() {}
 
 func (x *AddMenuItemRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_restaurant_proto_msgTypes[7]
+	mi := &file_restaurant_proto_msgTypes[10]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -511,7 +788,7 @@ func (x *AddMenuItemRequest) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use AddMenuItemRequest.ProtoReflect.Descriptor instead.
 func (*AddMenuItemRequest) Descriptor() ([]byte, []int) {
-	return file_restaurant_proto_rawDescGZIP(), []int{7}
+	return file_restaurant_proto_rawDescGZIP(), []int{10}
 }
 
 func (x *AddMenuItemRequest) GetRestaurantId() string {
@@ -552,7 +829,7 @@ type RemoveMenuItemRequest struct {
 
 func (x *RemoveMenuItemRequest) Reset() {
 	*x = RemoveMenuItemRequest{}
-	mi := &file_restaurant_proto_msgTypes[8]
+	mi := &file_restaurant_proto_msgTypes[11]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -564,7 +841,7 @@ func (x *RemoveMenuItemRequest) String() string {
 func (*RemoveMenuItemRequest) ProtoMessage() {}
 
 func (x *RemoveMenuItemRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_restaurant_proto_msgTypes[8]
+	mi := &file_restaurant_proto_msgTypes[11]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -577,7 +854,7 @@ func (x *RemoveMenuItemRequest) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use RemoveMenuItemRequest.ProtoReflect.Descriptor instead.
 func (*RemoveMenuItemRequest) Descriptor() ([]byte, []int) {
-	return file_restaurant_proto_rawDescGZIP(), []int{8}
+	return file_restaurant_proto_rawDescGZIP(), []int{11}
 }
 
 func (x *RemoveMenuItemRequest) GetRestaurantId() string {
@@ -607,7 +884,7 @@ type UpdateMenuItemRequest struct {
 
 func (x *UpdateMenuItemRequest) Reset() {
 	*x = UpdateMenuItemRequest{}
-	mi := &file_restaurant_proto_msgTypes[9]
+	mi := &file_restaurant_proto_msgTypes[12]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -619,7 +896,7 @@ func (x *UpdateMenuItemRequest) String() string {
 func (*UpdateMenuItemRequest) ProtoMessage() {}
 
 func (x *UpdateMenuItemRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_restaurant_proto_msgTypes[9]
+	mi := &file_restaurant_proto_msgTypes[12]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -632,7 +909,7 @@ func (x *UpdateMenuItemRequest) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use UpdateMenuItemRequest.ProtoReflect.Descriptor instead.
 func (*UpdateMenuItemRequest) Descriptor() ([]byte, []int) {
-	return file_restaurant_proto_rawDescGZIP(), []int{9}
+	return file_restaurant_proto_rawDescGZIP(), []int{12}
 }
 
 func (x *UpdateMenuItemRequest) GetRestaurantId() string {
@@ -685,7 +962,7 @@ type Order struct {
 
 func (x *Order) Reset() {
 	*x = Order{}
-	mi := &file_restaurant_proto_msgTypes[10]
+	mi := &file_restaurant_proto_msgTypes[13]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -697,7 +974,7 @@ func (x *Order) String() string {
 func (*Order) ProtoMessage() {}
 
 func (x *Order) ProtoReflect() protoreflect.Message {
-	mi := &file_restaurant_proto_msgTypes[10]
+	mi := &file_restaurant_proto_msgTypes[13]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -710,7 +987,7 @@ func (x *Order) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use Order.ProtoReflect.Descriptor instead.
 func (*Order) Descriptor() ([]byte, []int) {
-	return file_restaurant_proto_rawDescGZIP(), []int{10}
+	return file_restaurant_proto_rawDescGZIP(), []int{13}
 }
 
 func (x *Order) GetOrderId() string {
@@ -755,30 +1032,30 @@ func (x *Order) GetStatus() orderpb.OrderStatus {
 	return orderpb.OrderStatus(0)
 }
 
-type PlaceOrderRequest struct {
-	state         protoimpl.MessageState `protogen:"open.v1"`
-	CustomerId    string                 `protobuf:"bytes,1,opt,name=customer_id,json=customerId,proto3" json:"customer_id,omitempty"`
-	RestaurantId  string                 `protobuf:"bytes,2,opt,name=restaurant_id,json=restaurantId,proto3" json:"restaurant_id,omitempty"`
-	Items         []*OrderItem           `protobuf:"bytes,3,rep,name=items,proto3" json:"items,omitempty"`
+type WatchOrdersRequest struct {
+	state        protoimpl.MessageState `protogen:"open.v1"`
+	RestaurantId string                 `protobuf:"bytes,1,opt,name=restaurant_id,json=restaurantId,proto3" json:"restaurant_id,omitempty"`
+	// Cursor of the last event received. Zero starts with a snapshot of the open orders.
+	Cursor        int64 `protobuf:"varint,2,opt,name=cursor,proto3" json:"cursor,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
-func (x *PlaceOrderRequest) Reset() {
-	*x = PlaceOrderRequest{}
-	mi := &file_restaurant_proto_msgTypes[11]
+func (x *WatchOrdersRequest) Reset() {
+	*x = WatchOrdersRequest{}
+	mi := &file_restaurant_proto_msgTypes[14]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *PlaceOrderRequest) String() string {
+func (x *WatchOrdersRequest) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*PlaceOrderRequest) ProtoMessage() {}
+func (*WatchOrdersRequest) ProtoMessage() {}
 
-func (x *PlaceOrderRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_restaurant_proto_msgTypes[11]
+func (x *WatchOrdersRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_restaurant_proto_msgTypes[14]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -789,36 +1066,168 @@ func (x *PlaceOrderRequest) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use PlaceOrderRequest.ProtoReflect.Descriptor instead.
-func (*PlaceOrderRequest) Descriptor() ([]byte, []int) {
-	return file_restaurant_proto_rawDescGZIP(), []int{11}
+// Deprecated: Use WatchOrdersRequest.ProtoReflect.Descriptor instead.
+func (*WatchOrdersRequest) Descriptor() ([]byte, []int) {
+	return file_restaurant_proto_rawDescGZIP(), []int{14}
 }
 
-func (x *PlaceOrderRequest) GetCustomerId() string {
+func (x *WatchOrdersRequest) GetRestaurantId() string {
 	if x != nil {
-		return x.CustomerId
+		return x.RestaurantId
 	}
 	return ""
 }
 
-func (x *PlaceOrderRequest) GetRestaurantId() string {
+func (x *WatchOrdersRequest) GetCursor() int64 {
 	if x != nil {
-		return x.RestaurantId
+		return x.Cursor
 	}
-	return ""
+	return 0
 }
 
-func (x *PlaceOrderRequest) GetItems() []*OrderItem {
-	if x != nil {
-		return x.Items
-	}
-	return nil
+type OrderFeedEvent struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	Type  OrderFeedEvent_Type    `protobuf:"varint,1,opt,name=type,proto3,enum=restaurant.OrderFeedEvent_Type" json:"type,omitempty"`
+	// Pass back as WatchOrdersRequest.cursor to resume after this event.
+	Cursor         int64  `protobuf:"varint,2,opt,name=cursor,proto3" json:"cursor,omitempty"`
+	Order          *Order `protobuf:"bytes,3,opt,name=order,proto3" json:"order,omitempty"`
+	OccurredAtUnix int64  `protobuf:"varint,4,opt,name=occurred_at_unix,json=occurredAtUnix,proto3" json:"occurred_at_unix,omitempty"`
+	unknownFields  protoimpl.UnknownFields
+	sizeCache      protoimpl.SizeCache
 }
 
-type PlaceOrderResponse struct {
-	state         protoimpl.MessageState `protogen:"open.v1"`
-	OrderId       string                 `protobuf:"bytes,1,opt,name=order_id,json=orderId,proto3" json:"order_id,omitempty"`
-	TotalAmount   float64                `protobuf:"fixed64,2,opt,name=total_amount,json=totalAmount,proto3" json:"total_amount,omitempty"`
+func (x *OrderFeedEvent) Reset() {
+	*x = OrderFeedEvent{}
+	mi := &file_restaurant_proto_msgTypes[15]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *OrderFeedEvent) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*OrderFeedEvent) ProtoMessage() {}
+
+func (x *OrderFeedEvent) ProtoReflect() protoreflect.Message {
+	mi := &file_restaurant_proto_msgTypes[15]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use OrderFeedEvent.ProtoReflect.Descriptor instead.
+func (*OrderFeedEvent) Descriptor() ([]byte, []int) {
+	return file_restaurant_proto_rawDescGZIP(), []int{15}
+}
+
+func (x *OrderFeedEvent) GetType() OrderFeedEvent_Type {
+	if x != nil {
+		return x.Type
+	}
+	return OrderFeedEvent_SNAPSHOT
+}
+
+func (x *OrderFeedEvent) GetCursor() int64 {
+	if x != nil {
+		return x.Cursor
+	}
+	return 0
+}
+
+func (x *OrderFeedEvent) GetOrder() *Order {
+	if x != nil {
+		return x.Order
+	}
+	return nil
+}
+
+func (x *OrderFeedEvent) GetOccurredAtUnix() int64 {
+	if x != nil {
+		return x.OccurredAtUnix
+	}
+	return 0
+}
+
+type PlaceOrderRequest struct {
+	state        protoimpl.MessageState `protogen:"open.v1"`
+	CustomerId   string                 `protobuf:"bytes,1,opt,name=customer_id,json=customerId,proto3" json:"customer_id,omitempty"`
+	RestaurantId string                 `protobuf:"bytes,2,opt,name=restaurant_id,json=restaurantId,proto3" json:"restaurant_id,omitempty"`
+	Items        []*OrderItem           `protobuf:"bytes,3,rep,name=items,proto3" json:"items,omitempty"`
+	// Client supplied key, unique per customer. Retrying with the same key and
+	// payload returns the original order instead of placing a new one.
+	IdempotencyKey string `protobuf:"bytes,4,opt,name=idempotency_key,json=idempotencyKey,proto3" json:"idempotency_key,omitempty"`
+	unknownFields  protoimpl.UnknownFields
+	sizeCache      protoimpl.SizeCache
+}
+
+func (x *PlaceOrderRequest) Reset() {
+	*x = PlaceOrderRequest{}
+	mi := &file_restaurant_proto_msgTypes[16]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *PlaceOrderRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*PlaceOrderRequest) ProtoMessage() {}
+
+func (x *PlaceOrderRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_restaurant_proto_msgTypes[16]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use PlaceOrderRequest.ProtoReflect.Descriptor instead.
+func (*PlaceOrderRequest) Descriptor() ([]byte, []int) {
+	return file_restaurant_proto_rawDescGZIP(), []int{16}
+}
+
+func (x *PlaceOrderRequest) GetCustomerId() string {
+	if x != nil {
+		return x.CustomerId
+	}
+	return ""
+}
+
+func (x *PlaceOrderRequest) GetRestaurantId() string {
+	if x != nil {
+		return x.RestaurantId
+	}
+	return ""
+}
+
+func (x *PlaceOrderRequest) GetItems() []*OrderItem {
+	if x != nil {
+		return x.Items
+	}
+	return nil
+}
+
+func (x *PlaceOrderRequest) GetIdempotencyKey() string {
+	if x != nil {
+		return x.IdempotencyKey
+	}
+	return ""
+}
+
+type PlaceOrderResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	OrderId       string                 `protobuf:"bytes,1,opt,name=order_id,json=orderId,proto3" json:"order_id,omitempty"`
+	TotalAmount   float64                `protobuf:"fixed64,2,opt,name=total_amount,json=totalAmount,proto3" json:"total_amount,omitempty"`
 	Status        string                 `protobuf:"bytes,3,opt,name=status,proto3" json:"status,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
@@ -826,7 +1235,7 @@ type PlaceOrderResponse struct {
 
 func (x *PlaceOrderResponse) Reset() {
 	*x = PlaceOrderResponse{}
-	mi := &file_restaurant_proto_msgTypes[12]
+	mi := &file_restaurant_proto_msgTypes[17]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -838,7 +1247,7 @@ func (x *PlaceOrderResponse) String() string {
 func (*PlaceOrderResponse) ProtoMessage() {}
 
 func (x *PlaceOrderResponse) ProtoReflect() protoreflect.Message {
-	mi := &file_restaurant_proto_msgTypes[12]
+	mi := &file_restaurant_proto_msgTypes[17]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -851,7 +1260,7 @@ func (x *PlaceOrderResponse) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use PlaceOrderResponse.ProtoReflect.Descriptor instead.
 func (*PlaceOrderResponse) Descriptor() ([]byte, []int) {
-	return file_restaurant_proto_rawDescGZIP(), []int{12}
+	return file_restaurant_proto_rawDescGZIP(), []int{17}
 }
 
 func (x *PlaceOrderResponse) GetOrderId() string {
@@ -885,7 +1294,7 @@ type OrderItem struct {
 
 func (x *OrderItem) Reset() {
 	*x = OrderItem{}
-	mi := &file_restaurant_proto_msgTypes[13]
+	mi := &file_restaurant_proto_msgTypes[18]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -897,7 +1306,7 @@ func (x *OrderItem) String() string {
 func (*OrderItem) ProtoMessage() {}
 
 func (x *OrderItem) ProtoReflect() protoreflect.Message {
-	mi := &file_restaurant_proto_msgTypes[13]
+	mi := &file_restaurant_proto_msgTypes[18]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -910,7 +1319,7 @@ func (x *OrderItem) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use OrderItem.ProtoReflect.Descriptor instead.
 func (*OrderItem) Descriptor() ([]byte, []int) {
-	return file_restaurant_proto_rawDescGZIP(), []int{13}
+	return file_restaurant_proto_rawDescGZIP(), []int{18}
 }
 
 func (x *OrderItem) GetItemId() string {
@@ -936,7 +1345,7 @@ type GetOrdersRequest struct {
 
 func (x *GetOrdersRequest) Reset() {
 	*x = GetOrdersRequest{}
-	mi := &file_restaurant_proto_msgTypes[14]
+	mi := &file_restaurant_proto_msgTypes[19]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -948,7 +1357,7 @@ func (x *GetOrdersRequest) String() string {
 func (*GetOrdersRequest) ProtoMessage() {}
 
 func (x *GetOrdersRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_restaurant_proto_msgTypes[14]
+	mi := &file_restaurant_proto_msgTypes[19]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -961,7 +1370,7 @@ func (x *GetOrdersRequest) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use GetOrdersRequest.ProtoReflect.Descriptor instead.
 func (*GetOrdersRequest) Descriptor() ([]byte, []int) {
-	return file_restaurant_proto_rawDescGZIP(), []int{14}
+	return file_restaurant_proto_rawDescGZIP(), []int{19}
 }
 
 func (x *GetOrdersRequest) GetRestaurantId() string {
@@ -980,7 +1389,7 @@ type GetOrdersResponse struct {
 
 func (x *GetOrdersResponse) Reset() {
 	*x = GetOrdersResponse{}
-	mi := &file_restaurant_proto_msgTypes[15]
+	mi := &file_restaurant_proto_msgTypes[20]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -992,7 +1401,7 @@ func (x *GetOrdersResponse) String() string {
 func (*GetOrdersResponse) ProtoMessage() {}
 
 func (x *GetOrdersResponse) ProtoReflect() protoreflect.Message {
-	mi := &file_restaurant_proto_msgTypes[15]
+	mi := &file_restaurant_proto_msgTypes[20]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -1005,7 +1414,7 @@ func (x *GetOrdersResponse) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use GetOrdersResponse.ProtoReflect.Descriptor instead.
 func (*GetOrdersResponse) Descriptor() ([]byte, []int) {
-	return file_restaurant_proto_rawDescGZIP(), []int{15}
+	return file_restaurant_proto_rawDescGZIP(), []int{20}
 }
 
 func (x *GetOrdersResponse) GetOrders() []*Order {
@@ -1026,7 +1435,7 @@ type UpdateOrderStatusRequest struct {
 
 func (x *UpdateOrderStatusRequest) Reset() {
 	*x = UpdateOrderStatusRequest{}
-	mi := &file_restaurant_proto_msgTypes[16]
+	mi := &file_restaurant_proto_msgTypes[21]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -1038,7 +1447,7 @@ func (x *UpdateOrderStatusRequest) String() string {
 func (*UpdateOrderStatusRequest) ProtoMessage() {}
 
 func (x *UpdateOrderStatusRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_restaurant_proto_msgTypes[16]
+	mi := &file_restaurant_proto_msgTypes[21]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -1051,7 +1460,7 @@ func (x *UpdateOrderStatusRequest) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use UpdateOrderStatusRequest.ProtoReflect.Descriptor instead.
 func (*UpdateOrderStatusRequest) Descriptor() ([]byte, []int) {
-	return file_restaurant_proto_rawDescGZIP(), []int{16}
+	return file_restaurant_proto_rawDescGZIP(), []int{21}
 }
 
 func (x *UpdateOrderStatusRequest) GetRestaurantId() string {
@@ -1085,7 +1494,7 @@ type ShipOrderRequest struct {
 
 func (x *ShipOrderRequest) Reset() {
 	*x = ShipOrderRequest{}
-	mi := &file_restaurant_proto_msgTypes[17]
+	mi := &file_restaurant_proto_msgTypes[22]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -1097,7 +1506,7 @@ func (x *ShipOrderRequest) String() string {
 func (*ShipOrderRequest) ProtoMessage() {}
 
 func (x *ShipOrderRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_restaurant_proto_msgTypes[17]
+	mi := &file_restaurant_proto_msgTypes[22]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -1110,7 +1519,7 @@ func (x *ShipOrderRequest) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use ShipOrderRequest.ProtoReflect.Descriptor instead.
 func (*ShipOrderRequest) Descriptor() ([]byte, []int) {
-	return file_restaurant_proto_rawDescGZIP(), []int{17}
+	return file_restaurant_proto_rawDescGZIP(), []int{22}
 }
 
 func (x *ShipOrderRequest) GetRestaurantId() string {
@@ -1130,14 +1539,20 @@ func (x *ShipOrderRequest) GetOrderId() string {
 type ShipOrderResponse struct {
 	state               protoimpl.MessageState `protogen:"open.v1"`
 	ConfirmationMessage string                 `protobuf:"bytes,1,opt,name=confirmation_message,json=confirmationMessage,proto3" json:"confirmation_message,omitempty"`
-	DriverId            string                 `protobuf:"bytes,2,opt,name=driver_id,json=driverId,proto3" json:"driver_id,omitempty"`
-	unknownFields       protoimpl.UnknownFields
-	sizeCache           protoimpl.SizeCache
+	// Driver the delivery is offered to or assigned to, empty while searching.
+	DriverId string `protobuf:"bytes,2,opt,name=driver_id,json=driverId,proto3" json:"driver_id,omitempty"`
+	OfferId  string `protobuf:"bytes,3,opt,name=offer_id,json=offerId,proto3" json:"offer_id,omitempty"`
+	// One of SEARCHING, OFFERED, ASSIGNED or NO_DRIVER. NO_DRIVER means no
+	// driver took the order; shipping it again starts a new search.
+	DispatchStatus     string `protobuf:"bytes,4,opt,name=dispatch_status,json=dispatchStatus,proto3" json:"dispatch_status,omitempty"`
+	OfferExpiresAtUnix int64  `protobuf:"varint,5,opt,name=offer_expires_at_unix,json=offerExpiresAtUnix,proto3" json:"offer_expires_at_unix,omitempty"`
+	unknownFields      protoimpl.UnknownFields
+	sizeCache          protoimpl.SizeCache
 }
 
 func (x *ShipOrderResponse) Reset() {
 	*x = ShipOrderResponse{}
-	mi := &file_restaurant_proto_msgTypes[18]
+	mi := &file_restaurant_proto_msgTypes[23]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -1149,7 +1564,7 @@ func (x *ShipOrderResponse) String() string {
 func (*ShipOrderResponse) ProtoMessage() {}
 
 func (x *ShipOrderResponse) ProtoReflect() protoreflect.Message {
-	mi := &file_restaurant_proto_msgTypes[18]
+	mi := &file_restaurant_proto_msgTypes[23]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -1162,7 +1577,7 @@ func (x *ShipOrderResponse) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use ShipOrderResponse.ProtoReflect.Descriptor instead.
 func (*ShipOrderResponse) Descriptor() ([]byte, []int) {
-	return file_restaurant_proto_rawDescGZIP(), []int{18}
+	return file_restaurant_proto_rawDescGZIP(), []int{23}
 }
 
 func (x *ShipOrderResponse) GetConfirmationMessage() string {
@@ -1179,6 +1594,27 @@ func (x *ShipOrderResponse) GetDriverId() string {
 	return ""
 }
 
+func (x *ShipOrderResponse) GetOfferId() string {
+	if x != nil {
+		return x.OfferId
+	}
+	return ""
+}
+
+func (x *ShipOrderResponse) GetDispatchStatus() string {
+	if x != nil {
+		return x.DispatchStatus
+	}
+	return ""
+}
+
+func (x *ShipOrderResponse) GetOfferExpiresAtUnix() int64 {
+	if x != nil {
+		return x.OfferExpiresAtUnix
+	}
+	return 0
+}
+
 type GetOrderRequest struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
 	OrderId       string                 `protobuf:"bytes,1,opt,name=order_id,json=orderId,proto3" json:"order_id,omitempty"`
@@ -1188,7 +1624,7 @@ type GetOrderRequest struct {
 
 func (x *GetOrderRequest) Reset() {
 	*x = GetOrderRequest{}
-	mi := &file_restaurant_proto_msgTypes[19]
+	mi := &file_restaurant_proto_msgTypes[24]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -1200,7 +1636,7 @@ func (x *GetOrderRequest) String() string {
 func (*GetOrderRequest) ProtoMessage() {}
 
 func (x *GetOrderRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_restaurant_proto_msgTypes[19]
+	mi := &file_restaurant_proto_msgTypes[24]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -1213,7 +1649,7 @@ func (x *GetOrderRequest) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use GetOrderRequest.ProtoReflect.Descriptor instead.
 func (*GetOrderRequest) Descriptor() ([]byte, []int) {
-	return file_restaurant_proto_rawDescGZIP(), []int{19}
+	return file_restaurant_proto_rawDescGZIP(), []int{24}
 }
 
 func (x *GetOrderRequest) GetOrderId() string {
@@ -1223,112 +1659,1289 @@ func (x *GetOrderRequest) GetOrderId() string {
 	return ""
 }
 
-var File_restaurant_proto protoreflect.FileDescriptor
+type ImportMenuRequest struct {
+	state        protoimpl.MessageState `protogen:"open.v1"`
+	RestaurantId string                 `protobuf:"bytes,1,opt,name=restaurant_id,json=restaurantId,proto3" json:"restaurant_id,omitempty"`
+	Format       MenuFormat             `protobuf:"varint,2,opt,name=format,proto3,enum=restaurant.MenuFormat" json:"format,omitempty"`
+	// CSV with a name,description,price header, or a JSON array of menu items.
+	Data []byte `protobuf:"bytes,3,opt,name=data,proto3" json:"data,omitempty"`
+	// Validate and report what would change without applying anything.
+	DryRun        bool `protobuf:"varint,4,opt,name=dry_run,json=dryRun,proto3" json:"dry_run,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
 
-const file_restaurant_proto_rawDesc = "" +
-	"\n" +
-	"\x10restaurant.proto\x12\n" +
-	"restaurant\x1a\vorder.proto\"\xc1\x01\n" +
-	"\n" +
-	"Restaurant\x12#\n" +
-	"\rrestaurant_id\x18\x01 \x01(\tR\frestaurantId\x12\x14\n" +
-	"\x05email\x18\x02 \x01(\tR\x05email\x12\x12\n" +
-	"\x04name\x18\x03 \x01(\tR\x04name\x12\x1a\n" +
-	"\blatitude\x18\x04 \x01(\x02R\blatitude\x12\x1c\n" +
-	"\tlongitude\x18\x05 \x01(\x02R\tlongitude\x12*\n" +
-	"\x05menus\x18\x06 \x03(\v2\x14.restaurant.MenuItemR\x05menus\"o\n" +
-	"\bMenuItem\x12\x17\n" +
-	"\aitem_id\x18\x01 \x01(\tR\x06itemId\x12\x12\n" +
-	"\x04name\x18\x02 \x01(\tR\x04name\x12 \n" +
-	"\vdescription\x18\x03 \x01(\tR\vdescription\x12\x14\n" +
-	"\x05price\x18\x04 \x01(\x02R\x05price\"M\n" +
-	"\x16RestaurantLoginRequest\x12\x14\n" +
-	"\x05email\x18\x01 \x01(\tR\x05email\x12\x1d\n" +
-	"\n" +
-	"secret_key\x18\x02 \x01(\tR\tsecretKey\"\xd2\x01\n" +
-	"\x19RegisterRestaurantRequest\x12\x14\n" +
-	"\x05email\x18\x01 \x01(\tR\x05email\x12\x1d\n" +
-	"\n" +
-	"secret_key\x18\x02 \x01(\tR\tsecretKey\x12\x12\n" +
-	"\x04name\x18\x03 \x01(\tR\x04name\x12\x1a\n" +
-	"\blatitude\x18\x04 \x01(\x02R\blatitude\x12\x1c\n" +
-	"\tlongitude\x18\x05 \x01(\x02R\tlongitude\x122\n" +
-	"\x05menus\x18\x06 \x03(\v2\x1c.restaurant.RegisterMenuItemR\x05menus\"^\n" +
-	"\x10RegisterMenuItem\x12\x12\n" +
-	"\x04name\x18\x01 \x01(\tR\x04name\x12 \n" +
-	"\vdescription\x18\x02 \x01(\tR\vdescription\x12\x14\n" +
-	"\x05price\x18\x03 \x01(\x02R\x05price\";\n" +
-	"\x14GetRestaurantRequest\x12#\n" +
-	"\rrestaurant_id\x18\x01 \x01(\tR\frestaurantId\"o\n" +
-	"\x16ListRestaurantsRequest\x12\x1a\n" +
-	"\blatitude\x18\x01 \x01(\x02R\blatitude\x12\x1c\n" +
-	"\tlongitude\x18\x02 \x01(\x02R\tlongitude\x12\x1b\n" +
-	"\tradius_km\x18\x03 \x01(\x02R\bradiusKm\"\x85\x01\n" +
-	"\x12AddMenuItemRequest\x12#\n" +
-	"\rrestaurant_id\x18\x01 \x01(\tR\frestaurantId\x12\x12\n" +
-	"\x04name\x18\x02 \x01(\tR\x04name\x12 \n" +
-	"\vdescription\x18\x03 \x01(\tR\vdescription\x12\x14\n" +
-	"\x05price\x18\x04 \x01(\x02R\x05price\"U\n" +
-	"\x15RemoveMenuItemRequest\x12#\n" +
-	"\rrestaurant_id\x18\x01 \x01(\tR\frestaurantId\x12\x17\n" +
-	"\aitem_id\x18\x02 \x01(\tR\x06itemId\"\xa1\x01\n" +
-	"\x15UpdateMenuItemRequest\x12#\n" +
-	"\rrestaurant_id\x18\x01 \x01(\tR\frestaurantId\x12\x17\n" +
-	"\aitem_id\x18\x02 \x01(\tR\x06itemId\x12\x12\n" +
-	"\x04name\x18\x03 \x01(\tR\x04name\x12 \n" +
-	"\vdescription\x18\x04 \x01(\tR\vdescription\x12\x14\n" +
-	"\x05price\x18\x05 \x01(\x02R\x05price\"\xe4\x01\n" +
-	"\x05Order\x12\x19\n" +
-	"\border_id\x18\x01 \x01(\tR\aorderId\x12\x1f\n" +
-	"\vcustomer_id\x18\x02 \x01(\tR\n" +
-	"customerId\x12#\n" +
-	"\rrestaurant_id\x18\x03 \x01(\tR\frestaurantId\x12+\n" +
-	"\x05items\x18\x04 \x03(\v2\x15.restaurant.OrderItemR\x05items\x12!\n" +
-	"\ftotal_amount\x18\x05 \x01(\x01R\vtotalAmount\x12*\n" +
-	"\x06status\x18\x06 \x01(\x0e2\x12.order.OrderStatusR\x06status\"\x86\x01\n" +
-	"\x11PlaceOrderRequest\x12\x1f\n" +
-	"\vcustomer_id\x18\x01 \x01(\tR\n" +
-	"customerId\x12#\n" +
-	"\rrestaurant_id\x18\x02 \x01(\tR\frestaurantId\x12+\n" +
-	"\x05items\x18\x03 \x03(\v2\x15.restaurant.OrderItemR\x05items\"j\n" +
-	"\x12PlaceOrderResponse\x12\x19\n" +
-	"\border_id\x18\x01 \x01(\tR\aorderId\x12!\n" +
-	"\ftotal_amount\x18\x02 \x01(\x01R\vtotalAmount\x12\x16\n" +
-	"\x06status\x18\x03 \x01(\tR\x06status\"@\n" +
-	"\tOrderItem\x12\x17\n" +
-	"\aitem_id\x18\x01 \x01(\tR\x06itemId\x12\x1a\n" +
-	"\bquantity\x18\x02 \x01(\x05R\bquantity\"7\n" +
-	"\x10GetOrdersRequest\x12#\n" +
-	"\rrestaurant_id\x18\x01 \x01(\tR\frestaurantId\">\n" +
-	"\x11GetOrdersResponse\x12)\n" +
-	"\x06orders\x18\x01 \x03(\v2\x11.restaurant.OrderR\x06orders\"\x8d\x01\n" +
-	"\x18UpdateOrderStatusRequest\x12#\n" +
-	"\rrestaurant_id\x18\x01 \x01(\tR\frestaurantId\x12\x19\n" +
-	"\border_id\x18\x02 \x01(\tR\aorderId\x121\n" +
-	"\n" +
-	"new_status\x18\x03 \x01(\x0e2\x12.order.OrderStatusR\tnewStatus\"R\n" +
-	"\x10ShipOrderRequest\x12#\n" +
-	"\rrestaurant_id\x18\x01 \x01(\tR\frestaurantId\x12\x19\n" +
-	"\border_id\x18\x02 \x01(\tR\aorderId\"c\n" +
-	"\x11ShipOrderResponse\x121\n" +
-	"\x14confirmation_message\x18\x01 \x01(\tR\x13confirmationMessage\x12\x1b\n" +
-	"\tdriver_id\x18\x02 \x01(\tR\bdriverId\",\n" +
-	"\x0fGetOrderRequest\x12\x19\n" +
-	"\border_id\x18\x01 \x01(\tR\aorderId2\x8f\a\n" +
-	"\x11RestaurantService\x12C\n" +
-	"\x05Login\x12\".restaurant.RestaurantLoginRequest\x1a\x16.restaurant.Restaurant\x12S\n" +
-	"\x12RegisterRestaurant\x12%.restaurant.RegisterRestaurantRequest\x1a\x16.restaurant.Restaurant\x12I\n" +
-	"\rGetRestaurant\x12 .restaurant.GetRestaurantRequest\x1a\x16.restaurant.Restaurant\x12O\n" +
-	"\x0fListRestaurants\x12\".restaurant.ListRestaurantsRequest\x1a\x16.restaurant.Restaurant0\x01\x12C\n" +
-	"\vAddMenuItem\x12\x1e.restaurant.AddMenuItemRequest\x1a\x14.restaurant.MenuItem\x12I\n" +
-	"\x0eRemoveMenuItem\x12!.restaurant.RemoveMenuItemRequest\x1a\x14.restaurant.MenuItem\x12I\n" +
-	"\x0eUpdateMenuItem\x12!.restaurant.UpdateMenuItemRequest\x1a\x14.restaurant.MenuItem\x12K\n" +
+func (x *ImportMenuRequest) Reset() {
+	*x = ImportMenuRequest{}
+	mi := &file_restaurant_proto_msgTypes[25]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ImportMenuRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ImportMenuRequest) ProtoMessage() {}
+
+func (x *ImportMenuRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_restaurant_proto_msgTypes[25]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ImportMenuRequest.ProtoReflect.Descriptor instead.
+func (*ImportMenuRequest) Descriptor() ([]byte, []int) {
+	return file_restaurant_proto_rawDescGZIP(), []int{25}
+}
+
+func (x *ImportMenuRequest) GetRestaurantId() string {
+	if x != nil {
+		return x.RestaurantId
+	}
+	return ""
+}
+
+func (x *ImportMenuRequest) GetFormat() MenuFormat {
+	if x != nil {
+		return x.Format
+	}
+	return MenuFormat_CSV
+}
+
+func (x *ImportMenuRequest) GetData() []byte {
+	if x != nil {
+		return x.Data
+	}
+	return nil
+}
+
+func (x *ImportMenuRequest) GetDryRun() bool {
+	if x != nil {
+		return x.DryRun
+	}
+	return false
+}
+
+// MenuImportError describes a rejected line of an imported menu.
+type MenuImportError struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Line          int32                  `protobuf:"varint,1,opt,name=line,proto3" json:"line,omitempty"`
+	Field         string                 `protobuf:"bytes,2,opt,name=field,proto3" json:"field,omitempty"`
+	Message       string                 `protobuf:"bytes,3,opt,name=message,proto3" json:"message,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *MenuImportError) Reset() {
+	*x = MenuImportError{}
+	mi := &file_restaurant_proto_msgTypes[26]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *MenuImportError) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*MenuImportError) ProtoMessage() {}
+
+func (x *MenuImportError) ProtoReflect() protoreflect.Message {
+	mi := &file_restaurant_proto_msgTypes[26]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use MenuImportError.ProtoReflect.Descriptor instead.
+func (*MenuImportError) Descriptor() ([]byte, []int) {
+	return file_restaurant_proto_rawDescGZIP(), []int{26}
+}
+
+func (x *MenuImportError) GetLine() int32 {
+	if x != nil {
+		return x.Line
+	}
+	return 0
+}
+
+func (x *MenuImportError) GetField() string {
+	if x != nil {
+		return x.Field
+	}
+	return ""
+}
+
+func (x *MenuImportError) GetMessage() string {
+	if x != nil {
+		return x.Message
+	}
+	return ""
+}
+
+// ImportMenuResponse reports the outcome of an import. Nothing is applied
+// when errors is not empty.
+type ImportMenuResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Created       int32                  `protobuf:"varint,1,opt,name=created,proto3" json:"created,omitempty"`
+	Updated       int32                  `protobuf:"varint,2,opt,name=updated,proto3" json:"updated,omitempty"`
+	DryRun        bool                   `protobuf:"varint,3,opt,name=dry_run,json=dryRun,proto3" json:"dry_run,omitempty"`
+	Errors        []*MenuImportError     `protobuf:"bytes,4,rep,name=errors,proto3" json:"errors,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ImportMenuResponse) Reset() {
+	*x = ImportMenuResponse{}
+	mi := &file_restaurant_proto_msgTypes[27]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ImportMenuResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ImportMenuResponse) ProtoMessage() {}
+
+func (x *ImportMenuResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_restaurant_proto_msgTypes[27]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ImportMenuResponse.ProtoReflect.Descriptor instead.
+func (*ImportMenuResponse) Descriptor() ([]byte, []int) {
+	return file_restaurant_proto_rawDescGZIP(), []int{27}
+}
+
+func (x *ImportMenuResponse) GetCreated() int32 {
+	if x != nil {
+		return x.Created
+	}
+	return 0
+}
+
+func (x *ImportMenuResponse) GetUpdated() int32 {
+	if x != nil {
+		return x.Updated
+	}
+	return 0
+}
+
+func (x *ImportMenuResponse) GetDryRun() bool {
+	if x != nil {
+		return x.DryRun
+	}
+	return false
+}
+
+func (x *ImportMenuResponse) GetErrors() []*MenuImportError {
+	if x != nil {
+		return x.Errors
+	}
+	return nil
+}
+
+type ExportMenuRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	RestaurantId  string                 `protobuf:"bytes,1,opt,name=restaurant_id,json=restaurantId,proto3" json:"restaurant_id,omitempty"`
+	Format        MenuFormat             `protobuf:"varint,2,opt,name=format,proto3,enum=restaurant.MenuFormat" json:"format,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ExportMenuRequest) Reset() {
+	*x = ExportMenuRequest{}
+	mi := &file_restaurant_proto_msgTypes[28]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ExportMenuRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ExportMenuRequest) ProtoMessage() {}
+
+func (x *ExportMenuRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_restaurant_proto_msgTypes[28]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ExportMenuRequest.ProtoReflect.Descriptor instead.
+func (*ExportMenuRequest) Descriptor() ([]byte, []int) {
+	return file_restaurant_proto_rawDescGZIP(), []int{28}
+}
+
+func (x *ExportMenuRequest) GetRestaurantId() string {
+	if x != nil {
+		return x.RestaurantId
+	}
+	return ""
+}
+
+func (x *ExportMenuRequest) GetFormat() MenuFormat {
+	if x != nil {
+		return x.Format
+	}
+	return MenuFormat_CSV
+}
+
+type ExportMenuResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Format        MenuFormat             `protobuf:"varint,1,opt,name=format,proto3,enum=restaurant.MenuFormat" json:"format,omitempty"`
+	ContentType   string                 `protobuf:"bytes,2,opt,name=content_type,json=contentType,proto3" json:"content_type,omitempty"`
+	Data          []byte                 `protobuf:"bytes,3,opt,name=data,proto3" json:"data,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ExportMenuResponse) Reset() {
+	*x = ExportMenuResponse{}
+	mi := &file_restaurant_proto_msgTypes[29]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ExportMenuResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ExportMenuResponse) ProtoMessage() {}
+
+func (x *ExportMenuResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_restaurant_proto_msgTypes[29]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ExportMenuResponse.ProtoReflect.Descriptor instead.
+func (*ExportMenuResponse) Descriptor() ([]byte, []int) {
+	return file_restaurant_proto_rawDescGZIP(), []int{29}
+}
+
+func (x *ExportMenuResponse) GetFormat() MenuFormat {
+	if x != nil {
+		return x.Format
+	}
+	return MenuFormat_CSV
+}
+
+func (x *ExportMenuResponse) GetContentType() string {
+	if x != nil {
+		return x.ContentType
+	}
+	return ""
+}
+
+func (x *ExportMenuResponse) GetData() []byte {
+	if x != nil {
+		return x.Data
+	}
+	return nil
+}
+
+// DeliveryOffer is a delivery job offered to a single driver until it expires.
+type DeliveryOffer struct {
+	state        protoimpl.MessageState `protogen:"open.v1"`
+	OfferId      string                 `protobuf:"bytes,1,opt,name=offer_id,json=offerId,proto3" json:"offer_id,omitempty"`
+	OrderId      string                 `protobuf:"bytes,2,opt,name=order_id,json=orderId,proto3" json:"order_id,omitempty"`
+	RestaurantId string                 `protobuf:"bytes,3,opt,name=restaurant_id,json=restaurantId,proto3" json:"restaurant_id,omitempty"`
+	DriverId     string                 `protobuf:"bytes,4,opt,name=driver_id,json=driverId,proto3" json:"driver_id,omitempty"`
+	// One of PENDING, ACCEPTED, DECLINED or EXPIRED.
+	Status        string `protobuf:"bytes,5,opt,name=status,proto3" json:"status,omitempty"`
+	ExpiresAtUnix int64  `protobuf:"varint,6,opt,name=expires_at_unix,json=expiresAtUnix,proto3" json:"expires_at_unix,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *DeliveryOffer) Reset() {
+	*x = DeliveryOffer{}
+	mi := &file_restaurant_proto_msgTypes[30]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *DeliveryOffer) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*DeliveryOffer) ProtoMessage() {}
+
+func (x *DeliveryOffer) ProtoReflect() protoreflect.Message {
+	mi := &file_restaurant_proto_msgTypes[30]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use DeliveryOffer.ProtoReflect.Descriptor instead.
+func (*DeliveryOffer) Descriptor() ([]byte, []int) {
+	return file_restaurant_proto_rawDescGZIP(), []int{30}
+}
+
+func (x *DeliveryOffer) GetOfferId() string {
+	if x != nil {
+		return x.OfferId
+	}
+	return ""
+}
+
+func (x *DeliveryOffer) GetOrderId() string {
+	if x != nil {
+		return x.OrderId
+	}
+	return ""
+}
+
+func (x *DeliveryOffer) GetRestaurantId() string {
+	if x != nil {
+		return x.RestaurantId
+	}
+	return ""
+}
+
+func (x *DeliveryOffer) GetDriverId() string {
+	if x != nil {
+		return x.DriverId
+	}
+	return ""
+}
+
+func (x *DeliveryOffer) GetStatus() string {
+	if x != nil {
+		return x.Status
+	}
+	return ""
+}
+
+func (x *DeliveryOffer) GetExpiresAtUnix() int64 {
+	if x != nil {
+		return x.ExpiresAtUnix
+	}
+	return 0
+}
+
+type GetDeliveryOffersRequest struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// Defaults to the driver profile of the calling user; only admins may name another driver.
+	DriverId      string `protobuf:"bytes,1,opt,name=driver_id,json=driverId,proto3" json:"driver_id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetDeliveryOffersRequest) Reset() {
+	*x = GetDeliveryOffersRequest{}
+	mi := &file_restaurant_proto_msgTypes[31]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetDeliveryOffersRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetDeliveryOffersRequest) ProtoMessage() {}
+
+func (x *GetDeliveryOffersRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_restaurant_proto_msgTypes[31]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetDeliveryOffersRequest.ProtoReflect.Descriptor instead.
+func (*GetDeliveryOffersRequest) Descriptor() ([]byte, []int) {
+	return file_restaurant_proto_rawDescGZIP(), []int{31}
+}
+
+func (x *GetDeliveryOffersRequest) GetDriverId() string {
+	if x != nil {
+		return x.DriverId
+	}
+	return ""
+}
+
+type GetDeliveryOffersResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Offers        []*DeliveryOffer       `protobuf:"bytes,1,rep,name=offers,proto3" json:"offers,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetDeliveryOffersResponse) Reset() {
+	*x = GetDeliveryOffersResponse{}
+	mi := &file_restaurant_proto_msgTypes[32]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetDeliveryOffersResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetDeliveryOffersResponse) ProtoMessage() {}
+
+func (x *GetDeliveryOffersResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_restaurant_proto_msgTypes[32]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetDeliveryOffersResponse.ProtoReflect.Descriptor instead.
+func (*GetDeliveryOffersResponse) Descriptor() ([]byte, []int) {
+	return file_restaurant_proto_rawDescGZIP(), []int{32}
+}
+
+func (x *GetDeliveryOffersResponse) GetOffers() []*DeliveryOffer {
+	if x != nil {
+		return x.Offers
+	}
+	return nil
+}
+
+type RespondToDeliveryOfferRequest struct {
+	state   protoimpl.MessageState `protogen:"open.v1"`
+	OfferId string                 `protobuf:"bytes,1,opt,name=offer_id,json=offerId,proto3" json:"offer_id,omitempty"`
+	// Defaults to the driver profile of the calling user; only admins may name another driver.
+	DriverId      string `protobuf:"bytes,2,opt,name=driver_id,json=driverId,proto3" json:"driver_id,omitempty"`
+	Accept        bool   `protobuf:"varint,3,opt,name=accept,proto3" json:"accept,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *RespondToDeliveryOfferRequest) Reset() {
+	*x = RespondToDeliveryOfferRequest{}
+	mi := &file_restaurant_proto_msgTypes[33]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *RespondToDeliveryOfferRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*RespondToDeliveryOfferRequest) ProtoMessage() {}
+
+func (x *RespondToDeliveryOfferRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_restaurant_proto_msgTypes[33]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use RespondToDeliveryOfferRequest.ProtoReflect.Descriptor instead.
+func (*RespondToDeliveryOfferRequest) Descriptor() ([]byte, []int) {
+	return file_restaurant_proto_rawDescGZIP(), []int{33}
+}
+
+func (x *RespondToDeliveryOfferRequest) GetOfferId() string {
+	if x != nil {
+		return x.OfferId
+	}
+	return ""
+}
+
+func (x *RespondToDeliveryOfferRequest) GetDriverId() string {
+	if x != nil {
+		return x.DriverId
+	}
+	return ""
+}
+
+func (x *RespondToDeliveryOfferRequest) GetAccept() bool {
+	if x != nil {
+		return x.Accept
+	}
+	return false
+}
+
+// Cart holds the items a customer is about to order from a single restaurant.
+// Prices and availability are checked against the menu on every read.
+type Cart struct {
+	state        protoimpl.MessageState `protogen:"open.v1"`
+	CustomerId   string                 `protobuf:"bytes,1,opt,name=customer_id,json=customerId,proto3" json:"customer_id,omitempty"`
+	RestaurantId string                 `protobuf:"bytes,2,opt,name=restaurant_id,json=restaurantId,proto3" json:"restaurant_id,omitempty"`
+	Items        []*CartItem            `protobuf:"bytes,3,rep,name=items,proto3" json:"items,omitempty"`
+	// Sum of the available items at their current prices.
+	TotalAmount float64 `protobuf:"fixed64,4,opt,name=total_amount,json=totalAmount,proto3" json:"total_amount,omitempty"`
+	// False when an item is no longer available or the restaurant is not taking orders.
+	Valid         bool  `protobuf:"varint,5,opt,name=valid,proto3" json:"valid,omitempty"`
+	ExpiresAtUnix int64 `protobuf:"varint,6,opt,name=expires_at_unix,json=expiresAtUnix,proto3" json:"expires_at_unix,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *Cart) Reset() {
+	*x = Cart{}
+	mi := &file_restaurant_proto_msgTypes[34]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *Cart) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Cart) ProtoMessage() {}
+
+func (x *Cart) ProtoReflect() protoreflect.Message {
+	mi := &file_restaurant_proto_msgTypes[34]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Cart.ProtoReflect.Descriptor instead.
+func (*Cart) Descriptor() ([]byte, []int) {
+	return file_restaurant_proto_rawDescGZIP(), []int{34}
+}
+
+func (x *Cart) GetCustomerId() string {
+	if x != nil {
+		return x.CustomerId
+	}
+	return ""
+}
+
+func (x *Cart) GetRestaurantId() string {
+	if x != nil {
+		return x.RestaurantId
+	}
+	return ""
+}
+
+func (x *Cart) GetItems() []*CartItem {
+	if x != nil {
+		return x.Items
+	}
+	return nil
+}
+
+func (x *Cart) GetTotalAmount() float64 {
+	if x != nil {
+		return x.TotalAmount
+	}
+	return 0
+}
+
+func (x *Cart) GetValid() bool {
+	if x != nil {
+		return x.Valid
+	}
+	return false
+}
+
+func (x *Cart) GetExpiresAtUnix() int64 {
+	if x != nil {
+		return x.ExpiresAtUnix
+	}
+	return 0
+}
+
+type CartItem struct {
+	state     protoimpl.MessageState `protogen:"open.v1"`
+	ItemId    string                 `protobuf:"bytes,1,opt,name=item_id,json=itemId,proto3" json:"item_id,omitempty"`
+	Name      string                 `protobuf:"bytes,2,opt,name=name,proto3" json:"name,omitempty"`
+	Quantity  int32                  `protobuf:"varint,3,opt,name=quantity,proto3" json:"quantity,omitempty"`
+	UnitPrice float32                `protobuf:"fixed32,4,opt,name=unit_price,json=unitPrice,proto3" json:"unit_price,omitempty"`
+	Available bool                   `protobuf:"varint,5,opt,name=available,proto3" json:"available,omitempty"`
+	// Set when the price changed since the cart was last read.
+	PriceChanged  bool    `protobuf:"varint,6,opt,name=price_changed,json=priceChanged,proto3" json:"price_changed,omitempty"`
+	PreviousPrice float32 `protobuf:"fixed32,7,opt,name=previous_price,json=previousPrice,proto3" json:"previous_price,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *CartItem) Reset() {
+	*x = CartItem{}
+	mi := &file_restaurant_proto_msgTypes[35]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *CartItem) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CartItem) ProtoMessage() {}
+
+func (x *CartItem) ProtoReflect() protoreflect.Message {
+	mi := &file_restaurant_proto_msgTypes[35]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CartItem.ProtoReflect.Descriptor instead.
+func (*CartItem) Descriptor() ([]byte, []int) {
+	return file_restaurant_proto_rawDescGZIP(), []int{35}
+}
+
+func (x *CartItem) GetItemId() string {
+	if x != nil {
+		return x.ItemId
+	}
+	return ""
+}
+
+func (x *CartItem) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
+}
+
+func (x *CartItem) GetQuantity() int32 {
+	if x != nil {
+		return x.Quantity
+	}
+	return 0
+}
+
+func (x *CartItem) GetUnitPrice() float32 {
+	if x != nil {
+		return x.UnitPrice
+	}
+	return 0
+}
+
+func (x *CartItem) GetAvailable() bool {
+	if x != nil {
+		return x.Available
+	}
+	return false
+}
+
+func (x *CartItem) GetPriceChanged() bool {
+	if x != nil {
+		return x.PriceChanged
+	}
+	return false
+}
+
+func (x *CartItem) GetPreviousPrice() float32 {
+	if x != nil {
+		return x.PreviousPrice
+	}
+	return 0
+}
+
+type GetCartRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	CustomerId    string                 `protobuf:"bytes,1,opt,name=customer_id,json=customerId,proto3" json:"customer_id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetCartRequest) Reset() {
+	*x = GetCartRequest{}
+	mi := &file_restaurant_proto_msgTypes[36]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetCartRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetCartRequest) ProtoMessage() {}
+
+func (x *GetCartRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_restaurant_proto_msgTypes[36]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetCartRequest.ProtoReflect.Descriptor instead.
+func (*GetCartRequest) Descriptor() ([]byte, []int) {
+	return file_restaurant_proto_rawDescGZIP(), []int{36}
+}
+
+func (x *GetCartRequest) GetCustomerId() string {
+	if x != nil {
+		return x.CustomerId
+	}
+	return ""
+}
+
+type AddCartItemRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	CustomerId    string                 `protobuf:"bytes,1,opt,name=customer_id,json=customerId,proto3" json:"customer_id,omitempty"`
+	RestaurantId  string                 `protobuf:"bytes,2,opt,name=restaurant_id,json=restaurantId,proto3" json:"restaurant_id,omitempty"`
+	ItemId        string                 `protobuf:"bytes,3,opt,name=item_id,json=itemId,proto3" json:"item_id,omitempty"`
+	Quantity      int32                  `protobuf:"varint,4,opt,name=quantity,proto3" json:"quantity,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *AddCartItemRequest) Reset() {
+	*x = AddCartItemRequest{}
+	mi := &file_restaurant_proto_msgTypes[37]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *AddCartItemRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*AddCartItemRequest) ProtoMessage() {}
+
+func (x *AddCartItemRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_restaurant_proto_msgTypes[37]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use AddCartItemRequest.ProtoReflect.Descriptor instead.
+func (*AddCartItemRequest) Descriptor() ([]byte, []int) {
+	return file_restaurant_proto_rawDescGZIP(), []int{37}
+}
+
+func (x *AddCartItemRequest) GetCustomerId() string {
+	if x != nil {
+		return x.CustomerId
+	}
+	return ""
+}
+
+func (x *AddCartItemRequest) GetRestaurantId() string {
+	if x != nil {
+		return x.RestaurantId
+	}
+	return ""
+}
+
+func (x *AddCartItemRequest) GetItemId() string {
+	if x != nil {
+		return x.ItemId
+	}
+	return ""
+}
+
+func (x *AddCartItemRequest) GetQuantity() int32 {
+	if x != nil {
+		return x.Quantity
+	}
+	return 0
+}
+
+type UpdateCartItemRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	CustomerId    string                 `protobuf:"bytes,1,opt,name=customer_id,json=customerId,proto3" json:"customer_id,omitempty"`
+	ItemId        string                 `protobuf:"bytes,2,opt,name=item_id,json=itemId,proto3" json:"item_id,omitempty"`
+	Quantity      int32                  `protobuf:"varint,3,opt,name=quantity,proto3" json:"quantity,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *UpdateCartItemRequest) Reset() {
+	*x = UpdateCartItemRequest{}
+	mi := &file_restaurant_proto_msgTypes[38]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *UpdateCartItemRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*UpdateCartItemRequest) ProtoMessage() {}
+
+func (x *UpdateCartItemRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_restaurant_proto_msgTypes[38]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use UpdateCartItemRequest.ProtoReflect.Descriptor instead.
+func (*UpdateCartItemRequest) Descriptor() ([]byte, []int) {
+	return file_restaurant_proto_rawDescGZIP(), []int{38}
+}
+
+func (x *UpdateCartItemRequest) GetCustomerId() string {
+	if x != nil {
+		return x.CustomerId
+	}
+	return ""
+}
+
+func (x *UpdateCartItemRequest) GetItemId() string {
+	if x != nil {
+		return x.ItemId
+	}
+	return ""
+}
+
+func (x *UpdateCartItemRequest) GetQuantity() int32 {
+	if x != nil {
+		return x.Quantity
+	}
+	return 0
+}
+
+type RemoveCartItemRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	CustomerId    string                 `protobuf:"bytes,1,opt,name=customer_id,json=customerId,proto3" json:"customer_id,omitempty"`
+	ItemId        string                 `protobuf:"bytes,2,opt,name=item_id,json=itemId,proto3" json:"item_id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *RemoveCartItemRequest) Reset() {
+	*x = RemoveCartItemRequest{}
+	mi := &file_restaurant_proto_msgTypes[39]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *RemoveCartItemRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*RemoveCartItemRequest) ProtoMessage() {}
+
+func (x *RemoveCartItemRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_restaurant_proto_msgTypes[39]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use RemoveCartItemRequest.ProtoReflect.Descriptor instead.
+func (*RemoveCartItemRequest) Descriptor() ([]byte, []int) {
+	return file_restaurant_proto_rawDescGZIP(), []int{39}
+}
+
+func (x *RemoveCartItemRequest) GetCustomerId() string {
+	if x != nil {
+		return x.CustomerId
+	}
+	return ""
+}
+
+func (x *RemoveCartItemRequest) GetItemId() string {
+	if x != nil {
+		return x.ItemId
+	}
+	return ""
+}
+
+type ClearCartRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	CustomerId    string                 `protobuf:"bytes,1,opt,name=customer_id,json=customerId,proto3" json:"customer_id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ClearCartRequest) Reset() {
+	*x = ClearCartRequest{}
+	mi := &file_restaurant_proto_msgTypes[40]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ClearCartRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ClearCartRequest) ProtoMessage() {}
+
+func (x *ClearCartRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_restaurant_proto_msgTypes[40]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ClearCartRequest.ProtoReflect.Descriptor instead.
+func (*ClearCartRequest) Descriptor() ([]byte, []int) {
+	return file_restaurant_proto_rawDescGZIP(), []int{40}
+}
+
+func (x *ClearCartRequest) GetCustomerId() string {
+	if x != nil {
+		return x.CustomerId
+	}
+	return ""
+}
+
+type CheckoutCartRequest struct {
+	state      protoimpl.MessageState `protogen:"open.v1"`
+	CustomerId string                 `protobuf:"bytes,1,opt,name=customer_id,json=customerId,proto3" json:"customer_id,omitempty"`
+	// Same semantics as PlaceOrderRequest.idempotency_key.
+	IdempotencyKey string `protobuf:"bytes,2,opt,name=idempotency_key,json=idempotencyKey,proto3" json:"idempotency_key,omitempty"`
+	unknownFields  protoimpl.UnknownFields
+	sizeCache      protoimpl.SizeCache
+}
+
+func (x *CheckoutCartRequest) Reset() {
+	*x = CheckoutCartRequest{}
+	mi := &file_restaurant_proto_msgTypes[41]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *CheckoutCartRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CheckoutCartRequest) ProtoMessage() {}
+
+func (x *CheckoutCartRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_restaurant_proto_msgTypes[41]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CheckoutCartRequest.ProtoReflect.Descriptor instead.
+func (*CheckoutCartRequest) Descriptor() ([]byte, []int) {
+	return file_restaurant_proto_rawDescGZIP(), []int{41}
+}
+
+func (x *CheckoutCartRequest) GetCustomerId() string {
+	if x != nil {
+		return x.CustomerId
+	}
+	return ""
+}
+
+func (x *CheckoutCartRequest) GetIdempotencyKey() string {
+	if x != nil {
+		return x.IdempotencyKey
+	}
+	return ""
+}
+
+var File_restaurant_proto protoreflect.FileDescriptor
+
+const file_restaurant_proto_rawDesc = "" +
+	"\n" +
+	"\x10restaurant.proto\x12\n" +
+	"restaurant\x1a\vorder.proto\"\xf4\x01\n" +
+	"\n" +
+	"Restaurant\x12#\n" +
+	"\rrestaurant_id\x18\x01 \x01(\tR\frestaurantId\x12\x14\n" +
+	"\x05email\x18\x02 \x01(\tR\x05email\x12\x12\n" +
+	"\x04name\x18\x03 \x01(\tR\x04name\x12\x1a\n" +
+	"\blatitude\x18\x04 \x01(\x02R\blatitude\x12\x1c\n" +
+	"\tlongitude\x18\x05 \x01(\x02R\tlongitude\x12*\n" +
+	"\x05menus\x18\x06 \x03(\v2\x14.restaurant.MenuItemR\x05menus\x12\x16\n" +
+	"\x06status\x18\a \x01(\tR\x06status\x12\x19\n" +
+	"\bowner_id\x18\b \x01(\tR\aownerId\"o\n" +
+	"\bMenuItem\x12\x17\n" +
+	"\aitem_id\x18\x01 \x01(\tR\x06itemId\x12\x12\n" +
+	"\x04name\x18\x02 \x01(\tR\x04name\x12 \n" +
+	"\vdescription\x18\x03 \x01(\tR\vdescription\x12\x14\n" +
+	"\x05price\x18\x04 \x01(\x02R\x05price\"M\n" +
+	"\x16RestaurantLoginRequest\x12\x14\n" +
+	"\x05email\x18\x01 \x01(\tR\x05email\x12\x1d\n" +
+	"\n" +
+	"secret_key\x18\x02 \x01(\tR\tsecretKey\"\xd2\x01\n" +
+	"\x19RegisterRestaurantRequest\x12\x14\n" +
+	"\x05email\x18\x01 \x01(\tR\x05email\x12\x1d\n" +
+	"\n" +
+	"secret_key\x18\x02 \x01(\tR\tsecretKey\x12\x12\n" +
+	"\x04name\x18\x03 \x01(\tR\x04name\x12\x1a\n" +
+	"\blatitude\x18\x04 \x01(\x02R\blatitude\x12\x1c\n" +
+	"\tlongitude\x18\x05 \x01(\x02R\tlongitude\x122\n" +
+	"\x05menus\x18\x06 \x03(\v2\x1c.restaurant.RegisterMenuItemR\x05menus\"^\n" +
+	"\x10RegisterMenuItem\x12\x12\n" +
+	"\x04name\x18\x01 \x01(\tR\x04name\x12 \n" +
+	"\vdescription\x18\x02 \x01(\tR\vdescription\x12\x14\n" +
+	"\x05price\x18\x03 \x01(\x02R\x05price\";\n" +
+	"\x14GetRestaurantRequest\x12#\n" +
+	"\rrestaurant_id\x18\x01 \x01(\tR\frestaurantId\"\xa2\x01\n" +
+	"\x17UpdateRestaurantRequest\x12#\n" +
+	"\rrestaurant_id\x18\x01 \x01(\tR\frestaurantId\x12\x14\n" +
+	"\x05email\x18\x02 \x01(\tR\x05email\x12\x12\n" +
+	"\x04name\x18\x03 \x01(\tR\x04name\x12\x1a\n" +
+	"\blatitude\x18\x04 \x01(\x02R\blatitude\x12\x1c\n" +
+	"\tlongitude\x18\x05 \x01(\x02R\tlongitude\"B\n" +
+	"\x1bDeactivateRestaurantRequest\x12#\n" +
+	"\rrestaurant_id\x18\x01 \x01(\tR\frestaurantId\"B\n" +
+	"\x1bReactivateRestaurantRequest\x12#\n" +
+	"\rrestaurant_id\x18\x01 \x01(\tR\frestaurantId\"o\n" +
+	"\x16ListRestaurantsRequest\x12\x1a\n" +
+	"\blatitude\x18\x01 \x01(\x02R\blatitude\x12\x1c\n" +
+	"\tlongitude\x18\x02 \x01(\x02R\tlongitude\x12\x1b\n" +
+	"\tradius_km\x18\x03 \x01(\x02R\bradiusKm\"\x85\x01\n" +
+	"\x12AddMenuItemRequest\x12#\n" +
+	"\rrestaurant_id\x18\x01 \x01(\tR\frestaurantId\x12\x12\n" +
+	"\x04name\x18\x02 \x01(\tR\x04name\x12 \n" +
+	"\vdescription\x18\x03 \x01(\tR\vdescription\x12\x14\n" +
+	"\x05price\x18\x04 \x01(\x02R\x05price\"U\n" +
+	"\x15RemoveMenuItemRequest\x12#\n" +
+	"\rrestaurant_id\x18\x01 \x01(\tR\frestaurantId\x12\x17\n" +
+	"\aitem_id\x18\x02 \x01(\tR\x06itemId\"\xa1\x01\n" +
+	"\x15UpdateMenuItemRequest\x12#\n" +
+	"\rrestaurant_id\x18\x01 \x01(\tR\frestaurantId\x12\x17\n" +
+	"\aitem_id\x18\x02 \x01(\tR\x06itemId\x12\x12\n" +
+	"\x04name\x18\x03 \x01(\tR\x04name\x12 \n" +
+	"\vdescription\x18\x04 \x01(\tR\vdescription\x12\x14\n" +
+	"\x05price\x18\x05 \x01(\x02R\x05price\"\xe4\x01\n" +
+	"\x05Order\x12\x19\n" +
+	"\border_id\x18\x01 \x01(\tR\aorderId\x12\x1f\n" +
+	"\vcustomer_id\x18\x02 \x01(\tR\n" +
+	"customerId\x12#\n" +
+	"\rrestaurant_id\x18\x03 \x01(\tR\frestaurantId\x12+\n" +
+	"\x05items\x18\x04 \x03(\v2\x15.restaurant.OrderItemR\x05items\x12!\n" +
+	"\ftotal_amount\x18\x05 \x01(\x01R\vtotalAmount\x12*\n" +
+	"\x06status\x18\x06 \x01(\x0e2\x12.order.OrderStatusR\x06status\"Q\n" +
+	"\x12WatchOrdersRequest\x12#\n" +
+	"\rrestaurant_id\x18\x01 \x01(\tR\frestaurantId\x12\x16\n" +
+	"\x06cursor\x18\x02 \x01(\x03R\x06cursor\"\xe7\x01\n" +
+	"\x0eOrderFeedEvent\x123\n" +
+	"\x04type\x18\x01 \x01(\x0e2\x1f.restaurant.OrderFeedEvent.TypeR\x04type\x12\x16\n" +
+	"\x06cursor\x18\x02 \x01(\x03R\x06cursor\x12'\n" +
+	"\x05order\x18\x03 \x01(\v2\x11.restaurant.OrderR\x05order\x12(\n" +
+	"\x10occurred_at_unix\x18\x04 \x01(\x03R\x0eoccurredAtUnix\"5\n" +
+	"\x04Type\x12\f\n" +
+	"\bSNAPSHOT\x10\x00\x12\v\n" +
+	"\aCREATED\x10\x01\x12\x12\n" +
+	"\x0eSTATUS_CHANGED\x10\x02\"\xaf\x01\n" +
+	"\x11PlaceOrderRequest\x12\x1f\n" +
+	"\vcustomer_id\x18\x01 \x01(\tR\n" +
+	"customerId\x12#\n" +
+	"\rrestaurant_id\x18\x02 \x01(\tR\frestaurantId\x12+\n" +
+	"\x05items\x18\x03 \x03(\v2\x15.restaurant.OrderItemR\x05items\x12'\n" +
+	"\x0fidempotency_key\x18\x04 \x01(\tR\x0eidempotencyKey\"j\n" +
+	"\x12PlaceOrderResponse\x12\x19\n" +
+	"\border_id\x18\x01 \x01(\tR\aorderId\x12!\n" +
+	"\ftotal_amount\x18\x02 \x01(\x01R\vtotalAmount\x12\x16\n" +
+	"\x06status\x18\x03 \x01(\tR\x06status\"@\n" +
+	"\tOrderItem\x12\x17\n" +
+	"\aitem_id\x18\x01 \x01(\tR\x06itemId\x12\x1a\n" +
+	"\bquantity\x18\x02 \x01(\x05R\bquantity\"7\n" +
+	"\x10GetOrdersRequest\x12#\n" +
+	"\rrestaurant_id\x18\x01 \x01(\tR\frestaurantId\">\n" +
+	"\x11GetOrdersResponse\x12)\n" +
+	"\x06orders\x18\x01 \x03(\v2\x11.restaurant.OrderR\x06orders\"\x8d\x01\n" +
+	"\x18UpdateOrderStatusRequest\x12#\n" +
+	"\rrestaurant_id\x18\x01 \x01(\tR\frestaurantId\x12\x19\n" +
+	"\border_id\x18\x02 \x01(\tR\aorderId\x121\n" +
+	"\n" +
+	"new_status\x18\x03 \x01(\x0e2\x12.order.OrderStatusR\tnewStatus\"R\n" +
+	"\x10ShipOrderRequest\x12#\n" +
+	"\rrestaurant_id\x18\x01 \x01(\tR\frestaurantId\x12\x19\n" +
+	"\border_id\x18\x02 \x01(\tR\aorderId\"\xda\x01\n" +
+	"\x11ShipOrderResponse\x121\n" +
+	"\x14confirmation_message\x18\x01 \x01(\tR\x13confirmationMessage\x12\x1b\n" +
+	"\tdriver_id\x18\x02 \x01(\tR\bdriverId\x12\x19\n" +
+	"\boffer_id\x18\x03 \x01(\tR\aofferId\x12'\n" +
+	"\x0fdispatch_status\x18\x04 \x01(\tR\x0edispatchStatus\x121\n" +
+	"\x15offer_expires_at_unix\x18\x05 \x01(\x03R\x12offerExpiresAtUnix\",\n" +
+	"\x0fGetOrderRequest\x12\x19\n" +
+	"\border_id\x18\x01 \x01(\tR\aorderId\"\x95\x01\n" +
+	"\x11ImportMenuRequest\x12#\n" +
+	"\rrestaurant_id\x18\x01 \x01(\tR\frestaurantId\x12.\n" +
+	"\x06format\x18\x02 \x01(\x0e2\x16.restaurant.MenuFormatR\x06format\x12\x12\n" +
+	"\x04data\x18\x03 \x01(\fR\x04data\x12\x17\n" +
+	"\adry_run\x18\x04 \x01(\bR\x06dryRun\"U\n" +
+	"\x0fMenuImportError\x12\x12\n" +
+	"\x04line\x18\x01 \x01(\x05R\x04line\x12\x14\n" +
+	"\x05field\x18\x02 \x01(\tR\x05field\x12\x18\n" +
+	"\amessage\x18\x03 \x01(\tR\amessage\"\x96\x01\n" +
+	"\x12ImportMenuResponse\x12\x18\n" +
+	"\acreated\x18\x01 \x01(\x05R\acreated\x12\x18\n" +
+	"\aupdated\x18\x02 \x01(\x05R\aupdated\x12\x17\n" +
+	"\adry_run\x18\x03 \x01(\bR\x06dryRun\x123\n" +
+	"\x06errors\x18\x04 \x03(\v2\x1b.restaurant.MenuImportErrorR\x06errors\"h\n" +
+	"\x11ExportMenuRequest\x12#\n" +
+	"\rrestaurant_id\x18\x01 \x01(\tR\frestaurantId\x12.\n" +
+	"\x06format\x18\x02 \x01(\x0e2\x16.restaurant.MenuFormatR\x06format\"{\n" +
+	"\x12ExportMenuResponse\x12.\n" +
+	"\x06format\x18\x01 \x01(\x0e2\x16.restaurant.MenuFormatR\x06format\x12!\n" +
+	"\fcontent_type\x18\x02 \x01(\tR\vcontentType\x12\x12\n" +
+	"\x04data\x18\x03 \x01(\fR\x04data\"\xc7\x01\n" +
+	"\rDeliveryOffer\x12\x19\n" +
+	"\boffer_id\x18\x01 \x01(\tR\aofferId\x12\x19\n" +
+	"\border_id\x18\x02 \x01(\tR\aorderId\x12#\n" +
+	"\rrestaurant_id\x18\x03 \x01(\tR\frestaurantId\x12\x1b\n" +
+	"\tdriver_id\x18\x04 \x01(\tR\bdriverId\x12\x16\n" +
+	"\x06status\x18\x05 \x01(\tR\x06status\x12&\n" +
+	"\x0fexpires_at_unix\x18\x06 \x01(\x03R\rexpiresAtUnix\"7\n" +
+	"\x18GetDeliveryOffersRequest\x12\x1b\n" +
+	"\tdriver_id\x18\x01 \x01(\tR\bdriverId\"N\n" +
+	"\x19GetDeliveryOffersResponse\x121\n" +
+	"\x06offers\x18\x01 \x03(\v2\x19.restaurant.DeliveryOfferR\x06offers\"o\n" +
+	"\x1dRespondToDeliveryOfferRequest\x12\x19\n" +
+	"\boffer_id\x18\x01 \x01(\tR\aofferId\x12\x1b\n" +
+	"\tdriver_id\x18\x02 \x01(\tR\bdriverId\x12\x16\n" +
+	"\x06accept\x18\x03 \x01(\bR\x06accept\"\xd9\x01\n" +
+	"\x04Cart\x12\x1f\n" +
+	"\vcustomer_id\x18\x01 \x01(\tR\n" +
+	"customerId\x12#\n" +
+	"\rrestaurant_id\x18\x02 \x01(\tR\frestaurantId\x12*\n" +
+	"\x05items\x18\x03 \x03(\v2\x14.restaurant.CartItemR\x05items\x12!\n" +
+	"\ftotal_amount\x18\x04 \x01(\x01R\vtotalAmount\x12\x14\n" +
+	"\x05valid\x18\x05 \x01(\bR\x05valid\x12&\n" +
+	"\x0fexpires_at_unix\x18\x06 \x01(\x03R\rexpiresAtUnix\"\xdc\x01\n" +
+	"\bCartItem\x12\x17\n" +
+	"\aitem_id\x18\x01 \x01(\tR\x06itemId\x12\x12\n" +
+	"\x04name\x18\x02 \x01(\tR\x04name\x12\x1a\n" +
+	"\bquantity\x18\x03 \x01(\x05R\bquantity\x12\x1d\n" +
+	"\n" +
+	"unit_price\x18\x04 \x01(\x02R\tunitPrice\x12\x1c\n" +
+	"\tavailable\x18\x05 \x01(\bR\tavailable\x12#\n" +
+	"\rprice_changed\x18\x06 \x01(\bR\fpriceChanged\x12%\n" +
+	"\x0eprevious_price\x18\a \x01(\x02R\rpreviousPrice\"1\n" +
+	"\x0eGetCartRequest\x12\x1f\n" +
+	"\vcustomer_id\x18\x01 \x01(\tR\n" +
+	"customerId\"\x8f\x01\n" +
+	"\x12AddCartItemRequest\x12\x1f\n" +
+	"\vcustomer_id\x18\x01 \x01(\tR\n" +
+	"customerId\x12#\n" +
+	"\rrestaurant_id\x18\x02 \x01(\tR\frestaurantId\x12\x17\n" +
+	"\aitem_id\x18\x03 \x01(\tR\x06itemId\x12\x1a\n" +
+	"\bquantity\x18\x04 \x01(\x05R\bquantity\"m\n" +
+	"\x15UpdateCartItemRequest\x12\x1f\n" +
+	"\vcustomer_id\x18\x01 \x01(\tR\n" +
+	"customerId\x12\x17\n" +
+	"\aitem_id\x18\x02 \x01(\tR\x06itemId\x12\x1a\n" +
+	"\bquantity\x18\x03 \x01(\x05R\bquantity\"Q\n" +
+	"\x15RemoveCartItemRequest\x12\x1f\n" +
+	"\vcustomer_id\x18\x01 \x01(\tR\n" +
+	"customerId\x12\x17\n" +
+	"\aitem_id\x18\x02 \x01(\tR\x06itemId\"3\n" +
+	"\x10ClearCartRequest\x12\x1f\n" +
+	"\vcustomer_id\x18\x01 \x01(\tR\n" +
+	"customerId\"_\n" +
+	"\x13CheckoutCartRequest\x12\x1f\n" +
+	"\vcustomer_id\x18\x01 \x01(\tR\n" +
+	"customerId\x12'\n" +
+	"\x0fidempotency_key\x18\x02 \x01(\tR\x0eidempotencyKey*\x1f\n" +
+	"\n" +
+	"MenuFormat\x12\a\n" +
+	"\x03CSV\x10\x00\x12\b\n" +
+	"\x04JSON\x10\x012\xd1\x0f\n" +
+	"\x11RestaurantService\x12C\n" +
+	"\x05Login\x12\".restaurant.RestaurantLoginRequest\x1a\x16.restaurant.Restaurant\x12S\n" +
+	"\x12RegisterRestaurant\x12%.restaurant.RegisterRestaurantRequest\x1a\x16.restaurant.Restaurant\x12I\n" +
+	"\rGetRestaurant\x12 .restaurant.GetRestaurantRequest\x1a\x16.restaurant.Restaurant\x12O\n" +
+	"\x0fListRestaurants\x12\".restaurant.ListRestaurantsRequest\x1a\x16.restaurant.Restaurant0\x01\x12O\n" +
+	"\x10UpdateRestaurant\x12#.restaurant.UpdateRestaurantRequest\x1a\x16.restaurant.Restaurant\x12W\n" +
+	"\x14DeactivateRestaurant\x12'.restaurant.DeactivateRestaurantRequest\x1a\x16.restaurant.Restaurant\x12W\n" +
+	"\x14ReactivateRestaurant\x12'.restaurant.ReactivateRestaurantRequest\x1a\x16.restaurant.Restaurant\x12C\n" +
+	"\vAddMenuItem\x12\x1e.restaurant.AddMenuItemRequest\x1a\x14.restaurant.MenuItem\x12I\n" +
+	"\x0eRemoveMenuItem\x12!.restaurant.RemoveMenuItemRequest\x1a\x14.restaurant.MenuItem\x12I\n" +
+	"\x0eUpdateMenuItem\x12!.restaurant.UpdateMenuItemRequest\x1a\x14.restaurant.MenuItem\x12K\n" +
+	"\n" +
+	"ImportMenu\x12\x1d.restaurant.ImportMenuRequest\x1a\x1e.restaurant.ImportMenuResponse\x12K\n" +
+	"\n" +
+	"ExportMenu\x12\x1d.restaurant.ExportMenuRequest\x1a\x1e.restaurant.ExportMenuResponse\x12K\n" +
 	"\n" +
 	"PlaceOrder\x12\x1d.restaurant.PlaceOrderRequest\x1a\x1e.restaurant.PlaceOrderResponse\x12H\n" +
 	"\tGetOrders\x12\x1c.restaurant.GetOrdersRequest\x1a\x1d.restaurant.GetOrdersResponse\x12L\n" +
 	"\x11UpdateOrderStatus\x12$.restaurant.UpdateOrderStatusRequest\x1a\x11.restaurant.Order\x12H\n" +
 	"\tShipOrder\x12\x1c.restaurant.ShipOrderRequest\x1a\x1d.restaurant.ShipOrderResponse\x12:\n" +
-	"\bGetOrder\x12\x1b.restaurant.GetOrderRequest\x1a\x11.restaurant.OrderBMZKgithub.com/tamirat-dejene/ha-soranu/shared/protos/restaurantpb;restaurantpbb\x06proto3"
+	"\bGetOrder\x12\x1b.restaurant.GetOrderRequest\x1a\x11.restaurant.Order\x12K\n" +
+	"\vWatchOrders\x12\x1e.restaurant.WatchOrdersRequest\x1a\x1a.restaurant.OrderFeedEvent0\x01\x12`\n" +
+	"\x11GetDeliveryOffers\x12$.restaurant.GetDeliveryOffersRequest\x1a%.restaurant.GetDeliveryOffersResponse\x12^\n" +
+	"\x16RespondToDeliveryOffer\x12).restaurant.RespondToDeliveryOfferRequest\x1a\x19.restaurant.DeliveryOffer\x127\n" +
+	"\aGetCart\x12\x1a.restaurant.GetCartRequest\x1a\x10.restaurant.Cart\x12?\n" +
+	"\vAddCartItem\x12\x1e.restaurant.AddCartItemRequest\x1a\x10.restaurant.Cart\x12E\n" +
+	"\x0eUpdateCartItem\x12!.restaurant.UpdateCartItemRequest\x1a\x10.restaurant.Cart\x12E\n" +
+	"\x0eRemoveCartItem\x12!.restaurant.RemoveCartItemRequest\x1a\x10.restaurant.Cart\x12;\n" +
+	"\tClearCart\x12\x1c.restaurant.ClearCartRequest\x1a\x10.restaurant.Cart\x12O\n" +
+	"\fCheckoutCart\x12\x1f.restaurant.CheckoutCartRequest\x1a\x1e.restaurant.PlaceOrderResponseBMZKgithub.com/tamirat-dejene/ha-soranu/shared/protos/restaurantpb;restaurantpbb\x06proto3"
 
 var (
 	file_restaurant_proto_rawDescOnce sync.Once
@@ -1342,67 +2955,128 @@ func file_restaurant_proto_rawDescGZIP() []byte {
 	return file_restaurant_proto_rawDescData
 }
 
-var file_restaurant_proto_msgTypes = make([]protoimpl.MessageInfo, 20)
+var file_restaurant_proto_enumTypes = make([]protoimpl.EnumInfo, 2)
+var file_restaurant_proto_msgTypes = make([]protoimpl.MessageInfo, 42)
 var file_restaurant_proto_goTypes = []any{
-	(*Restaurant)(nil),                // 0: restaurant.Restaurant
-	(*MenuItem)(nil),                  // 1: restaurant.MenuItem
-	(*RestaurantLoginRequest)(nil),    // 2: restaurant.RestaurantLoginRequest
-	(*RegisterRestaurantRequest)(nil), // 3: restaurant.RegisterRestaurantRequest
-	(*RegisterMenuItem)(nil),          // 4: restaurant.RegisterMenuItem
-	(*GetRestaurantRequest)(nil),      // 5: restaurant.GetRestaurantRequest
-	(*ListRestaurantsRequest)(nil),    // 6: restaurant.ListRestaurantsRequest
-	(*AddMenuItemRequest)(nil),        // 7: restaurant.AddMenuItemRequest
-	(*RemoveMenuItemRequest)(nil),     // 8: restaurant.RemoveMenuItemRequest
-	(*UpdateMenuItemRequest)(nil),     // 9: restaurant.UpdateMenuItemRequest
-	(*Order)(nil),                     // 10: restaurant.Order
-	(*PlaceOrderRequest)(nil),         // 11: restaurant.PlaceOrderRequest
-	(*PlaceOrderResponse)(nil),        // 12: restaurant.PlaceOrderResponse
-	(*OrderItem)(nil),                 // 13: restaurant.OrderItem
-	(*GetOrdersRequest)(nil),          // 14: restaurant.GetOrdersRequest
-	(*GetOrdersResponse)(nil),         // 15: restaurant.GetOrdersResponse
-	(*UpdateOrderStatusRequest)(nil),  // 16: restaurant.UpdateOrderStatusRequest
-	(*ShipOrderRequest)(nil),          // 17: restaurant.ShipOrderRequest
-	(*ShipOrderResponse)(nil),         // 18: restaurant.ShipOrderResponse
-	(*GetOrderRequest)(nil),           // 19: restaurant.GetOrderRequest
-	(orderpb.OrderStatus)(0),          // 20: order.OrderStatus
+	(MenuFormat)(0),                       // 0: restaurant.MenuFormat
+	(OrderFeedEvent_Type)(0),              // 1: restaurant.OrderFeedEvent.Type
+	(*Restaurant)(nil),                    // 2: restaurant.Restaurant
+	(*MenuItem)(nil),                      // 3: restaurant.MenuItem
+	(*RestaurantLoginRequest)(nil),        // 4: restaurant.RestaurantLoginRequest
+	(*RegisterRestaurantRequest)(nil),     // 5: restaurant.RegisterRestaurantRequest
+	(*RegisterMenuItem)(nil),              // 6: restaurant.RegisterMenuItem
+	(*GetRestaurantRequest)(nil),          // 7: restaurant.GetRestaurantRequest
+	(*UpdateRestaurantRequest)(nil),       // 8: restaurant.UpdateRestaurantRequest
+	(*DeactivateRestaurantRequest)(nil),   // 9: restaurant.DeactivateRestaurantRequest
+	(*ReactivateRestaurantRequest)(nil),   // 10: restaurant.ReactivateRestaurantRequest
+	(*ListRestaurantsRequest)(nil),        // 11: restaurant.ListRestaurantsRequest
+	(*AddMenuItemRequest)(nil),            // 12: restaurant.AddMenuItemRequest
+	(*RemoveMenuItemRequest)(nil),         // 13: restaurant.RemoveMenuItemRequest
+	(*UpdateMenuItemRequest)(nil),         // 14: restaurant.UpdateMenuItemRequest
+	(*Order)(nil),                         // 15: restaurant.Order
+	(*WatchOrdersRequest)(nil),            // 16: restaurant.WatchOrdersRequest
+	(*OrderFeedEvent)(nil),                // 17: restaurant.OrderFeedEvent
+	(*PlaceOrderRequest)(nil),             // 18: restaurant.PlaceOrderRequest
+	(*PlaceOrderResponse)(nil),            // 19: restaurant.PlaceOrderResponse
+	(*OrderItem)(nil),                     // 20: restaurant.OrderItem
+	(*GetOrdersRequest)(nil),              // 21: restaurant.GetOrdersRequest
+	(*GetOrdersResponse)(nil),             // 22: restaurant.GetOrdersResponse
+	(*UpdateOrderStatusRequest)(nil),      // 23: restaurant.UpdateOrderStatusRequest
+	(*ShipOrderRequest)(nil),              // 24: restaurant.ShipOrderRequest
+	(*ShipOrderResponse)(nil),             // 25: restaurant.ShipOrderResponse
+	(*GetOrderRequest)(nil),               // 26: restaurant.GetOrderRequest
+	(*ImportMenuRequest)(nil),             // 27: restaurant.ImportMenuRequest
+	(*MenuImportError)(nil),               // 28: restaurant.MenuImportError
+	(*ImportMenuResponse)(nil),            // 29: restaurant.ImportMenuResponse
+	(*ExportMenuRequest)(nil),             // 30: restaurant.ExportMenuRequest
+	(*ExportMenuResponse)(nil),            // 31: restaurant.ExportMenuResponse
+	(*DeliveryOffer)(nil),                 // 32: restaurant.DeliveryOffer
+	(*GetDeliveryOffersRequest)(nil),      // 33: restaurant.GetDeliveryOffersRequest
+	(*GetDeliveryOffersResponse)(nil),     // 34: restaurant.GetDeliveryOffersResponse
+	(*RespondToDeliveryOfferRequest)(nil), // 35: restaurant.RespondToDeliveryOfferRequest
+	(*Cart)(nil),                          // 36: restaurant.Cart
+	(*CartItem)(nil),                      // 37: restaurant.CartItem
+	(*GetCartRequest)(nil),                // 38: restaurant.GetCartRequest
+	(*AddCartItemRequest)(nil),            // 39: restaurant.AddCartItemRequest
+	(*UpdateCartItemRequest)(nil),         // 40: restaurant.UpdateCartItemRequest
+	(*RemoveCartItemRequest)(nil),         // 41: restaurant.RemoveCartItemRequest
+	(*ClearCartRequest)(nil),              // 42: restaurant.ClearCartRequest
+	(*CheckoutCartRequest)(nil),           // 43: restaurant.CheckoutCartRequest
+	(orderpb.OrderStatus)(0),              // 44: order.OrderStatus
 }
 var file_restaurant_proto_depIdxs = []int32{
-	1,  // 0: restaurant.Restaurant.menus:type_name -> restaurant.MenuItem
-	4,  // 1: restaurant.RegisterRestaurantRequest.menus:type_name -> restaurant.RegisterMenuItem
-	13, // 2: restaurant.Order.items:type_name -> restaurant.OrderItem
-	20, // 3: restaurant.Order.status:type_name -> order.OrderStatus
-	13, // 4: restaurant.PlaceOrderRequest.items:type_name -> restaurant.OrderItem
-	10, // 5: restaurant.GetOrdersResponse.orders:type_name -> restaurant.Order
-	20, // 6: restaurant.UpdateOrderStatusRequest.new_status:type_name -> order.OrderStatus
-	2,  // 7: restaurant.RestaurantService.Login:input_type -> restaurant.RestaurantLoginRequest
-	3,  // 8: restaurant.RestaurantService.RegisterRestaurant:input_type -> restaurant.RegisterRestaurantRequest
-	5,  // 9: restaurant.RestaurantService.GetRestaurant:input_type -> restaurant.GetRestaurantRequest
-	6,  // 10: restaurant.RestaurantService.ListRestaurants:input_type -> restaurant.ListRestaurantsRequest
-	7,  // 11: restaurant.RestaurantService.AddMenuItem:input_type -> restaurant.AddMenuItemRequest
-	8,  // 12: restaurant.RestaurantService.RemoveMenuItem:input_type -> restaurant.RemoveMenuItemRequest
-	9,  // 13: restaurant.RestaurantService.UpdateMenuItem:input_type -> restaurant.UpdateMenuItemRequest
-	11, // 14: restaurant.RestaurantService.PlaceOrder:input_type -> restaurant.PlaceOrderRequest
-	14, // 15: restaurant.RestaurantService.GetOrders:input_type -> restaurant.GetOrdersRequest
-	16, // 16: restaurant.RestaurantService.UpdateOrderStatus:input_type -> restaurant.UpdateOrderStatusRequest
-	17, // 17: restaurant.RestaurantService.ShipOrder:input_type -> restaurant.ShipOrderRequest
-	19, // 18: restaurant.RestaurantService.GetOrder:input_type -> restaurant.GetOrderRequest
-	0,  // 19: restaurant.RestaurantService.Login:output_type -> restaurant.Restaurant
-	0,  // 20: restaurant.RestaurantService.RegisterRestaurant:output_type -> restaurant.Restaurant
-	0,  // 21: restaurant.RestaurantService.GetRestaurant:output_type -> restaurant.Restaurant
-	0,  // 22: restaurant.RestaurantService.ListRestaurants:output_type -> restaurant.Restaurant
-	1,  // 23: restaurant.RestaurantService.AddMenuItem:output_type -> restaurant.MenuItem
-	1,  // 24: restaurant.RestaurantService.RemoveMenuItem:output_type -> restaurant.MenuItem
-	1,  // 25: restaurant.RestaurantService.UpdateMenuItem:output_type -> restaurant.MenuItem
-	12, // 26: restaurant.RestaurantService.PlaceOrder:output_type -> restaurant.PlaceOrderResponse
-	15, // 27: restaurant.RestaurantService.GetOrders:output_type -> restaurant.GetOrdersResponse
-	10, // 28: restaurant.RestaurantService.UpdateOrderStatus:output_type -> restaurant.Order
-	18, // 29: restaurant.RestaurantService.ShipOrder:output_type -> restaurant.ShipOrderResponse
-	10, // 30: restaurant.RestaurantService.GetOrder:output_type -> restaurant.Order
-	19, // [19:31] is the sub-list for method output_type
-	7,  // [7:19] is the sub-list for method input_type
-	7,  // [7:7] is the sub-list for extension type_name
-	7,  // [7:7] is the sub-list for extension extendee
-	0,  // [0:7] is the sub-list for field type_name
+	3,  // 0: restaurant.Restaurant.menus:type_name -> restaurant.MenuItem
+	6,  // 1: restaurant.RegisterRestaurantRequest.menus:type_name -> restaurant.RegisterMenuItem
+	20, // 2: restaurant.Order.items:type_name -> restaurant.OrderItem
+	44, // 3: restaurant.Order.status:type_name -> order.OrderStatus
+	1,  // 4: restaurant.OrderFeedEvent.type:type_name -> restaurant.OrderFeedEvent.Type
+	15, // 5: restaurant.OrderFeedEvent.order:type_name -> restaurant.Order
+	20, // 6: restaurant.PlaceOrderRequest.items:type_name -> restaurant.OrderItem
+	15, // 7: restaurant.GetOrdersResponse.orders:type_name -> restaurant.Order
+	44, // 8: restaurant.UpdateOrderStatusRequest.new_status:type_name -> order.OrderStatus
+	0,  // 9: restaurant.ImportMenuRequest.format:type_name -> restaurant.MenuFormat
+	28, // 10: restaurant.ImportMenuResponse.errors:type_name -> restaurant.MenuImportError
+	0,  // 11: restaurant.ExportMenuRequest.format:type_name -> restaurant.MenuFormat
+	0,  // 12: restaurant.ExportMenuResponse.format:type_name -> restaurant.MenuFormat
+	32, // 13: restaurant.GetDeliveryOffersResponse.offers:type_name -> restaurant.DeliveryOffer
+	37, // 14: restaurant.Cart.items:type_name -> restaurant.CartItem
+	4,  // 15: restaurant.RestaurantService.Login:input_type -> restaurant.RestaurantLoginRequest
+	5,  // 16: restaurant.RestaurantService.RegisterRestaurant:input_type -> restaurant.RegisterRestaurantRequest
+	7,  // 17: restaurant.RestaurantService.GetRestaurant:input_type -> restaurant.GetRestaurantRequest
+	11, // 18: restaurant.RestaurantService.ListRestaurants:input_type -> restaurant.ListRestaurantsRequest
+	8,  // 19: restaurant.RestaurantService.UpdateRestaurant:input_type -> restaurant.UpdateRestaurantRequest
+	9,  // 20: restaurant.RestaurantService.DeactivateRestaurant:input_type -> restaurant.DeactivateRestaurantRequest
+	10, // 21: restaurant.RestaurantService.ReactivateRestaurant:input_type -> restaurant.ReactivateRestaurantRequest
+	12, // 22: restaurant.RestaurantService.AddMenuItem:input_type -> restaurant.AddMenuItemRequest
+	13, // 23: restaurant.RestaurantService.RemoveMenuItem:input_type -> restaurant.RemoveMenuItemRequest
+	14, // 24: restaurant.RestaurantService.UpdateMenuItem:input_type -> restaurant.UpdateMenuItemRequest
+	27, // 25: restaurant.RestaurantService.ImportMenu:input_type -> restaurant.ImportMenuRequest
+	30, // 26: restaurant.RestaurantService.ExportMenu:input_type -> restaurant.ExportMenuRequest
+	18, // 27: restaurant.RestaurantService.PlaceOrder:input_type -> restaurant.PlaceOrderRequest
+	21, // 28: restaurant.RestaurantService.GetOrders:input_type -> restaurant.GetOrdersRequest
+	23, // 29: restaurant.RestaurantService.UpdateOrderStatus:input_type -> restaurant.UpdateOrderStatusRequest
+	24, // 30: restaurant.RestaurantService.ShipOrder:input_type -> restaurant.ShipOrderRequest
+	26, // 31: restaurant.RestaurantService.GetOrder:input_type -> restaurant.GetOrderRequest
+	16, // 32: restaurant.RestaurantService.WatchOrders:input_type -> restaurant.WatchOrdersRequest
+	33, // 33: restaurant.RestaurantService.GetDeliveryOffers:input_type -> restaurant.GetDeliveryOffersRequest
+	35, // 34: restaurant.RestaurantService.RespondToDeliveryOffer:input_type -> restaurant.RespondToDeliveryOfferRequest
+	38, // 35: restaurant.RestaurantService.GetCart:input_type -> restaurant.GetCartRequest
+	39, // 36: restaurant.RestaurantService.AddCartItem:input_type -> restaurant.AddCartItemRequest
+	40, // 37: restaurant.RestaurantService.UpdateCartItem:input_type -> restaurant.UpdateCartItemRequest
+	41, // 38: restaurant.RestaurantService.RemoveCartItem:input_type -> restaurant.RemoveCartItemRequest
+	42, // 39: restaurant.RestaurantService.ClearCart:input_type -> restaurant.ClearCartRequest
+	43, // 40: restaurant.RestaurantService.CheckoutCart:input_type -> restaurant.CheckoutCartRequest
+	2,  // 41: restaurant.RestaurantService.Login:output_type -> restaurant.Restaurant
+	2,  // 42: restaurant.RestaurantService.RegisterRestaurant:output_type -> restaurant.Restaurant
+	2,  // 43: restaurant.RestaurantService.GetRestaurant:output_type -> restaurant.Restaurant
+	2,  // 44: restaurant.RestaurantService.ListRestaurants:output_type -> restaurant.Restaurant
+	2,  // 45: restaurant.RestaurantService.UpdateRestaurant:output_type -> restaurant.Restaurant
+	2,  // 46: restaurant.RestaurantService.DeactivateRestaurant:output_type -> restaurant.Restaurant
+	2,  // 47: restaurant.RestaurantService.ReactivateRestaurant:output_type -> restaurant.Restaurant
+	3,  // 48: restaurant.RestaurantService.AddMenuItem:output_type -> restaurant.MenuItem
+	3,  // 49: restaurant.RestaurantService.RemoveMenuItem:output_type -> restaurant.MenuItem
+	3,  // 50: restaurant.RestaurantService.UpdateMenuItem:output_type -> restaurant.MenuItem
+	29, // 51: restaurant.RestaurantService.ImportMenu:output_type -> restaurant.ImportMenuResponse
+	31, // 52: restaurant.RestaurantService.ExportMenu:output_type -> restaurant.ExportMenuResponse
+	19, // 53: restaurant.RestaurantService.PlaceOrder:output_type -> restaurant.PlaceOrderResponse
+	22, // 54: restaurant.RestaurantService.GetOrders:output_type -> restaurant.GetOrdersResponse
+	15, // 55: restaurant.RestaurantService.UpdateOrderStatus:output_type -> restaurant.Order
+	25, // 56: restaurant.RestaurantService.ShipOrder:output_type -> restaurant.ShipOrderResponse
+	15, // 57: restaurant.RestaurantService.GetOrder:output_type -> restaurant.Order
+	17, // 58: restaurant.RestaurantService.WatchOrders:output_type -> restaurant.OrderFeedEvent
+	34, // 59: restaurant.RestaurantService.GetDeliveryOffers:output_type -> restaurant.GetDeliveryOffersResponse
+	32, // 60: restaurant.RestaurantService.RespondToDeliveryOffer:output_type -> restaurant.DeliveryOffer
+	36, // 61: restaurant.RestaurantService.GetCart:output_type -> restaurant.Cart
+	36, // 62: restaurant.RestaurantService.AddCartItem:output_type -> restaurant.Cart
+	36, // 63: restaurant.RestaurantService.UpdateCartItem:output_type -> restaurant.Cart
+	36, // 64: restaurant.RestaurantService.RemoveCartItem:output_type -> restaurant.Cart
+	36, // 65: restaurant.RestaurantService.ClearCart:output_type -> restaurant.Cart
+	19, // 66: restaurant.RestaurantService.CheckoutCart:output_type -> restaurant.PlaceOrderResponse
+	41, // [41:67] is the sub-list for method output_type
+	15, // [15:41] is the sub-list for method input_type
+	15, // [15:15] is the sub-list for extension type_name
+	15, // [15:15] is the sub-list for extension extendee
+	0,  // [0:15] is the sub-list for field type_name
 }
 
 func init() { file_restaurant_proto_init() }
@@ -1415,13 +3089,14 @@ func file_restaurant_proto_init() {
 		File: protoimpl.DescBuilder{
 			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
 			RawDescriptor: unsafe.Slice(unsafe.StringData(file_restaurant_proto_rawDesc), len(file_restaurant_proto_rawDesc)),
-			NumEnums:      0,
-			NumMessages:   20,
+			NumEnums:      2,
+			NumMessages:   42,
 			NumExtensions: 0,
 			NumServices:   1,
 		},
 		GoTypes:           file_restaurant_proto_goTypes,
 		DependencyIndexes: file_restaurant_proto_depIdxs,
+		EnumInfos:         file_restaurant_proto_enumTypes,
 		MessageInfos:      file_restaurant_proto_msgTypes,
 	}.Build()
 	File_restaurant_proto = out.File
@@ -19,36 +19,92 @@ import (
 const _ = grpc.SupportPackageIsVersion9
 
 const (
-	RestaurantService_Login_FullMethodName              = "/restaurant.RestaurantService/Login"
-	RestaurantService_RegisterRestaurant_FullMethodName = "/restaurant.RestaurantService/RegisterRestaurant"
-	RestaurantService_GetRestaurant_FullMethodName      = "/restaurant.RestaurantService/GetRestaurant"
-	RestaurantService_ListRestaurants_FullMethodName    = "/restaurant.RestaurantService/ListRestaurants"
-	RestaurantService_AddMenuItem_FullMethodName        = "/restaurant.RestaurantService/AddMenuItem"
-	RestaurantService_RemoveMenuItem_FullMethodName     = "/restaurant.RestaurantService/RemoveMenuItem"
-	RestaurantService_UpdateMenuItem_FullMethodName     = "/restaurant.RestaurantService/UpdateMenuItem"
-	RestaurantService_PlaceOrder_FullMethodName         = "/restaurant.RestaurantService/PlaceOrder"
-	RestaurantService_GetOrders_FullMethodName          = "/restaurant.RestaurantService/GetOrders"
-	RestaurantService_UpdateOrderStatus_FullMethodName  = "/restaurant.RestaurantService/UpdateOrderStatus"
-	RestaurantService_ShipOrder_FullMethodName          = "/restaurant.RestaurantService/ShipOrder"
-	RestaurantService_GetOrder_FullMethodName           = "/restaurant.RestaurantService/GetOrder"
+	RestaurantService_Login_FullMethodName                  = "/restaurant.RestaurantService/Login"
+	RestaurantService_RegisterRestaurant_FullMethodName     = "/restaurant.RestaurantService/RegisterRestaurant"
+	RestaurantService_GetRestaurant_FullMethodName          = "/restaurant.RestaurantService/GetRestaurant"
+	RestaurantService_ListRestaurants_FullMethodName        = "/restaurant.RestaurantService/ListRestaurants"
+	RestaurantService_UpdateRestaurant_FullMethodName       = "/restaurant.RestaurantService/UpdateRestaurant"
+	RestaurantService_DeactivateRestaurant_FullMethodName   = "/restaurant.RestaurantService/DeactivateRestaurant"
+	RestaurantService_ReactivateRestaurant_FullMethodName   = "/restaurant.RestaurantService/ReactivateRestaurant"
+	RestaurantService_AddMenuItem_FullMethodName            = "/restaurant.RestaurantService/AddMenuItem"
+	RestaurantService_RemoveMenuItem_FullMethodName         = "/restaurant.RestaurantService/RemoveMenuItem"
+	RestaurantService_UpdateMenuItem_FullMethodName         = "/restaurant.RestaurantService/UpdateMenuItem"
+	RestaurantService_ImportMenu_FullMethodName             = "/restaurant.RestaurantService/ImportMenu"
+	RestaurantService_ExportMenu_FullMethodName             = "/restaurant.RestaurantService/ExportMenu"
+	RestaurantService_PlaceOrder_FullMethodName             = "/restaurant.RestaurantService/PlaceOrder"
+	RestaurantService_GetOrders_FullMethodName              = "/restaurant.RestaurantService/GetOrders"
+	RestaurantService_UpdateOrderStatus_FullMethodName      = "/restaurant.RestaurantService/UpdateOrderStatus"
+	RestaurantService_ShipOrder_FullMethodName              = "/restaurant.RestaurantService/ShipOrder"
+	RestaurantService_GetOrder_FullMethodName               = "/restaurant.RestaurantService/GetOrder"
+	RestaurantService_WatchOrders_FullMethodName            = "/restaurant.RestaurantService/WatchOrders"
+	RestaurantService_GetDeliveryOffers_FullMethodName      = "/restaurant.RestaurantService/GetDeliveryOffers"
+	RestaurantService_RespondToDeliveryOffer_FullMethodName = "/restaurant.RestaurantService/RespondToDeliveryOffer"
+	RestaurantService_GetCart_FullMethodName                = "/restaurant.RestaurantService/GetCart"
+	RestaurantService_AddCartItem_FullMethodName            = "/restaurant.RestaurantService/AddCartItem"
+	RestaurantService_UpdateCartItem_FullMethodName         = "/restaurant.RestaurantService/UpdateCartItem"
+	RestaurantService_RemoveCartItem_FullMethodName         = "/restaurant.RestaurantService/RemoveCartItem"
+	RestaurantService_ClearCart_FullMethodName              = "/restaurant.RestaurantService/ClearCart"
+	RestaurantService_CheckoutCart_FullMethodName           = "/restaurant.RestaurantService/CheckoutCart"
 )
 
 // RestaurantServiceClient is the client API for RestaurantService service.
 //
 // For semantics around ctx use and closing/ending streaming RPCs, please refer to https://pkg.go.dev/google.golang.org/grpc/?tab=doc#ClientConn.NewStream.
+//
+// RestaurantService provides methods for restaurants to authenticate, manage menus, and handle orders.
 type RestaurantServiceClient interface {
+	// Login authenticates a restaurant using email and secret_key and returns the Restaurant record.
 	Login(ctx context.Context, in *RestaurantLoginRequest, opts ...grpc.CallOption) (*Restaurant, error)
+	// RegisterRestaurant registers a new restaurant and returns the created Restaurant.
 	RegisterRestaurant(ctx context.Context, in *RegisterRestaurantRequest, opts ...grpc.CallOption) (*Restaurant, error)
+	// GetRestaurant returns the Restaurant identified by restaurant_id.
 	GetRestaurant(ctx context.Context, in *GetRestaurantRequest, opts ...grpc.CallOption) (*Restaurant, error)
+	// ListRestaurants streams active restaurants within a radius of the given location.
 	ListRestaurants(ctx context.Context, in *ListRestaurantsRequest, opts ...grpc.CallOption) (grpc.ServerStreamingClient[Restaurant], error)
+	// UpdateRestaurant updates a restaurant's profile and returns the updated Restaurant.
+	UpdateRestaurant(ctx context.Context, in *UpdateRestaurantRequest, opts ...grpc.CallOption) (*Restaurant, error)
+	// DeactivateRestaurant stops a restaurant from being listed and taking orders. Its order history is kept.
+	DeactivateRestaurant(ctx context.Context, in *DeactivateRestaurantRequest, opts ...grpc.CallOption) (*Restaurant, error)
+	// ReactivateRestaurant lists a deactivated restaurant again and lets it take orders.
+	ReactivateRestaurant(ctx context.Context, in *ReactivateRestaurantRequest, opts ...grpc.CallOption) (*Restaurant, error)
+	// AddMenuItem adds a new menu item to the specified restaurant and returns the created MenuItem.
 	AddMenuItem(ctx context.Context, in *AddMenuItemRequest, opts ...grpc.CallOption) (*MenuItem, error)
+	// RemoveMenuItem removes a menu item from the specified restaurant and returns the removed MenuItem.
 	RemoveMenuItem(ctx context.Context, in *RemoveMenuItemRequest, opts ...grpc.CallOption) (*MenuItem, error)
+	// UpdateMenuItem updates an existing menu item and returns the updated MenuItem.
 	UpdateMenuItem(ctx context.Context, in *UpdateMenuItemRequest, opts ...grpc.CallOption) (*MenuItem, error)
+	// ImportMenu validates a CSV or JSON menu and upserts its items by name in a single transaction.
+	ImportMenu(ctx context.Context, in *ImportMenuRequest, opts ...grpc.CallOption) (*ImportMenuResponse, error)
+	// ExportMenu returns the restaurant's menu encoded as CSV or JSON.
+	ExportMenu(ctx context.Context, in *ExportMenuRequest, opts ...grpc.CallOption) (*ExportMenuResponse, error)
+	// PlaceOrder places a new order for a restaurant and returns order details.
 	PlaceOrder(ctx context.Context, in *PlaceOrderRequest, opts ...grpc.CallOption) (*PlaceOrderResponse, error)
+	// GetOrders returns all orders for a restaurant.
 	GetOrders(ctx context.Context, in *GetOrdersRequest, opts ...grpc.CallOption) (*GetOrdersResponse, error)
+	// UpdateOrderStatus updates the status of an order and returns the updated Order.
 	UpdateOrderStatus(ctx context.Context, in *UpdateOrderStatusRequest, opts ...grpc.CallOption) (*Order, error)
+	// ShipOrder marks an order as shipped and offers the delivery to the nearest available driver.
 	ShipOrder(ctx context.Context, in *ShipOrderRequest, opts ...grpc.CallOption) (*ShipOrderResponse, error)
+	// GetOrder returns a single Order by order_id.
 	GetOrder(ctx context.Context, in *GetOrderRequest, opts ...grpc.CallOption) (*Order, error)
+	// WatchOrders streams the restaurant's open orders and then every new order and status change.
+	WatchOrders(ctx context.Context, in *WatchOrdersRequest, opts ...grpc.CallOption) (grpc.ServerStreamingClient[OrderFeedEvent], error)
+	// GetDeliveryOffers returns the open delivery offers for a driver.
+	GetDeliveryOffers(ctx context.Context, in *GetDeliveryOffersRequest, opts ...grpc.CallOption) (*GetDeliveryOffersResponse, error)
+	// RespondToDeliveryOffer accepts or declines a delivery offer and returns the updated DeliveryOffer.
+	RespondToDeliveryOffer(ctx context.Context, in *RespondToDeliveryOfferRequest, opts ...grpc.CallOption) (*DeliveryOffer, error)
+	// GetCart returns the customer's cart revalidated against the current menu.
+	GetCart(ctx context.Context, in *GetCartRequest, opts ...grpc.CallOption) (*Cart, error)
+	// AddCartItem adds an item to the cart, or raises its quantity if it is already there.
+	AddCartItem(ctx context.Context, in *AddCartItemRequest, opts ...grpc.CallOption) (*Cart, error)
+	// UpdateCartItem sets the quantity of a cart item; a quantity of zero removes it.
+	UpdateCartItem(ctx context.Context, in *UpdateCartItemRequest, opts ...grpc.CallOption) (*Cart, error)
+	// RemoveCartItem removes an item from the cart.
+	RemoveCartItem(ctx context.Context, in *RemoveCartItemRequest, opts ...grpc.CallOption) (*Cart, error)
+	// ClearCart empties the cart.
+	ClearCart(ctx context.Context, in *ClearCartRequest, opts ...grpc.CallOption) (*Cart, error)
+	// CheckoutCart places an order from the cart and empties it.
+	CheckoutCart(ctx context.Context, in *CheckoutCartRequest, opts ...grpc.CallOption) (*PlaceOrderResponse, error)
 }
 
 type restaurantServiceClient struct {
@@ -108,6 +164,36 @@ func (c *restaurantServiceClient) ListRestaurants(ctx context.Context, in *ListR
 // This type alias is provided for backwards compatibility with existing code that references the prior non-generic stream type by name.
 type RestaurantService_ListRestaurantsClient = grpc.ServerStreamingClient[Restaurant]
 
+func (c *restaurantServiceClient) UpdateRestaurant(ctx context.Context, in *UpdateRestaurantRequest, opts ...grpc.CallOption) (*Restaurant, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(Restaurant)
+	err := c.cc.Invoke(ctx, RestaurantService_UpdateRestaurant_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *restaurantServiceClient) DeactivateRestaurant(ctx context.Context, in *DeactivateRestaurantRequest, opts ...grpc.CallOption) (*Restaurant, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(Restaurant)
+	err := c.cc.Invoke(ctx, RestaurantService_DeactivateRestaurant_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *restaurantServiceClient) ReactivateRestaurant(ctx context.Context, in *ReactivateRestaurantRequest, opts ...grpc.CallOption) (*Restaurant, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(Restaurant)
+	err := c.cc.Invoke(ctx, RestaurantService_ReactivateRestaurant_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
 func (c *restaurantServiceClient) AddMenuItem(ctx context.Context, in *AddMenuItemRequest, opts ...grpc.CallOption) (*MenuItem, error) {
 	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
 	out := new(MenuItem)
@@ -138,6 +224,26 @@ func (c *restaurantServiceClient) UpdateMenuItem(ctx context.Context, in *Update
 	return out, nil
 }
 
+func (c *restaurantServiceClient) ImportMenu(ctx context.Context, in *ImportMenuRequest, opts ...grpc.CallOption) (*ImportMenuResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(ImportMenuResponse)
+	err := c.cc.Invoke(ctx, RestaurantService_ImportMenu_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *restaurantServiceClient) ExportMenu(ctx context.Context, in *ExportMenuRequest, opts ...grpc.CallOption) (*ExportMenuResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(ExportMenuResponse)
+	err := c.cc.Invoke(ctx, RestaurantService_ExportMenu_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
 func (c *restaurantServiceClient) PlaceOrder(ctx context.Context, in *PlaceOrderRequest, opts ...grpc.CallOption) (*PlaceOrderResponse, error) {
 	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
 	out := new(PlaceOrderResponse)
@@ -188,22 +294,163 @@ func (c *restaurantServiceClient) GetOrder(ctx context.Context, in *GetOrderRequ
 	return out, nil
 }
 
+func (c *restaurantServiceClient) WatchOrders(ctx context.Context, in *WatchOrdersRequest, opts ...grpc.CallOption) (grpc.ServerStreamingClient[OrderFeedEvent], error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	stream, err := c.cc.NewStream(ctx, &RestaurantService_ServiceDesc.Streams[1], RestaurantService_WatchOrders_FullMethodName, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	x := &grpc.GenericClientStream[WatchOrdersRequest, OrderFeedEvent]{ClientStream: stream}
+	if err := x.ClientStream.SendMsg(in); err != nil {
+		return nil, err
+	}
+	if err := x.ClientStream.CloseSend(); err != nil {
+		return nil, err
+	}
+	return x, nil
+}
+
+// This type alias is provided for backwards compatibility with existing code that references the prior non-generic stream type by name.
+type RestaurantService_WatchOrdersClient = grpc.ServerStreamingClient[OrderFeedEvent]
+
+func (c *restaurantServiceClient) GetDeliveryOffers(ctx context.Context, in *GetDeliveryOffersRequest, opts ...grpc.CallOption) (*GetDeliveryOffersResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(GetDeliveryOffersResponse)
+	err := c.cc.Invoke(ctx, RestaurantService_GetDeliveryOffers_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *restaurantServiceClient) RespondToDeliveryOffer(ctx context.Context, in *RespondToDeliveryOfferRequest, opts ...grpc.CallOption) (*DeliveryOffer, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(DeliveryOffer)
+	err := c.cc.Invoke(ctx, RestaurantService_RespondToDeliveryOffer_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *restaurantServiceClient) GetCart(ctx context.Context, in *GetCartRequest, opts ...grpc.CallOption) (*Cart, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(Cart)
+	err := c.cc.Invoke(ctx, RestaurantService_GetCart_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *restaurantServiceClient) AddCartItem(ctx context.Context, in *AddCartItemRequest, opts ...grpc.CallOption) (*Cart, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(Cart)
+	err := c.cc.Invoke(ctx, RestaurantService_AddCartItem_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *restaurantServiceClient) UpdateCartItem(ctx context.Context, in *UpdateCartItemRequest, opts ...grpc.CallOption) (*Cart, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(Cart)
+	err := c.cc.Invoke(ctx, RestaurantService_UpdateCartItem_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *restaurantServiceClient) RemoveCartItem(ctx context.Context, in *RemoveCartItemRequest, opts ...grpc.CallOption) (*Cart, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(Cart)
+	err := c.cc.Invoke(ctx, RestaurantService_RemoveCartItem_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *restaurantServiceClient) ClearCart(ctx context.Context, in *ClearCartRequest, opts ...grpc.CallOption) (*Cart, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(Cart)
+	err := c.cc.Invoke(ctx, RestaurantService_ClearCart_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *restaurantServiceClient) CheckoutCart(ctx context.Context, in *CheckoutCartRequest, opts ...grpc.CallOption) (*PlaceOrderResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(PlaceOrderResponse)
+	err := c.cc.Invoke(ctx, RestaurantService_CheckoutCart_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
 // RestaurantServiceServer is the server API for RestaurantService service.
 // All implementations must embed UnimplementedRestaurantServiceServer
 // for forward compatibility.
+//
+// RestaurantService provides methods for restaurants to authenticate, manage menus, and handle orders.
 type RestaurantServiceServer interface {
+	// Login authenticates a restaurant using email and secret_key and returns the Restaurant record.
 	Login(context.Context, *RestaurantLoginRequest) (*Restaurant, error)
+	// RegisterRestaurant registers a new restaurant and returns the created Restaurant.
 	RegisterRestaurant(context.Context, *RegisterRestaurantRequest) (*Restaurant, error)
+	// GetRestaurant returns the Restaurant identified by restaurant_id.
 	GetRestaurant(context.Context, *GetRestaurantRequest) (*Restaurant, error)
+	// ListRestaurants streams active restaurants within a radius of the given location.
 	ListRestaurants(*ListRestaurantsRequest, grpc.ServerStreamingServer[Restaurant]) error
+	// UpdateRestaurant updates a restaurant's profile and returns the updated Restaurant.
+	UpdateRestaurant(context.Context, *UpdateRestaurantRequest) (*Restaurant, error)
+	// DeactivateRestaurant stops a restaurant from being listed and taking orders. Its order history is kept.
+	DeactivateRestaurant(context.Context, *DeactivateRestaurantRequest) (*Restaurant, error)
+	// ReactivateRestaurant lists a deactivated restaurant again and lets it take orders.
+	ReactivateRestaurant(context.Context, *ReactivateRestaurantRequest) (*Restaurant, error)
+	// AddMenuItem adds a new menu item to the specified restaurant and returns the created MenuItem.
 	AddMenuItem(context.Context, *AddMenuItemRequest) (*MenuItem, error)
+	// RemoveMenuItem removes a menu item from the specified restaurant and returns the removed MenuItem.
 	RemoveMenuItem(context.Context, *RemoveMenuItemRequest) (*MenuItem, error)
+	// UpdateMenuItem updates an existing menu item and returns the updated MenuItem.
 	UpdateMenuItem(context.Context, *UpdateMenuItemRequest) (*MenuItem, error)
+	// ImportMenu validates a CSV or JSON menu and upserts its items by name in a single transaction.
+	ImportMenu(context.Context, *ImportMenuRequest) (*ImportMenuResponse, error)
+	// ExportMenu returns the restaurant's menu encoded as CSV or JSON.
+	ExportMenu(context.Context, *ExportMenuRequest) (*ExportMenuResponse, error)
+	// PlaceOrder places a new order for a restaurant and returns order details.
 	PlaceOrder(context.Context, *PlaceOrderRequest) (*PlaceOrderResponse, error)
+	// GetOrders returns all orders for a restaurant.
 	GetOrders(context.Context, *GetOrdersRequest) (*GetOrdersResponse, error)
+	// UpdateOrderStatus updates the status of an order and returns the updated Order.
 	UpdateOrderStatus(context.Context, *UpdateOrderStatusRequest) (*Order, error)
+	// ShipOrder marks an order as shipped and offers the delivery to the nearest available driver.
 	ShipOrder(context.Context, *ShipOrderRequest) (*ShipOrderResponse, error)
+	// GetOrder returns a single Order by order_id.
 	GetOrder(context.Context, *GetOrderRequest) (*Order, error)
+	// WatchOrders streams the restaurant's open orders and then every new order and status change.
+	WatchOrders(*WatchOrdersRequest, grpc.ServerStreamingServer[OrderFeedEvent]) error
+	// GetDeliveryOffers returns the open delivery offers for a driver.
+	GetDeliveryOffers(context.Context, *GetDeliveryOffersRequest) (*GetDeliveryOffersResponse, error)
+	// RespondToDeliveryOffer accepts or declines a delivery offer and returns the updated DeliveryOffer.
+	RespondToDeliveryOffer(context.Context, *RespondToDeliveryOfferRequest) (*DeliveryOffer, error)
+	// GetCart returns the customer's cart revalidated against the current menu.
+	GetCart(context.Context, *GetCartRequest) (*Cart, error)
+	// AddCartItem adds an item to the cart, or raises its quantity if it is already there.
+	AddCartItem(context.Context, *AddCartItemRequest) (*Cart, error)
+	// UpdateCartItem sets the quantity of a cart item; a quantity of zero removes it.
+	UpdateCartItem(context.Context, *UpdateCartItemRequest) (*Cart, error)
+	// RemoveCartItem removes an item from the cart.
+	RemoveCartItem(context.Context, *RemoveCartItemRequest) (*Cart, error)
+	// ClearCart empties the cart.
+	ClearCart(context.Context, *ClearCartRequest) (*Cart, error)
+	// CheckoutCart places an order from the cart and empties it.
+	CheckoutCart(context.Context, *CheckoutCartRequest) (*PlaceOrderResponse, error)
 	mustEmbedUnimplementedRestaurantServiceServer()
 }
 
@@ -226,6 +473,15 @@ func (UnimplementedRestaurantServiceServer) GetRestaurant(context.Context, *GetR
 func (UnimplementedRestaurantServiceServer) ListRestaurants(*ListRestaurantsRequest, grpc.ServerStreamingServer[Restaurant]) error {
 	return status.Error(codes.Unimplemented, "method ListRestaurants not implemented")
 }
+func (UnimplementedRestaurantServiceServer) UpdateRestaurant(context.Context, *UpdateRestaurantRequest) (*Restaurant, error) {
+	return nil, status.Error(codes.Unimplemented, "method UpdateRestaurant not implemented")
+}
+func (UnimplementedRestaurantServiceServer) DeactivateRestaurant(context.Context, *DeactivateRestaurantRequest) (*Restaurant, error) {
+	return nil, status.Error(codes.Unimplemented, "method DeactivateRestaurant not implemented")
+}
+func (UnimplementedRestaurantServiceServer) ReactivateRestaurant(context.Context, *ReactivateRestaurantRequest) (*Restaurant, error) {
+	return nil, status.Error(codes.Unimplemented, "method ReactivateRestaurant not implemented")
+}
 func (UnimplementedRestaurantServiceServer) AddMenuItem(context.Context, *AddMenuItemRequest) (*MenuItem, error) {
 	return nil, status.Error(codes.Unimplemented, "method AddMenuItem not implemented")
 }
@@ -235,6 +491,12 @@ func (UnimplementedRestaurantServiceServer) RemoveMenuItem(context.Context, *Rem
 func (UnimplementedRestaurantServiceServer) UpdateMenuItem(context.Context, *UpdateMenuItemRequest) (*MenuItem, error) {
 	return nil, status.Error(codes.Unimplemented, "method UpdateMenuItem not implemented")
 }
+func (UnimplementedRestaurantServiceServer) ImportMenu(context.Context, *ImportMenuRequest) (*ImportMenuResponse, error) {
+	return nil, status.Error(codes.Unimplemented, "method ImportMenu not implemented")
+}
+func (UnimplementedRestaurantServiceServer) ExportMenu(context.Context, *ExportMenuRequest) (*ExportMenuResponse, error) {
+	return nil, status.Error(codes.Unimplemented, "method ExportMenu not implemented")
+}
 func (UnimplementedRestaurantServiceServer) PlaceOrder(context.Context, *PlaceOrderRequest) (*PlaceOrderResponse, error) {
 	return nil, status.Error(codes.Unimplemented, "method PlaceOrder not implemented")
 }
@@ -250,6 +512,33 @@ func (UnimplementedRestaurantServiceServer) ShipOrder(context.Context, *ShipOrde
 func (UnimplementedRestaurantServiceServer) GetOrder(context.Context, *GetOrderRequest) (*Order, error) {
 	return nil, status.Error(codes.Unimplemented, "method GetOrder not implemented")
 }
+func (UnimplementedRestaurantServiceServer) WatchOrders(*WatchOrdersRequest, grpc.ServerStreamingServer[OrderFeedEvent]) error {
+	return status.Error(codes.Unimplemented, "method WatchOrders not implemented")
+}
+func (UnimplementedRestaurantServiceServer) GetDeliveryOffers(context.Context, *GetDeliveryOffersRequest) (*GetDeliveryOffersResponse, error) {
+	return nil, status.Error(codes.Unimplemented, "method GetDeliveryOffers not implemented")
+}
+func (UnimplementedRestaurantServiceServer) RespondToDeliveryOffer(context.Context, *RespondToDeliveryOfferRequest) (*DeliveryOffer, error) {
+	return nil, status.Error(codes.Unimplemented, "method RespondToDeliveryOffer not implemented")
+}
+func (UnimplementedRestaurantServiceServer) GetCart(context.Context, *GetCartRequest) (*Cart, error) {
+	return nil, status.Error(codes.Unimplemented, "method GetCart not implemented")
+}
+func (UnimplementedRestaurantServiceServer) AddCartItem(context.Context, *AddCartItemRequest) (*Cart, error) {
+	return nil, status.Error(codes.Unimplemented, "method AddCartItem not implemented")
+}
+func (UnimplementedRestaurantServiceServer) UpdateCartItem(context.Context, *UpdateCartItemRequest) (*Cart, error) {
+	return nil, status.Error(codes.Unimplemented, "method UpdateCartItem not implemented")
+}
+func (UnimplementedRestaurantServiceServer) RemoveCartItem(context.Context, *RemoveCartItemRequest) (*Cart, error) {
+	return nil, status.Error(codes.Unimplemented, "method RemoveCartItem not implemented")
+}
+func (UnimplementedRestaurantServiceServer) ClearCart(context.Context, *ClearCartRequest) (*Cart, error) {
+	return nil, status.Error(codes.Unimplemented, "method ClearCart not implemented")
+}
+func (UnimplementedRestaurantServiceServer) CheckoutCart(context.Context, *CheckoutCartRequest) (*PlaceOrderResponse, error) {
+	return nil, status.Error(codes.Unimplemented, "method CheckoutCart not implemented")
+}
 func (UnimplementedRestaurantServiceServer) mustEmbedUnimplementedRestaurantServiceServer() {}
 func (UnimplementedRestaurantServiceServer) testEmbeddedByValue()                           {}
 
@@ -336,6 +625,60 @@ func _RestaurantService_ListRestaurants_Handler(srv interface{}, stream grpc.Ser
 // This type alias is provided for backwards compatibility with existing code that references the prior non-generic stream type by name.
 type RestaurantService_ListRestaurantsServer = grpc.ServerStreamingServer[Restaurant]
 
+func _RestaurantService_UpdateRestaurant_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(UpdateRestaurantRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(RestaurantServiceServer).UpdateRestaurant(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: RestaurantService_UpdateRestaurant_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(RestaurantServiceServer).UpdateRestaurant(ctx, req.(*UpdateRestaurantRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _RestaurantService_DeactivateRestaurant_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(DeactivateRestaurantRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(RestaurantServiceServer).DeactivateRestaurant(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: RestaurantService_DeactivateRestaurant_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(RestaurantServiceServer).DeactivateRestaurant(ctx, req.(*DeactivateRestaurantRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _RestaurantService_ReactivateRestaurant_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ReactivateRestaurantRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(RestaurantServiceServer).ReactivateRestaurant(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: RestaurantService_ReactivateRestaurant_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(RestaurantServiceServer).ReactivateRestaurant(ctx, req.(*ReactivateRestaurantRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
 func _RestaurantService_AddMenuItem_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
 	in := new(AddMenuItemRequest)
 	if err := dec(in); err != nil {
@@ -390,6 +733,42 @@ func _RestaurantService_UpdateMenuItem_Handler(srv interface{}, ctx context.Cont
 	return interceptor(ctx, in, info, handler)
 }
 
+func _RestaurantService_ImportMenu_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ImportMenuRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(RestaurantServiceServer).ImportMenu(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: RestaurantService_ImportMenu_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(RestaurantServiceServer).ImportMenu(ctx, req.(*ImportMenuRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _RestaurantService_ExportMenu_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ExportMenuRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(RestaurantServiceServer).ExportMenu(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: RestaurantService_ExportMenu_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(RestaurantServiceServer).ExportMenu(ctx, req.(*ExportMenuRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
 func _RestaurantService_PlaceOrder_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
 	in := new(PlaceOrderRequest)
 	if err := dec(in); err != nil {
@@ -480,6 +859,161 @@ func _RestaurantService_GetOrder_Handler(srv interface{}, ctx context.Context, d
 	return interceptor(ctx, in, info, handler)
 }
 
+func _RestaurantService_WatchOrders_Handler(srv interface{}, stream grpc.ServerStream) error {
+	m := new(WatchOrdersRequest)
+	if err := stream.RecvMsg(m); err != nil {
+		return err
+	}
+	return srv.(RestaurantServiceServer).WatchOrders(m, &grpc.GenericServerStream[WatchOrdersRequest, OrderFeedEvent]{ServerStream: stream})
+}
+
+// This type alias is provided for backwards compatibility with existing code that references the prior non-generic stream type by name.
+type RestaurantService_WatchOrdersServer = grpc.ServerStreamingServer[OrderFeedEvent]
+
+func _RestaurantService_GetDeliveryOffers_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetDeliveryOffersRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(RestaurantServiceServer).GetDeliveryOffers(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: RestaurantService_GetDeliveryOffers_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(RestaurantServiceServer).GetDeliveryOffers(ctx, req.(*GetDeliveryOffersRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _RestaurantService_RespondToDeliveryOffer_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(RespondToDeliveryOfferRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(RestaurantServiceServer).RespondToDeliveryOffer(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: RestaurantService_RespondToDeliveryOffer_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(RestaurantServiceServer).RespondToDeliveryOffer(ctx, req.(*RespondToDeliveryOfferRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _RestaurantService_GetCart_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetCartRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(RestaurantServiceServer).GetCart(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: RestaurantService_GetCart_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(RestaurantServiceServer).GetCart(ctx, req.(*GetCartRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _RestaurantService_AddCartItem_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(AddCartItemRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(RestaurantServiceServer).AddCartItem(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: RestaurantService_AddCartItem_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(RestaurantServiceServer).AddCartItem(ctx, req.(*AddCartItemRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _RestaurantService_UpdateCartItem_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(UpdateCartItemRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(RestaurantServiceServer).UpdateCartItem(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: RestaurantService_UpdateCartItem_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(RestaurantServiceServer).UpdateCartItem(ctx, req.(*UpdateCartItemRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _RestaurantService_RemoveCartItem_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(RemoveCartItemRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(RestaurantServiceServer).RemoveCartItem(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: RestaurantService_RemoveCartItem_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(RestaurantServiceServer).RemoveCartItem(ctx, req.(*RemoveCartItemRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _RestaurantService_ClearCart_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ClearCartRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(RestaurantServiceServer).ClearCart(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: RestaurantService_ClearCart_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(RestaurantServiceServer).ClearCart(ctx, req.(*ClearCartRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _RestaurantService_CheckoutCart_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(CheckoutCartRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(RestaurantServiceServer).CheckoutCart(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: RestaurantService_CheckoutCart_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(RestaurantServiceServer).CheckoutCart(ctx, req.(*CheckoutCartRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
 // RestaurantService_ServiceDesc is the grpc.ServiceDesc for RestaurantService service.
 // It's only intended for direct use with grpc.RegisterService,
 // and not to be introspected or modified (even as a copy)
@@ -499,6 +1033,18 @@ var RestaurantService_ServiceDesc = grpc.ServiceDesc{
 			MethodName: "GetRestaurant",
 			Handler:    _RestaurantService_GetRestaurant_Handler,
 		},
+		{
+			MethodName: "UpdateRestaurant",
+			Handler:    _RestaurantService_UpdateRestaurant_Handler,
+		},
+		{
+			MethodName: "DeactivateRestaurant",
+			Handler:    _RestaurantService_DeactivateRestaurant_Handler,
+		},
+		{
+			MethodName: "ReactivateRestaurant",
+			Handler:    _RestaurantService_ReactivateRestaurant_Handler,
+		},
 		{
 			MethodName: "AddMenuItem",
 			Handler:    _RestaurantService_AddMenuItem_Handler,
@@ -511,6 +1057,14 @@ var RestaurantService_ServiceDesc = grpc.ServiceDesc{
 			MethodName: "UpdateMenuItem",
 			Handler:    _RestaurantService_UpdateMenuItem_Handler,
 		},
+		{
+			MethodName: "ImportMenu",
+			Handler:    _RestaurantService_ImportMenu_Handler,
+		},
+		{
+			MethodName: "ExportMenu",
+			Handler:    _RestaurantService_ExportMenu_Handler,
+		},
 		{
 			MethodName: "PlaceOrder",
 			Handler:    _RestaurantService_PlaceOrder_Handler,
@@ -531,6 +1085,38 @@ var RestaurantService_ServiceDesc = grpc.ServiceDesc{
 			MethodName: "GetOrder",
 			Handler:    _RestaurantService_GetOrder_Handler,
 		},
+		{
+			MethodName: "GetDeliveryOffers",
+			Handler:    _RestaurantService_GetDeliveryOffers_Handler,
+		},
+		{
+			MethodName: "RespondToDeliveryOffer",
+			Handler:    _RestaurantService_RespondToDeliveryOffer_Handler,
+		},
+		{
+			MethodName: "GetCart",
+			Handler:    _RestaurantService_GetCart_Handler,
+		},
+		{
+			MethodName: "AddCartItem",
+			Handler:    _RestaurantService_AddCartItem_Handler,
+		},
+		{
+			MethodName: "UpdateCartItem",
+			Handler:    _RestaurantService_UpdateCartItem_Handler,
+		},
+		{
+			MethodName: "RemoveCartItem",
+			Handler:    _RestaurantService_RemoveCartItem_Handler,
+		},
+		{
+			MethodName: "ClearCart",
+			Handler:    _RestaurantService_ClearCart_Handler,
+		},
+		{
+			MethodName: "CheckoutCart",
+			Handler:    _RestaurantService_CheckoutCart_Handler,
+		},
 	},
 	Streams: []grpc.StreamDesc{
 		{
@@ -538,6 +1124,11 @@ var RestaurantService_ServiceDesc = grpc.ServiceDesc{
 			Handler:       _RestaurantService_ListRestaurants_Handler,
 			ServerStreams: true,
 		},
+		{
+			StreamName:    "WatchOrders",
+			Handler:       _RestaurantService_WatchOrders_Handler,
+			ServerStreams: true,
+		},
 	},
 	Metadata: "restaurant.proto",
 }
@@ -0,0 +1,249 @@
+// Code generated by protoc-gen-go. DO NOT EDIT.
+// versions:
+// 	protoc-gen-go v1.36.10
+// 	protoc        v3.21.12
+// source: user_data.proto
+
+package userdatapb
+
+import (
+	protoreflect "google.golang.org/protobuf/reflect/protoreflect"
+	protoimpl "google.golang.org/protobuf/runtime/protoimpl"
+	reflect "reflect"
+	sync "sync"
+	unsafe "unsafe"
+)
+
+const (
+	// Verify that this generated code is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(20 - protoimpl.MinVersion)
+	// Verify that runtime/protoimpl is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(protoimpl.MaxVersion - 20)
+)
+
+// UserDataRequested asks every service holding data of a user to delete or
+// export it, depending on the event it is published as.
+type UserDataRequested struct {
+	state     protoimpl.MessageState `protogen:"open.v1"`
+	RequestId string                 `protobuf:"bytes,1,opt,name=request_id,json=requestId,proto3" json:"request_id,omitempty"`
+	UserId    string                 `protobuf:"bytes,2,opt,name=user_id,json=userId,proto3" json:"user_id,omitempty"`
+	// Set when the user is a driver.
+	DriverId        string `protobuf:"bytes,3,opt,name=driver_id,json=driverId,proto3" json:"driver_id,omitempty"`
+	RequestedAtUnix int64  `protobuf:"varint,4,opt,name=requested_at_unix,json=requestedAtUnix,proto3" json:"requested_at_unix,omitempty"`
+	unknownFields   protoimpl.UnknownFields
+	sizeCache       protoimpl.SizeCache
+}
+
+func (x *UserDataRequested) Reset() {
+	*x = UserDataRequested{}
+	mi := &file_user_data_proto_msgTypes[0]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *UserDataRequested) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*UserDataRequested) ProtoMessage() {}
+
+func (x *UserDataRequested) ProtoReflect() protoreflect.Message {
+	mi := &file_user_data_proto_msgTypes[0]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use UserDataRequested.ProtoReflect.Descriptor instead.
+func (*UserDataRequested) Descriptor() ([]byte, []int) {
+	return file_user_data_proto_rawDescGZIP(), []int{0}
+}
+
+func (x *UserDataRequested) GetRequestId() string {
+	if x != nil {
+		return x.RequestId
+	}
+	return ""
+}
+
+func (x *UserDataRequested) GetUserId() string {
+	if x != nil {
+		return x.UserId
+	}
+	return ""
+}
+
+func (x *UserDataRequested) GetDriverId() string {
+	if x != nil {
+		return x.DriverId
+	}
+	return ""
+}
+
+func (x *UserDataRequested) GetRequestedAtUnix() int64 {
+	if x != nil {
+		return x.RequestedAtUnix
+	}
+	return 0
+}
+
+// UserDataProcessed reports that a service is done with a UserDataRequested.
+type UserDataProcessed struct {
+	state     protoimpl.MessageState `protogen:"open.v1"`
+	RequestId string                 `protobuf:"bytes,1,opt,name=request_id,json=requestId,proto3" json:"request_id,omitempty"`
+	UserId    string                 `protobuf:"bytes,2,opt,name=user_id,json=userId,proto3" json:"user_id,omitempty"`
+	Service   string                 `protobuf:"bytes,3,opt,name=service,proto3" json:"service,omitempty"`
+	// The user's data held by the service as JSON, for exports.
+	Data []byte `protobuf:"bytes,4,opt,name=data,proto3" json:"data,omitempty"`
+	// Why the service could not process the request, empty on success.
+	Error           string `protobuf:"bytes,5,opt,name=error,proto3" json:"error,omitempty"`
+	ProcessedAtUnix int64  `protobuf:"varint,6,opt,name=processed_at_unix,json=processedAtUnix,proto3" json:"processed_at_unix,omitempty"`
+	unknownFields   protoimpl.UnknownFields
+	sizeCache       protoimpl.SizeCache
+}
+
+func (x *UserDataProcessed) Reset() {
+	*x = UserDataProcessed{}
+	mi := &file_user_data_proto_msgTypes[1]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *UserDataProcessed) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*UserDataProcessed) ProtoMessage() {}
+
+func (x *UserDataProcessed) ProtoReflect() protoreflect.Message {
+	mi := &file_user_data_proto_msgTypes[1]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use UserDataProcessed.ProtoReflect.Descriptor instead.
+func (*UserDataProcessed) Descriptor() ([]byte, []int) {
+	return file_user_data_proto_rawDescGZIP(), []int{1}
+}
+
+func (x *UserDataProcessed) GetRequestId() string {
+	if x != nil {
+		return x.RequestId
+	}
+	return ""
+}
+
+func (x *UserDataProcessed) GetUserId() string {
+	if x != nil {
+		return x.UserId
+	}
+	return ""
+}
+
+func (x *UserDataProcessed) GetService() string {
+	if x != nil {
+		return x.Service
+	}
+	return ""
+}
+
+func (x *UserDataProcessed) GetData() []byte {
+	if x != nil {
+		return x.Data
+	}
+	return nil
+}
+
+func (x *UserDataProcessed) GetError() string {
+	if x != nil {
+		return x.Error
+	}
+	return ""
+}
+
+func (x *UserDataProcessed) GetProcessedAtUnix() int64 {
+	if x != nil {
+		return x.ProcessedAtUnix
+	}
+	return 0
+}
+
+var File_user_data_proto protoreflect.FileDescriptor
+
+const file_user_data_proto_rawDesc = "" +
+	"\n" +
+	"\x0fuser_data.proto\x12\tuser_data\"\x94\x01\n" +
+	"\x11UserDataRequested\x12\x1d\n" +
+	"\n" +
+	"request_id\x18\x01 \x01(\tR\trequestId\x12\x17\n" +
+	"\auser_id\x18\x02 \x01(\tR\x06userId\x12\x1b\n" +
+	"\tdriver_id\x18\x03 \x01(\tR\bdriverId\x12*\n" +
+	"\x11requested_at_unix\x18\x04 \x01(\x03R\x0frequestedAtUnix\"\xbb\x01\n" +
+	"\x11UserDataProcessed\x12\x1d\n" +
+	"\n" +
+	"request_id\x18\x01 \x01(\tR\trequestId\x12\x17\n" +
+	"\auser_id\x18\x02 \x01(\tR\x06userId\x12\x18\n" +
+	"\aservice\x18\x03 \x01(\tR\aservice\x12\x12\n" +
+	"\x04data\x18\x04 \x01(\fR\x04data\x12\x14\n" +
+	"\x05error\x18\x05 \x01(\tR\x05error\x12*\n" +
+	"\x11processed_at_unix\x18\x06 \x01(\x03R\x0fprocessedAtUnixBIZGgithub.com/tamirat-dejene/ha-soranu/shared/protos/userdatapb;userdatapbb\x06proto3"
+
+var (
+	file_user_data_proto_rawDescOnce sync.Once
+	file_user_data_proto_rawDescData []byte
+)
+
+func file_user_data_proto_rawDescGZIP() []byte {
+	file_user_data_proto_rawDescOnce.Do(func() {
+		file_user_data_proto_rawDescData = protoimpl.X.CompressGZIP(unsafe.Slice(unsafe.StringData(file_user_data_proto_rawDesc), len(file_user_data_proto_rawDesc)))
+	})
+	return file_user_data_proto_rawDescData
+}
+
+var file_user_data_proto_msgTypes = make([]protoimpl.MessageInfo, 2)
+var file_user_data_proto_goTypes = []any{
+	(*UserDataRequested)(nil), // 0: user_data.UserDataRequested
+	(*UserDataProcessed)(nil), // 1: user_data.UserDataProcessed
+}
+var file_user_data_proto_depIdxs = []int32{
+	0, // [0:0] is the sub-list for method output_type
+	0, // [0:0] is the sub-list for method input_type
+	0, // [0:0] is the sub-list for extension type_name
+	0, // [0:0] is the sub-list for extension extendee
+	0, // [0:0] is the sub-list for field type_name
+}
+
+func init() { file_user_data_proto_init() }
+func file_user_data_proto_init() {
+	if File_user_data_proto != nil {
+		return
+	}
+	type x struct{}
+	out := protoimpl.TypeBuilder{
+		File: protoimpl.DescBuilder{
+			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
+			RawDescriptor: unsafe.Slice(unsafe.StringData(file_user_data_proto_rawDesc), len(file_user_data_proto_rawDesc)),
+			NumEnums:      0,
+			NumMessages:   2,
+			NumExtensions: 0,
+			NumServices:   0,
+		},
+		GoTypes:           file_user_data_proto_goTypes,
+		DependencyIndexes: file_user_data_proto_depIdxs,
+		MessageInfos:      file_user_data_proto_msgTypes,
+	}.Build()
+	File_user_data_proto = out.File
+	file_user_data_proto_goTypes = nil
+	file_user_data_proto_depIdxs = nil
+}
@@ -24,16 +24,20 @@ const (
 
 // Address represents a user's address details.
 type Address struct {
-	state         protoimpl.MessageState `protogen:"open.v1"`
-	AddressId     string                 `protobuf:"bytes,1,opt,name=address_id,json=addressId,proto3" json:"address_id,omitempty"`
-	Street        string                 `protobuf:"bytes,2,opt,name=street,proto3" json:"street,omitempty"`
-	City          string                 `protobuf:"bytes,3,opt,name=city,proto3" json:"city,omitempty"`
-	State         string                 `protobuf:"bytes,4,opt,name=state,proto3" json:"state,omitempty"`
-	PostalCode    uint32                 `protobuf:"varint,5,opt,name=postal_code,json=postalCode,proto3" json:"postal_code,omitempty"`
-	Country       string                 `protobuf:"bytes,6,opt,name=country,proto3" json:"country,omitempty"`
-	Latitude      float32                `protobuf:"fixed32,7,opt,name=latitude,proto3" json:"latitude,omitempty"`
-	Longitude     float32                `protobuf:"fixed32,8,opt,name=longitude,proto3" json:"longitude,omitempty"`
-	CreatedAt     *timestamppb.Timestamp `protobuf:"bytes,9,opt,name=created_at,json=createdAt,proto3" json:"created_at,omitempty"`
+	state      protoimpl.MessageState `protogen:"open.v1"`
+	AddressId  string                 `protobuf:"bytes,1,opt,name=address_id,json=addressId,proto3" json:"address_id,omitempty"`
+	Street     string                 `protobuf:"bytes,2,opt,name=street,proto3" json:"street,omitempty"`
+	City       string                 `protobuf:"bytes,3,opt,name=city,proto3" json:"city,omitempty"`
+	State      string                 `protobuf:"bytes,4,opt,name=state,proto3" json:"state,omitempty"`
+	PostalCode uint32                 `protobuf:"varint,5,opt,name=postal_code,json=postalCode,proto3" json:"postal_code,omitempty"`
+	Country    string                 `protobuf:"bytes,6,opt,name=country,proto3" json:"country,omitempty"`
+	Latitude   float32                `protobuf:"fixed32,7,opt,name=latitude,proto3" json:"latitude,omitempty"`
+	Longitude  float32                `protobuf:"fixed32,8,opt,name=longitude,proto3" json:"longitude,omitempty"`
+	CreatedAt  *timestamppb.Timestamp `protobuf:"bytes,9,opt,name=created_at,json=createdAt,proto3" json:"created_at,omitempty"`
+	// A name the user gave the address, such as "Home" or "Work".
+	Label string `protobuf:"bytes,10,opt,name=label,proto3" json:"label,omitempty"`
+	// Whether orders go to this address unless another one is chosen.
+	IsDefault     bool `protobuf:"varint,11,opt,name=is_default,json=isDefault,proto3" json:"is_default,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
@@ -131,6 +135,20 @@ func (x *Address) GetCreatedAt() *timestamppb.Timestamp {
 	return nil
 }
 
+func (x *Address) GetLabel() string {
+	if x != nil {
+		return x.Label
+	}
+	return ""
+}
+
+func (x *Address) GetIsDefault() bool {
+	if x != nil {
+		return x.IsDefault
+	}
+	return false
+}
+
 // User represents a user in the system.
 type User struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
@@ -140,8 +158,11 @@ type User struct {
 	PhoneNumber   string                 `protobuf:"bytes,4,opt,name=phone_number,json=phoneNumber,proto3" json:"phone_number,omitempty"`
 	Addresses     []*Address             `protobuf:"bytes,5,rep,name=addresses,proto3" json:"addresses,omitempty"`
 	CreatedAt     *timestamppb.Timestamp `protobuf:"bytes,6,opt,name=created_at,json=createdAt,proto3" json:"created_at,omitempty"`
-	unknownFields protoimpl.UnknownFields
-	sizeCache     protoimpl.SizeCache
+	EmailVerified bool                   `protobuf:"varint,7,opt,name=email_verified,json=emailVerified,proto3" json:"email_verified,omitempty"`
+	// The default one of the addresses, unset when the user has none.
+	DefaultAddress *Address `protobuf:"bytes,8,opt,name=default_address,json=defaultAddress,proto3" json:"default_address,omitempty"`
+	unknownFields  protoimpl.UnknownFields
+	sizeCache      protoimpl.SizeCache
 }
 
 func (x *User) Reset() {
@@ -216,6 +237,20 @@ func (x *User) GetCreatedAt() *timestamppb.Timestamp {
 	return nil
 }
 
+func (x *User) GetEmailVerified() bool {
+	if x != nil {
+		return x.EmailVerified
+	}
+	return false
+}
+
+func (x *User) GetDefaultAddress() *Address {
+	if x != nil {
+		return x.DefaultAddress
+	}
+	return nil
+}
+
 // MessageResponse is a generic response message.
 type MessageResponse struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
@@ -351,6 +386,59 @@ func (x *GetUserResponse) GetUser() *User {
 	return nil
 }
 
+// UpdateProfileRequest contains the user's new profile details.
+type UpdateProfileRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	UserId        string                 `protobuf:"bytes,1,opt,name=user_id,json=userId,proto3" json:"user_id,omitempty"`
+	Username      string                 `protobuf:"bytes,2,opt,name=username,proto3" json:"username,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *UpdateProfileRequest) Reset() {
+	*x = UpdateProfileRequest{}
+	mi := &file_user_proto_msgTypes[5]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *UpdateProfileRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*UpdateProfileRequest) ProtoMessage() {}
+
+func (x *UpdateProfileRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_user_proto_msgTypes[5]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use UpdateProfileRequest.ProtoReflect.Descriptor instead.
+func (*UpdateProfileRequest) Descriptor() ([]byte, []int) {
+	return file_user_proto_rawDescGZIP(), []int{5}
+}
+
+func (x *UpdateProfileRequest) GetUserId() string {
+	if x != nil {
+		return x.UserId
+	}
+	return ""
+}
+
+func (x *UpdateProfileRequest) GetUsername() string {
+	if x != nil {
+		return x.Username
+	}
+	return ""
+}
+
 // GetPhoneNumberRequest contains the user ID to retrieve the phone number.
 type GetPhoneNumberRequest struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
@@ -361,7 +449,7 @@ type GetPhoneNumberRequest struct {
 
 func (x *GetPhoneNumberRequest) Reset() {
 	*x = GetPhoneNumberRequest{}
-	mi := &file_user_proto_msgTypes[5]
+	mi := &file_user_proto_msgTypes[6]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -373,7 +461,7 @@ func (x *GetPhoneNumberRequest) String() string {
 func (*GetPhoneNumberRequest) ProtoMessage() {}
 
 func (x *GetPhoneNumberRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_user_proto_msgTypes[5]
+	mi := &file_user_proto_msgTypes[6]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -386,7 +474,7 @@ func (x *GetPhoneNumberRequest) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use GetPhoneNumberRequest.ProtoReflect.Descriptor instead.
 func (*GetPhoneNumberRequest) Descriptor() ([]byte, []int) {
-	return file_user_proto_rawDescGZIP(), []int{5}
+	return file_user_proto_rawDescGZIP(), []int{6}
 }
 
 func (x *GetPhoneNumberRequest) GetUserId() string {
@@ -407,7 +495,7 @@ type GetPhoneNumberResponse struct {
 
 func (x *GetPhoneNumberResponse) Reset() {
 	*x = GetPhoneNumberResponse{}
-	mi := &file_user_proto_msgTypes[6]
+	mi := &file_user_proto_msgTypes[7]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -419,7 +507,7 @@ func (x *GetPhoneNumberResponse) String() string {
 func (*GetPhoneNumberResponse) ProtoMessage() {}
 
 func (x *GetPhoneNumberResponse) ProtoReflect() protoreflect.Message {
-	mi := &file_user_proto_msgTypes[6]
+	mi := &file_user_proto_msgTypes[7]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -432,7 +520,7 @@ func (x *GetPhoneNumberResponse) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use GetPhoneNumberResponse.ProtoReflect.Descriptor instead.
 func (*GetPhoneNumberResponse) Descriptor() ([]byte, []int) {
-	return file_user_proto_rawDescGZIP(), []int{6}
+	return file_user_proto_rawDescGZIP(), []int{7}
 }
 
 func (x *GetPhoneNumberResponse) GetUserId() string {
@@ -460,7 +548,7 @@ type AddPhoneNumberRequest struct {
 
 func (x *AddPhoneNumberRequest) Reset() {
 	*x = AddPhoneNumberRequest{}
-	mi := &file_user_proto_msgTypes[7]
+	mi := &file_user_proto_msgTypes[8]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -472,7 +560,7 @@ func (x *AddPhoneNumberRequest) String() string {
 func (*AddPhoneNumberRequest) ProtoMessage() {}
 
 func (x *AddPhoneNumberRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_user_proto_msgTypes[7]
+	mi := &file_user_proto_msgTypes[8]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -485,7 +573,7 @@ func (x *AddPhoneNumberRequest) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use AddPhoneNumberRequest.ProtoReflect.Descriptor instead.
 func (*AddPhoneNumberRequest) Descriptor() ([]byte, []int) {
-	return file_user_proto_rawDescGZIP(), []int{7}
+	return file_user_proto_rawDescGZIP(), []int{8}
 }
 
 func (x *AddPhoneNumberRequest) GetUserId() string {
@@ -513,7 +601,7 @@ type UpdatePhoneNumberRequest struct {
 
 func (x *UpdatePhoneNumberRequest) Reset() {
 	*x = UpdatePhoneNumberRequest{}
-	mi := &file_user_proto_msgTypes[8]
+	mi := &file_user_proto_msgTypes[9]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -525,7 +613,7 @@ func (x *UpdatePhoneNumberRequest) String() string {
 func (*UpdatePhoneNumberRequest) ProtoMessage() {}
 
 func (x *UpdatePhoneNumberRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_user_proto_msgTypes[8]
+	mi := &file_user_proto_msgTypes[9]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -538,7 +626,7 @@ func (x *UpdatePhoneNumberRequest) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use UpdatePhoneNumberRequest.ProtoReflect.Descriptor instead.
 func (*UpdatePhoneNumberRequest) Descriptor() ([]byte, []int) {
-	return file_user_proto_rawDescGZIP(), []int{8}
+	return file_user_proto_rawDescGZIP(), []int{9}
 }
 
 func (x *UpdatePhoneNumberRequest) GetUserId() string {
@@ -565,7 +653,7 @@ type RemovePhoneNumberRequest struct {
 
 func (x *RemovePhoneNumberRequest) Reset() {
 	*x = RemovePhoneNumberRequest{}
-	mi := &file_user_proto_msgTypes[9]
+	mi := &file_user_proto_msgTypes[10]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -577,7 +665,7 @@ func (x *RemovePhoneNumberRequest) String() string {
 func (*RemovePhoneNumberRequest) ProtoMessage() {}
 
 func (x *RemovePhoneNumberRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_user_proto_msgTypes[9]
+	mi := &file_user_proto_msgTypes[10]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -590,7 +678,7 @@ func (x *RemovePhoneNumberRequest) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use RemovePhoneNumberRequest.ProtoReflect.Descriptor instead.
 func (*RemovePhoneNumberRequest) Descriptor() ([]byte, []int) {
-	return file_user_proto_rawDescGZIP(), []int{9}
+	return file_user_proto_rawDescGZIP(), []int{10}
 }
 
 func (x *RemovePhoneNumberRequest) GetUserId() string {
@@ -610,7 +698,7 @@ type GetAddressesRequest struct {
 
 func (x *GetAddressesRequest) Reset() {
 	*x = GetAddressesRequest{}
-	mi := &file_user_proto_msgTypes[10]
+	mi := &file_user_proto_msgTypes[11]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -622,7 +710,7 @@ func (x *GetAddressesRequest) String() string {
 func (*GetAddressesRequest) ProtoMessage() {}
 
 func (x *GetAddressesRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_user_proto_msgTypes[10]
+	mi := &file_user_proto_msgTypes[11]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -635,7 +723,7 @@ func (x *GetAddressesRequest) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use GetAddressesRequest.ProtoReflect.Descriptor instead.
 func (*GetAddressesRequest) Descriptor() ([]byte, []int) {
-	return file_user_proto_rawDescGZIP(), []int{10}
+	return file_user_proto_rawDescGZIP(), []int{11}
 }
 
 func (x *GetAddressesRequest) GetUserId() string {
@@ -655,7 +743,7 @@ type GetAddressesResponse struct {
 
 func (x *GetAddressesResponse) Reset() {
 	*x = GetAddressesResponse{}
-	mi := &file_user_proto_msgTypes[11]
+	mi := &file_user_proto_msgTypes[12]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -667,7 +755,7 @@ func (x *GetAddressesResponse) String() string {
 func (*GetAddressesResponse) ProtoMessage() {}
 
 func (x *GetAddressesResponse) ProtoReflect() protoreflect.Message {
-	mi := &file_user_proto_msgTypes[11]
+	mi := &file_user_proto_msgTypes[12]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -680,7 +768,7 @@ func (x *GetAddressesResponse) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use GetAddressesResponse.ProtoReflect.Descriptor instead.
 func (*GetAddressesResponse) Descriptor() ([]byte, []int) {
-	return file_user_proto_rawDescGZIP(), []int{11}
+	return file_user_proto_rawDescGZIP(), []int{12}
 }
 
 func (x *GetAddressesResponse) GetAddresses() []*Address {
@@ -692,22 +780,26 @@ func (x *GetAddressesResponse) GetAddresses() []*Address {
 
 // AddAddressRequest contains the details for adding a new address.
 type AddAddressRequest struct {
-	state         protoimpl.MessageState `protogen:"open.v1"`
-	UserId        string                 `protobuf:"bytes,1,opt,name=user_id,json=userId,proto3" json:"user_id,omitempty"`
-	Street        string                 `protobuf:"bytes,2,opt,name=street,proto3" json:"street,omitempty"`
-	City          string                 `protobuf:"bytes,3,opt,name=city,proto3" json:"city,omitempty"`
-	State         string                 `protobuf:"bytes,4,opt,name=state,proto3" json:"state,omitempty"`
-	PostalCode    uint32                 `protobuf:"varint,5,opt,name=postal_code,json=postalCode,proto3" json:"postal_code,omitempty"`
-	Country       string                 `protobuf:"bytes,6,opt,name=country,proto3" json:"country,omitempty"`
-	Latitude      float32                `protobuf:"fixed32,7,opt,name=latitude,proto3" json:"latitude,omitempty"`
-	Longitude     float32                `protobuf:"fixed32,8,opt,name=longitude,proto3" json:"longitude,omitempty"`
+	state      protoimpl.MessageState `protogen:"open.v1"`
+	UserId     string                 `protobuf:"bytes,1,opt,name=user_id,json=userId,proto3" json:"user_id,omitempty"`
+	Street     string                 `protobuf:"bytes,2,opt,name=street,proto3" json:"street,omitempty"`
+	City       string                 `protobuf:"bytes,3,opt,name=city,proto3" json:"city,omitempty"`
+	State      string                 `protobuf:"bytes,4,opt,name=state,proto3" json:"state,omitempty"`
+	PostalCode uint32                 `protobuf:"varint,5,opt,name=postal_code,json=postalCode,proto3" json:"postal_code,omitempty"`
+	Country    string                 `protobuf:"bytes,6,opt,name=country,proto3" json:"country,omitempty"`
+	Latitude   float32                `protobuf:"fixed32,7,opt,name=latitude,proto3" json:"latitude,omitempty"`
+	Longitude  float32                `protobuf:"fixed32,8,opt,name=longitude,proto3" json:"longitude,omitempty"`
+	Label      string                 `protobuf:"bytes,9,opt,name=label,proto3" json:"label,omitempty"`
+	// Makes the new address the default one. A user's first address is
+	// always the default one.
+	IsDefault     bool `protobuf:"varint,10,opt,name=is_default,json=isDefault,proto3" json:"is_default,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
 func (x *AddAddressRequest) Reset() {
 	*x = AddAddressRequest{}
-	mi := &file_user_proto_msgTypes[12]
+	mi := &file_user_proto_msgTypes[13]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -719,7 +811,7 @@ func (x *AddAddressRequest) String() string {
 func (*AddAddressRequest) ProtoMessage() {}
 
 func (x *AddAddressRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_user_proto_msgTypes[12]
+	mi := &file_user_proto_msgTypes[13]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -732,7 +824,7 @@ func (x *AddAddressRequest) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use AddAddressRequest.ProtoReflect.Descriptor instead.
 func (*AddAddressRequest) Descriptor() ([]byte, []int) {
-	return file_user_proto_rawDescGZIP(), []int{12}
+	return file_user_proto_rawDescGZIP(), []int{13}
 }
 
 func (x *AddAddressRequest) GetUserId() string {
@@ -791,29 +883,52 @@ func (x *AddAddressRequest) GetLongitude() float32 {
 	return 0
 }
 
-// AddAddressResponse contains the newly added address.
-type AddAddressResponse struct {
+func (x *AddAddressRequest) GetLabel() string {
+	if x != nil {
+		return x.Label
+	}
+	return ""
+}
+
+func (x *AddAddressRequest) GetIsDefault() bool {
+	if x != nil {
+		return x.IsDefault
+	}
+	return false
+}
+
+// UpdateAddressRequest contains the new details of an address of the user.
+type UpdateAddressRequest struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
-	Address       *Address               `protobuf:"bytes,1,opt,name=address,proto3" json:"address,omitempty"`
+	UserId        string                 `protobuf:"bytes,1,opt,name=user_id,json=userId,proto3" json:"user_id,omitempty"`
+	AddressId     string                 `protobuf:"bytes,2,opt,name=address_id,json=addressId,proto3" json:"address_id,omitempty"`
+	Street        string                 `protobuf:"bytes,3,opt,name=street,proto3" json:"street,omitempty"`
+	City          string                 `protobuf:"bytes,4,opt,name=city,proto3" json:"city,omitempty"`
+	State         string                 `protobuf:"bytes,5,opt,name=state,proto3" json:"state,omitempty"`
+	PostalCode    uint32                 `protobuf:"varint,6,opt,name=postal_code,json=postalCode,proto3" json:"postal_code,omitempty"`
+	Country       string                 `protobuf:"bytes,7,opt,name=country,proto3" json:"country,omitempty"`
+	Latitude      float32                `protobuf:"fixed32,8,opt,name=latitude,proto3" json:"latitude,omitempty"`
+	Longitude     float32                `protobuf:"fixed32,9,opt,name=longitude,proto3" json:"longitude,omitempty"`
+	Label         string                 `protobuf:"bytes,10,opt,name=label,proto3" json:"label,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
-func (x *AddAddressResponse) Reset() {
-	*x = AddAddressResponse{}
-	mi := &file_user_proto_msgTypes[13]
+func (x *UpdateAddressRequest) Reset() {
+	*x = UpdateAddressRequest{}
+	mi := &file_user_proto_msgTypes[14]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *AddAddressResponse) String() string {
+func (x *UpdateAddressRequest) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*AddAddressResponse) ProtoMessage() {}
+func (*UpdateAddressRequest) ProtoMessage() {}
 
-func (x *AddAddressResponse) ProtoReflect() protoreflect.Message {
-	mi := &file_user_proto_msgTypes[13]
+func (x *UpdateAddressRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_user_proto_msgTypes[14]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -824,92 +939,104 @@ func (x *AddAddressResponse) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use AddAddressResponse.ProtoReflect.Descriptor instead.
-func (*AddAddressResponse) Descriptor() ([]byte, []int) {
-	return file_user_proto_rawDescGZIP(), []int{13}
+// Deprecated: Use UpdateAddressRequest.ProtoReflect.Descriptor instead.
+func (*UpdateAddressRequest) Descriptor() ([]byte, []int) {
+	return file_user_proto_rawDescGZIP(), []int{14}
 }
 
-func (x *AddAddressResponse) GetAddress() *Address {
+func (x *UpdateAddressRequest) GetUserId() string {
 	if x != nil {
-		return x.Address
+		return x.UserId
 	}
-	return nil
+	return ""
 }
 
-// RemoveAddressRequest contains the user ID and address ID to be removed.
-type RemoveAddressRequest struct {
-	state         protoimpl.MessageState `protogen:"open.v1"`
-	UserId        string                 `protobuf:"bytes,1,opt,name=user_id,json=userId,proto3" json:"user_id,omitempty"`
-	AddressId     string                 `protobuf:"bytes,2,opt,name=address_id,json=addressId,proto3" json:"address_id,omitempty"`
-	unknownFields protoimpl.UnknownFields
-	sizeCache     protoimpl.SizeCache
+func (x *UpdateAddressRequest) GetAddressId() string {
+	if x != nil {
+		return x.AddressId
+	}
+	return ""
 }
 
-func (x *RemoveAddressRequest) Reset() {
-	*x = RemoveAddressRequest{}
-	mi := &file_user_proto_msgTypes[14]
-	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
-	ms.StoreMessageInfo(mi)
+func (x *UpdateAddressRequest) GetStreet() string {
+	if x != nil {
+		return x.Street
+	}
+	return ""
 }
 
-func (x *RemoveAddressRequest) String() string {
-	return protoimpl.X.MessageStringOf(x)
+func (x *UpdateAddressRequest) GetCity() string {
+	if x != nil {
+		return x.City
+	}
+	return ""
 }
 
-func (*RemoveAddressRequest) ProtoMessage() {}
-
-func (x *RemoveAddressRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_user_proto_msgTypes[14]
+func (x *UpdateAddressRequest) GetState() string {
 	if x != nil {
-		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
-		if ms.LoadMessageInfo() == nil {
-			ms.StoreMessageInfo(mi)
-		}
-		return ms
+		return x.State
 	}
-	return mi.MessageOf(x)
+	return ""
 }
 
-// Deprecated: Use RemoveAddressRequest.ProtoReflect.Descriptor instead.
-func (*RemoveAddressRequest) Descriptor() ([]byte, []int) {
-	return file_user_proto_rawDescGZIP(), []int{14}
+func (x *UpdateAddressRequest) GetPostalCode() uint32 {
+	if x != nil {
+		return x.PostalCode
+	}
+	return 0
 }
 
-func (x *RemoveAddressRequest) GetUserId() string {
+func (x *UpdateAddressRequest) GetCountry() string {
 	if x != nil {
-		return x.UserId
+		return x.Country
 	}
 	return ""
 }
 
-func (x *RemoveAddressRequest) GetAddressId() string {
+func (x *UpdateAddressRequest) GetLatitude() float32 {
 	if x != nil {
-		return x.AddressId
+		return x.Latitude
+	}
+	return 0
+}
+
+func (x *UpdateAddressRequest) GetLongitude() float32 {
+	if x != nil {
+		return x.Longitude
+	}
+	return 0
+}
+
+func (x *UpdateAddressRequest) GetLabel() string {
+	if x != nil {
+		return x.Label
 	}
 	return ""
 }
 
-type BeDriverRequest struct {
+// SetDefaultAddressRequest identifies the address to make the default one.
+type SetDefaultAddressRequest struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
 	UserId        string                 `protobuf:"bytes,1,opt,name=user_id,json=userId,proto3" json:"user_id,omitempty"`
+	AddressId     string                 `protobuf:"bytes,2,opt,name=address_id,json=addressId,proto3" json:"address_id,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
-func (x *BeDriverRequest) Reset() {
-	*x = BeDriverRequest{}
+func (x *SetDefaultAddressRequest) Reset() {
+	*x = SetDefaultAddressRequest{}
 	mi := &file_user_proto_msgTypes[15]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *BeDriverRequest) String() string {
+func (x *SetDefaultAddressRequest) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*BeDriverRequest) ProtoMessage() {}
+func (*SetDefaultAddressRequest) ProtoMessage() {}
 
-func (x *BeDriverRequest) ProtoReflect() protoreflect.Message {
+func (x *SetDefaultAddressRequest) ProtoReflect() protoreflect.Message {
 	mi := &file_user_proto_msgTypes[15]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
@@ -921,39 +1048,47 @@ func (x *BeDriverRequest) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use BeDriverRequest.ProtoReflect.Descriptor instead.
-func (*BeDriverRequest) Descriptor() ([]byte, []int) {
+// Deprecated: Use SetDefaultAddressRequest.ProtoReflect.Descriptor instead.
+func (*SetDefaultAddressRequest) Descriptor() ([]byte, []int) {
 	return file_user_proto_rawDescGZIP(), []int{15}
 }
 
-func (x *BeDriverRequest) GetUserId() string {
+func (x *SetDefaultAddressRequest) GetUserId() string {
 	if x != nil {
 		return x.UserId
 	}
 	return ""
 }
 
-type BeDriverResponse struct {
+func (x *SetDefaultAddressRequest) GetAddressId() string {
+	if x != nil {
+		return x.AddressId
+	}
+	return ""
+}
+
+// AddAddressResponse contains the newly added address.
+type AddAddressResponse struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
-	DriverId      string                 `protobuf:"bytes,1,opt,name=driver_id,json=driverId,proto3" json:"driver_id,omitempty"`
+	Address       *Address               `protobuf:"bytes,1,opt,name=address,proto3" json:"address,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
-func (x *BeDriverResponse) Reset() {
-	*x = BeDriverResponse{}
+func (x *AddAddressResponse) Reset() {
+	*x = AddAddressResponse{}
 	mi := &file_user_proto_msgTypes[16]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *BeDriverResponse) String() string {
+func (x *AddAddressResponse) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*BeDriverResponse) ProtoMessage() {}
+func (*AddAddressResponse) ProtoMessage() {}
 
-func (x *BeDriverResponse) ProtoReflect() protoreflect.Message {
+func (x *AddAddressResponse) ProtoReflect() protoreflect.Message {
 	mi := &file_user_proto_msgTypes[16]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
@@ -965,40 +1100,451 @@ func (x *BeDriverResponse) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use BeDriverResponse.ProtoReflect.Descriptor instead.
-func (*BeDriverResponse) Descriptor() ([]byte, []int) {
+// Deprecated: Use AddAddressResponse.ProtoReflect.Descriptor instead.
+func (*AddAddressResponse) Descriptor() ([]byte, []int) {
 	return file_user_proto_rawDescGZIP(), []int{16}
 }
 
-func (x *BeDriverResponse) GetDriverId() string {
+func (x *AddAddressResponse) GetAddress() *Address {
 	if x != nil {
-		return x.DriverId
+		return x.Address
 	}
-	return ""
+	return nil
 }
 
-type DriverResponse struct {
+// RemoveAddressRequest contains the user ID and address ID to be removed.
+type RemoveAddressRequest struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
-	DriverIds     []string               `protobuf:"bytes,1,rep,name=driver_ids,json=driverIds,proto3" json:"driver_ids,omitempty"`
+	UserId        string                 `protobuf:"bytes,1,opt,name=user_id,json=userId,proto3" json:"user_id,omitempty"`
+	AddressId     string                 `protobuf:"bytes,2,opt,name=address_id,json=addressId,proto3" json:"address_id,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
-func (x *DriverResponse) Reset() {
-	*x = DriverResponse{}
+func (x *RemoveAddressRequest) Reset() {
+	*x = RemoveAddressRequest{}
 	mi := &file_user_proto_msgTypes[17]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
+func (x *RemoveAddressRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*RemoveAddressRequest) ProtoMessage() {}
+
+func (x *RemoveAddressRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_user_proto_msgTypes[17]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use RemoveAddressRequest.ProtoReflect.Descriptor instead.
+func (*RemoveAddressRequest) Descriptor() ([]byte, []int) {
+	return file_user_proto_rawDescGZIP(), []int{17}
+}
+
+func (x *RemoveAddressRequest) GetUserId() string {
+	if x != nil {
+		return x.UserId
+	}
+	return ""
+}
+
+func (x *RemoveAddressRequest) GetAddressId() string {
+	if x != nil {
+		return x.AddressId
+	}
+	return ""
+}
+
+// Vehicle describes the vehicle a driver delivers with.
+type Vehicle struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// One of bicycle, scooter, motorcycle, car or van.
+	Type  string `protobuf:"bytes,1,opt,name=type,proto3" json:"type,omitempty"`
+	Make  string `protobuf:"bytes,2,opt,name=make,proto3" json:"make,omitempty"`
+	Model string `protobuf:"bytes,3,opt,name=model,proto3" json:"model,omitempty"`
+	// Required for every type but bicycle.
+	PlateNumber   string `protobuf:"bytes,4,opt,name=plate_number,json=plateNumber,proto3" json:"plate_number,omitempty"`
+	Color         string `protobuf:"bytes,5,opt,name=color,proto3" json:"color,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *Vehicle) Reset() {
+	*x = Vehicle{}
+	mi := &file_user_proto_msgTypes[18]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *Vehicle) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Vehicle) ProtoMessage() {}
+
+func (x *Vehicle) ProtoReflect() protoreflect.Message {
+	mi := &file_user_proto_msgTypes[18]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Vehicle.ProtoReflect.Descriptor instead.
+func (*Vehicle) Descriptor() ([]byte, []int) {
+	return file_user_proto_rawDescGZIP(), []int{18}
+}
+
+func (x *Vehicle) GetType() string {
+	if x != nil {
+		return x.Type
+	}
+	return ""
+}
+
+func (x *Vehicle) GetMake() string {
+	if x != nil {
+		return x.Make
+	}
+	return ""
+}
+
+func (x *Vehicle) GetModel() string {
+	if x != nil {
+		return x.Model
+	}
+	return ""
+}
+
+func (x *Vehicle) GetPlateNumber() string {
+	if x != nil {
+		return x.PlateNumber
+	}
+	return ""
+}
+
+func (x *Vehicle) GetColor() string {
+	if x != nil {
+		return x.Color
+	}
+	return ""
+}
+
+type BeDriverRequest struct {
+	state  protoimpl.MessageState `protogen:"open.v1"`
+	UserId string                 `protobuf:"bytes,1,opt,name=user_id,json=userId,proto3" json:"user_id,omitempty"`
+	// Optional, the vehicle can be added later with UpdateVehicle.
+	Vehicle       *Vehicle `protobuf:"bytes,2,opt,name=vehicle,proto3" json:"vehicle,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *BeDriverRequest) Reset() {
+	*x = BeDriverRequest{}
+	mi := &file_user_proto_msgTypes[19]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *BeDriverRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*BeDriverRequest) ProtoMessage() {}
+
+func (x *BeDriverRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_user_proto_msgTypes[19]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use BeDriverRequest.ProtoReflect.Descriptor instead.
+func (*BeDriverRequest) Descriptor() ([]byte, []int) {
+	return file_user_proto_rawDescGZIP(), []int{19}
+}
+
+func (x *BeDriverRequest) GetUserId() string {
+	if x != nil {
+		return x.UserId
+	}
+	return ""
+}
+
+func (x *BeDriverRequest) GetVehicle() *Vehicle {
+	if x != nil {
+		return x.Vehicle
+	}
+	return nil
+}
+
+type BeDriverResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	DriverId      string                 `protobuf:"bytes,1,opt,name=driver_id,json=driverId,proto3" json:"driver_id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *BeDriverResponse) Reset() {
+	*x = BeDriverResponse{}
+	mi := &file_user_proto_msgTypes[20]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *BeDriverResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*BeDriverResponse) ProtoMessage() {}
+
+func (x *BeDriverResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_user_proto_msgTypes[20]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use BeDriverResponse.ProtoReflect.Descriptor instead.
+func (*BeDriverResponse) Descriptor() ([]byte, []int) {
+	return file_user_proto_rawDescGZIP(), []int{20}
+}
+
+func (x *BeDriverResponse) GetDriverId() string {
+	if x != nil {
+		return x.DriverId
+	}
+	return ""
+}
+
+type DriverResponse struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// The IDs of the drivers in drivers, in the same order.
+	DriverIds     []string        `protobuf:"bytes,1,rep,name=driver_ids,json=driverIds,proto3" json:"driver_ids,omitempty"`
+	Drivers       []*NearbyDriver `protobuf:"bytes,2,rep,name=drivers,proto3" json:"drivers,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *DriverResponse) Reset() {
+	*x = DriverResponse{}
+	mi := &file_user_proto_msgTypes[21]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
 func (x *DriverResponse) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*DriverResponse) ProtoMessage() {}
+func (*DriverResponse) ProtoMessage() {}
+
+func (x *DriverResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_user_proto_msgTypes[21]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use DriverResponse.ProtoReflect.Descriptor instead.
+func (*DriverResponse) Descriptor() ([]byte, []int) {
+	return file_user_proto_rawDescGZIP(), []int{21}
+}
+
+func (x *DriverResponse) GetDriverIds() []string {
+	if x != nil {
+		return x.DriverIds
+	}
+	return nil
+}
+
+func (x *DriverResponse) GetDrivers() []*NearbyDriver {
+	if x != nil {
+		return x.Drivers
+	}
+	return nil
+}
+
+// NearbyDriver is an online driver found by GetDrivers.
+type NearbyDriver struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	DriverId      string                 `protobuf:"bytes,1,opt,name=driver_id,json=driverId,proto3" json:"driver_id,omitempty"`
+	Latitude      float32                `protobuf:"fixed32,2,opt,name=latitude,proto3" json:"latitude,omitempty"`
+	Longitude     float32                `protobuf:"fixed32,3,opt,name=longitude,proto3" json:"longitude,omitempty"`
+	DistanceKm    float32                `protobuf:"fixed32,4,opt,name=distance_km,json=distanceKm,proto3" json:"distance_km,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *NearbyDriver) Reset() {
+	*x = NearbyDriver{}
+	mi := &file_user_proto_msgTypes[22]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *NearbyDriver) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*NearbyDriver) ProtoMessage() {}
+
+func (x *NearbyDriver) ProtoReflect() protoreflect.Message {
+	mi := &file_user_proto_msgTypes[22]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use NearbyDriver.ProtoReflect.Descriptor instead.
+func (*NearbyDriver) Descriptor() ([]byte, []int) {
+	return file_user_proto_rawDescGZIP(), []int{22}
+}
+
+func (x *NearbyDriver) GetDriverId() string {
+	if x != nil {
+		return x.DriverId
+	}
+	return ""
+}
+
+func (x *NearbyDriver) GetLatitude() float32 {
+	if x != nil {
+		return x.Latitude
+	}
+	return 0
+}
+
+func (x *NearbyDriver) GetLongitude() float32 {
+	if x != nil {
+		return x.Longitude
+	}
+	return 0
+}
+
+func (x *NearbyDriver) GetDistanceKm() float32 {
+	if x != nil {
+		return x.DistanceKm
+	}
+	return 0
+}
+
+type GetDriversRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Latitude      float32                `protobuf:"fixed32,1,opt,name=latitude,proto3" json:"latitude,omitempty"`
+	Longitude     float32                `protobuf:"fixed32,2,opt,name=longitude,proto3" json:"longitude,omitempty"`
+	RadiusKm      float32                `protobuf:"fixed32,3,opt,name=radius_km,json=radiusKm,proto3" json:"radius_km,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetDriversRequest) Reset() {
+	*x = GetDriversRequest{}
+	mi := &file_user_proto_msgTypes[23]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetDriversRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetDriversRequest) ProtoMessage() {}
+
+func (x *GetDriversRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_user_proto_msgTypes[23]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetDriversRequest.ProtoReflect.Descriptor instead.
+func (*GetDriversRequest) Descriptor() ([]byte, []int) {
+	return file_user_proto_rawDescGZIP(), []int{23}
+}
+
+func (x *GetDriversRequest) GetLatitude() float32 {
+	if x != nil {
+		return x.Latitude
+	}
+	return 0
+}
+
+func (x *GetDriversRequest) GetLongitude() float32 {
+	if x != nil {
+		return x.Longitude
+	}
+	return 0
+}
+
+func (x *GetDriversRequest) GetRadiusKm() float32 {
+	if x != nil {
+		return x.RadiusKm
+	}
+	return 0
+}
+
+type RemoveDriverRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	DriverId      string                 `protobuf:"bytes,1,opt,name=driver_id,json=driverId,proto3" json:"driver_id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *RemoveDriverRequest) Reset() {
+	*x = RemoveDriverRequest{}
+	mi := &file_user_proto_msgTypes[24]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *RemoveDriverRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*RemoveDriverRequest) ProtoMessage() {}
 
-func (x *DriverResponse) ProtoReflect() protoreflect.Message {
-	mi := &file_user_proto_msgTypes[17]
+func (x *RemoveDriverRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_user_proto_msgTypes[24]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -1009,42 +1555,162 @@ func (x *DriverResponse) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use DriverResponse.ProtoReflect.Descriptor instead.
-func (*DriverResponse) Descriptor() ([]byte, []int) {
-	return file_user_proto_rawDescGZIP(), []int{17}
+// Deprecated: Use RemoveDriverRequest.ProtoReflect.Descriptor instead.
+func (*RemoveDriverRequest) Descriptor() ([]byte, []int) {
+	return file_user_proto_rawDescGZIP(), []int{24}
 }
 
-func (x *DriverResponse) GetDriverIds() []string {
+func (x *RemoveDriverRequest) GetDriverId() string {
 	if x != nil {
-		return x.DriverIds
+		return x.DriverId
+	}
+	return ""
+}
+
+type GetDriverProfileRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	UserId        string                 `protobuf:"bytes,1,opt,name=user_id,json=userId,proto3" json:"user_id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetDriverProfileRequest) Reset() {
+	*x = GetDriverProfileRequest{}
+	mi := &file_user_proto_msgTypes[25]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetDriverProfileRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetDriverProfileRequest) ProtoMessage() {}
+
+func (x *GetDriverProfileRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_user_proto_msgTypes[25]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetDriverProfileRequest.ProtoReflect.Descriptor instead.
+func (*GetDriverProfileRequest) Descriptor() ([]byte, []int) {
+	return file_user_proto_rawDescGZIP(), []int{25}
+}
+
+func (x *GetDriverProfileRequest) GetUserId() string {
+	if x != nil {
+		return x.UserId
+	}
+	return ""
+}
+
+// DriverProfile is a user's profile as a driver.
+type DriverProfile struct {
+	state    protoimpl.MessageState `protogen:"open.v1"`
+	DriverId string                 `protobuf:"bytes,1,opt,name=driver_id,json=driverId,proto3" json:"driver_id,omitempty"`
+	UserId   string                 `protobuf:"bytes,2,opt,name=user_id,json=userId,proto3" json:"user_id,omitempty"`
+	Vehicle  *Vehicle               `protobuf:"bytes,3,opt,name=vehicle,proto3" json:"vehicle,omitempty"`
+	// Whether the driver is on duty.
+	Online        bool                   `protobuf:"varint,4,opt,name=online,proto3" json:"online,omitempty"`
+	CreatedAt     *timestamppb.Timestamp `protobuf:"bytes,5,opt,name=created_at,json=createdAt,proto3" json:"created_at,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *DriverProfile) Reset() {
+	*x = DriverProfile{}
+	mi := &file_user_proto_msgTypes[26]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *DriverProfile) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*DriverProfile) ProtoMessage() {}
+
+func (x *DriverProfile) ProtoReflect() protoreflect.Message {
+	mi := &file_user_proto_msgTypes[26]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use DriverProfile.ProtoReflect.Descriptor instead.
+func (*DriverProfile) Descriptor() ([]byte, []int) {
+	return file_user_proto_rawDescGZIP(), []int{26}
+}
+
+func (x *DriverProfile) GetDriverId() string {
+	if x != nil {
+		return x.DriverId
+	}
+	return ""
+}
+
+func (x *DriverProfile) GetUserId() string {
+	if x != nil {
+		return x.UserId
+	}
+	return ""
+}
+
+func (x *DriverProfile) GetVehicle() *Vehicle {
+	if x != nil {
+		return x.Vehicle
 	}
 	return nil
 }
 
-type GetDriversRequest struct {
+func (x *DriverProfile) GetOnline() bool {
+	if x != nil {
+		return x.Online
+	}
+	return false
+}
+
+func (x *DriverProfile) GetCreatedAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.CreatedAt
+	}
+	return nil
+}
+
+type DriverProfileResponse struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
-	Latitude      float32                `protobuf:"fixed32,1,opt,name=latitude,proto3" json:"latitude,omitempty"`
-	Longitude     float32                `protobuf:"fixed32,2,opt,name=longitude,proto3" json:"longitude,omitempty"`
-	RadiusKm      float32                `protobuf:"fixed32,3,opt,name=radius_km,json=radiusKm,proto3" json:"radius_km,omitempty"`
+	Profile       *DriverProfile         `protobuf:"bytes,1,opt,name=profile,proto3" json:"profile,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
-func (x *GetDriversRequest) Reset() {
-	*x = GetDriversRequest{}
-	mi := &file_user_proto_msgTypes[18]
+func (x *DriverProfileResponse) Reset() {
+	*x = DriverProfileResponse{}
+	mi := &file_user_proto_msgTypes[27]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *GetDriversRequest) String() string {
+func (x *DriverProfileResponse) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*GetDriversRequest) ProtoMessage() {}
+func (*DriverProfileResponse) ProtoMessage() {}
 
-func (x *GetDriversRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_user_proto_msgTypes[18]
+func (x *DriverProfileResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_user_proto_msgTypes[27]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -1055,54 +1721,198 @@ func (x *GetDriversRequest) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use GetDriversRequest.ProtoReflect.Descriptor instead.
-func (*GetDriversRequest) Descriptor() ([]byte, []int) {
-	return file_user_proto_rawDescGZIP(), []int{18}
+// Deprecated: Use DriverProfileResponse.ProtoReflect.Descriptor instead.
+func (*DriverProfileResponse) Descriptor() ([]byte, []int) {
+	return file_user_proto_rawDescGZIP(), []int{27}
 }
 
-func (x *GetDriversRequest) GetLatitude() float32 {
+func (x *DriverProfileResponse) GetProfile() *DriverProfile {
+	if x != nil {
+		return x.Profile
+	}
+	return nil
+}
+
+type UpdateVehicleRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	UserId        string                 `protobuf:"bytes,1,opt,name=user_id,json=userId,proto3" json:"user_id,omitempty"`
+	Vehicle       *Vehicle               `protobuf:"bytes,2,opt,name=vehicle,proto3" json:"vehicle,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *UpdateVehicleRequest) Reset() {
+	*x = UpdateVehicleRequest{}
+	mi := &file_user_proto_msgTypes[28]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *UpdateVehicleRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*UpdateVehicleRequest) ProtoMessage() {}
+
+func (x *UpdateVehicleRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_user_proto_msgTypes[28]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use UpdateVehicleRequest.ProtoReflect.Descriptor instead.
+func (*UpdateVehicleRequest) Descriptor() ([]byte, []int) {
+	return file_user_proto_rawDescGZIP(), []int{28}
+}
+
+func (x *UpdateVehicleRequest) GetUserId() string {
+	if x != nil {
+		return x.UserId
+	}
+	return ""
+}
+
+func (x *UpdateVehicleRequest) GetVehicle() *Vehicle {
+	if x != nil {
+		return x.Vehicle
+	}
+	return nil
+}
+
+type GoOnlineRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	UserId        string                 `protobuf:"bytes,1,opt,name=user_id,json=userId,proto3" json:"user_id,omitempty"`
+	Latitude      float32                `protobuf:"fixed32,2,opt,name=latitude,proto3" json:"latitude,omitempty"`
+	Longitude     float32                `protobuf:"fixed32,3,opt,name=longitude,proto3" json:"longitude,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GoOnlineRequest) Reset() {
+	*x = GoOnlineRequest{}
+	mi := &file_user_proto_msgTypes[29]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GoOnlineRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GoOnlineRequest) ProtoMessage() {}
+
+func (x *GoOnlineRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_user_proto_msgTypes[29]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GoOnlineRequest.ProtoReflect.Descriptor instead.
+func (*GoOnlineRequest) Descriptor() ([]byte, []int) {
+	return file_user_proto_rawDescGZIP(), []int{29}
+}
+
+func (x *GoOnlineRequest) GetUserId() string {
+	if x != nil {
+		return x.UserId
+	}
+	return ""
+}
+
+func (x *GoOnlineRequest) GetLatitude() float32 {
 	if x != nil {
 		return x.Latitude
 	}
 	return 0
 }
 
-func (x *GetDriversRequest) GetLongitude() float32 {
+func (x *GoOnlineRequest) GetLongitude() float32 {
 	if x != nil {
 		return x.Longitude
 	}
 	return 0
 }
 
-func (x *GetDriversRequest) GetRadiusKm() float32 {
+type GoOfflineRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	UserId        string                 `protobuf:"bytes,1,opt,name=user_id,json=userId,proto3" json:"user_id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GoOfflineRequest) Reset() {
+	*x = GoOfflineRequest{}
+	mi := &file_user_proto_msgTypes[30]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GoOfflineRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GoOfflineRequest) ProtoMessage() {}
+
+func (x *GoOfflineRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_user_proto_msgTypes[30]
 	if x != nil {
-		return x.RadiusKm
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
 	}
-	return 0
+	return mi.MessageOf(x)
 }
 
-type RemoveDriverRequest struct {
+// Deprecated: Use GoOfflineRequest.ProtoReflect.Descriptor instead.
+func (*GoOfflineRequest) Descriptor() ([]byte, []int) {
+	return file_user_proto_rawDescGZIP(), []int{30}
+}
+
+func (x *GoOfflineRequest) GetUserId() string {
+	if x != nil {
+		return x.UserId
+	}
+	return ""
+}
+
+type UpdateDriverLocationRequest struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
-	DriverId      string                 `protobuf:"bytes,1,opt,name=driver_id,json=driverId,proto3" json:"driver_id,omitempty"`
+	UserId        string                 `protobuf:"bytes,1,opt,name=user_id,json=userId,proto3" json:"user_id,omitempty"`
+	Latitude      float32                `protobuf:"fixed32,2,opt,name=latitude,proto3" json:"latitude,omitempty"`
+	Longitude     float32                `protobuf:"fixed32,3,opt,name=longitude,proto3" json:"longitude,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
-func (x *RemoveDriverRequest) Reset() {
-	*x = RemoveDriverRequest{}
-	mi := &file_user_proto_msgTypes[19]
+func (x *UpdateDriverLocationRequest) Reset() {
+	*x = UpdateDriverLocationRequest{}
+	mi := &file_user_proto_msgTypes[31]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *RemoveDriverRequest) String() string {
+func (x *UpdateDriverLocationRequest) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*RemoveDriverRequest) ProtoMessage() {}
+func (*UpdateDriverLocationRequest) ProtoMessage() {}
 
-func (x *RemoveDriverRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_user_proto_msgTypes[19]
+func (x *UpdateDriverLocationRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_user_proto_msgTypes[31]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -1113,24 +1923,38 @@ func (x *RemoveDriverRequest) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use RemoveDriverRequest.ProtoReflect.Descriptor instead.
-func (*RemoveDriverRequest) Descriptor() ([]byte, []int) {
-	return file_user_proto_rawDescGZIP(), []int{19}
+// Deprecated: Use UpdateDriverLocationRequest.ProtoReflect.Descriptor instead.
+func (*UpdateDriverLocationRequest) Descriptor() ([]byte, []int) {
+	return file_user_proto_rawDescGZIP(), []int{31}
 }
 
-func (x *RemoveDriverRequest) GetDriverId() string {
+func (x *UpdateDriverLocationRequest) GetUserId() string {
 	if x != nil {
-		return x.DriverId
+		return x.UserId
 	}
 	return ""
 }
 
+func (x *UpdateDriverLocationRequest) GetLatitude() float32 {
+	if x != nil {
+		return x.Latitude
+	}
+	return 0
+}
+
+func (x *UpdateDriverLocationRequest) GetLongitude() float32 {
+	if x != nil {
+		return x.Longitude
+	}
+	return 0
+}
+
 var File_user_proto protoreflect.FileDescriptor
 
 const file_user_proto_rawDesc = "" +
 	"\n" +
 	"\n" +
-	"user.proto\x12\x04user\x1a\x1fgoogle/protobuf/timestamp.proto\"\x9a\x02\n" +
+	"user.proto\x12\x04user\x1a\x1fgoogle/protobuf/timestamp.proto\"\xcf\x02\n" +
 	"\aAddress\x12\x1d\n" +
 	"\n" +
 	"address_id\x18\x01 \x01(\tR\taddressId\x12\x16\n" +
@@ -1143,7 +1967,11 @@ const file_user_proto_rawDesc = "" +
 	"\blatitude\x18\a \x01(\x02R\blatitude\x12\x1c\n" +
 	"\tlongitude\x18\b \x01(\x02R\tlongitude\x129\n" +
 	"\n" +
-	"created_at\x18\t \x01(\v2\x1a.google.protobuf.TimestampR\tcreatedAt\"\xdc\x01\n" +
+	"created_at\x18\t \x01(\v2\x1a.google.protobuf.TimestampR\tcreatedAt\x12\x14\n" +
+	"\x05label\x18\n" +
+	" \x01(\tR\x05label\x12\x1d\n" +
+	"\n" +
+	"is_default\x18\v \x01(\bR\tisDefault\"\xbb\x02\n" +
 	"\x04User\x12\x17\n" +
 	"\auser_id\x18\x01 \x01(\tR\x06userId\x12\x14\n" +
 	"\x05email\x18\x02 \x01(\tR\x05email\x12\x1a\n" +
@@ -1151,14 +1979,19 @@ const file_user_proto_rawDesc = "" +
 	"\fphone_number\x18\x04 \x01(\tR\vphoneNumber\x12+\n" +
 	"\taddresses\x18\x05 \x03(\v2\r.user.AddressR\taddresses\x129\n" +
 	"\n" +
-	"created_at\x18\x06 \x01(\v2\x1a.google.protobuf.TimestampR\tcreatedAt\"+\n" +
+	"created_at\x18\x06 \x01(\v2\x1a.google.protobuf.TimestampR\tcreatedAt\x12%\n" +
+	"\x0eemail_verified\x18\a \x01(\bR\remailVerified\x126\n" +
+	"\x0fdefault_address\x18\b \x01(\v2\r.user.AddressR\x0edefaultAddress\"+\n" +
 	"\x0fMessageResponse\x12\x18\n" +
 	"\amessage\x18\x01 \x01(\tR\amessage\")\n" +
 	"\x0eGetUserRequest\x12\x17\n" +
 	"\auser_id\x18\x01 \x01(\tR\x06userId\"1\n" +
 	"\x0fGetUserResponse\x12\x1e\n" +
 	"\x04user\x18\x01 \x01(\v2\n" +
-	".user.UserR\x04user\"0\n" +
+	".user.UserR\x04user\"K\n" +
+	"\x14UpdateProfileRequest\x12\x17\n" +
+	"\auser_id\x18\x01 \x01(\tR\x06userId\x12\x1a\n" +
+	"\busername\x18\x02 \x01(\tR\busername\"0\n" +
 	"\x15GetPhoneNumberRequest\x12\x17\n" +
 	"\auser_id\x18\x01 \x01(\tR\x06userId\"T\n" +
 	"\x16GetPhoneNumberResponse\x12\x17\n" +
@@ -1175,7 +2008,7 @@ const file_user_proto_rawDesc = "" +
 	"\x13GetAddressesRequest\x12\x17\n" +
 	"\auser_id\x18\x01 \x01(\tR\x06userId\"C\n" +
 	"\x14GetAddressesResponse\x12+\n" +
-	"\taddresses\x18\x01 \x03(\v2\r.user.AddressR\taddresses\"\xe3\x01\n" +
+	"\taddresses\x18\x01 \x03(\v2\r.user.AddressR\taddresses\"\x98\x02\n" +
 	"\x11AddAddressRequest\x12\x17\n" +
 	"\auser_id\x18\x01 \x01(\tR\x06userId\x12\x16\n" +
 	"\x06street\x18\x02 \x01(\tR\x06street\x12\x12\n" +
@@ -1185,39 +2018,108 @@ const file_user_proto_rawDesc = "" +
 	"postalCode\x12\x18\n" +
 	"\acountry\x18\x06 \x01(\tR\acountry\x12\x1a\n" +
 	"\blatitude\x18\a \x01(\x02R\blatitude\x12\x1c\n" +
-	"\tlongitude\x18\b \x01(\x02R\tlongitude\"=\n" +
+	"\tlongitude\x18\b \x01(\x02R\tlongitude\x12\x14\n" +
+	"\x05label\x18\t \x01(\tR\x05label\x12\x1d\n" +
+	"\n" +
+	"is_default\x18\n" +
+	" \x01(\bR\tisDefault\"\x9b\x02\n" +
+	"\x14UpdateAddressRequest\x12\x17\n" +
+	"\auser_id\x18\x01 \x01(\tR\x06userId\x12\x1d\n" +
+	"\n" +
+	"address_id\x18\x02 \x01(\tR\taddressId\x12\x16\n" +
+	"\x06street\x18\x03 \x01(\tR\x06street\x12\x12\n" +
+	"\x04city\x18\x04 \x01(\tR\x04city\x12\x14\n" +
+	"\x05state\x18\x05 \x01(\tR\x05state\x12\x1f\n" +
+	"\vpostal_code\x18\x06 \x01(\rR\n" +
+	"postalCode\x12\x18\n" +
+	"\acountry\x18\a \x01(\tR\acountry\x12\x1a\n" +
+	"\blatitude\x18\b \x01(\x02R\blatitude\x12\x1c\n" +
+	"\tlongitude\x18\t \x01(\x02R\tlongitude\x12\x14\n" +
+	"\x05label\x18\n" +
+	" \x01(\tR\x05label\"R\n" +
+	"\x18SetDefaultAddressRequest\x12\x17\n" +
+	"\auser_id\x18\x01 \x01(\tR\x06userId\x12\x1d\n" +
+	"\n" +
+	"address_id\x18\x02 \x01(\tR\taddressId\"=\n" +
 	"\x12AddAddressResponse\x12'\n" +
 	"\aaddress\x18\x01 \x01(\v2\r.user.AddressR\aaddress\"N\n" +
 	"\x14RemoveAddressRequest\x12\x17\n" +
 	"\auser_id\x18\x01 \x01(\tR\x06userId\x12\x1d\n" +
 	"\n" +
-	"address_id\x18\x02 \x01(\tR\taddressId\"*\n" +
+	"address_id\x18\x02 \x01(\tR\taddressId\"\x80\x01\n" +
+	"\aVehicle\x12\x12\n" +
+	"\x04type\x18\x01 \x01(\tR\x04type\x12\x12\n" +
+	"\x04make\x18\x02 \x01(\tR\x04make\x12\x14\n" +
+	"\x05model\x18\x03 \x01(\tR\x05model\x12!\n" +
+	"\fplate_number\x18\x04 \x01(\tR\vplateNumber\x12\x14\n" +
+	"\x05color\x18\x05 \x01(\tR\x05color\"S\n" +
 	"\x0fBeDriverRequest\x12\x17\n" +
-	"\auser_id\x18\x01 \x01(\tR\x06userId\"/\n" +
+	"\auser_id\x18\x01 \x01(\tR\x06userId\x12'\n" +
+	"\avehicle\x18\x02 \x01(\v2\r.user.VehicleR\avehicle\"/\n" +
 	"\x10BeDriverResponse\x12\x1b\n" +
-	"\tdriver_id\x18\x01 \x01(\tR\bdriverId\"/\n" +
+	"\tdriver_id\x18\x01 \x01(\tR\bdriverId\"]\n" +
 	"\x0eDriverResponse\x12\x1d\n" +
 	"\n" +
-	"driver_ids\x18\x01 \x03(\tR\tdriverIds\"j\n" +
+	"driver_ids\x18\x01 \x03(\tR\tdriverIds\x12,\n" +
+	"\adrivers\x18\x02 \x03(\v2\x12.user.NearbyDriverR\adrivers\"\x86\x01\n" +
+	"\fNearbyDriver\x12\x1b\n" +
+	"\tdriver_id\x18\x01 \x01(\tR\bdriverId\x12\x1a\n" +
+	"\blatitude\x18\x02 \x01(\x02R\blatitude\x12\x1c\n" +
+	"\tlongitude\x18\x03 \x01(\x02R\tlongitude\x12\x1f\n" +
+	"\vdistance_km\x18\x04 \x01(\x02R\n" +
+	"distanceKm\"j\n" +
 	"\x11GetDriversRequest\x12\x1a\n" +
 	"\blatitude\x18\x01 \x01(\x02R\blatitude\x12\x1c\n" +
 	"\tlongitude\x18\x02 \x01(\x02R\tlongitude\x12\x1b\n" +
 	"\tradius_km\x18\x03 \x01(\x02R\bradiusKm\"2\n" +
 	"\x13RemoveDriverRequest\x12\x1b\n" +
-	"\tdriver_id\x18\x01 \x01(\tR\bdriverId2\xf6\x05\n" +
+	"\tdriver_id\x18\x01 \x01(\tR\bdriverId\"2\n" +
+	"\x17GetDriverProfileRequest\x12\x17\n" +
+	"\auser_id\x18\x01 \x01(\tR\x06userId\"\xc1\x01\n" +
+	"\rDriverProfile\x12\x1b\n" +
+	"\tdriver_id\x18\x01 \x01(\tR\bdriverId\x12\x17\n" +
+	"\auser_id\x18\x02 \x01(\tR\x06userId\x12'\n" +
+	"\avehicle\x18\x03 \x01(\v2\r.user.VehicleR\avehicle\x12\x16\n" +
+	"\x06online\x18\x04 \x01(\bR\x06online\x129\n" +
+	"\n" +
+	"created_at\x18\x05 \x01(\v2\x1a.google.protobuf.TimestampR\tcreatedAt\"F\n" +
+	"\x15DriverProfileResponse\x12-\n" +
+	"\aprofile\x18\x01 \x01(\v2\x13.user.DriverProfileR\aprofile\"X\n" +
+	"\x14UpdateVehicleRequest\x12\x17\n" +
+	"\auser_id\x18\x01 \x01(\tR\x06userId\x12'\n" +
+	"\avehicle\x18\x02 \x01(\v2\r.user.VehicleR\avehicle\"d\n" +
+	"\x0fGoOnlineRequest\x12\x17\n" +
+	"\auser_id\x18\x01 \x01(\tR\x06userId\x12\x1a\n" +
+	"\blatitude\x18\x02 \x01(\x02R\blatitude\x12\x1c\n" +
+	"\tlongitude\x18\x03 \x01(\x02R\tlongitude\"+\n" +
+	"\x10GoOfflineRequest\x12\x17\n" +
+	"\auser_id\x18\x01 \x01(\tR\x06userId\"p\n" +
+	"\x1bUpdateDriverLocationRequest\x12\x17\n" +
+	"\auser_id\x18\x01 \x01(\tR\x06userId\x12\x1a\n" +
+	"\blatitude\x18\x02 \x01(\x02R\blatitude\x12\x1c\n" +
+	"\tlongitude\x18\x03 \x01(\x02R\tlongitude2\xaf\n" +
+	"\n" +
 	"\vUserService\x126\n" +
-	"\aGetUser\x12\x14.user.GetUserRequest\x1a\x15.user.GetUserResponse\x12K\n" +
+	"\aGetUser\x12\x14.user.GetUserRequest\x1a\x15.user.GetUserResponse\x12B\n" +
+	"\rUpdateProfile\x12\x1a.user.UpdateProfileRequest\x1a\x15.user.GetUserResponse\x12K\n" +
 	"\x0eGetPhoneNumber\x12\x1b.user.GetPhoneNumberRequest\x1a\x1c.user.GetPhoneNumberResponse\x12D\n" +
 	"\x0eAddPhoneNumber\x12\x1b.user.AddPhoneNumberRequest\x1a\x15.user.MessageResponse\x12J\n" +
 	"\x11UpdatePhoneNumber\x12\x1e.user.UpdatePhoneNumberRequest\x1a\x15.user.MessageResponse\x12J\n" +
 	"\x11RemovePhoneNumber\x12\x1e.user.RemovePhoneNumberRequest\x1a\x15.user.MessageResponse\x12E\n" +
 	"\fGetAddresses\x12\x19.user.GetAddressesRequest\x1a\x1a.user.GetAddressesResponse\x12?\n" +
 	"\n" +
-	"AddAddress\x12\x17.user.AddAddressRequest\x1a\x18.user.AddAddressResponse\x12B\n" +
+	"AddAddress\x12\x17.user.AddAddressRequest\x1a\x18.user.AddAddressResponse\x12E\n" +
+	"\rUpdateAddress\x12\x1a.user.UpdateAddressRequest\x1a\x18.user.AddAddressResponse\x12J\n" +
+	"\x11SetDefaultAddress\x12\x1e.user.SetDefaultAddressRequest\x1a\x15.user.MessageResponse\x12B\n" +
 	"\rRemoveAddress\x12\x1a.user.RemoveAddressRequest\x1a\x15.user.MessageResponse\x129\n" +
 	"\bBeDriver\x12\x15.user.BeDriverRequest\x1a\x16.user.BeDriverResponse\x12;\n" +
 	"\n" +
-	"GetDrivers\x12\x17.user.GetDriversRequest\x1a\x14.user.DriverResponse\x12@\n" +
+	"GetDrivers\x12\x17.user.GetDriversRequest\x1a\x14.user.DriverResponse\x12N\n" +
+	"\x10GetDriverProfile\x12\x1d.user.GetDriverProfileRequest\x1a\x1b.user.DriverProfileResponse\x12H\n" +
+	"\rUpdateVehicle\x12\x1a.user.UpdateVehicleRequest\x1a\x1b.user.DriverProfileResponse\x128\n" +
+	"\bGoOnline\x12\x15.user.GoOnlineRequest\x1a\x15.user.MessageResponse\x12:\n" +
+	"\tGoOffline\x12\x16.user.GoOfflineRequest\x1a\x15.user.MessageResponse\x12P\n" +
+	"\x14UpdateDriverLocation\x12!.user.UpdateDriverLocationRequest\x1a\x15.user.MessageResponse\x12@\n" +
 	"\fRemoveDriver\x12\x19.user.RemoveDriverRequest\x1a\x15.user.MessageResponseBAZ?github.com/tamirat-dejene/ha-soranu/shared/protos/userpb;userpbb\x06proto3"
 
 var (
@@ -1232,64 +2134,99 @@ func file_user_proto_rawDescGZIP() []byte {
 	return file_user_proto_rawDescData
 }
 
-var file_user_proto_msgTypes = make([]protoimpl.MessageInfo, 20)
+var file_user_proto_msgTypes = make([]protoimpl.MessageInfo, 32)
 var file_user_proto_goTypes = []any{
-	(*Address)(nil),                  // 0: user.Address
-	(*User)(nil),                     // 1: user.User
-	(*MessageResponse)(nil),          // 2: user.MessageResponse
-	(*GetUserRequest)(nil),           // 3: user.GetUserRequest
-	(*GetUserResponse)(nil),          // 4: user.GetUserResponse
-	(*GetPhoneNumberRequest)(nil),    // 5: user.GetPhoneNumberRequest
-	(*GetPhoneNumberResponse)(nil),   // 6: user.GetPhoneNumberResponse
-	(*AddPhoneNumberRequest)(nil),    // 7: user.AddPhoneNumberRequest
-	(*UpdatePhoneNumberRequest)(nil), // 8: user.UpdatePhoneNumberRequest
-	(*RemovePhoneNumberRequest)(nil), // 9: user.RemovePhoneNumberRequest
-	(*GetAddressesRequest)(nil),      // 10: user.GetAddressesRequest
-	(*GetAddressesResponse)(nil),     // 11: user.GetAddressesResponse
-	(*AddAddressRequest)(nil),        // 12: user.AddAddressRequest
-	(*AddAddressResponse)(nil),       // 13: user.AddAddressResponse
-	(*RemoveAddressRequest)(nil),     // 14: user.RemoveAddressRequest
-	(*BeDriverRequest)(nil),          // 15: user.BeDriverRequest
-	(*BeDriverResponse)(nil),         // 16: user.BeDriverResponse
-	(*DriverResponse)(nil),           // 17: user.DriverResponse
-	(*GetDriversRequest)(nil),        // 18: user.GetDriversRequest
-	(*RemoveDriverRequest)(nil),      // 19: user.RemoveDriverRequest
-	(*timestamppb.Timestamp)(nil),    // 20: google.protobuf.Timestamp
+	(*Address)(nil),                     // 0: user.Address
+	(*User)(nil),                        // 1: user.User
+	(*MessageResponse)(nil),             // 2: user.MessageResponse
+	(*GetUserRequest)(nil),              // 3: user.GetUserRequest
+	(*GetUserResponse)(nil),             // 4: user.GetUserResponse
+	(*UpdateProfileRequest)(nil),        // 5: user.UpdateProfileRequest
+	(*GetPhoneNumberRequest)(nil),       // 6: user.GetPhoneNumberRequest
+	(*GetPhoneNumberResponse)(nil),      // 7: user.GetPhoneNumberResponse
+	(*AddPhoneNumberRequest)(nil),       // 8: user.AddPhoneNumberRequest
+	(*UpdatePhoneNumberRequest)(nil),    // 9: user.UpdatePhoneNumberRequest
+	(*RemovePhoneNumberRequest)(nil),    // 10: user.RemovePhoneNumberRequest
+	(*GetAddressesRequest)(nil),         // 11: user.GetAddressesRequest
+	(*GetAddressesResponse)(nil),        // 12: user.GetAddressesResponse
+	(*AddAddressRequest)(nil),           // 13: user.AddAddressRequest
+	(*UpdateAddressRequest)(nil),        // 14: user.UpdateAddressRequest
+	(*SetDefaultAddressRequest)(nil),    // 15: user.SetDefaultAddressRequest
+	(*AddAddressResponse)(nil),          // 16: user.AddAddressResponse
+	(*RemoveAddressRequest)(nil),        // 17: user.RemoveAddressRequest
+	(*Vehicle)(nil),                     // 18: user.Vehicle
+	(*BeDriverRequest)(nil),             // 19: user.BeDriverRequest
+	(*BeDriverResponse)(nil),            // 20: user.BeDriverResponse
+	(*DriverResponse)(nil),              // 21: user.DriverResponse
+	(*NearbyDriver)(nil),                // 22: user.NearbyDriver
+	(*GetDriversRequest)(nil),           // 23: user.GetDriversRequest
+	(*RemoveDriverRequest)(nil),         // 24: user.RemoveDriverRequest
+	(*GetDriverProfileRequest)(nil),     // 25: user.GetDriverProfileRequest
+	(*DriverProfile)(nil),               // 26: user.DriverProfile
+	(*DriverProfileResponse)(nil),       // 27: user.DriverProfileResponse
+	(*UpdateVehicleRequest)(nil),        // 28: user.UpdateVehicleRequest
+	(*GoOnlineRequest)(nil),             // 29: user.GoOnlineRequest
+	(*GoOfflineRequest)(nil),            // 30: user.GoOfflineRequest
+	(*UpdateDriverLocationRequest)(nil), // 31: user.UpdateDriverLocationRequest
+	(*timestamppb.Timestamp)(nil),       // 32: google.protobuf.Timestamp
 }
 var file_user_proto_depIdxs = []int32{
-	20, // 0: user.Address.created_at:type_name -> google.protobuf.Timestamp
+	32, // 0: user.Address.created_at:type_name -> google.protobuf.Timestamp
 	0,  // 1: user.User.addresses:type_name -> user.Address
-	20, // 2: user.User.created_at:type_name -> google.protobuf.Timestamp
-	1,  // 3: user.GetUserResponse.user:type_name -> user.User
-	0,  // 4: user.GetAddressesResponse.addresses:type_name -> user.Address
-	0,  // 5: user.AddAddressResponse.address:type_name -> user.Address
-	3,  // 6: user.UserService.GetUser:input_type -> user.GetUserRequest
-	5,  // 7: user.UserService.GetPhoneNumber:input_type -> user.GetPhoneNumberRequest
-	7,  // 8: user.UserService.AddPhoneNumber:input_type -> user.AddPhoneNumberRequest
-	8,  // 9: user.UserService.UpdatePhoneNumber:input_type -> user.UpdatePhoneNumberRequest
-	9,  // 10: user.UserService.RemovePhoneNumber:input_type -> user.RemovePhoneNumberRequest
-	10, // 11: user.UserService.GetAddresses:input_type -> user.GetAddressesRequest
-	12, // 12: user.UserService.AddAddress:input_type -> user.AddAddressRequest
-	14, // 13: user.UserService.RemoveAddress:input_type -> user.RemoveAddressRequest
-	15, // 14: user.UserService.BeDriver:input_type -> user.BeDriverRequest
-	18, // 15: user.UserService.GetDrivers:input_type -> user.GetDriversRequest
-	19, // 16: user.UserService.RemoveDriver:input_type -> user.RemoveDriverRequest
-	4,  // 17: user.UserService.GetUser:output_type -> user.GetUserResponse
-	6,  // 18: user.UserService.GetPhoneNumber:output_type -> user.GetPhoneNumberResponse
-	2,  // 19: user.UserService.AddPhoneNumber:output_type -> user.MessageResponse
-	2,  // 20: user.UserService.UpdatePhoneNumber:output_type -> user.MessageResponse
-	2,  // 21: user.UserService.RemovePhoneNumber:output_type -> user.MessageResponse
-	11, // 22: user.UserService.GetAddresses:output_type -> user.GetAddressesResponse
-	13, // 23: user.UserService.AddAddress:output_type -> user.AddAddressResponse
-	2,  // 24: user.UserService.RemoveAddress:output_type -> user.MessageResponse
-	16, // 25: user.UserService.BeDriver:output_type -> user.BeDriverResponse
-	17, // 26: user.UserService.GetDrivers:output_type -> user.DriverResponse
-	2,  // 27: user.UserService.RemoveDriver:output_type -> user.MessageResponse
-	17, // [17:28] is the sub-list for method output_type
-	6,  // [6:17] is the sub-list for method input_type
-	6,  // [6:6] is the sub-list for extension type_name
-	6,  // [6:6] is the sub-list for extension extendee
-	0,  // [0:6] is the sub-list for field type_name
+	32, // 2: user.User.created_at:type_name -> google.protobuf.Timestamp
+	0,  // 3: user.User.default_address:type_name -> user.Address
+	1,  // 4: user.GetUserResponse.user:type_name -> user.User
+	0,  // 5: user.GetAddressesResponse.addresses:type_name -> user.Address
+	0,  // 6: user.AddAddressResponse.address:type_name -> user.Address
+	18, // 7: user.BeDriverRequest.vehicle:type_name -> user.Vehicle
+	22, // 8: user.DriverResponse.drivers:type_name -> user.NearbyDriver
+	18, // 9: user.DriverProfile.vehicle:type_name -> user.Vehicle
+	32, // 10: user.DriverProfile.created_at:type_name -> google.protobuf.Timestamp
+	26, // 11: user.DriverProfileResponse.profile:type_name -> user.DriverProfile
+	18, // 12: user.UpdateVehicleRequest.vehicle:type_name -> user.Vehicle
+	3,  // 13: user.UserService.GetUser:input_type -> user.GetUserRequest
+	5,  // 14: user.UserService.UpdateProfile:input_type -> user.UpdateProfileRequest
+	6,  // 15: user.UserService.GetPhoneNumber:input_type -> user.GetPhoneNumberRequest
+	8,  // 16: user.UserService.AddPhoneNumber:input_type -> user.AddPhoneNumberRequest
+	9,  // 17: user.UserService.UpdatePhoneNumber:input_type -> user.UpdatePhoneNumberRequest
+	10, // 18: user.UserService.RemovePhoneNumber:input_type -> user.RemovePhoneNumberRequest
+	11, // 19: user.UserService.GetAddresses:input_type -> user.GetAddressesRequest
+	13, // 20: user.UserService.AddAddress:input_type -> user.AddAddressRequest
+	14, // 21: user.UserService.UpdateAddress:input_type -> user.UpdateAddressRequest
+	15, // 22: user.UserService.SetDefaultAddress:input_type -> user.SetDefaultAddressRequest
+	17, // 23: user.UserService.RemoveAddress:input_type -> user.RemoveAddressRequest
+	19, // 24: user.UserService.BeDriver:input_type -> user.BeDriverRequest
+	23, // 25: user.UserService.GetDrivers:input_type -> user.GetDriversRequest
+	25, // 26: user.UserService.GetDriverProfile:input_type -> user.GetDriverProfileRequest
+	28, // 27: user.UserService.UpdateVehicle:input_type -> user.UpdateVehicleRequest
+	29, // 28: user.UserService.GoOnline:input_type -> user.GoOnlineRequest
+	30, // 29: user.UserService.GoOffline:input_type -> user.GoOfflineRequest
+	31, // 30: user.UserService.UpdateDriverLocation:input_type -> user.UpdateDriverLocationRequest
+	24, // 31: user.UserService.RemoveDriver:input_type -> user.RemoveDriverRequest
+	4,  // 32: user.UserService.GetUser:output_type -> user.GetUserResponse
+	4,  // 33: user.UserService.UpdateProfile:output_type -> user.GetUserResponse
+	7,  // 34: user.UserService.GetPhoneNumber:output_type -> user.GetPhoneNumberResponse
+	2,  // 35: user.UserService.AddPhoneNumber:output_type -> user.MessageResponse
+	2,  // 36: user.UserService.UpdatePhoneNumber:output_type -> user.MessageResponse
+	2,  // 37: user.UserService.RemovePhoneNumber:output_type -> user.MessageResponse
+	12, // 38: user.UserService.GetAddresses:output_type -> user.GetAddressesResponse
+	16, // 39: user.UserService.AddAddress:output_type -> user.AddAddressResponse
+	16, // 40: user.UserService.UpdateAddress:output_type -> user.AddAddressResponse
+	2,  // 41: user.UserService.SetDefaultAddress:output_type -> user.MessageResponse
+	2,  // 42: user.UserService.RemoveAddress:output_type -> user.MessageResponse
+	20, // 43: user.UserService.BeDriver:output_type -> user.BeDriverResponse
+	21, // 44: user.UserService.GetDrivers:output_type -> user.DriverResponse
+	27, // 45: user.UserService.GetDriverProfile:output_type -> user.DriverProfileResponse
+	27, // 46: user.UserService.UpdateVehicle:output_type -> user.DriverProfileResponse
+	2,  // 47: user.UserService.GoOnline:output_type -> user.MessageResponse
+	2,  // 48: user.UserService.GoOffline:output_type -> user.MessageResponse
+	2,  // 49: user.UserService.UpdateDriverLocation:output_type -> user.MessageResponse
+	2,  // 50: user.UserService.RemoveDriver:output_type -> user.MessageResponse
+	32, // [32:51] is the sub-list for method output_type
+	13, // [13:32] is the sub-list for method input_type
+	13, // [13:13] is the sub-list for extension type_name
+	13, // [13:13] is the sub-list for extension extendee
+	0,  // [0:13] is the sub-list for field type_name
 }
 
 func init() { file_user_proto_init() }
@@ -1303,7 +2240,7 @@ func file_user_proto_init() {
 			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
 			RawDescriptor: unsafe.Slice(unsafe.StringData(file_user_proto_rawDesc), len(file_user_proto_rawDesc)),
 			NumEnums:      0,
-			NumMessages:   20,
+			NumMessages:   32,
 			NumExtensions: 0,
 			NumServices:   1,
 		},
@@ -19,17 +19,25 @@ import (
 const _ = grpc.SupportPackageIsVersion9
 
 const (
-	UserService_GetUser_FullMethodName           = "/user.UserService/GetUser"
-	UserService_GetPhoneNumber_FullMethodName    = "/user.UserService/GetPhoneNumber"
-	UserService_AddPhoneNumber_FullMethodName    = "/user.UserService/AddPhoneNumber"
-	UserService_UpdatePhoneNumber_FullMethodName = "/user.UserService/UpdatePhoneNumber"
-	UserService_RemovePhoneNumber_FullMethodName = "/user.UserService/RemovePhoneNumber"
-	UserService_GetAddresses_FullMethodName      = "/user.UserService/GetAddresses"
-	UserService_AddAddress_FullMethodName        = "/user.UserService/AddAddress"
-	UserService_RemoveAddress_FullMethodName     = "/user.UserService/RemoveAddress"
-	UserService_BeDriver_FullMethodName          = "/user.UserService/BeDriver"
-	UserService_GetDrivers_FullMethodName        = "/user.UserService/GetDrivers"
-	UserService_RemoveDriver_FullMethodName      = "/user.UserService/RemoveDriver"
+	UserService_GetUser_FullMethodName              = "/user.UserService/GetUser"
+	UserService_UpdateProfile_FullMethodName        = "/user.UserService/UpdateProfile"
+	UserService_GetPhoneNumber_FullMethodName       = "/user.UserService/GetPhoneNumber"
+	UserService_AddPhoneNumber_FullMethodName       = "/user.UserService/AddPhoneNumber"
+	UserService_UpdatePhoneNumber_FullMethodName    = "/user.UserService/UpdatePhoneNumber"
+	UserService_RemovePhoneNumber_FullMethodName    = "/user.UserService/RemovePhoneNumber"
+	UserService_GetAddresses_FullMethodName         = "/user.UserService/GetAddresses"
+	UserService_AddAddress_FullMethodName           = "/user.UserService/AddAddress"
+	UserService_UpdateAddress_FullMethodName        = "/user.UserService/UpdateAddress"
+	UserService_SetDefaultAddress_FullMethodName    = "/user.UserService/SetDefaultAddress"
+	UserService_RemoveAddress_FullMethodName        = "/user.UserService/RemoveAddress"
+	UserService_BeDriver_FullMethodName             = "/user.UserService/BeDriver"
+	UserService_GetDrivers_FullMethodName           = "/user.UserService/GetDrivers"
+	UserService_GetDriverProfile_FullMethodName     = "/user.UserService/GetDriverProfile"
+	UserService_UpdateVehicle_FullMethodName        = "/user.UserService/UpdateVehicle"
+	UserService_GoOnline_FullMethodName             = "/user.UserService/GoOnline"
+	UserService_GoOffline_FullMethodName            = "/user.UserService/GoOffline"
+	UserService_UpdateDriverLocation_FullMethodName = "/user.UserService/UpdateDriverLocation"
+	UserService_RemoveDriver_FullMethodName         = "/user.UserService/RemoveDriver"
 )
 
 // UserServiceClient is the client API for UserService service.
@@ -40,6 +48,8 @@ const (
 type UserServiceClient interface {
 	// Retrieves user information by user ID.
 	GetUser(ctx context.Context, in *GetUserRequest, opts ...grpc.CallOption) (*GetUserResponse, error)
+	// Updates the user's profile; the email is changed with AuthService.ChangeEmail.
+	UpdateProfile(ctx context.Context, in *UpdateProfileRequest, opts ...grpc.CallOption) (*GetUserResponse, error)
 	// Manages user's phone number.
 	GetPhoneNumber(ctx context.Context, in *GetPhoneNumberRequest, opts ...grpc.CallOption) (*GetPhoneNumberResponse, error)
 	// Adds, updates, or removes user's phone number.
@@ -52,12 +62,26 @@ type UserServiceClient interface {
 	GetAddresses(ctx context.Context, in *GetAddressesRequest, opts ...grpc.CallOption) (*GetAddressesResponse, error)
 	// Adds a new address for the user.
 	AddAddress(ctx context.Context, in *AddAddressRequest, opts ...grpc.CallOption) (*AddAddressResponse, error)
+	// Replaces the details of one of the user's addresses.
+	UpdateAddress(ctx context.Context, in *UpdateAddressRequest, opts ...grpc.CallOption) (*AddAddressResponse, error)
+	// Makes one of the user's addresses the default one.
+	SetDefaultAddress(ctx context.Context, in *SetDefaultAddressRequest, opts ...grpc.CallOption) (*MessageResponse, error)
 	// Removes an address from the user.
 	RemoveAddress(ctx context.Context, in *RemoveAddressRequest, opts ...grpc.CallOption) (*MessageResponse, error)
 	// Makes a user a driver.
 	BeDriver(ctx context.Context, in *BeDriverRequest, opts ...grpc.CallOption) (*BeDriverResponse, error)
-	// Retrieves drivers within a certain radius.
+	// Retrieves the online drivers within a certain radius, the nearest first.
 	GetDrivers(ctx context.Context, in *GetDriversRequest, opts ...grpc.CallOption) (*DriverResponse, error)
+	// Retrieves the driver profile of a user.
+	GetDriverProfile(ctx context.Context, in *GetDriverProfileRequest, opts ...grpc.CallOption) (*DriverProfileResponse, error)
+	// Replaces the vehicle details of a driver.
+	UpdateVehicle(ctx context.Context, in *UpdateVehicleRequest, opts ...grpc.CallOption) (*DriverProfileResponse, error)
+	// Puts a driver on duty at the given location, so that they are offered deliveries.
+	GoOnline(ctx context.Context, in *GoOnlineRequest, opts ...grpc.CallOption) (*MessageResponse, error)
+	// Takes a driver off duty.
+	GoOffline(ctx context.Context, in *GoOfflineRequest, opts ...grpc.CallOption) (*MessageResponse, error)
+	// Records the current location of an online driver.
+	UpdateDriverLocation(ctx context.Context, in *UpdateDriverLocationRequest, opts ...grpc.CallOption) (*MessageResponse, error)
 	// Removes a driver by driver ID.
 	RemoveDriver(ctx context.Context, in *RemoveDriverRequest, opts ...grpc.CallOption) (*MessageResponse, error)
 }
@@ -80,6 +104,16 @@ func (c *userServiceClient) GetUser(ctx context.Context, in *GetUserRequest, opt
 	return out, nil
 }
 
+func (c *userServiceClient) UpdateProfile(ctx context.Context, in *UpdateProfileRequest, opts ...grpc.CallOption) (*GetUserResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(GetUserResponse)
+	err := c.cc.Invoke(ctx, UserService_UpdateProfile_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
 func (c *userServiceClient) GetPhoneNumber(ctx context.Context, in *GetPhoneNumberRequest, opts ...grpc.CallOption) (*GetPhoneNumberResponse, error) {
 	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
 	out := new(GetPhoneNumberResponse)
@@ -140,6 +174,26 @@ func (c *userServiceClient) AddAddress(ctx context.Context, in *AddAddressReques
 	return out, nil
 }
 
+func (c *userServiceClient) UpdateAddress(ctx context.Context, in *UpdateAddressRequest, opts ...grpc.CallOption) (*AddAddressResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(AddAddressResponse)
+	err := c.cc.Invoke(ctx, UserService_UpdateAddress_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *userServiceClient) SetDefaultAddress(ctx context.Context, in *SetDefaultAddressRequest, opts ...grpc.CallOption) (*MessageResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(MessageResponse)
+	err := c.cc.Invoke(ctx, UserService_SetDefaultAddress_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
 func (c *userServiceClient) RemoveAddress(ctx context.Context, in *RemoveAddressRequest, opts ...grpc.CallOption) (*MessageResponse, error) {
 	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
 	out := new(MessageResponse)
@@ -170,6 +224,56 @@ func (c *userServiceClient) GetDrivers(ctx context.Context, in *GetDriversReques
 	return out, nil
 }
 
+func (c *userServiceClient) GetDriverProfile(ctx context.Context, in *GetDriverProfileRequest, opts ...grpc.CallOption) (*DriverProfileResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(DriverProfileResponse)
+	err := c.cc.Invoke(ctx, UserService_GetDriverProfile_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *userServiceClient) UpdateVehicle(ctx context.Context, in *UpdateVehicleRequest, opts ...grpc.CallOption) (*DriverProfileResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(DriverProfileResponse)
+	err := c.cc.Invoke(ctx, UserService_UpdateVehicle_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *userServiceClient) GoOnline(ctx context.Context, in *GoOnlineRequest, opts ...grpc.CallOption) (*MessageResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(MessageResponse)
+	err := c.cc.Invoke(ctx, UserService_GoOnline_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *userServiceClient) GoOffline(ctx context.Context, in *GoOfflineRequest, opts ...grpc.CallOption) (*MessageResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(MessageResponse)
+	err := c.cc.Invoke(ctx, UserService_GoOffline_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *userServiceClient) UpdateDriverLocation(ctx context.Context, in *UpdateDriverLocationRequest, opts ...grpc.CallOption) (*MessageResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(MessageResponse)
+	err := c.cc.Invoke(ctx, UserService_UpdateDriverLocation_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
 func (c *userServiceClient) RemoveDriver(ctx context.Context, in *RemoveDriverRequest, opts ...grpc.CallOption) (*MessageResponse, error) {
 	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
 	out := new(MessageResponse)
@@ -188,6 +292,8 @@ func (c *userServiceClient) RemoveDriver(ctx context.Context, in *RemoveDriverRe
 type UserServiceServer interface {
 	// Retrieves user information by user ID.
 	GetUser(context.Context, *GetUserRequest) (*GetUserResponse, error)
+	// Updates the user's profile; the email is changed with AuthService.ChangeEmail.
+	UpdateProfile(context.Context, *UpdateProfileRequest) (*GetUserResponse, error)
 	// Manages user's phone number.
 	GetPhoneNumber(context.Context, *GetPhoneNumberRequest) (*GetPhoneNumberResponse, error)
 	// Adds, updates, or removes user's phone number.
@@ -200,12 +306,26 @@ type UserServiceServer interface {
 	GetAddresses(context.Context, *GetAddressesRequest) (*GetAddressesResponse, error)
 	// Adds a new address for the user.
 	AddAddress(context.Context, *AddAddressRequest) (*AddAddressResponse, error)
+	// Replaces the details of one of the user's addresses.
+	UpdateAddress(context.Context, *UpdateAddressRequest) (*AddAddressResponse, error)
+	// Makes one of the user's addresses the default one.
+	SetDefaultAddress(context.Context, *SetDefaultAddressRequest) (*MessageResponse, error)
 	// Removes an address from the user.
 	RemoveAddress(context.Context, *RemoveAddressRequest) (*MessageResponse, error)
 	// Makes a user a driver.
 	BeDriver(context.Context, *BeDriverRequest) (*BeDriverResponse, error)
-	// Retrieves drivers within a certain radius.
+	// Retrieves the online drivers within a certain radius, the nearest first.
 	GetDrivers(context.Context, *GetDriversRequest) (*DriverResponse, error)
+	// Retrieves the driver profile of a user.
+	GetDriverProfile(context.Context, *GetDriverProfileRequest) (*DriverProfileResponse, error)
+	// Replaces the vehicle details of a driver.
+	UpdateVehicle(context.Context, *UpdateVehicleRequest) (*DriverProfileResponse, error)
+	// Puts a driver on duty at the given location, so that they are offered deliveries.
+	GoOnline(context.Context, *GoOnlineRequest) (*MessageResponse, error)
+	// Takes a driver off duty.
+	GoOffline(context.Context, *GoOfflineRequest) (*MessageResponse, error)
+	// Records the current location of an online driver.
+	UpdateDriverLocation(context.Context, *UpdateDriverLocationRequest) (*MessageResponse, error)
 	// Removes a driver by driver ID.
 	RemoveDriver(context.Context, *RemoveDriverRequest) (*MessageResponse, error)
 	mustEmbedUnimplementedUserServiceServer()
@@ -221,6 +341,9 @@ type UnimplementedUserServiceServer struct{}
 func (UnimplementedUserServiceServer) GetUser(context.Context, *GetUserRequest) (*GetUserResponse, error) {
 	return nil, status.Error(codes.Unimplemented, "method GetUser not implemented")
 }
+func (UnimplementedUserServiceServer) UpdateProfile(context.Context, *UpdateProfileRequest) (*GetUserResponse, error) {
+	return nil, status.Error(codes.Unimplemented, "method UpdateProfile not implemented")
+}
 func (UnimplementedUserServiceServer) GetPhoneNumber(context.Context, *GetPhoneNumberRequest) (*GetPhoneNumberResponse, error) {
 	return nil, status.Error(codes.Unimplemented, "method GetPhoneNumber not implemented")
 }
@@ -239,6 +362,12 @@ func (UnimplementedUserServiceServer) GetAddresses(context.Context, *GetAddresse
 func (UnimplementedUserServiceServer) AddAddress(context.Context, *AddAddressRequest) (*AddAddressResponse, error) {
 	return nil, status.Error(codes.Unimplemented, "method AddAddress not implemented")
 }
+func (UnimplementedUserServiceServer) UpdateAddress(context.Context, *UpdateAddressRequest) (*AddAddressResponse, error) {
+	return nil, status.Error(codes.Unimplemented, "method UpdateAddress not implemented")
+}
+func (UnimplementedUserServiceServer) SetDefaultAddress(context.Context, *SetDefaultAddressRequest) (*MessageResponse, error) {
+	return nil, status.Error(codes.Unimplemented, "method SetDefaultAddress not implemented")
+}
 func (UnimplementedUserServiceServer) RemoveAddress(context.Context, *RemoveAddressRequest) (*MessageResponse, error) {
 	return nil, status.Error(codes.Unimplemented, "method RemoveAddress not implemented")
 }
@@ -248,6 +377,21 @@ func (UnimplementedUserServiceServer) BeDriver(context.Context, *BeDriverRequest
 func (UnimplementedUserServiceServer) GetDrivers(context.Context, *GetDriversRequest) (*DriverResponse, error) {
 	return nil, status.Error(codes.Unimplemented, "method GetDrivers not implemented")
 }
+func (UnimplementedUserServiceServer) GetDriverProfile(context.Context, *GetDriverProfileRequest) (*DriverProfileResponse, error) {
+	return nil, status.Error(codes.Unimplemented, "method GetDriverProfile not implemented")
+}
+func (UnimplementedUserServiceServer) UpdateVehicle(context.Context, *UpdateVehicleRequest) (*DriverProfileResponse, error) {
+	return nil, status.Error(codes.Unimplemented, "method UpdateVehicle not implemented")
+}
+func (UnimplementedUserServiceServer) GoOnline(context.Context, *GoOnlineRequest) (*MessageResponse, error) {
+	return nil, status.Error(codes.Unimplemented, "method GoOnline not implemented")
+}
+func (UnimplementedUserServiceServer) GoOffline(context.Context, *GoOfflineRequest) (*MessageResponse, error) {
+	return nil, status.Error(codes.Unimplemented, "method GoOffline not implemented")
+}
+func (UnimplementedUserServiceServer) UpdateDriverLocation(context.Context, *UpdateDriverLocationRequest) (*MessageResponse, error) {
+	return nil, status.Error(codes.Unimplemented, "method UpdateDriverLocation not implemented")
+}
 func (UnimplementedUserServiceServer) RemoveDriver(context.Context, *RemoveDriverRequest) (*MessageResponse, error) {
 	return nil, status.Error(codes.Unimplemented, "method RemoveDriver not implemented")
 }
@@ -290,6 +434,24 @@ func _UserService_GetUser_Handler(srv interface{}, ctx context.Context, dec func
 	return interceptor(ctx, in, info, handler)
 }
 
+func _UserService_UpdateProfile_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(UpdateProfileRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(UserServiceServer).UpdateProfile(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: UserService_UpdateProfile_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(UserServiceServer).UpdateProfile(ctx, req.(*UpdateProfileRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
 func _UserService_GetPhoneNumber_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
 	in := new(GetPhoneNumberRequest)
 	if err := dec(in); err != nil {
@@ -398,6 +560,42 @@ func _UserService_AddAddress_Handler(srv interface{}, ctx context.Context, dec f
 	return interceptor(ctx, in, info, handler)
 }
 
+func _UserService_UpdateAddress_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(UpdateAddressRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(UserServiceServer).UpdateAddress(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: UserService_UpdateAddress_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(UserServiceServer).UpdateAddress(ctx, req.(*UpdateAddressRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _UserService_SetDefaultAddress_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(SetDefaultAddressRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(UserServiceServer).SetDefaultAddress(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: UserService_SetDefaultAddress_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(UserServiceServer).SetDefaultAddress(ctx, req.(*SetDefaultAddressRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
 func _UserService_RemoveAddress_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
 	in := new(RemoveAddressRequest)
 	if err := dec(in); err != nil {
@@ -452,6 +650,96 @@ func _UserService_GetDrivers_Handler(srv interface{}, ctx context.Context, dec f
 	return interceptor(ctx, in, info, handler)
 }
 
+func _UserService_GetDriverProfile_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetDriverProfileRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(UserServiceServer).GetDriverProfile(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: UserService_GetDriverProfile_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(UserServiceServer).GetDriverProfile(ctx, req.(*GetDriverProfileRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _UserService_UpdateVehicle_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(UpdateVehicleRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(UserServiceServer).UpdateVehicle(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: UserService_UpdateVehicle_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(UserServiceServer).UpdateVehicle(ctx, req.(*UpdateVehicleRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _UserService_GoOnline_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GoOnlineRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(UserServiceServer).GoOnline(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: UserService_GoOnline_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(UserServiceServer).GoOnline(ctx, req.(*GoOnlineRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _UserService_GoOffline_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GoOfflineRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(UserServiceServer).GoOffline(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: UserService_GoOffline_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(UserServiceServer).GoOffline(ctx, req.(*GoOfflineRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _UserService_UpdateDriverLocation_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(UpdateDriverLocationRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(UserServiceServer).UpdateDriverLocation(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: UserService_UpdateDriverLocation_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(UserServiceServer).UpdateDriverLocation(ctx, req.(*UpdateDriverLocationRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
 func _UserService_RemoveDriver_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
 	in := new(RemoveDriverRequest)
 	if err := dec(in); err != nil {
@@ -481,6 +769,10 @@ var UserService_ServiceDesc = grpc.ServiceDesc{
 			MethodName: "GetUser",
 			Handler:    _UserService_GetUser_Handler,
 		},
+		{
+			MethodName: "UpdateProfile",
+			Handler:    _UserService_UpdateProfile_Handler,
+		},
 		{
 			MethodName: "GetPhoneNumber",
 			Handler:    _UserService_GetPhoneNumber_Handler,
@@ -505,6 +797,14 @@ var UserService_ServiceDesc = grpc.ServiceDesc{
 			MethodName: "AddAddress",
 			Handler:    _UserService_AddAddress_Handler,
 		},
+		{
+			MethodName: "UpdateAddress",
+			Handler:    _UserService_UpdateAddress_Handler,
+		},
+		{
+			MethodName: "SetDefaultAddress",
+			Handler:    _UserService_SetDefaultAddress_Handler,
+		},
 		{
 			MethodName: "RemoveAddress",
 			Handler:    _UserService_RemoveAddress_Handler,
@@ -517,6 +817,26 @@ var UserService_ServiceDesc = grpc.ServiceDesc{
 			MethodName: "GetDrivers",
 			Handler:    _UserService_GetDrivers_Handler,
 		},
+		{
+			MethodName: "GetDriverProfile",
+			Handler:    _UserService_GetDriverProfile_Handler,
+		},
+		{
+			MethodName: "UpdateVehicle",
+			Handler:    _UserService_UpdateVehicle_Handler,
+		},
+		{
+			MethodName: "GoOnline",
+			Handler:    _UserService_GoOnline_Handler,
+		},
+		{
+			MethodName: "GoOffline",
+			Handler:    _UserService_GoOffline_Handler,
+		},
+		{
+			MethodName: "UpdateDriverLocation",
+			Handler:    _UserService_UpdateDriverLocation_Handler,
+		},
 		{
 			MethodName: "RemoveDriver",
 			Handler:    _UserService_RemoveDriver_Handler,